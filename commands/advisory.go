@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdAdvisory = &Command{
+		Run: printHelp,
+		Usage: `
+advisory list [--state <STATE>] [-L <LIMIT>] [--json]
+advisory view [--json] <GHSA-ID>|<ALERT>
+`,
+		Long: `Read the security advisories and Dependabot alerts of a repository.
+
+## Commands:
+
+	* _list_:
+		List the security advisories that the maintainers of the repository
+		drafted or published, as "<GHSA-ID>  <SEVERITY>  <STATE>  <SUMMARY>"
+		lines.
+
+	* _view_:
+		Show a security advisory given by its <GHSA-ID>, or the Dependabot alert
+		numbered <ALERT> along with the advisory it was raised for.
+
+## Options:
+	--state <STATE>
+		Only list advisories in <STATE>: "triage", "draft", "published", or
+		"closed".
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> advisories.
+
+	--json
+		Output the advisories or the alert as JSON instead of text.
+
+	-R, --repo <REPO>
+		Read the advisories of <REPO>, given in "OWNER/NAME" format, instead of
+		the repository of the current directory.
+
+## Examples:
+		$ hub advisory list --state published
+		$ hub advisory view GHSA-xvch-5gv4-984h
+		$ hub advisory view 12
+
+## See also:
+
+hub-secret(1), hub-audit-log(1), hub(1)
+`,
+	}
+
+	cmdListAdvisories = &Command{
+		Key: "list",
+		Run: listAdvisories,
+		KnownFlags: `
+		--state STATE
+		-L, --limit N
+		--json
+		-R, --repo REPO
+`,
+	}
+
+	cmdViewAdvisory = &Command{
+		Key: "view",
+		Run: viewAdvisory,
+		KnownFlags: `
+		--json
+		-R, --repo REPO
+`,
+	}
+)
+
+func init() {
+	cmdAdvisory.Use(cmdListAdvisories)
+	cmdAdvisory.Use(cmdViewAdvisory)
+	CmdRunner.Use(cmdAdvisory)
+}
+
+var ghsaIdRegexp = regexp.MustCompile(`(?i)^GHSA(-[0-9a-z]{4}){3}$`)
+
+func listAdvisories(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of security advisories for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	advisories, err := gh.RepositoryAdvisories(project, args.Flag.Value("--state"), args.Flag.Int("--limit"))
+	utils.Check(err)
+
+	if args.Flag.Bool("--json") {
+		out, err := encodeJSON(advisories)
+		utils.Check(err)
+		ui.Println(string(out))
+		return
+	}
+
+	severityWidth, stateWidth := 0, 0
+	for _, advisory := range advisories {
+		if w := len(advisory.Severity); w > severityWidth {
+			severityWidth = w
+		}
+		if w := len(advisory.State); w > stateWidth {
+			stateWidth = w
+		}
+	}
+	for _, advisory := range advisories {
+		ui.Printf("%s  %-*s  %-*s  %s\n", advisory.GhsaId, severityWidth, advisory.Severity, stateWidth, advisory.State, advisory.Summary)
+	}
+}
+
+func viewAdvisory(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	id := args.GetParam(0)
+	alertNumber, err := strconv.Atoi(strings.TrimPrefix(id, "#"))
+	if err == nil && alertNumber < 1 || err != nil && !ghsaIdRegexp.MatchString(id) {
+		utils.Check(fmt.Errorf("invalid advisory or alert: '%s'", id))
+	}
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	gh := github.NewClient(project.Host)
+
+	if ghsaIdRegexp.MatchString(id) {
+		advisory, err := gh.FetchRepositoryAdvisory(project, id)
+		utils.Check(err)
+		if args.Flag.Bool("--json") {
+			out, err := encodeJSON(advisory)
+			utils.Check(err)
+			ui.Println(string(out))
+			return
+		}
+		printRepositoryAdvisory(advisory)
+		return
+	}
+
+	alert, err := gh.FetchDependabotAlert(project, alertNumber)
+	utils.Check(err)
+	if args.Flag.Bool("--json") {
+		out, err := encodeJSON(alert)
+		utils.Check(err)
+		ui.Println(string(out))
+		return
+	}
+	printDependabotAlert(alert)
+}
+
+// advisoryIds formats the GHSA ID of an advisory along with its CVE ID, if
+// one was assigned.
+func advisoryIds(ghsaId, cveId string) string {
+	if cveId == "" {
+		return ghsaId
+	}
+	return fmt.Sprintf("%s (%s)", ghsaId, cveId)
+}
+
+func printRepositoryAdvisory(advisory *github.RepositoryAdvisory) {
+	ui.Printf("# %s\n\n", advisory.Summary)
+	ui.Printf("* advisory: %s\n", advisoryIds(advisory.GhsaId, advisory.CveId))
+	ui.Printf("* severity: %s\n", advisory.Severity)
+	ui.Printf("* state: %s\n", advisory.State)
+	if advisory.PublishedAt != nil {
+		ui.Printf("* published on %s\n", advisory.PublishedAt.String())
+	}
+	for _, vuln := range advisory.Vulnerabilities {
+		patched := vuln.PatchedVersions
+		if patched == "" {
+			patched = "none"
+		}
+		ui.Printf("* %s: %s (patched: %s)\n", vuln.Package, vuln.VulnerableVersionRange, patched)
+	}
+	ui.Printf("\n%s\n", advisory.Description)
+}
+
+func printDependabotAlert(alert *github.DependabotAlert) {
+	advisory := alert.SecurityAdvisory
+	ui.Printf("# %s\n\n", advisory.Summary)
+	state := alert.State
+	if alert.DismissedReason != "" {
+		state = fmt.Sprintf("%s (%s)", state, alert.DismissedReason)
+	}
+	ui.Printf("* alert #%d: %s\n", alert.Number, state)
+	ui.Printf("* package: %s in %s\n", alert.Dependency.Package, alert.Dependency.ManifestPath)
+	patched := "none"
+	if alert.SecurityVulnerability.FirstPatchedVersion != nil {
+		patched = alert.SecurityVulnerability.FirstPatchedVersion.Identifier
+	}
+	ui.Printf("* vulnerable: %s (patched: %s)\n", alert.SecurityVulnerability.VulnerableVersionRange, patched)
+	ui.Printf("* advisory: %s\n", advisoryIds(advisory.GhsaId, advisory.CveId))
+	ui.Printf("* severity: %s\n", advisory.Severity)
+	ui.Printf("* %s\n", alert.HtmlUrl)
+	ui.Printf("\n%s\n", advisory.Description)
+}
@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdAlerts = &Command{
+		Run: printHelp,
+		Usage: `
+alerts list [-t <TYPE>] [-s <STATE>] [--severity <SEVERITY>] [-L <LIMIT>] [--output <FORMAT>] [--exit-status]
+`,
+		Long: `List the security alerts of a repository.
+
+## Commands:
+
+	* _list_:
+		List alerts as "#<NUMBER>  <SEVERITY>  <STATE>  <SUMMARY>  (<LOCATION>)"
+		lines, where <LOCATION> is the vulnerable package, the file and line of
+		the problem, or the kind of the leaked secret.
+
+## Options:
+	-t, --type <TYPE>
+		The kind of alerts to list: "dependabot", "code-scanning", or
+		"secret-scanning" (default: "dependabot"). Multiple types can be
+		separated by commas, in which case each line starts with the type.
+
+	-s, --state <STATE>
+		Only list alerts in <STATE>, such as "open", "dismissed", "fixed", or
+		"resolved". The states available depend on <TYPE>.
+
+	--severity <SEVERITY>
+		Only list alerts of <SEVERITY>, such as "critical", "high", "medium", or
+		"low". Secret scanning alerts have no severity.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> alerts of each type.
+
+	--output <FORMAT>
+		Print the alerts in a structured format instead: "json", "yaml", "csv",
+		or "tsv". Alerts have the fields "type", "number", "state", "severity",
+		"summary", "location", and "url".
+
+	--exit-status
+		Exit with status 1 if any alerts were listed. Use this with filters to
+		fail a CI build, e.g. when there are open critical alerts.
+
+	-R, --repo <REPO>
+		List the alerts of <REPO>, given in "OWNER/NAME" format, instead of the
+		repository of the current directory.
+
+## Examples:
+		$ hub alerts list --state open
+		$ hub alerts list -t dependabot,code-scanning --output json
+		$ hub alerts list --state open --severity critical --exit-status
+
+## See also:
+
+hub-advisory(1), hub-ci-status(1), hub(1)
+`,
+	}
+
+	cmdListAlerts = &Command{
+		Key: "list",
+		Run: listAlerts,
+		KnownFlags: `
+		-t, --type TYPE
+		-s, --state STATE
+		--severity SEVERITY
+		-L, --limit N
+		--output FORMAT
+		--exit-status
+		-R, --repo REPO
+`,
+	}
+)
+
+func init() {
+	cmdAlerts.Use(cmdListAlerts)
+	CmdRunner.Use(cmdAlerts)
+}
+
+var alertTypes = []string{"dependabot", "code-scanning", "secret-scanning"}
+
+// securityAlert is the common representation of the alerts of all types.
+type securityAlert struct {
+	kind     string
+	number   int
+	state    string
+	severity string
+	summary  string
+	location string
+	url      string
+}
+
+func (a securityAlert) record() ui.Record {
+	return ui.Record{}.
+		With("type", a.kind).
+		With("number", a.number).
+		With("state", a.state).
+		With("severity", a.severity).
+		With("summary", a.summary).
+		With("location", a.location).
+		With("url", a.url)
+}
+
+func fetchSecurityAlerts(gh *github.Client, project *github.Project, kind string, filters github.AlertFilters, limit int) (alerts []securityAlert, err error) {
+	switch kind {
+	case "dependabot":
+		var dependabotAlerts []github.DependabotAlert
+		dependabotAlerts, err = gh.DependabotAlerts(project, filters, limit)
+		for _, a := range dependabotAlerts {
+			alerts = append(alerts, securityAlert{
+				kind:     kind,
+				number:   a.Number,
+				state:    a.State,
+				severity: a.SecurityAdvisory.Severity,
+				summary:  a.SecurityAdvisory.Summary,
+				location: a.Dependency.Package.String(),
+				url:      a.HtmlUrl,
+			})
+		}
+	case "code-scanning":
+		var codeScanningAlerts []github.CodeScanningAlert
+		codeScanningAlerts, err = gh.CodeScanningAlerts(project, filters, limit)
+		for _, a := range codeScanningAlerts {
+			location := a.MostRecentInstance.Location
+			alerts = append(alerts, securityAlert{
+				kind:     kind,
+				number:   a.Number,
+				state:    a.State,
+				severity: a.Severity(),
+				summary:  a.Rule.Description,
+				location: fmt.Sprintf("%s:%d", location.Path, location.StartLine),
+				url:      a.HtmlUrl,
+			})
+		}
+	case "secret-scanning":
+		var secretScanningAlerts []github.SecretScanningAlert
+		secretScanningAlerts, err = gh.SecretScanningAlerts(project, filters, limit)
+		for _, a := range secretScanningAlerts {
+			alerts = append(alerts, securityAlert{
+				kind:     kind,
+				number:   a.Number,
+				state:    a.State,
+				summary:  "Leaked secret",
+				location: a.SecretTypeDisplayName,
+				url:      a.HtmlUrl,
+			})
+		}
+	}
+	return
+}
+
+func listAlerts(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	kinds := []string{"dependabot"}
+	if args.Flag.HasReceived("--type") {
+		kinds = commaSeparated(args.Flag.AllValues("--type"))
+	}
+	for _, kind := range kinds {
+		valid := false
+		for _, t := range alertTypes {
+			valid = valid || t == kind
+		}
+		if !valid {
+			utils.Check(fmt.Errorf("invalid alert type: '%s' (expected one of: %s)", kind, strings.Join(alertTypes, ", ")))
+		}
+		if kind == "secret-scanning" && args.Flag.HasReceived("--severity") {
+			utils.Check(fmt.Errorf("secret scanning alerts can't be filtered by severity"))
+		}
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of %s alerts for %s\n", strings.Join(kinds, ", "), project)
+		return
+	}
+
+	filters := github.AlertFilters{
+		State:    args.Flag.Value("--state"),
+		Severity: args.Flag.Value("--severity"),
+	}
+	gh := github.NewClient(project.Host)
+	alerts := []securityAlert{}
+	for _, kind := range kinds {
+		kindAlerts, err := fetchSecurityAlerts(gh, project, kind, filters, args.Flag.Int("--limit"))
+		utils.Check(err)
+		alerts = append(alerts, kindAlerts...)
+	}
+
+	printSecurityAlerts(alerts, len(kinds) > 1, args.Flag.Value("--output"))
+
+	if args.Flag.Bool("--exit-status") && len(alerts) > 0 {
+		os.Exit(1)
+	}
+}
+
+// printSecurityAlerts prints alerts as a table, or as records in a structured
+// format if one is given.
+func printSecurityAlerts(alerts []securityAlert, showKind bool, format string) {
+	if format != "" {
+		records := []ui.Record{}
+		for _, alert := range alerts {
+			records = append(records, alert.record())
+		}
+		printRecords(format, records, securityAlert{}.record())
+		return
+	}
+
+	kindWidth, numWidth, severityWidth, stateWidth := 0, 0, 0, 0
+	for i, alert := range alerts {
+		if alert.severity == "" {
+			alerts[i].severity = "-"
+		}
+		if w := len(alert.kind); showKind && w+2 > kindWidth {
+			kindWidth = w + 2
+		}
+		if w := len(strconv.Itoa(alert.number)) + 1; w > numWidth {
+			numWidth = w
+		}
+		if w := len(alerts[i].severity); w > severityWidth {
+			severityWidth = w
+		}
+		if w := len(alert.state); w > stateWidth {
+			stateWidth = w
+		}
+	}
+	for _, alert := range alerts {
+		kind := ""
+		if showKind {
+			kind = alert.kind
+		}
+		ui.Printf("%-*s%*s  %-*s  %-*s  %s  (%s)\n", kindWidth, kind, numWidth, fmt.Sprintf("#%d", alert.number), severityWidth, alert.severity, stateWidth, alert.state, alert.summary, alert.location)
+	}
+}
@@ -5,16 +5,42 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
 	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
 )
 
 var cmdAlias = &Command{
-	Run:   alias,
-	Usage: "alias [-s] [<SHELL>]",
-	Long: `Show shell instructions for wrapping git.
+	Run: alias,
+	Usage: `
+alias [-s] [<SHELL>]
+alias set <NAME> <EXPANSION>
+alias delete <NAME>
+alias list
+`,
+	Long: `Show shell instructions for wrapping git, or manage hub command aliases.
+
+## Commands:
+
+With no subcommand, show instructions for wrapping git with hub.
+
+	* _set_:
+		Define <NAME> as a shortcut that runs <EXPANSION> instead. Placeholders
+		"$1", "$2", etc. in <EXPANSION> are replaced with the positional arguments
+		given to the alias; arguments past the last placeholder are appended.
+
+		If <EXPANSION> starts with "!", it is run with sh(1) and the arguments are
+		available as shell positional parameters.
+
+	* _delete_:
+		Remove the alias <NAME>.
+
+	* _list_:
+		Print all aliases.
 
 ## Options
 	-s
@@ -23,9 +49,19 @@ var cmdAlias = &Command{
 	<SHELL>
 		Specify the type of shell (default: "$SHELL" environment variable).
 
+## Examples:
+		$ hub alias set co 'pr checkout $1'
+		$ hub co 123
+
+		$ hub alias set rl '!hub api repos/{owner}/{repo}/releases | jq ".[].tag_name"'
+
+Aliases are stored in the hub configuration file as "aliases.<NAME>" settings.
+They are consulted before git aliases, but never override commands of hub or
+git.
+
 ## See also:
 
-hub(1)
+hub(1), hub-config(1)
 `,
 }
 
@@ -34,6 +70,18 @@ func init() {
 }
 
 func alias(command *Command, args *Args) {
+	switch args.FirstParam() {
+	case "set":
+		setAlias(command, args)
+		return
+	case "delete":
+		deleteAlias(command, args)
+		return
+	case "list":
+		listAliases(args)
+		return
+	}
+
 	var shell string
 	if args.ParamsSize() > 0 {
 		shell = args.FirstParam()
@@ -125,3 +173,66 @@ end`
 
 	args.NoForward()
 }
+
+func setAlias(command *Command, args *Args) {
+	if args.ParamsSize() != 3 {
+		utils.Check(command.UsageError(""))
+	}
+	name := args.GetParam(1)
+	expansion := args.GetParam(2)
+
+	if isBuiltInHubCommand(name) || git.IsBuiltInGitCommand(name) {
+		utils.Check(fmt.Errorf("Aborted: \"%s\" is already a command", name))
+	}
+	if !strings.HasPrefix(expansion, "!") {
+		_, err := splitAliasCmd(expansion)
+		utils.Check(err)
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set alias %s to %q\n", name, expansion)
+		return
+	}
+
+	config := github.CurrentConfig()
+	utils.Check(config.SetSetting("", "aliases."+name, expansion))
+	utils.Check(config.Save())
+}
+
+func deleteAlias(command *Command, args *Args) {
+	if args.ParamsSize() != 2 {
+		utils.Check(command.UsageError(""))
+	}
+	name := args.GetParam(1)
+
+	config := github.CurrentConfig()
+	if _, ok := config.Settings["aliases."+name]; !ok {
+		utils.Check(fmt.Errorf("no such alias: %s", name))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would delete alias %s\n", name)
+		return
+	}
+
+	utils.Check(config.UnsetSetting("", "aliases."+name))
+	utils.Check(config.Save())
+}
+
+func listAliases(args *Args) {
+	config := github.CurrentConfig()
+	names := []string{}
+	for key := range config.Settings {
+		if strings.HasPrefix(key, "aliases.") {
+			names = append(names, strings.TrimPrefix(key, "aliases."))
+		}
+	}
+	sort.Strings(names)
+
+	args.NoForward()
+	for _, name := range names {
+		ui.Printf("%s\t%s\n", name, config.Settings["aliases."+name])
+	}
+}
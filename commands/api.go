@@ -2,10 +2,14 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
+	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -13,11 +17,12 @@ import (
 	"github.com/github/hub/github"
 	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
+	"gopkg.in/yaml.v2"
 )
 
 var cmdApi = &Command{
 	Run:   apiCommand,
-	Usage: "api [-it] [-X <METHOD>] [-H <HEADER>] [--cache <TTL>] <ENDPOINT> [-F <FIELD>|--input <FILE>]",
+	Usage: "api [-it] [-X <METHOD>] [-H <HEADER>] [--hostname <HOST>] [--cache <TTL>] [--paginate [--slurp]] [--format <TEMPLATE>|--jq <EXPR>] [--status-only] [--fail-fast] <ENDPOINT> [-F <FIELD>|--input <FILE>]",
 	Long: `Low-level GitHub API request interface.
 
 ## Options:
@@ -39,9 +44,16 @@ var cmdApi = &Command{
 		If <VALUE> is "true", "false", "null", or looks like a number, an
 		appropriate JSON type is used instead of a string.
 
-		It is not possible to serialize <VALUE> as a nested JSON array or hash.
-		Instead, construct the request payload externally and pass it via
-		'--input'.
+		Nested JSON objects and arrays can be built by addressing fields within
+		<KEY>: "pull_request.draft=true" or "head[repo]=hub" set a field of a
+		nested object, and "labels[]=bug" appends to an array. These are only
+		recognized in request bodies, not with '-XGET'.
+
+		The field "file=@<PATH>" is special: the contents of <PATH> are uploaded
+		as the raw request body to the uploads host, as needed for release
+		assets, and the other fields are sent in the query string. The "name"
+		field defaults to the filename of <PATH>, and the "Content-Type" header
+		is guessed from its extension.
 
 		Unless '-XGET' was used, all fields are sent serialized as JSON within the
 		request body. When <ENDPOINT> is "graphql", all fields other than "query"
@@ -56,6 +68,7 @@ var cmdApi = &Command{
 	--input <FILE>
 		The filename to read the raw request body from. Use "-" to read from standard
 		input. Use this when you want to manually construct the request payload.
+		Files with a ".yml" or ".yaml" extension are converted from YAML to JSON.
 
 	-H, --header <KEY>:<VALUE>
 		Set an HTTP request header.
@@ -63,22 +76,87 @@ var cmdApi = &Command{
 	-i, --include
 		Include HTTP response headers in the output.
 
+	--status-only
+		Output the HTTP status code of the response instead of its body. Combined
+		with '--include', output the status line and headers.
+
+	--fail-fast
+		For unsuccessful responses, print the error message parsed from the
+		response body to standard error instead of printing the body to
+		standard output. Either way, the exit status is 22 when the HTTP status
+		is not in the 2xx range.
+
 	-t, --flat
 		Parse response JSON and output the data in a line-based key-value format
 		suitable for use in shell scripts.
 
+	--format <TEMPLATE>
+		Render the response JSON through a Go template. This flag has no '-t'
+		shorthand since that one stands for '--flat'. See
+		<https://golang.org/pkg/text/template/> for the syntax. In addition to the
+		builtin template functions, these helpers are available:
+
+		'timeago' <TIME>: render an ISO 8601 timestamp relative to now
+
+		'color' <COLOR> <TEXT>: wrap <TEXT> in color if colored output is enabled.
+		<COLOR> is one of "black", "red", "green", "yellow", "blue", "magenta",
+		"cyan", "white", or "bold"; combine them with "+", e.g. "bold+red".
+
+		'truncate' <LENGTH> <TEXT>: shorten <TEXT> to at most <LENGTH> characters
+
+		'tablerow' <FIELDS>...: output <FIELDS> as a row of a table whose columns
+		are aligned across all rows
+
+		With '--paginate', the template is applied to each page of results
+		separately, unless '--slurp' is also used.
+
+	--jq <EXPR>
+		Filter the response JSON through a jq expression and output each result
+		on its own line. Strings are output without quotes; other values as
+		JSON. See <https://stedolan.github.io/jq/manual/> for the syntax, of
+		which a subset is supported:
+
+		Paths: '.', '.key', '.["key"]', '.[INDEX]', '.[]', and '?' to ignore
+		errors.
+
+		Operators: '|', ',', '//', '==', '!=', '<', '<=', '>', '>=', '+', '-',
+		'and', 'or'.
+
+		Values: literals, '[...]' arrays, '{key: ...}' objects, and strings with
+		'\(...)' interpolation.
+
+		Functions: 'select', 'map', 'length', 'keys', 'has', 'not', 'join',
+		'add', 'sort', 'first', 'last', 'contains', 'startswith', 'endswith',
+		'test', 'ascii_downcase', 'ascii_upcase', 'tostring', 'tonumber',
+		'type', and 'empty'.
+
+		With '--paginate', the expression is applied to each page of results
+		separately, unless '--slurp' is also used.
+
 	--paginate
 		Automatically request and output the next page of results until all
 		resources have been listed. For GET requests, this follows the '<next>'
 		resource as indicated in the "Link" response header. For GraphQL queries,
 		this utilizes 'pageInfo' that must be present in the query; see EXAMPLES.
 
-		Note that multiple JSON documents will be output as a result.
+		Note that multiple JSON documents will be output as a result, unless
+		'--slurp' is also used.
+
+	--slurp
+		When used with '--paginate', buffer all pages and merge them into a single
+		JSON document. Array responses are concatenated into one array. For
+		GraphQL queries, the "nodes" and "edges" lists of the paginated connection
+		are concatenated, and "pageInfo" reflects the last page.
 
 	--color[=<WHEN>]
 		Enable colored output even if stdout is not a terminal. <WHEN> can be one
 		of "always" (default for '--color'), "never", or "auto" (default).
 
+	--hostname <HOST>
+		The GitHub host to send the request to, such as a GitHub Enterprise
+		host, instead of the host of the current repository. Credentials for
+		<HOST> are looked up in the configuration.
+
 	--cache <TTL>
 		Cache valid responses to GET requests for <TTL> seconds.
 
@@ -92,6 +170,11 @@ var cmdApi = &Command{
 		To learn about available endpoints, see <https://developer.github.com/v3/>.
 		To make GraphQL queries, use "graphql" as <ENDPOINT> and pass '-F query=QUERY'.
 
+		<ENDPOINT> can also be a full URL. For URLs of the API of github.com or of a
+		GitHub Enterprise host, such as "https://ghe.example.com/api/v3/user", the
+		credentials for that host are used. Other URLs are requested without
+		credentials.
+
 		If the literal strings "{owner}" or "{repo}" appear in <ENDPOINT> or in the
 		GraphQL "query" field, fill in those placeholders with values read from the
 		git remote configuration of the current git repository.
@@ -107,6 +190,27 @@ var cmdApi = &Command{
 		# post a comment to issue #23 of the current repository
 		$ hub api repos/{owner}/{repo}/issues/23/comments --raw-field "body=Nice job!"
 
+		# list open issues as an aligned table
+		$ hub api repos/{owner}/{repo}/issues --format \
+		  '{{range .}}{{tablerow (printf "#%v" .number) .title (timeago .updated_at)}}{{end}}'
+
+		# open an issue with several labels
+		$ hub api repos/{owner}/{repo}/issues -f title=Crash -f 'labels[]=bug' -f 'labels[]=ui'
+
+		# create a check run with a nested "output" object
+		$ hub api repos/{owner}/{repo}/check-runs -f name=lint -f head_sha="$SHA" \
+		  -f output.title=Lint -f output.summary="No problems"
+
+		# upload a release asset
+		$ hub api repos/{owner}/{repo}/releases/1234/assets -F file=@dist/hub.tgz
+
+		# print the numbers of open pull requests that are not drafts
+		$ hub api repos/{owner}/{repo}/pulls --jq '.[] | select(.draft | not) | .number'
+
+		# copy the description of a repository from github.com to GitHub Enterprise
+		$ desc="$(hub api repos/mislav/dotfiles --jq .description)"
+		$ hub api --hostname ghe.example.com -XPATCH repos/mislav/dotfiles -f description="$desc"
+
 		# perform a GraphQL query read from a file
 		$ hub api graphql -F query=@path/to/myquery.graphql
 
@@ -150,17 +254,25 @@ func apiCommand(cmd *Command, args *Args) {
 	}
 	cacheTTL := args.Flag.Int("--cache")
 
+	// Query string parameters can't be nested, so only the fields of request
+	// bodies are parsed for "." and "[]".
+	nestedFields := method != "GET"
+	uploadFile := ""
 	params := make(map[string]interface{})
 	for _, val := range args.Flag.AllValues("--field") {
 		parts := strings.SplitN(val, "=", 2)
 		if len(parts) >= 2 {
-			params[parts[0]] = magicValue(parts[1])
+			if parts[0] == "file" && strings.HasPrefix(parts[1], "@") {
+				uploadFile = parts[1][1:]
+				continue
+			}
+			utils.Check(setField(params, parts[0], magicValue(parts[1]), nestedFields))
 		}
 	}
 	for _, val := range args.Flag.AllValues("--raw-field") {
 		parts := strings.SplitN(val, "=", 2)
 		if len(parts) >= 2 {
-			params[parts[0]] = parts[1]
+			utils.Check(setField(params, parts[0], parts[1], nestedFields))
 		}
 	}
 
@@ -184,6 +296,14 @@ func apiCommand(cmd *Command, args *Args) {
 			repo = project.Name
 		}
 	}
+	if args.Flag.HasReceived("--hostname") {
+		host = args.Flag.Value("--hostname")
+		if u, err := url.Parse(host); err == nil && u.Host != "" {
+			host = u.Host
+		}
+	} else if urlHost := apiUrlHost(path); urlHost != "" {
+		host = urlHost
+	}
 	if host == "" {
 		defHost, err := github.CurrentConfig().DefaultHostNoPrompt()
 		utils.Check(err)
@@ -213,10 +333,26 @@ func apiCommand(cmd *Command, args *Args) {
 		path = strings.Replace(path, "{repo}", repo, -1)
 	}
 
+	gh := github.NewClient(host)
+
 	var body interface{}
-	if args.Flag.HasReceived("--input") {
+	if uploadFile != "" {
+		if _, ok := params["name"]; !ok && uploadFile != "-" {
+			params["name"] = filepath.Base(uploadFile)
+		}
+		if _, ok := headers["Content-Type"]; !ok {
+			headers["Content-Type"] = uploadContentType(uploadFile)
+		}
+		path = gh.UploadsUrl(strings.SplitN(path, "{", 2)[0])
+		path = appendQuery(path, params)
+		body = bytes.NewReader(readFile(uploadFile))
+	} else if args.Flag.HasReceived("--input") {
 		fn := args.Flag.Value("--input")
-		if fn == "-" {
+		if ext := strings.ToLower(filepath.Ext(fn)); ext == ".yml" || ext == ".yaml" {
+			payload, err := yamlToJSON(readFile(fn))
+			utils.Check(err)
+			body = bytes.NewReader(payload)
+		} else if fn == "-" {
 			body = os.Stdin
 		} else {
 			fi, err := os.Open(fn)
@@ -228,20 +364,47 @@ func apiCommand(cmd *Command, args *Args) {
 		body = params
 	}
 
-	gh := github.NewClient(host)
-
-	out := ui.Stdout
+	var out io.Writer
 	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
 	parseJSON := args.Flag.Bool("--flat")
 	includeHeaders := args.Flag.Bool("--include")
+	statusOnly := args.Flag.Bool("--status-only")
+	failFast := args.Flag.Bool("--fail-fast")
 	paginate := args.Flag.Bool("--paginate")
+	slurp := args.Flag.Bool("--slurp")
+	if slurp && !paginate {
+		utils.Check(fmt.Errorf("the '--slurp' option requires '--paginate'"))
+	}
+	formatTemplate := args.Flag.Value("--format")
+	if formatTemplate != "" && parseJSON {
+		utils.Check(fmt.Errorf("the '--format' and '--flat' options are mutually exclusive"))
+	}
+	var jqQuery *utils.JQ
+	if args.Flag.HasReceived("--jq") {
+		if formatTemplate != "" || parseJSON {
+			utils.Check(fmt.Errorf("the '--jq' option can't be combined with '--format' or '--flat'"))
+		}
+		var err error
+		jqQuery, err = utils.CompileJQ(args.Flag.Value("--jq"))
+		utils.Check(err)
+	}
 
 	args.NoForward()
 
+	var merged interface{}
+
 	requestLoop := true
 	for requestLoop {
+		spinner := ui.StartSpinner(fmt.Sprintf("%s %s ...", method, path))
 		response, err := gh.GenericAPIRequest(method, path, body, headers, cacheTTL)
+		spinner.Stop()
 		utils.Check(err)
+		if out == nil {
+			// the pager takes over the terminal, so it waits for the first
+			// response to be done with the spinner
+			startPager(args)
+			out = ui.Stdout
+		}
 		success := response.StatusCode < 300
 
 		jsonType := true
@@ -255,10 +418,46 @@ func apiCommand(cmd *Command, args *Args) {
 			fmt.Fprintf(out, "\r\n")
 		}
 
+		if !success && failFast {
+			ui.Errorln(response.FormatError("requesting " + path))
+			ui.Exit(22)
+		}
+
 		endCursor := ""
 		hasNextPage := false
 
-		if parseJSON && jsonType {
+		if statusOnly {
+			if !includeHeaders {
+				fmt.Fprintln(out, response.StatusCode)
+			}
+			if paginate && isGraphQL {
+				hasNextPage, endCursor = utils.JSONPath(ioutil.Discard, response.Body, false)
+			}
+		} else if slurp && success {
+			bodyCopy := &bytes.Buffer{}
+			page, err := decodeJSONPage(io.TeeReader(response.Body, bodyCopy))
+			utils.Check(err)
+			merged = mergeJSONPage(merged, page, isGraphQL)
+			if isGraphQL {
+				hasNextPage, endCursor = utils.JSONPath(ioutil.Discard, bodyCopy, false)
+			}
+		} else if formatTemplate != "" && success {
+			bodyCopy := &bytes.Buffer{}
+			page, err := decodeJSONPage(io.TeeReader(response.Body, bodyCopy))
+			utils.Check(err)
+			utils.Check(utils.ExecuteTemplate(out, formatTemplate, page, colorize))
+			if paginate && isGraphQL {
+				hasNextPage, endCursor = utils.JSONPath(ioutil.Discard, bodyCopy, false)
+			}
+		} else if jqQuery != nil && success {
+			bodyCopy := &bytes.Buffer{}
+			page, err := decodeJSONPage(io.TeeReader(response.Body, bodyCopy))
+			utils.Check(err)
+			utils.Check(jqQuery.Execute(out, page))
+			if paginate && isGraphQL {
+				hasNextPage, endCursor = utils.JSONPath(ioutil.Discard, bodyCopy, false)
+			}
+		} else if parseJSON && jsonType {
 			hasNextPage, endCursor = utils.JSONPath(out, response.Body, colorize)
 		} else if paginate && isGraphQL {
 			bodyCopy := &bytes.Buffer{}
@@ -270,7 +469,7 @@ func apiCommand(cmd *Command, args *Args) {
 		response.Body.Close()
 
 		if !success {
-			os.Exit(22)
+			ui.Exit(22)
 		}
 
 		requestLoop = false
@@ -289,10 +488,118 @@ func apiCommand(cmd *Command, args *Args) {
 				requestLoop = true
 			}
 		}
-		if requestLoop && !parseJSON {
+		if requestLoop && !parseJSON && !slurp && !statusOnly && formatTemplate == "" && jqQuery == nil {
 			fmt.Fprintf(out, "\n")
 		}
 	}
+
+	if slurp && !statusOnly {
+		mergedJSON, err := encodeJSON(merged)
+		utils.Check(err)
+		if parseJSON {
+			utils.JSONPath(out, bytes.NewReader(mergedJSON), colorize)
+		} else if formatTemplate != "" {
+			utils.Check(utils.ExecuteTemplate(out, formatTemplate, merged, colorize))
+		} else if jqQuery != nil {
+			utils.Check(jqQuery.Execute(out, merged))
+		} else {
+			out.Write(mergedJSON)
+		}
+	}
+}
+
+// apiUrlHost returns the GitHub host that serves the API at endpoint if it's
+// a full URL, or an empty string otherwise. URLs of other hosts are requested
+// without credentials.
+func apiUrlHost(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || !u.IsAbs() {
+		return ""
+	}
+	hostname := strings.ToLower(u.Host)
+	for _, prefix := range []string{"api.github.", "uploads.github."} {
+		if strings.HasPrefix(hostname, prefix) {
+			return strings.TrimPrefix(hostname, strings.SplitN(prefix, ".", 2)[0]+".")
+		}
+	}
+	if strings.HasPrefix(u.Path, "/api/") {
+		return hostname
+	}
+	return ""
+}
+
+func decodeJSONPage(r io.Reader) (page interface{}, err error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	err = dec.Decode(&page)
+	return
+}
+
+func encodeJSON(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// mergeJSONPage combines the next page of a paginated response into the
+// results accumulated so far. REST array responses are concatenated, as are
+// arrays found directly within a REST object response (e.g. search "items").
+// For GraphQL, only the "nodes" and "edges" of connections carrying
+// "pageInfo" are concatenated; other values are taken from the latest page.
+func mergeJSONPage(dst, src interface{}, isGraphQL bool) interface{} {
+	if dst == nil {
+		return src
+	}
+	if isGraphQL {
+		return mergeGraphQLValue(dst, src, false)
+	}
+
+	switch s := src.(type) {
+	case []interface{}:
+		if d, ok := dst.([]interface{}); ok {
+			return append(d, s...)
+		}
+	case map[string]interface{}:
+		if d, ok := dst.(map[string]interface{}); ok {
+			for key, value := range s {
+				dv, dIsArray := d[key].([]interface{})
+				sv, sIsArray := value.([]interface{})
+				if dIsArray && sIsArray {
+					d[key] = append(dv, sv...)
+				} else {
+					d[key] = value
+				}
+			}
+			return d
+		}
+	}
+	return src
+}
+
+func mergeGraphQLValue(dst, src interface{}, isConnectionList bool) interface{} {
+	switch s := src.(type) {
+	case []interface{}:
+		if d, ok := dst.([]interface{}); ok && isConnectionList {
+			return append(d, s...)
+		}
+	case map[string]interface{}:
+		if d, ok := dst.(map[string]interface{}); ok {
+			_, isConnection := s["pageInfo"]
+			for key, value := range s {
+				if dv, exists := d[key]; exists && key != "pageInfo" {
+					d[key] = mergeGraphQLValue(dv, value, isConnection && (key == "nodes" || key == "edges"))
+				} else {
+					d[key] = value
+				}
+			}
+			return d
+		}
+	}
+	return src
 }
 
 const (
@@ -320,6 +627,117 @@ func magicValue(value string) interface{} {
 	}
 }
 
+// setField assigns value to the field named key. When nested is set, key can
+// address nested objects with "." or "[KEY]", and append to arrays with "[]":
+// "pull_request.draft", "labels[]", or "head[repo][name]".
+func setField(params map[string]interface{}, key string, value interface{}, nested bool) error {
+	if !nested {
+		params[key] = value
+		return nil
+	}
+	_, err := setFieldPath(params, parseFieldKey(key), value, key)
+	return err
+}
+
+func parseFieldKey(key string) []string {
+	segments := []string{}
+	for _, part := range strings.Split(key, ".") {
+		name := part
+		if i := strings.IndexByte(part, '['); i > 0 && strings.HasSuffix(part, "]") {
+			name = part[:i]
+			segments = append(segments, name)
+			for _, sub := range strings.Split(part[i+1:len(part)-1], "][") {
+				segments = append(segments, sub)
+			}
+			continue
+		}
+		segments = append(segments, name)
+	}
+	return segments
+}
+
+func setFieldPath(parent interface{}, segments []string, value interface{}, key string) (interface{}, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+
+	if segments[0] == "" {
+		list, ok := parent.([]interface{})
+		if parent != nil && !ok {
+			return nil, fmt.Errorf("invalid field '%s': not an array", key)
+		}
+		item, err := setFieldPath(nil, segments[1:], value, key)
+		if err != nil {
+			return nil, err
+		}
+		return append(list, item), nil
+	}
+
+	object, ok := parent.(map[string]interface{})
+	if parent == nil {
+		object = make(map[string]interface{})
+	} else if !ok {
+		return nil, fmt.Errorf("invalid field '%s': not an object", key)
+	}
+	child, err := setFieldPath(object[segments[0]], segments[1:], value, key)
+	if err != nil {
+		return nil, err
+	}
+	object[segments[0]] = child
+	return object, nil
+}
+
+// yamlToJSON converts a YAML document to JSON. Unlike JSON, YAML allows
+// non-string keys, which are converted to strings.
+func yamlToJSON(content []byte) ([]byte, error) {
+	var data interface{}
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+	return encodeJSON(yamlValueToJSON(data))
+}
+
+func yamlValueToJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			object[fmt.Sprint(key)] = yamlValueToJSON(item)
+		}
+		return object
+	case []interface{}:
+		for i, item := range v {
+			v[i] = yamlValueToJSON(item)
+		}
+	}
+	return value
+}
+
+func uploadContentType(file string) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(file)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+func appendQuery(path string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return path
+	}
+	query := url.Values{}
+	for key, value := range params {
+		if value == nil {
+			value = ""
+		}
+		query.Add(key, fmt.Sprint(value))
+	}
+	sep := "?"
+	if strings.Contains(path, sep) {
+		sep = "&"
+	}
+	return path + sep + query.Encode()
+}
+
 func readFile(file string) (content []byte) {
 	var err error
 	if file == "-" {
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func mergeJSONPages(t *testing.T, isGraphQL bool, pages ...string) string {
+	var merged interface{}
+	for _, p := range pages {
+		page, err := decodeJSONPage(strings.NewReader(p))
+		assert.Equal(t, nil, err)
+		merged = mergeJSONPage(merged, page, isGraphQL)
+	}
+	out, err := encodeJSON(merged)
+	assert.Equal(t, nil, err)
+	return string(out)
+}
+
+func TestMergeJSONPage_Arrays(t *testing.T) {
+	out := mergeJSONPages(t, false, `[{"id":1}]`, `[{"id":2},{"id":3}]`)
+	assert.Equal(t, `[{"id":1},{"id":2},{"id":3}]`, out)
+}
+
+func TestMergeJSONPage_SearchResults(t *testing.T) {
+	out := mergeJSONPages(t, false,
+		`{"total_count":3,"items":[{"id":1},{"id":2}]}`,
+		`{"total_count":3,"items":[{"id":3}]}`)
+	assert.Equal(t, `{"items":[{"id":1},{"id":2},{"id":3}],"total_count":3}`, out)
+}
+
+func TestMergeJSONPage_GraphQL(t *testing.T) {
+	out := mergeJSONPages(t, true,
+		`{"data":{"viewer":{"login":"mislav","pinned":{"nodes":[1]},"repos":{"nodes":[{"n":"a"}],"pageInfo":{"hasNextPage":true,"endCursor":"A"}}}}}`,
+		`{"data":{"viewer":{"login":"mislav","pinned":{"nodes":[1]},"repos":{"nodes":[{"n":"b"}],"pageInfo":{"hasNextPage":false,"endCursor":"B"}}}}}`)
+	assert.Equal(t, `{"data":{"viewer":{"login":"mislav","pinned":{"nodes":[1]},"repos":{"nodes":[{"n":"a"},{"n":"b"}],"pageInfo":{"endCursor":"B","hasNextPage":false}}}}}`, out)
+}
+
+func TestSetField_Nested(t *testing.T) {
+	params := map[string]interface{}{}
+	assert.Equal(t, nil, setField(params, "title", "Crash", true))
+	assert.Equal(t, nil, setField(params, "labels[]", "bug", true))
+	assert.Equal(t, nil, setField(params, "labels[]", "ui", true))
+	assert.Equal(t, nil, setField(params, "pull_request.draft", true, true))
+	assert.Equal(t, nil, setField(params, "head[repo][name]", "hub", true))
+
+	out, err := encodeJSON(params)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"head":{"repo":{"name":"hub"}},"labels":["bug","ui"],"pull_request":{"draft":true},"title":"Crash"}`, string(out))
+
+	err = setField(params, "title.text", "x", true)
+	assert.Equal(t, "invalid field 'title.text': not an object", err.Error())
+	err = setField(params, "title[]", "x", true)
+	assert.Equal(t, "invalid field 'title[]': not an array", err.Error())
+}
+
+func TestSetField_Flat(t *testing.T) {
+	params := map[string]interface{}{}
+	setField(params, "labels[]", "bug", false)
+	assert.Equal(t, map[string]interface{}{"labels[]": "bug"}, params)
+}
+
+func TestYamlToJSON(t *testing.T) {
+	out, err := yamlToJSON([]byte("title: Crash\nlabels: [bug, ui]\nmilestone: 3\nmeta:\n  1: one\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"labels":["bug","ui"],"meta":{"1":"one"},"milestone":3,"title":"Crash"}`, string(out))
+}
+
+func TestApiUrlHost(t *testing.T) {
+	assert.Equal(t, "", apiUrlHost("repos/octocat/hello"))
+	assert.Equal(t, "github.com", apiUrlHost("https://api.github.com/user"))
+	assert.Equal(t, "github.com", apiUrlHost("https://uploads.github.com/repos/o/r/releases/1/assets"))
+	assert.Equal(t, "git.my.org", apiUrlHost("https://git.my.org/api/v3/user"))
+	assert.Equal(t, "", apiUrlHost("https://example.com/user"))
+}
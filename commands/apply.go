@@ -18,7 +18,9 @@ var cmdApply = &Command{
 
 ## Options:
 	<GITHUB-URL>
-		A URL to a pull request or commit on GitHub.
+		A URL to a pull request, commit, or comparison ("/compare/<BASE>...<HEAD>")
+		on GitHub. Patches are downloaded through the GitHub API, so URLs of private
+		repositories work too.
 
 ## Examples:
 		$ hub apply https://github.com/jingweno/gh/pull/55
@@ -42,13 +44,17 @@ var cmdAm = &Command{
 		(Recommended) See git-am(1).
 
 	<GITHUB-URL>
-		A URL to a pull request or commit on GitHub.
+		A URL to a pull request, commit, or comparison ("/compare/<BASE>...<HEAD>")
+		on GitHub. Patches are downloaded through the GitHub API, so URLs of private
+		repositories work too.
 
 ## Examples:
 		$ hub am -3 https://github.com/jingweno/gh/pull/55
 		> curl https://github.com/jingweno/gh/pull/55.patch -o /tmp/55.patch
 		> git am -3 /tmp/55.patch
 
+		$ hub am -3 https://github.com/github/hub/compare/v2.14.0...v2.14.1
+
 ## See also:
 
 hub-apply(1), hub-cherry-pick(1), hub(1), git-am(1)
@@ -70,6 +76,7 @@ func transformApplyArgs(args *Args) {
 	gistRegexp := regexp.MustCompile("^https?://gist\\.github\\.com/([\\w.-]+/)?([a-f0-9]+)")
 	commitRegexp := regexp.MustCompile("^(commit|pull/[0-9]+/commits)/([0-9a-f]+)")
 	pullRegexp := regexp.MustCompile("^pull/([0-9]+)")
+	compareRegexp := regexp.MustCompile("^compare/(.+?)/?$")
 	for idx, arg := range args.Params {
 		var (
 			patch    io.ReadCloser
@@ -82,6 +89,15 @@ func transformApplyArgs(args *Args) {
 				patch, apiError = gh.CommitPatch(projectURL.Project, match[2])
 			} else if match := pullRegexp.FindStringSubmatch(projectURL.ProjectPath()); match != nil {
 				patch, apiError = gh.PullRequestPatch(projectURL.Project, match[1])
+			} else if match := compareRegexp.FindStringSubmatch(projectURL.ProjectPath()); match != nil {
+				base, head := splitCompareRange(parseCompareRange(match[1]))
+				if base == "" {
+					var repo *github.Repository
+					repo, apiError = gh.Repository(projectURL.Project)
+					utils.Check(apiError)
+					base = repo.DefaultBranch
+				}
+				patch, apiError = gh.ComparePatch(projectURL.Project, base, head)
 			}
 		} else {
 			match := gistRegexp.FindStringSubmatch(arg)
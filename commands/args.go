@@ -17,6 +17,13 @@ type Args struct {
 	beforeChain []*cmd.Cmd
 	afterChain  []*cmd.Cmd
 	Noop        bool
+	Verbose     bool
+	NoCache     bool
+	Account     string
+	Remote      string
+	NoPager     bool
+	Quiet       bool
+	Offline     bool
 	Terminator  bool
 	noForward   bool
 	Callbacks   []func() error
@@ -186,6 +193,13 @@ func NewArgs(args []string) *Args {
 		command string
 		params  []string
 		noop    bool
+		verbose bool
+		noCache bool
+		account string
+		remote  string
+		noPager bool
+		quiet   bool
+		offline bool
 	)
 
 	cmdIdx := findCommandIndex(args)
@@ -196,6 +210,33 @@ func NewArgs(args []string) *Args {
 			if globalFlags[i] == noopFlag {
 				noop = true
 				globalFlags = append(globalFlags[:i], globalFlags[i+1:]...)
+			} else if globalFlags[i] == verboseFlag {
+				verbose = true
+				globalFlags = append(globalFlags[:i], globalFlags[i+1:]...)
+			} else if globalFlags[i] == noCacheFlag {
+				noCache = true
+				globalFlags = append(globalFlags[:i], globalFlags[i+1:]...)
+			} else if globalFlags[i] == accountFlag && i+1 < len(globalFlags) {
+				account = globalFlags[i+1]
+				globalFlags = append(globalFlags[:i], globalFlags[i+2:]...)
+			} else if strings.HasPrefix(globalFlags[i], accountFlag+"=") {
+				account = strings.TrimPrefix(globalFlags[i], accountFlag+"=")
+				globalFlags = append(globalFlags[:i], globalFlags[i+1:]...)
+			} else if globalFlags[i] == remoteFlag && i+1 < len(globalFlags) {
+				remote = globalFlags[i+1]
+				globalFlags = append(globalFlags[:i], globalFlags[i+2:]...)
+			} else if strings.HasPrefix(globalFlags[i], remoteFlag+"=") {
+				remote = strings.TrimPrefix(globalFlags[i], remoteFlag+"=")
+				globalFlags = append(globalFlags[:i], globalFlags[i+1:]...)
+			} else if globalFlags[i] == offlineFlag {
+				offline = true
+				globalFlags = append(globalFlags[:i], globalFlags[i+1:]...)
+			} else if globalFlags[i] == quietFlag || globalFlags[i] == "-q" {
+				quiet = true
+				globalFlags = append(globalFlags[:i], globalFlags[i+1:]...)
+			} else if globalFlags[i] == noPagerFlag || globalFlags[i] == "-P" {
+				// git understands the flag too, so it stays among the global flags
+				noPager = true
 			}
 		}
 	}
@@ -211,6 +252,13 @@ func NewArgs(args []string) *Args {
 		Command:     command,
 		Params:      params,
 		Noop:        noop,
+		Verbose:     verbose,
+		NoCache:     noCache,
+		Account:     account,
+		Remote:      remote,
+		NoPager:     noPager,
+		Quiet:       quiet,
+		Offline:     offline,
 		beforeChain: make([]*cmd.Cmd, 0),
 		afterChain:  make([]*cmd.Cmd, 0),
 	}
@@ -218,6 +266,13 @@ func NewArgs(args []string) *Args {
 
 const (
 	noopFlag    = "--noop"
+	verboseFlag = "--verbose"
+	accountFlag = "--account"
+	remoteFlag  = "--remote"
+	noCacheFlag = "--no-cache"
+	noPagerFlag = "--no-pager"
+	quietFlag   = "--quiet"
+	offlineFlag = "--offline"
 	versionFlag = "--version"
 	listCmds    = "--list-cmds="
 	helpFlag    = "--help"
@@ -242,7 +297,7 @@ func findCommandIndex(args []string) int {
 			break
 		} else {
 			commandIndex = i + 1
-			if arg == configFlag || arg == chdirFlag {
+			if arg == configFlag || arg == chdirFlag || arg == accountFlag || arg == remoteFlag {
 				slurpNextValue = true
 			}
 		}
@@ -98,6 +98,76 @@ func TestArgs_GlobalFlags_NoopTwice(t *testing.T) {
 	assert.Equal(t, true, args.Noop)
 }
 
+func TestArgs_GlobalFlags_Verbose(t *testing.T) {
+	args := NewArgs([]string{"--verbose", "--noop", "--no-cache", "-c", "a=b", "pr", "list", "--verbose"})
+	assert.Equal(t, "pr", args.Command)
+	assert.Equal(t, []string{"-c", "a=b"}, args.GlobalFlags)
+	assert.Equal(t, []string{"list", "--verbose"}, args.Params)
+	assert.Equal(t, true, args.Noop)
+	assert.Equal(t, true, args.Verbose)
+	assert.Equal(t, true, args.NoCache)
+}
+
+func TestArgs_GlobalFlags_Account(t *testing.T) {
+	args := NewArgs([]string{"--account", "work", "-c", "a=b", "pr", "list"})
+	assert.Equal(t, "pr", args.Command)
+	assert.Equal(t, []string{"-c", "a=b"}, args.GlobalFlags)
+	assert.Equal(t, []string{"list"}, args.Params)
+	assert.Equal(t, "work", args.Account)
+
+	args = NewArgs([]string{"--account=work", "status"})
+	assert.Equal(t, "status", args.Command)
+	assert.Equal(t, 0, len(args.GlobalFlags))
+	assert.Equal(t, "work", args.Account)
+}
+
+func TestArgs_GlobalFlags_Remote(t *testing.T) {
+	args := NewArgs([]string{"--remote", "upstream", "-C", "mydir", "pr", "list"})
+	assert.Equal(t, "pr", args.Command)
+	assert.Equal(t, []string{"-C", "mydir"}, args.GlobalFlags)
+	assert.Equal(t, []string{"list"}, args.Params)
+	assert.Equal(t, "upstream", args.Remote)
+
+	args = NewArgs([]string{"--remote=upstream", "browse"})
+	assert.Equal(t, "browse", args.Command)
+	assert.Equal(t, 0, len(args.GlobalFlags))
+	assert.Equal(t, "upstream", args.Remote)
+}
+
+func TestArgs_GlobalFlags_NoPager(t *testing.T) {
+	args := NewArgs([]string{"--no-pager", "issue"})
+	assert.Equal(t, "issue", args.Command)
+	assert.Equal(t, []string{"--no-pager"}, args.GlobalFlags)
+	assert.T(t, args.NoPager)
+
+	args = NewArgs([]string{"-P", "log"})
+	assert.Equal(t, "log", args.Command)
+	assert.T(t, args.NoPager)
+
+	args = NewArgs([]string{"pr", "list"})
+	assert.Equal(t, false, args.NoPager)
+}
+
+func TestArgs_GlobalFlags_Quiet(t *testing.T) {
+	args := NewArgs([]string{"-q", "delete", "foo"})
+	assert.Equal(t, "delete", args.Command)
+	assert.Equal(t, []string{}, args.GlobalFlags)
+	assert.T(t, args.Quiet)
+
+	args = NewArgs([]string{"--quiet", "--noop", "star"})
+	assert.Equal(t, "star", args.Command)
+	assert.T(t, args.Quiet)
+	assert.T(t, args.Noop)
+}
+
+func TestArgs_GlobalFlags_Offline(t *testing.T) {
+	args := NewArgs([]string{"--offline", "browse", "-u"})
+	assert.Equal(t, "browse", args.Command)
+	assert.Equal(t, []string{"-u"}, args.Params)
+	assert.Equal(t, []string{}, args.GlobalFlags)
+	assert.T(t, args.Offline)
+}
+
 func TestArgs_GlobalFlags_Repeated(t *testing.T) {
 	args := NewArgs([]string{"-C", "mydir", "-c", "a=b", "--bare", "-c", "c=d", "-c", "e=f", "status"})
 	assert.Equal(t, "status", args.Command)
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var cmdAuditLog = &Command{
+	Run:   auditLog,
+	Usage: "audit-log [--org <ORG>] [--phrase <QUERY>] [-L <LIMIT>] [--json]",
+	Long: `Search the audit log of a GitHub organization.
+
+Events are listed most recent first as "<TIME>  <ACTOR>  <ACTION>  <TARGET>"
+lines, where <TARGET> is the repository or user the action applies to. Only
+owners of the organization can read its audit log.
+
+## Options:
+	--org <ORG>
+		The login of the organization (default: the owner of the repository of
+		the current directory).
+
+	--phrase <QUERY>
+		Only list events that match <QUERY>, using the same qualifiers as the
+		search box of the audit log, such as "action:repo.destroy" or
+		"actor:octocat created:>=2020-01-01".
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> events (default: 30).
+
+	--json
+		Output the events as a JSON array instead of a table.
+
+## Examples:
+		$ hub audit-log --org github --phrase 'action:repo.destroy'
+		$ hub audit-log --phrase 'actor:octocat' -L 100 --json
+
+## See also:
+
+hub-org(1), hub-api(1), hub(1)
+`,
+	KnownFlags: `
+		--org ORG
+		--phrase QUERY
+		-L, --limit N
+		--json
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdAuditLog)
+}
+
+func auditLog(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	org, host := resolveOrg(args.Flag.Value("--org"))
+
+	limit := 30
+	if args.Flag.HasReceived("--limit") {
+		limit = args.Flag.Int("--limit")
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request audit log of %s\n", org)
+		return
+	}
+
+	gh := github.NewClient(host)
+	entries, err := gh.AuditLog(org, args.Flag.Value("--phrase"), limit)
+	utils.Check(err)
+
+	if args.Flag.Bool("--json") {
+		out, err := encodeJSON(entries)
+		utils.Check(err)
+		ui.Println(string(out))
+		return
+	}
+
+	actorWidth, actionWidth := 0, 0
+	for _, entry := range entries {
+		if w := len(entry.Actor); w > actorWidth {
+			actorWidth = w
+		}
+		if w := len(entry.Action); w > actionWidth {
+			actionWidth = w
+		}
+	}
+	for _, entry := range entries {
+		target := entry.Repo
+		if target == "" {
+			target = entry.User
+		}
+		line := fmt.Sprintf("%s  %-*s  %-*s  %s", entry.Time().Format(time.RFC3339), actorWidth, entry.Actor, actionWidth, entry.Action, target)
+		ui.Println(strings.TrimRight(line, " "))
+	}
+}
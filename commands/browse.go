@@ -3,8 +3,12 @@ package commands
 import (
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/github/hub/git"
 	"github.com/github/hub/github"
 	"github.com/github/hub/utils"
 )
@@ -25,7 +29,13 @@ var cmdBrowse = &Command{
 		Defaults to repository in the current working directory.
 
 	<SUBPAGE>
-		One of "wiki", "commits", "issues", or other (default: "tree").
+		One of "wiki", "commits", "issues", "actions", "releases", "security",
+		"settings", or other (default: "tree").
+
+		A local <FILE>, optionally followed by ":<LINE>" or ":<START>-<END>", opens
+		a permalink to the file (and lines) as of the current commit, which needs
+		to have been pushed. A "commit/<REF>" subpage resolves <REF> to a SHA
+		locally.
 
 ## Examples:
 		$ hub browse
@@ -40,6 +50,12 @@ var cmdBrowse = &Command{
 		$ hub browse gh wiki
 		> open https://github.com/USER/gh/wiki
 
+		$ hub browse -- commands/browse.go:10-20
+		> open https://github.com/REPO/blob/SHA/commands/browse.go#L10-L20
+
+		$ hub browse -- commit/HEAD~1
+		> open https://github.com/REPO/commit/SHA
+
 ## See also:
 
 hub-compare(1), hub(1)
@@ -73,11 +89,16 @@ func browse(command *Command, args *Args) {
 		dest = ""
 	}
 
+	fileLink := ""
+	if dest == "" && subpage != "" && !browseSubpages[subpage] {
+		fileLink = fileSubpage(subpage)
+	}
+
 	localRepo, _ := github.LocalRepo()
 	if dest != "" {
 		project = github.NewProject("", dest, "")
 		branch = localRepo.MasterBranch()
-	} else if subpage != "" && subpage != "commits" && subpage != "tree" && subpage != "blob" && subpage != "settings" {
+	} else if fileLink != "" || (subpage != "" && subpage != "commits" && subpage != "tree" && subpage != "blob" && subpage != "settings") {
 		project, err = localRepo.MainProject()
 		branch = localRepo.MasterBranch()
 		utils.Check(err)
@@ -108,12 +129,20 @@ func browse(command *Command, args *Args) {
 		utils.Check(command.UsageError(""))
 	}
 
-	if subpage == "commits" {
+	if fileLink != "" {
+		path = fileLink
+	} else if subpage == "commits" {
 		path = fmt.Sprintf("commits/%s", branchInURL(branch))
 	} else if subpage == "tree" || subpage == "" {
 		if !branch.IsMaster() {
 			path = fmt.Sprintf("tree/%s", branchInURL(branch))
 		}
+	} else if ref := strings.TrimPrefix(subpage, "commit/"); ref != subpage && dest == "" {
+		if sha, err := git.Ref(ref); err == nil {
+			path = "commit/" + sha
+		} else {
+			path = subpage
+		}
 	} else {
 		path = subpage
 	}
@@ -126,6 +155,70 @@ func browse(command *Command, args *Args) {
 	printBrowseOrCopy(args, pageUrl, !flagBrowseURLPrint && !flagBrowseURLCopy, flagBrowseURLCopy)
 }
 
+// browseSubpages are repository pages that take precedence over local files
+// of the same name.
+var browseSubpages = map[string]bool{
+	"actions":  true,
+	"commits":  true,
+	"issues":   true,
+	"pulls":    true,
+	"releases": true,
+	"security": true,
+	"settings": true,
+	"tree":     true,
+	"wiki":     true,
+}
+
+var fileLinesRegexp = regexp.MustCompile(`^(.+?)(?::(\d+)(?:-(\d+))?)?$`)
+
+// fileSubpage returns the "blob/SHA/PATH#L<START>-L<END>" subpage for an
+// argument naming a local file with an optional line range, or an empty
+// string if arg isn't a local file.
+func fileSubpage(arg string) string {
+	match := fileLinesRegexp.FindStringSubmatch(arg)
+	file := match[1]
+	info, err := os.Stat(file)
+	if err != nil {
+		return ""
+	}
+
+	toplevel, err := git.WorkdirName()
+	if err != nil {
+		return ""
+	}
+	toplevel, _ = filepath.EvalSymlinks(toplevel)
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return ""
+	}
+	absFile, _ = filepath.EvalSymlinks(absFile)
+	relPath, err := filepath.Rel(toplevel, absFile)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return ""
+	}
+
+	sha, err := git.Ref("HEAD")
+	if err != nil {
+		return ""
+	}
+
+	kind := "blob"
+	if info.IsDir() {
+		kind = "tree"
+	}
+	subpage := fmt.Sprintf("%s/%s/%s", kind, sha, filepath.ToSlash(relPath))
+	if relPath == "." {
+		subpage = fmt.Sprintf("tree/%s", sha)
+	}
+	if match[2] != "" {
+		subpage = fmt.Sprintf("%s#L%s", subpage, match[2])
+		if match[3] != "" {
+			subpage = fmt.Sprintf("%s-L%s", subpage, match[3])
+		}
+	}
+	return subpage
+}
+
 func branchInURL(branch *github.Branch) string {
 	parts := strings.Split(branch.ShortName(), "/")
 	newPath := make([]string, len(parts))
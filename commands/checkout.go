@@ -3,6 +3,8 @@ package commands
 import (
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/github/hub/git"
 	"github.com/github/hub/github"
@@ -12,20 +14,52 @@ import (
 var cmdCheckout = &Command{
 	Run:          checkout,
 	GitExtension: true,
-	Usage:        "checkout <PULLREQ-URL> [<BRANCH>]",
-	Long: `Check out the head of a pull request as a local branch.
+	Usage: `
+checkout <PULLREQ-URL> [<BRANCH>]
+checkout <COMMIT-URL> [<BRANCH>]
+checkout <BRANCH-URL> [<BRANCH>]
+`,
+	Long: `Check out the head of a pull request, a commit, or a branch from a GitHub URL.
+
+<PULLREQ-URL> is checked out as a local branch that tracks the head of the
+pull request.
+
+<COMMIT-URL> is the URL of a commit, or of a check run or GitHub Actions
+workflow run, whose commit is checked out as a detached HEAD. With <BRANCH>,
+a new branch is started at the commit instead.
+
+<BRANCH-URL> is the URL of a branch ("/tree/<NAME>") or of a comparison
+("/compare/<BASE>...<HEAD>"), whose head branch is checked out as a local
+branch that tracks it. Branches of forks that no git remote points to are
+checked out as "<OWNER>-<NAME>".
 
 ## Examples:
 		$ hub checkout https://github.com/jingweno/gh/pull/73
 		> git fetch origin pull/73/head:jingweno-feature
 		> git checkout jingweno-feature
 
+		$ hub checkout https://github.com/github/hub/commit/a0b1c2d
+		> git fetch -q --no-tags origin
+		> git checkout --detach a0b1c2d
+
+		$ hub checkout https://github.com/github/hub/compare/master...mislav:fixes
+		> git fetch git://github.com/mislav/hub.git refs/heads/fixes:mislav-fixes
+		> git checkout mislav-fixes
+
 ## See also:
 
 hub-merge(1), hub-am(1), hub(1), git-checkout(1)
 `,
 }
 
+var (
+	checkoutPullRegexp    = regexp.MustCompile(`^pull/(\d+)`)
+	checkoutCommitRegexp  = regexp.MustCompile(`^commits?/([a-f0-9]{7,40})(/|$)`)
+	checkoutRunRegexp     = regexp.MustCompile(`^(actions/)?runs/(\d+)`)
+	checkoutTreeRegexp    = regexp.MustCompile(`^tree/(.+?)/?$`)
+	checkoutCompareRegexp = regexp.MustCompile(`^compare/(.+?)/?$`)
+)
+
 func init() {
 	CmdRunner.Use(cmdCheckout)
 }
@@ -49,24 +83,46 @@ func checkout(command *Command, args *Args) {
 		return
 	}
 
-	pullURLRegex := regexp.MustCompile("^pull/(\\d+)")
 	projectPath := url.ProjectPath()
-	if !pullURLRegex.MatchString(projectPath) {
-		// not a valid PR URL
+	var newArgs []string
+	if matches := checkoutPullRegexp.FindStringSubmatch(projectPath); matches != nil {
+		utils.Check(sanitizeCheckoutFlags(args, "pull request"))
+		gh := github.NewClient(url.Project.Host)
+		pullRequest, err := gh.PullRequest(url.Project, matches[1])
+		utils.Check(err)
+		newArgs, err = transformCheckoutArgs(args, pullRequest, newBranchName)
+		utils.Check(err)
+	} else if matches := checkoutCommitRegexp.FindStringSubmatch(projectPath); matches != nil {
+		utils.Check(sanitizeCheckoutFlags(args, "commit"))
+		newArgs, err = checkoutCommitArgs(args, url.Project, matches[1], newBranchName)
+		utils.Check(err)
+	} else if matches := checkoutRunRegexp.FindStringSubmatch(projectPath); matches != nil {
+		utils.Check(sanitizeCheckoutFlags(args, "commit"))
+		runId, _ := strconv.ParseInt(matches[2], 10, 64)
+		gh := github.NewClient(url.Project.Host)
+		var sha string
+		if matches[1] == "" {
+			sha, err = gh.CheckRunHeadSha(url.Project, runId)
+		} else {
+			sha, err = gh.WorkflowRunHeadSha(url.Project, runId)
+		}
+		utils.Check(err)
+		newArgs, err = checkoutCommitArgs(args, url.Project, sha, newBranchName)
+		utils.Check(err)
+	} else if matches := checkoutTreeRegexp.FindStringSubmatch(projectPath); matches != nil {
+		utils.Check(sanitizeCheckoutFlags(args, "branch"))
+		newArgs, err = checkoutBranchArgs(args, url.Project, matches[1], newBranchName)
+		utils.Check(err)
+	} else if matches := checkoutCompareRegexp.FindStringSubmatch(projectPath); matches != nil {
+		utils.Check(sanitizeCheckoutFlags(args, "branch"))
+		project, branch := compareHead(url.Project, matches[1])
+		newArgs, err = checkoutBranchArgs(args, project, branch, newBranchName)
+		utils.Check(err)
+	} else {
+		// not a URL that can be checked out
 		return
 	}
 
-	err = sanitizeCheckoutFlags(args)
-	utils.Check(err)
-
-	id := pullURLRegex.FindStringSubmatch(projectPath)[1]
-	gh := github.NewClient(url.Project.Host)
-	pullRequest, err := gh.PullRequest(url.Project, id)
-	utils.Check(err)
-
-	newArgs, err := transformCheckoutArgs(args, pullRequest, newBranchName)
-	utils.Check(err)
-
 	if idx := args.IndexOfParam(newBranchName); idx >= 0 {
 		args.RemoveParam(idx)
 	}
@@ -95,17 +151,7 @@ func transformCheckoutArgs(args *Args, pullRequest *github.PullRequest, newBranc
 		if newBranchName == "" {
 			newBranchName = pullRequest.Head.Ref
 		}
-		remoteBranch := fmt.Sprintf("%s/%s", headRemote.Name, pullRequest.Head.Ref)
-		refSpec := fmt.Sprintf("+refs/heads/%s:refs/remotes/%s", pullRequest.Head.Ref, remoteBranch)
-		if git.HasFile("refs", "heads", newBranchName) {
-			newArgs = append(newArgs, newBranchName)
-			args.After("git", "merge", "--ff-only", fmt.Sprintf("refs/remotes/%s", remoteBranch))
-		} else {
-			newArgs = append(newArgs, "-b", newBranchName, "--no-track", remoteBranch)
-			args.After("git", "config", fmt.Sprintf("branch.%s.remote", newBranchName), headRemote.Name)
-			args.After("git", "config", fmt.Sprintf("branch.%s.merge", newBranchName), "refs/heads/"+pullRequest.Head.Ref)
-		}
-		args.Before("git", "fetch", headRemote.Name, refSpec)
+		newArgs = checkoutRemoteBranchArgs(args, headRemote.Name, pullRequest.Head.Ref, newBranchName)
 	} else {
 		if newBranchName == "" {
 			newBranchName = pullRequest.Head.Ref
@@ -147,13 +193,114 @@ func transformCheckoutArgs(args *Args, pullRequest *github.PullRequest, newBranc
 	return
 }
 
-func sanitizeCheckoutFlags(args *Args) error {
+// checkoutRemoteBranchArgs checks out branch of a git remote as newBranchName,
+// fast-forwarding it if it already exists.
+func checkoutRemoteBranchArgs(args *Args, remoteName, branch, newBranchName string) (newArgs []string) {
+	remoteBranch := fmt.Sprintf("%s/%s", remoteName, branch)
+	refSpec := fmt.Sprintf("+refs/heads/%s:refs/remotes/%s", branch, remoteBranch)
+	if git.HasFile("refs", "heads", newBranchName) {
+		newArgs = append(newArgs, newBranchName)
+		args.After("git", "merge", "--ff-only", fmt.Sprintf("refs/remotes/%s", remoteBranch))
+	} else {
+		newArgs = append(newArgs, "-b", newBranchName, "--no-track", remoteBranch)
+		args.After("git", "config", fmt.Sprintf("branch.%s.remote", newBranchName), remoteName)
+		args.After("git", "config", fmt.Sprintf("branch.%s.merge", newBranchName), "refs/heads/"+branch)
+	}
+	args.Before("git", "fetch", remoteName, refSpec)
+	return
+}
+
+// checkoutBranchArgs checks out branch of project. Without a git remote for
+// project, the branch is fetched from its URL and named after its owner.
+func checkoutBranchArgs(args *Args, project *github.Project, branch, newBranchName string) (newArgs []string, err error) {
+	repo, err := github.LocalRepo()
+	if err != nil {
+		return
+	}
+
+	if remote, remoteErr := repo.RemoteForProject(project); remoteErr == nil {
+		if newBranchName == "" {
+			newBranchName = branch
+		}
+		return checkoutRemoteBranchArgs(args, remote.Name, branch, newBranchName), nil
+	}
+
+	if newBranchName == "" {
+		newBranchName = fmt.Sprintf("%s-%s", project.Owner, branch)
+	}
+	newArgs = append(newArgs, newBranchName)
+
+	remoteURL := project.GitURL("", "", false)
+	ref := "refs/heads/" + branch
+	if b, errB := repo.CurrentBranch(); errB == nil && b.ShortName() == newBranchName {
+		args.Before("git", "fetch", remoteURL, ref)
+		args.After("git", "merge", "--ff-only", "FETCH_HEAD")
+	} else {
+		args.Before("git", "fetch", remoteURL, fmt.Sprintf("%s:%s", ref, newBranchName))
+	}
+
+	if mc, err := git.Config(fmt.Sprintf("branch.%s.merge", newBranchName)); err != nil || mc == "" {
+		args.After("git", "config", fmt.Sprintf("branch.%s.remote", newBranchName), remoteURL)
+		args.After("git", "config", fmt.Sprintf("branch.%s.merge", newBranchName), ref)
+	}
+	return
+}
+
+// checkoutCommitArgs checks out sha from project as a detached HEAD, or as
+// newBranchName if given.
+func checkoutCommitArgs(args *Args, project *github.Project, sha, newBranchName string) (newArgs []string, err error) {
+	repo, err := github.LocalRepo()
+	if err != nil {
+		return
+	}
+
+	tmpName := "_hub-checkout"
+	remoteName := tmpName
+	if remote, err := repo.RemoteForProject(project); err == nil {
+		remoteName = remote.Name
+	} else {
+		args.Before("git", "remote", "add", remoteName, project.GitURL("", "", false))
+	}
+
+	fetchArgs := []string{"git", "fetch", "-q", "--no-tags", remoteName}
+	if len(sha) == 40 {
+		// full SHAs can be fetched directly, even when no branch contains them
+		fetchArgs = append(fetchArgs, sha)
+	}
+	args.Before(fetchArgs...)
+
+	if remoteName == tmpName {
+		args.Before("git", "remote", "rm", remoteName)
+	}
+
+	if newBranchName != "" {
+		return []string{"-b", newBranchName, sha}, nil
+	}
+	return []string{"--detach", sha}, nil
+}
+
+// compareHead resolves the head branch of the "<BASE>...<HEAD>" range of a
+// compare URL of project. <HEAD> can be qualified as "<OWNER>:<BRANCH>" or
+// "<OWNER>:<REPO>:<BRANCH>" to refer to a fork.
+func compareHead(project *github.Project, compareRange string) (*github.Project, string) {
+	_, head := splitCompareRange(parseCompareRange(compareRange))
+	parts := strings.SplitN(head, ":", 3)
+	switch len(parts) {
+	case 2:
+		return github.NewProject(parts[0], project.Name, project.Host), parts[1]
+	case 3:
+		return github.NewProject(parts[0], parts[1], project.Host), parts[2]
+	}
+	return project, head
+}
+
+func sanitizeCheckoutFlags(args *Args, what string) error {
 	if i := args.IndexOfParam("-b"); i != -1 {
-		return fmt.Errorf("Unsupported flag -b when checking out pull request")
+		return fmt.Errorf("Unsupported flag -b when checking out %s", what)
 	}
 
 	if i := args.IndexOfParam("--orphan"); i != -1 {
-		return fmt.Errorf("Unsupported flag --orphan when checking out pull request")
+		return fmt.Errorf("Unsupported flag --orphan when checking out %s", what)
 	}
 
 	return nil
@@ -14,9 +14,22 @@ var cmdCherryPick = &Command{
 	Usage: `
 cherry-pick <COMMIT-URL>
 cherry-pick <USER>@<SHA>
+cherry-pick <PULLREQ-URL>
+cherry-pick <COMPARE-URL>
 `,
 	Long: `Cherry-pick a commit from a fork on GitHub.
 
+Given the URL of a pull request, all of its commits are cherry-picked in order.
+Given the URL of a comparison ("/compare/<BASE>...<HEAD>"), the commits of
+<HEAD> that <BASE> doesn't have are cherry-picked in order.
+
+## Examples:
+		$ hub cherry-pick https://github.com/github/hub/pull/73
+		> git fetch -q --no-tags origin refs/pull/73/head
+		> git cherry-pick a1b2c3d e4f5a6b
+
+		$ hub cherry-pick https://github.com/github/hub/compare/master...mislav:fixes
+
 ## See also:
 
 hub-am(1), hub(1), git-cherry-pick(1)
@@ -39,7 +52,8 @@ func transformCherryPickArgs(args *Args) {
 	}
 
 	var project *github.Project
-	var sha, refspec string
+	var shas []string
+	var refspec string
 	shaRe := "[a-f0-9]{7,40}"
 
 	var mainProject *github.Project
@@ -53,15 +67,37 @@ func transformCherryPickArgs(args *Args) {
 		projectPath := url.ProjectPath()
 		commitRegex := regexp.MustCompile(fmt.Sprintf("^commit/(%s)", shaRe))
 		pullRegex := regexp.MustCompile(fmt.Sprintf(`^pull/(\d+)/commits/(%s)`, shaRe))
+		pullRequestRegex := regexp.MustCompile(`^pull/(\d+)(/(commits|files))?/?$`)
+		compareRegex := regexp.MustCompile(`^compare/(.+?)/?$`)
 		if matches := commitRegex.FindStringSubmatch(projectPath); len(matches) > 0 {
-			sha = matches[1]
+			shas = []string{matches[1]}
 			project = url.Project
 		} else if matches := pullRegex.FindStringSubmatch(projectPath); len(matches) > 0 {
 			pullId := matches[1]
-			sha = matches[2]
+			shas = []string{matches[2]}
 			utils.Check(mainProjectErr)
 			project = mainProject
 			refspec = fmt.Sprintf("refs/pull/%s/head", pullId)
+		} else if matches := pullRequestRegex.FindStringSubmatch(projectPath); len(matches) > 0 {
+			gh := github.NewClient(url.Project.Host)
+			commits, err := gh.PullRequestCommits(url.Project, matches[1])
+			utils.Check(err)
+			shas = commitShas(commits)
+			project = url.Project
+			refspec = fmt.Sprintf("refs/pull/%s/head", matches[1])
+		} else if matches := compareRegex.FindStringSubmatch(projectPath); len(matches) > 0 {
+			gh := github.NewClient(url.Project.Host)
+			base, head := splitCompareRange(parseCompareRange(matches[1]))
+			if base == "" {
+				repo, err := gh.Repository(url.Project)
+				utils.Check(err)
+				base = repo.DefaultBranch
+			}
+			comparison, err := gh.CompareCommits(url.Project, base, head)
+			utils.Check(err)
+			shas = commitShas(comparison.Commits)
+			// the head can be a branch, a tag, or a SHA
+			project, refspec = compareHead(url.Project, matches[1])
 		}
 	} else {
 		ownerWithShaRegexp := regexp.MustCompile(fmt.Sprintf("^(%s)@(%s)$", OwnerRe, shaRe))
@@ -69,12 +105,17 @@ func transformCherryPickArgs(args *Args) {
 			utils.Check(mainProjectErr)
 			project = mainProject
 			project.Owner = matches[1]
-			sha = matches[2]
+			shas = []string{matches[2]}
 		}
 	}
 
 	if project != nil {
-		args.ReplaceParam(args.IndexOfParam(ref), sha)
+		if len(shas) == 0 {
+			utils.Check(fmt.Errorf("Aborted: no commits to cherry-pick from %s", ref))
+		}
+		idx := args.IndexOfParam(ref)
+		args.RemoveParam(idx)
+		args.InsertParam(idx, shas...)
 
 		tmpName := "_hub-cherry-pick"
 		remoteName := tmpName
@@ -96,3 +137,11 @@ func transformCherryPickArgs(args *Args) {
 		}
 	}
 }
+
+func commitShas(commits []github.ComparisonCommit) []string {
+	shas := []string{}
+	for _, commit := range commits {
+		shas = append(shas, commit.Sha)
+	}
+	return shas
+}
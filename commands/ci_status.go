@@ -1,9 +1,13 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/github/hub/git"
 	"github.com/github/hub/github"
@@ -12,13 +16,29 @@ import (
 )
 
 var cmdCiStatus = &Command{
-	Run:   ciStatus,
-	Usage: "ci-status [-v] [<COMMIT>]",
-	Long: `Display status of GitHub checks for a commit.
+	Run: ciStatus,
+	Usage: `
+ci-status [-v] [--output <FORMAT>] [-R <REPO>] [<COMMIT>]
+ci-status --annotations [--output <FORMAT>] [-R <REPO>] [<COMMIT>]
+ci-status --log <CHECK> [-R <REPO>] [<COMMIT>]
+ci-status --rerun-failed [-R <REPO>] [<COMMIT>]
+ci-status set --state <STATE> [--context <CONTEXT>] [--target-url <URL>] [-d <DESCRIPTION>] [-R <REPO>] [<COMMIT>]
+`,
+	Long: `Display status of GitHub checks for a commit, or publish a commit status.
+
+## Commands:
+
+With no arguments, show the combined status of <COMMIT>.
+
+	* _set_:
+		Create a commit status for <COMMIT>. A status with the same <CONTEXT> as an
+		earlier one replaces it.
 
 ## Options:
 	-v, --verbose
-		Print detailed report of all status checks and their URLs.
+		Print detailed report of all status checks and their URLs. Check runs are
+		also listed with how long they took, followed by their annotations, such
+		as the errors that a linter reported.
 
 	-f, --format <FORMAT>
 		Pretty print all status checks using <FORMAT> (implies '--verbose'). See the
@@ -33,10 +53,53 @@ var cmdCiStatus = &Command{
 
 		%t: name of the status check
 
+		%D: how long the check run took, e.g. "1m23s"
+
+	--output <FORMAT>
+		Print all status checks in a structured format instead: "json", "yaml",
+		"csv", or "tsv". Status checks have the fields "context", "state", and
+		"url". The exit status is the same as without this option.
+
+		With '--annotations', annotations have the fields "check", "path",
+		"line", "column", "level", and "message", and <FORMAT> can also be
+		"sarif" to produce a SARIF log with a run for each check.
+
+	--annotations
+		Print all annotations of check runs, such as the errors that a compiler or
+		linter reported, as "<PATH>:<LINE>:<COLUMN>: <LEVEL>: <MESSAGE>" lines.
+		Editors can load these to jump to each location, e.g. 'vim -q'.
+
+	--log <CHECK>
+		Print the log of the check run named <CHECK>. Logs are available for
+		checks that run on GitHub Actions.
+
+	--rerun-failed
+		Re-run the check runs of <COMMIT> that have failed, were cancelled, or
+		timed out.
+
 	--color[=<WHEN>]
 		Enable colored output even if stdout is not a terminal. <WHEN> can be one
 		of "always" (default for '--color'), "never", or "auto" (default).
 
+	-R, --repo <REPO>
+		Look up the status of <COMMIT> in <REPO>, given in "OWNER/NAME" format,
+		instead of the repository of the current directory. <COMMIT> must be
+		given, and is resolved by GitHub rather than by the local git repository.
+
+	--state <STATE>
+		With 'set', the state of the status: "pending", "success", "failure", or
+		"error".
+
+	--context <CONTEXT>
+		With 'set', the label that tells the status apart from those of other
+		systems, e.g. "deploy/prod" (default: "default").
+
+	--target-url <URL>
+		With 'set', the URL that the status links to, e.g. a build log.
+
+	-d, --description <DESCRIPTION>
+		With 'set', a short description of the status.
+
 	<COMMIT>
 		A commit SHA or branch name (default: "HEAD").
 
@@ -44,7 +107,15 @@ Possible outputs and exit statuses:
 
 - success, neutral: 0
 - failure, error, action_required, cancelled, timed_out: 1
-- pending: 2
+- pending: 8
+- no status: 9
+
+## Examples:
+		$ hub ci-status -v
+		$ vim -q <(hub ci-status --annotations)
+		$ hub ci-status --log "build (ubuntu-latest)" | less
+		$ hub ci-status --rerun-failed
+		$ hub ci-status set --state success --context deploy/prod --target-url https://example.com/deploys/42
 
 ## See also:
 
@@ -52,9 +123,22 @@ hub-pull-request(1), hub(1)
 `,
 }
 
+var cmdSetCiStatus = &Command{
+	Key: "set",
+	Run: setCiStatus,
+	KnownFlags: `
+		--state STATE
+		--context CONTEXT
+		--target-url URL
+		-d, --description DESCRIPTION
+		-R, --repo REPO
+`,
+}
+
 var severityList []string
 
 func init() {
+	cmdCiStatus.Use(cmdSetCiStatus)
 	CmdRunner.Use(cmdCiStatus)
 
 	severityList = []string{
@@ -78,31 +162,56 @@ func checkSeverity(targetState string) int {
 	return -1
 }
 
-func ciStatus(cmd *Command, args *Args) {
+// ciStatusCommit resolves the <COMMIT> argument of ci-status commands.
+func ciStatusCommit(args *Args) (project *github.Project, sha string) {
 	ref := "HEAD"
 	if !args.IsParamsEmpty() {
 		ref = args.RemoveParam(0)
 	}
 
-	localRepo, err := github.LocalRepo()
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
-	project, err := localRepo.MainProject()
-	utils.Check(err)
-
-	sha, err := git.Ref(ref)
-	if err != nil {
-		err = fmt.Errorf("Aborted: no revision could be determined from '%s'", ref)
+	sha = ref
+	if args.Flag.HasReceived("--repo") {
+		// without a local clone, GitHub resolves the revision
+		if ref == "HEAD" {
+			utils.Check(fmt.Errorf("Aborted: <COMMIT> is required with --repo"))
+		}
+	} else {
+		sha, err = git.Ref(ref)
+		if err != nil {
+			err = fmt.Errorf("Aborted: no revision could be determined from '%s'", ref)
+		}
+		utils.Check(err)
 	}
-	utils.Check(err)
+	return
+}
+
+func ciStatus(cmd *Command, args *Args) {
+	project, sha := ciStatusCommit(args)
 
 	if args.Noop {
-		ui.Printf("Would request CI status for %s\n", sha)
+		if args.Flag.Bool("--rerun-failed") {
+			ui.Printf("Would re-run failed checks for %s\n", sha)
+		} else {
+			ui.Printf("Would request CI status for %s\n", sha)
+		}
 	} else {
 		gh := github.NewClient(project.Host)
 		response, err := gh.FetchCIStatus(project, sha)
 		utils.Check(err)
 
+		if args.Flag.HasReceived("--log") {
+			args.NoForward()
+			printCheckRunLog(gh, project, response.Statuses, args.Flag.Value("--log"))
+			return
+		} else if args.Flag.Bool("--rerun-failed") {
+			args.NoForward()
+			rerunFailedChecks(gh, project, response.Statuses)
+			return
+		}
+
 		state := ""
 		if len(response.Statuses) > 0 {
 			for _, status := range response.Statuses {
@@ -117,17 +226,32 @@ func ciStatus(cmd *Command, args *Args) {
 		case "success", "neutral":
 			exitCode = 0
 		case "failure", "error", "action_required", "cancelled", "timed_out":
-			exitCode = 1
+			exitCode = utils.ExitFailure
 		case "pending":
-			exitCode = 2
+			exitCode = utils.ExitPending
 		default:
-			exitCode = 3
+			exitCode = utils.ExitNoChecks
 		}
 
 		verbose := args.Flag.Bool("--verbose") || args.Flag.HasReceived("--format")
-		if verbose && len(response.Statuses) > 0 {
+		if args.Flag.Bool("--annotations") {
+			printAnnotations(gh, project, response.Statuses, args.Flag.Value("--output"))
+		} else if args.Flag.HasReceived("--output") {
+			records := []ui.Record{}
+			for _, status := range response.Statuses {
+				records = append(records, ui.Record{}.
+					With("context", status.Context).
+					With("state", status.State).
+					With("url", status.TargetUrl))
+			}
+			printRecords(args.Flag.Value("--output"), records, nil)
+		} else if verbose && len(response.Statuses) > 0 {
 			colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
-			ciVerboseFormat(response.Statuses, args.Flag.Value("--format"), colorize)
+			annotations := map[*github.CheckRun][]github.CheckRunAnnotation{}
+			if !args.Flag.HasReceived("--format") {
+				annotations = fetchAnnotations(gh, project, response.Statuses)
+			}
+			ciVerboseFormat(response.Statuses, annotations, args.Flag.Value("--format"), colorize)
 		} else {
 			if state != "" {
 				ui.Println(state)
@@ -136,22 +260,94 @@ func ciStatus(cmd *Command, args *Args) {
 			}
 		}
 
-		os.Exit(exitCode)
+		ui.Exit(exitCode)
 	}
 }
 
-func ciVerboseFormat(statuses []github.CIStatus, formatString string, colorize bool) {
-	contextWidth := 0
+var commitStatusStates = []string{"pending", "success", "failure", "error"}
+
+func setCiStatus(cmd *Command, args *Args) {
+	state := args.Flag.Value("--state")
+	if state == "" || args.ParamsSize() > 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	validState := false
+	for _, s := range commitStatusStates {
+		validState = validState || s == state
+	}
+	if !validState {
+		utils.Check(fmt.Errorf("invalid state: '%s' (expected one of: %s)", state, strings.Join(commitStatusStates, ", ")))
+	}
+
+	project, sha := ciStatusCommit(args)
+
+	context := "default"
+	if args.Flag.HasReceived("--context") {
+		context = args.Flag.Value("--context")
+	}
+	params := map[string]interface{}{
+		"state":   state,
+		"context": context,
+	}
+	if args.Flag.HasReceived("--target-url") {
+		params["target_url"] = args.Flag.Value("--target-url")
+	}
+	if args.Flag.HasReceived("--description") {
+		params["description"] = args.Flag.Value("--description")
+	}
+
+	shortSha := sha
+	if len(shortSha) > 7 {
+		shortSha = shortSha[:7]
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set %s status of %s to %s for %s\n", context, shortSha, state, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	status, err := gh.CreateCIStatus(project, sha, params)
+	utils.Check(err)
+	ui.Infof("Set %s status of %s to %s\n", status.Context, shortSha, status.State)
+}
+
+// maxAnnotations is how many annotations of each check run are listed.
+const maxAnnotations = 10
+
+func fetchAnnotations(gh *github.Client, project *github.Project, statuses []github.CIStatus) map[*github.CheckRun][]github.CheckRunAnnotation {
+	annotations := map[*github.CheckRun][]github.CheckRunAnnotation{}
 	for _, status := range statuses {
-		if len(status.Context) > contextWidth {
-			contextWidth = len(status.Context)
+		if run := status.CheckRun; run != nil && run.Output.AnnotationsCount > 0 {
+			runAnnotations, err := gh.CheckRunAnnotations(project, run.Id, maxAnnotations)
+			utils.Check(err)
+			annotations[run] = runAnnotations
 		}
 	}
+	return annotations
+}
 
+func ciVerboseFormat(statuses []github.CIStatus, annotations map[*github.CheckRun][]github.CheckRunAnnotation, formatString string, colorize bool) {
 	sort.SliceStable(statuses, func(a, b int) bool {
 		return stateRank(statuses[a].State) < stateRank(statuses[b].State)
 	})
 
+	// the default format aligns the statuses in a table, annotations included
+	var table *ui.Table
+	if formatString == "" {
+		table = ui.NewTable(ui.TableColumn{}, ui.TableColumn{}, ui.TableColumn{})
+		table.Separator = "\t"
+		table.Colorize = colorize
+	}
+	printLine := func(line string) {
+		if table != nil {
+			table.AddLine(line)
+		} else {
+			ui.Println(line)
+		}
+	}
+
 	for _, status := range statuses {
 		var color int
 		var stateMarker string
@@ -170,26 +366,147 @@ func ciVerboseFormat(statuses []github.CIStatus, formatString string, colorize b
 			color = 33
 		}
 
-		placeholders := map[string]string{
-			"S":  status.State,
-			"sC": "",
-			"t":  status.Context,
-			"U":  status.TargetUrl,
+		duration := ""
+		if status.CheckRun != nil && status.CheckRun.Duration() > 0 {
+			duration = status.CheckRun.Duration().Round(time.Second).String()
 		}
 
-		if colorize {
-			placeholders["sC"] = fmt.Sprintf("\033[%dm", color)
+		if table != nil {
+			context, url := status.Context, status.TargetUrl
+			if duration != "" {
+				if url == "" {
+					context += fmt.Sprintf(" (%s)", duration)
+				} else {
+					url += fmt.Sprintf(" (%s)", duration)
+				}
+			}
+			table.AddRow(
+				ui.TableCell{Text: stateMarker, Color: strconv.Itoa(color)},
+				ui.TableCell{Text: context},
+				ui.TableCell{Text: url},
+			)
+		} else {
+			placeholders := map[string]string{
+				"S":  status.State,
+				"sC": "",
+				"t":  status.Context,
+				"U":  status.TargetUrl,
+				"D":  duration,
+			}
+			if colorize {
+				placeholders["sC"] = fmt.Sprintf("\033[%dm", color)
+			}
+			ui.Print(ui.Expand(formatString, placeholders, colorize))
 		}
 
-		format := formatString
-		if format == "" {
-			if status.TargetUrl == "" {
-				format = fmt.Sprintf("%%sC%s%%Creset\t%%t\n", stateMarker)
-			} else {
-				format = fmt.Sprintf("%%sC%s%%Creset\t%%<(%d)%%t\t%%U\n", stateMarker, contextWidth)
+		if status.CheckRun == nil {
+			continue
+		}
+		for _, annotation := range annotations[status.CheckRun] {
+			message := strings.SplitN(strings.TrimSpace(annotation.Message), "\n", 2)[0]
+			printLine(fmt.Sprintf("\t  %s:%d: %s: %s", annotation.Path, annotation.StartLine, annotation.AnnotationLevel, message))
+		}
+		if more := status.CheckRun.Output.AnnotationsCount - len(annotations[status.CheckRun]); more > 0 && len(annotations[status.CheckRun]) > 0 {
+			printLine(fmt.Sprintf("\t  (%d more %s)", more, pluralize(more, "annotation")))
+		}
+	}
+
+	if table != nil {
+		utils.Check(table.Render(ui.Stdout))
+	}
+}
+
+func printAnnotations(gh *github.Client, project *github.Project, statuses []github.CIStatus, format string) {
+	sarif := github.NewSarifLog()
+	records := []ui.Record{}
+
+	for _, status := range statuses {
+		run := status.CheckRun
+		if run == nil || run.Output.AnnotationsCount == 0 {
+			continue
+		}
+		annotations, err := gh.CheckRunAnnotations(project, run.Id, 0)
+		utils.Check(err)
+
+		results := []github.SarifResult{}
+		for _, annotation := range annotations {
+			column := annotation.StartColumn
+			if column == 0 {
+				column = 1
+			}
+			switch format {
+			case "":
+				message := strings.SplitN(strings.TrimSpace(annotation.Message), "\n", 2)[0]
+				ui.Printf("%s:%d:%d: %s: %s\n", annotation.Path, annotation.StartLine, column, annotation.AnnotationLevel, message)
+			case "sarif":
+				results = append(results, annotation.SarifResult())
+			default:
+				records = append(records, ui.Record{}.
+					With("check", run.Name).
+					With("path", annotation.Path).
+					With("line", annotation.StartLine).
+					With("column", column).
+					With("level", annotation.AnnotationLevel).
+					With("message", annotation.Message))
 			}
 		}
-		ui.Print(ui.Expand(format, placeholders, colorize))
+		sarif.AddRun(run.Name, results)
+	}
+
+	if format == "sarif" {
+		enc := json.NewEncoder(ui.Stdout)
+		enc.SetIndent("", "  ")
+		utils.Check(enc.Encode(sarif))
+	} else if format != "" {
+		printRecords(format, records, nil)
+	}
+}
+
+// findCheckRun looks up the check run named name among statuses. If it was
+// run several times, the latest run is returned.
+func findCheckRun(statuses []github.CIStatus, name string) *github.CheckRun {
+	var found *github.CheckRun
+	for _, status := range statuses {
+		if run := status.CheckRun; run != nil && strings.EqualFold(run.Name, name) {
+			if found == nil || run.Id > found.Id {
+				found = run
+			}
+		}
+	}
+	return found
+}
+
+func printCheckRunLog(gh *github.Client, project *github.Project, statuses []github.CIStatus, name string) {
+	run := findCheckRun(statuses, name)
+	if run == nil {
+		utils.Check(fmt.Errorf("Unable to find check `%s'", name))
+	}
+
+	log, err := gh.CheckRunLog(project, run)
+	utils.Check(err)
+	defer log.Close()
+	_, err = io.Copy(ui.Stdout, log)
+	utils.Check(err)
+}
+
+func rerunFailedChecks(gh *github.Client, project *github.Project, statuses []github.CIStatus) {
+	rerun := 0
+	for _, status := range statuses {
+		switch status.State {
+		case "failure", "error", "action_required", "cancelled", "timed_out":
+		default:
+			continue
+		}
+		if status.CheckRun == nil {
+			ui.Errorf("warning: can't re-run status `%s'\n", status.Context)
+			continue
+		}
+		utils.Check(gh.RerunCheckRun(project, status.CheckRun))
+		ui.Printf("Re-running %s\n", status.Context)
+		rerun++
+	}
+	if rerun == 0 {
+		ui.Println("No failed checks to re-run")
 	}
 }
 
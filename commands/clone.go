@@ -25,6 +25,12 @@ var cmdClone = &Command{
 	<DESTINATION>
 		Directory name to clone into (default: <REPOSITORY>).
 
+Other options, such as '--depth', '--filter=blob:none', or '--sparse' for
+shallow, partial, or sparse clones, are passed on to git-clone(1).
+
+When the repository is a fork, an "upstream" remote is added for its parent
+repository. Unless the clone is shallow or partial, the remote is also fetched.
+
 ## Protocol used for cloning
 
 The 'git:' protocol will be used for cloning public repositories, while the SSH
@@ -36,6 +42,10 @@ everything. See "HTTPS instead of git protocol" and "HUB_PROTOCOL" of hub(1).
 		$ hub clone rtomayko/ronn
 		> git clone git://github.com/rtomayko/ronn.git
 
+		$ hub clone --filter=blob:none mislav/ronn
+		> git clone --filter=blob:none git@github.com:mislav/ronn.git
+		> git -C ronn remote add upstream git://github.com/rtomayko/ronn.git
+
 ## See also:
 
 hub-fork(1), hub(1), git-clone(1)
@@ -59,6 +69,7 @@ func transformCloneArgs(args *Args) {
 	p := utils.NewArgsParser()
 	p.RegisterValue("--branch", "-b")
 	p.RegisterValue("--depth")
+	p.RegisterValue("--filter")
 	p.RegisterValue("--reference")
 	if args.Command == "submodule" {
 		p.RegisterValue("--name")
@@ -73,19 +84,47 @@ func transformCloneArgs(args *Args) {
 		p.RegisterValue("--template")
 		p.RegisterValue("--upload-pack", "-u")
 	}
+	p.RegisterBool("--bare")
+	p.RegisterBool("--mirror")
 	p.Parse(args.Params)
 
 	nameWithOwnerRegexp := regexp.MustCompile(NameWithOwnerRe)
 	for _, i := range p.PositionalIndices {
 		a := args.Params[i]
 		if nameWithOwnerRegexp.MatchString(a) && !isCloneable(a) {
-			url := getCloneUrl(a, isSSH, args.Command != "submodule")
+			url, project, repo := resolveCloneUrl(a, isSSH, args.Command != "submodule")
 			args.ReplaceParam(i, url)
+
+			// the parent's wiki isn't a remote of the fork's wiki
+			if args.Command == "clone" && !strings.HasSuffix(a, ".wiki") {
+				addUpstreamRemote(args, p, project, repo)
+			}
 		}
 		break
 	}
 }
 
+// addUpstreamRemote adds an "upstream" remote to the clone of a fork. The
+// remote isn't fetched for shallow or partial clones, since that would
+// download the full history of the parent.
+func addUpstreamRemote(args *Args, p *utils.ArgsParser, project *github.Project, repo *github.Repository) {
+	parent := repo.Parent
+	if parent == nil || parent.Owner == nil || p.Bool("--bare") || p.Bool("--mirror") || p.Value("--origin") == "upstream" {
+		return
+	}
+
+	dir := repo.Name
+	if len(p.PositionalIndices) > 1 {
+		dir = args.Params[p.PositionalIndices[1]]
+	}
+	upstreamURL := project.GitURL(parent.Name, parent.Owner.Login, parent.Private)
+	if p.Value("--depth") != "" || p.Value("--filter") != "" {
+		args.After("git", "-C", dir, "remote", "add", "upstream", upstreamURL)
+	} else {
+		args.After("git", "-C", dir, "remote", "add", "-f", "upstream", upstreamURL)
+	}
+}
+
 func parseClonePrivateFlag(args *Args) bool {
 	if i := args.IndexOfParam("-p"); i != -1 {
 		args.RemoveParam(i)
@@ -96,6 +135,13 @@ func parseClonePrivateFlag(args *Args) bool {
 }
 
 func getCloneUrl(nameWithOwner string, isSSH, allowSSH bool) string {
+	url, _, _ := resolveCloneUrl(nameWithOwner, isSSH, allowSSH)
+	return url
+}
+
+// resolveCloneUrl looks up the repository for a "[<OWNER>/]<REPO>" shorthand
+// and returns the URL to clone it from.
+func resolveCloneUrl(nameWithOwner string, isSSH, allowSSH bool) (string, *github.Project, *github.Repository) {
 	name := nameWithOwner
 	owner := ""
 	if strings.Contains(name, "/") {
@@ -146,11 +192,10 @@ func getCloneUrl(nameWithOwner string, isSSH, allowSSH bool) string {
 		}
 	}
 
-	if !isSSH &&
-		allowSSH &&
-		!github.IsHttpsProtocol() {
-		isSSH = repo.Private || repo.Permissions.Push
+	if !isSSH && !github.IsHttpsProtocol() {
+		// Private repositories can't be read over the git protocol
+		isSSH = repo.Private || allowSSH && repo.Permissions.Push
 	}
 
-	return project.GitURL(name, owner, isSSH)
+	return project.GitURL(name, owner, isSSH), project, repo
 }
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdCodeScanning = &Command{
+		Run: printHelp,
+		Usage: `
+code-scanning upload [-c <COMMIT>] [-r <REF>] <FILE>
+code-scanning alerts [-s <STATE>] [--severity <SEVERITY>] [--tool <NAME>] [-r <REF>] [-L <LIMIT>] [--output <FORMAT>]
+code-scanning dismiss --reason <REASON> [-m <COMMENT>] <NUMBER>
+`,
+		Long: `Integrate code analysis tools with GitHub code scanning.
+
+## Commands:
+
+	* _upload_:
+		Upload the results of a code analysis tool in the SARIF 2.1.0 format from
+		<FILE> and print the ID of the upload. Pass "-" to read the results from
+		standard input. GitHub processes the results in the background and then
+		raises alerts for the problems found.
+
+	* _alerts_:
+		List code scanning alerts as "#<NUMBER>  <SEVERITY>  <STATE>  <RULE>
+		(<PATH>:<LINE>)" lines.
+
+	* _dismiss_:
+		Close the alert <NUMBER> without fixing the problem.
+
+## Options:
+	-c, --commit <COMMIT>
+		The commit that the results of 'upload' are for (default: "HEAD").
+
+	-r, --ref <REF>
+		With 'upload', the full name of the branch or pull request ref that
+		<COMMIT> was analyzed on, e.g. "refs/heads/main" or "refs/pull/42/merge"
+		(default: the current branch). With 'alerts', only list the alerts for
+		<REF>.
+
+	-s, --state <STATE>
+		Only list alerts in <STATE>: "open", "dismissed", or "fixed".
+
+	--severity <SEVERITY>
+		Only list alerts of <SEVERITY>, such as "critical", "high", or "error".
+
+	--tool <NAME>
+		Only list alerts raised by the code analysis tool <NAME>.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> alerts.
+
+	--output <FORMAT>
+		Print the alerts in a structured format instead: "json", "yaml", "csv",
+		or "tsv". See hub-alerts(1) for the fields of alerts.
+
+	--reason <REASON>
+		Why the alert is dismissed: "false positive", "won't fix", or "used in
+		tests". Dashes can be used in place of spaces, e.g. "false-positive".
+
+	-m, --message <COMMENT>
+		Explain why the alert is dismissed.
+
+	-R, --repo <REPO>
+		Use <REPO>, given in "OWNER/NAME" format, instead of the repository of
+		the current directory. With 'upload', '--commit' and '--ref' are then
+		required.
+
+## Examples:
+		$ semgrep --sarif -o results.sarif && hub code-scanning upload results.sarif
+		$ hub code-scanning alerts --state open --tool semgrep
+		$ hub code-scanning dismiss --reason "used in tests" 12
+
+## See also:
+
+hub-alerts(1), hub-ci-status(1), hub(1)
+`,
+	}
+
+	cmdUploadSarif = &Command{
+		Key: "upload",
+		Run: uploadSarif,
+		KnownFlags: `
+		-c, --commit COMMIT
+		-r, --ref REF
+		-R, --repo REPO
+`,
+	}
+
+	cmdCodeScanningAlerts = &Command{
+		Key: "alerts",
+		Run: listCodeScanningAlerts,
+		KnownFlags: `
+		-s, --state STATE
+		--severity SEVERITY
+		--tool NAME
+		-r, --ref REF
+		-L, --limit N
+		--output FORMAT
+		-R, --repo REPO
+`,
+	}
+
+	cmdDismissCodeScanningAlert = &Command{
+		Key: "dismiss",
+		Run: dismissCodeScanningAlert,
+		KnownFlags: `
+		--reason REASON
+		-m, --message COMMENT
+		-R, --repo REPO
+`,
+	}
+)
+
+func init() {
+	cmdCodeScanning.Use(cmdUploadSarif)
+	cmdCodeScanning.Use(cmdCodeScanningAlerts)
+	cmdCodeScanning.Use(cmdDismissCodeScanningAlert)
+	CmdRunner.Use(cmdCodeScanning)
+}
+
+func uploadSarif(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	file := args.GetParam(0)
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	sha := args.Flag.Value("--commit")
+	ref := args.Flag.Value("--ref")
+	if args.Flag.HasReceived("--repo") {
+		if sha == "" || ref == "" {
+			utils.Check(fmt.Errorf("Aborted: --commit and --ref are required with --repo"))
+		}
+	} else {
+		if sha == "" {
+			sha = "HEAD"
+		}
+		sha, err = git.Ref(sha)
+		utils.Check(err)
+		if ref == "" {
+			localRepo, err := github.LocalRepo()
+			utils.Check(err)
+			branch, err := localRepo.CurrentBranch()
+			utils.Check(err)
+			ref = branch.Name
+		}
+	}
+	if !strings.HasPrefix(ref, "refs/") {
+		utils.Check(fmt.Errorf("invalid ref: '%s' (expected a full ref name such as refs/heads/%s)", ref, ref))
+	}
+
+	sarif, err := github.EncodeSarif(readFile(file))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would upload %s for %s (%s) to %s\n", file, sha, ref, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	id, err := gh.UploadSarif(project, sha, ref, sarif)
+	utils.Check(err)
+	ui.Println(id)
+}
+
+func listCodeScanningAlerts(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of code scanning alerts for %s\n", project)
+		return
+	}
+
+	filters := github.AlertFilters{
+		State:    args.Flag.Value("--state"),
+		Severity: args.Flag.Value("--severity"),
+		Tool:     args.Flag.Value("--tool"),
+		Ref:      args.Flag.Value("--ref"),
+	}
+	gh := github.NewClient(project.Host)
+	alerts, err := fetchSecurityAlerts(gh, project, "code-scanning", filters, args.Flag.Int("--limit"))
+	utils.Check(err)
+	printSecurityAlerts(alerts, false, args.Flag.Value("--output"))
+}
+
+func dismissCodeScanningAlert(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 || !args.Flag.HasReceived("--reason") {
+		utils.Check(cmd.UsageError(""))
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(args.GetParam(0), "#"))
+	if err != nil || number < 1 {
+		utils.Check(fmt.Errorf("invalid alert number: '%s'", args.GetParam(0)))
+	}
+
+	reason := strings.Replace(strings.ToLower(args.Flag.Value("--reason")), "-", " ", -1)
+	if reason == "wont fix" {
+		reason = "won't fix"
+	}
+	validReason := false
+	for _, r := range github.CodeScanningDismissReasons {
+		validReason = validReason || r == reason
+	}
+	if !validReason {
+		utils.Check(fmt.Errorf("invalid reason: '%s' (expected one of: %s)", args.Flag.Value("--reason"), strings.Join(github.CodeScanningDismissReasons, ", ")))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would dismiss code scanning alert #%d in %s as %s\n", number, project, reason)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	err = gh.DismissCodeScanningAlert(project, number, reason, args.Flag.Value("--message"))
+	utils.Check(err)
+	ui.Infof("Dismissed alert #%d as %s\n", number, reason)
+}
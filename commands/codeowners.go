@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var cmdCodeowners = &Command{
+	Run: codeowners,
+	Usage: `
+codeowners [--file <FILE>] <PATH>...
+codeowners --validate [--file <FILE>]
+`,
+	Long: `Show who owns files according to the CODEOWNERS file of the repository.
+
+The CODEOWNERS file is looked up in the same locations as on GitHub:
+".github/CODEOWNERS", "CODEOWNERS", and "docs/CODEOWNERS". Patterns are matched
+the way GitHub does: like in gitignore files, with the last matching pattern
+taking precedence, except that "!" negation and "[ ]" character ranges are
+not supported.
+
+## Options:
+	--validate
+		Report lines that GitHub ignores because of invalid syntax, and owners
+		that aren't existing users or teams. Exits with a non-zero status if any
+		problems were found.
+
+	--file <FILE>
+		Read the rules from <FILE> instead of the CODEOWNERS file in effect.
+
+	<PATH>
+		A file or directory in the working tree. Each <PATH> is printed along with
+		its owners.
+
+## Examples:
+		$ hub codeowners src/main.go docs/README.md
+		$ git diff --name-only main | xargs hub codeowners
+		$ hub codeowners --validate
+
+## See also:
+
+hub-pr(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdCodeowners)
+}
+
+func codeowners(command *Command, args *Args) {
+	validate := args.Flag.Bool("--validate")
+	if validate == !args.IsParamsEmpty() {
+		utils.Check(command.UsageError(""))
+	}
+
+	workdir, err := git.WorkdirName()
+	utils.Check(err)
+
+	file := args.Flag.Value("--file")
+	if file == "" {
+		file, err = github.FindCodeowners(workdir)
+		utils.Check(err)
+		file = filepath.Join(workdir, file)
+	}
+
+	f, err := os.Open(file)
+	utils.Check(err)
+	defer f.Close()
+	rules, err := github.ParseCodeowners(f)
+	utils.Check(err)
+
+	displayFile := file
+	if rel, err := filepath.Rel(workdir, file); err == nil && !strings.HasPrefix(rel, "..") {
+		displayFile = rel
+	}
+
+	args.NoForward()
+	if validate {
+		validateCodeowners(rules, displayFile)
+		return
+	}
+
+	cwd, err := os.Getwd()
+	utils.Check(err)
+	// the working tree path reported by git has symlinks resolved
+	if resolved, err := filepath.EvalSymlinks(cwd); err == nil {
+		cwd = resolved
+	}
+
+	paths := args.Params
+	pathWidth := 0
+	for _, path := range paths {
+		if len(path) > pathWidth {
+			pathWidth = len(path)
+		}
+	}
+	for _, path := range paths {
+		absPath := path
+		if !filepath.IsAbs(absPath) {
+			absPath = filepath.Join(cwd, path)
+		}
+		repoPath, err := filepath.Rel(workdir, absPath)
+		if err != nil || strings.HasPrefix(repoPath, "..") {
+			utils.Check(fmt.Errorf("%s is outside the repository", path))
+		}
+
+		owners := "(no owners)"
+		if rule := rules.Match(repoPath); rule != nil && len(rule.Owners) > 0 {
+			owners = strings.Join(rule.Owners, " ")
+		}
+		ui.Printf("%-*s  %s\n", pathWidth, path, owners)
+	}
+}
+
+func validateCodeowners(rules *github.Codeowners, file string) {
+	problems := append([]github.CodeownersError{}, rules.Errors...)
+
+	if owners := rules.Owners(); len(owners) > 0 {
+		project, err := resolveProject("")
+		utils.Check(err)
+		gh := github.NewClient(project.Host)
+
+		exists := map[string]bool{}
+		for _, owner := range owners {
+			ok, err := gh.OwnerExists(owner)
+			utils.Check(err)
+			exists[strings.ToLower(owner)] = ok
+		}
+		for _, rule := range rules.Rules {
+			for _, owner := range rule.Owners {
+				if ok, checked := exists[strings.ToLower(owner)]; checked && !ok {
+					problems = append(problems, github.CodeownersError{
+						Line:    rule.Line,
+						Message: fmt.Sprintf("unknown owner '%s'", owner),
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+	for _, problem := range problems {
+		ui.Errorf("%s:%d: %s\n", file, problem.Line, problem.Message)
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+	ui.Printf("%s: no problems found\n", file)
+}
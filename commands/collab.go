@@ -0,0 +1,228 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdCollab = &Command{
+		Run: printHelp,
+		Usage: `
+collab list [--permission <PERMISSION>] [-L <LIMIT>]
+collab add [--permission <PERMISSION>] <USER>...
+collab remove <USER>...
+collab check <USER>
+`,
+		Long: `Manage who has access to a repository.
+
+## Commands:
+
+	* _list_:
+		List collaborators as "<USER>  <ROLE>" lines. This includes the members
+		of the organization and of teams that have access to the repository.
+
+	* _add_:
+		Grant each <USER> access to the repository. Users who didn't have access
+		yet are sent an invitation, which they need to accept. For existing
+		collaborators, this changes their permission.
+
+	* _remove_:
+		Revoke the access of each <USER> as an outside collaborator.
+
+	* _check_:
+		Print the effective permission of <USER>: "admin", "maintain", "write",
+		"triage", "read", or "none".
+
+## Options:
+	--permission <PERMISSION>
+		One of "pull" (or "read"), "triage", "push" (or "write"), "maintain", or
+		"admin". With 'add', the permission to grant (default: "push"). With
+		'list', only list collaborators with at least <PERMISSION>.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> collaborators.
+
+	-R, --repo <REPO>
+		Manage the collaborators of <REPO>, given in "OWNER/NAME" format, instead
+		of the repository of the current directory.
+
+## Examples:
+		$ hub collab add --permission triage octocat
+		$ hub collab list --permission admin
+		$ hub collab check mislav
+
+## See also:
+
+hub-team(1), hub(1)
+`,
+	}
+
+	cmdListCollaborators = &Command{
+		Key: "list",
+		Run: listCollaborators,
+		KnownFlags: `
+		--permission PERMISSION
+		-L, --limit N
+		-R, --repo REPO
+`,
+	}
+
+	cmdAddCollaborator = &Command{
+		Key: "add",
+		Run: addCollaborators,
+		KnownFlags: `
+		--permission PERMISSION
+		-R, --repo REPO
+`,
+	}
+
+	cmdRemoveCollaborator = &Command{
+		Key: "remove",
+		Run: removeCollaborators,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+
+	cmdCheckCollaborator = &Command{
+		Key: "check",
+		Run: checkCollaborator,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+)
+
+func init() {
+	cmdCollab.Use(cmdListCollaborators)
+	cmdCollab.Use(cmdAddCollaborator)
+	cmdCollab.Use(cmdRemoveCollaborator)
+	cmdCollab.Use(cmdCheckCollaborator)
+	CmdRunner.Use(cmdCollab)
+}
+
+var permissionAliases = map[string]string{
+	"read":  "pull",
+	"write": "push",
+}
+
+// collabPermission validates the --permission flag, translating the names
+// shown in the web interface to those of the API.
+func collabPermission(args *Args, defaultPermission string) string {
+	if !args.Flag.HasReceived("--permission") {
+		return defaultPermission
+	}
+	permission := strings.ToLower(args.Flag.Value("--permission"))
+	if alias, ok := permissionAliases[permission]; ok {
+		permission = alias
+	}
+	for _, p := range github.CollaboratorPermissions {
+		if p == permission {
+			return permission
+		}
+	}
+	utils.Check(fmt.Errorf("invalid permission: '%s' (expected one of: %s)", args.Flag.Value("--permission"), strings.Join(github.CollaboratorPermissions, ", ")))
+	return ""
+}
+
+func listCollaborators(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	permission := collabPermission(args, "")
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of collaborators for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	collaborators, err := gh.Collaborators(project, permission, args.Flag.Int("--limit"))
+	utils.Check(err)
+
+	loginWidth := 0
+	for _, collaborator := range collaborators {
+		if len(collaborator.Login) > loginWidth {
+			loginWidth = len(collaborator.Login)
+		}
+	}
+	for _, collaborator := range collaborators {
+		ui.Printf("%-*s  %s\n", loginWidth, collaborator.Login, collaborator.RoleName)
+	}
+}
+
+func addCollaborators(cmd *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	permission := collabPermission(args, "push")
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	for _, user := range args.Params {
+		user = strings.TrimPrefix(user, "@")
+		if args.Noop {
+			ui.Printf("Would grant %s %s access to %s\n", user, permission, project)
+			continue
+		}
+
+		invited, err := gh.AddCollaborator(project, user, permission)
+		utils.Check(err)
+		if invited {
+			ui.Infof("Invited %s to %s with %s access\n", user, project, permission)
+		} else {
+			ui.Infof("Granted %s %s access to %s\n", user, permission, project)
+		}
+	}
+}
+
+func removeCollaborators(cmd *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	for _, user := range args.Params {
+		user = strings.TrimPrefix(user, "@")
+		if args.Noop {
+			ui.Printf("Would remove %s from %s\n", user, project)
+			continue
+		}
+
+		utils.Check(gh.RemoveCollaborator(project, user))
+		ui.Infof("Removed %s from %s\n", user, project)
+	}
+}
+
+func checkCollaborator(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	user := strings.TrimPrefix(args.GetParam(0), "@")
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would check the permission of %s on %s\n", user, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	permission, err := gh.CollaboratorPermission(project, user)
+	utils.Check(err)
+	ui.Println(permission)
+}
@@ -70,7 +70,7 @@ func (c *Command) parseArguments(args *Args) error {
 		args.Terminator = args.Flag.HasTerminated
 		return nil
 	} else {
-		return fmt.Errorf("%s\n%s", err, c.Synopsis())
+		return &usageError{fmt.Sprintf("%s\n%s", err, c.Synopsis())}
 	}
 }
 
@@ -87,7 +87,21 @@ func (c *Command) UsageError(msg string) error {
 	if msg != "" {
 		nl = "\n"
 	}
-	return fmt.Errorf("%s%s%s", msg, nl, c.Synopsis())
+	return &usageError{fmt.Sprintf("%s%s%s", msg, nl, c.Synopsis())}
+}
+
+// usageError reports that a command was invoked with the wrong arguments,
+// which hub exits from with a code of its own.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func (e *usageError) ExitCode() int {
+	return utils.ExitUsage
 }
 
 func (c *Command) Synopsis() string {
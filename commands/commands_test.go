@@ -84,6 +84,23 @@ func TestFlagsAfterArguments(t *testing.T) {
 	assert.Equal(t, "bar", args.LastParam())
 }
 
+func TestRepoFlag(t *testing.T) {
+	for _, c := range []*Command{cmdIssue, cmdShowIssue, cmdListPulls, cmdRelease, cmdDeleteRelease, cmdCiStatus} {
+		args := NewArgs([]string{"hub", "-R", "octocat/spoon-knife"})
+		err := c.parseArguments(args)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "octocat/spoon-knife", args.Flag.Value("--repo"))
+	}
+}
+
+func TestPullRequestDraftFlags(t *testing.T) {
+	args := NewArgs([]string{"pr", "--no-draft"})
+	err := cmdListPulls.parseArguments(args)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, args.Flag.Bool("--draft"))
+	assert.Equal(t, true, args.Flag.Bool("--no-draft"))
+}
+
 func TestCommandNameTakeKey(t *testing.T) {
 	c := &Command{Key: "bar", Usage: "foo -t -v --foo"}
 	assert.Equal(t, "bar", c.Name())
@@ -144,3 +161,9 @@ func Test_NameWithOwnerRe(t *testing.T) {
 	assert.Equal(t, false, re.MatchString("own_er/name"))
 	assert.Equal(t, false, re.MatchString("-owner/name"))
 }
+
+func TestSplitReviewers(t *testing.T) {
+	users, teams := splitReviewers([]string{"mislav", "github/hubbers", "josh"})
+	assert.Equal(t, []string{"mislav", "josh"}, users)
+	assert.Equal(t, []string{"hubbers"}, teams)
+}
@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var cmdCommunity = &Command{
+	Run:   community,
+	Usage: "community [-d <DATE>] [-l <LABEL>] [--json] [<OWNER>/<REPO>]",
+	Long: `Report on the community health of a repository.
+
+The report includes which community health files are present (code of
+conduct, contributing guidelines, issue and pull request templates, license,
+README), how many first-time contributors opened pull requests over a period,
+and how many open issues are available for newcomers.
+
+## Options:
+	-d, --since <DATE>
+		Count first-time contributors whose pull requests were opened on or after
+		<DATE> in ISO 8601 format (default: 90 days ago).
+
+	-l, --label <LABEL>
+		The label that marks issues suitable for newcomers (default: "good first
+		issue").
+
+	--json
+		Output the report as a JSON document instead of a table.
+
+	<OWNER>/<REPO>
+		The repository to report on (default: the current repository).
+
+## Examples:
+		$ hub community
+		$ hub community --since 2020-01-01 --json github/hub
+
+## See also:
+
+hub-issue(1), hub-pr(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdCommunity)
+}
+
+var communityFiles = []struct {
+	key   string
+	title string
+}{
+	{"readme", "README"},
+	{"code_of_conduct", "Code of conduct"},
+	{"contributing", "Contributing guidelines"},
+	{"issue_template", "Issue template"},
+	{"pull_request_template", "Pull request template"},
+	{"license", "License"},
+}
+
+type communityReport struct {
+	Repository            string          `json:"repository"`
+	HealthPercentage      int             `json:"health_percentage"`
+	Files                 map[string]bool `json:"files"`
+	Since                 string          `json:"since"`
+	FirstTimeContributors []string        `json:"first_time_contributors"`
+	GoodFirstIssueLabel   string          `json:"good_first_issue_label"`
+	GoodFirstIssues       int             `json:"good_first_issues"`
+}
+
+func community(cmd *Command, args *Args) {
+	nameWithOwner := ""
+	if !args.IsParamsEmpty() {
+		nameWithOwner = args.FirstParam()
+	}
+	project, err := resolveProject(nameWithOwner)
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	args.NoForward()
+
+	since := time.Now().AddDate(0, 0, -90).Format("2006-01-02")
+	if args.Flag.HasReceived("--since") {
+		since = args.Flag.Value("--since")
+	}
+	label := "good first issue"
+	if args.Flag.HasReceived("--label") {
+		label = args.Flag.Value("--label")
+	}
+
+	if args.Noop {
+		ui.Printf("Would request community report for %s\n", project)
+		return
+	}
+
+	report := communityReport{
+		Repository:          project.String(),
+		Files:               map[string]bool{},
+		Since:               since,
+		GoodFirstIssueLabel: label,
+	}
+
+	profile, err := gh.CommunityProfile(project)
+	utils.Check(err)
+	report.HealthPercentage = profile.HealthPercentage
+	for _, f := range communityFiles {
+		report.Files[f.key] = profile.Files[f.key] != nil
+	}
+
+	pulls, err := gh.SearchIssues(fmt.Sprintf("repo:%s type:pr created:>=%s", project, since), nil, 0)
+	utils.Check(err)
+	report.FirstTimeContributors = firstTimeContributors(pulls.Items)
+
+	goodFirstIssues, err := gh.SearchIssues(fmt.Sprintf("repo:%s type:issue state:open label:%q", project, label), nil, 1)
+	utils.Check(err)
+	report.GoodFirstIssues = goodFirstIssues.TotalCount
+
+	if args.Flag.Bool("--json") {
+		out, err := encodeJSON(report)
+		utils.Check(err)
+		ui.Println(string(out))
+		return
+	}
+
+	ui.Printf("Community report for %s\n\n", report.Repository)
+	ui.Printf("  %-26s %d%%\n", "Health", report.HealthPercentage)
+	for _, f := range communityFiles {
+		present := "missing"
+		if report.Files[f.key] {
+			present = "present"
+		}
+		ui.Printf("  %-26s %s\n", f.title, present)
+	}
+	ui.Printf("\n  %-26s %d\n", "First-time contributors", len(report.FirstTimeContributors))
+	if len(report.FirstTimeContributors) > 0 {
+		ui.Printf("  %-26s %s\n", "", strings.Join(report.FirstTimeContributors, ", "))
+	}
+	ui.Printf("  %-26s %d\n", fmt.Sprintf("Open %q issues", label), report.GoodFirstIssues)
+}
+
+// firstTimeContributors returns the sorted logins of pull request authors
+// that GitHub reports as never having contributed to the repository before.
+func firstTimeContributors(pulls []github.Issue) []string {
+	seen := map[string]bool{}
+	logins := []string{}
+	for _, pr := range pulls {
+		if pr.User == nil || seen[pr.User.Login] {
+			continue
+		}
+		switch pr.AuthorAssociation {
+		case "FIRST_TIMER", "FIRST_TIME_CONTRIBUTOR":
+			seen[pr.User.Login] = true
+			logins = append(logins, pr.User.Login)
+		}
+	}
+	sort.Strings(logins)
+	return logins
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
 )
 
@@ -15,8 +16,9 @@ var cmdCompare = &Command{
 	Usage: `
 compare [-uc] [-b <BASE>]
 compare [-uc] [<OWNER>] [<BASE>...]<HEAD>
+compare (--print [--stat]|--json) [-b <BASE>] [<OWNER>] [<BASE>...]<HEAD>
 `,
-	Long: `Open a GitHub compare page in a web browser.
+	Long: `Open a GitHub compare page in a web browser, or print the comparison.
 
 ## Options:
 	-u, --url
@@ -28,12 +30,25 @@ compare [-uc] [<OWNER>] [<BASE>...]<HEAD>
 	-b, --base <BASE>
 		Base branch to compare against in case no explicit arguments were given.
 
+	--print
+		Print the number of commits <HEAD> is ahead and behind of <BASE>,
+		followed by the list of commits, instead of opening the compare page.
+
+	--stat
+		With '--print', also list the changed files with the number of added and
+		removed lines.
+
+	--json
+		Print the comparison as a JSON document instead of opening the compare
+		page.
+
 	[<BASE>...]<HEAD>
 		Branch names, tag names, or commit SHAs specifying the range to compare.
 		If a range with two dots ('A..B') is given, it will be transformed into a
 		range with three dots.
 
 		The <BASE> portion defaults to the default branch of the repository.
+		Either side can be prefixed with "<USER>:" to compare across forks.
 
 		The <HEAD> argument defaults to the current branch. If the current branch
 		is not pushed to a remote, the command will error.
@@ -54,6 +69,10 @@ compare [-uc] [<OWNER>] [<BASE>...]<HEAD>
 		$ hub compare -u jingweno feature
 		https://github.com/jingweno/REPO/compare/feature
 
+		$ hub compare --print --stat mislav:master...jingweno:feature
+		Comparing mislav:master...jingweno:feature: 2 commits ahead, 0 behind
+		...
+
 ## See also:
 
 hub-browse(1), hub(1)
@@ -121,9 +140,36 @@ func compare(command *Command, args *Args) {
 		}
 	}
 
+	args.NoForward()
+
+	flagComparePrint := args.Flag.Bool("--print") || args.Flag.Bool("--stat")
+	flagCompareJSON := args.Flag.Bool("--json")
+	if flagComparePrint || flagCompareJSON {
+		base, head := splitCompareRange(r)
+		if base == "" {
+			base = localRepo.MasterBranch().ShortName()
+		}
+		if args.Noop {
+			ui.Printf("Would compare %s...%s in %s\n", base, head, mainProject)
+			return
+		}
+
+		gh := github.NewClientWithHost(host)
+		comparison, err := gh.CompareCommits(mainProject, base, head)
+		utils.Check(err)
+
+		if flagCompareJSON {
+			out, err := encodeJSON(comparison)
+			utils.Check(err)
+			ui.Println(string(out))
+		} else {
+			printComparison(fmt.Sprintf("%s...%s", base, head), comparison, args.Flag.Bool("--stat"))
+		}
+		return
+	}
+
 	url := mainProject.WebURL("", "", "compare/"+rangeQueryEscape(r))
 
-	args.NoForward()
 	flagCompareURLOnly := args.Flag.Bool("--url")
 	flagCompareCopy := args.Flag.Bool("--copy")
 	printBrowseOrCopy(args, url, !flagCompareURLOnly && !flagCompareCopy, flagCompareCopy)
@@ -136,6 +182,60 @@ func parseCompareRange(r string) string {
 	return shaOrTagRangeRegexp.ReplaceAllString(r, "$1...$2")
 }
 
+// splitCompareRange splits a "BASE...HEAD" range into its parts. The base is
+// empty if r names only the head.
+func splitCompareRange(r string) (base, head string) {
+	if parts := strings.SplitN(r, "...", 2); len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return "", r
+}
+
+func printComparison(r string, comparison *github.Comparison, stat bool) {
+	ui.Printf("Comparing %s: %d %s ahead, %d behind\n", r, comparison.AheadBy, pluralize(comparison.AheadBy, "commit"), comparison.BehindBy)
+	if len(comparison.Commits) > 0 {
+		ui.Println()
+	}
+	for _, commit := range comparison.Commits {
+		sha := commit.Sha
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		subject := strings.SplitN(commit.Commit.Message, "\n", 2)[0]
+		ui.Printf("%s %s (%s)\n", sha, subject, commit.Commit.Author.Name)
+	}
+
+	if !stat || len(comparison.Files) == 0 {
+		return
+	}
+
+	ui.Println()
+	nameWidth, maxChanges := 0, 0
+	additions, deletions := 0, 0
+	for _, file := range comparison.Files {
+		if len(file.Filename) > nameWidth {
+			nameWidth = len(file.Filename)
+		}
+		if file.Changes > maxChanges {
+			maxChanges = file.Changes
+		}
+		additions += file.Additions
+		deletions += file.Deletions
+	}
+	for _, file := range comparison.Files {
+		plus, minus := file.Additions, file.Deletions
+		if maxChanges > compareStatWidth {
+			plus = plus * compareStatWidth / maxChanges
+			minus = minus * compareStatWidth / maxChanges
+		}
+		ui.Printf(" %-*s | %d %s%s\n", nameWidth, file.Filename, file.Changes, strings.Repeat("+", plus), strings.Repeat("-", minus))
+	}
+	ui.Printf(" %d %s changed, %d insertions(+), %d deletions(-)\n", len(comparison.Files), pluralize(len(comparison.Files), "file"), additions, deletions)
+}
+
+// compareStatWidth is the maximum width of the +/- graph printed by '--stat'.
+const compareStatWidth = 40
+
 // characters we want to allow unencoded in compare views
 var compareUnescaper = strings.NewReplacer(
 	"%2F", "/",
@@ -12,3 +12,13 @@ func TestParseRange(t *testing.T) {
 	s = "1.0...2.0"
 	assert.Equal(t, "1.0...2.0", parseCompareRange(s))
 }
+
+func TestSplitCompareRange(t *testing.T) {
+	base, head := splitCompareRange("mislav:master...jingweno:feature")
+	assert.Equal(t, "mislav:master", base)
+	assert.Equal(t, "jingweno:feature", head)
+
+	base, head = splitCompareRange("feature")
+	assert.Equal(t, "", base)
+	assert.Equal(t, "feature", head)
+}
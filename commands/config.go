@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var cmdConfig = &Command{
+	Run:          configCmd,
+	GitExtension: true,
+	Usage: `
+config get [--host <HOST>] <KEY>
+config set [--host <HOST>] <KEY> <VALUE>
+config unset [--host <HOST>] <KEY>
+config list [--host <HOST>]
+`,
+	Long: `Read and write settings of hub.
+
+Settings are stored in the hub configuration file (usually "~/.config/hub")
+alongside the credentials for each host. Invocations that don't refer to a hub
+setting are passed through to git-config(1).
+
+## Commands:
+
+	* _get_:
+		Print the value of the setting <KEY> in effect for <HOST> (default: the
+		default host).
+
+	* _set_:
+		Change the setting <KEY> to <VALUE>.
+
+	* _unset_:
+		Remove the setting <KEY>.
+
+	* _list_:
+		Print all settings in effect as "<KEY>=<VALUE>" lines.
+
+## Options:
+
+	--host <HOST>
+		Read or write the setting only for <HOST>, which hub has to have
+		credentials for. Settings for a host take precedence over global ones.
+
+## Settings:
+
+	git_protocol
+		The protocol used for git remotes: "https", "ssh", or "git". The
+		HUB_PROTOCOL environment variable and the "hub.protocol" git config take
+		precedence.
+
+	default_host
+		The host to use outside of a git repository (default: "github.com"). The
+		GITHUB_HOST environment variable takes precedence. Can't be set per host.
+
+	editor
+		The text editor for composing messages (default: the git editor).
+
+	pager
+		The program used to page long output such as lists of issues and help
+		text (default: $PAGER, or else "less"). Set it to "cat" to turn paging
+		off. The '--no-pager' global flag turns it off for a single command.
+
+	color
+		When to color output: "always", "never", or "auto" (default). The '--color'
+		flag of individual commands takes precedence. The NO_COLOR environment
+		variable turns off "auto" coloring.
+
+	color.labels
+		How to color labels: "background" (default), "foreground", or "none".
+
+	color.open, color.closed, color.merged, color.draft
+		The color of issues and pull requests in that state: "black", "red",
+		"green", "yellow", "blue", "magenta", "cyan", "white", or "gray".
+
+	proxy
+		The URL of an "http", "https", or "socks5" proxy to reach the GitHub API
+		through. Without it, the HTTPS_PROXY and NO_PROXY environment variables
+		are honored.
+
+	ca_cert
+		The path to a PEM file with additional certificate authorities to trust,
+		e.g. the one of a corporate proxy intercepting TLS connections.
+
+	skip_tls_verify
+		Set to "true" to skip verifying the TLS certificate of the server.
+		Insecure; prefer 'ca_cert'.
+
+	aliases.<NAME>
+		An alias for a hub command.
+
+	filters.<NAME>
+		A saved issue or pull request list, run with 'hub query <NAME>'.
+
+## Examples:
+		$ hub config set git_protocol ssh
+		$ hub config set --host git.example.com editor nano
+		$ hub config set --host git.example.com ca_cert ~/corp-ca.pem
+		$ hub config get editor
+		$ hub config list
+
+## See also:
+
+hub(1), git-config(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdConfig)
+}
+
+func configCmd(command *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		return
+	}
+
+	p := utils.NewArgsParser()
+	p.RegisterValue("--host")
+	rest, err := p.Parse(args.Params[1:])
+	if err != nil {
+		return
+	}
+
+	action := args.FirstParam()
+	switch action {
+	case "get", "unset":
+		if len(rest) != 1 || !isHubSetting(rest[0]) {
+			return
+		}
+	case "set":
+		if len(rest) != 2 || !isHubSetting(rest[0]) {
+			return
+		}
+	case "list":
+		if len(rest) != 0 {
+			return
+		}
+	default:
+		return
+	}
+
+	args.NoForward()
+	host := p.Value("--host")
+	effectiveHost := host
+	if effectiveHost == "" {
+		effectiveHost = github.DefaultGitHubHost()
+	}
+	config := github.CurrentConfig()
+
+	switch action {
+	case "get":
+		value := config.Setting(effectiveHost, rest[0])
+		if value == "" {
+			utils.Check(fmt.Errorf("%s is not set", rest[0]))
+		}
+		ui.Println(value)
+	case "set":
+		if args.Noop {
+			ui.Printf("Would set %s to %q\n", rest[0], rest[1])
+			return
+		}
+		utils.Check(config.SetSetting(host, rest[0], rest[1]))
+		utils.Check(config.Save())
+	case "unset":
+		if args.Noop {
+			ui.Printf("Would unset %s\n", rest[0])
+			return
+		}
+		utils.Check(config.UnsetSetting(host, rest[0]))
+		utils.Check(config.Save())
+	case "list":
+		settings := config.EffectiveSettings(effectiveHost)
+		keys := []string{}
+		for key := range settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			ui.Printf("%s=%s\n", key, settings[key])
+		}
+	}
+}
+
+// isHubSetting tells settings of hub apart from git configuration keys. Git
+// keys always have a section, and git has no "aliases" or "filters" section.
+func isHubSetting(key string) bool {
+	if strings.HasPrefix(key, "aliases.") || strings.HasPrefix(key, "filters.") {
+		return true
+	}
+	for _, k := range github.SettingKeys() {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
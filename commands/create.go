@@ -2,7 +2,12 @@ package commands
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/github/hub/git"
 	"github.com/github/hub/github"
@@ -12,13 +17,17 @@ import (
 
 var cmdCreate = &Command{
 	Run:   create,
-	Usage: "create [-poc] [-d <DESCRIPTION>] [-h <HOMEPAGE>] [[<ORGANIZATION>/]<NAME>]",
+	Usage: "create [-poc] [--internal] [-d <DESCRIPTION>] [-h <HOMEPAGE>] [--template <TEMPLATE>] [--gitignore <TEMPLATE>] [--license <LICENSE>] [[<ORGANIZATION>/]<NAME>]",
 	Long: `Create a new repository on GitHub and add a git remote for it.
 
 ## Options:
 	-p, --private
 		Create a private repository.
 
+	--internal
+		Create a repository visible to all members of the enterprise that owns
+		<ORGANIZATION>.
+
 	-d, --description <DESCRIPTION>
 		A short description of the GitHub repository.
 
@@ -26,6 +35,19 @@ var cmdCreate = &Command{
 		A URL with more information about the repository. Use this, for example, if
 		your project has an external website.
 
+	--template <TEMPLATE>
+		Create the repository with the files and folders of the template
+		repository <TEMPLATE>, given in "<OWNER>/<REPO>" format.
+
+	--gitignore <TEMPLATE>
+		Write a ".gitignore" file from a GitHub template, such as "Go" or "Node",
+		and commit it locally.
+
+	--license <LICENSE>
+		Write a "LICENSE" file for a license keyword, such as "mit" or
+		"apache-2.0", and commit it locally. The copyright holder is taken from
+		the "user.name" git config.
+
 	--remote-name <REMOTE>
 		Set the name for the new git remote (default: "origin").
 
@@ -37,7 +59,7 @@ var cmdCreate = &Command{
 
 	[<ORGANIZATION>/]<NAME>
 		The name for the repository on GitHub (default: name of the current working
-		directory).
+		directory, or of the main working tree when in a linked git worktree).
 
 		Optionally, create the repository within <ORGANIZATION>.
 
@@ -50,6 +72,12 @@ var cmdCreate = &Command{
 		[ repo created in GitHub organization ]
 		> git remote add -f origin git@github.com:sinatra/recipes.git
 
+		$ hub create --gitignore Go --license mit
+		[ repo created on GitHub ]
+		> git remote add -f origin git@github.com:USER/REPO.git
+		> git add .gitignore LICENSE
+		> git commit -m "Add .gitignore and LICENSE" -- .gitignore LICENSE
+
 ## See also:
 
 hub-init(1), hub(1)
@@ -69,7 +97,7 @@ func create(command *Command, args *Args) {
 
 	var newRepoName string
 	if args.IsParamsEmpty() {
-		dirName, err := git.WorkdirName()
+		dirName, err := git.MainWorkdirName()
 		utils.Check(err)
 		newRepoName = github.SanitizeProjectName(dirName)
 	} else {
@@ -96,6 +124,19 @@ func create(command *Command, args *Args) {
 	gh := github.NewClient(project.Host)
 
 	flagCreatePrivate := args.Flag.Bool("--private")
+	flagCreateInternal := args.Flag.Bool("--internal")
+	if flagCreatePrivate && flagCreateInternal {
+		utils.Check(command.UsageError("--private and --internal are mutually exclusive"))
+	}
+
+	var templateProject *github.Project
+	if flagCreateTemplate := args.Flag.Value("--template"); flagCreateTemplate != "" {
+		templateProject, err = resolveProject(flagCreateTemplate)
+		utils.Check(err)
+		templateProject.Host = project.Host
+	}
+
+	starterFiles := starterFiles(gh, args, host)
 
 	repo, err := gh.Repository(project)
 	if err == nil {
@@ -117,10 +158,34 @@ func create(command *Command, args *Args) {
 
 	if repo == nil {
 		if !args.Noop {
-			flagCreateDescription := args.Flag.Value("--description")
-			flagCreateHomepage := args.Flag.Value("--homepage")
-			repo, err := gh.CreateRepository(project, flagCreateDescription, flagCreateHomepage, flagCreatePrivate)
-			utils.Check(err)
+			params := map[string]interface{}{
+				"description": args.Flag.Value("--description"),
+				"private":     flagCreatePrivate,
+			}
+			settings := map[string]interface{}{}
+			if flagCreateHomepage := args.Flag.Value("--homepage"); flagCreateHomepage != "" {
+				settings["homepage"] = flagCreateHomepage
+			}
+			if flagCreateInternal {
+				settings["visibility"] = "internal"
+			}
+
+			var repo *github.Repository
+			if templateProject != nil {
+				// generating from a template doesn't accept all settings up front
+				repo, err = gh.CreateRepositoryFromTemplate(templateProject, project, params)
+				utils.Check(err)
+				if len(settings) > 0 {
+					repo, err = gh.EditRepository(github.NewProject(repo.FullName, "", project.Host), settings)
+					utils.Check(err)
+				}
+			} else {
+				for key, value := range settings {
+					params[key] = value
+				}
+				repo, err = gh.CreateRepository(project, params)
+				utils.Check(err)
+			}
 			project = github.NewProject(repo.FullName, "", project.Host)
 		}
 	}
@@ -143,9 +208,69 @@ func create(command *Command, args *Args) {
 		args.Before("git", "remote", "add", "-f", originName, url)
 	}
 
+	if len(starterFiles) > 0 {
+		names := []string{}
+		for _, file := range starterFiles {
+			names = append(names, file.name)
+		}
+		for _, file := range starterFiles {
+			if args.Noop {
+				ui.Printf("Would write %s\n", file.name)
+			} else {
+				utils.Check(ioutil.WriteFile(file.name, []byte(file.contents), 0644))
+			}
+		}
+		args.Before(append([]string{"git", "add"}, names...)...)
+		message := fmt.Sprintf("Add %s", strings.Join(names, " and "))
+		args.Before(append([]string{"git", "commit", "-m", message, "--"}, names...)...)
+	}
+
 	webUrl := project.WebURL("", "", "")
 	args.NoForward()
 	flagCreateBrowse := args.Flag.Bool("--browse")
 	flagCreateCopy := args.Flag.Bool("--copy")
 	printBrowseOrCopy(args, webUrl, flagCreateBrowse, flagCreateCopy)
 }
+
+type starterFile struct {
+	name     string
+	contents string
+}
+
+// starterFiles fetches the files requested with '--gitignore' and '--license'
+// so that the new repository isn't created if any of them can't be written.
+func starterFiles(gh *github.Client, args *Args, host *github.Host) (files []starterFile) {
+	if template := args.Flag.Value("--gitignore"); template != "" {
+		source, err := gh.GitignoreTemplate(template)
+		utils.Check(err)
+		files = append(files, starterFile{".gitignore", source})
+	}
+
+	if key := args.Flag.Value("--license"); key != "" {
+		license, err := gh.License(key)
+		utils.Check(err)
+		fullname, _ := git.Config("user.name")
+		if fullname == "" {
+			fullname = host.User
+		}
+		body := strings.NewReplacer(
+			"[year]", strconv.Itoa(time.Now().Year()),
+			"[fullname]", fullname,
+		).Replace(license.Body)
+		files = append(files, starterFile{"LICENSE", body})
+	}
+
+	workdir, err := git.WorkdirName()
+	utils.Check(err)
+	cwd, err := os.Getwd()
+	utils.Check(err)
+	for i, file := range files {
+		if rel, err := filepath.Rel(cwd, filepath.Join(workdir, file.name)); err == nil {
+			files[i].name = rel
+		}
+		if _, err := os.Stat(files[i].name); err == nil {
+			utils.Check(fmt.Errorf("Aborted: %s already exists", file.name))
+		}
+	}
+	return
+}
@@ -2,9 +2,11 @@ package commands
 
 import (
 	"bufio"
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/github/hub/github"
@@ -13,8 +15,11 @@ import (
 )
 
 var cmdDelete = &Command{
-	Run:   deleteRepo,
-	Usage: "delete [-y] [<ORGANIZATION>/]<NAME>",
+	Run: deleteRepo,
+	Usage: `
+delete [-y] [--dry-run] [<ORGANIZATION>/]<NAME>
+delete --stdin (-y|--dry-run) [--resume]
+`,
 	Long: `Delete an existing repository on GitHub.
 
 ## Options:
@@ -22,9 +27,25 @@ var cmdDelete = &Command{
 	-y, --yes
 		Skip the confirmation prompt and immediately delete the repository.
 
+	--dry-run
+		Check that the repositories exist and list what would be deleted, without
+		deleting anything.
+
+	--stdin
+		Delete the repositories named on standard input, one per line. Empty lines
+		and lines starting with "#" are skipped. Requires '--yes' or '--dry-run',
+		since there is no way to confirm each deletion.
+
+	--resume
+		Continue an interrupted '--stdin' deletion of the same list of
+		repositories, skipping the ones that were already deleted.
+
 	[<ORGANIZATION>/]<NAME>
 		The name for the repository on GitHub.
 
+Without '--yes', hub asks to type the full "<OWNER>/<NAME>" of the repository
+to confirm the deletion.
+
 ## Examples:
 		$ hub delete recipes
 		[ personal repo deleted on GitHub ]
@@ -32,6 +53,9 @@ var cmdDelete = &Command{
 		$ hub delete sinatra/recipes
 		[ repo deleted in GitHub organization ]
 
+		$ hub delete --stdin --dry-run < old-forks.txt
+		$ hub delete --stdin --yes < old-forks.txt
+
 ## See also:
 
 hub-init(1), hub(1)
@@ -43,6 +67,20 @@ func init() {
 }
 
 func deleteRepo(command *Command, args *Args) {
+	dryRun := args.Flag.Bool("--dry-run")
+
+	if args.Flag.Bool("--stdin") {
+		if !args.IsParamsEmpty() {
+			utils.Check(command.UsageError(""))
+		}
+		if !args.Flag.Bool("--yes") && !dryRun {
+			utils.Check(fmt.Errorf("Aborted: --stdin requires --yes or --dry-run"))
+		}
+		deleteReposFromStdin(args, dryRun)
+		args.NoForward()
+		return
+	}
+
 	var repoName string
 	if !args.IsParamsEmpty() {
 		repoName = args.FirstParam()
@@ -53,6 +91,27 @@ func deleteRepo(command *Command, args *Args) {
 		utils.Check(command.UsageError(""))
 	}
 
+	project := deletionProject(repoName)
+
+	if !dryRun && !args.Flag.Bool("--yes") {
+		ui.Printf("Really delete repository '%s'? Type its full name to confirm: ", project)
+		answer := ""
+		scanner := bufio.NewScanner(os.Stdin)
+		if scanner.Scan() {
+			answer = strings.TrimSpace(scanner.Text())
+		}
+		utils.Check(scanner.Err())
+		if !strings.EqualFold(answer, project.String()) {
+			utils.Check(fmt.Errorf("Please type '%s' for confirmation.", project))
+		}
+	}
+
+	utils.Check(deleteRepository(args, project, dryRun))
+	args.NoForward()
+}
+
+// deletionProject resolves "[<OWNER>/]<NAME>" against the default host.
+func deletionProject(repoName string) *github.Project {
 	config := github.CurrentConfig()
 	host, err := config.DefaultHost()
 	if err != nil {
@@ -65,33 +124,87 @@ func deleteRepo(command *Command, args *Args) {
 		owner, repoName = split[0], split[1]
 	}
 
-	project := github.NewProject(owner, repoName, host.Host)
+	return github.NewProject(owner, repoName, host.Host)
+}
+
+func deleteRepository(args *Args, project *github.Project, dryRun bool) error {
 	gh := github.NewClient(project.Host)
 
-	if !args.Flag.Bool("--yes") {
-		ui.Printf("Really delete repository '%s' (yes/N)? ", project)
-		answer := ""
-		scanner := bufio.NewScanner(os.Stdin)
-		if scanner.Scan() {
-			answer = strings.TrimSpace(scanner.Text())
+	if dryRun {
+		repo, err := gh.Repository(project)
+		if err != nil {
+			if strings.Contains(err.Error(), "HTTP 404") {
+				err = fmt.Errorf("repository %s doesn't exist", project)
+			}
+			return err
 		}
-		utils.Check(scanner.Err())
-		if answer != "yes" {
-			utils.Check(fmt.Errorf("Please type 'yes' for confirmation."))
+		if repo.Parent != nil {
+			ui.Printf("Would delete repository '%s' (fork of '%s').\n", project, repo.Parent.FullName)
+		} else {
+			ui.Printf("Would delete repository '%s'.\n", project)
 		}
+		return nil
 	}
 
 	if args.Noop {
 		ui.Printf("Would delete repository '%s'.\n", project)
-	} else {
-		err = gh.DeleteRepository(project)
-		if err != nil && strings.Contains(err.Error(), "HTTP 403") {
-			ui.Errorf("Please edit the token used for hub at https://%s/settings/tokens\n", project.Host)
-			ui.Errorln("and verify that the `delete_repo` scope is enabled.")
+		return nil
+	}
+
+	err := gh.DeleteRepository(project)
+	if err != nil && strings.Contains(err.Error(), "HTTP 403") {
+		ui.Errorf("Please edit the token used for hub at https://%s/settings/tokens\n", project.Host)
+		ui.Errorln("and verify that the `delete_repo` scope is enabled.")
+	}
+	if err != nil {
+		return err
+	}
+	ui.Infof("Deleted repository '%s'.\n", project)
+	return nil
+}
+
+func deleteReposFromStdin(args *Args, dryRun bool) {
+	re := regexp.MustCompile(NameWithOwnerRe)
+	names := []string{}
+	scanner := bufio.NewScanner(os.Stdin)
+	for line := 1; scanner.Scan(); line++ {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+		if !re.MatchString(name) {
+			utils.Check(fmt.Errorf("invalid repository name on line %d: '%s'", line, name))
 		}
-		utils.Check(err)
-		ui.Printf("Deleted repository '%s'.\n", project)
+		names = append(names, name)
 	}
+	utils.Check(scanner.Err())
 
-	args.NoForward()
+	op := func(name string) error {
+		return deleteRepository(args, deletionProject(name), dryRun)
+	}
+
+	if dryRun || args.Noop {
+		failed := map[string]string{}
+		for _, name := range names {
+			if err := op(name); err != nil {
+				failed[name] = err.Error()
+			}
+		}
+		if len(failed) > 0 {
+			utils.Check(&github.BulkError{Failed: failed})
+		}
+		return
+	}
+
+	job := newBulkJob(args, bulkDeleteJobName(names))
+	utils.Check(job.Run(names, op))
+}
+
+// bulkDeleteJobName identifies a deletion by its list of repositories, so that
+// '--resume' never picks up the state of an unrelated deletion.
+func bulkDeleteJobName(names []string) string {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return fmt.Sprintf("delete-%x", sum[:8])
 }
@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestBulkDeleteJobName(t *testing.T) {
+	name := bulkDeleteJobName([]string{"old-fork", "our-org/old-repo"})
+	assert.Equal(t, name, bulkDeleteJobName([]string{"our-org/old-repo", "old-fork"}))
+	assert.NotEqual(t, name, bulkDeleteJobName([]string{"old-fork"}))
+	assert.NotEqual(t, name, bulkDeleteJobName([]string{"old-fork", "our-org/other-repo"}))
+}
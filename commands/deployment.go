@@ -0,0 +1,256 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdDeployment = &Command{
+		Run: printHelp,
+		Usage: `
+deployment list [-e <ENVIRONMENT>] [-L <LIMIT>]
+deployment create [-e <ENVIRONMENT>] [--ref <REF>] [-d <DESCRIPTION>] [--auto-merge] [--skip-checks]
+deployment status set [-d <DESCRIPTION>] [--log-url <URL>] [--environment-url <URL>] <ID> <STATE>
+`,
+		Long: `Create GitHub deployments and report on their progress.
+
+## Commands:
+
+	* _list_:
+		List deployments, most recent first, as "<ID>  <ENVIRONMENT>  <REF>
+		(<SHA>)  <DATE>" lines.
+
+	* _create_:
+		Create a deployment of <REF> to <ENVIRONMENT> and print its ID.
+
+	* _status set_:
+		Report the state of deployment <ID>. <STATE> is one of "queued",
+		"pending", "in_progress", "success", "failure", "error", or "inactive".
+
+## Options:
+	-e, --env <ENVIRONMENT>
+		The environment to deploy to (default: "production"). With 'list', only
+		list the deployments to <ENVIRONMENT>.
+
+	--ref <REF>
+		The branch, tag, or commit SHA to deploy (default: the current branch).
+
+	-d, --description <DESCRIPTION>
+		A short description of the deployment or of its status.
+
+	--auto-merge
+		Have GitHub merge the default branch into <REF> first if <REF> is behind
+		it. The deployment isn't created if a merge was necessary.
+
+	--skip-checks
+		Create the deployment even if the commit status checks of <REF> didn't
+		pass.
+
+	--log-url <URL>
+		The URL of the output of the deployment.
+
+	--environment-url <URL>
+		The URL for accessing the deployed environment.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> deployments.
+
+	-R, --repo <REPO>
+		Manage the deployments of <REPO>, given in "OWNER/NAME" format, instead
+		of the repository of the current directory.
+
+## Examples:
+		$ id=$(hub deployment create --env staging --ref "$GIT_SHA")
+		$ hub deployment status set "$id" in_progress --log-url "$BUILD_URL"
+		$ hub deployment status set "$id" success --environment-url https://staging.example.com
+		$ hub deployment list -e staging -L 5
+
+## See also:
+
+hub-ci-status(1), hub(1)
+`,
+	}
+
+	cmdListDeployments = &Command{
+		Key: "list",
+		Run: listDeployments,
+		KnownFlags: `
+		-e, --env ENVIRONMENT
+		-L, --limit N
+		-R, --repo REPO
+`,
+	}
+
+	cmdCreateDeployment = &Command{
+		Key: "create",
+		Run: createDeployment,
+		KnownFlags: `
+		-e, --env ENVIRONMENT
+		--ref REF
+		-d, --description DESCRIPTION
+		--auto-merge
+		--skip-checks
+		-R, --repo REPO
+`,
+	}
+
+	cmdDeploymentStatus = &Command{
+		Key: "status",
+		Run: setDeploymentStatus,
+		KnownFlags: `
+		-d, --description DESCRIPTION
+		--log-url URL
+		--environment-url URL
+		-R, --repo REPO
+`,
+	}
+)
+
+func init() {
+	cmdDeployment.Use(cmdListDeployments)
+	cmdDeployment.Use(cmdCreateDeployment)
+	cmdDeployment.Use(cmdDeploymentStatus)
+	CmdRunner.Use(cmdDeployment)
+}
+
+func listDeployments(cmd *Command, args *Args) {
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	environment := args.Flag.Value("--env")
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of deployments for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	deployments, err := gh.Deployments(project, environment, args.Flag.Int("--limit"))
+	utils.Check(err)
+
+	idWidth, envWidth, refWidth := 0, 0, 0
+	for _, deployment := range deployments {
+		if width := len(strconv.Itoa(deployment.Id)); width > idWidth {
+			idWidth = width
+		}
+		if len(deployment.Environment) > envWidth {
+			envWidth = len(deployment.Environment)
+		}
+		if len(deployment.Ref) > refWidth {
+			refWidth = len(deployment.Ref)
+		}
+	}
+	for _, deployment := range deployments {
+		sha := deployment.Sha
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		ui.Printf("%*d  %-*s  %-*s (%s)  %s\n", idWidth, deployment.Id, envWidth, deployment.Environment,
+			refWidth, deployment.Ref, sha, deployment.CreatedAt.Format("2006-01-02"))
+	}
+}
+
+func createDeployment(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	environment := args.Flag.Value("--env")
+	if environment == "" {
+		environment = "production"
+	}
+
+	ref := args.Flag.Value("--ref")
+	if ref == "" {
+		localRepo, err := github.LocalRepo()
+		utils.Check(err)
+		branch, err := localRepo.CurrentBranch()
+		if err != nil {
+			utils.Check(fmt.Errorf("Aborted: not currently on any branch; specify a ref to deploy with --ref"))
+		}
+		ref = branch.ShortName()
+	}
+
+	params := map[string]interface{}{
+		"ref":         ref,
+		"environment": environment,
+		"auto_merge":  args.Flag.Bool("--auto-merge"),
+	}
+	if args.Flag.HasReceived("--description") {
+		params["description"] = args.Flag.Value("--description")
+	}
+	if args.Flag.Bool("--skip-checks") {
+		params["required_contexts"] = []string{}
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create deployment of %s to %s for %s\n", ref, environment, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	deployment, err := gh.CreateDeployment(project, params)
+	utils.Check(err)
+	ui.Println(deployment.Id)
+}
+
+var deploymentStates = []string{"queued", "pending", "in_progress", "success", "failure", "error", "inactive"}
+
+func setDeploymentStatus(cmd *Command, args *Args) {
+	if args.ParamsSize() != 3 || args.GetParam(0) != "set" {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	id, err := strconv.Atoi(args.GetParam(1))
+	if err != nil {
+		utils.Check(fmt.Errorf("invalid deployment ID: '%s'", args.GetParam(1)))
+	}
+
+	state := args.GetParam(2)
+	validState := false
+	for _, s := range deploymentStates {
+		if s == state {
+			validState = true
+		}
+	}
+	if !validState {
+		utils.Check(fmt.Errorf("invalid state: '%s' (expected one of: %s)", state, strings.Join(deploymentStates, ", ")))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	params := map[string]interface{}{
+		"state": state,
+	}
+	for flag, param := range map[string]string{
+		"--description":     "description",
+		"--log-url":         "log_url",
+		"--environment-url": "environment_url",
+	} {
+		if args.Flag.HasReceived(flag) {
+			params[param] = args.Flag.Value(flag)
+		}
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set status of deployment %d to %s for %s\n", id, state, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	_, err = gh.CreateDeploymentStatus(project, id, params)
+	utils.Check(err)
+	ui.Printf("Deployment %d: %s\n", id, state)
+}
@@ -0,0 +1,354 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdDiscussion = &Command{
+		Run: printHelp,
+		Usage: `
+discussion list [--category <CATEGORY>] [-L <LIMIT>]
+discussion show [-u] [--raw] <NUMBER>
+discussion create --category <CATEGORY> [-oc] [-m <MESSAGE>|-F <FILE>] [--edit]
+discussion answer <NUMBER> <COMMENT>
+`,
+		Long: `Manage GitHub Discussions for the current repository.
+
+## Commands:
+
+	* _list_:
+		List discussions, most recently updated first, as "#<NUMBER>  <TITLE>
+		(<CATEGORY>)" lines. Answered discussions are marked with "[answered]".
+
+	* _show_:
+		Show a discussion with its comments. Each comment is listed with the
+		<COMMENT> ID to pass to _answer_.
+
+	* _create_:
+		Start a new discussion in <CATEGORY>.
+
+	* _answer_:
+		Mark <COMMENT> as the answer to discussion <NUMBER>. <COMMENT> is the ID
+		shown by _show_, or the URL of the comment. The discussion has to be in a
+		category that accepts answers, such as "Q&A".
+
+## Options:
+	--category <CATEGORY>
+		The name of a discussion category, e.g. "Q&A" or "Ideas". With 'list',
+		only list the discussions in <CATEGORY>.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> discussions.
+
+	-u, --url
+		With 'show', print the URL of the discussion instead of its contents.
+
+	--raw
+		With 'show', print the markdown of the discussion and its comments as
+		is. By default, markdown is formatted for reading when printing to a
+		terminal.
+
+	-m, --message <MESSAGE>
+		The text up to the first blank line in <MESSAGE> is treated as the
+		discussion title, and the rest is used as the body in Markdown format.
+
+		When multiple '--message' are passed, their values are concatenated with a
+		blank line in-between.
+
+		When neither '--message' nor '--file' were supplied, a text editor will
+		open to author the title and body in.
+
+	-F, --file <FILE>
+		Read the discussion title and body from <FILE>. Pass "-" to read from
+		standard input instead. See '--message' for the formatting rules.
+
+	-e, --edit
+		Further edit the contents of <FILE> or <MESSAGE> in a text editor before
+		submitting.
+
+	-o, --browse
+		Open the new discussion in a web browser.
+
+	-c, --copy
+		Put the URL of the new discussion to clipboard instead of printing it.
+
+	-R, --repo <REPO>
+		Manage the discussions of <REPO>, given in "OWNER/NAME" format, instead
+		of the repository of the current directory.
+
+## Examples:
+		$ hub discussion list --category "Q&A"
+		$ hub discussion create --category Ideas -m "Support for plugins"
+		$ hub discussion show 42
+		$ hub discussion answer 42 1234567
+
+## See also:
+
+hub-issue(1), hub(1)
+`,
+	}
+
+	cmdListDiscussions = &Command{
+		Key: "list",
+		Run: listDiscussions,
+		KnownFlags: `
+		--category CATEGORY
+		-L, --limit N
+		-R, --repo REPO
+`,
+	}
+
+	cmdShowDiscussion = &Command{
+		Key: "show",
+		Run: showDiscussion,
+		KnownFlags: `
+		-u, --url
+		--raw
+		-R, --repo REPO
+`,
+	}
+
+	cmdCreateDiscussion = &Command{
+		Key: "create",
+		Run: createDiscussion,
+		KnownFlags: `
+		--category CATEGORY
+		-m, --message MSG
+		-F, --file FILE
+		-e, --edit
+		-o, --browse
+		-c, --copy
+		-R, --repo REPO
+`,
+	}
+
+	cmdAnswerDiscussion = &Command{
+		Key: "answer",
+		Run: answerDiscussion,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+)
+
+func init() {
+	cmdDiscussion.Use(cmdListDiscussions)
+	cmdDiscussion.Use(cmdShowDiscussion)
+	cmdDiscussion.Use(cmdCreateDiscussion)
+	cmdDiscussion.Use(cmdAnswerDiscussion)
+	CmdRunner.Use(cmdDiscussion)
+}
+
+// discussionNumberFromArgs parses the <NUMBER> parameter of discussion
+// subcommands.
+func discussionNumberFromArgs(cmd *Command, args *Args) int {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(args.GetParam(0), "#"))
+	if err != nil || number < 1 {
+		utils.Check(fmt.Errorf("invalid discussion number: '%s'", args.GetParam(0)))
+	}
+	return number
+}
+
+func listDiscussions(cmd *Command, args *Args) {
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of discussions for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+
+	categoryId := ""
+	if args.Flag.HasReceived("--category") {
+		categories, _, err := gh.DiscussionCategories(project)
+		utils.Check(err)
+		category, err := github.FindDiscussionCategory(categories, args.Flag.Value("--category"))
+		utils.Check(err)
+		categoryId = category.Id
+	}
+
+	discussions, err := gh.Discussions(project, categoryId, args.Flag.Int("--limit"))
+	utils.Check(err)
+
+	numWidth := 0
+	for _, discussion := range discussions {
+		if width := len(strconv.Itoa(discussion.Number)) + 1; width > numWidth {
+			numWidth = width
+		}
+	}
+	for _, discussion := range discussions {
+		answered := ""
+		if discussion.Answer != nil {
+			answered = " [answered]"
+		}
+		ui.Printf("%*s  %s  (%s)%s\n", numWidth, fmt.Sprintf("#%d", discussion.Number), discussion.Title, discussion.Category.Name, answered)
+	}
+}
+
+func showDiscussion(cmd *Command, args *Args) {
+	number := discussionNumberFromArgs(cmd, args)
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Flag.Bool("--url") {
+		ui.Println(project.WebURL("", "", fmt.Sprintf("discussions/%d", number)))
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	discussion, err := gh.FetchDiscussion(project, number)
+	utils.Check(err)
+
+	colorize := colorizeOutput(false, "")
+	ui.Printf("# %s\n\n", discussion.Title)
+	ui.Printf("* created by @%s on %s\n", discussionAuthor(discussion.Author), discussion.CreatedAt.String())
+	ui.Printf("* category: %s\n", discussion.Category.Name)
+	if discussion.Answer != nil {
+		ui.Printf("* answer: %d\n", discussion.Answer.DatabaseId)
+	}
+	ui.Printf("\n%s\n", formatBody(args, discussion.Body, colorize))
+
+	if len(discussion.Comments.Nodes) == 0 {
+		return
+	}
+	ui.Printf("\n## Comments:\n")
+	for _, comment := range discussion.Comments.Nodes {
+		answer := ""
+		if comment.IsAnswer {
+			answer = " (answer)"
+		}
+		ui.Printf("\n### comment %d by @%s on %s%s\n\n%s\n", comment.DatabaseId, discussionAuthor(comment.Author), comment.CreatedAt.String(), answer, formatBody(args, comment.Body, colorize))
+	}
+	if more := discussion.Comments.TotalCount - len(discussion.Comments.Nodes); more > 0 {
+		ui.Printf("\n(%d more comments at %s)\n", more, discussion.URL)
+	}
+}
+
+// discussionAuthor is the login of a discussion or comment author, whose
+// account might have been deleted.
+func discussionAuthor(user *github.User) string {
+	if user == nil {
+		return "ghost"
+	}
+	return user.Login
+}
+
+func createDiscussion(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	if !args.Flag.HasReceived("--category") {
+		utils.Check(cmd.UsageError("a category is required"))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	categories, repositoryId, err := gh.DiscussionCategories(project)
+	utils.Check(err)
+	category, err := github.FindDiscussionCategory(categories, args.Flag.Value("--category"))
+	utils.Check(err)
+
+	messageBuilder := &github.MessageBuilder{
+		Filename: "DISCUSSION_EDITMSG",
+		Title:    "discussion",
+	}
+
+	messageBuilder.AddCommentedSection(fmt.Sprintf(`Starting a discussion in %s for %s
+
+Write a message for this discussion. The first block of
+text is the title and the rest is the body.`, category.Name, project))
+
+	flagDiscussionMessage := args.Flag.AllValues("--message")
+	if len(flagDiscussionMessage) > 0 {
+		messageBuilder.Message = strings.Join(flagDiscussionMessage, "\n\n")
+		messageBuilder.Edit = args.Flag.Bool("--edit")
+	} else if args.Flag.HasReceived("--file") {
+		messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
+		utils.Check(err)
+		messageBuilder.Edit = args.Flag.Bool("--edit")
+	} else {
+		messageBuilder.Edit = true
+	}
+
+	title, body, err := messageBuilder.Extract()
+	utils.Check(err)
+
+	if title == "" {
+		utils.Check(fmt.Errorf("Aborting discussion due to empty title"))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create discussion `%s' in %s for %s\n", title, category.Name, project)
+	} else {
+		discussion, err := gh.CreateDiscussion(repositoryId, category, title, body)
+		utils.Check(err)
+		printBrowseOrCopy(args, discussion.URL, args.Flag.Bool("--browse"), args.Flag.Bool("--copy"))
+	}
+
+	messageBuilder.Cleanup()
+}
+
+var discussionCommentURLRe = regexp.MustCompile(`#discussioncomment-(\d+)$`)
+
+func answerDiscussion(cmd *Command, args *Args) {
+	if args.ParamsSize() != 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+	number := discussionNumberFromArgs(cmd, args)
+
+	commentArg := args.GetParam(1)
+	if m := discussionCommentURLRe.FindStringSubmatch(commentArg); m != nil {
+		commentArg = m[1]
+	}
+	commentId, err := strconv.Atoi(commentArg)
+	if err != nil {
+		utils.Check(fmt.Errorf("invalid comment: '%s'", args.GetParam(1)))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would mark comment %d as the answer to discussion #%d for %s\n", commentId, number, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	discussion, err := gh.FetchDiscussion(project, number)
+	utils.Check(err)
+	if !discussion.Category.IsAnswerable {
+		utils.Check(fmt.Errorf("Discussions in %s can't be answered", discussion.Category.Name))
+	}
+
+	var comment *github.DiscussionComment
+	for i, c := range discussion.Comments.Nodes {
+		if c.DatabaseId == commentId {
+			comment = &discussion.Comments.Nodes[i]
+		}
+	}
+	if comment == nil {
+		utils.Check(fmt.Errorf("Unable to find comment %d in discussion #%d", commentId, number))
+	}
+
+	utils.Check(gh.MarkDiscussionCommentAsAnswer(comment.Id))
+	ui.Infof("Marked comment %d as the answer to discussion #%d\n", commentId, number)
+}
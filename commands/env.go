@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdEnv = &Command{
+		Run: printHelp,
+		Usage: `
+env create [--ref <BRANCH>|--pr <NUMBER>] [--machine <TYPE>] [-R <REPO>]
+env list [-R <REPO>]
+env delete [-R <REPO>] <NAME>...
+`,
+		Long: `Manage disposable development environments for a repository.
+
+Environments are GitHub Codespaces by default. Connecting to a codespace over
+SSH takes the GitHub CLI, and 'env create' prints the command for it.
+
+## Commands:
+
+	* _create_:
+		Create an environment for <BRANCH>, or for the head branch of the pull
+		request <NUMBER> to review it, and print its name along with how to
+		connect to it.
+
+	* _list_:
+		List your environments for the repository as
+		"<NAME>  <STATE>  <BRANCH>" lines.
+
+	* _delete_:
+		Delete each environment <NAME>.
+
+## Options:
+	--ref <BRANCH>
+		The branch to check out in the environment (default: the default branch
+		of the repository).
+
+	--pr <NUMBER>
+		Check out the head branch of pull request <NUMBER>, which may come from a
+		fork.
+
+	--machine <TYPE>
+		The machine type of the environment, such as "basicLinux32gb" (default:
+		chosen by GitHub).
+
+	-R, --repo <REPO>
+		The repository in "<OWNER>/<REPO>" format (default: the current
+		repository).
+
+## Configuration:
+
+	* 'hub.envBackend':
+		An executable to manage environments instead of GitHub Codespaces. hub
+		runs it with the same command and options, such as
+		"<BACKEND> create --ref <BRANCH>", and with the HUB_REPO, HUB_HOST, and
+		HUB_TOKEN environment variables that extensions get; see
+		hub-extension(1).
+
+## Examples:
+		$ hub env create --pr 123
+		$ hub env list
+		$ git config hub.envBackend hub-env-docker
+
+## See also:
+
+hub-pr(1), hub-extension(1), hub(1)
+`,
+	}
+
+	cmdEnvCreate = &Command{
+		Key: "create",
+		Run: createEnv,
+		KnownFlags: `
+		--ref BRANCH
+		--pr NUMBER
+		--machine TYPE
+		-R, --repo REPO
+`,
+	}
+
+	cmdEnvList = &Command{
+		Key: "list",
+		Run: listEnvs,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+
+	cmdEnvDelete = &Command{
+		Key: "delete",
+		Run: deleteEnvs,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+)
+
+func init() {
+	cmdEnv.Use(cmdEnvCreate)
+	cmdEnv.Use(cmdEnvList)
+	cmdEnv.Use(cmdEnvDelete)
+	CmdRunner.Use(cmdEnv)
+}
+
+// runEnvBackend hands the command over to the executable configured in
+// hub.envBackend, if any, and reports whether it did.
+func runEnvBackend(project *github.Project, params []string, noop bool) bool {
+	backend, err := git.Config("hub.envBackend")
+	if err != nil || backend == "" {
+		return false
+	}
+	path, err := exec.LookPath(backend)
+	if err != nil {
+		utils.Check(fmt.Errorf("could not find the environment backend '%s' (configured in hub.envBackend)", backend))
+	}
+	utils.Check(runExtensionFor(path, project, params, noop))
+	return true
+}
+
+func createEnv(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	ref := args.Flag.Value("--ref")
+	prNumber := 0
+	if args.Flag.HasReceived("--pr") {
+		if ref != "" {
+			utils.Check(cmd.UsageError("--ref and --pr can't be used together"))
+		}
+		var err error
+		prNumber, err = strconv.Atoi(args.Flag.Value("--pr"))
+		if err != nil || prNumber < 1 {
+			utils.Check(fmt.Errorf("invalid pull request number: '%s'", args.Flag.Value("--pr")))
+		}
+	}
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	params := []string{cmd.Key}
+	for _, flag := range []string{"--ref", "--pr", "--machine"} {
+		if args.Flag.HasReceived(flag) {
+			params = append(params, flag, args.Flag.Value(flag))
+		}
+	}
+	if runEnvBackend(project, params, args.Noop) {
+		return
+	}
+
+	if args.Noop {
+		if prNumber > 0 {
+			ui.Printf("Would create a codespace for pull request #%d of %s\n", prNumber, project)
+		} else {
+			ui.Printf("Would create a codespace for %s\n", project)
+		}
+		return
+	}
+
+	codespaceParams := map[string]interface{}{}
+	if args.Flag.HasReceived("--machine") {
+		codespaceParams["machine"] = args.Flag.Value("--machine")
+	}
+	gh := github.NewClient(project.Host)
+	var codespace *github.Codespace
+	if prNumber > 0 {
+		codespace, err = gh.CreatePullRequestCodespace(project, prNumber, codespaceParams)
+	} else {
+		if ref != "" {
+			codespaceParams["ref"] = ref
+		}
+		codespace, err = gh.CreateCodespace(project, codespaceParams)
+	}
+	utils.Check(err)
+
+	ui.Println(codespace.Name)
+	ui.Errorf("Open it in the browser: %s\n", codespace.WebUrl)
+	ui.Errorf("Connect over SSH: gh codespace ssh --codespace %s\n", codespace.Name)
+}
+
+func listEnvs(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if runEnvBackend(project, []string{cmd.Key}, args.Noop) {
+		return
+	}
+	if args.Noop {
+		ui.Printf("Would request list of codespaces for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	codespaces, err := gh.Codespaces(project)
+	utils.Check(err)
+
+	table := ui.NewTable(ui.TableColumn{}, ui.TableColumn{}, ui.TableColumn{})
+	for _, codespace := range codespaces {
+		table.AddRow(
+			ui.TableCell{Text: codespace.Name},
+			ui.TableCell{Text: codespace.State},
+			ui.TableCell{Text: codespace.GitStatus.Ref},
+		)
+	}
+	utils.Check(table.Render(ui.Stdout))
+}
+
+func deleteEnvs(cmd *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	// codespaces are deleted by name, so this works outside of a repository
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	if args.Flag.HasReceived("--repo") {
+		utils.Check(err)
+	}
+
+	args.NoForward()
+	if runEnvBackend(project, append([]string{cmd.Key}, args.Params...), args.Noop) {
+		return
+	}
+
+	var gh *github.Client
+	if project != nil {
+		gh = github.NewClient(project.Host)
+	} else {
+		gh = accountClient("deleting codespace")
+	}
+	for _, name := range args.Params {
+		if args.Noop {
+			ui.Printf("Would delete codespace %s\n", name)
+			continue
+		}
+		utils.Check(gh.DeleteCodespace(name))
+		ui.Printf("Deleted codespace %s\n", name)
+	}
+}
@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/hub/cmd"
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+	"github.com/mitchellh/go-homedir"
+)
+
+var (
+	cmdExtension = &Command{
+		Run: listExtensions,
+		Usage: `
+extension [list]
+extension install <OWNER>/<REPO>
+extension upgrade <NAME>
+extension remove <NAME>
+`,
+		Long: `Manage extensions that add commands to hub.
+
+An extension is an executable named "hub-<NAME>". Running 'hub <NAME>' runs
+the extension if <NAME> isn't a command of hub itself. Extensions are looked
+up among installed ones first, then on PATH.
+
+Extensions are run with these environment variables set:
+
+	HUB_HOST
+		The GitHub host of the current repository or the default host.
+
+	HUB_REPO
+		The "<OWNER>/<REPO>" of the current repository, if any.
+
+	HUB_TOKEN
+		The OAuth token for HUB_HOST, if hub has one.
+
+## Commands:
+
+With no arguments, list installed extensions.
+
+	* _install_:
+		Clone the GitHub repository <OWNER>/<REPO> to the extensions directory.
+		The repository name has to start with "hub-" and contain an executable
+		of the same name.
+
+	* _upgrade_:
+		Pull the latest changes of the installed extension <NAME>.
+
+	* _remove_:
+		Delete the installed extension <NAME>.
+
+## Files:
+
+Extensions are installed to "$XDG_DATA_HOME/hub/extensions" (default:
+"~/.local/share/hub/extensions").
+
+## Examples:
+		$ hub extension install mislav/hub-triage
+		$ hub triage
+
+## See also:
+
+hub(1)
+`,
+	}
+
+	cmdInstallExtension = &Command{
+		Key: "install",
+		Run: installExtension,
+	}
+
+	cmdUpgradeExtension = &Command{
+		Key: "upgrade",
+		Run: upgradeExtension,
+	}
+
+	cmdRemoveExtension = &Command{
+		Key: "remove",
+		Run: removeExtension,
+	}
+
+	cmdListExtensions = &Command{
+		Key: "list",
+		Run: listExtensions,
+	}
+)
+
+func init() {
+	cmdExtension.Use(cmdInstallExtension)
+	cmdExtension.Use(cmdUpgradeExtension)
+	cmdExtension.Use(cmdRemoveExtension)
+	cmdExtension.Use(cmdListExtensions)
+	CmdRunner.Use(cmdExtension)
+}
+
+const extensionPrefix = "hub-"
+
+func extensionsDir() string {
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		home, err := homedir.Dir()
+		utils.Check(err)
+		dataDir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataDir, "hub", "extensions")
+}
+
+// findExtension returns the path to the executable implementing command, or
+// an empty string if there is no such extension.
+func findExtension(command string) string {
+	if command == "" || strings.ContainsAny(command, `/\`) {
+		return ""
+	}
+	name := extensionPrefix + command
+
+	installed := filepath.Join(extensionsDir(), name, name)
+	if info, err := os.Stat(installed); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+		return installed
+	}
+
+	if path, err := exec.LookPath(name); err == nil {
+		return path
+	}
+	return ""
+}
+
+// runExtension runs an extension executable, passing on the context of the
+// current repository through the environment.
+func runExtension(path string, args *Args) error {
+	var project *github.Project
+	if localRepo, err := github.LocalRepo(); err == nil {
+		project, _ = localRepo.MainProject()
+	}
+	return runExtensionFor(path, project, args.Params, args.Noop)
+}
+
+// runExtensionFor runs an executable with params like an extension, passing on
+// the context of project through the environment.
+func runExtensionFor(path string, project *github.Project, params []string, noop bool) error {
+	host := github.DefaultGitHubHost()
+	if project != nil {
+		host = project.Host
+		os.Setenv("HUB_REPO", fmt.Sprintf("%s/%s", project.Owner, project.Name))
+	}
+	os.Setenv("HUB_HOST", host)
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if h := github.CurrentConfig().Find(host); h != nil && h.AccessToken != "" {
+		token = h.AccessToken
+	}
+	if token != "" {
+		os.Setenv("HUB_TOKEN", token)
+	}
+
+	c := cmd.New(path).WithArgs(params...)
+	if noop {
+		ui.Println(c)
+		return nil
+	}
+	return c.Run()
+}
+
+func installExtension(command *Command, args *Args) {
+	if args.ParamsSize() != 1 || !strings.Contains(args.FirstParam(), "/") {
+		utils.Check(command.UsageError(""))
+	}
+	nameWithOwner := args.FirstParam()
+	name := strings.SplitN(nameWithOwner, "/", 2)[1]
+	if !strings.HasPrefix(name, extensionPrefix) {
+		utils.Check(fmt.Errorf("Aborted: the name of an extension repository has to start with %q", extensionPrefix))
+	}
+
+	dir := filepath.Join(extensionsDir(), name)
+	if _, err := os.Stat(dir); err == nil {
+		utils.Check(fmt.Errorf("Aborted: extension %s is already installed", strings.TrimPrefix(name, extensionPrefix)))
+	}
+
+	url := getCloneUrl(nameWithOwner, false, true)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would clone %s to %s\n", url, dir)
+		return
+	}
+
+	utils.Check(os.MkdirAll(extensionsDir(), 0755))
+	utils.Check(git.Spawn("clone", "--quiet", url, dir))
+
+	if info, err := os.Stat(filepath.Join(dir, name)); err != nil || info.Mode()&0111 == 0 {
+		os.RemoveAll(dir)
+		utils.Check(fmt.Errorf("Aborted: %s doesn't contain an executable named %s", nameWithOwner, name))
+	}
+	ui.Infof("Installed extension %s\n", strings.TrimPrefix(name, extensionPrefix))
+}
+
+func installedExtensionDir(command *Command, args *Args) string {
+	if args.ParamsSize() != 1 {
+		utils.Check(command.UsageError(""))
+	}
+	name := strings.TrimPrefix(args.FirstParam(), extensionPrefix)
+	dir := filepath.Join(extensionsDir(), extensionPrefix+name)
+	if _, err := os.Stat(dir); err != nil || strings.ContainsAny(name, `/\`) {
+		utils.Check(fmt.Errorf("no such extension: %s", name))
+	}
+	return dir
+}
+
+func upgradeExtension(command *Command, args *Args) {
+	dir := installedExtensionDir(command, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would upgrade extension in %s\n", dir)
+		return
+	}
+	utils.Check(git.Spawn("-C", dir, "pull", "--quiet", "--ff-only"))
+}
+
+func removeExtension(command *Command, args *Args) {
+	dir := installedExtensionDir(command, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would delete %s\n", dir)
+		return
+	}
+	utils.Check(os.RemoveAll(dir))
+}
+
+func listExtensions(command *Command, args *Args) {
+	args.NoForward()
+
+	entries, err := ioutil.ReadDir(extensionsDir())
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), extensionPrefix) {
+			ui.Println(strings.TrimPrefix(entry.Name(), extensionPrefix))
+		}
+	}
+}
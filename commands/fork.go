@@ -3,14 +3,18 @@ package commands
 import (
 	"fmt"
 
+	"github.com/github/hub/git"
 	"github.com/github/hub/github"
 	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
 )
 
 var cmdFork = &Command{
-	Run:   fork,
-	Usage: "fork [--no-remote] [--remote-name <REMOTE>] [--org <ORGANIZATION>]",
+	Run: fork,
+	Usage: `
+fork [--no-remote] [--remote-name <REMOTE>] [--org <ORGANIZATION>] [--fork-name <NAME>] [--default-branch-only]
+fork --clone [--org <ORGANIZATION>] [--fork-name <NAME>] [--default-branch-only] [<OWNER>/<REPO>]
+`,
 	Long: `Fork the current repository on GitHub and add a git remote for it.
 
 ## Options:
@@ -23,6 +27,20 @@ var cmdFork = &Command{
 	--org <ORGANIZATION>
 		Fork the repository within this organization.
 
+	--fork-name <NAME>
+		Name the fork <NAME> instead of using the name of the original repository.
+
+	--default-branch-only
+		Only copy the default branch of the original repository to the fork.
+
+	--clone
+		Clone the fork to a new directory named after it, with an "upstream"
+		remote pointing to the original repository.
+
+	<OWNER>/<REPO>
+		The repository to fork instead of the current one. Unless '--clone' is
+		given, no remote is added for the fork.
+
 ## Examples:
 		$ hub fork
 		[ repo forked on GitHub ]
@@ -32,6 +50,11 @@ var cmdFork = &Command{
 		[ repo forked on GitHub into the ORGANIZATION organization]
 		> git remote add -f ORGANIZATION git@github.com:ORGANIZATION/REPO.git
 
+		$ hub fork --clone --fork-name=REPO-fork OWNER/REPO
+		[ repo forked on GitHub as USER/REPO-fork ]
+		> git clone git@github.com:USER/REPO-fork.git REPO-fork
+		> git -C REPO-fork remote add -f upstream git://github.com/OWNER/REPO.git
+
 ## See also:
 
 hub-clone(1), hub(1)
@@ -43,27 +66,47 @@ func init() {
 }
 
 func fork(cmd *Command, args *Args) {
-	localRepo, err := github.LocalRepo()
-	utils.Check(err)
-
-	project, err := localRepo.MainProject()
-	utils.Check(err)
+	var localRepo *github.GitHubRepo
+	var project *github.Project
+	var originURL string
+	var err error
+
+	if args.ParamsSize() > 1 {
+		utils.Check(cmd.UsageError(""))
+	} else if args.ParamsSize() == 1 {
+		project, err = resolveProject(args.FirstParam())
+		utils.Check(err)
+		originURL = project.GitURL("", "", false)
+	} else {
+		localRepo, err = github.LocalRepo()
+		utils.Check(err)
+		project, err = localRepo.MainProject()
+		utils.Check(err)
+		originRemote, err := localRepo.RemoteForProject(project)
+		utils.Check(err)
+		originURL = originRemote.URL.String()
+	}
 
 	config := github.CurrentConfig()
 	host, err := config.PromptForHost(project.Host)
 	utils.Check(github.FormatError("forking repository", err))
 
-	originRemote, err := localRepo.RemoteForProject(project)
-	utils.Check(err)
-
 	params := map[string]interface{}{}
 	forkOwner := host.User
 	if flagForkOrganization := args.Flag.Value("--org"); flagForkOrganization != "" {
 		forkOwner = flagForkOrganization
 		params["organization"] = forkOwner
 	}
+	forkName := project.Name
+	if flagForkName := args.Flag.Value("--fork-name"); flagForkName != "" {
+		forkName = flagForkName
+		params["name"] = forkName
+	}
+	if args.Flag.Bool("--default-branch-only") {
+		params["default_branch_only"] = true
+	}
 
-	forkProject := github.NewProject(forkOwner, project.Name, project.Host)
+	forkProject := github.NewProject(forkOwner, forkName, project.Host)
 	var newRemoteName string
 	if flagForkRemoteName := args.Flag.Value("--remote-name"); flagForkRemoteName != "" {
 		newRemoteName = flagForkRemoteName
@@ -72,26 +115,13 @@ func fork(cmd *Command, args *Args) {
 	}
 
 	client := github.NewClient(project.Host)
-	existingRepo, err := client.Repository(forkProject)
-	if err == nil {
-		existingProject, err := github.NewProjectFromRepo(existingRepo)
-		if err == nil && !existingProject.SameAs(forkProject) {
-			existingRepo = nil
-		}
-	}
-	if err == nil && existingRepo != nil {
-		var parentURL *github.URL
-		if parent := existingRepo.Parent; parent != nil {
-			parentURL, _ = github.ParseURL(parent.HtmlUrl)
-		}
-		if parentURL == nil || !project.SameAs(parentURL.Project) {
-			err = fmt.Errorf("Error creating fork: %s already exists on %s",
-				forkProject, forkProject.Host)
-			utils.Check(err)
-		}
-	} else {
+	exists, err := existingFork(client, project, forkProject)
+	utils.Check(err)
+	if !exists {
 		if !args.Noop {
+			spinner := ui.StartSpinner(fmt.Sprintf("Forking %s ...", project))
 			newRepo, err := client.ForkRepository(project, params)
+			spinner.Stop()
 			utils.Check(err)
 			forkProject.Owner = newRepo.Owner.Login
 			forkProject.Name = newRepo.Name
@@ -99,9 +129,18 @@ func fork(cmd *Command, args *Args) {
 	}
 
 	args.NoForward()
-	if !args.Flag.Bool("--no-remote") {
+	if args.Flag.Bool("--clone") {
+		dir := forkProject.Name
+		args.Before("git", "clone", forkProject.GitURL("", "", true), dir)
+		args.Before("git", "-C", dir, "remote", "add", "-f", "upstream", originURL)
 
-		originURL := originRemote.URL.String()
+		args.AfterFn(func() error {
+			ui.Printf("cloned fork into %s\n", dir)
+			return nil
+		})
+	} else if localRepo == nil {
+		ui.Println(forkProject.WebURL("", "", ""))
+	} else if !args.Flag.Bool("--no-remote") {
 		url := forkProject.GitURL("", "", true)
 
 		// Check to see if the remote already exists.
@@ -125,8 +164,89 @@ func fork(cmd *Command, args *Args) {
 		args.Before("git", "remote", "set-url", newRemoteName, url)
 
 		args.AfterFn(func() error {
-			ui.Printf("new remote: %s\n", newRemoteName)
+			ui.Infof("new remote: %s\n", newRemoteName)
 			return nil
 		})
 	}
 }
+
+// existingFork reports whether forkProject already exists as a fork of
+// project. It fails if a repository by that name exists but isn't such a fork.
+func existingFork(client *github.Client, project, forkProject *github.Project) (bool, error) {
+	existingRepo, err := client.Repository(forkProject)
+	if err == nil {
+		existingProject, err := github.NewProjectFromRepo(existingRepo)
+		if err == nil && !existingProject.SameAs(forkProject) {
+			existingRepo = nil
+		}
+	}
+	if err != nil || existingRepo == nil {
+		return false, nil
+	}
+
+	var parentURL *github.URL
+	if parent := existingRepo.Parent; parent != nil {
+		parentURL, _ = github.ParseURL(parent.HtmlUrl)
+	}
+	if parentURL == nil || !project.SameAs(parentURL.Project) {
+		return false, fmt.Errorf("Error creating fork: %s already exists on %s",
+			forkProject, forkProject.Host)
+	}
+	return true, nil
+}
+
+// forkForPush offers to fork project, which the current user can't push to,
+// and returns a git remote for the fork to push to instead. The remote is
+// added if there isn't one for the fork yet. It returns nil if the user
+// declines.
+func forkForPush(project *github.Project, host *github.Host) *github.Remote {
+	question := fmt.Sprintf("You don't have push access to %s. Fork it and push to the fork instead", project)
+	if !confirm(question) {
+		return nil
+	}
+
+	client := github.NewClient(project.Host)
+	forkProject := github.NewProject(host.User, project.Name, project.Host)
+	exists, err := existingFork(client, project, forkProject)
+	utils.Check(err)
+	if !exists {
+		spinner := ui.StartSpinner(fmt.Sprintf("Forking %s ...", project))
+		newRepo, err := client.ForkRepository(project, map[string]interface{}{})
+		spinner.Stop()
+		utils.Check(err)
+		forkProject.Owner = newRepo.Owner.Login
+		forkProject.Name = newRepo.Name
+		ui.Printf("forked %s to %s\n", project, forkProject)
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	if remote, err := localRepo.RemoteForProject(forkProject); err == nil {
+		return remote
+	}
+
+	remoteName := forkProject.Owner
+	if _, err := localRepo.RemoteByName(remoteName); err == nil {
+		utils.Check(fmt.Errorf("Aborted: can't add a remote for %s because a remote named %s already exists", forkProject, remoteName))
+	}
+	err = git.Spawn("remote", "add", remoteName, forkProject.GitURL("", "", true))
+	utils.Check(err)
+	ui.Infof("new remote: %s\n", remoteName)
+
+	// The remotes of localRepo are cached, so look up the new one afresh.
+	localRepo, err = github.LocalRepo()
+	utils.Check(err)
+	remote, err := localRepo.RemoteByName(remoteName)
+	utils.Check(err)
+	return remote
+}
+
+// hasCredentials reports whether hub can make API requests to host without
+// asking the user to log in.
+func hasCredentials(host string) bool {
+	config := github.CurrentConfig()
+	if h := config.Find(host); h != nil {
+		return h.User != "" && (h.AccessToken != "" || config.DetectToken() != "")
+	}
+	return config.DetectToken() != ""
+}
@@ -2,6 +2,9 @@ package commands
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -14,41 +17,89 @@ var (
 	cmdGist = &Command{
 		Run: printGistHelp,
 		Usage: `
-gist create [-oc] [--public] [<FILES>...]
-gist show <ID> [<FILENAME>]
+gist list [--public|--secret] [-L <LIMIT>]
+gist create [-oc] [--public] [-d <DESCRIPTION>] [--filename <FILENAME>] [<FILES>...]
+gist show [-uc] <ID> [<FILENAME>]
+gist edit [-oc] [--filename <FILENAME>] <ID> [<FILES>...]
+gist delete [-y] <ID>
+gist clone <ID> [<DIRECTORY>]
 `,
-		Long: `Create and print GitHub Gists
+		Long: `Create, print, and manage GitHub Gists
 
 ## Commands:
 
+	* _list_:
+		List your gists.
+
 	* _create_:
 		Create a new gist. If no <FILES> are specified, the content is read from
-		standard input.
+		standard input. Pass "-" as one of <FILES> to read a file from standard
+		input in addition to others.
 
 	* _show_:
 		Print the contents of a gist. If the gist contains multiple files, the
 		operation will error out unless <FILENAME> is specified.
 
+	* _edit_:
+		Upload <FILES> to a gist, replacing the gist files of the same name and
+		adding the rest. If no <FILES> are specified, open the gist file in a text
+		editor instead; a gist with multiple files requires '--filename'.
+
+	* _delete_:
+		Delete a gist.
+
+	* _clone_:
+		Clone the git repository of a gist.
+
+<ID> can also be the URL of a gist.
+
 ## Options:
 
 	--public
-		Make the new gist public (default: false).
+		Make the new gist public (default: false). In list mode, display only
+		public gists.
+
+	--secret
+		Display only secret gists.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> gists.
+
+	-d, --desc <DESCRIPTION>
+		The description of the new gist.
+
+	--filename <FILENAME>
+		The name of the gist file that holds the content read from standard input
+		(default: "gistfile1.txt"). With 'edit', the gist file to open in a text
+		editor.
 
 	-o, --browse
 		Open the new gist in a web browser.
 
+	-u, --url
+		With 'show', print the URL of the gist instead of its contents.
+
 	-c, --copy
-		Put the URL of the new gist to clipboard instead of printing it.
+		Put the URL of the new gist to clipboard instead of printing it. With
+		'show', copy the URL of the gist instead of printing its contents.
+
+	-y, --yes
+		Delete the gist without asking for confirmation.
 
 ## Examples:
 
     $ echo hello | hub gist create --public
 
+    $ make 2>&1 | hub gist create --filename output.log --desc "crash log" --copy
+
     $ hub gist create file1.txt file2.txt
 
     # print a specific file within a gist:
     $ hub gist show ID testfile1.txt
 
+    $ hub gist edit ID file1.txt
+    $ hub gist clone ID
+
 ## See also:
 
 hub(1), hub-api(1)
@@ -58,6 +109,10 @@ hub(1), hub-api(1)
 	cmdShowGist = &Command{
 		Key: "show",
 		Run: showGist,
+		KnownFlags: `
+		-u, --url
+		-c, --copy
+`,
 	}
 
 	cmdCreateGist = &Command{
@@ -65,18 +120,72 @@ hub(1), hub-api(1)
 		Run: createGist,
 		KnownFlags: `
 		--public
+		-d, --desc DESCRIPTION
+		--filename FILENAME
 		-o, --browse
 		-c, --copy
 `,
 	}
+
+	cmdListGists = &Command{
+		Key: "list",
+		Run: listGists,
+		KnownFlags: `
+		--public
+		--secret
+		-L, --limit N
+`,
+	}
+
+	cmdEditGist = &Command{
+		Key: "edit",
+		Run: editGist,
+		KnownFlags: `
+		--filename FILENAME
+		-o, --browse
+		-c, --copy
+`,
+	}
+
+	cmdDeleteGist = &Command{
+		Key: "delete",
+		Run: deleteGist,
+		KnownFlags: `
+		-y, --yes
+`,
+	}
+
+	cmdCloneGist = &Command{
+		Key: "clone",
+		Run: cloneGist,
+	}
 )
 
 func init() {
+	cmdGist.Use(cmdListGists)
 	cmdGist.Use(cmdShowGist)
 	cmdGist.Use(cmdCreateGist)
+	cmdGist.Use(cmdEditGist)
+	cmdGist.Use(cmdDeleteGist)
+	cmdGist.Use(cmdCloneGist)
 	CmdRunner.Use(cmdGist)
 }
 
+// gistID extracts the ID from a gist URL, or returns the argument unchanged.
+func gistID(arg string) string {
+	if strings.Contains(arg, "://") {
+		arg = strings.TrimSuffix(strings.TrimRight(arg, "/"), ".git")
+		arg = arg[strings.LastIndex(arg, "/")+1:]
+	}
+	return arg
+}
+
+func gistClient() *github.Client {
+	host, err := github.CurrentConfig().DefaultHostNoPrompt()
+	utils.Check(err)
+	return github.NewClient(host.Host)
+}
+
 func getGist(gh *github.Client, id string, filename string) error {
 	gist, err := gh.FetchGist(id)
 	if err != nil {
@@ -125,6 +234,18 @@ func createGist(cmd *Command, args *Args) {
 		filenames = args.Params
 	}
 
+	stdinCount := 0
+	for _, filename := range filenames {
+		if filename == "-" {
+			stdinCount++
+		}
+	}
+	if stdinCount > 1 {
+		utils.Check(fmt.Errorf("standard input can only be read once"))
+	} else if stdinCount == 0 && args.Flag.HasReceived("--filename") {
+		utils.Check(fmt.Errorf("--filename can only be used when reading from standard input"))
+	}
+
 	var gist *github.Gist
 	if args.Noop {
 		ui.Println("Would create gist")
@@ -132,7 +253,9 @@ func createGist(cmd *Command, args *Args) {
 			HtmlUrl: fmt.Sprintf("https://gist.%s/%s", gh.Host.Host, "ID"),
 		}
 	} else {
-		gist, err = gh.CreateGist(filenames, args.Flag.Bool("--public"))
+		spinner := ui.StartSpinner("Creating gist ...")
+		gist, err = gh.CreateGist(filenames, args.Flag.Value("--filename"), args.Flag.Value("--desc"), args.Flag.Bool("--public"))
+		spinner.Stop()
 		utils.Check(err)
 	}
 
@@ -152,7 +275,15 @@ func showGist(cmd *Command, args *Args) {
 	utils.Check(err)
 	gh := github.NewClient(host.Host)
 
-	id := args.GetParam(0)
+	id := gistID(args.GetParam(0))
+
+	if args.Flag.Bool("--url") || args.Flag.Bool("--copy") {
+		gist, err := gh.FetchGist(id)
+		utils.Check(err)
+		printBrowseOrCopy(args, gist.HtmlUrl, false, args.Flag.Bool("--copy"))
+		return
+	}
+
 	filename := ""
 	if args.ParamsSize() > 1 {
 		filename = args.GetParam(1)
@@ -161,3 +292,156 @@ func showGist(cmd *Command, args *Args) {
 	err = getGist(gh, id, filename)
 	utils.Check(err)
 }
+
+func listGists(cmd *Command, args *Args) {
+	args.NoForward()
+
+	onlyPublic := args.Flag.Bool("--public")
+	onlySecret := args.Flag.Bool("--secret")
+	if onlyPublic && onlySecret {
+		utils.Check(fmt.Errorf("--public and --secret are mutually exclusive"))
+	}
+
+	gh := gistClient()
+	if args.Noop {
+		ui.Println("Would request list of gists")
+		return
+	}
+
+	gists, err := gh.FetchGists(args.Flag.Int("--limit"), func(gist *github.Gist) bool {
+		return !(onlyPublic && !gist.Public) && !(onlySecret && gist.Public)
+	})
+	utils.Check(err)
+
+	for _, gist := range gists {
+		ui.Println(formatGist(gist))
+	}
+}
+
+// formatGist describes a gist as "<ID>  <DESCRIPTION>  (<FILES>, <VISIBILITY>)",
+// using the names of its files if it has no description.
+func formatGist(gist github.Gist) string {
+	description := gist.Description
+	if description == "" {
+		filenames := []string{}
+		for name := range gist.Files {
+			filenames = append(filenames, name)
+		}
+		sort.Strings(filenames)
+		description = strings.Join(filenames, ", ")
+	}
+
+	files := "1 file"
+	if len(gist.Files) != 1 {
+		files = fmt.Sprintf("%d files", len(gist.Files))
+	}
+	visibility := "secret"
+	if gist.Public {
+		visibility = "public"
+	}
+
+	return fmt.Sprintf("%s  %s  (%s, %s)", gist.Id, description, files, visibility)
+}
+
+func editGist(cmd *Command, args *Args) {
+	args.NoForward()
+
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError("you must specify a gist ID"))
+	}
+	id := gistID(args.GetParam(0))
+	gh := gistClient()
+
+	files := map[string]string{}
+	for _, filename := range args.Params[1:] {
+		content, err := ioutil.ReadFile(filename)
+		utils.Check(err)
+		files[filepath.Base(filename)] = string(content)
+	}
+
+	var gist *github.Gist
+	if args.Noop {
+		ui.Printf("Would update gist %s\n", id)
+		gist = &github.Gist{
+			HtmlUrl: fmt.Sprintf("https://gist.%s/%s", gh.Host.Host, id),
+		}
+	} else {
+		if len(files) == 0 {
+			current, err := gh.FetchGist(id)
+			utils.Check(err)
+
+			filename := args.Flag.Value("--filename")
+			if filename == "" {
+				if len(current.Files) > 1 {
+					utils.Check(fmt.Errorf("This gist contains multiple files, you must specify one with --filename"))
+				}
+				for name := range current.Files {
+					filename = name
+				}
+			}
+			file, ok := current.Files[filename]
+			if !ok {
+				utils.Check(fmt.Errorf("no such file in gist"))
+			}
+
+			content, err := github.EditFile(filename, []byte(file.Content))
+			utils.Check(err)
+			if string(content) == file.Content {
+				ui.Errorln("Aborted: no changes were made to the gist")
+				os.Exit(1)
+			}
+			files[filename] = string(content)
+		}
+
+		var err error
+		gist, err = gh.UpdateGist(id, files)
+		utils.Check(err)
+	}
+
+	printBrowseOrCopy(args, gist.HtmlUrl, args.Flag.Bool("--browse"), args.Flag.Bool("--copy"))
+}
+
+func deleteGist(cmd *Command, args *Args) {
+	args.NoForward()
+
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError("you must specify a gist ID"))
+	}
+	id := gistID(args.GetParam(0))
+	gh := gistClient()
+
+	if !args.Flag.Bool("--yes") && !confirm(fmt.Sprintf("Really delete gist '%s'", id)) {
+		utils.Check(fmt.Errorf("Please type 'yes' for confirmation."))
+	}
+
+	if args.Noop {
+		ui.Printf("Would delete gist %s\n", id)
+		return
+	}
+
+	utils.Check(gh.DeleteGist(id))
+	ui.Infof("Deleted gist %s\n", id)
+}
+
+func cloneGist(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError("you must specify a gist ID"))
+	}
+	id := gistID(args.GetParam(0))
+	gh := gistClient()
+
+	cloneURL := ""
+	if args.Noop {
+		cloneURL = fmt.Sprintf("https://gist.%s/%s.git", gh.Host.Host, id)
+	} else {
+		gist, err := gh.FetchGist(id)
+		utils.Check(err)
+		cloneURL = gist.GitPullUrl
+	}
+
+	params := []string{cloneURL}
+	if args.ParamsSize() > 1 {
+		params = append(params, args.GetParam(1))
+	}
+	args.Replace(args.Executable, "clone", params...)
+}
@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdGPGKey = &Command{
+		Run: printHelp,
+		Usage: `
+gpg-key list
+gpg-key add [--name <NAME>] <FILE>
+gpg-key remove <ID>
+`,
+		Long: `Manage the GPG keys of your GitHub account.
+
+## Commands:
+
+	* _list_:
+		List GPG keys as "<ID>  <KEY-ID>  <EMAILS>" lines.
+
+	* _add_:
+		Add the ASCII-armored public key in <FILE>, or standard input if <FILE>
+		is "-", as exported by "gpg --armor --export <KEY-ID>".
+
+	* _remove_:
+		Remove the GPG key with <ID>. The key ID shown by 'gpg' can be used as
+		well.
+
+## Options:
+	--name <NAME>
+		A name for the key.
+
+## Examples:
+		$ gpg --armor --export 3262EFF25BA0D270 | hub gpg-key add -
+
+## See also:
+
+hub-ssh-key(1), hub(1)
+`,
+	}
+
+	cmdListGPGKeys = &Command{
+		Key: "list",
+		Run: listGPGKeys,
+	}
+
+	cmdAddGPGKey = &Command{
+		Key: "add",
+		Run: addGPGKey,
+		KnownFlags: `
+		--name NAME
+`,
+	}
+
+	cmdRemoveGPGKey = &Command{
+		Key: "remove",
+		Run: removeGPGKey,
+	}
+)
+
+func init() {
+	cmdGPGKey.Use(cmdListGPGKeys)
+	cmdGPGKey.Use(cmdAddGPGKey)
+	cmdGPGKey.Use(cmdRemoveGPGKey)
+	CmdRunner.Use(cmdGPGKey)
+}
+
+func listGPGKeys(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Println("Would request list of GPG keys")
+		return
+	}
+
+	gh := accountClient("fetching GPG keys")
+	keys, err := gh.GPGKeys()
+	utils.Check(err)
+
+	idWidth := 0
+	for _, key := range keys {
+		if width := len(strconv.FormatInt(key.Id, 10)); width > idWidth {
+			idWidth = width
+		}
+	}
+	for _, key := range keys {
+		emails := ""
+		for i, email := range key.Emails {
+			if i == 0 {
+				emails += "  "
+			} else {
+				emails += ", "
+			}
+			emails += email.Email
+		}
+		expires := ""
+		if key.ExpiresAt != nil {
+			expires = fmt.Sprintf("  (expires %s)", key.ExpiresAt.Format("2006-01-02"))
+		}
+		ui.Printf("%*d  %s%s%s\n", idWidth, key.Id, key.KeyId, emails, expires)
+	}
+}
+
+func addGPGKey(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	file := args.GetParam(0)
+	key := readKeyFile(file)
+	if strings.Contains(key, "PRIVATE KEY BLOCK") {
+		utils.Check(fmt.Errorf("%s contains a private key; refusing to upload it", file))
+	}
+	if !strings.Contains(key, "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+		utils.Check(fmt.Errorf("invalid GPG public key: %s (export it with `gpg --armor --export`)", file))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would add GPG key from %s\n", file)
+		return
+	}
+
+	gh := accountClient("adding GPG key")
+	gpgKey, err := gh.AddGPGKey(args.Flag.Value("--name"), key)
+	utils.Check(err)
+	ui.Infof("Added GPG key %d (%s)\n", gpgKey.Id, gpgKey.KeyId)
+}
+
+func removeGPGKey(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	param := args.GetParam(0)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would remove GPG key %s\n", param)
+		return
+	}
+
+	gh := accountClient("removing GPG key")
+	keys, err := gh.GPGKeys()
+	utils.Check(err)
+
+	var key *github.GPGKey
+	for i := range keys {
+		if strconv.FormatInt(keys[i].Id, 10) == param || strings.EqualFold(keys[i].KeyId, param) {
+			key = &keys[i]
+			break
+		}
+	}
+	if key == nil {
+		utils.Check(fmt.Errorf("Unable to find GPG key '%s'", param))
+	}
+
+	utils.Check(gh.DeleteGPGKey(key.Id))
+	ui.Infof("Removed GPG key %d (%s)\n", key.Id, key.KeyId)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/github/hub/cmd"
+	"github.com/github/hub/github"
 	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
 	"github.com/kballard/go-shellquote"
@@ -60,6 +61,7 @@ func init() {
 func runHelp(helpCmd *Command, args *Args) {
 	if args.IsParamsEmpty() {
 		args.AfterFn(func() error {
+			startPager(args)
 			ui.Println(helpText)
 			return nil
 		})
@@ -97,6 +99,7 @@ func runHelp(helpCmd *Command, args *Args) {
 			}
 		}
 
+		startPager(args)
 		ui.Println(c.HelpText())
 		args.NoForward()
 	}
@@ -127,7 +130,10 @@ func displayManPage(manPage string, args *Args) error {
 		manArgs = []string{manProgram}
 	} else {
 		manPage += ".txt"
-		manProgram = os.Getenv("PAGER")
+		manProgram = github.ConfigSetting("pager")
+		if manProgram == "" {
+			manProgram = os.Getenv("PAGER")
+		}
 		if manProgram != "" {
 			var err error
 			manArgs, err = shellquote.Split(manProgram)
@@ -205,17 +211,48 @@ func customCommands() []string {
 var helpText = `
 These GitHub commands are provided by hub:
 
+   advisory       Read security advisories and Dependabot alerts
+   alerts         List Dependabot, code scanning, and secret scanning alerts
    api            Low-level GitHub API request interface
+   audit-log      Search the audit log of an organization
    browse         Open a GitHub page in the default browser
    ci-status      Show the status of GitHub checks for a commit
+   code-scanning  Upload SARIF results and manage code scanning alerts
+   codeowners     Show who owns files according to CODEOWNERS
+   collab         Manage who has access to a repository
+   community      Report on the community health of a repository
    compare        Open a compare page on GitHub
    create         Create this repository on GitHub and add GitHub as origin
    delete         Delete a repository on GitHub
+   deployment     Create deployments and report their status
+   discussion     List, view, or start GitHub discussions
+   env            Create disposable development environments for a repository
+   extension      Manage extensions that add commands to hub
    fork           Make a fork of a remote repository on GitHub and add as remote
    gist           Make a gist
+   gpg-key        Manage the GPG keys of your account
+   hook           Manage webhooks and their deliveries
+   invitation     List and accept invitations to repositories
    issue          List or create GitHub issues
+   org            List the members of an organization
    pr             List or checkout GitHub pull requests
+   protect        Configure branch protection rules
    pull-request   Open a pull request on GitHub
+   query          Run a saved filter for issues or pull requests
    release        List or create GitHub releases
+   remotes        Show how git remotes map to GitHub repositories
+   rename-default-branch  Rename the default branch on GitHub and locally
+   repo           View and manage repository settings
+   search         Search for issues, pull requests, repositories, or code
+   secret         Manage GitHub Actions and Dependabot secrets
+   ssh-key        Manage the SSH keys of your account
+   star           Star a repository or list starred repositories
+   stats          Report contributor and commit statistics of a repository
    sync           Fetch git objects from upstream and update branches
+   team           List teams and manage their members
+   traffic        Report on the views and clones of a repository
+   unprotect      Remove branch protection rules
+   unstar         Remove the star from a repository
+   user           Inspect the profile of a user or organization
+   watch          Change notifications for a repository
 `
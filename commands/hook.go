@@ -0,0 +1,337 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdHook = &Command{
+		Run: printHelp,
+		Usage: `
+hook list
+hook create --url <URL> [--event <EVENTS>] [--secret <SECRET>]
+hook delete <ID>
+hook ping <ID>
+hook deliveries [-L <LIMIT>] [--redeliver <DELIVERY-ID>] <ID>
+hook forward (--port <PORT> | --url <URL>) [--event <EVENTS>] [--secret <SECRET>]
+`,
+		Long: `Manage the webhooks of a repository and debug their deliveries.
+
+## Commands:
+
+	* _list_:
+		List webhooks as "<ID>  <URL>  <EVENTS>" lines. Inactive webhooks are
+		marked as such.
+
+	* _create_:
+		Create a webhook that posts JSON payloads to <URL> and print its ID.
+
+	* _delete_:
+		Delete the webhook with <ID>.
+
+	* _ping_:
+		Have GitHub send a "ping" event to the webhook with <ID>.
+
+	* _deliveries_:
+		List the recent deliveries of the webhook with <ID>, most recent first,
+		as "<DELIVERY-ID>  <TIME>  <STATUS-CODE>  <EVENT>  <DURATION>" lines.
+
+	* _forward_:
+		Forward events to a local HTTP server for development until interrupted,
+		printing the response status for each one. This creates a temporary
+		webhook that is deleted on exit, and replays its deliveries as they
+		happen. Without admin access to the repository, its public events are
+		polled instead; these are delayed and their payloads are less complete.
+
+## Options:
+	--url <URL>
+		The URL that payloads are delivered to.
+
+	--port <PORT>
+		With 'forward', deliver payloads to "http://localhost:<PORT>/".
+
+	--event <EVENTS>
+		A comma-separated list of events that trigger the webhook (default:
+		"push", or "*" for 'forward'). Use "*" to subscribe to all events. This
+		option can be specified multiple times.
+
+	--secret <SECRET>
+		The secret used to sign payloads in the "X-Hub-Signature-256" header.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> deliveries.
+
+	--redeliver <DELIVERY-ID>
+		Send the payload of the delivery with <DELIVERY-ID> again instead of
+		listing deliveries.
+
+	-R, --repo <REPO>
+		Manage the webhooks of <REPO>, given in "OWNER/NAME" format, instead of
+		the repository of the current directory.
+
+## Examples:
+		$ hub hook create --url https://ci.example.com/hook --event push,pull_request --secret "$SECRET"
+		$ hub hook deliveries -L 5 12345678
+		$ hub hook deliveries --redeliver 987654321 12345678
+		$ hub hook forward --event pull_request --port 8080
+
+## See also:
+
+hub(1)
+`,
+	}
+
+	cmdListHooks = &Command{
+		Key: "list",
+		Run: listHooks,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+
+	cmdCreateHook = &Command{
+		Key: "create",
+		Run: createHook,
+		KnownFlags: `
+		--url URL
+		--event EVENTS
+		--secret SECRET
+		-R, --repo REPO
+`,
+	}
+
+	cmdDeleteHook = &Command{
+		Key: "delete",
+		Run: deleteHook,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+
+	cmdPingHook = &Command{
+		Key: "ping",
+		Run: pingHook,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+
+	cmdForwardHook = &Command{
+		Key: "forward",
+		Run: forwardHook,
+		KnownFlags: `
+		--port PORT
+		--url URL
+		--event EVENTS
+		--secret SECRET
+		-R, --repo REPO
+`,
+	}
+
+	cmdHookDeliveries = &Command{
+		Key: "deliveries",
+		Run: listHookDeliveries,
+		KnownFlags: `
+		-L, --limit N
+		--redeliver ID
+		-R, --repo REPO
+`,
+	}
+)
+
+func init() {
+	cmdHook.Use(cmdListHooks)
+	cmdHook.Use(cmdCreateHook)
+	cmdHook.Use(cmdDeleteHook)
+	cmdHook.Use(cmdPingHook)
+	cmdHook.Use(cmdHookDeliveries)
+	cmdHook.Use(cmdForwardHook)
+	CmdRunner.Use(cmdHook)
+}
+
+func parseHookId(s string) int64 {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		utils.Check(fmt.Errorf("invalid webhook ID: '%s'", s))
+	}
+	return id
+}
+
+func listHooks(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of webhooks for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	hooks, err := gh.Hooks(project)
+	utils.Check(err)
+
+	idWidth, urlWidth := 0, 0
+	for _, hook := range hooks {
+		if width := len(strconv.FormatInt(hook.Id, 10)); width > idWidth {
+			idWidth = width
+		}
+		if len(hook.Config.Url) > urlWidth {
+			urlWidth = len(hook.Config.Url)
+		}
+	}
+	for _, hook := range hooks {
+		inactive := ""
+		if !hook.Active {
+			inactive = " (inactive)"
+		}
+		ui.Printf("%*d  %-*s  %s%s\n", idWidth, hook.Id, urlWidth, hook.Config.Url,
+			strings.Join(hook.Events, ","), inactive)
+	}
+}
+
+func createHook(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	hookUrl := args.Flag.Value("--url")
+	if hookUrl == "" {
+		utils.Check(cmd.UsageError("missing required option --url"))
+	}
+	events := commaSeparated(args.Flag.AllValues("--event"))
+	if len(events) == 0 {
+		events = []string{"push"}
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create webhook for %s delivering %s to %s\n", strings.Join(events, ","), hookUrl, project)
+		return
+	}
+
+	config := map[string]interface{}{
+		"url":          hookUrl,
+		"content_type": "json",
+	}
+	if args.Flag.HasReceived("--secret") {
+		config["secret"] = args.Flag.Value("--secret")
+	}
+
+	gh := github.NewClient(project.Host)
+	hook, err := gh.CreateHook(project, map[string]interface{}{
+		"name":   "web",
+		"active": true,
+		"events": events,
+		"config": config,
+	})
+	utils.Check(err)
+	ui.Println(hook.Id)
+}
+
+func deleteHook(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	id := parseHookId(args.GetParam(0))
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would delete webhook %d from %s\n", id, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	utils.Check(gh.DeleteHook(project, id))
+	ui.Infof("Deleted webhook %d\n", id)
+}
+
+func pingHook(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	id := parseHookId(args.GetParam(0))
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would ping webhook %d of %s\n", id, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	utils.Check(gh.PingHook(project, id))
+	ui.Infof("Pinged webhook %d; see `hub hook deliveries %d` for the response\n", id, id)
+}
+
+func listHookDeliveries(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	id := parseHookId(args.GetParam(0))
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Flag.HasReceived("--redeliver") {
+		deliveryId, err := strconv.ParseInt(args.Flag.Value("--redeliver"), 10, 64)
+		if err != nil {
+			utils.Check(fmt.Errorf("invalid delivery ID: '%s'", args.Flag.Value("--redeliver")))
+		}
+		if args.Noop {
+			ui.Printf("Would redeliver %d of webhook %d\n", deliveryId, id)
+			return
+		}
+		utils.Check(gh.RedeliverHook(project, id, deliveryId))
+		ui.Infof("Redelivered %d of webhook %d\n", deliveryId, id)
+		return
+	}
+
+	if args.Noop {
+		ui.Printf("Would request list of deliveries of webhook %d\n", id)
+		return
+	}
+
+	deliveries, err := gh.HookDeliveries(project, id, args.Flag.Int("--limit"))
+	utils.Check(err)
+
+	idWidth, eventWidth := 0, 0
+	events := make([]string, len(deliveries))
+	for i, delivery := range deliveries {
+		if width := len(strconv.FormatInt(delivery.Id, 10)); width > idWidth {
+			idWidth = width
+		}
+		events[i] = delivery.Event
+		if delivery.Action != "" {
+			events[i] += "." + delivery.Action
+		}
+		if len(events[i]) > eventWidth {
+			eventWidth = len(events[i])
+		}
+	}
+	for i, delivery := range deliveries {
+		redelivery := ""
+		if delivery.Redelivery {
+			redelivery = "  (redelivery)"
+		}
+		ui.Printf("%*d  %s  %3d  %-*s  %.2fs%s\n", idWidth, delivery.Id,
+			delivery.DeliveredAt.Format(time.RFC3339), delivery.StatusCode,
+			eventWidth, events[i], delivery.Duration, redelivery)
+	}
+}
@@ -0,0 +1,246 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+// The temporary webhook that `hook forward` creates never reaches anything:
+// GitHub records each delivery along with its payload, which hub then fetches
+// and replays against the local server. The ".invalid" TLD can never resolve.
+const forwardPlaceholderUrl = "https://hub-hook-forward.invalid/"
+
+var forwardPollInterval = 2 * time.Second
+
+// hookRelay forwards webhook payloads to a local HTTP server.
+type hookRelay struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// forward posts payload to the local server with the given headers and
+// returns the response status. When a secret is configured, the payload is
+// signed anew since it was re-encoded by the API and the signature computed by
+// GitHub no longer matches.
+func (r *hookRelay) forward(headers map[string]string, payload []byte) (string, error) {
+	req, err := http.NewRequest("POST", r.url, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Del("Content-Length")
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.secret != "" {
+		req.Header.Set("X-Hub-Signature", "sha1="+hmacHex(sha1.New, r.secret, payload))
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hmacHex(sha256.New, r.secret, payload))
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	res.Body.Close()
+	return res.Status, nil
+}
+
+func hmacHex(h func() hash.Hash, secret string, payload []byte) string {
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var eventTypeWordRe = regexp.MustCompile(`[a-z][A-Z]`)
+
+// webhookEventName translates the type of an Events API event, such as
+// "PullRequestReviewEvent", to the name of the matching webhook event.
+func webhookEventName(eventType string) string {
+	name := strings.TrimSuffix(eventType, "Event")
+	name = eventTypeWordRe.ReplaceAllStringFunc(name, func(s string) string {
+		return s[:1] + "_" + s[1:]
+	})
+	return strings.ToLower(name)
+}
+
+func payloadAction(payload []byte) string {
+	p := struct {
+		Action string `json:"action"`
+	}{}
+	json.Unmarshal(payload, &p)
+	return p.Action
+}
+
+func forwardHook(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	target := args.Flag.Value("--url")
+	if target == "" {
+		if !args.Flag.HasReceived("--port") {
+			utils.Check(cmd.UsageError("missing required option --port or --url"))
+		}
+		port, err := strconv.Atoi(args.Flag.Value("--port"))
+		if err != nil || port <= 0 {
+			utils.Check(fmt.Errorf("invalid port: '%s'", args.Flag.Value("--port")))
+		}
+		target = fmt.Sprintf("http://localhost:%d/", port)
+	}
+	events := commaSeparated(args.Flag.AllValues("--event"))
+	if len(events) == 0 {
+		events = []string{"*"}
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would forward %s events of %s to %s\n", strings.Join(events, ","), project, target)
+		return
+	}
+
+	relay := &hookRelay{
+		url:    target,
+		secret: args.Flag.Value("--secret"),
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+	gh := github.NewClient(project.Host)
+	repo, err := gh.Repository(project)
+	utils.Check(err)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	if repo.Permissions != nil && repo.Permissions.Admin {
+		forwardHookDeliveries(gh, project, events, relay, interrupt)
+	} else {
+		ui.Errorf("warning: creating webhooks for %s requires admin access; polling its public events instead\n", project)
+		forwardRepositoryEvents(gh, project, events, relay, interrupt)
+	}
+}
+
+func forwardHookDeliveries(gh *github.Client, project *github.Project, events []string, relay *hookRelay, interrupt chan os.Signal) {
+	hook, err := gh.CreateHook(project, map[string]interface{}{
+		"name":   "web",
+		"active": true,
+		"events": events,
+		"config": map[string]interface{}{
+			"url":          forwardPlaceholderUrl,
+			"content_type": "json",
+		},
+	})
+	utils.Check(err)
+	ui.Errorf("Forwarding %s events of %s to %s; press Ctrl-C to stop\n", strings.Join(events, ","), project, relay.url)
+
+	seen := map[int64]bool{}
+	for {
+		select {
+		case <-interrupt:
+			// Requests made with the client were aborted by the interrupt, so
+			// the clean-up needs one of its own.
+			utils.Check(gh.WithContext(context.Background()).DeleteHook(project, hook.Id))
+			ui.Errorf("Deleted temporary webhook %d\n", hook.Id)
+			return
+		case <-time.After(forwardPollInterval):
+		}
+
+		deliveries, err := gh.HookDeliveries(project, hook.Id, 30)
+		if err != nil {
+			ui.Errorln(err)
+			continue
+		}
+		for i := len(deliveries) - 1; i >= 0; i-- {
+			delivery := deliveries[i]
+			if seen[delivery.Id] {
+				continue
+			}
+			request, err := gh.HookDeliveryRequest(project, hook.Id, delivery.Id)
+			if err != nil {
+				ui.Errorln(err)
+				break
+			}
+			seen[delivery.Id] = true
+			relayPayload(relay, delivery.Event, request.Headers, request.Payload)
+		}
+	}
+}
+
+func forwardRepositoryEvents(gh *github.Client, project *github.Project, events []string, relay *hookRelay, interrupt chan os.Signal) {
+	wanted := map[string]bool{}
+	for _, event := range events {
+		wanted[event] = true
+	}
+	ui.Errorf("Forwarding %s events of %s to %s; press Ctrl-C to stop\n", strings.Join(events, ","), project, relay.url)
+
+	seen := map[string]bool{}
+	interval := time.Duration(0)
+	for first := true; ; first = false {
+		select {
+		case <-interrupt:
+			return
+		case <-time.After(interval):
+		}
+
+		repoEvents, pollInterval, err := gh.RepositoryEvents(project)
+		interval = forwardPollInterval
+		if pollInterval > interval {
+			interval = pollInterval
+		}
+		if err != nil {
+			ui.Errorln(err)
+			continue
+		}
+		for i := len(repoEvents) - 1; i >= 0; i-- {
+			event := repoEvents[i]
+			if seen[event.Id] {
+				continue
+			}
+			seen[event.Id] = true
+			name := webhookEventName(event.Type)
+			if first || !(wanted["*"] || wanted[name]) {
+				continue
+			}
+			relayPayload(relay, name, map[string]string{
+				"User-Agent":        github.UserAgent,
+				"X-GitHub-Event":    name,
+				"X-GitHub-Delivery": event.Id,
+			}, event.Payload)
+		}
+	}
+}
+
+func relayPayload(relay *hookRelay, event string, headers map[string]string, payload []byte) {
+	if action := payloadAction(payload); action != "" {
+		event = event + "." + action
+	}
+	status, err := relay.forward(headers, payload)
+	if err != nil {
+		ui.Errorf("%s: %s\n", event, err)
+	} else {
+		ui.Printf("%s: %s\n", event, status)
+	}
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestWebhookEventName(t *testing.T) {
+	assert.Equal(t, "push", webhookEventName("PushEvent"))
+	assert.Equal(t, "pull_request", webhookEventName("PullRequestEvent"))
+	assert.Equal(t, "pull_request_review_comment", webhookEventName("PullRequestReviewCommentEvent"))
+}
+
+func TestHookRelay_Forward(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(202)
+	}))
+	defer server.Close()
+
+	relay := &hookRelay{url: server.URL + "/hooks", secret: "s3cr3t", client: server.Client()}
+	status, err := relay.forward(map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-Hub-Signature-256": "sha256=stale",
+	}, []byte(`{"ref":"refs/heads/main"}`))
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "202 Accepted", status)
+	assert.Equal(t, "/hooks", received.URL.Path)
+	assert.Equal(t, `{"ref":"refs/heads/main"}`, string(body))
+	assert.Equal(t, "push", received.Header.Get("X-GitHub-Event"))
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "sha256=8588fd50c04ac2c191575340c4c0fe284157898def8e4d10af822f05dd7c9bb5", received.Header.Get("X-Hub-Signature-256"))
+}
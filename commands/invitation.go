@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdInvitation = &Command{
+		Run: printHelp,
+		Usage: `
+invitation list
+invitation accept <ID>|all
+`,
+		Long: `Manage your pending invitations to collaborate on repositories.
+
+## Commands:
+
+	* _list_:
+		List pending invitations as "<ID>  <REPO>  <PERMISSION>  (invited by
+		<USER>)" lines.
+
+	* _accept_:
+		Accept the invitation with <ID>, or all pending invitations.
+
+## Examples:
+		$ hub invitation list
+		$ hub invitation accept all
+
+## See also:
+
+hub-collab(1), hub(1)
+`,
+	}
+
+	cmdListInvitations = &Command{
+		Key: "list",
+		Run: listInvitations,
+	}
+
+	cmdAcceptInvitation = &Command{
+		Key: "accept",
+		Run: acceptInvitation,
+	}
+)
+
+func init() {
+	cmdInvitation.Use(cmdListInvitations)
+	cmdInvitation.Use(cmdAcceptInvitation)
+	CmdRunner.Use(cmdInvitation)
+}
+
+func listInvitations(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Println("Would request list of repository invitations")
+		return
+	}
+
+	gh := accountClient("fetching invitations")
+	invitations, err := gh.RepositoryInvitations()
+	utils.Check(err)
+
+	idWidth, repoWidth, permWidth := 0, 0, 0
+	for _, invitation := range invitations {
+		if w := len(strconv.FormatInt(invitation.Id, 10)); w > idWidth {
+			idWidth = w
+		}
+		if w := len(invitation.Repository.FullName); w > repoWidth {
+			repoWidth = w
+		}
+		if w := len(invitation.Permissions); w > permWidth {
+			permWidth = w
+		}
+	}
+	for _, invitation := range invitations {
+		inviter := ""
+		if invitation.Inviter != nil {
+			inviter = fmt.Sprintf("  (invited by %s)", invitation.Inviter.Login)
+		}
+		ui.Printf("%*d  %-*s  %-*s%s\n", idWidth, invitation.Id, repoWidth, invitation.Repository.FullName,
+			permWidth, invitation.Permissions, inviter)
+	}
+}
+
+func acceptInvitation(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	args.NoForward()
+	gh := accountClient("accepting invitation")
+	var invitations []github.Invitation
+
+	if args.GetParam(0) == "all" {
+		if args.Noop {
+			ui.Println("Would accept all repository invitations")
+			return
+		}
+		var err error
+		invitations, err = gh.RepositoryInvitations()
+		utils.Check(err)
+		if len(invitations) == 0 {
+			ui.Errorln("No pending invitations")
+			return
+		}
+	} else {
+		id, err := strconv.ParseInt(args.GetParam(0), 10, 64)
+		if err != nil {
+			utils.Check(fmt.Errorf("invalid invitation ID: '%s'", args.GetParam(0)))
+		}
+		if args.Noop {
+			ui.Printf("Would accept invitation %d\n", id)
+			return
+		}
+		invitations = []github.Invitation{{Id: id}}
+	}
+
+	for _, invitation := range invitations {
+		utils.Check(gh.AcceptInvitation(invitation.Id))
+		if invitation.Repository != nil {
+			ui.Infof("Accepted invitation to %s\n", invitation.Repository.FullName)
+		} else {
+			ui.Infof("Accepted invitation %d\n", invitation.Id)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -17,10 +19,23 @@ var (
 	cmdIssue = &Command{
 		Run: listIssues,
 		Usage: `
-issue [-a <ASSIGNEE>] [-c <CREATOR>] [-@ <USER>] [-s <STATE>] [-f <FORMAT>] [-M <MILESTONE>] [-l <LABELS>] [-d <DATE>] [-o <SORT_KEY> [-^]] [-L <LIMIT>]
-issue show [-f <FORMAT>] <NUMBER>
-issue create [-oc] [-m <MESSAGE>|-F <FILE>] [--edit] [-a <USERS>] [-M <MILESTONE>] [-l <LABELS>]
-issue labels [--color]
+issue [-a <ASSIGNEE>] [-c <CREATOR>] [-@ <USER>] [-s <STATE>] [-f <FORMAT>|--output <FORMAT>] [--reactions] [-M <MILESTONE>] [-l <LABELS>] [-d <DATE>] [-o <SORT_KEY> [-^]] [-L <LIMIT>]
+issue show [-uc] [-f <FORMAT>] [--comments] [--raw] <NUMBER>
+issue create [-oc] [-m <MESSAGE>|-F <FILE>|-t <TEMPLATE>] [--edit] [-a <USERS>] [-M <MILESTONE>] [-l <LABELS>]
+issue create --web [-m <MESSAGE>|-F <FILE>|-t <TEMPLATE>] [--edit] [-a <USERS>] [-M <MILESTONE>] [-l <LABELS>]
+issue edit [-m <MESSAGE>|-F <FILE>] [--edit] [--add-label <LABELS>] [--remove-label <LABELS>] [--add-assignee <USERS>] [--remove-assignee <USERS>] [-M <MILESTONE>] <NUMBER>
+issue edit [--add-label <LABELS>] [--remove-label <LABELS>] [--add-assignee <USERS>] [--remove-assignee <USERS>] [-M <MILESTONE>] --stdin
+issue close [--comment <TEXT>] (<NUMBER>|--stdin)
+issue reopen [--comment <TEXT>] (<NUMBER>|--stdin)
+issue lock [--reason <REASON>] (<NUMBER>|--stdin)
+issue unlock (<NUMBER>|--stdin)
+issue transfer <NUMBER> [<OWNER>/]<REPO>
+issue pin (<NUMBER>|--stdin)
+issue unpin (<NUMBER>|--stdin)
+issue react [--comment <ID>] [--remove] <NUMBER> <REACTION>
+issue labels [--color] [--output <FORMAT>]
+issue export [-s <STATE>] [-L <LIMIT>] [--ndjson]
+issue import [--resume] [<FILE>]
 `,
 		Long: `Manage GitHub Issues for the current repository.
 
@@ -29,35 +44,83 @@ issue labels [--color]
 With no arguments, show a list of open issues.
 
 	* _show_:
-		Show an existing issue specified by <NUMBER>.
+		Show an existing issue specified by <NUMBER> along with its comments and
+		reactions. In a terminal, the markdown of the issue and its comments is
+		formatted for reading.
 
 	* _create_:
 		Open an issue in the current repository.
 
+	* _edit_:
+		Update an existing issue specified by <NUMBER>. With no options, open its
+		title and description in a text editor.
+
+	* _close_:
+		Close an issue, optionally leaving a comment first.
+
+	* _reopen_:
+		Reopen a closed issue, optionally leaving a comment first.
+
+	* _lock_:
+		Lock the conversation on an issue so that only collaborators can comment.
+
+	* _unlock_:
+		Unlock the conversation on an issue.
+
+	* _transfer_:
+		Move an issue to another repository <REPO> of the same owner, and print
+		the URL of the issue in its new location.
+
+	* _pin_:
+		Pin an issue to the top of the repository's issue list.
+
+	* _unpin_:
+		Unpin a pinned issue.
+
+	* _react_:
+		React to an issue with <REACTION>: "+1", "-1", "laugh", "hooray",
+		"confused", "heart", "rocket", or "eyes".
+
 	* _labels_:
 		List the labels available in this repository.
 
+	* _export_:
+		Print all issues of the repository, including closed ones, along with
+		their comments as JSON, e.g. to migrate them with 'import'. Pull requests
+		are left out.
+
+	* _import_:
+		Create the issues exported with 'export' to <FILE>, or read from standard
+		input, in this repository. Labels and milestones are created as needed.
+		Each issue and comment starts with a note linking to the original and
+		naming its author, and issues that were closed are closed again.
+
 ## Options:
 	-a, --assignee <ASSIGNEE>
-		In list mode, display only issues assigned to <ASSIGNEE>.
+		In list mode, display only issues assigned to <ASSIGNEE>, which can be a
+		login name, "@me" for the current user, "none", or "*" for any.
 
 	-a, --assign <USERS>
 		A comma-separated list of GitHub handles to assign to the created issue.
 
 	-c, --creator <CREATOR>
-		Display only issues created by <CREATOR>.
+		Display only issues created by <CREATOR>, or by the current user with
+		"@me".
 
 	-@, --mentioned <USER>
-		Display only issues mentioning <USER>.
+		Display only issues mentioning <USER>, or the current user with "@me".
 
 	-s, --state <STATE>
-		Display issues with state <STATE> (default: "open").
+		Display issues with state <STATE> (default: "open"). With 'export',
+		export only issues with state <STATE> (default: "all").
 
 	-f, --format <FORMAT>
 		Pretty print the contents of the issues using format <FORMAT> (default:
-		"%sC%>(8)%i%Creset  %t%  l%n"). See the "PRETTY FORMATS" section of
-		git-log(1) for some additional details on how placeholders are used in
-		format. The available placeholders for issues are:
+		"%sC%>(8)%i%Creset  %t%  l%n"). Without '--format', titles and labels
+		are aligned in columns and titles are shortened to fit the width of the
+		terminal. See the "PRETTY FORMATS" section of git-log(1) for some
+		additional details on how placeholders are used in format. The available
+		placeholders for issues are:
 
 		%I: issue number
 
@@ -89,6 +152,10 @@ With no arguments, show a list of open issues.
 
 		%Nc: number of comments wrapped in parentheses, or blank string if zero.
 
+		%rc: reaction counts, e.g. "+1 (3), heart (1)"
+
+		%rN: total number of reactions
+
 		%cD: created date-only (no time of day)
 
 		%cr: created date, relative
@@ -109,6 +176,26 @@ With no arguments, show a list of open issues.
 
 		%%: a literal %
 
+		With 'show', <FORMAT> can also be "json" to print the issue as a JSON
+		object that includes its reactions and comments.
+
+	--comments
+		With 'show' and a '--format', print the comment thread after the issue.
+
+	--raw
+		With 'show', print the markdown of bodies and comments as is. By
+		default, markdown is formatted for reading when printing to a terminal.
+
+	--reactions
+		In list mode, show the reaction counts of each issue after its labels.
+		Ignored if '--format' is given; use the "%rc" placeholder instead.
+
+	--output <FORMAT>
+		Print the list of issues or labels in a structured format instead:
+		"json", "yaml", "csv", or "tsv". Issues have the fields "number", "state",
+		"title", "url", "author", "labels", "assignees", "milestone", "comments",
+		"created_at", and "updated_at". Labels have the fields "name" and "color".
+
 	--color[=<WHEN>]
 		Enable colored output even if stdout is not a terminal. <WHEN> can be one
 		of "always" (default for '--color'), "never", or "auto" (default).
@@ -131,11 +218,30 @@ With no arguments, show a list of open issues.
 		Open the issue title and description in a text editor before submitting.
 		This can be used in combination with '--message' or '--file'.
 
+	-t, --template <TEMPLATE>
+		Start from the issue template or issue form <TEMPLATE> in the
+		".github/ISSUE_TEMPLATE" directory, matched by its name or file name.
+
+		When the repository has several templates and neither '--message',
+		'--file', nor '--template' were supplied to 'issue create', a menu to choose
+		one is shown. The fields of issue forms are prompted for one by one before
+		the result opens in a text editor. Labels and assignees from the template
+		are applied unless '--labels' or '--assign' are given.
+
 	-o, --browse
 		Open the new issue in a web browser.
 
+	--web
+		Instead of creating the issue, open the form for a new issue in a web
+		browser, filled in with the title, description, template, labels,
+		assignees, and milestone given. A text editor only opens with '--edit'.
+
+	-u, --url
+		With 'show', print the URL of the issue instead of its contents.
+
 	-c, --copy
-		Put the URL of the new issue to clipboard instead of printing it.
+		Put the URL of the new issue to clipboard instead of printing it. With
+		'show', copy the URL of the issue instead of printing its contents.
 
 	-M, --milestone <NAME>
 		Display only issues for a GitHub milestone with the name <NAME>.
@@ -158,14 +264,63 @@ With no arguments, show a list of open issues.
 		Sort by ascending dates instead of descending.
 
 	-L, --limit <LIMIT>
-		Display only the first <LIMIT> issues.
+		Display only the first <LIMIT> issues. With 'export', export only the
+		<LIMIT> oldest issues.
 
 	--include-pulls
 		Include pull requests as well as issues.
 
+	--add-label <LABELS>
+		A comma-separated list of labels to add to the issue.
+
+	--remove-label <LABELS>
+		A comma-separated list of labels to remove from the issue.
+
+	--add-assignee <USERS>
+		A comma-separated list of GitHub handles to assign to the issue.
+
+	--remove-assignee <USERS>
+		A comma-separated list of GitHub handles to unassign from the issue.
+
+	--comment <TEXT>
+		Leave a comment with <TEXT> before closing or reopening the issue.
+
+	--comment <ID>
+		With 'react', react to the comment <ID> of the issue instead.
+
+	--remove
+		With 'react', remove your reaction instead of adding it.
+
+	--reason <REASON>
+		The reason for locking the issue: "off-topic", "too heated", "resolved",
+		or "spam".
+
+	--stdin
+		With 'close', 'reopen', 'lock', 'unlock', 'pin', 'unpin', or 'edit',
+		apply the command to each issue read from standard input instead of
+		<NUMBER>, one issue number or URL per line. Empty lines and comments
+		starting with "#" are skipped. Several issues are updated at once, and
+		the ones that failed are listed at the end. With 'edit', only the label,
+		assignee, and milestone options are supported. For example:
+
+			$ hub issue list -l stale -f '%I%n' | hub issue close --stdin
+			$ hub issue edit --add-label triaged --stdin < issues.txt
+
+	--ndjson
+		With 'export', print one JSON object per issue and line instead of a
+		JSON array. 'import' reads either.
+
+	--resume
+		Continue an interrupted 'import', skipping the issues that were already
+		imported in full.
+
 	--color
 		Enable colored output for labels list.
 
+	-R, --repo <REPO>
+		Manage the issues of <REPO>, given in "OWNER/NAME" format, instead of the
+		repository of the current directory. Accepted by all issue commands.
+
 ## See also:
 
 hub-pr(1), hub(1)
@@ -184,6 +339,9 @@ hub-pr(1), hub(1)
 		--include-pulls
 		-L, --limit N
 		--color
+		--output FORMAT
+		--reactions
+		-R, --repo REPO
 `,
 	}
 
@@ -199,6 +357,9 @@ hub-pr(1), hub(1)
 		-o, --browse
 		-c, --copy
 		-e, --edit
+		-t, --template NAME
+		--web
+		-R, --repo REPO
 `,
 	}
 
@@ -207,7 +368,124 @@ hub-pr(1), hub(1)
 		Run: showIssue,
 		KnownFlags: `
 		-f, --format FMT
+		--comments
+		--raw
 		--color
+		-u, --url
+		-c, --copy
+		-R, --repo REPO
+`,
+	}
+
+	cmdEditIssue = &Command{
+		Key: "edit",
+		Run: editIssue,
+		KnownFlags: `
+		-m, --message MSG
+		-F, --file FILE
+		-e, --edit
+		--add-label LIST
+		--remove-label LIST
+		--add-assignee USERS
+		--remove-assignee USERS
+		-M, --milestone NAME
+		--stdin
+		-R, --repo REPO
+`,
+	}
+
+	cmdCloseIssue = &Command{
+		Key: "close",
+		Run: closeIssue,
+		KnownFlags: `
+		--comment TEXT
+		--stdin
+		-R, --repo REPO
+`,
+	}
+
+	cmdReopenIssue = &Command{
+		Key: "reopen",
+		Run: reopenIssue,
+		KnownFlags: `
+		--comment TEXT
+		--stdin
+		-R, --repo REPO
+`,
+	}
+
+	cmdLockIssue = &Command{
+		Key: "lock",
+		Run: lockIssue,
+		KnownFlags: `
+		--reason REASON
+		--stdin
+		-R, --repo REPO
+`,
+	}
+
+	cmdUnlockIssue = &Command{
+		Key: "unlock",
+		Run: unlockIssue,
+		KnownFlags: `
+		--stdin
+		-R, --repo REPO
+`,
+	}
+
+	cmdTransferIssue = &Command{
+		Key: "transfer",
+		Run: transferIssue,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+
+	cmdPinIssue = &Command{
+		Key: "pin",
+		Run: pinIssue,
+		KnownFlags: `
+		--stdin
+		-R, --repo REPO
+`,
+	}
+
+	cmdUnpinIssue = &Command{
+		Key: "unpin",
+		Run: unpinIssue,
+		KnownFlags: `
+		--stdin
+		-R, --repo REPO
+`,
+	}
+
+	cmdReactIssue = &Command{
+		Key: "react",
+		Run: reactIssue,
+		KnownFlags: `
+		--comment ID
+		--remove
+		-R, --repo REPO
+`,
+	}
+
+	cmdExportIssue = &Command{
+		Key: "export",
+		Run: exportIssues,
+		KnownFlags: `
+		-s, --state STATE
+		-L, --limit N
+		--ndjson
+		-R, --repo REPO
+`,
+	}
+
+	cmdImportIssue = &Command{
+		Key: "import",
+		Run: importIssues,
+		KnownFlags: `
+		--resume
+		-R, --repo REPO
 `,
 	}
 
@@ -216,6 +494,8 @@ hub-pr(1), hub(1)
 		Run: listLabels,
 		KnownFlags: `
 		--color
+		--output FORMAT
+		-R, --repo REPO
 `,
 	}
 )
@@ -223,15 +503,23 @@ hub-pr(1), hub(1)
 func init() {
 	cmdIssue.Use(cmdShowIssue)
 	cmdIssue.Use(cmdCreateIssue)
+	cmdIssue.Use(cmdEditIssue)
+	cmdIssue.Use(cmdCloseIssue)
+	cmdIssue.Use(cmdReopenIssue)
+	cmdIssue.Use(cmdLockIssue)
+	cmdIssue.Use(cmdUnlockIssue)
+	cmdIssue.Use(cmdTransferIssue)
+	cmdIssue.Use(cmdPinIssue)
+	cmdIssue.Use(cmdUnpinIssue)
+	cmdIssue.Use(cmdReactIssue)
 	cmdIssue.Use(cmdLabel)
+	cmdIssue.Use(cmdExportIssue)
+	cmdIssue.Use(cmdImportIssue)
 	CmdRunner.Use(cmdIssue)
 }
 
 func listIssues(cmd *Command, args *Args) {
-	localRepo, err := github.LocalRepo()
-	utils.Check(err)
-
-	project, err := localRepo.MainProject()
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
 	gh := github.NewClient(project.Host)
@@ -239,12 +527,14 @@ func listIssues(cmd *Command, args *Args) {
 	if args.Noop {
 		ui.Printf("Would request list of issues for %s\n", project)
 	} else {
+		users := &userFilter{gh: gh}
 		filters := map[string]interface{}{}
 		if args.Flag.HasReceived("--state") {
 			filters["state"] = args.Flag.Value("--state")
 		}
 		if args.Flag.HasReceived("--assignee") {
-			filters["assignee"] = args.Flag.Value("--assignee")
+			filters["assignee"], err = users.resolve("--assignee", args.Flag.Value("--assignee"), false)
+			utils.Check(err)
 		}
 		if args.Flag.HasReceived("--milestone") {
 			milestoneValue := args.Flag.Value("--milestone")
@@ -259,10 +549,12 @@ func listIssues(cmd *Command, args *Args) {
 			}
 		}
 		if args.Flag.HasReceived("--creator") {
-			filters["creator"] = args.Flag.Value("--creator")
+			filters["creator"], err = users.resolve("--creator", args.Flag.Value("--creator"), false)
+			utils.Check(err)
 		}
 		if args.Flag.HasReceived("--mentioned") {
-			filters["mentioned"] = args.Flag.Value("--mentioned")
+			filters["mentioned"], err = users.resolve("--mentioned", args.Flag.Value("--mentioned"), false)
+			utils.Check(err)
 		}
 		if args.Flag.HasReceived("--labels") {
 			labels := commaSeparated(args.Flag.AllValues("--labels"))
@@ -292,12 +584,25 @@ func listIssues(cmd *Command, args *Args) {
 		flagIssueFormat := "%sC%>(8)%i%Creset  %t%  l%n"
 		if args.Flag.HasReceived("--format") {
 			flagIssueFormat = args.Flag.Value("--format")
+		} else if args.Flag.Bool("--reactions") {
+			flagIssueFormat = "%sC%>(8)%i%Creset  %t%  l%  rc%n"
 		}
 
 		issues, err := gh.FetchIssues(project, filters, flagIssueLimit, func(issue *github.Issue) bool {
 			return issue.PullRequest == nil || flagIssueIncludePulls
 		})
 		utils.Check(err)
+		startPager(args)
+
+		if args.Flag.HasReceived("--output") {
+			records := []ui.Record{}
+			for _, issue := range issues {
+				records = append(records, issueRecord(issue))
+			}
+			printRecords(args.Flag.Value("--output"), records, issueRecord(github.Issue{}))
+			args.NoForward()
+			return
+		}
 
 		maxNumWidth := 0
 		for _, issue := range issues {
@@ -307,22 +612,63 @@ func listIssues(cmd *Command, args *Args) {
 		}
 
 		colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
-		for _, issue := range issues {
-			ui.Print(formatIssue(issue, flagIssueFormat, colorize))
+		if args.Flag.HasReceived("--format") {
+			for _, issue := range issues {
+				ui.Print(formatIssue(issue, flagIssueFormat, colorize))
+			}
+		} else {
+			table := newIssueTable(colorize)
+			for _, issue := range issues {
+				table.AddRow(issueTableRow(issue, issueStateColor(issue.State), args.Flag.Bool("--reactions"), colorize)...)
+			}
+			utils.Check(table.Render(ui.Stdout))
 		}
 	}
 
 	args.NoForward()
 }
 
+// newIssueTable prepares the default listing of issues and pull requests,
+// which is fit to the width of the terminal by truncating titles.
+func newIssueTable(colorize bool) *ui.Table {
+	table := ui.NewTable(
+		ui.TableColumn{AlignRight: true, MinWidth: 8},
+		ui.TableColumn{Truncate: true},
+		ui.TableColumn{},
+	)
+	table.Colorize = colorize
+	table.MaxWidth = ui.TerminalWidth(os.Stdout)
+	return table
+}
+
+// issueTableRow lists the number, title, and labels of an issue, and
+// optionally its reactions, as cells of a table made with newIssueTable.
+func issueTableRow(issue github.Issue, stateColor string, reactions, colorize bool) []ui.TableCell {
+	placeholders := formatIssuePlaceholders(issue, colorize)
+	extras := []string{}
+	for _, key := range []string{"l", "rc"} {
+		if placeholders[key] != "" && (key != "rc" || reactions) {
+			extras = append(extras, placeholders[key])
+		}
+	}
+	return []ui.TableCell{
+		{Text: placeholders["i"], Color: stateColor},
+		{Text: issue.Title},
+		{Text: strings.Join(extras, "  ")},
+	}
+}
+
+func issueStateColor(state string) string {
+	if state == "closed" {
+		return themeColor("closed", "31")
+	}
+	return themeColor("open", "32")
+}
+
 func formatIssuePlaceholders(issue github.Issue, colorize bool) map[string]string {
 	var stateColorSwitch string
 	if colorize {
-		issueColor := 32
-		if issue.State == "closed" {
-			issueColor = 31
-		}
-		stateColorSwitch = fmt.Sprintf("\033[%dm", issueColor)
+		stateColorSwitch = fmt.Sprintf("\033[%sm", issueStateColor(issue.State))
 	}
 
 	var labelStrings []string
@@ -386,6 +732,8 @@ func formatIssuePlaceholders(issue github.Issue, colorize bool) map[string]strin
 		"Mt": milestoneTitle,
 		"NC": numComments,
 		"Nc": numCommentsWrapped,
+		"rc": formatReactions(issue.Reactions),
+		"rN": strconv.Itoa(reactionsTotal(issue.Reactions)),
 		"cD": createdDate,
 		"cI": createdAtISO8601,
 		"ct": createdAtUnix,
@@ -397,28 +745,34 @@ func formatIssuePlaceholders(issue github.Issue, colorize bool) map[string]strin
 	}
 }
 
-func formatPullRequestPlaceholders(pr github.PullRequest, colorize bool) map[string]string {
-	prState := pr.State
-	if prState == "open" && pr.Draft {
-		prState = "draft"
+// pullRequestState is "open", "draft", "merged", or "closed".
+func pullRequestState(pr github.PullRequest) string {
+	if pr.State == "open" && pr.Draft {
+		return "draft"
 	} else if !pr.MergedAt.IsZero() {
-		prState = "merged"
+		return "merged"
 	}
+	return pr.State
+}
+
+func pullRequestStateColor(prState string) string {
+	switch prState {
+	case "draft":
+		return themeColor("draft", "37")
+	case "merged":
+		return themeColor("merged", "35")
+	case "closed":
+		return themeColor("closed", "31")
+	}
+	return themeColor("open", "32")
+}
+
+func formatPullRequestPlaceholders(pr github.PullRequest, colorize bool) map[string]string {
+	prState := pullRequestState(pr)
 
 	var stateColorSwitch string
-	var prColor int
 	if colorize {
-		switch prState {
-		case "draft":
-			prColor = 37
-		case "merged":
-			prColor = 35
-		case "closed":
-			prColor = 31
-		default:
-			prColor = 32
-		}
-		stateColorSwitch = fmt.Sprintf("\033[%dm", prColor)
+		stateColorSwitch = fmt.Sprintf("\033[%sm", pullRequestStateColor(prState))
 	}
 
 	base := pr.Base.Ref
@@ -474,11 +828,14 @@ func showIssue(cmd *Command, args *Args) {
 		utils.Check(cmd.UsageError(""))
 	}
 
-	localRepo, err := github.LocalRepo()
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
-	project, err := localRepo.MainProject()
-	utils.Check(err)
+	if args.Flag.Bool("--url") || args.Flag.Bool("--copy") {
+		args.NoForward()
+		printBrowseOrCopy(args, project.WebURL("", "", "issues/"+issueNumber), false, args.Flag.Bool("--copy"))
+		return
+	}
 
 	gh := github.NewClient(project.Host)
 
@@ -488,10 +845,26 @@ func showIssue(cmd *Command, args *Args) {
 
 	args.NoForward()
 
+	flagShowIssueFormat := args.Flag.Value("--format")
+	withComments := !args.Flag.HasReceived("--format") || args.Flag.Bool("--comments") || flagShowIssueFormat == "json"
+
+	commentsList := []github.Comment{}
+	if withComments {
+		commentsList, err = gh.FetchComments(project, issueNumber)
+		utils.Check(err)
+	}
+
+	if flagShowIssueFormat == "json" {
+		printIssueJSON(*issue, commentsList)
+		return
+	}
+
 	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
 	if args.Flag.HasReceived("--format") {
-		flagShowIssueFormat := args.Flag.Value("--format")
 		ui.Print(formatIssue(*issue, flagShowIssueFormat, colorize))
+		if withComments {
+			printComments(args, commentsList, colorize)
+		}
 		return
 	}
 
@@ -499,8 +872,6 @@ func showIssue(cmd *Command, args *Args) {
 	if issue.State != "open" {
 		closed = "[CLOSED] "
 	}
-	commentsList, err := gh.FetchComments(project, issueNumber)
-	utils.Check(err)
 
 	ui.Printf("# %s%s\n\n", closed, issue.Title)
 	ui.Printf("* created by @%s on %s\n", issue.User.Login, issue.CreatedAt.String())
@@ -513,21 +884,93 @@ func showIssue(cmd *Command, args *Args) {
 		ui.Printf("* assignees: %s\n", strings.Join(assignees, ", "))
 	}
 
-	ui.Printf("\n%s\n", issue.Body)
+	if reactions := formatReactions(issue.Reactions); reactions != "" {
+		ui.Printf("* reactions: %s\n", reactions)
+	}
 
-	if issue.Comments > 0 {
-		ui.Printf("\n## Comments:\n")
-		for _, comment := range commentsList {
-			ui.Printf("\n### comment by @%s on %s\n\n%s\n", comment.User.Login, comment.CreatedAt.String(), comment.Body)
+	ui.Printf("\n%s\n", formatBody(args, issue.Body, colorize))
+
+	printComments(args, commentsList, colorize)
+}
+
+func printComments(args *Args, comments []github.Comment, colorize bool) {
+	if len(comments) == 0 {
+		return
+	}
+
+	ui.Printf("\n## Comments:\n")
+	for _, comment := range comments {
+		ui.Printf("\n### comment by @%s on %s\n\n%s\n", comment.User.Login, comment.CreatedAt.String(), formatBody(args, comment.Body, colorize))
+		if reactions := formatReactions(comment.Reactions); reactions != "" {
+			ui.Printf("\n* reactions: %s\n", reactions)
 		}
 	}
 }
 
-func createIssue(cmd *Command, args *Args) {
-	localRepo, err := github.LocalRepo()
-	utils.Check(err)
+// formatReactions summarizes the non-zero reaction counts, e.g. "+1 (3), heart (1)".
+func formatReactions(reactions *github.Reactions) string {
+	summary := []string{}
+	for _, r := range reactions.Counts() {
+		if r.Count > 0 {
+			summary = append(summary, fmt.Sprintf("%s (%d)", r.Content, r.Count))
+		}
+	}
+	return strings.Join(summary, ", ")
+}
+
+func reactionsTotal(reactions *github.Reactions) int {
+	total := 0
+	for _, r := range reactions.Counts() {
+		total += r.Count
+	}
+	return total
+}
+
+func reactionsRecord(reactions *github.Reactions) ui.Record {
+	record := ui.Record{}
+	for _, r := range reactions.Counts() {
+		record = record.With(r.Content, r.Count)
+	}
+	return record
+}
 
-	project, err := localRepo.MainProject()
+func printIssueJSON(issue github.Issue, comments []github.Comment) {
+	commentRecords := []ui.Record{}
+	for _, comment := range comments {
+		author := ""
+		if comment.User != nil {
+			author = comment.User.Login
+		}
+		commentRecords = append(commentRecords, ui.Record{}.
+			With("id", comment.Id).
+			With("author", author).
+			With("author_association", comment.AuthorAssociation).
+			With("body", comment.Body).
+			With("url", comment.HtmlUrl).
+			With("reactions", reactionsRecord(comment.Reactions)).
+			With("created_at", comment.CreatedAt).
+			With("updated_at", comment.UpdatedAt))
+	}
+
+	// the comment count of list output is replaced by the comments themselves
+	record := ui.Record{}
+	for _, f := range issueRecord(issue) {
+		if f.Name == "comments" {
+			f.Value = commentRecords
+		}
+		record = append(record, f)
+	}
+	record = record.
+		With("body", issue.Body).
+		With("reactions", reactionsRecord(issue.Reactions))
+
+	buf := &bytes.Buffer{}
+	utils.Check(ui.WriteRecord(buf, record))
+	ui.Print(buf.String())
+}
+
+func createIssue(cmd *Command, args *Args) {
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
 	gh := github.NewClient(project.Host)
@@ -542,8 +985,16 @@ func createIssue(cmd *Command, args *Args) {
 Write a message for this issue. The first block of
 text is the title and the rest is the description.`, project))
 
+	var issueTemplate *github.IssueTemplateFile
 	flagIssueEdit := args.Flag.Bool("--edit")
 	flagIssueMessage := args.Flag.AllValues("--message")
+	if args.Flag.HasReceived("--template") && (len(flagIssueMessage) > 0 || args.Flag.HasReceived("--file")) {
+		utils.Check(fmt.Errorf("--template can't be used with --message or --file"))
+	}
+	if args.Flag.Bool("--web") {
+		createIssueOnWeb(args, project, messageBuilder)
+		return
+	}
 	if len(flagIssueMessage) > 0 {
 		messageBuilder.Message = strings.Join(flagIssueMessage, "\n\n")
 		messageBuilder.Edit = flagIssueEdit
@@ -554,15 +1005,26 @@ text is the title and the rest is the description.`, project))
 	} else {
 		messageBuilder.Edit = true
 
-		workdir, _ := git.WorkdirName()
+		workdir := ""
+		if !args.Flag.HasReceived("--repo") {
+			workdir, _ = git.WorkdirName()
+		}
 		if workdir != "" {
-			template, err := github.ReadTemplate(github.IssueTemplate, workdir)
+			issueTemplate, err = chooseIssueTemplate(workdir, args.Flag.Value("--template"))
 			utils.Check(err)
-			if template != "" {
-				messageBuilder.Message = template
+			if issueTemplate != nil {
+				messageBuilder.Message, err = issueTemplateMessage(issueTemplate)
+				utils.Check(err)
+			} else {
+				template, err := github.ReadTemplate(github.IssueTemplate, workdir)
+				utils.Check(err)
+				if template != "" {
+					messageBuilder.Message = template
+				}
 			}
+		} else if args.Flag.HasReceived("--template") {
+			utils.Check(fmt.Errorf("--template requires the git working tree of the repository"))
 		}
-
 	}
 
 	title, body, err := messageBuilder.Extract()
@@ -578,11 +1040,17 @@ text is the title and the rest is the description.`, project))
 	}
 
 	flagIssueLabels := commaSeparated(args.Flag.AllValues("--labels"))
+	if len(flagIssueLabels) == 0 && issueTemplate != nil {
+		flagIssueLabels = issueTemplate.Labels
+	}
 	if len(flagIssueLabels) > 0 {
 		params["labels"] = flagIssueLabels
 	}
 
 	flagIssueAssignees := commaSeparated(args.Flag.AllValues("--assign"))
+	if len(flagIssueAssignees) == 0 && issueTemplate != nil {
+		flagIssueAssignees = issueTemplate.Assignees
+	}
 	if len(flagIssueAssignees) > 0 {
 		params["assignees"] = flagIssueAssignees
 	}
@@ -608,11 +1076,461 @@ text is the title and the rest is the description.`, project))
 	messageBuilder.Cleanup()
 }
 
-func listLabels(cmd *Command, args *Args) {
-	localRepo, err := github.LocalRepo()
+// createIssueOnWeb opens the form for a new issue in a web browser, filled in
+// with what was given on the command line.
+func createIssueOnWeb(args *Args, project *github.Project, messageBuilder *github.MessageBuilder) {
+	fields := map[string]string{
+		"labels":    strings.Join(commaSeparated(args.Flag.AllValues("--labels")), ","),
+		"assignees": strings.Join(commaSeparated(args.Flag.AllValues("--assign")), ","),
+		"milestone": args.Flag.Value("--milestone"),
+	}
+
+	flagIssueMessage := args.Flag.AllValues("--message")
+	if len(flagIssueMessage) > 0 || args.Flag.HasReceived("--file") || args.Flag.Bool("--edit") {
+		var err error
+		if len(flagIssueMessage) > 0 {
+			messageBuilder.Message = strings.Join(flagIssueMessage, "\n\n")
+		} else if args.Flag.HasReceived("--file") {
+			messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
+			utils.Check(err)
+		}
+		messageBuilder.Edit = args.Flag.Bool("--edit")
+		fields["title"], fields["body"], err = messageBuilder.Extract()
+		utils.Check(err)
+		defer messageBuilder.Cleanup()
+	} else if args.Flag.HasReceived("--template") {
+		// GitHub looks up templates by their file name
+		fields["template"] = args.Flag.Value("--template")
+		if workdir, err := git.WorkdirName(); err == nil && !args.Flag.HasReceived("--repo") {
+			template, err := chooseIssueTemplate(workdir, args.Flag.Value("--template"))
+			utils.Check(err)
+			fields["template"] = filepath.Base(template.Path)
+		}
+	}
+
+	args.NoForward()
+	printBrowseOrCopy(args, prefilledFormURL(project.WebURL("", "", "issues/new"), fields), true, false)
+}
+
+// issueNumberFromArgs parses the <NUMBER> parameter of issue subcommands.
+func issueNumberFromArgs(cmd *Command, args *Args) int {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError("you must specify an issue number"))
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(args.GetParam(0), "#"))
+	if err != nil {
+		utils.Check(fmt.Errorf("invalid issue number: '%s'", args.GetParam(0)))
+	}
+	return number
+}
+
+func editIssue(cmd *Command, args *Args) {
+	if args.Flag.Bool("--stdin") {
+		editIssuesFromStdin(cmd, args)
+		return
+	}
+
+	number := issueNumberFromArgs(cmd, args)
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	addLabels := commaSeparated(args.Flag.AllValues("--add-label"))
+	removeLabels := commaSeparated(args.Flag.AllValues("--remove-label"))
+	addAssignees := commaSeparated(args.Flag.AllValues("--add-assignee"))
+	removeAssignees := commaSeparated(args.Flag.AllValues("--remove-assignee"))
+
+	editText := args.Flag.HasReceived("--message") || args.Flag.HasReceived("--file") || args.Flag.Bool("--edit")
+	if !editText && len(addLabels) == 0 && len(removeLabels) == 0 &&
+		len(addAssignees) == 0 && len(removeAssignees) == 0 && !args.Flag.HasReceived("--milestone") {
+		editText = true
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would update issue #%d for %s\n", number, project)
+		return
+	}
+
+	params := map[string]interface{}{}
+
+	if args.Flag.HasReceived("--milestone") {
+		milestoneValue := args.Flag.Value("--milestone")
+		if milestoneValue == "none" {
+			params["milestone"] = nil
+		} else {
+			milestoneNumber, err := milestoneValueToNumber(milestoneValue, gh, project)
+			utils.Check(err)
+			params["milestone"] = milestoneNumber
+		}
+	}
+
+	var messageBuilder *github.MessageBuilder
+	if editText {
+		messageBuilder = &github.MessageBuilder{
+			Filename: "ISSUE_EDITMSG",
+			Title:    "issue",
+		}
+
+		messageBuilder.AddCommentedSection(fmt.Sprintf(`Editing issue #%d for %s
+
+Update the message for this issue. The first block of
+text is the title and the rest is the description.`, number, project))
+
+		flagIssueMessage := args.Flag.AllValues("--message")
+		if len(flagIssueMessage) > 0 {
+			messageBuilder.Message = strings.Join(flagIssueMessage, "\n\n")
+			messageBuilder.Edit = args.Flag.Bool("--edit")
+		} else if args.Flag.HasReceived("--file") {
+			messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
+			utils.Check(err)
+			messageBuilder.Edit = args.Flag.Bool("--edit")
+		} else {
+			issue, err := gh.FetchIssue(project, strconv.Itoa(number))
+			utils.Check(err)
+			messageBuilder.Message = fmt.Sprintf("%s\n\n%s", issue.Title, issue.Body)
+			messageBuilder.Edit = true
+		}
+
+		title, body, err := messageBuilder.Extract()
+		utils.Check(err)
+
+		if title == "" {
+			utils.Check(fmt.Errorf("Aborting due to empty issue title"))
+		}
+		params["title"] = title
+		params["body"] = body
+	}
+
+	if len(params) > 0 {
+		utils.Check(gh.UpdateIssue(project, number, params))
+	}
+	if len(addLabels) > 0 {
+		utils.Check(gh.AddLabels(project, number, addLabels))
+	}
+	for _, label := range removeLabels {
+		utils.Check(gh.RemoveLabel(project, number, label))
+	}
+	if len(addAssignees) > 0 {
+		utils.Check(gh.AddAssignees(project, number, addAssignees))
+	}
+	if len(removeAssignees) > 0 {
+		utils.Check(gh.RemoveAssignees(project, number, removeAssignees))
+	}
+
+	if messageBuilder != nil {
+		messageBuilder.Cleanup()
+	}
+
+	ui.Println(project.WebURL("", "", fmt.Sprintf("issues/%d", number)))
+}
+
+// editIssuesFromStdin changes the labels, assignees, or milestone of the
+// issues given on standard input. Their title and description can only be
+// edited one at a time.
+func editIssuesFromStdin(cmd *Command, args *Args) {
+	if args.Flag.HasReceived("--message") || args.Flag.HasReceived("--file") || args.Flag.Bool("--edit") {
+		utils.Check(fmt.Errorf("--stdin can't be combined with --message, --file, or --edit"))
+	}
+
+	addLabels := commaSeparated(args.Flag.AllValues("--add-label"))
+	removeLabels := commaSeparated(args.Flag.AllValues("--remove-label"))
+	addAssignees := commaSeparated(args.Flag.AllValues("--add-assignee"))
+	removeAssignees := commaSeparated(args.Flag.AllValues("--remove-assignee"))
+	setMilestone := args.Flag.HasReceived("--milestone")
+	if len(addLabels) == 0 && len(removeLabels) == 0 &&
+		len(addAssignees) == 0 && len(removeAssignees) == 0 && !setMilestone {
+		utils.Check(cmd.UsageError("--stdin requires a label, assignee, or milestone option"))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	// The milestone is looked up once, so it has to be one of the current
+	// repository.
+	var milestone interface{}
+	if setMilestone && !args.Noop {
+		if value := args.Flag.Value("--milestone"); value != "none" {
+			milestone, err = milestoneValueToNumber(value, github.NewClient(project.Host), project)
+			utils.Check(err)
+		}
+	}
+
+	updateIssuesFromStdin(args, project, "update", "Updated", func(gh *github.Client, target issueTarget) error {
+		if setMilestone {
+			if !target.project.SameAs(project) {
+				return fmt.Errorf("can't set the milestone of an issue outside of %s", project)
+			}
+			if err := gh.UpdateIssue(target.project, target.number, map[string]interface{}{"milestone": milestone}); err != nil {
+				return err
+			}
+		}
+		if len(addLabels) > 0 {
+			if err := gh.AddLabels(target.project, target.number, addLabels); err != nil {
+				return err
+			}
+		}
+		for _, label := range removeLabels {
+			if err := gh.RemoveLabel(target.project, target.number, label); err != nil {
+				return err
+			}
+		}
+		if len(addAssignees) > 0 {
+			if err := gh.AddAssignees(target.project, target.number, addAssignees); err != nil {
+				return err
+			}
+		}
+		if len(removeAssignees) > 0 {
+			return gh.RemoveAssignees(target.project, target.number, removeAssignees)
+		}
+		return nil
+	})
+}
+
+func closeIssue(cmd *Command, args *Args) {
+	setIssueState(cmd, args, "closed")
+}
+
+func reopenIssue(cmd *Command, args *Args) {
+	setIssueState(cmd, args, "open")
+}
+
+func setIssueState(cmd *Command, args *Args, state string) {
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
-	project, err := localRepo.MainProject()
+	action, verb := "close", "Closed"
+	if state == "open" {
+		action, verb = "reopen", "Reopened"
+	}
+
+	comment := args.Flag.Value("--comment")
+	update := func(gh *github.Client, target issueTarget) error {
+		if comment != "" {
+			if _, err := gh.CreateComment(target.project, target.number, comment); err != nil {
+				return err
+			}
+		}
+		return gh.UpdateIssue(target.project, target.number, map[string]interface{}{"state": state})
+	}
+
+	if args.Flag.Bool("--stdin") {
+		updateIssuesFromStdin(args, project, action, verb, update)
+		return
+	}
+
+	number := issueNumberFromArgs(cmd, args)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set state of issue #%d for %s to %s\n", number, project, state)
+		return
+	}
+
+	utils.Check(update(gh, issueTarget{project, number}))
+	ui.Infof("%s issue #%d\n", verb, number)
+}
+
+func lockIssue(cmd *Command, args *Args) {
+	reason := args.Flag.Value("--reason")
+	switch reason {
+	case "", "off-topic", "too heated", "resolved", "spam":
+	default:
+		utils.Check(fmt.Errorf("invalid lock reason: '%s'", reason))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	update := func(gh *github.Client, target issueTarget) error {
+		return gh.LockIssue(target.project, target.number, reason)
+	}
+
+	if args.Flag.Bool("--stdin") {
+		updateIssuesFromStdin(args, project, "lock", "Locked", update)
+		return
+	}
+
+	number := issueNumberFromArgs(cmd, args)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would lock issue #%d for %s\n", number, project)
+		return
+	}
+
+	utils.Check(update(gh, issueTarget{project, number}))
+	ui.Infof("Locked issue #%d\n", number)
+}
+
+func unlockIssue(cmd *Command, args *Args) {
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	update := func(gh *github.Client, target issueTarget) error {
+		return gh.UnlockIssue(target.project, target.number)
+	}
+
+	if args.Flag.Bool("--stdin") {
+		updateIssuesFromStdin(args, project, "unlock", "Unlocked", update)
+		return
+	}
+
+	number := issueNumberFromArgs(cmd, args)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would unlock issue #%d for %s\n", number, project)
+		return
+	}
+
+	utils.Check(update(gh, issueTarget{project, number}))
+	ui.Infof("Unlocked issue #%d\n", number)
+}
+
+func transferIssue(cmd *Command, args *Args) {
+	number := issueNumberFromArgs(cmd, args)
+	if args.ParamsSize() < 2 {
+		utils.Check(cmd.UsageError("you must specify a target repository"))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	target := args.GetParam(1)
+	if !strings.Contains(target, "/") {
+		target = fmt.Sprintf("%s/%s", project.Owner, target)
+	}
+	targetProject := github.NewProject(target, "", project.Host)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would transfer issue #%d from %s to %s\n", number, project, targetProject)
+		return
+	}
+
+	issue, err := gh.FetchIssue(project, strconv.Itoa(number))
+	utils.Check(err)
+	repo, err := gh.Repository(targetProject)
+	utils.Check(err)
+
+	transferred, err := gh.TransferIssue(issue, repo)
+	utils.Check(err)
+	ui.Println(transferred.HtmlUrl)
+}
+
+func pinIssue(cmd *Command, args *Args) {
+	setIssuePinned(cmd, args, true)
+}
+
+func unpinIssue(cmd *Command, args *Args) {
+	setIssuePinned(cmd, args, false)
+}
+
+func setIssuePinned(cmd *Command, args *Args, pinned bool) {
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	action, verb := "unpin", "Unpinned"
+	if pinned {
+		action, verb = "pin", "Pinned"
+	}
+
+	update := func(gh *github.Client, target issueTarget) error {
+		issue, err := gh.FetchIssue(target.project, strconv.Itoa(target.number))
+		if err != nil {
+			return err
+		}
+		return gh.SetIssuePinned(issue, pinned)
+	}
+
+	if args.Flag.Bool("--stdin") {
+		updateIssuesFromStdin(args, project, action, verb, update)
+		return
+	}
+
+	number := issueNumberFromArgs(cmd, args)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would %s issue #%d for %s\n", action, number, project)
+		return
+	}
+
+	utils.Check(update(gh, issueTarget{project, number}))
+	ui.Infof("%s issue #%d\n", verb, number)
+}
+
+func reactIssue(cmd *Command, args *Args) {
+	if args.ParamsSize() != 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+	react(args, "issue", issueNumberFromArgs(cmd, args))
+}
+
+// react adds or removes the reaction given as the second parameter to an
+// issue or pull request, or to one of its comments.
+func react(args *Args, kind string, number int) {
+	content, err := github.NormalizeReaction(args.GetParam(1))
+	utils.Check(err)
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	subject := &github.ReactionSubject{Project: project, Number: number}
+	target := fmt.Sprintf("%s #%d", kind, number)
+	if args.Flag.HasReceived("--comment") {
+		subject.CommentId, err = strconv.Atoi(args.Flag.Value("--comment"))
+		if err != nil || subject.CommentId < 1 {
+			utils.Check(fmt.Errorf("invalid comment ID: '%s'", args.Flag.Value("--comment")))
+		}
+		target = fmt.Sprintf("comment %d on %s", subject.CommentId, target)
+	}
+	remove := args.Flag.Bool("--remove")
+
+	args.NoForward()
+	if args.Noop {
+		if remove {
+			ui.Printf("Would remove %s reaction from %s for %s\n", content, target, project)
+		} else {
+			ui.Printf("Would react with %s to %s for %s\n", content, target, project)
+		}
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	if remove {
+		user, err := gh.CurrentUser()
+		utils.Check(err)
+		removed, err := gh.RemoveReaction(subject, content, user.Login)
+		utils.Check(err)
+		if removed {
+			ui.Infof("Removed %s reaction from %s\n", content, target)
+		} else {
+			ui.Printf("You haven't reacted with %s to %s\n", content, target)
+		}
+		return
+	}
+
+	created, err := gh.AddReaction(subject, content)
+	utils.Check(err)
+	if created {
+		ui.Infof("Reacted with %s to %s\n", content, target)
+	} else {
+		ui.Infof("Already reacted with %s to %s\n", content, target)
+	}
+}
+
+func listLabels(cmd *Command, args *Args) {
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
 	gh := github.NewClient(project.Host)
@@ -626,6 +1544,15 @@ func listLabels(cmd *Command, args *Args) {
 	labels, err := gh.FetchLabels(project)
 	utils.Check(err)
 
+	if args.Flag.HasReceived("--output") {
+		records := []ui.Record{}
+		for _, label := range labels {
+			records = append(records, labelRecord(label))
+		}
+		printRecords(args.Flag.Value("--output"), records, labelRecord(github.IssueLabel{}))
+		return
+	}
+
 	flagLabelsColorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
 	for _, label := range labels {
 		ui.Print(formatLabel(label, flagLabelsColorize))
@@ -633,8 +1560,14 @@ func listLabels(cmd *Command, args *Args) {
 }
 
 func colorizeOutput(colorSet bool, when string) bool {
-	if !colorSet || when == "auto" {
-		return ui.IsTerminal(os.Stdout)
+	if !colorSet {
+		if when = github.ConfigSetting("color"); when == "" {
+			when = "auto"
+		}
+	}
+	if when == "auto" {
+		// https://no-color.org
+		return ui.IsTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
 	} else if when == "never" {
 		return false
 	} else {
@@ -651,7 +1584,17 @@ func formatLabel(label github.IssueLabel, colorize bool) string {
 	return fmt.Sprintf("%s\n", label.Name)
 }
 
+// colorizeLabel shows a label in its color according to the "color.labels"
+// setting: as the "background" of the label name (default), as the
+// "foreground" color of the name, or not at all with "none".
 func colorizeLabel(label github.IssueLabel, color *utils.Color) string {
+	switch github.ConfigSetting("color.labels") {
+	case "foreground":
+		return fmt.Sprintf("\033[38;%sm%s\033[m", utils.RgbToTermColorCode(color), label.Name)
+	case "none":
+		return label.Name
+	}
+
 	bgColorCode := utils.RgbToTermColorCode(color)
 	fgColor := pickHighContrastTextColor(color)
 	fgColorCode := utils.RgbToTermColorCode(fgColor)
@@ -659,6 +1602,15 @@ func colorizeLabel(label github.IssueLabel, color *utils.Color) string {
 		fgColorCode, bgColorCode, label.Name)
 }
 
+// themeColor is the SGR parameter of the color set for name, such as "open"
+// or "merged", with the "color.<NAME>" setting, or else defaultCode.
+func themeColor(name, defaultCode string) string {
+	if code, ok := ui.ColorCode(github.ConfigSetting("color." + name)); ok {
+		return code
+	}
+	return defaultCode
+}
+
 type contrastCandidate struct {
 	color    *utils.Color
 	contrast float64
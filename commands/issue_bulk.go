@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+// issueTarget is one of the issues that an issue subcommand given '--stdin'
+// applies to.
+type issueTarget struct {
+	project *github.Project
+	number  int
+}
+
+// name identifies the issue in messages, with its repository only if it
+// isn't the current one.
+func (t issueTarget) name(current *github.Project) string {
+	if t.project.SameAs(current) {
+		return fmt.Sprintf("#%d", t.number)
+	}
+	return fmt.Sprintf("%s#%d", t.project, t.number)
+}
+
+var issuePathRegexp = regexp.MustCompile(`^(?:issues|pull)/(\d+)`)
+
+// readIssueTargets reads one issue per line: either its number, optionally
+// prefixed with "#", in project, or the URL of an issue or pull request on the
+// same host. Empty lines and other lines starting with "#" are skipped.
+func readIssueTargets(r io.Reader, project *github.Project) ([]issueTarget, error) {
+	targets := []issueTarget{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if number, err := strconv.Atoi(strings.TrimPrefix(text, "#")); err == nil && number > 0 {
+			targets = append(targets, issueTarget{project, number})
+			continue
+		}
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if url, err := github.ParseURL(text); err == nil && strings.EqualFold(url.Project.Host, project.Host) {
+			if m := issuePathRegexp.FindStringSubmatch(url.ProjectPath()); m != nil {
+				number, _ := strconv.Atoi(m[1])
+				targets = append(targets, issueTarget{url.Project, number})
+				continue
+			}
+		}
+		return nil, fmt.Errorf("invalid issue on line %d: '%s'", line, text)
+	}
+	return targets, scanner.Err()
+}
+
+// updateIssuesFromStdin applies update to each issue read from standard
+// input, a few at a time, showing how many are done. Once all of them are,
+// it reports each success in the order the issues were given, as "<verb>
+// issue #<NUMBER>", and then all failures together.
+func updateIssuesFromStdin(args *Args, project *github.Project, action, verb string, update func(gh *github.Client, target issueTarget) error) {
+	if !args.IsParamsEmpty() {
+		utils.Check(fmt.Errorf("--stdin can't be combined with an issue number"))
+	}
+
+	targets, err := readIssueTargets(os.Stdin, project)
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		for _, target := range targets {
+			ui.Printf("Would %s issue #%d for %s\n", action, target.number, target.project)
+		}
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	errs := make([]error, len(targets))
+	progress := ui.NewProgressCounter("Updating issues", len(targets))
+	github.ParallelFetch(len(targets), github.ParallelFetchLimit, func(i int) error {
+		errs[i] = update(gh, targets[i])
+		progress.Add(1)
+		return nil
+	})
+	progress.Done()
+
+	failed := map[string]string{}
+	for i, target := range targets {
+		if errs[i] != nil {
+			failed[target.name(project)] = errs[i].Error()
+		} else {
+			ui.Infof("%s issue %s\n", verb, target.name(project))
+		}
+	}
+	if len(failed) > 0 {
+		utils.Check(&github.BulkError{Failed: failed})
+	}
+}
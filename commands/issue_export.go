@@ -0,0 +1,399 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+// exportedIssue is the format in which 'issue export' writes issues and
+// 'issue import' reads them.
+type exportedIssue struct {
+	Number    int                 `json:"number"`
+	URL       string              `json:"url"`
+	Title     string              `json:"title"`
+	Body      string              `json:"body"`
+	State     string              `json:"state"`
+	Author    string              `json:"author"`
+	Labels    []github.IssueLabel `json:"labels"`
+	Assignees []string            `json:"assignees"`
+	Milestone *exportedMilestone  `json:"milestone"`
+	CreatedAt time.Time           `json:"created_at"`
+	ClosedAt  *time.Time          `json:"closed_at"`
+	Comments  []exportedComment   `json:"comments"`
+}
+
+type exportedMilestone struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	DueOn       *time.Time `json:"due_on"`
+}
+
+type exportedComment struct {
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	URL       string    `json:"url"`
+}
+
+func newExportedIssue(issue github.Issue, comments []github.Comment) exportedIssue {
+	exported := exportedIssue{
+		Number:    issue.Number,
+		URL:       issue.HtmlUrl,
+		Title:     issue.Title,
+		Body:      issue.Body,
+		State:     issue.State,
+		Labels:    issue.Labels,
+		Assignees: userLogins(issue.Assignees),
+		CreatedAt: issue.CreatedAt,
+		Comments:  []exportedComment{},
+	}
+	if exported.Labels == nil {
+		exported.Labels = []github.IssueLabel{}
+	}
+	if issue.User != nil {
+		exported.Author = issue.User.Login
+	}
+	if !issue.ClosedAt.IsZero() {
+		closedAt := issue.ClosedAt
+		exported.ClosedAt = &closedAt
+	}
+	if m := issue.Milestone; m != nil {
+		exported.Milestone = &exportedMilestone{
+			Title:       m.Title,
+			Description: m.Description,
+			State:       m.State,
+			DueOn:       m.DueOn,
+		}
+	}
+	for _, comment := range comments {
+		author := ""
+		if comment.User != nil {
+			author = comment.User.Login
+		}
+		exported.Comments = append(exported.Comments, exportedComment{
+			Author:    author,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+			URL:       comment.HtmlUrl,
+		})
+	}
+	return exported
+}
+
+func exportIssues(cmd *Command, args *Args) {
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	state := "all"
+	if args.Flag.HasReceived("--state") {
+		state = args.Flag.Value("--state")
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would export %s issues of %s\n", state, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	exported, err := fetchExportedIssues(gh, project, state, args.Flag.Int("--limit"))
+	utils.Check(err)
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if args.Flag.Bool("--ndjson") {
+		for _, issue := range exported {
+			utils.Check(enc.Encode(issue))
+		}
+	} else {
+		enc.SetIndent("", "  ")
+		utils.Check(enc.Encode(exported))
+	}
+	ui.Print(buf.String())
+}
+
+// fetchExportedIssues fetches the issues of project in the order they were
+// opened, together with their comments.
+func fetchExportedIssues(gh *github.Client, project *github.Project, state string, limit int) ([]exportedIssue, error) {
+	filters := map[string]interface{}{
+		"state":     state,
+		"sort":      "created",
+		"direction": "asc",
+	}
+	spinner := ui.StartSpinner(fmt.Sprintf("Fetching issues of %s ...", project))
+	issues, err := gh.FetchIssues(project, filters, limit, func(issue *github.Issue) bool {
+		return issue.PullRequest == nil
+	})
+	spinner.Stop()
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make([]exportedIssue, len(issues))
+	progress := ui.NewProgressCounter("Fetching comments", len(issues))
+	err = github.ParallelFetch(len(issues), github.ParallelFetchLimit, func(i int) error {
+		defer progress.Add(1)
+		var comments []github.Comment
+		if issues[i].Comments > 0 {
+			var err error
+			if comments, err = gh.FetchComments(project, strconv.Itoa(issues[i].Number)); err != nil {
+				return err
+			}
+		}
+		exported[i] = newExportedIssue(issues[i], comments)
+		return nil
+	})
+	progress.Done()
+	return exported, err
+}
+
+// readExportedIssues reads issues written by 'issue export', either as a JSON
+// array or as one JSON object per line.
+func readExportedIssues(r io.Reader) ([]exportedIssue, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []exportedIssue{}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &issues); err != nil {
+			return nil, fmt.Errorf("invalid issues: %s", err)
+		}
+		return issues, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		issue := exportedIssue{}
+		if err := json.Unmarshal(text, &issue); err != nil {
+			return nil, fmt.Errorf("invalid issue on line %d: %s", line, err)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, scanner.Err()
+}
+
+// importedIssueBody is the description of an imported issue, which links to
+// the original so that GitHub cross-references the two.
+func importedIssueBody(issue exportedIssue) string {
+	header := fmt.Sprintf("_Imported from %s", issue.URL)
+	if issue.Author != "" {
+		header += fmt.Sprintf(", originally opened by @%s on %s", issue.Author, issue.CreatedAt.Format("2006-01-02"))
+	}
+	header += "._"
+	if issue.Body == "" {
+		return header
+	}
+	return header + "\n\n" + issue.Body
+}
+
+func importedCommentBody(comment exportedComment) string {
+	header := fmt.Sprintf("_Originally posted by @%s on %s", comment.Author, comment.CreatedAt.Format("2006-01-02"))
+	if comment.URL != "" {
+		header += fmt.Sprintf(" in %s", comment.URL)
+	}
+	return header + "._\n\n" + comment.Body
+}
+
+func importIssues(cmd *Command, args *Args) {
+	if args.ParamsSize() > 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	filename := "-"
+	if !args.IsParamsEmpty() {
+		filename = args.FirstParam()
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	input := io.Reader(os.Stdin)
+	if filename != "-" {
+		f, err := os.Open(filename)
+		utils.Check(err)
+		defer f.Close()
+		input = f
+	}
+	issues, err := readExportedIssues(input)
+	utils.Check(err)
+
+	for _, issue := range issues {
+		if issue.URL == "" {
+			utils.Check(fmt.Errorf("issue #%d has no url", issue.Number))
+		}
+	}
+
+	args.NoForward()
+	if args.Noop {
+		for _, issue := range issues {
+			ui.Printf("Would import %s into %s\n", issue.URL, project)
+		}
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	utils.Check(importExportedIssues(gh, project, issues, newBulkJob(args, "issue-import-"+project.String())))
+}
+
+// importExportedIssues creates issues in project from exported ones, along
+// with the labels and milestones they use.
+func importExportedIssues(gh *github.Client, project *github.Project, issues []exportedIssue, job *github.BulkJob) error {
+	labels := []github.IssueLabel{}
+	milestones := []exportedMilestone{}
+	byURL := map[string]exportedIssue{}
+	urls := []string{}
+	for _, issue := range issues {
+		labels = append(labels, issue.Labels...)
+		if issue.Milestone != nil {
+			milestones = append(milestones, *issue.Milestone)
+		}
+		byURL[issue.URL] = issue
+		urls = append(urls, issue.URL)
+	}
+
+	if err := createMissingLabels(gh, project, labels); err != nil {
+		return err
+	}
+	milestoneNumbers, err := createMissingMilestones(gh, project, milestones)
+	if err != nil {
+		return err
+	}
+
+	// An import that hit a rate limit is retried, so remember how far it got
+	// to not create the issue or its comments twice.
+	created := map[string]*github.Issue{}
+	commented := map[string]int{}
+	return job.Run(urls, func(url string) error {
+		issue := byURL[url]
+		if created[url] == nil {
+			params := map[string]interface{}{
+				"title":     issue.Title,
+				"body":      importedIssueBody(issue),
+				"labels":    labelNames(issue.Labels),
+				"assignees": issue.Assignees,
+			}
+			if issue.Milestone != nil {
+				params["milestone"] = milestoneNumbers[issue.Milestone.Title]
+			}
+			newIssue, err := gh.CreateIssue(project, params)
+			if err != nil {
+				return err
+			}
+			created[url] = newIssue
+		}
+		newIssue := created[url]
+
+		for _, comment := range issue.Comments[commented[url]:] {
+			if _, err := gh.CreateComment(project, newIssue.Number, importedCommentBody(comment)); err != nil {
+				return err
+			}
+			commented[url]++
+		}
+
+		if issue.State == "closed" && newIssue.State != "closed" {
+			if err := gh.UpdateIssue(project, newIssue.Number, map[string]interface{}{"state": "closed"}); err != nil {
+				return err
+			}
+			newIssue.State = "closed"
+		}
+
+		ui.Infof("Imported %s as %s\n", url, newIssue.HtmlUrl)
+		return nil
+	})
+}
+
+func labelNames(labels []github.IssueLabel) []string {
+	names := []string{}
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+// createMissingLabels creates the labels that project doesn't have yet with
+// their original color.
+func createMissingLabels(gh *github.Client, project *github.Project, labels []github.IssueLabel) error {
+	existing, err := gh.FetchLabels(project)
+	if err != nil {
+		return err
+	}
+	found := map[string]bool{}
+	for _, label := range existing {
+		found[strings.ToLower(label.Name)] = true
+	}
+
+	for _, label := range labels {
+		if found[strings.ToLower(label.Name)] {
+			continue
+		}
+		if label.Color == "" {
+			label.Color = "ededed"
+		}
+		if err := gh.CreateLabel(project, label); err != nil {
+			return err
+		}
+		ui.Infof("Created label '%s'\n", label.Name)
+		found[strings.ToLower(label.Name)] = true
+	}
+	return nil
+}
+
+// createMissingMilestones creates the milestones that project doesn't have
+// yet, and returns the numbers of all of them by title.
+func createMissingMilestones(gh *github.Client, project *github.Project, milestones []exportedMilestone) (map[string]int, error) {
+	numbers := map[string]int{}
+	if len(milestones) == 0 {
+		return numbers, nil
+	}
+
+	existing, err := gh.FetchAllMilestones(project)
+	if err != nil {
+		return nil, err
+	}
+	for _, milestone := range existing {
+		numbers[milestone.Title] = milestone.Number
+	}
+
+	for _, m := range milestones {
+		if numbers[m.Title] != 0 {
+			continue
+		}
+		params := map[string]interface{}{
+			"title":       m.Title,
+			"description": m.Description,
+		}
+		if m.State != "" {
+			params["state"] = m.State
+		}
+		if m.DueOn != nil {
+			params["due_on"] = m.DueOn.Format(time.RFC3339)
+		}
+		milestone, err := gh.CreateMilestone(project, params)
+		if err != nil {
+			return nil, err
+		}
+		ui.Infof("Created milestone '%s'\n", m.Title)
+		numbers[m.Title] = milestone.Number
+	}
+	return numbers, nil
+}
@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+)
+
+// chooseIssueTemplate picks one of the templates from `.github/ISSUE_TEMPLATE`:
+// the one named by '--template', the only one available when blank issues are
+// disabled, or else whichever the user selects from a menu. It returns nil
+// when the repository has no such templates, a blank issue was chosen, or
+// there is no terminal to show the menu on.
+func chooseIssueTemplate(workdir, name string) (*github.IssueTemplateFile, error) {
+	templates, blankAllowed, err := github.ReadIssueTemplates(workdir)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != "" {
+		for _, template := range templates {
+			base := strings.TrimSuffix(filepath.Base(template.Path), filepath.Ext(template.Path))
+			if strings.EqualFold(template.Name, name) || strings.EqualFold(base, name) {
+				return template, nil
+			}
+		}
+		return nil, fmt.Errorf("no issue template found named '%s'", name)
+	}
+
+	if len(templates) == 0 {
+		return nil, nil
+	} else if len(templates) == 1 && !blankAllowed {
+		return templates[0], nil
+	}
+
+	if !ui.IsTerminal(os.Stdin) {
+		return nil, nil
+	}
+
+	ui.Println("Choose an issue template:")
+	for i, template := range templates {
+		line := fmt.Sprintf("  %d. %s", i+1, template.Name)
+		if template.About != "" {
+			line += " - " + template.About
+		}
+		ui.Println(line)
+	}
+	choices := len(templates)
+	if blankAllowed {
+		choices++
+		ui.Printf("  %d. Open a blank issue\n", choices)
+	}
+
+	input := bufio.NewReader(os.Stdin)
+	for {
+		answer, err := promptLine(input, fmt.Sprintf("Template [1-%d]: ", choices))
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err != nil || n < 1 || n > choices {
+			ui.Errorf("Please enter a number between 1 and %d.\n", choices)
+			continue
+		}
+		if n > len(templates) {
+			return nil, nil
+		}
+		return templates[n-1], nil
+	}
+}
+
+// issueTemplateMessage returns the initial text of the issue editor for a
+// template. The fields of issue forms are asked for one by one on the
+// terminal and rendered to Markdown the same way GitHub does.
+func issueTemplateMessage(template *github.IssueTemplateFile) (string, error) {
+	if !template.IsForm() {
+		return template.Title + "\n\n" + template.Body, nil
+	}
+	if !ui.IsTerminal(os.Stdin) {
+		return "", fmt.Errorf("can't fill in issue form '%s' without a terminal", template.Name)
+	}
+
+	input := bufio.NewReader(os.Stdin)
+	title, err := promptLine(input, promptWithDefault("Title", template.Title))
+	if err != nil {
+		return "", err
+	}
+	if title == "" {
+		title = template.Title
+	}
+
+	answers := map[int]interface{}{}
+	for i := range template.Fields {
+		field := &template.Fields[i]
+		if field.Type == "markdown" {
+			continue
+		}
+		for {
+			answer, err := promptFormField(input, field)
+			if err != nil {
+				return "", err
+			}
+			if field.Validations.Required && isBlankAnswer(answer) {
+				ui.Errorf("'%s' is required.\n", field.Attributes.Label)
+				continue
+			}
+			answers[i] = answer
+			break
+		}
+	}
+
+	return title + "\n\n" + github.RenderIssueForm(template, answers), nil
+}
+
+func promptFormField(input *bufio.Reader, field *github.IssueFormField) (interface{}, error) {
+	label := field.Attributes.Label
+	if field.Validations.Required {
+		label += " (required)"
+	}
+	ui.Printf("\n%s\n", label)
+	if field.Attributes.Description != "" {
+		ui.Printf("%s\n", field.Attributes.Description)
+	}
+
+	switch field.Type {
+	case "textarea":
+		ui.Println("Enter text and finish with a line containing only \".\":")
+		lines := []string{}
+		for {
+			line, err := promptLine(input, "")
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			if line == "." || err == io.EOF {
+				break
+			}
+			lines = append(lines, line)
+		}
+		answer := strings.Join(lines, "\n")
+		if strings.TrimSpace(answer) == "" {
+			answer = field.Attributes.Value
+		}
+		return answer, nil
+
+	case "dropdown", "checkboxes":
+		options := field.OptionLabels()
+		for i, option := range options {
+			ui.Printf("  %d. %s\n", i+1, option)
+		}
+		multiple := field.Type == "checkboxes" || field.Attributes.Multiple
+		prompt := "Choice: "
+		if multiple {
+			prompt = "Choices (comma-separated numbers): "
+		}
+		for {
+			line, err := promptLine(input, prompt)
+			if err != nil {
+				return nil, err
+			}
+			selected, ok := selectOptions(options, line, multiple)
+			if !ok {
+				ui.Errorf("Please enter a number between 1 and %d.\n", len(options))
+				continue
+			}
+			if field.Type == "checkboxes" {
+				return selected, nil
+			}
+			return strings.Join(selected, ", "), nil
+		}
+
+	default:
+		line, err := promptLine(input, promptWithDefault("", field.Attributes.Value))
+		if line == "" {
+			line = field.Attributes.Value
+		}
+		return line, err
+	}
+}
+
+// selectOptions maps a line of option numbers to the labels of the options.
+func selectOptions(options []string, line string, multiple bool) (selected []string, ok bool) {
+	selected = []string{}
+	for _, part := range strings.Split(line, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 1 || n > len(options) {
+			return nil, false
+		}
+		selected = append(selected, options[n-1])
+	}
+	if !multiple && len(selected) > 1 {
+		return nil, false
+	}
+	return selected, true
+}
+
+func isBlankAnswer(answer interface{}) bool {
+	switch a := answer.(type) {
+	case string:
+		return strings.TrimSpace(a) == ""
+	case []string:
+		return len(a) == 0
+	}
+	return true
+}
+
+func promptWithDefault(prompt, value string) string {
+	if value != "" {
+		prompt = strings.TrimSpace(fmt.Sprintf("%s [%s]", prompt, value))
+	}
+	if prompt == "" {
+		return "> "
+	}
+	return prompt + ": "
+}
+
+func promptLine(input *bufio.Reader, prompt string) (string, error) {
+	if prompt != "" {
+		ui.Print(prompt)
+	}
+	line, err := input.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
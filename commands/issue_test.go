@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -307,3 +308,85 @@ func TestFormatIssue_customFormatString(t *testing.T) {
 		},
 	})
 }
+
+func TestFormatReactions(t *testing.T) {
+	if got := formatReactions(nil); got != "" {
+		t.Errorf("formatReactions(nil) = %q, want empty", got)
+	}
+
+	reactions := &github.Reactions{TotalCount: 4, PlusOne: 3, Heart: 1}
+	if got, want := formatReactions(reactions), "+1 (3), heart (1)"; got != want {
+		t.Errorf("formatReactions() = %q, want %q", got, want)
+	}
+}
+
+func TestReadIssueTargets(t *testing.T) {
+	project := github.NewProject("github", "hub", "github.com")
+	input := "# stale issues\n102\n\n #103 \nhttps://github.com/github/hub-docs/issues/7\nhttps://github.com/github/hub/pull/12/files\n"
+
+	targets, err := readIssueTargets(strings.NewReader(input), project)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, target := range targets {
+		got = append(got, target.name(project))
+	}
+	want := []string{"#102", "#103", "github/hub-docs#7", "#12"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("readIssueTargets() = %v, want %v", got, want)
+	}
+}
+
+func TestReadIssueTargets_Invalid(t *testing.T) {
+	project := github.NewProject("github", "hub", "github.com")
+	for _, input := range []string{
+		"102\nnot-an-issue\n",
+		"102\nhttps://github.com/github/hub/releases/1\n",
+		"102\nhttps://example.com/github/hub/issues/1\n",
+	} {
+		_, err := readIssueTargets(strings.NewReader(input), project)
+		if err == nil || !strings.HasPrefix(err.Error(), "invalid issue on line 2: ") {
+			t.Errorf("readIssueTargets(%q) error = %v", input, err)
+		}
+	}
+}
+
+func TestReadExportedIssues(t *testing.T) {
+	array := `[
+  {"number": 1, "url": "https://github.com/github/hub/issues/1", "title": "One"},
+  {"number": 2, "url": "https://github.com/github/hub/issues/2", "title": "Two"}
+]`
+	ndjson := `{"number": 1, "url": "https://github.com/github/hub/issues/1", "title": "One"}
+
+{"number": 2, "url": "https://github.com/github/hub/issues/2", "title": "Two"}
+`
+	for _, input := range []string{array, ndjson} {
+		issues, err := readExportedIssues(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issues) != 2 || issues[0].Title != "One" || issues[1].Number != 2 {
+			t.Errorf("readExportedIssues(%q) = %+v", input, issues)
+		}
+	}
+
+	_, err := readExportedIssues(strings.NewReader("{\"number\": 1}\n{oops}\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid issue on line 2: ") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestImportedIssueBody(t *testing.T) {
+	issue := exportedIssue{
+		URL:       "https://github.com/github/hub/issues/1",
+		Author:    "mislav",
+		Body:      "It's broken.",
+		CreatedAt: time.Date(2020, 4, 1, 12, 30, 0, 0, time.UTC),
+	}
+	expect := "_Imported from https://github.com/github/hub/issues/1, originally opened by @mislav on 2020-04-01._\n\nIt's broken."
+	if got := importedIssueBody(issue); got != expect {
+		t.Errorf("importedIssueBody() = %q, want %q", got, expect)
+	}
+}
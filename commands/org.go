@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdOrg = &Command{
+		Run: printHelp,
+		Usage: `
+org members [--role <ROLE>] [-L <LIMIT>] [<ORG>]
+`,
+		Long: `Report on the members of a GitHub organization.
+
+## Commands:
+
+	* _members_:
+		List the logins of the members of <ORG>, one per line.
+
+## Options:
+	--role <ROLE>
+		Only list members with <ROLE>: "admin" for organization owners, or
+		"member" for everyone else (default: "all").
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> members.
+
+	<ORG>
+		The login of the organization (default: the owner of the repository of
+		the current directory).
+
+## Examples:
+		$ hub org members --role admin github
+
+## See also:
+
+hub-team(1), hub(1)
+`,
+	}
+
+	cmdOrgMembers = &Command{
+		Key: "members",
+		Run: listOrgMembers,
+		KnownFlags: `
+		--role ROLE
+		-L, --limit N
+`,
+	}
+)
+
+func init() {
+	cmdOrg.Use(cmdOrgMembers)
+	CmdRunner.Use(cmdOrg)
+}
+
+// orgFromArgs resolves the <ORG> parameter at index i along with the GitHub
+// host to query. Without the parameter, the owner of the current repository is
+// used.
+func orgFromArgs(cmd *Command, args *Args, i int) (org string, host string) {
+	if args.ParamsSize() > i+1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	if args.ParamsSize() == i+1 {
+		return resolveOrg(args.GetParam(i))
+	}
+	return resolveOrg("")
+}
+
+// resolveOrg validates the login of an organization and returns it along with
+// the GitHub host to query. An empty org stands for the owner of the current
+// repository.
+func resolveOrg(org string) (string, string) {
+	if org == "" {
+		project, err := resolveProject("")
+		utils.Check(err)
+		return project.Owner, project.Host
+	}
+
+	if !regexp.MustCompile(fmt.Sprintf("^%s$", OwnerRe)).MatchString(org) {
+		utils.Check(fmt.Errorf("invalid organization name: '%s'", org))
+	}
+	defaultHost, err := github.CurrentConfig().DefaultHost()
+	if err != nil {
+		utils.Check(github.FormatError("fetching organization", err))
+	}
+	return org, defaultHost.Host
+}
+
+func listOrgMembers(cmd *Command, args *Args) {
+	org, host := orgFromArgs(cmd, args, 0)
+
+	role := "all"
+	if args.Flag.HasReceived("--role") {
+		role = args.Flag.Value("--role")
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of members of %s\n", org)
+		return
+	}
+
+	gh := github.NewClient(host)
+	members, err := gh.OrgMembers(org, role, args.Flag.Int("--limit"))
+	utils.Check(err)
+	for _, member := range members {
+		ui.Println(member.Login)
+	}
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -15,10 +16,28 @@ var (
 	cmdPr = &Command{
 		Run: printHelp,
 		Usage: `
-pr list [-s <STATE>] [-h <HEAD>] [-b <BASE>] [-o <SORT_KEY> [-^]] [-f <FORMAT>] [-L <LIMIT>]
+pr list [-s <STATE>] [-h <HEAD>] [-b <BASE>] [-l <LABELS>] [--draft|--no-draft] [--author <USER>] [--assignee <USER>] [--review-requested <USER>] [--checks <STATUS>] [-o <SORT_KEY> [-^]] [-f <FORMAT>|--output <FORMAT>] [--reactions] [-L <LIMIT>] [-R <REPO>]
 pr checkout <PR-NUMBER> [<BRANCH>]
 pr show [-uc] [-f <FORMAT>] [-h <HEAD>]
 pr show [-uc] [-f <FORMAT>] <PR-NUMBER>
+pr merge [--merge|--squash|--rebase] [-m <MESSAGE>|-F <FILE>] [-d] [--auto] [<PR-NUMBER>]
+pr close [-m <COMMENT>] [-d] [<PR-NUMBER>]
+pr reopen [-m <COMMENT>] [<PR-NUMBER>]
+pr stack [create [-b <BASE>] [--draft] <BRANCH>...]
+pr stack sync
+pr edit [--add-reviewer <USERS>] [--remove-reviewer <USERS>] [-b <BASE>] [-t <TITLE>] [--add-label <LABELS>] [--remove-label <LABELS>] [-M <MILESTONE>] [<PR-NUMBER>]
+pr react [--comment <ID>] [--remove] <PR-NUMBER> <REACTION>
+pr comment [-m <MESSAGE>] [<PR-NUMBER>]
+pr comment --file <PATH> --line <LINE> [--side <SIDE>] [-m <MESSAGE>] [<PR-NUMBER>]
+pr comment --reply-to <COMMENT-ID> [-m <MESSAGE>] [<PR-NUMBER>]
+pr apply-suggestions [--batch] [--push] [<PR-NUMBER>]
+pr status [<PR-NUMBER>]
+pr update-branch [--rebase] [<PR-NUMBER>]
+pr update-branch --all [--rebase] [-R <REPO>]
+pr checks [--watch] [--required-only] [--color[=<WHEN>]] [<PR-NUMBER>]
+pr links [--add <ISSUES>] [--remove <ISSUES>] [<PR-NUMBER>]
+pr diff [--name-only] [--color[=<WHEN>]] [<PR-NUMBER>]
+pr files [--output <FORMAT>] [--color[=<WHEN>]] [<PR-NUMBER>]
 `,
 		Long: `Manage GitHub Pull Requests for the current repository.
 
@@ -32,9 +51,95 @@ pr show [-uc] [-f <FORMAT>] <PR-NUMBER>
 
 	* _show_:
 		Open a pull request page in a web browser. When no <PR-NUMBER> is
-		specified, <HEAD> is used to look up open pull requests and defaults to
-		the current branch name. With '--format', print information about the
-		pull request instead of opening it.
+		specified, the pull request recorded for the current branch by
+		'hub pull-request --push' is used. Otherwise, <HEAD> is used to look up
+		open pull requests and defaults to the current branch name. With
+		'--format', print information about the pull request instead of opening
+		it.
+
+		The _merge_, _close_, _reopen_, and _edit_ commands also default to the
+		pull request recorded for the current branch when <PR-NUMBER> is omitted.
+
+	* _merge_:
+		Merge the pull request <PR-NUMBER> using the merge method given by
+		'--merge' (default), '--squash', or '--rebase'. With '--auto', enable
+		auto-merge instead so that GitHub merges the pull request once all
+		required reviews and checks have passed.
+
+	* _close_:
+		Close the pull request <PR-NUMBER> without merging it, optionally leaving
+		a comment first.
+
+	* _reopen_:
+		Reopen a closed pull request, optionally leaving a comment first.
+
+	* _stack_:
+		Show the stack of dependent pull requests that the current branch
+		belongs to.
+
+	* _stack create_:
+		Push each <BRANCH> and open a pull request for it that is based on the
+		previous <BRANCH>; the first one is based on <BASE> (default: the default
+		branch). Branches that already have a pull request are retargeted instead.
+		The stack is recorded in the "branch.<BRANCH>.hub-stack-parent" git config.
+
+	* _stack sync_:
+		Retarget pull requests whose base branch has been merged onto the branch
+		it was merged into. The _merge_ command does this automatically for the
+		pull requests stacked on the one being merged.
+
+	* _edit_:
+		Change the reviewers, base branch, title, labels, or milestone of the
+		existing pull request <PR-NUMBER>.
+
+	* _react_:
+		React to a pull request with <REACTION>: "+1", "-1", "laugh", "hooray",
+		"confused", "heart", "rocket", or "eyes".
+
+	* _comment_:
+		Comment on a pull request. With '--file' and '--line', comment on that
+		line of the diff of the pull request instead, starting a review thread.
+		With '--reply-to', reply to an existing review thread. Without
+		'--message', a text editor opens to write the comment in.
+
+	* _apply-suggestions_:
+		Apply the suggested changes of the review comments on a pull request as
+		commits on the current branch, one commit per suggestion. The current
+		branch has to be at the head of the pull request, as after 'checkout'.
+		Suggestions on outdated lines, ones that overlap another suggestion, and
+		ones that are already applied are skipped.
+
+	* _status_:
+		Show whether a pull request can be merged without conflicts, how far its
+		head branch is behind its base, and the status of its reviews and checks.
+		Exits with a non-zero status if the pull request has merge conflicts.
+
+	* _update-branch_:
+		Bring the head branch of a pull request up to date with its base branch
+		by merging the base into it on GitHub, or by rebasing it with '--rebase'.
+		With '--all', update each of your open pull requests that is behind its
+		base.
+
+	* _checks_:
+		List the checks of the head commit of a pull request with their status.
+		Exits with status 0 only if all checks required by the branch protection
+		of the base branch have passed, or all checks if none are required;
+		otherwise the exit status is 1 if a check has failed, 8 if one is still
+		pending, and 9 if there are no checks. Required checks that haven't
+		started yet count as pending.
+
+	* _links_:
+		List the issues that a pull request closes when merged. With '--add' or
+		'--remove', change which issues it closes by adding "Closes" lines to its
+		description or removing the closing keywords from it.
+
+	* _diff_:
+		Print the changes of a pull request as a unified diff. The diff is fetched
+		from GitHub, so the head branch doesn't have to be available locally.
+
+	* _files_:
+		List the files that a pull request changes, with the number of lines
+		added and removed in each.
 
 ## Options:
 
@@ -47,13 +152,43 @@ pr show [-uc] [-f <FORMAT>] <PR-NUMBER>
 		"OWNER:BRANCH" format must be used for pull requests from forks.
 
 	-b, --base <BRANCH>
-		Show pull requests based off the specified <BRANCH>.
+		Show pull requests based off the specified <BRANCH>. With 'edit', change
+		the base branch of the pull request to <BRANCH>. With 'stack create', base
+		the first pull request of the stack on <BRANCH>.
+
+	-l, --label <LABELS>
+		Show only pull requests that have all of the comma-separated <LABELS>.
+
+	--draft
+		Show only draft pull requests. With 'stack create', open the pull requests
+		as drafts.
+
+	--no-draft
+		Show only pull requests that are ready for review.
+
+	--author <USER>
+		Show only pull requests opened by <USER>, which can be a login name or
+		"@me" for the current user.
+
+	--assignee <USER>
+		Show only pull requests assigned to <USER>, which can be a login name or
+		"@me" for the current user.
+
+	--review-requested <USER>
+		Show only pull requests awaiting review from <USER>, which can be a login
+		name, "@me" for the current user, or a team in "ORG/TEAM" format.
+
+	--checks <STATUS>
+		Show only pull requests whose head commit has checks with the combined
+		<STATUS>: "passing", "failing", or "pending".
 
 	-f, --format <FORMAT>
 		Pretty print the list of pull requests using format <FORMAT> (default:
-		"%pC%>(8)%i%Creset  %t%  l%n"). See the "PRETTY FORMATS" section of
-		git-log(1) for some additional details on how placeholders are used in
-		format. The available placeholders are:
+		"%pC%>(8)%i%Creset  %t%  l%n"). Without '--format', titles and labels
+		are aligned in columns and titles are shortened to fit the width of the
+		terminal. See the "PRETTY FORMATS" section of git-log(1) for some
+		additional details on how placeholders are used in format. The available
+		placeholders are:
 
 		%I: pull request number
 
@@ -93,6 +228,12 @@ pr show [-uc] [-f <FORMAT>] <PR-NUMBER>
 
 		%rs: comma-separated list of requested reviewers
 
+		%rv: review decision ("approved", "changes requested", or "review required")
+
+		%ck: checks status ("passing", "failing", or "pending")
+
+		%kC: set color to green, red, or yellow, depending on checks status
+
 		%Mn: milestone number
 
 		%Mt: milestone title
@@ -121,16 +262,42 @@ pr show [-uc] [-f <FORMAT>] <PR-NUMBER>
 
 		%mI: merged date, ISO 8601 format
 
+		%rc: reaction counts, e.g. "+1 (3), heart (1)"
+
+		%rN: total number of reactions
+
 		%n: newline
 
 		%%: a literal %
 
+	--output <FORMAT>
+		Print the list of pull requests in a structured format instead: "json",
+		"yaml", "csv", or "tsv". Pull requests have the fields "number", "state",
+		"title", "url", "draft", "base", "head", "author", "labels", "assignees",
+		"requested_reviewers", "milestone", "created_at", "updated_at", and
+		"merged_at". With 'files', files have the fields "filename", "status",
+		"additions", "deletions", and "previous_filename".
+
+	--name-only
+		With 'diff', print only the names of the changed files.
+
+	--reactions
+		Show the reaction counts of each pull request after its labels. Ignored
+		if '--format' is given; use the "%rc" placeholder instead.
+
 	--color[=<WHEN>]
 		Enable colored output even if stdout is not a terminal. <WHEN> can be one
 		of "always" (default for '--color'), "never", or "auto" (default).
 
+	-R, --repo <REPO>
+		List pull requests of <REPO>, given in "OWNER/NAME" format, instead of the
+		repository of the current directory.
+
 	-o, --sort <KEY>
 		Sort displayed pull requests by "created" (default), "updated", "popularity", or "long-running".
+		Pull requests are fetched with a single GraphQL query per 100 results,
+		except when sorting by "long-running", which the GraphQL API doesn't
+		support.
 
 	-^, --sort-ascending
 		Sort by ascending dates instead of descending.
@@ -144,6 +311,98 @@ pr show [-uc] [-f <FORMAT>] <PR-NUMBER>
 	-c, --copy
 		Put the pull request URL to clipboard instead of opening it.
 
+	--merge, --squash, --rebase
+		Create a merge commit (default), squash all commits into one, or rebase
+		the commits onto the base branch when merging.
+
+	-m, --message <MESSAGE>
+		Use the first line of <MESSAGE> as the title of the merge or squash commit
+		and the rest as its description. Defaults to what GitHub would use. With
+		'comment', <MESSAGE> is the text of the comment; multiple '--message' are
+		joined by blank lines.
+
+	-F, --file <FILE>
+		Read the merge commit title and description from <FILE>. Pass "-" to read
+		from standard input instead. See '--message' for the formatting rules.
+		With 'comment', '--file' is the path of the file in the diff to comment
+		on.
+
+	--line <LINE>
+		With 'comment', the line number of the file to comment on.
+
+	--side <SIDE>
+		With 'comment', whether <LINE> is in the new version of the file, "RIGHT"
+		(default), or in the old version, "LEFT", as for removed lines.
+
+	--reply-to <COMMENT-ID>
+		With 'comment', reply to the thread of the review comment <COMMENT-ID>.
+
+	--batch
+		With 'apply-suggestions', commit all suggestions together.
+
+	--watch
+		With 'checks', wait until none of the checks that decide the exit status
+		is pending before listing the checks.
+
+	--required-only
+		With 'checks', only list the checks that are required.
+
+	--add <ISSUES>
+		With 'links', a comma-separated list of issues to link, either as numbers
+		or in "OWNER/NAME#NUMBER" format.
+
+	--remove <ISSUES>
+		With 'links', a comma-separated list of issues to unlink. Issues that were
+		linked manually on GitHub rather than with closing keywords have to be
+		unlinked there.
+
+	--rebase
+		With 'update-branch', rebase the head branch onto the base branch instead
+		of merging the base branch into it.
+
+	--all
+		With 'update-branch', update all of your open pull requests.
+
+	--push
+		With 'apply-suggestions', push the new commits to the branch that the
+		current branch tracks.
+
+	-d, --delete-branch
+		Delete the head branch of the pull request after merging or closing it,
+		both on GitHub and locally if it exists.
+
+	--auto
+		Enable auto-merge for a pull request whose requirements are not met yet.
+
+	-m, --comment <COMMENT>
+		Leave a comment with <COMMENT> before closing or reopening the pull request.
+		With 'react', '--comment' takes the ID of a comment in the conversation of
+		the pull request to react to instead.
+
+	--remove
+		With 'react', remove your reaction instead of adding it.
+
+	--add-reviewer <USERS>
+		A comma-separated list of GitHub handles to request a review from. Teams
+		can be specified in "ORG/TEAM" format.
+
+	--remove-reviewer <USERS>
+		A comma-separated list of users or "ORG/TEAM" teams whose review request
+		should be withdrawn.
+
+	-t, --title <TITLE>
+		Change the title of the pull request to <TITLE>.
+
+	--add-label <LABELS>
+		A comma-separated list of labels to add to the pull request.
+
+	--remove-label <LABELS>
+		A comma-separated list of labels to remove from the pull request.
+
+	-M, --milestone <NAME>
+		Set the milestone of the pull request to <NAME>, given by title or
+		number. Use "none" to remove the milestone.
+
 ## See also:
 
 hub-issue(1), hub-pull-request(1), hub(1)
@@ -162,6 +421,155 @@ hub-issue(1), hub-pull-request(1), hub(1)
 		Long: cmdPr.Long,
 	}
 
+	cmdMergePr = &Command{
+		Key: "merge",
+		Run: mergePr,
+		KnownFlags: `
+		--merge
+		--squash
+		--rebase
+		-m, --message MSG
+		-F, --file FILE
+		-d, --delete-branch
+		--auto
+		-R, --repo REPO
+`,
+	}
+
+	cmdClosePr = &Command{
+		Key: "close",
+		Run: closePr,
+		KnownFlags: `
+		-m, --comment TEXT
+		-d, --delete-branch
+		-R, --repo REPO
+`,
+	}
+
+	cmdReopenPr = &Command{
+		Key: "reopen",
+		Run: reopenPr,
+		KnownFlags: `
+		-m, --comment TEXT
+		-R, --repo REPO
+`,
+	}
+
+	cmdEditPr = &Command{
+		Key: "edit",
+		Run: editPr,
+		KnownFlags: `
+		--add-reviewer USERS
+		--remove-reviewer USERS
+		-b, --base BASE
+		-t, --title TITLE
+		--add-label LABELS
+		--remove-label LABELS
+		-M, --milestone NAME
+		-R, --repo REPO
+`,
+	}
+
+	cmdStackPr = &Command{
+		Key: "stack",
+		Run: stackPr,
+		KnownFlags: `
+		-b, --base BASE
+		--draft
+`,
+	}
+
+	cmdReactPr = &Command{
+		Key: "react",
+		Run: reactPr,
+		KnownFlags: `
+		--comment ID
+		--remove
+		-R, --repo REPO
+`,
+	}
+
+	cmdCommentPr = &Command{
+		Key: "comment",
+		Run: commentPr,
+		KnownFlags: `
+		-m, --message MSG
+		--file PATH
+		--line LINE
+		--side SIDE
+		--reply-to ID
+		-R, --repo REPO
+`,
+	}
+
+	cmdApplySuggestionsPr = &Command{
+		Key: "apply-suggestions",
+		Run: applySuggestionsPr,
+		KnownFlags: `
+		--batch
+		--push
+`,
+	}
+
+	cmdStatusPr = &Command{
+		Key: "status",
+		Run: statusPr,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+
+	cmdUpdateBranchPr = &Command{
+		Key: "update-branch",
+		Run: updateBranchPr,
+		KnownFlags: `
+		--rebase
+		--all
+		-R, --repo REPO
+`,
+	}
+
+	cmdChecksPr = &Command{
+		Key: "checks",
+		Run: checksPr,
+		KnownFlags: `
+		--watch
+		--required-only
+		--color
+		-R, --repo REPO
+`,
+	}
+
+	cmdLinksPr = &Command{
+		Key: "links",
+		Run: linksPr,
+		KnownFlags: `
+		--add ISSUES
+		--remove ISSUES
+		-R, --repo REPO
+`,
+	}
+
+	cmdDiffPr = &Command{
+		Key: "diff",
+		Run: diffPr,
+		KnownFlags: `
+		--name-only
+		--color
+		-R, --repo REPO
+`,
+	}
+
+	cmdFilesPr = &Command{
+		Key: "files",
+		Run: filesPr,
+		KnownFlags: `
+		--output FORMAT
+		--color
+		-R, --repo REPO
+`,
+	}
+
 	cmdShowPr = &Command{
 		Key: "show",
 		Run: showPr,
@@ -179,6 +587,20 @@ func init() {
 	cmdPr.Use(cmdListPulls)
 	cmdPr.Use(cmdCheckoutPr)
 	cmdPr.Use(cmdShowPr)
+	cmdPr.Use(cmdMergePr)
+	cmdPr.Use(cmdClosePr)
+	cmdPr.Use(cmdReopenPr)
+	cmdPr.Use(cmdEditPr)
+	cmdPr.Use(cmdStackPr)
+	cmdPr.Use(cmdReactPr)
+	cmdPr.Use(cmdCommentPr)
+	cmdPr.Use(cmdApplySuggestionsPr)
+	cmdPr.Use(cmdStatusPr)
+	cmdPr.Use(cmdUpdateBranchPr)
+	cmdPr.Use(cmdChecksPr)
+	cmdPr.Use(cmdLinksPr)
+	cmdPr.Use(cmdDiffPr)
+	cmdPr.Use(cmdFilesPr)
 	CmdRunner.Use(cmdPr)
 }
 
@@ -187,10 +609,7 @@ func printHelp(command *Command, args *Args) {
 }
 
 func listPulls(cmd *Command, args *Args) {
-	localRepo, err := github.LocalRepo()
-	utils.Check(err)
-
-	project, err := localRepo.MainProject()
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
 	gh := github.NewClient(project.Host)
@@ -235,16 +654,291 @@ func listPulls(cmd *Command, args *Args) {
 	flagPullRequestFormat := args.Flag.Value("--format")
 	if !args.Flag.HasReceived("--format") {
 		flagPullRequestFormat = "%pC%>(8)%i%Creset  %t%  l%n"
+		if args.Flag.Bool("--reactions") {
+			flagPullRequestFormat = "%pC%>(8)%i%Creset  %t%  l%  rc%n"
+		}
+	}
+
+	flagPullRequestDraft := args.Flag.Bool("--draft")
+	flagPullRequestNoDraft := args.Flag.Bool("--no-draft")
+	if flagPullRequestDraft && flagPullRequestNoDraft {
+		utils.Check(fmt.Errorf("can't use both --draft and --no-draft"))
 	}
 
-	pulls, err := gh.FetchPullRequests(project, filters, flagPullRequestLimit, func(pr *github.PullRequest) bool {
-		return !(onlyMerged && pr.MergedAt.IsZero())
-	})
+	flagPullRequestLabels := commaSeparated(args.Flag.AllValues("--label"))
+
+	users := &userFilter{gh: gh}
+	author, err := users.resolve("--author", args.Flag.Value("--author"), false)
+	utils.Check(err)
+	assignee, err := users.resolve("--assignee", args.Flag.Value("--assignee"), false)
+	utils.Check(err)
+	reviewRequested, err := users.resolve("--review-requested", args.Flag.Value("--review-requested"), true)
 	utils.Check(err)
 
+	flagPullRequestChecks := args.Flag.Value("--checks")
+	switch flagPullRequestChecks {
+	case "", "passing", "failing", "pending":
+	default:
+		utils.Check(fmt.Errorf("invalid checks status: '%s' (expected \"passing\", \"failing\", or \"pending\")", flagPullRequestChecks))
+	}
+
+	// the status of checks comes from a separate query, so the limit can only
+	// be applied after filtering by it
+	fetchLimit := flagPullRequestLimit
+	if flagPullRequestChecks != "" {
+		fetchLimit = 0
+	}
+
+	filter := func(pr *github.PullRequest) bool {
+		if onlyMerged && pr.MergedAt.IsZero() {
+			return false
+		}
+		if (flagPullRequestDraft && !pr.Draft) || (flagPullRequestNoDraft && pr.Draft) {
+			return false
+		}
+		if !hasLabels(pr.Labels, flagPullRequestLabels) {
+			return false
+		}
+		if author != "" && (pr.User == nil || !strings.EqualFold(pr.User.Login, author)) {
+			return false
+		}
+		if assignee != "" && !isAssigned(pr.Assignees, assignee) {
+			return false
+		}
+		return reviewRequested == "" || isReviewRequested(pr, reviewRequested)
+	}
+	withStatus := flagPullRequestChecks != "" || usesPlaceholders(flagPullRequestFormat, statusPlaceholders...)
+
+	var pulls []github.PullRequest
+	var statuses map[int]github.PullRequestStatus
+	if orderBy, ok := pullRequestGraphQLOrder[args.Flag.Value("--sort")]; ok {
+		query := github.PullRequestQuery{
+			OrderBy:   orderBy,
+			Ascending: args.Flag.Bool("--sort-ascending"),
+			BaseRef:   args.Flag.Value("--base"),
+			Labels:    flagPullRequestLabels,
+			Fields: pullRequestListFields(flagPullRequestFormat, pullRequestFieldOptions{
+				structured: args.Flag.HasReceived("--output"),
+				byLabels:   len(flagPullRequestLabels) > 0,
+				byAssignee: assignee != "",
+				byReviewer: reviewRequested != "",
+				withStatus: withStatus,
+			}),
+		}
+		switch args.Flag.Value("--state") {
+		case "", "open":
+			query.States = []string{"OPEN"}
+		case "closed":
+			query.States = []string{"CLOSED", "MERGED"}
+		case "merged":
+			query.States = []string{"MERGED"}
+		}
+
+		graphQLFilter := filter
+		if head, ok := filters["head"].(string); ok {
+			parts := strings.SplitN(head, ":", 2)
+			query.HeadRef = parts[1]
+			graphQLFilter = func(pr *github.PullRequest) bool {
+				return strings.HasPrefix(strings.ToLower(pr.Head.Label), strings.ToLower(parts[0])+":") && filter(pr)
+			}
+		} else if !flagPullRequestDraft && !flagPullRequestNoDraft && len(flagPullRequestLabels) < 2 && author == "" && assignee == "" && reviewRequested == "" {
+			graphQLFilter = nil
+		}
+
+		pulls, statuses, err = gh.FetchPullRequestsGraphQL(project, query, fetchLimit, graphQLFilter)
+		utils.Check(err)
+	} else {
+		pulls, err = gh.FetchPullRequests(project, filters, fetchLimit, filter)
+		utils.Check(err)
+
+		if withStatus {
+			numbers := []int{}
+			for _, pr := range pulls {
+				numbers = append(numbers, pr.Number)
+			}
+			statuses, err = gh.PullRequestStatuses(project, numbers)
+			utils.Check(err)
+		}
+	}
+
+	if flagPullRequestChecks != "" {
+		filtered := []github.PullRequest{}
+		for _, pr := range pulls {
+			if statuses[pr.Number].Checks == flagPullRequestChecks {
+				filtered = append(filtered, pr)
+				if flagPullRequestLimit > 0 && len(filtered) == flagPullRequestLimit {
+					break
+				}
+			}
+		}
+		pulls = filtered
+	}
+
+	if args.Flag.HasReceived("--output") {
+		records := []ui.Record{}
+		for _, pr := range pulls {
+			records = append(records, pullRequestRecord(pr))
+		}
+		startPager(args)
+		printRecords(args.Flag.Value("--output"), records, pullRequestRecord(github.PullRequest{
+			Base: &github.PullRequestSpec{},
+			Head: &github.PullRequestSpec{},
+		}))
+		return
+	}
+
+	if usesPlaceholders(flagPullRequestFormat, reactionPlaceholders...) {
+		utils.Check(fetchPullRequestReactions(gh, project, pulls))
+	}
+	startPager(args)
+
 	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	if !args.Flag.HasReceived("--format") {
+		table := newIssueTable(colorize)
+		for _, pr := range pulls {
+			stateColor := pullRequestStateColor(pullRequestState(pr))
+			table.AddRow(issueTableRow(github.Issue(pr), stateColor, args.Flag.Bool("--reactions"), colorize)...)
+		}
+		utils.Check(table.Render(ui.Stdout))
+		return
+	}
+	for _, pr := range pulls {
+		placeholders := pullRequestPlaceholders(pr, colorize)
+		if statuses != nil {
+			for key, value := range formatPullRequestStatusPlaceholders(statuses[pr.Number], colorize) {
+				placeholders[key] = value
+			}
+		}
+		ui.Print(ui.Expand(flagPullRequestFormat, placeholders, colorize))
+	}
+}
+
+// hasLabels reports whether all of the wanted labels are among labels.
+func hasLabels(labels []github.IssueLabel, wanted []string) bool {
+	for _, name := range wanted {
+		found := false
+		for _, label := range labels {
+			if strings.EqualFold(label.Name, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// isAssigned reports whether login is among assignees.
+func isAssigned(assignees []github.User, login string) bool {
+	for _, user := range assignees {
+		if strings.EqualFold(user.Login, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReviewRequested reports whether a review was requested from a user login
+// or an "ORG/TEAM" team on a pull request.
+func isReviewRequested(pr *github.PullRequest, reviewer string) bool {
+	if parts := strings.SplitN(reviewer, "/", 2); len(parts) == 2 {
+		return strings.EqualFold(pr.Base.Repo.Owner.Login, parts[0]) && pr.HasRequestedTeam(parts[1])
+	}
+	return pr.HasRequestedReviewer(reviewer)
+}
+
+var statusPlaceholders = []string{"rv", "ck", "kC"}
+
+// usesPlaceholders reports whether format refers to any of the placeholders
+// named by keys, with or without a " ", "+", or "-" modifier.
+func usesPlaceholders(format string, keys ...string) bool {
+	for _, key := range keys {
+		if regexp.MustCompile(`%[-+ ]*` + regexp.QuoteMeta(key)).MatchString(format) {
+			return true
+		}
+	}
+	return false
+}
+
+// reactionPlaceholders are the format placeholders that need the reactions
+// of pull requests, which the pull requests API doesn't include.
+var reactionPlaceholders = []string{"rc", "rN"}
+
+func fetchPullRequestReactions(gh *github.Client, project *github.Project, pulls []github.PullRequest) error {
+	numbers := []int{}
 	for _, pr := range pulls {
-		ui.Print(formatPullRequest(pr, flagPullRequestFormat, colorize))
+		numbers = append(numbers, pr.Number)
+	}
+	counts, err := gh.ReactionCounts(project, numbers)
+	if err != nil {
+		return err
+	}
+	for i := range pulls {
+		pulls[i].Reactions = counts[pulls[i].Number]
+	}
+	return nil
+}
+
+// pullRequestGraphQLOrder maps the sort keys of `pr list` that GraphQL
+// supports to its order fields. Other sort keys are listed via the REST API.
+var pullRequestGraphQLOrder = map[string]string{
+	"":           "CREATED_AT",
+	"created":    "CREATED_AT",
+	"updated":    "UPDATED_AT",
+	"popularity": "COMMENTS",
+	"comments":   "COMMENTS",
+}
+
+// pullRequestFieldOptions describes what else besides the format the listed
+// pull requests are needed for.
+type pullRequestFieldOptions struct {
+	structured bool // all fields are rendered as '--output'
+	byLabels   bool
+	byAssignee bool
+	byReviewer bool
+	withStatus bool
+}
+
+// pullRequestListFields determines which optional pull request fields have to
+// be fetched to render the format or the structured output, and to filter.
+func pullRequestListFields(format string, opts pullRequestFieldOptions) []string {
+	fields := []string{}
+	add := func(field string, needed bool) {
+		if needed || opts.structured {
+			fields = append(fields, field)
+		}
+	}
+	add("body", usesPlaceholders(format, "b"))
+	add("labels", opts.byLabels || usesPlaceholders(format, "l", "L"))
+	add("assignees", opts.byAssignee || usesPlaceholders(format, "as"))
+	add("milestone", usesPlaceholders(format, "Mn", "Mt"))
+	add("reviewRequests", opts.byReviewer || usesPlaceholders(format, "rs"))
+	add("refs", usesPlaceholders(format, "sB", "sH", "sm"))
+	if opts.withStatus {
+		fields = append(fields, "status")
+	}
+	return fields
+}
+
+func formatPullRequestStatusPlaceholders(status github.PullRequestStatus, colorize bool) map[string]string {
+	var checksColorSwitch string
+	if colorize && status.Checks != "" {
+		checksColor := 33
+		switch status.Checks {
+		case "passing":
+			checksColor = 32
+		case "failing":
+			checksColor = 31
+		}
+		checksColorSwitch = fmt.Sprintf("\033[%dm", checksColor)
+	}
+
+	return map[string]string{
+		"rv": strings.Replace(status.ReviewDecision, "_", " ", -1),
+		"ck": status.Checks,
+		"kC": checksColorSwitch,
 	}
 }
 
@@ -301,6 +995,8 @@ func showPr(command *Command, args *Args) {
 		} else {
 			utils.Check(fmt.Errorf("invalid pull request number: '%s'", words[0]))
 		}
+	} else if prNumber = currentBranchPrNumber(); prNumber > 0 && !args.Flag.HasReceived("--head") {
+		openUrl = baseProject.WebURL("", "", fmt.Sprintf("pull/%d", prNumber))
 	} else {
 		pr, err = findCurrentPullRequest(localRepo, gh, baseProject, args.Flag.Value("--head"))
 		utils.Check(err)
@@ -313,6 +1009,11 @@ func showPr(command *Command, args *Args) {
 			pr, err = gh.PullRequest(baseProject, strconv.Itoa(prNumber))
 			utils.Check(err)
 		}
+		if usesPlaceholders(format, reactionPlaceholders...) {
+			pulls := []github.PullRequest{*pr}
+			utils.Check(fetchPullRequestReactions(gh, baseProject, pulls))
+			pr = &pulls[0]
+		}
 		colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
 		ui.Println(formatPullRequest(*pr, format, colorize))
 		return
@@ -324,6 +1025,345 @@ func showPr(command *Command, args *Args) {
 	printBrowseOrCopy(args, openUrl, !printUrl && !copyUrl, copyUrl)
 }
 
+func reactPr(command *Command, args *Args) {
+	if args.ParamsSize() != 2 {
+		utils.Check(command.UsageError(""))
+	}
+	react(args, "pull request", prNumberFromArgs(command, args))
+}
+
+// prNumberFromArgs parses the <PR-NUMBER> parameter of pr subcommands. When
+// omitted, the pull request recorded for the current branch is used.
+func prNumberFromArgs(command *Command, args *Args) int {
+	if args.IsParamsEmpty() {
+		if !args.Flag.HasReceived("--repo") {
+			if prNumber := currentBranchPrNumber(); prNumber > 0 {
+				return prNumber
+			}
+		}
+		utils.Check(command.UsageError("you must specify a pull request number"))
+	}
+	prNumber, err := strconv.Atoi(strings.TrimPrefix(args.GetParam(0), "#"))
+	if err != nil {
+		utils.Check(fmt.Errorf("invalid pull request number: '%s'", args.GetParam(0)))
+	}
+	return prNumber
+}
+
+// prNumberConfigKey is the git config key under which `pull-request --push`
+// records the number of the pull request opened from branch.
+func prNumberConfigKey(branch string) string {
+	return fmt.Sprintf("branch.%s.hub-pr", branch)
+}
+
+// branchPrNumber returns the pull request number recorded for branch, or 0 if
+// there is none.
+func branchPrNumber(branch string) int {
+	value, err := git.Config(prNumberConfigKey(branch))
+	if err != nil {
+		return 0
+	}
+	prNumber, _ := strconv.Atoi(value)
+	return prNumber
+}
+
+// currentBranchPrNumber returns the pull request number recorded for the
+// current branch, or 0 if there is none.
+func currentBranchPrNumber() int {
+	localRepo, err := github.LocalRepo()
+	if err != nil {
+		return 0
+	}
+	branch, err := localRepo.CurrentBranch()
+	if err != nil {
+		return 0
+	}
+	return branchPrNumber(branch.ShortName())
+}
+
+func closePr(command *Command, args *Args) {
+	setPrState(command, args, "closed")
+}
+
+func reopenPr(command *Command, args *Args) {
+	setPrState(command, args, "open")
+}
+
+func setPrState(command *Command, args *Args, state string) {
+	prNumber := prNumberFromArgs(command, args)
+
+	flagDeleteBranch := args.Flag.Bool("--delete-branch")
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	verb := "Closed"
+	if state == "open" {
+		verb = "Reopened"
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set state of pull request #%d for %s to %s\n", prNumber, project, state)
+		return
+	}
+
+	var pr *github.PullRequest
+	if flagDeleteBranch {
+		pr, err = gh.PullRequest(project, strconv.Itoa(prNumber))
+		utils.Check(err)
+	}
+
+	if comment := args.Flag.Value("--comment"); comment != "" {
+		_, err := gh.CreateComment(project, prNumber, comment)
+		utils.Check(err)
+	}
+
+	utils.Check(gh.UpdateIssue(project, prNumber, map[string]interface{}{"state": state}))
+	ui.Printf("%s pull request #%d\n", verb, prNumber)
+
+	if flagDeleteBranch {
+		deleteHeadBranch(gh, project, pr, !args.Flag.HasReceived("--repo"))
+	}
+}
+
+func mergePr(command *Command, args *Args) {
+	prNumber := prNumberFromArgs(command, args)
+
+	method := ""
+	for _, m := range []string{"merge", "squash", "rebase"} {
+		if args.Flag.Bool("--" + m) {
+			if method != "" {
+				utils.Check(fmt.Errorf("only one of --merge, --squash, or --rebase can be used"))
+			}
+			method = m
+		}
+	}
+	if method == "" {
+		method = "merge"
+	}
+
+	var err error
+	message := ""
+	if args.Flag.HasReceived("--message") {
+		message = strings.Join(args.Flag.AllValues("--message"), "\n\n")
+	} else if args.Flag.HasReceived("--file") {
+		message, err = msgFromFile(args.Flag.Value("--file"))
+		utils.Check(err)
+	}
+	commitTitle, commitBody := "", ""
+	if message != "" {
+		parts := strings.SplitN(strings.TrimSpace(message), "\n\n", 2)
+		commitTitle = strings.TrimSpace(strings.Replace(parts[0], "\n", " ", -1))
+		if len(parts) > 1 {
+			commitBody = strings.TrimSpace(parts[1])
+		}
+	}
+
+	flagDeleteBranch := args.Flag.Bool("--delete-branch")
+	flagAuto := args.Flag.Bool("--auto")
+	if flagAuto && flagDeleteBranch {
+		utils.Check(fmt.Errorf("--delete-branch can't be used with --auto"))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would %s pull request #%d of %s\n", method, prNumber, project)
+		return
+	}
+
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+	if pr.State != "open" {
+		utils.Check(fmt.Errorf("Error merging pull request: #%d is %s", prNumber, pr.State))
+	}
+
+	if flagAuto {
+		utils.Check(gh.EnablePullRequestAutoMerge(pr, method, commitTitle, commitBody))
+		ui.Infof("Enabled auto-merge (%s) for pull request #%d\n", method, prNumber)
+		return
+	}
+
+	params := map[string]interface{}{
+		"merge_method": method,
+		"sha":          pr.Head.Sha,
+	}
+	if commitTitle != "" {
+		params["commit_title"] = commitTitle
+		params["commit_message"] = commitBody
+	}
+	result, err := gh.MergePullRequest(project, prNumber, params)
+	utils.Check(err)
+
+	verb := map[string]string{"merge": "Merged", "squash": "Squashed and merged", "rebase": "Rebased and merged"}[method]
+	sha := result.Sha
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	ui.Printf("%s pull request #%d (%s)\n", verb, prNumber, sha)
+
+	if pr.IsSameRepo() && !args.Flag.HasReceived("--repo") {
+		retargetStackChildren(gh, project, pr.Head.Ref, pr.Base.Ref)
+	}
+
+	if flagDeleteBranch {
+		deleteHeadBranch(gh, project, pr, !args.Flag.HasReceived("--repo"))
+	}
+}
+
+func editPr(command *Command, args *Args) {
+	prNumber := prNumberFromArgs(command, args)
+
+	addReviewers := commaSeparated(args.Flag.AllValues("--add-reviewer"))
+	removeReviewers := commaSeparated(args.Flag.AllValues("--remove-reviewer"))
+	addLabels := commaSeparated(args.Flag.AllValues("--add-label"))
+	removeLabels := commaSeparated(args.Flag.AllValues("--remove-label"))
+
+	prParams := map[string]interface{}{}
+	if args.Flag.HasReceived("--title") {
+		title := strings.TrimSpace(args.Flag.Value("--title"))
+		if title == "" {
+			utils.Check(fmt.Errorf("Aborting due to empty pull request title"))
+		}
+		prParams["title"] = title
+	}
+	if args.Flag.HasReceived("--base") {
+		prParams["base"] = args.Flag.Value("--base")
+	}
+
+	if len(prParams) == 0 && len(addReviewers) == 0 && len(removeReviewers) == 0 &&
+		len(addLabels) == 0 && len(removeLabels) == 0 && !args.Flag.HasReceived("--milestone") {
+		utils.Check(command.UsageError("nothing to edit"))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would update pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+
+	if len(prParams) > 0 {
+		pr, err = gh.UpdatePullRequest(project, prNumber, prParams)
+		utils.Check(err)
+	}
+
+	if args.Flag.HasReceived("--milestone") {
+		params := map[string]interface{}{}
+		milestoneValue := args.Flag.Value("--milestone")
+		if milestoneValue == "none" {
+			params["milestone"] = nil
+		} else {
+			milestoneNumber, err := milestoneValueToNumber(milestoneValue, gh, project)
+			utils.Check(err)
+			params["milestone"] = milestoneNumber
+		}
+		utils.Check(gh.UpdateIssue(project, prNumber, params))
+	}
+
+	if len(addLabels) > 0 {
+		utils.Check(gh.AddLabels(project, prNumber, addLabels))
+	}
+	for _, label := range removeLabels {
+		utils.Check(gh.RemoveLabel(project, prNumber, label))
+	}
+
+	if len(removeReviewers) > 0 {
+		users, teams := splitReviewers(removeReviewers)
+		utils.Check(gh.RemoveReviewRequest(project, prNumber, map[string]interface{}{
+			"reviewers":      users,
+			"team_reviewers": teams,
+		}))
+	}
+
+	if userReviewers, teamReviewers := pendingReviewers(pr, addReviewers); len(userReviewers) > 0 || len(teamReviewers) > 0 {
+		utils.Check(gh.RequestReview(project, prNumber, map[string]interface{}{
+			"reviewers":      userReviewers,
+			"team_reviewers": teamReviewers,
+		}))
+	}
+
+	ui.Println(pr.HtmlUrl)
+}
+
+// splitReviewers separates user logins from teams given in "ORG/TEAM" format,
+// returning the team slugs for the latter.
+func splitReviewers(reviewers []string) (users, teams []string) {
+	users = []string{}
+	teams = []string{}
+	for _, reviewer := range reviewers {
+		if strings.Contains(reviewer, "/") {
+			teams = append(teams, strings.SplitN(reviewer, "/", 2)[1])
+		} else {
+			users = append(users, reviewer)
+		}
+	}
+	return
+}
+
+// pendingReviewers returns the users and team slugs among reviewers whose
+// review hasn't been requested on the pull request yet.
+func pendingReviewers(pr *github.PullRequest, reviewers []string) (userReviewers, teamReviewers []string) {
+	userReviewers = []string{}
+	teamReviewers = []string{}
+	users, teams := splitReviewers(reviewers)
+	for _, user := range users {
+		if !pr.HasRequestedReviewer(user) {
+			userReviewers = append(userReviewers, user)
+		}
+	}
+	for _, team := range teams {
+		if !pr.HasRequestedTeam(team) {
+			teamReviewers = append(teamReviewers, team)
+		}
+	}
+	return
+}
+
+// deleteHeadBranch removes the head branch of a pull request from GitHub and,
+// optionally, the local branch of the same name.
+func deleteHeadBranch(gh *github.Client, project *github.Project, pr *github.PullRequest, deleteLocal bool) {
+	branch := pr.Head.Ref
+	if pr.IsSameRepo() {
+		utils.Check(gh.DeleteBranch(project, branch))
+		ui.Infof("Deleted branch %s on %s\n", branch, project)
+	} else {
+		ui.Errorf("warning: not deleting branch '%s' of a fork\n", pr.Head.Label)
+	}
+
+	if !deleteLocal {
+		return
+	}
+	localBranches, err := git.LocalBranches()
+	if err != nil {
+		return
+	}
+	for _, localBranch := range localBranches {
+		if localBranch != branch {
+			continue
+		}
+		if head, err := git.Head(); err == nil && head == "refs/heads/"+branch {
+			git.Quiet("checkout", "--quiet", pr.Base.Ref)
+		}
+		if git.Quiet("branch", "-D", branch) {
+			ui.Infof("Deleted local branch %s\n", branch)
+		} else {
+			ui.Errorf("warning: could not delete local branch '%s'\n", branch)
+		}
+	}
+}
+
 func findCurrentPullRequest(localRepo *github.GitHubRepo, gh *github.Client, baseProject *github.Project, headArg string) (*github.PullRequest, error) {
 	filterParams := map[string]interface{}{
 		"state": "open",
@@ -412,6 +1452,10 @@ func deducePushTarget(branch *github.Branch, owner string) (*github.Project, err
 }
 
 func formatPullRequest(pr github.PullRequest, format string, colorize bool) string {
+	return ui.Expand(format, pullRequestPlaceholders(pr, colorize), colorize)
+}
+
+func pullRequestPlaceholders(pr github.PullRequest, colorize bool) map[string]string {
 	placeholders := formatIssuePlaceholders(github.Issue(pr), colorize)
 	delete(placeholders, "NC")
 	delete(placeholders, "Nc")
@@ -419,5 +1463,5 @@ func formatPullRequest(pr github.PullRequest, format string, colorize bool) stri
 	for key, value := range formatPullRequestPlaceholders(pr, colorize) {
 		placeholders[key] = value
 	}
-	return ui.Expand(format, placeholders, colorize)
+	return placeholders
 }
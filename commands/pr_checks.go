@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var checksPollInterval = 10 * time.Second
+
+func checksPr(command *Command, args *Args) {
+	prNumber := prNumberFromArgs(command, args)
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request the checks of pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+	required, err := gh.RequiredStatusChecks(project, pr.Base.Ref)
+	utils.Check(err)
+
+	var listed, gating []github.CIStatus
+	var spinner *ui.Spinner
+	for {
+		response, err := gh.FetchCIStatus(project, pr.Head.Sha)
+		utils.Check(err)
+		listed, gating = gateStatuses(response.Statuses, required, args.Flag.Bool("--required-only"))
+		if !args.Flag.Bool("--watch") || gateState(gating) != "pending" {
+			break
+		}
+		if spinner == nil {
+			spinner = ui.StartSpinner(fmt.Sprintf("Waiting for the checks of pull request #%d ...", prNumber))
+		}
+		time.Sleep(checksPollInterval)
+	}
+	if spinner != nil {
+		spinner.Stop()
+	}
+
+	if len(listed) > 0 {
+		colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+		ciVerboseFormat(listed, nil, "", colorize)
+	} else {
+		ui.Println("no checks")
+	}
+
+	switch gateState(gating) {
+	case "success":
+		ui.Exit(0)
+	case "failure":
+		ui.Exit(utils.ExitFailure)
+	case "pending":
+		ui.Exit(utils.ExitPending)
+	default:
+		ui.Exit(utils.ExitNoChecks)
+	}
+}
+
+// gateStatuses picks the latest status of each check, and the ones among them
+// that are required. Without required checks, all checks are required. A
+// required check that hasn't reported yet is pending.
+func gateStatuses(statuses []github.CIStatus, required []string, requiredOnly bool) (listed, gating []github.CIStatus) {
+	latest := map[string]int{}
+	for _, status := range statuses {
+		if i, ok := latest[status.Context]; ok {
+			if run := listed[i].CheckRun; run != nil && status.CheckRun != nil && status.CheckRun.Id > run.Id {
+				listed[i] = status
+			}
+			continue
+		}
+		latest[status.Context] = len(listed)
+		listed = append(listed, status)
+	}
+
+	if len(required) == 0 {
+		return listed, listed
+	}
+
+	for _, context := range required {
+		if i, ok := latest[context]; ok {
+			gating = append(gating, listed[i])
+		} else {
+			status := github.CIStatus{State: "pending", Context: context}
+			gating = append(gating, status)
+			if !requiredOnly {
+				listed = append(listed, status)
+			}
+		}
+	}
+	if requiredOnly {
+		return gating, gating
+	}
+	return listed, gating
+}
+
+// gateState is "success" if all statuses passed, "failure" if any failed, or
+// else "pending" if any has yet to finish. It's blank without any statuses.
+func gateState(statuses []github.CIStatus) string {
+	if len(statuses) == 0 {
+		return ""
+	}
+	state := "success"
+	for _, status := range statuses {
+		switch status.State {
+		case "failure", "error", "action_required", "cancelled", "timed_out", "stale":
+			return "failure"
+		case "pending":
+			state = "pending"
+		}
+	}
+	return state
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+func commentPr(command *Command, args *Args) {
+	path := args.Flag.Value("--file")
+	replyTo := 0
+	if args.Flag.HasReceived("--reply-to") {
+		if path != "" {
+			utils.Check(command.UsageError("--reply-to can't be combined with --file"))
+		}
+		var err error
+		if replyTo, err = strconv.Atoi(args.Flag.Value("--reply-to")); err != nil {
+			utils.Check(fmt.Errorf("invalid comment id: '%s'", args.Flag.Value("--reply-to")))
+		}
+	}
+
+	line := 0
+	if path != "" {
+		if !args.Flag.HasReceived("--line") {
+			utils.Check(command.UsageError("--file requires --line"))
+		}
+		var err error
+		if line, err = strconv.Atoi(args.Flag.Value("--line")); err != nil || line < 1 {
+			utils.Check(fmt.Errorf("invalid line number: '%s'", args.Flag.Value("--line")))
+		}
+	} else if args.Flag.HasReceived("--line") || args.Flag.HasReceived("--side") {
+		utils.Check(command.UsageError("--line and --side require --file"))
+	}
+
+	side := "RIGHT"
+	if args.Flag.HasReceived("--side") {
+		side = strings.ToUpper(args.Flag.Value("--side"))
+		if side != "LEFT" && side != "RIGHT" {
+			utils.Check(fmt.Errorf("invalid side: '%s'", args.Flag.Value("--side")))
+		}
+	}
+
+	prNumber := prNumberFromArgs(command, args)
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	var editor *github.Editor
+	body := strings.Join(args.Flag.AllValues("--message"), "\n\n")
+	if !args.Flag.HasReceived("--message") {
+		editor, err = github.NewEditor("PR_COMMENT_EDITMSG", "comment", "")
+		utils.Check(err)
+		editor.AddCommentedSection(fmt.Sprintf("Commenting on pull request #%d for %s", prNumber, project))
+		body, err = editor.EditContent()
+		utils.Check(err)
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		if editor != nil {
+			editor.DeleteFile()
+		}
+		utils.Check(fmt.Errorf("Aborting due to empty comment"))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		switch {
+		case path != "":
+			ui.Printf("Would comment on %s:%d of pull request #%d for %s\n", path, line, prNumber, project)
+		case replyTo != 0:
+			ui.Printf("Would reply to review comment %d of pull request #%d for %s\n", replyTo, prNumber, project)
+		default:
+			ui.Printf("Would comment on pull request #%d for %s\n", prNumber, project)
+		}
+		return
+	}
+
+	var comment *github.Comment
+	switch {
+	case path != "":
+		pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+		utils.Check(err)
+		comment, err = gh.CreateReviewComment(project, prNumber, map[string]interface{}{
+			"body":      body,
+			"commit_id": pr.Head.Sha,
+			"path":      path,
+			"line":      line,
+			"side":      side,
+		})
+		utils.Check(err)
+	case replyTo != 0:
+		comment, err = gh.ReplyToReviewComment(project, prNumber, replyTo, body)
+		utils.Check(err)
+	default:
+		comment, err = gh.CreateComment(project, prNumber, body)
+		utils.Check(err)
+	}
+
+	if editor != nil {
+		editor.DeleteFile()
+	}
+	ui.Println(comment.HtmlUrl)
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+func diffPr(command *Command, args *Args) {
+	prNumber := prNumberFromArgs(command, args)
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request the diff of pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	if args.Flag.Bool("--name-only") {
+		files, err := gh.PullRequestFiles(project, strconv.Itoa(prNumber))
+		utils.Check(err)
+		for _, file := range files {
+			ui.Println(file.Filename)
+		}
+		return
+	}
+
+	diff, err := gh.PullRequestDiff(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+	defer diff.Close()
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	startPager(args)
+	scanner := bufio.NewScanner(diff)
+	scanner.Buffer(nil, 1024*1024*10)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if colorize {
+			line = colorizeDiffLine(line)
+		}
+		ui.Println(line)
+	}
+	utils.Check(scanner.Err())
+}
+
+// colorizeDiffLine colors a line of a unified diff the way git-diff(1) does by
+// default.
+func colorizeDiffLine(line string) string {
+	color := ""
+	switch {
+	case strings.HasPrefix(line, "diff --git "), strings.HasPrefix(line, "index "),
+		strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "+++ "),
+		strings.HasPrefix(line, "new file mode"), strings.HasPrefix(line, "deleted file mode"),
+		strings.HasPrefix(line, "similarity index"), strings.HasPrefix(line, "rename "):
+		color = "1"
+	case strings.HasPrefix(line, "@@"):
+		color = "36"
+	case strings.HasPrefix(line, "+"):
+		color = "32"
+	case strings.HasPrefix(line, "-"):
+		color = "31"
+	}
+	if color == "" {
+		return line
+	}
+	return fmt.Sprintf("\033[%sm%s\033[m", color, line)
+}
+
+func filesPr(command *Command, args *Args) {
+	prNumber := prNumberFromArgs(command, args)
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request the files of pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	files, err := gh.PullRequestFiles(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+
+	if args.Flag.HasReceived("--output") {
+		records := []ui.Record{}
+		for _, file := range files {
+			records = append(records, pullRequestFileRecord(file))
+		}
+		printRecords(args.Flag.Value("--output"), records, pullRequestFileRecord(github.ComparisonFile{}))
+		return
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	table := ui.NewTable(ui.TableColumn{AlignRight: true}, ui.TableColumn{AlignRight: true}, ui.TableColumn{})
+	table.Colorize = colorize
+	for _, file := range files {
+		name := file.Filename
+		if file.PreviousFilename != "" {
+			name = fmt.Sprintf("%s => %s", file.PreviousFilename, file.Filename)
+		}
+		table.AddRow(
+			ui.TableCell{Text: fmt.Sprintf("+%d", file.Additions), Color: "32"},
+			ui.TableCell{Text: fmt.Sprintf("-%d", file.Deletions), Color: "31"},
+			ui.TableCell{Text: name},
+		)
+	}
+	utils.Check(table.Render(ui.Stdout))
+}
+
+func pullRequestFileRecord(file github.ComparisonFile) ui.Record {
+	return ui.Record{}.
+		With("filename", file.Filename).
+		With("status", file.Status).
+		With("additions", file.Additions).
+		With("deletions", file.Deletions).
+		With("previous_filename", file.PreviousFilename)
+}
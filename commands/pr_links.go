@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+// issueReference is an issue as it's referenced in Markdown: "#NUMBER" for
+// issues of the same repository, or "OWNER/NAME#NUMBER".
+type issueReference struct {
+	repo   string
+	number int
+}
+
+func (r issueReference) String() string {
+	return fmt.Sprintf("%s#%d", r.repo, r.number)
+}
+
+func (r issueReference) equals(other issueReference) bool {
+	return r.number == other.number && strings.EqualFold(r.repo, other.repo)
+}
+
+var issueReferenceRegexp = regexp.MustCompile(`^(?:([\w.-]+/[\w.-]+))?#?(\d+)$`)
+
+// closingKeywordRegexp matches the keywords that link an issue to a pull
+// request: https://docs.github.com/articles/closing-issues-using-keywords
+var closingKeywordRegexp = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+([\w.-]+/[\w.-]+)?#(\d+)\b`)
+
+func parseIssueReferences(values []string) ([]issueReference, error) {
+	refs := []issueReference{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		m := issueReferenceRegexp.FindStringSubmatch(value)
+		if m == nil {
+			return nil, fmt.Errorf("invalid issue: '%s'", value)
+		}
+		number, _ := strconv.Atoi(m[2])
+		refs = append(refs, issueReference{repo: m[1], number: number})
+	}
+	return refs, nil
+}
+
+// closedIssues lists the issues that body closes with closing keywords.
+func closedIssues(body string) []issueReference {
+	refs := []issueReference{}
+	for _, m := range closingKeywordRegexp.FindAllStringSubmatch(body, -1) {
+		number, _ := strconv.Atoi(m[2])
+		refs = append(refs, issueReference{repo: m[1], number: number})
+	}
+	return refs
+}
+
+func containsIssue(refs []issueReference, ref issueReference) bool {
+	for _, r := range refs {
+		if r.equals(ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// addClosingKeywords appends a "Closes" line to body for each of issues that
+// it doesn't close already.
+func addClosingKeywords(body string, issues []issueReference) string {
+	closed := closedIssues(body)
+	lines := []string{}
+	for _, ref := range issues {
+		if !containsIssue(closed, ref) {
+			lines = append(lines, "Closes "+ref.String())
+			closed = append(closed, ref)
+		}
+	}
+	if len(lines) == 0 {
+		return body
+	}
+	if body != "" {
+		body += "\n\n"
+	}
+	return body + strings.Join(lines, "\n")
+}
+
+// removeClosingKeywords stops body from closing issues. Lines consisting of
+// just a closing keyword and the issue are removed, while references to the
+// issues elsewhere are kept without the keyword.
+func removeClosingKeywords(body string, issues []issueReference) string {
+	lines := []string{}
+	for _, line := range strings.Split(body, "\n") {
+		removed := false
+		line = closingKeywordRegexp.ReplaceAllStringFunc(line, func(match string) string {
+			m := closingKeywordRegexp.FindStringSubmatch(match)
+			number, _ := strconv.Atoi(m[2])
+			ref := issueReference{repo: m[1], number: number}
+			if !containsIssue(issues, ref) {
+				return match
+			}
+			removed = true
+			return ref.String()
+		})
+		if removed && issueReferenceRegexp.MatchString(strings.TrimSpace(line)) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+func linksPr(command *Command, args *Args) {
+	toAdd, err := parseIssueReferences(commaSeparated(args.Flag.AllValues("--add")))
+	utils.Check(err)
+	toRemove, err := parseIssueReferences(commaSeparated(args.Flag.AllValues("--remove")))
+	utils.Check(err)
+
+	prNumber := prNumberFromArgs(command, args)
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if len(toAdd) == 0 && len(toRemove) == 0 {
+		if args.Noop {
+			ui.Printf("Would request the linked issues of pull request #%d for %s\n", prNumber, project)
+			return
+		}
+		issues, err := gh.PullRequestClosingIssues(project, prNumber)
+		utils.Check(err)
+		if len(issues) == 0 {
+			ui.Println("No linked issues")
+			return
+		}
+
+		table := ui.NewTable(ui.TableColumn{AlignRight: true}, ui.TableColumn{}, ui.TableColumn{})
+		for _, issue := range issues {
+			ref := fmt.Sprintf("#%d", issue.Number)
+			if !strings.EqualFold(issue.Repository.NameWithOwner, project.String()) {
+				ref = issue.Repository.NameWithOwner + ref
+			}
+			table.AddRow(
+				ui.TableCell{Text: ref},
+				ui.TableCell{Text: strings.ToLower(issue.State)},
+				ui.TableCell{Text: issue.Title},
+			)
+		}
+		utils.Check(table.Render(ui.Stdout))
+		return
+	}
+
+	if args.Noop {
+		ui.Printf("Would update the linked issues of pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+	body := removeClosingKeywords(pr.Body, toRemove)
+	body = addClosingKeywords(body, toAdd)
+	if body == pr.Body {
+		ui.Printf("The linked issues of pull request #%d are unchanged\n", prNumber)
+		return
+	}
+
+	_, err = gh.UpdatePullRequest(project, prNumber, map[string]interface{}{"body": body})
+	utils.Check(err)
+	ui.Printf("Updated the linked issues of pull request #%d\n", prNumber)
+}
@@ -0,0 +1,255 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+// stackParentConfigKey is the git config key recording the branch that branch
+// is stacked on.
+func stackParentConfigKey(branch string) string {
+	return fmt.Sprintf("branch.%s.hub-stack-parent", branch)
+}
+
+// stackParents maps every stacked branch to the branch it is based on.
+func stackParents() map[string]string {
+	parents := map[string]string{}
+	lines, err := git.ConfigAll(`^branch\..*\.hub-stack-parent$`)
+	if err != nil {
+		return parents
+	}
+	for _, line := range lines {
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		branch := strings.TrimSuffix(strings.TrimPrefix(parts[0], "branch."), ".hub-stack-parent")
+		parents[branch] = parts[1]
+	}
+	return parents
+}
+
+// stackChildren inverts the result of stackParents, listing the branches
+// stacked directly on each branch in alphabetical order.
+func stackChildren(parents map[string]string) map[string][]string {
+	children := map[string][]string{}
+	for branch, parent := range parents {
+		children[parent] = append(children[parent], branch)
+	}
+	for _, branches := range children {
+		sort.Strings(branches)
+	}
+	return children
+}
+
+func stackPr(command *Command, args *Args) {
+	subcommand := ""
+	if !args.IsParamsEmpty() {
+		subcommand = args.RemoveParam(0)
+	}
+
+	switch subcommand {
+	case "":
+		showStack(args)
+	case "create":
+		createStack(command, args)
+	case "sync":
+		syncStack(args)
+	default:
+		utils.Check(fmt.Errorf("error: Unknown subcommand: stack %s", subcommand))
+	}
+}
+
+func showStack(args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	currentBranch, err := localRepo.CurrentBranch()
+	utils.Check(err)
+	current := currentBranch.ShortName()
+
+	parents := stackParents()
+	root := current
+	for i := 0; i < len(parents); i++ {
+		parent, ok := parents[root]
+		if !ok {
+			break
+		}
+		root = parent
+	}
+
+	children := stackChildren(parents)
+	if len(children[root]) == 0 {
+		utils.Check(fmt.Errorf("Aborted: branch '%s' is not part of a stack", current))
+	}
+
+	args.NoForward()
+
+	var printBranch func(branch string, depth int)
+	printBranch = func(branch string, depth int) {
+		marker := "  "
+		if branch == current {
+			marker = "* "
+		}
+		line := branch
+		if depth > 0 {
+			line = strings.Repeat("  ", depth-1) + "└ " + branch
+		}
+		if prNumber := branchPrNumber(branch); prNumber > 0 {
+			line = fmt.Sprintf("%s #%d", line, prNumber)
+		}
+		ui.Println(marker + line)
+		for _, child := range children[branch] {
+			printBranch(child, depth+1)
+		}
+	}
+	printBranch(root, 0)
+}
+
+func createStack(command *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(command.UsageError("you must specify the branches of the stack"))
+	}
+	branches := args.Params
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+	remote, err := localRepo.MainRemote()
+	utils.Check(err)
+
+	base := args.Flag.Value("--base")
+	if base == "" {
+		base = localRepo.MasterBranch().ShortName()
+	}
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	parent := base
+	for _, branch := range branches {
+		if args.Noop {
+			ui.Printf("Would push %s to %s and open a pull request based on %s\n", branch, remote.Name, parent)
+			parent = branch
+			continue
+		}
+
+		utils.Check(git.Spawn("push", "--set-upstream", remote.Name, fmt.Sprintf("%s:%s", branch, branch)))
+
+		var pr *github.PullRequest
+		if prNumber := branchPrNumber(branch); prNumber > 0 {
+			pr, err = gh.PullRequest(project, strconv.Itoa(prNumber))
+			utils.Check(err)
+			if pr.Base.Ref != parent {
+				pr, err = gh.UpdatePullRequest(project, prNumber, map[string]interface{}{"base": parent})
+				utils.Check(err)
+			}
+		} else {
+			commits, _ := git.RefList(parent, branch)
+			if len(commits) == 0 {
+				utils.Check(fmt.Errorf("Aborted: no commits detected between %s and %s", parent, branch))
+			}
+			message, err := git.Show(commits[len(commits)-1])
+			utils.Check(err)
+			parts := strings.SplitN(strings.TrimSpace(message), "\n\n", 2)
+
+			params := map[string]interface{}{
+				"base":  parent,
+				"head":  fmt.Sprintf("%s:%s", project.Owner, branch),
+				"title": strings.TrimSpace(parts[0]),
+			}
+			if len(parts) > 1 {
+				params["body"] = strings.TrimSpace(parts[1])
+			}
+			if args.Flag.Bool("--draft") {
+				params["draft"] = true
+			}
+
+			pr, err = gh.CreatePullRequest(project, params)
+			utils.Check(err)
+			git.SetConfig(prNumberConfigKey(branch), strconv.Itoa(pr.Number))
+		}
+
+		git.SetConfig(stackParentConfigKey(branch), parent)
+		ui.Println(pr.HtmlUrl)
+		parent = branch
+	}
+}
+
+func syncStack(args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would retarget stacked pull requests for %s\n", project)
+		return
+	}
+
+	pulls := map[int]*github.PullRequest{}
+	fetchPr := func(prNumber int) *github.PullRequest {
+		if pulls[prNumber] == nil {
+			pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+			utils.Check(err)
+			pulls[prNumber] = pr
+		}
+		return pulls[prNumber]
+	}
+
+	parents := stackParents()
+	branches := []string{}
+	for branch := range parents {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+
+	for _, branch := range branches {
+		parent := parents[branch]
+		newBase := parent
+		for i := 0; i <= len(parents); i++ {
+			parentNumber := branchPrNumber(newBase)
+			if parentNumber == 0 {
+				break
+			}
+			parentPr := fetchPr(parentNumber)
+			if parentPr.MergedAt.IsZero() {
+				break
+			}
+			newBase = parentPr.Base.Ref
+		}
+		if newBase == parent {
+			continue
+		}
+
+		if prNumber := branchPrNumber(branch); prNumber > 0 && fetchPr(prNumber).State == "open" {
+			_, err := gh.UpdatePullRequest(project, prNumber, map[string]interface{}{"base": newBase})
+			utils.Check(err)
+			ui.Infof("Retargeted pull request #%d onto %s\n", prNumber, newBase)
+		}
+		git.SetConfig(stackParentConfigKey(branch), newBase)
+	}
+}
+
+// retargetStackChildren moves the pull requests stacked on a merged branch
+// onto the branch it was merged into. This has to happen before the merged
+// branch is deleted, since GitHub closes pull requests whose base is removed.
+func retargetStackChildren(gh *github.Client, project *github.Project, merged, base string) {
+	for _, child := range stackChildren(stackParents())[merged] {
+		if prNumber := branchPrNumber(child); prNumber > 0 {
+			_, err := gh.UpdatePullRequest(project, prNumber, map[string]interface{}{"base": base})
+			utils.Check(err)
+			ui.Infof("Retargeted pull request #%d onto %s\n", prNumber, base)
+		}
+		git.SetConfig(stackParentConfigKey(child), base)
+	}
+}
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/github/hub/fixtures"
+	"github.com/github/hub/git"
+)
+
+func TestStackParents(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	git.SetConfig(stackParentConfigKey("feature-a"), "master")
+	git.SetConfig(stackParentConfigKey("feature-b"), "feature-a")
+	git.SetConfig(stackParentConfigKey("feature.c"), "feature-a")
+
+	parents := stackParents()
+	assert.Equal(t, map[string]string{
+		"feature-a": "master",
+		"feature-b": "feature-a",
+		"feature.c": "feature-a",
+	}, parents)
+
+	children := stackChildren(parents)
+	assert.Equal(t, []string{"feature-a"}, children["master"])
+	assert.Equal(t, []string{"feature-b", "feature.c"}, children["feature-a"])
+}
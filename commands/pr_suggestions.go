@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var suggestionRegexp = regexp.MustCompile("(?s)```suggestion[^\n]*\n(.*?)```")
+
+// suggestion is a change suggested by a review comment: the lines start to
+// end of a file, counted from 1, replaced with replacement.
+type suggestion struct {
+	comment     github.ReviewComment
+	start       int
+	end         int
+	replacement []string
+}
+
+// parseSuggestion extracts the suggested change from a review comment, if it
+// has one and it applies to the latest version of the file.
+func parseSuggestion(comment github.ReviewComment) (*suggestion, bool) {
+	if comment.InReplyToId != 0 || comment.Line == 0 || comment.Side == "LEFT" {
+		return nil, false
+	}
+	m := suggestionRegexp.FindStringSubmatch(strings.Replace(comment.Body, "\r\n", "\n", -1))
+	if m == nil {
+		return nil, false
+	}
+
+	s := &suggestion{comment: comment, start: comment.Line, end: comment.Line}
+	if comment.StartLine > 0 {
+		s.start = comment.StartLine
+	}
+	if text := strings.TrimSuffix(m[1], "\n"); m[1] != "" {
+		s.replacement = strings.Split(text, "\n")
+	}
+	return s, true
+}
+
+// apply replaces the lines of the suggestion in content.
+func (s *suggestion) apply(content string) (string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if s.end > len(lines) {
+		return "", fmt.Errorf("%s has no line %d", s.comment.Path, s.end)
+	}
+
+	replaced := make([]string, 0, len(lines))
+	replaced = append(replaced, lines[:s.start-1]...)
+	for _, line := range s.replacement {
+		replaced = append(replaced, line+"\n")
+	}
+	replaced = append(replaced, lines[s.end:]...)
+
+	result := strings.Join(replaced, "")
+	// Keep a missing newline at the end of the file missing.
+	if s.end == len(lines) && !strings.HasSuffix(content, "\n") {
+		result = strings.TrimSuffix(result, "\n")
+	}
+	return result, nil
+}
+
+// orderSuggestions sorts suggestions by file and from the bottom of each file
+// up, so that applying one doesn't move the lines of the next. Suggestions
+// that overlap one before them are returned separately.
+func orderSuggestions(suggestions []*suggestion) (ordered, overlapping []*suggestion) {
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.comment.Path != b.comment.Path {
+			return a.comment.Path < b.comment.Path
+		}
+		return a.start > b.start
+	})
+
+	for _, s := range suggestions {
+		if len(ordered) > 0 {
+			prev := ordered[len(ordered)-1]
+			if prev.comment.Path == s.comment.Path && s.end >= prev.start {
+				overlapping = append(overlapping, s)
+				continue
+			}
+		}
+		ordered = append(ordered, s)
+	}
+	return
+}
+
+// suggestionCoAuthors lists a "Co-authored-by" trailer for each author of
+// suggestions, the way GitHub credits them when applying suggestions.
+func suggestionCoAuthors(suggestions []*suggestion) []string {
+	trailers := []string{}
+	seen := map[string]bool{}
+	for _, s := range suggestions {
+		user := s.comment.User
+		if user == nil || seen[user.Login] {
+			continue
+		}
+		seen[user.Login] = true
+		trailers = append(trailers, fmt.Sprintf("Co-authored-by: %s <%d+%s@users.noreply.github.com>", user.Login, user.Id, user.Login))
+	}
+	return trailers
+}
+
+func applySuggestionsPr(command *Command, args *Args) {
+	prNumber := prNumberFromArgs(command, args)
+	project, err := resolveProject("")
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	branch, err := localRepo.CurrentBranch()
+	utils.Check(err)
+	workdir, err := git.WorkdirName()
+	utils.Check(err)
+
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+	head, err := git.Ref("HEAD")
+	utils.Check(err)
+	if head != pr.Head.Sha {
+		utils.Check(fmt.Errorf("Aborted: the current branch isn't at the head of pull request #%d\n(use `hub pr checkout %d` to check it out)", prNumber, prNumber))
+	}
+
+	var pushRemote string
+	var pushBranch *github.Branch
+	if args.Flag.Bool("--push") {
+		pushRemote, pushBranch, err = branchTrackingInformation(branch)
+		if err != nil {
+			utils.Check(fmt.Errorf("Aborted: the current branch '%s' doesn't track a remote branch to push to", branch.ShortName()))
+		}
+	}
+
+	comments, err := gh.PullRequestReviewComments(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+
+	suggestions := []*suggestion{}
+	for _, comment := range comments {
+		if s, ok := parseSuggestion(comment); ok {
+			suggestions = append(suggestions, s)
+		}
+	}
+	suggestions, overlapping := orderSuggestions(suggestions)
+	for _, s := range overlapping {
+		ui.Errorf("warning: skipping suggestion %s that overlaps another one\n", s.comment.HtmlUrl)
+	}
+
+	paths := []string{}
+	for _, s := range suggestions {
+		if len(paths) == 0 || paths[len(paths)-1] != s.comment.Path {
+			paths = append(paths, s.comment.Path)
+		}
+	}
+	if len(paths) > 0 && !git.Quiet(append([]string{"diff", "--quiet", "HEAD", "--"}, paths...)...) {
+		utils.Check(fmt.Errorf("Aborted: there are uncommitted changes to files with suggestions"))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would apply %d suggestions from pull request #%d for %s\n", len(suggestions), prNumber, project)
+		return
+	}
+
+	applied := []*suggestion{}
+	for _, s := range suggestions {
+		filename := filepath.Join(workdir, s.comment.Path)
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			ui.Errorf("warning: skipping suggestion %s: %s\n", s.comment.HtmlUrl, err)
+			continue
+		}
+		result, err := s.apply(string(content))
+		if err != nil {
+			ui.Errorf("warning: skipping suggestion %s: %s\n", s.comment.HtmlUrl, err)
+			continue
+		}
+		if result == string(content) {
+			ui.Errorf("warning: skipping suggestion %s that is already applied\n", s.comment.HtmlUrl)
+			continue
+		}
+		utils.Check(ioutil.WriteFile(filename, []byte(result), 0644))
+		applied = append(applied, s)
+
+		if !args.Flag.Bool("--batch") {
+			message := fmt.Sprintf("Apply suggestion from code review\n\n%s\n\n%s", s.comment.HtmlUrl, strings.Join(suggestionCoAuthors([]*suggestion{s}), "\n"))
+			utils.Check(git.Spawn("commit", "--quiet", "--message", message, "--", filename))
+		}
+	}
+
+	if len(applied) == 0 {
+		ui.Println("No suggestions to apply")
+		return
+	}
+
+	if args.Flag.Bool("--batch") {
+		files := []string{}
+		for _, s := range applied {
+			files = append(files, filepath.Join(workdir, s.comment.Path))
+		}
+		message := fmt.Sprintf("Apply suggestions from code review\n\n%s", strings.Join(suggestionCoAuthors(applied), "\n"))
+		utils.Check(git.Spawn(append([]string{"commit", "--quiet", "--message", message, "--"}, files...)...))
+	}
+	ui.Printf("Applied %d %s from pull request #%d\n", len(applied), pluralize(len(applied), "suggestion"), prNumber)
+
+	if pushBranch != nil {
+		utils.Check(git.Spawn("push", pushRemote, "HEAD:"+pushBranch.Name))
+	}
+}
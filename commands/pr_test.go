@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/github/hub/github"
+)
+
+func TestUsesPlaceholders(t *testing.T) {
+	assert.Equal(t, true, usesPlaceholders("%i %ck%n", statusPlaceholders...))
+	assert.Equal(t, true, usesPlaceholders("%t% rv", statusPlaceholders...))
+	assert.Equal(t, false, usesPlaceholders("%i %t%n", statusPlaceholders...))
+	assert.Equal(t, false, usesPlaceholders("%L", "l"))
+}
+
+func TestPullRequestListFields(t *testing.T) {
+	assert.Equal(t, []string{"labels"}, pullRequestListFields("%pC%>(8)%i%Creset  %t%  l%n", pullRequestFieldOptions{}))
+	assert.Equal(t, []string{}, pullRequestListFields("%I%n", pullRequestFieldOptions{}))
+	assert.Equal(t, []string{"labels", "reviewRequests", "status"}, pullRequestListFields("%I %ck%n", pullRequestFieldOptions{byLabels: true, byReviewer: true, withStatus: true}))
+	assert.Equal(t, []string{"assignees"}, pullRequestListFields("%I%n", pullRequestFieldOptions{byAssignee: true}))
+	assert.Equal(t, []string{"body", "milestone", "refs"}, pullRequestListFields("%b %Mt %sH", pullRequestFieldOptions{}))
+	assert.Equal(t, []string{"body", "labels", "assignees", "milestone", "reviewRequests", "refs"}, pullRequestListFields("", pullRequestFieldOptions{structured: true}))
+}
+
+func TestColorizeDiffLine(t *testing.T) {
+	assert.Equal(t, "\033[1mdiff --git a/x b/x\033[m", colorizeDiffLine("diff --git a/x b/x"))
+	assert.Equal(t, "\033[1m--- a/x\033[m", colorizeDiffLine("--- a/x"))
+	assert.Equal(t, "\033[36m@@ -1 +1 @@\033[m", colorizeDiffLine("@@ -1 +1 @@"))
+	assert.Equal(t, "\033[32m+added\033[m", colorizeDiffLine("+added"))
+	assert.Equal(t, "\033[31m-removed\033[m", colorizeDiffLine("-removed"))
+	assert.Equal(t, " context", colorizeDiffLine(" context"))
+}
+
+func TestParseSuggestion(t *testing.T) {
+	comment := github.ReviewComment{Path: "a.txt", Line: 3, StartLine: 2, Side: "RIGHT"}
+	comment.Body = "Maybe:\r\n```suggestion\r\ntwo\r\nthree\r\n```"
+	s, ok := parseSuggestion(comment)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 2, s.start)
+	assert.Equal(t, 3, s.end)
+	assert.Equal(t, []string{"two", "three"}, s.replacement)
+
+	comment.Body = "```suggestion\n```"
+	s, ok = parseSuggestion(comment)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 0, len(s.replacement))
+
+	comment.Line = 0
+	_, ok = parseSuggestion(comment)
+	assert.Equal(t, false, ok)
+
+	_, ok = parseSuggestion(github.ReviewComment{Line: 1, Comment: github.Comment{Body: "Nice"}})
+	assert.Equal(t, false, ok)
+}
+
+func TestSuggestionApply(t *testing.T) {
+	s := &suggestion{start: 2, end: 3, replacement: []string{"TWO"}}
+	result, err := s.apply("one\ntwo\nthree\nfour\n")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "one\nTWO\nfour\n", result)
+
+	s = &suggestion{start: 2, end: 2, replacement: []string{"Two"}}
+	result, err = s.apply("one\ntwo")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "one\nTwo", result)
+
+	s = &suggestion{start: 1, end: 1}
+	result, err = s.apply("one\ntwo\n")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "two\n", result)
+
+	s = &suggestion{start: 5, end: 5, comment: github.ReviewComment{Path: "a.txt"}}
+	_, err = s.apply("one\n")
+	assert.Equal(t, "a.txt has no line 5", err.Error())
+}
+
+func TestOrderSuggestions(t *testing.T) {
+	newSuggestion := func(path string, start, end int) *suggestion {
+		return &suggestion{comment: github.ReviewComment{Path: path}, start: start, end: end}
+	}
+	a := newSuggestion("b.txt", 1, 1)
+	b := newSuggestion("a.txt", 2, 4)
+	c := newSuggestion("a.txt", 10, 10)
+	d := newSuggestion("a.txt", 4, 5)
+
+	ordered, overlapping := orderSuggestions([]*suggestion{a, b, c, d})
+	assert.Equal(t, []*suggestion{c, d, a}, ordered)
+	assert.Equal(t, []*suggestion{b}, overlapping)
+}
+
+func TestGateStatuses(t *testing.T) {
+	statuses := []github.CIStatus{
+		{Context: "build", State: "failure", CheckRun: &github.CheckRun{Id: 1}},
+		{Context: "build", State: "success", CheckRun: &github.CheckRun{Id: 2}},
+		{Context: "lint", State: "failure"},
+	}
+
+	listed, gating := gateStatuses(statuses, nil, false)
+	assert.Equal(t, 2, len(listed))
+	assert.Equal(t, "success", listed[0].State)
+	assert.Equal(t, "failure", gateState(gating))
+
+	listed, gating = gateStatuses(statuses, []string{"build", "test"}, false)
+	assert.Equal(t, 3, len(listed))
+	assert.Equal(t, "test", listed[2].Context)
+	assert.Equal(t, "pending", gateState(gating))
+
+	listed, gating = gateStatuses(statuses, []string{"build"}, true)
+	assert.Equal(t, 1, len(listed))
+	assert.Equal(t, "success", gateState(gating))
+}
+
+func TestGateState(t *testing.T) {
+	assert.Equal(t, "", gateState(nil))
+	assert.Equal(t, "success", gateState([]github.CIStatus{{State: "success"}, {State: "skipped"}, {State: "neutral"}}))
+	assert.Equal(t, "pending", gateState([]github.CIStatus{{State: "success"}, {State: "pending"}}))
+	assert.Equal(t, "failure", gateState([]github.CIStatus{{State: "pending"}, {State: "timed_out"}}))
+}
+
+func TestParseIssueReferences(t *testing.T) {
+	refs, err := parseIssueReferences([]string{"42", "#57", "github/hub#3", ""})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []issueReference{{"", 42}, {"", 57}, {"github/hub", 3}}, refs)
+
+	_, err = parseIssueReferences([]string{"hub#3"})
+	assert.Equal(t, "invalid issue: 'hub#3'", err.Error())
+}
+
+func TestAddClosingKeywords(t *testing.T) {
+	issues := []issueReference{{"", 42}, {"github/hub", 3}}
+	assert.Equal(t, "Body\n\nFixes #42\n\nCloses github/hub#3", addClosingKeywords("Body\n\nFixes #42", issues))
+	assert.Equal(t, "Closes #42\nCloses github/hub#3", addClosingKeywords("", issues))
+	assert.Equal(t, "resolved: #42, closes GitHub/Hub#3", addClosingKeywords("resolved: #42, closes GitHub/Hub#3", issues))
+}
+
+func TestRemoveClosingKeywords(t *testing.T) {
+	issues := []issueReference{{"", 42}}
+	assert.Equal(t, "Body", removeClosingKeywords("Body\n\nCloses #42", issues))
+	assert.Equal(t, "This fixes a bug from #42.\n\nCloses #57", removeClosingKeywords("This fixes a bug from #42.\n\nCloses #57", issues))
+	assert.Equal(t, "Related to #42 and #57", removeClosingKeywords("Related to fixes #42 and #57", issues))
+}
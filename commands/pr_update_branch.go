@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+func statusPr(command *Command, args *Args) {
+	prNumber := prNumberFromArgs(command, args)
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request the status of pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+
+	var comparison *github.Comparison
+	var statuses map[int]github.PullRequestStatus
+	err = github.ParallelFetch(2, github.ParallelFetchLimit, func(i int) error {
+		var err error
+		if i == 0 {
+			comparison, err = gh.CompareCommits(project, pr.Base.Ref, pr.Head.Sha)
+		} else {
+			statuses, err = gh.PullRequestStatuses(project, []int{prNumber})
+		}
+		return err
+	})
+	utils.Check(err)
+
+	ui.Printf("#%d %s\n", pr.Number, pr.Title)
+	ui.Print(formatPullRequestStatus(pr, comparison, statuses[prNumber]))
+
+	if pr.Mergeable != nil && !*pr.Mergeable {
+		os.Exit(1)
+	}
+}
+
+// formatPullRequestStatus describes whether pr can be merged, given the
+// comparison of its base branch with its head.
+func formatPullRequestStatus(pr *github.PullRequest, comparison *github.Comparison, status github.PullRequestStatus) string {
+	mergeable := "unknown (GitHub is still checking for conflicts)"
+	if pr.Mergeable != nil {
+		if *pr.Mergeable {
+			mergeable = "yes"
+		} else {
+			mergeable = fmt.Sprintf("no, it has conflicts with %s", pr.Base.Ref)
+		}
+	}
+
+	upToDate := "yes"
+	if comparison.BehindBy == 1 {
+		upToDate = fmt.Sprintf("no, 1 commit behind %s", pr.Base.Ref)
+	} else if comparison.BehindBy > 1 {
+		upToDate = fmt.Sprintf("no, %d commits behind %s", comparison.BehindBy, pr.Base.Ref)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Mergeable:   %s", mergeable),
+		fmt.Sprintf("Up to date:  %s", upToDate),
+	}
+	if status.ReviewDecision != "" {
+		lines = append(lines, fmt.Sprintf("Review:      %s", strings.Replace(status.ReviewDecision, "_", " ", -1)))
+	}
+	if status.Checks != "" {
+		lines = append(lines, fmt.Sprintf("Checks:      %s", status.Checks))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func updateBranchPr(command *Command, args *Args) {
+	method := "merge"
+	if args.Flag.Bool("--rebase") {
+		method = "rebase"
+	}
+
+	if args.Flag.Bool("--all") {
+		if !args.IsParamsEmpty() {
+			utils.Check(command.UsageError("--all can't be combined with a pull request number"))
+		}
+		updateAllPrBranches(args, method)
+		return
+	}
+
+	prNumber := prNumberFromArgs(command, args)
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would update the branch of pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+	comparison, err := gh.CompareCommits(project, pr.Base.Ref, pr.Head.Sha)
+	utils.Check(err)
+	if comparison.BehindBy == 0 {
+		ui.Printf("Pull request #%d is already up to date with %s\n", prNumber, pr.Base.Ref)
+		return
+	}
+
+	utils.Check(gh.UpdatePullRequestBranch(pr, method))
+	ui.Printf("Updated pull request #%d with %s\n", prNumber, pr.Base.Ref)
+}
+
+// updateAllPrBranches updates the branches of the current user's open pull
+// requests that are behind their base, carrying on past the ones that fail.
+func updateAllPrBranches(args *Args, method string) {
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would update the branches of your open pull requests for %s\n", project)
+		return
+	}
+
+	user, err := gh.CurrentUser()
+	utils.Check(err)
+	pulls, err := gh.FetchPullRequests(project, map[string]interface{}{"state": "open"}, -1, func(pr *github.PullRequest) bool {
+		return pr.User != nil && pr.User.Login == user.Login
+	})
+	utils.Check(err)
+
+	comparisons := make([]*github.Comparison, len(pulls))
+	err = github.ParallelFetch(len(pulls), github.ParallelFetchLimit, func(i int) error {
+		var err error
+		comparisons[i], err = gh.CompareCommits(project, pulls[i].Base.Ref, pulls[i].Head.Sha)
+		return err
+	})
+	utils.Check(err)
+
+	updated, failed := 0, 0
+	for i, pr := range pulls {
+		if comparisons[i].BehindBy == 0 {
+			continue
+		}
+		if err := gh.UpdatePullRequestBranch(&pulls[i], method); err != nil {
+			ui.Errorf("Error updating pull request #%d: %s\n", pr.Number, err)
+			failed++
+			continue
+		}
+		ui.Printf("Updated pull request #%d with %s\n", pr.Number, pr.Base.Ref)
+		updated++
+	}
+
+	if updated == 0 && failed == 0 {
+		ui.Println("All of your open pull requests are up to date")
+	}
+	if failed > 0 {
+		utils.Check(fmt.Errorf("failed to update %d of %d pull requests", failed, updated+failed))
+	}
+}
@@ -0,0 +1,338 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+	"gopkg.in/yaml.v2"
+)
+
+var cmdProtect = &Command{
+	Run: protectBranch,
+	Usage: `
+protect [-c <CONTEXTS>] [--strict] [-r <COUNT>] [--dismiss-stale-reviews] [--code-owner-reviews] [--signed-commits] [--linear-history] [--enforce-admins] <BRANCH> [<OWNER>/<REPO>]
+protect --show <BRANCH> [<OWNER>/<REPO>]
+protect show <BRANCH> [<OWNER>/<REPO>]
+protect -F <FILE> [--resume]
+`,
+	Long: `Configure branch protection rules on GitHub.
+
+## Options:
+	--show
+		Print the protection rules currently in effect for <BRANCH>. The "show"
+		verb is an alias of this flag.
+
+	-c, --status-checks <CONTEXTS>
+		A comma-separated list of status checks that must pass before merging.
+
+	--strict
+		Require branches to be up to date with <BRANCH> before merging.
+
+	-r, --required-reviews <COUNT>
+		The number of approving reviews required before merging.
+
+	--dismiss-stale-reviews
+		Dismiss approving reviews when new commits are pushed.
+
+	--code-owner-reviews
+		Require an approving review from code owners.
+
+	--signed-commits
+		Require commits pushed to <BRANCH> to have verified signatures.
+
+	--linear-history
+		Prevent merge commits from being pushed to <BRANCH>.
+
+	--enforce-admins
+		Enforce all rules for repository administrators as well.
+
+	-F, --file <FILE>
+		Apply the policies from a YAML <FILE> to all the repositories listed in
+		it. The file contains a list of policies, each having the "repos" and
+		"branch" keys and any of the rule keys "status_checks", "strict",
+		"required_reviews", "dismiss_stale_reviews", "code_owner_reviews",
+		"signed_commits", "linear_history", and "enforce_admins".
+
+	--resume
+		Continue applying a policy <FILE> from where a previous, interrupted run
+		left off. A run is only resumed if neither the <FILE> nor the list of
+		repositories in it have changed since.
+
+	<BRANCH>
+		The branch to protect.
+
+	<OWNER>/<REPO>
+		The repository to configure (default: the current repository).
+
+Rules that are not passed as options are disabled, since configuring branch
+protection replaces any rules that were previously in effect.
+
+## Examples:
+		$ hub protect main -c ci/test,lint --strict -r 2 --linear-history
+		$ hub protect show main
+
+		$ cat rules.yml
+		- repos: [my-org/api, my-org/web]
+		  branch: main
+		  status_checks: [ci/test]
+		  required_reviews: 1
+		  signed_commits: true
+		$ hub protect --file rules.yml
+
+## See also:
+
+hub-unprotect(1), hub(1)
+`,
+}
+
+var cmdUnprotect = &Command{
+	Run:   unprotectBranch,
+	Usage: "unprotect <BRANCH> [<OWNER>/<REPO>]",
+	Long: `Remove all branch protection rules from a branch on GitHub.
+
+## Options:
+	<BRANCH>
+		The branch to remove protection from.
+
+	<OWNER>/<REPO>
+		The repository to configure (default: the current repository).
+
+## See also:
+
+hub-protect(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdProtect)
+	CmdRunner.Use(cmdUnprotect)
+}
+
+type protectionPolicy struct {
+	Repos               []string `yaml:"repos"`
+	Branch              string   `yaml:"branch"`
+	StatusChecks        []string `yaml:"status_checks"`
+	Strict              bool     `yaml:"strict"`
+	RequiredReviews     int      `yaml:"required_reviews"`
+	DismissStaleReviews bool     `yaml:"dismiss_stale_reviews"`
+	CodeOwnerReviews    bool     `yaml:"code_owner_reviews"`
+	SignedCommits       bool     `yaml:"signed_commits"`
+	LinearHistory       bool     `yaml:"linear_history"`
+	EnforceAdmins       bool     `yaml:"enforce_admins"`
+}
+
+func (p *protectionPolicy) params() map[string]interface{} {
+	params := map[string]interface{}{
+		"required_status_checks":        nil,
+		"required_pull_request_reviews": nil,
+		"enforce_admins":                p.EnforceAdmins,
+		"required_linear_history":       p.LinearHistory,
+		"restrictions":                  nil,
+	}
+	if len(p.StatusChecks) > 0 || p.Strict {
+		contexts := p.StatusChecks
+		if contexts == nil {
+			contexts = []string{}
+		}
+		params["required_status_checks"] = map[string]interface{}{
+			"strict":   p.Strict,
+			"contexts": contexts,
+		}
+	}
+	if p.RequiredReviews > 0 || p.DismissStaleReviews || p.CodeOwnerReviews {
+		params["required_pull_request_reviews"] = map[string]interface{}{
+			"required_approving_review_count": p.RequiredReviews,
+			"dismiss_stale_reviews":           p.DismissStaleReviews,
+			"require_code_owner_reviews":      p.CodeOwnerReviews,
+		}
+	}
+	return params
+}
+
+func (p *protectionPolicy) apply(gh *github.Client, project *github.Project) error {
+	if err := gh.UpdateBranchProtection(project, p.Branch, p.params()); err != nil {
+		return err
+	}
+	return gh.SetRequiredSignatures(project, p.Branch, p.SignedCommits)
+}
+
+func protectBranch(cmd *Command, args *Args) {
+	args.NoForward()
+
+	if args.Flag.HasReceived("--file") {
+		applyProtectionFile(args, args.Flag.Value("--file"))
+		return
+	}
+
+	show := args.Flag.Bool("--show")
+	if args.ParamsSize() > 1 && args.FirstParam() == "show" {
+		args.RemoveParam(0)
+		show = true
+	}
+
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError("you must specify a branch"))
+	}
+	branch := args.GetParam(0)
+	project := repoProjectFromArgs(args, 1)
+	gh := github.NewClient(project.Host)
+
+	if show {
+		if args.Noop {
+			ui.Printf("Would request branch protection for %s@%s\n", project, branch)
+			return
+		}
+		protection, err := gh.BranchProtection(project, branch)
+		utils.Check(err)
+		printBranchProtection(project, branch, protection)
+		return
+	}
+
+	policy := &protectionPolicy{
+		Branch:              branch,
+		StatusChecks:        commaSeparated(args.Flag.AllValues("--status-checks")),
+		Strict:              args.Flag.Bool("--strict"),
+		DismissStaleReviews: args.Flag.Bool("--dismiss-stale-reviews"),
+		CodeOwnerReviews:    args.Flag.Bool("--code-owner-reviews"),
+		SignedCommits:       args.Flag.Bool("--signed-commits"),
+		LinearHistory:       args.Flag.Bool("--linear-history"),
+		EnforceAdmins:       args.Flag.Bool("--enforce-admins"),
+	}
+	if args.Flag.HasReceived("--required-reviews") {
+		count, err := strconv.Atoi(args.Flag.Value("--required-reviews"))
+		if err != nil || count < 0 {
+			utils.Check(fmt.Errorf("invalid number of required reviews: '%s'", args.Flag.Value("--required-reviews")))
+		}
+		policy.RequiredReviews = count
+	}
+
+	if args.Noop {
+		ui.Printf("Would protect branch %s@%s\n", project, branch)
+		return
+	}
+
+	utils.Check(policy.apply(gh, project))
+	ui.Infof("Protected branch %s@%s\n", project, branch)
+}
+
+func applyProtectionFile(args *Args, filename string) {
+	content, err := ioutil.ReadFile(filename)
+	utils.Check(err)
+
+	policies := []protectionPolicy{}
+	utils.Check(yaml.UnmarshalStrict(content, &policies))
+
+	targets := []string{}
+	targetPolicies := map[string]*protectionPolicy{}
+	for i := range policies {
+		policy := &policies[i]
+		if policy.Branch == "" {
+			utils.Check(fmt.Errorf("%s: policy #%d is missing the \"branch\" key", filename, i+1))
+		}
+		for _, repo := range policy.Repos {
+			target := fmt.Sprintf("%s@%s", repo, policy.Branch)
+			targets = append(targets, target)
+			targetPolicies[target] = policy
+		}
+	}
+
+	if args.Noop {
+		for _, target := range targets {
+			ui.Printf("Would protect branch %s\n", target)
+		}
+		return
+	}
+
+	job := newBulkJob(args, protectionJobName(content, targets))
+	err = job.Run(targets, func(target string) error {
+		policy := targetPolicies[target]
+		project, err := resolveProject(strings.TrimSuffix(target, "@"+policy.Branch))
+		if err != nil {
+			return err
+		}
+		if err = policy.apply(github.NewClient(project.Host), project); err != nil {
+			return err
+		}
+		ui.Infof("Protected branch %s\n", target)
+		return nil
+	})
+	utils.Check(err)
+}
+
+// protectionJobName identifies a '--file' run so that '--resume' never skips
+// targets based on the progress of a different policy or list of repositories.
+func protectionJobName(content []byte, targets []string) string {
+	sorted := append([]string{}, targets...)
+	sort.Strings(sorted)
+	h := sha256.New()
+	h.Write(content)
+	h.Write([]byte(strings.Join(sorted, "\n")))
+	return fmt.Sprintf("protect-%x", h.Sum(nil)[:8])
+}
+
+func printBranchProtection(project *github.Project, branch string, protection *github.BranchProtection) {
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+
+	checks := "none"
+	if c := protection.RequiredStatusChecks; c != nil {
+		checks = strings.Join(c.Contexts, ", ")
+		if checks == "" {
+			checks = "none"
+		}
+		if c.Strict {
+			checks += " (strict)"
+		}
+	}
+
+	reviews := "none"
+	if r := protection.RequiredPullRequestReviews; r != nil {
+		reviews = strconv.Itoa(r.RequiredApprovingReviewCount)
+		extras := []string{}
+		if r.DismissStaleReviews {
+			extras = append(extras, "dismiss stale")
+		}
+		if r.RequireCodeOwnerReviews {
+			extras = append(extras, "code owners")
+		}
+		if len(extras) > 0 {
+			reviews += fmt.Sprintf(" (%s)", strings.Join(extras, ", "))
+		}
+	}
+
+	ui.Printf("Branch protection for %s@%s\n\n", project, branch)
+	ui.Printf("  %-24s %s\n", "Required status checks:", checks)
+	ui.Printf("  %-24s %s\n", "Required reviews:", reviews)
+	ui.Printf("  %-24s %s\n", "Signed commits:", yesNo(protection.RequiresSignatures()))
+	ui.Printf("  %-24s %s\n", "Linear history:", yesNo(protection.RequiresLinearHistory()))
+	ui.Printf("  %-24s %s\n", "Enforce for admins:", yesNo(protection.EnforcesAdmins()))
+}
+
+func unprotectBranch(cmd *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError("you must specify a branch"))
+	}
+	branch := args.GetParam(0)
+	project := repoProjectFromArgs(args, 1)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would remove protection from branch %s@%s\n", project, branch)
+		return
+	}
+
+	utils.Check(gh.DeleteBranchProtection(project, branch))
+	ui.Infof("Removed protection from branch %s@%s\n", project, branch)
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestProtectionPolicyParams(t *testing.T) {
+	policy := &protectionPolicy{
+		StatusChecks:  []string{"ci/test"},
+		LinearHistory: true,
+	}
+	params := policy.params()
+
+	assert.Equal(t, map[string]interface{}{
+		"strict":   false,
+		"contexts": []string{"ci/test"},
+	}, params["required_status_checks"])
+	assert.Equal(t, nil, params["required_pull_request_reviews"])
+	assert.Equal(t, true, params["required_linear_history"])
+	assert.Equal(t, false, params["enforce_admins"])
+
+	policy = &protectionPolicy{RequiredReviews: 2, CodeOwnerReviews: true}
+	params = policy.params()
+	assert.Equal(t, nil, params["required_status_checks"])
+	assert.Equal(t, map[string]interface{}{
+		"required_approving_review_count": 2,
+		"dismiss_stale_reviews":           false,
+		"require_code_owner_reviews":      true,
+	}, params["required_pull_request_reviews"])
+}
+
+func TestProtectionJobName(t *testing.T) {
+	content := []byte("- repos: [my-org/api, my-org/web]\n  branch: main\n")
+	name := protectionJobName(content, []string{"my-org/api@main", "my-org/web@main"})
+	assert.Equal(t, name, protectionJobName(content, []string{"my-org/web@main", "my-org/api@main"}))
+	assert.NotEqual(t, name, protectionJobName(content, []string{"my-org/api@main"}))
+
+	edited := []byte("- repos: [my-org/api, my-org/web]\n  branch: main\n  signed_commits: true\n")
+	assert.NotEqual(t, name, protectionJobName(edited, []string{"my-org/api@main", "my-org/web@main"}))
+}
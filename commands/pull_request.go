@@ -4,22 +4,25 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/github/hub/git"
 	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
 )
 
 var cmdPullRequest = &Command{
 	Run: pullRequest,
 	Usage: `
-pull-request [-focpd] [-b <BASE>] [-h <HEAD>] [-r <REVIEWERS> ] [-a <ASSIGNEES>] [-M <MILESTONE>] [-l <LABELS>]
+pull-request [-focpd] [--no-verify] [-b <BASE>] [-h <HEAD>] [-r <REVIEWERS> ] [--suggest-reviewers] [-a <ASSIGNEES>] [-M <MILESTONE>] [-l <LABELS>] [--closes <ISSUES>]
 pull-request -m <MESSAGE> [--edit]
 pull-request -F <FILE> [--edit]
 pull-request -i <ISSUE>
+pull-request --web [-p] [-b <BASE>] [-h <HEAD>] [-m <MESSAGE>|-F <FILE>] [--edit] [-a <ASSIGNEES>] [-M <MILESTONE>] [-l <LABELS>]
 `,
 	Long: `Create a GitHub Pull Request.
 
@@ -61,11 +64,26 @@ pull-request -i <ISSUE>
 	-o, --browse
 		Open the new pull request in a web browser.
 
+	--web
+		Instead of creating the pull request, open the form for a new pull request
+		in a web browser, filled in with the base and head branches, title,
+		description, labels, assignees, and milestone given. Without '--message'
+		or '--file', GitHub fills in the title and description from the commits.
+		A text editor only opens with '--edit'. Reviewers and drafts can't be
+		filled in this way.
+
 	-c, --copy
 		Put the URL of the new pull request to clipboard instead of printing it.
 
 	-p, --push
-		Push the current branch to <HEAD> before creating the pull request.
+		Push the current branch to <HEAD> before creating the pull request. The
+		pushed branch is set as upstream of the current branch, and the number of
+		the new pull request is recorded in the "branch.<BRANCH>.hub-pr" git
+		config so that hub-pr(1) commands can find it without arguments.
+
+		If you don't have push access to the repository of <HEAD>, hub offers to
+		fork it, add a git remote for the fork, and push there instead. The pull
+		request is then opened from the branch of the fork.
 
 	-b, --base <BASE>
 		The base branch in the "[<OWNER>:]<BRANCH>" format. Defaults to the default
@@ -82,6 +100,13 @@ pull-request -i <ISSUE>
 		A comma-separated list (no spaces around the comma) of GitHub handles to
 		request a review from.
 
+	--suggest-reviewers
+		Also request a review from up to 3 people who know the code being changed
+		best: the authors of the most lines that the pull request modifies or
+		deletes, according to git-blame(1). Lines that are only added count toward
+		the author of the line preceding them. The suggestions are listed in the
+		text editor, if one opens, and exclude yourself and bot accounts.
+
 	-a, --assign <USERS>
 		A comma-separated list (no spaces around the comma) of GitHub handles to
 		assign to this pull request.
@@ -97,11 +122,22 @@ pull-request -i <ISSUE>
 	-d, --draft
 		Create the pull request as a draft.
 
+	--closes <ISSUES>
+		A comma-separated list of issues that the pull request resolves, either
+		as numbers or in "OWNER/NAME#NUMBER" format. A "Closes" line is added to
+		the description for each issue that it doesn't close already, which links
+		the issue to the pull request so that it gets closed on merge. Use
+		'hub pr links' to change the linked issues later.
+
 	--no-maintainer-edits
 		When creating a pull request from a fork, this disallows projects
 		maintainers from being able to push to the head branch of this fork.
 		Maintainer edits are allowed by default.
 
+	--no-verify
+		Skip the checks of the pull request title, description, and base branch
+		(see "Configuration").
+
 ## Examples:
 		$ hub pull-request
 		[ opens a text editor for writing title and message ]
@@ -113,6 +149,9 @@ pull-request -i <ISSUE>
 		$ hub pull-request --browse -m "My title"
 		[ creates a pull request with the given title and opens it in a browser ]
 
+		$ hub pull-request --suggest-reviewers -m "Fix the parser"
+		[ requests a review from the previous authors of the changed code ]
+
 		$ hub pull-request -F - --edit < path/to/message-template.md
 		[ further edit the title and message received on standard input ]
 
@@ -121,6 +160,34 @@ pull-request -i <ISSUE>
 	* 'HUB_RETRY_TIMEOUT':
 		The maximum time to keep retrying after HTTP 422 on '--push' (default: 9).
 
+Before submitting, hub checks the pull request against the rules in the
+"pull_request" section of the ".github/hub.yml" file of the repository, and
+aborts if any of them is broken:
+
+		pull_request:
+		  title_pattern: "^(feat|fix|docs|chore)(\\(.+\\))?: "
+		  required_sections: ["## Summary", "## Testing"]
+		  allowed_bases: [main, "release/*"]
+		  max_diff_lines: 800
+
+	* 'title_pattern':
+		A regular expression that the title has to match.
+
+	* 'required_sections':
+		Lines, such as Markdown headings, that the description has to contain.
+
+	* 'allowed_bases':
+		Shell glob patterns that the base branch has to match.
+
+	* 'max_diff_lines':
+		Warn, without aborting, if the pull request adds and removes more lines.
+
+The git config values "hub.prTitlePattern", "hub.prRequiredSection",
+"hub.prAllowedBase", and "hub.prMaxDiffLines" take precedence over the file;
+the second and third can be given multiple times. Regardless of configuration,
+hub aborts when the base is "master" but the default branch of the repository
+is "main", or vice versa.
+
 ## See also:
 
 hub(1), hub-merge(1), hub-checkout(1)
@@ -204,9 +271,17 @@ func pullRequest(cmd *Command, args *Args) {
 		}
 	}
 
+	var forkRemote *github.Remote
 	if headRepo, err := client.Repository(headProject); err == nil {
 		headProject.Owner = headRepo.Owner.Login
 		headProject.Name = headRepo.Name
+
+		if flagPullRequestPush && !args.Noop && headRepo.Permissions != nil && !headRepo.Permissions.Push {
+			if forkRemote = forkForPush(headProject, host); forkRemote != nil {
+				headProject, err = forkRemote.Project()
+				utils.Check(err)
+			}
+		}
 	}
 
 	fullBase := fmt.Sprintf("%s:%s", baseProject.Owner, base)
@@ -233,7 +308,9 @@ func pullRequest(cmd *Command, args *Args) {
 	if remote != nil {
 		baseTracking = fmt.Sprintf("%s/%s", remote.Name, base)
 	}
-	if remote == nil || !baseProject.SameAs(headProject) {
+	if forkRemote != nil {
+		remote = forkRemote
+	} else if remote == nil || !baseProject.SameAs(headProject) {
 		remote, _ = localRepo.RemoteForProject(headProject)
 	}
 	if remote != nil {
@@ -249,9 +326,34 @@ func pullRequest(cmd *Command, args *Args) {
 Write a message for this pull request. The first block
 of text is the title and the rest is the description.`, fullBase, fullHead))
 
+	flagPullRequestReviewers := commaSeparated(args.Flag.AllValues("--reviewer"))
+	var suggestedReviewers []reviewerSuggestion
+	if args.Flag.Bool("--suggest-reviewers") {
+		headForBlame := headTracking
+		if flagPullRequestPush {
+			headForBlame = head
+		}
+		exclude := append([]string{host.User}, flagPullRequestReviewers...)
+		suggestedReviewers, err = suggestReviewers(client, baseProject, baseTracking, headForBlame, exclude)
+		utils.Check(err)
+
+		if len(suggestedReviewers) > 0 {
+			suggestions := []string{}
+			for _, suggestion := range suggestedReviewers {
+				suggestions = append(suggestions, fmt.Sprintf("  @%s (%d %s)", suggestion.Login, suggestion.Lines, pluralize(suggestion.Lines, "line")))
+				flagPullRequestReviewers = append(flagPullRequestReviewers, suggestion.Login)
+			}
+			messageBuilder.AddCommentedSection("\nReview will be requested from, based on git blame of the changes:\n\n" + strings.Join(suggestions, "\n"))
+		}
+	}
+
 	flagPullRequestMessage := args.Flag.AllValues("--message")
 	flagPullRequestEdit := args.Flag.Bool("--edit")
 	flagPullRequestIssue := args.Flag.Value("--issue")
+	flagPullRequestWeb := args.Flag.Bool("--web")
+	if flagPullRequestWeb && flagPullRequestIssue != "" {
+		utils.Check(fmt.Errorf("--web can't be used with --issue"))
+	}
 	if !args.Flag.HasReceived("--issue") && args.ParamsSize() > 0 {
 		flagPullRequestIssue = parsePullRequestIssueNumber(args.GetParam(0))
 	}
@@ -271,6 +373,8 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		message, err := git.Show(commits[len(commits)-1])
 		utils.Check(err)
 		messageBuilder.Message = message
+	} else if flagPullRequestWeb {
+		messageBuilder.Edit = flagPullRequestEdit
 	} else if flagPullRequestIssue == "" {
 		messageBuilder.Edit = true
 
@@ -311,10 +415,24 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 	title, body, err := messageBuilder.Extract()
 	utils.Check(err)
 
-	if title == "" && flagPullRequestIssue == "" {
+	if title == "" && flagPullRequestIssue == "" && !flagPullRequestWeb {
 		utils.Check(fmt.Errorf("Aborting due to empty pull request title"))
 	}
 
+	if args.Flag.HasReceived("--closes") {
+		issues, err := parseIssueReferences(commaSeparated(args.Flag.AllValues("--closes")))
+		utils.Check(err)
+		body = addClosingKeywords(body, issues)
+	}
+
+	if title != "" && !args.Flag.Bool("--no-verify") {
+		headForDiff := headTracking
+		if flagPullRequestPush {
+			headForDiff = head
+		}
+		utils.Check(verifyPullRequest(client, baseProject, base, baseTracking, headForDiff, title, body))
+	}
+
 	if flagPullRequestPush {
 		if args.Noop {
 			args.Before(fmt.Sprintf("Would push to %s/%s", remote.Name, head), "")
@@ -324,12 +442,38 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		}
 	}
 
+	if flagPullRequestWeb {
+		if len(flagPullRequestReviewers) > 0 || args.Flag.Bool("--draft") {
+			ui.Errorln("warning: reviewers and drafts can't be filled in on the web form")
+		}
+		compareURL := baseProject.WebURL("", "", fmt.Sprintf("compare/%s...%s", rangeQueryEscape(base), rangeQueryEscape(fullHead)))
+		formURL := prefilledFormURL(compareURL, map[string]string{
+			"expand":    "1",
+			"title":     title,
+			"body":      body,
+			"labels":    strings.Join(commaSeparated(args.Flag.AllValues("--labels")), ","),
+			"assignees": strings.Join(commaSeparated(args.Flag.AllValues("--assign")), ","),
+			"milestone": args.Flag.Value("--milestone"),
+		})
+		messageBuilder.Cleanup()
+		args.NoForward()
+		printBrowseOrCopy(args, formURL, true, false)
+		return
+	}
+
 	milestoneNumber, err := milestoneValueToNumber(args.Flag.Value("--milestone"), client, baseProject)
 	utils.Check(err)
 
 	var pullRequestURL string
 	if args.Noop {
 		args.Before(fmt.Sprintf("Would request a pull request to %s from %s", fullBase, fullHead), "")
+		if len(suggestedReviewers) > 0 {
+			logins := []string{}
+			for _, suggestion := range suggestedReviewers {
+				logins = append(logins, suggestion.Login)
+			}
+			args.Before(fmt.Sprintf("Would request review from %s", strings.Join(logins, ", ")), "")
+		}
 		pullRequestURL = "PULL_REQUEST_URL"
 	} else {
 		params := map[string]interface{}{
@@ -394,6 +538,10 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 
 		pullRequestURL = pr.HtmlUrl
 
+		if flagPullRequestPush && currentBranch != nil {
+			git.SetConfig(prNumberConfigKey(currentBranch.ShortName()), strconv.Itoa(pr.Number))
+		}
+
 		params = map[string]interface{}{}
 		flagPullRequestLabels := commaSeparated(args.Flag.AllValues("--labels"))
 		if len(flagPullRequestLabels) > 0 {
@@ -412,20 +560,8 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 			utils.Check(err)
 		}
 
-		flagPullRequestReviewers := commaSeparated(args.Flag.AllValues("--reviewer"))
 		if len(flagPullRequestReviewers) > 0 {
-			userReviewers := []string{}
-			teamReviewers := []string{}
-			for _, reviewer := range flagPullRequestReviewers {
-				if strings.Contains(reviewer, "/") {
-					teamName := strings.SplitN(reviewer, "/", 2)[1]
-					if !pr.HasRequestedTeam(teamName) {
-						teamReviewers = append(teamReviewers, teamName)
-					}
-				} else if !pr.HasRequestedReviewer(reviewer) {
-					userReviewers = append(userReviewers, reviewer)
-				}
-			}
+			userReviewers, teamReviewers := pendingReviewers(pr, flagPullRequestReviewers)
 			if len(userReviewers) > 0 || len(teamReviewers) > 0 {
 				err = client.RequestReview(baseProject, pr.Number, map[string]interface{}{
 					"reviewers":      userReviewers,
@@ -440,6 +576,55 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 	printBrowseOrCopy(args, pullRequestURL, args.Flag.Bool("--browse"), args.Flag.Bool("--copy"))
 }
 
+const maxSuggestedReviewers = 3
+
+type reviewerSuggestion struct {
+	Login string
+	Lines int
+}
+
+// suggestReviewers proposes the GitHub users who last changed the lines that
+// head modifies compared to base, skipping bots and the users in exclude.
+func suggestReviewers(client *github.Client, project *github.Project, base, head string, exclude []string) ([]reviewerSuggestion, error) {
+	authors, err := git.BlameChanges(base, head)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := map[string]bool{}
+	for _, login := range exclude {
+		excluded[strings.ToLower(login)] = true
+	}
+
+	suggestions := []reviewerSuggestion{}
+	index := map[string]int{}
+	for _, author := range authors {
+		login, err := client.CommitAuthorLogin(project, author.Sha)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(login)
+		if login == "" || excluded[key] || strings.HasSuffix(login, "[bot]") {
+			continue
+		}
+		if i, ok := index[key]; ok {
+			// several e-mail addresses of the same user
+			suggestions[i].Lines += author.Lines
+			continue
+		}
+		if len(suggestions) == maxSuggestedReviewers {
+			break
+		}
+		index[key] = len(suggestions)
+		suggestions = append(suggestions, reviewerSuggestion{login, author.Lines})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Lines > suggestions[j].Lines
+	})
+	return suggestions, nil
+}
+
 func parsePullRequestProject(context *github.Project, s string) (p *github.Project, ref string) {
 	p = context
 	ref = s
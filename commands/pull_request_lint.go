@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"gopkg.in/yaml.v2"
+)
+
+// pullRequestChecks are the rules that 'pull-request' verifies a new pull
+// request against, read from the "pull_request" section of .github/hub.yml
+// and overridden by "hub.pr*" git config.
+type pullRequestChecks struct {
+	TitlePattern     string   `yaml:"title_pattern"`
+	RequiredSections []string `yaml:"required_sections"`
+	MaxDiffLines     int      `yaml:"max_diff_lines"`
+	AllowedBases     []string `yaml:"allowed_bases"`
+}
+
+func loadPullRequestChecks() (*pullRequestChecks, error) {
+	checks := &pullRequestChecks{}
+	if workdir, err := git.WorkdirName(); err == nil {
+		content, err := ioutil.ReadFile(filepath.Join(workdir, ".github", "hub.yml"))
+		if err == nil {
+			config := struct {
+				PullRequest *pullRequestChecks `yaml:"pull_request"`
+			}{checks}
+			if err := yaml.Unmarshal(content, &config); err != nil {
+				return nil, fmt.Errorf("invalid .github/hub.yml: %s", err)
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	if pattern, err := git.Config("hub.prTitlePattern"); err == nil && pattern != "" {
+		checks.TitlePattern = pattern
+	}
+	if sections, err := git.ConfigAll("hub.prRequiredSection"); err == nil && len(sections) > 0 {
+		checks.RequiredSections = sections
+	}
+	if max, err := git.Config("hub.prMaxDiffLines"); err == nil && max != "" {
+		if checks.MaxDiffLines, err = strconv.Atoi(max); err != nil {
+			return nil, fmt.Errorf("invalid value for hub.prMaxDiffLines: '%s'", max)
+		}
+	}
+	if bases, err := git.ConfigAll("hub.prAllowedBase"); err == nil && len(bases) > 0 {
+		checks.AllowedBases = bases
+	}
+	return checks, nil
+}
+
+// problems lists how a pull request with title and body, opened against base
+// of a repository whose default branch is defaultBranch, breaks the checks.
+func (c *pullRequestChecks) problems(title, body, base, defaultBranch string) ([]string, error) {
+	problems := []string{}
+
+	if c.TitlePattern != "" {
+		titleRegexp, err := regexp.Compile(c.TitlePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid title pattern '%s': %s", c.TitlePattern, err)
+		}
+		if !titleRegexp.MatchString(title) {
+			problems = append(problems, fmt.Sprintf("the title doesn't match '%s'", c.TitlePattern))
+		}
+	}
+
+	for _, section := range c.RequiredSections {
+		if !hasSection(body, section) {
+			problems = append(problems, fmt.Sprintf("the description is missing the section '%s'", section))
+		}
+	}
+
+	if len(c.AllowedBases) > 0 && !matchesAnyPattern(base, c.AllowedBases) {
+		problems = append(problems, fmt.Sprintf("the base branch '%s' isn't one of: %s", base, strings.Join(c.AllowedBases, ", ")))
+	}
+
+	// Catch a base left over from before the default branch was renamed.
+	if isDefaultBranchName(base) && isDefaultBranchName(defaultBranch) && base != defaultBranch {
+		problems = append(problems, fmt.Sprintf("did you mean to target '%s' instead of '%s'?", defaultBranch, base))
+	}
+
+	return problems, nil
+}
+
+func isDefaultBranchName(branch string) bool {
+	return branch == "master" || branch == "main"
+}
+
+// hasSection tells whether a line of body consists of the heading section.
+func hasSection(body, section string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.EqualFold(strings.TrimSpace(line), strings.TrimSpace(section)) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyPullRequest checks a new pull request against pullRequestChecks, and
+// warns if it changes more lines between base and head than allowed.
+func verifyPullRequest(client *github.Client, project *github.Project, base, baseTracking, head, title, body string) error {
+	checks, err := loadPullRequestChecks()
+	if err != nil {
+		return err
+	}
+
+	defaultBranch := ""
+	if isDefaultBranchName(base) {
+		if repo, err := client.Repository(project); err == nil {
+			defaultBranch = repo.DefaultBranch
+		}
+	}
+
+	problems, err := checks.problems(title, body, base, defaultBranch)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("Aborted: the pull request doesn't pass these checks:\n  %s\n(use `--no-verify` to skip the checks)", strings.Join(problems, "\n  "))
+	}
+
+	if checks.MaxDiffLines > 0 {
+		if lines, err := git.DiffLines(baseTracking, head); err == nil && lines > checks.MaxDiffLines {
+			ui.Errorf("warning: the pull request changes %d lines, more than the maximum of %d\n", lines, checks.MaxDiffLines)
+		}
+	}
+	return nil
+}
@@ -31,3 +31,36 @@ func TestPullRequest_ParsePullRequestProject(t *testing.T) {
 	assert.Equal(t, "mojombo", p.Owner)
 	assert.Equal(t, "jekyll", p.Name)
 }
+
+func TestPullRequestChecks_Problems(t *testing.T) {
+	checks := &pullRequestChecks{
+		TitlePattern:     `^(feat|fix)(\(.+\))?: `,
+		RequiredSections: []string{"## Testing"},
+		AllowedBases:     []string{"main", "release/*"},
+	}
+
+	problems, err := checks.problems("fix(parser): handle tabs", "Fixes it.\n\n## testing \n\nRan it.", "release/2.0", "main")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{}, problems)
+
+	problems, _ = checks.problems("Handle tabs", "Fixes it.", "master", "main")
+	assert.Equal(t, []string{
+		`the title doesn't match '^(feat|fix)(\(.+\))?: '`,
+		"the description is missing the section '## Testing'",
+		"the base branch 'master' isn't one of: main, release/*",
+		"did you mean to target 'main' instead of 'master'?",
+	}, problems)
+
+	_, err = (&pullRequestChecks{TitlePattern: "("}).problems("title", "", "main", "")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestPullRequestChecks_DefaultBranch(t *testing.T) {
+	checks := &pullRequestChecks{}
+
+	problems, _ := checks.problems("title", "", "master", "develop")
+	assert.Equal(t, []string{}, problems)
+
+	problems, _ = checks.problems("title", "", "main", "master")
+	assert.Equal(t, []string{"did you mean to target 'master' instead of 'main'?"}, problems)
+}
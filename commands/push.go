@@ -1,9 +1,17 @@
 package commands
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
 	"strings"
 
+	"github.com/github/hub/cmd"
+	"github.com/github/hub/git"
 	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
 )
 
@@ -13,6 +21,11 @@ var cmdPush = &Command{
 	Usage:        "push <REMOTE>[,<REMOTE2>...] [<REF>]",
 	Long: `Push a git branch to each of the listed remotes.
 
+When GitHub denies a push to a single remote because you don't have push
+access to its repository, hub offers to fork the repository, add a git remote
+for the fork, and push there instead. This only happens if hub is already
+logged in to GitHub; hub never asks for credentials while pushing.
+
 ## Examples:
 		$ hub push origin,staging,qa bert_timeout
 		> git push origin bert_timeout
@@ -22,6 +35,14 @@ var cmdPush = &Command{
 		$ hub push origin
 		> git push origin HEAD
 
+		$ hub push origin topic
+		> git push origin topic
+		[ push is denied ]
+		You don't have push access to OWNER/REPO. Fork it and push to the fork instead (yes/N)? yes
+		[ repo forked on GitHub ]
+		> git remote add USER git@github.com:USER/REPO.git
+		> git push USER topic
+
 ## See also:
 
 hub(1), git-push(1)
@@ -35,7 +56,94 @@ func init() {
 func push(command *Command, args *Args) {
 	if !args.IsParamsEmpty() && strings.Contains(args.FirstParam(), ",") {
 		transformPushArgs(args)
+	} else if !args.Noop && !github.IsOffline() {
+		pushOrForkArgs(args)
+	}
+}
+
+// pushOrForkArgs runs the push to a single remote, and if GitHub denies it
+// because the user can't push to the repository of the remote, offers to push
+// to a fork of it instead.
+func pushOrForkArgs(args *Args) {
+	localRepo, err := github.LocalRepo()
+	if err != nil {
+		return
+	}
+
+	words := args.Words()
+	remoteName := ""
+	if len(words) > 0 {
+		remoteName = words[0]
+	} else if branch, err := localRepo.CurrentBranch(); err == nil {
+		remoteName, _ = git.Config(fmt.Sprintf("branch.%s.remote", branch.ShortName()))
+	}
+	if remoteName == "" {
+		remoteName = "origin"
+	}
+
+	remote, err := localRepo.RemoteByName(remoteName)
+	if err != nil {
+		return
+	}
+	project, err := remote.Project()
+	if err != nil || !hasCredentials(project.Host) {
+		return
 	}
+
+	pushCmd := args.ToCmd()
+	args.NoForward()
+	args.AfterFn(func() error {
+		denied, pushErr := spawnPush(pushCmd)
+		if !denied {
+			return pushErr
+		}
+
+		host, err := github.CurrentConfig().PromptForHost(project.Host)
+		if err != nil {
+			return pushErr
+		}
+		forkRemote := forkForPush(project, host)
+		if forkRemote == nil {
+			return pushErr
+		}
+
+		params := append([]string{}, args.Params...)
+		if len(words) > 0 {
+			params[args.IndexOfParam(remoteName)] = forkRemote.Name
+		} else {
+			params = append(params, forkRemote.Name, "HEAD")
+		}
+		return git.Spawn(append([]string{"push"}, params...)...)
+	})
+}
+
+var pushDeniedRe = regexp.MustCompile(`Permission to \S+ denied to |The requested URL returned error: 403`)
+
+// spawnPush runs the push, passing its error output through, and reports
+// whether it failed because the remote denied access.
+func spawnPush(pushCmd *cmd.Cmd) (bool, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return false, pushCmd.Spawn()
+	}
+	if ui.IsTerminal(os.Stderr) {
+		// git only reports progress to a terminal
+		pushCmd.Args = append(pushCmd.Args, "--progress")
+	}
+	pushCmd.Stderr = w
+
+	output := &bytes.Buffer{}
+	copied := make(chan struct{})
+	go func() {
+		io.Copy(io.MultiWriter(os.Stderr, output), r)
+		close(copied)
+	}()
+	err = pushCmd.Spawn()
+	w.Close()
+	<-copied
+	r.Close()
+
+	return err != nil && pushDeniedRe.MatchString(output.String()), err
 }
 
 func transformPushArgs(args *Args) {
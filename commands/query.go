@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var cmdQuery = &Command{
+	Run: query,
+	Usage: `
+query <NAME> [<ARGS>...]
+query set [--local] <NAME> <COMMAND>
+query delete [--local] <NAME>
+query list [--local]
+`,
+	Long: `Run saved filters for lists of issues and pull requests.
+
+## Commands:
+
+With a <NAME>, run the issue or pull request list saved under that name.
+Placeholders "$1", "$2", etc. in the saved command are replaced with <ARGS>;
+arguments past the last placeholder are appended. Pass additional flags after
+"--".
+
+	* _set_:
+		Save <COMMAND>, an 'issue' or 'pr list' command line without "hub", as
+		the query <NAME>.
+
+	* _delete_:
+		Remove the query <NAME>.
+
+	* _list_:
+		Print the queries in effect as "<NAME>	<COMMAND>" lines.
+
+## Options:
+	--local
+		Save or remove the query only for the current repository, or with 'list',
+		only print the queries of the current repository.
+
+## Examples:
+		$ hub query set my-reviews 'pr list --review-requested @me --state open'
+		$ hub query my-reviews
+
+		$ hub query set --local by-label 'issue -l $1 -o updated'
+		$ hub query by-label bug -- -L 10
+
+Global queries are stored in the hub configuration file as "filters.<NAME>"
+settings. Queries for a repository are stored in its git configuration as
+"hub.filters.<NAME>" and take precedence over global ones of the same name.
+
+## See also:
+
+hub-issue(1), hub-pr(1), hub-config(1), hub(1)
+`,
+	KnownFlags: `
+		--local
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdQuery)
+}
+
+var queryNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+func query(command *Command, args *Args) {
+	switch args.FirstParam() {
+	case "":
+		utils.Check(command.UsageError(""))
+	case "set":
+		setQuery(command, args)
+		return
+	case "delete":
+		deleteQuery(command, args)
+		return
+	case "list":
+		listQueries(args)
+		return
+	}
+
+	if args.Flag.Bool("--local") {
+		utils.Check(command.UsageError("--local can't be used when running a query"))
+	}
+	name := args.FirstParam()
+	expansion := savedQuery(name)
+	if expansion == "" {
+		utils.Check(fmt.Errorf("no such query: %s", name))
+	}
+
+	words, err := splitQueryCmd(expansion)
+	utils.Check(err)
+	words, err = interpolateAlias(words, args.Params[1:])
+	if err != nil {
+		utils.Check(fmt.Errorf("error expanding query %s: %s", name, err))
+	}
+
+	args.Command = words[0]
+	args.Params = words[1:]
+	utils.Check(CmdRunner.Lookup(args.Command).Call(args))
+}
+
+// savedQuery returns the command of a query, looking for one saved for the
+// current repository first.
+func savedQuery(name string) string {
+	if expansion, err := git.Config("hub.filters." + name); err == nil && expansion != "" {
+		return expansion
+	}
+	return github.ConfigSetting("filters." + name)
+}
+
+// splitQueryCmd splits the command of a query into words, which have to start
+// with one of the commands that list issues or pull requests.
+func splitQueryCmd(expansion string) ([]string, error) {
+	words, err := splitAliasCmd(expansion)
+	if err != nil {
+		return nil, err
+	}
+	if words[0] == "hub" {
+		words = words[1:]
+	}
+	if len(words) == 0 || (words[0] != "issue" && words[0] != "pr") {
+		return nil, fmt.Errorf("query has to be an 'issue' or 'pr' command: %s", expansion)
+	}
+	return words, nil
+}
+
+func setQuery(command *Command, args *Args) {
+	if args.ParamsSize() != 3 {
+		utils.Check(command.UsageError(""))
+	}
+	name := args.GetParam(1)
+	expansion := args.GetParam(2)
+
+	if !queryNameRegexp.MatchString(name) || name == "set" || name == "delete" || name == "list" {
+		utils.Check(fmt.Errorf("invalid query name: %s", name))
+	}
+	_, err := splitQueryCmd(expansion)
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set query %s to %q\n", name, expansion)
+		return
+	}
+
+	if args.Flag.Bool("--local") {
+		_, err := git.Dir()
+		utils.Check(err)
+		utils.Check(git.SetConfig("hub.filters."+name, expansion))
+		return
+	}
+
+	config := github.CurrentConfig()
+	utils.Check(config.SetSetting("", "filters."+name, expansion))
+	utils.Check(config.Save())
+}
+
+func deleteQuery(command *Command, args *Args) {
+	if args.ParamsSize() != 2 {
+		utils.Check(command.UsageError(""))
+	}
+	name := args.GetParam(1)
+	local := args.Flag.Bool("--local")
+
+	config := github.CurrentConfig()
+	if local {
+		if expansion, _ := git.Config("hub.filters." + name); expansion == "" {
+			utils.Check(fmt.Errorf("no such query for this repository: %s", name))
+		}
+	} else if _, ok := config.Settings["filters."+name]; !ok {
+		utils.Check(fmt.Errorf("no such query: %s", name))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would delete query %s\n", name)
+		return
+	}
+
+	if local {
+		utils.Check(git.UnsetConfig("hub.filters." + name))
+		return
+	}
+	utils.Check(config.UnsetSetting("", "filters."+name))
+	utils.Check(config.Save())
+}
+
+func listQueries(args *Args) {
+	queries := map[string]string{}
+	if !args.Flag.Bool("--local") {
+		for key, expansion := range github.CurrentConfig().Settings {
+			if strings.HasPrefix(key, "filters.") {
+				queries[strings.TrimPrefix(key, "filters.")] = expansion
+			}
+		}
+	}
+	if lines, err := git.ConfigAll(`^hub\.filters\..*`); err == nil {
+		for _, line := range lines {
+			if parts := strings.SplitN(line, " ", 2); len(parts) == 2 {
+				queries[strings.TrimPrefix(parts[0], "hub.filters.")] = parts[1]
+			}
+		}
+	}
+
+	names := []string{}
+	for name := range queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args.NoForward()
+	for _, name := range names {
+		ui.Printf("%s\t%s\n", name, queries[name])
+	}
+}
@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestSplitQueryCmd(t *testing.T) {
+	words, err := splitQueryCmd("hub pr list --review-requested @me")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"pr", "list", "--review-requested", "@me"}, words)
+
+	words, err = splitQueryCmd("issue -l 'help wanted'")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"issue", "-l", "help wanted"}, words)
+
+	_, err = splitQueryCmd("delete github/hub")
+	assert.Equal(t, "query has to be an 'issue' or 'pr' command: delete github/hub", err.Error())
+
+	_, err = splitQueryCmd("hub")
+	assert.NotEqual(t, nil, err)
+}
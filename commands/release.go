@@ -1,13 +1,18 @@
 package commands
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/github/hub/git"
 	"github.com/github/hub/github"
 	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
@@ -17,18 +22,21 @@ var (
 	cmdRelease = &Command{
 		Run: listReleases,
 		Usage: `
-release [--include-drafts] [--exclude-prereleases] [-L <LIMIT>] [-f <FORMAT>]
-release show [-f <FORMAT>] <TAG>
-release create [-dpoc] [-a <FILE>] [-m <MESSAGE>|-F <FILE>] [-t <TARGET>] <TAG>
+release [--include-drafts] [--exclude-prereleases] [-L <LIMIT>] [-f <FORMAT>|--output <FORMAT>]
+release show [-uc] [-f <FORMAT>] [--raw] (<TAG>|--latest)
+release create [-dpoc] [-a <FILE>] [-m <MESSAGE>|-F <FILE>] [-t <TARGET>] [--generate-notes] [--latest] [--discussion-category <CATEGORY>] [--sign] <TAG>
 release edit [<options>] <TAG>
-release download <TAG>
+release download [-i <PATTERN>] [--dir <DIR>] [-j <JOBS>] <TAG>
 release delete <TAG>
+release verify <TAG>
+release notes [--from <REF>] [--to <REF>] [--title <TITLE>]
 `,
 		Long: `Manage GitHub Releases for the current repository.
 
 ## Commands:
 
-With no arguments, shows a list of existing releases.
+With no arguments, shows a list of existing releases. In a terminal, the list
+is a table of tag names, titles, states, and publication dates.
 
 With '--include-drafts', include draft releases in the listing.
 With '--exclude-prereleases', exclude non-stable releases from the listing.
@@ -38,25 +46,45 @@ With '--exclude-prereleases', exclude non-stable releases from the listing.
 
 		With '--show-downloads', include the "Downloads" section.
 
+		With '--latest' instead of <TAG>, show the release marked as latest.
+
+		In a terminal, the release notes are formatted for reading. With
+		'--raw', print their markdown as is.
+
 	* _create_:
 		Create a GitHub release for the specified <TAG> name. If git tag <TAG>
 		does not exist, it will be created at <TARGET> (default: current branch).
 
 	* _edit_:
 		Edit the GitHub release for the specified <TAG> name. Accepts the same
-		options as _create_ command. Publish a draft with '--draft=false'.
+		options as _create_ command. Publish a draft with '--draft=false'. Promote
+		a pre-release to the latest release with '--prerelease=false --latest'.
 
 		Without '--message' or '--file', a text editor will open pre-populated with
 		the current release title and body. To re-use existing title and body
 		unchanged, pass '-m ""'.
 
 	* _download_:
-		Download the assets attached to release for the specified <TAG>.
+		Download the assets attached to release for the specified <TAG>. Assets
+		that were already downloaded, judging by their checksum or size, are
+		skipped.
 
 	* _delete_:
 		Delete the release and associated assets for the specified <TAG>. Note that
 		this does **not** remove the git tag <TAG>.
 
+	* _verify_:
+		Show whether GitHub verified the signature of the git tag <TAG>. For a
+		lightweight tag, the signature of the tagged commit is checked instead.
+		Exits with a non-zero status unless the signature is verified.
+
+	* _notes_:
+		Print release notes in Markdown that list the pull requests merged between
+		the git refs <FROM> and <TO>, grouped by their labels: "Breaking changes"
+		("breaking", "breaking-change"), "Features" ("feature", "enhancement"),
+		"Fixes" ("bug", "bugfix", "fix"), and "Other changes". Pull requests are
+		found through the commits in the local repository, so fetch tags first.
+
 ## Options:
 	-L, --limit
 		Display only the first <LIMIT> releases.
@@ -67,6 +95,25 @@ With '--exclude-prereleases', exclude non-stable releases from the listing.
 	-p, --prerelease
 		Create a pre-release.
 
+	--generate-notes
+		Have GitHub generate the release title and description from the pull
+		requests merged since the previous release. A '--message' or '--file' is
+		prepended to the generated description.
+
+	--latest
+		Mark the release as the latest release of the repository (default: the
+		most recent stable release). Pass '--latest=false' to leave the current
+		latest release in place.
+
+	--discussion-category <CATEGORY>
+		Start a discussion about the release in the discussion category named
+		<CATEGORY>.
+
+	-s, --sign
+		Create <TAG> as a signed, annotated git tag locally and push it before
+		creating the release. The tag is signed with GPG or SSH, according to the
+		"gpg.format" and "user.signingKey" git configuration.
+
 	-a, --attach <FILE>
 		Attach a file as an asset for this release.
 
@@ -94,13 +141,28 @@ With '--exclude-prereleases', exclude non-stable releases from the listing.
 	-o, --browse
 		Open the new release in a web browser.
 
+	-u, --url
+		With 'show', print the URL of the release instead of its contents.
+
 	-c, --copy
-		Put the URL of the new release to clipboard instead of printing it.
+		Put the URL of the new release to clipboard instead of printing it. With
+		'show', copy the URL of the release instead of printing its contents.
 
 	-t, --commitish <TARGET>
 		A commit SHA or branch name to attach the release to, only used if <TAG>
 		does not already exist (default: main branch).
 
+	--from <REF>
+		With 'notes', the git ref of the previous release (default: the most
+		recent tag before <TO>).
+
+	--to <REF>
+		With 'notes', the git ref of the new release (default: HEAD).
+
+	--title <TITLE>
+		With 'notes', print <TITLE> as the first line, which makes the output
+		suitable for 'release create -F -'.
+
 	-f, --format <FORMAT>
 		Pretty print releases using <FORMAT> (default: "%T%n"). See the "PRETTY
 		FORMATS" section of git-log(1) for some additional details on how
@@ -146,16 +208,39 @@ With '--exclude-prereleases', exclude non-stable releases from the listing.
 
 		%%: a literal %
 
+	-i, --include <PATTERN>
+		With 'download', only download the assets whose name matches the shell
+		glob <PATTERN>, e.g. '*.tar.gz'. Can be passed multiple times.
+
+	-D, --dir <DIR>
+		With 'download', save the assets to <DIR> instead of the current
+		directory. <DIR> is created if it doesn't exist.
+
+	-j, --jobs <JOBS>
+		With 'download', download up to <JOBS> assets at a time (default: 1).
+
+	--output <FORMAT>
+		Print the list of releases in a structured format instead: "json", "yaml",
+		"csv", or "tsv". Releases have the fields "tag_name", "name", "draft",
+		"prerelease", "url", "created_at", and "published_at".
+
 	--color[=<WHEN>]
 		Enable colored output even if stdout is not a terminal. <WHEN> can be one
 		of "always" (default for '--color'), "never", or "auto" (default).
 
+	-R, --repo <REPO>
+		Manage the releases of <REPO>, given in "OWNER/NAME" format, instead of
+		the repository of the current directory. Accepted by all release commands.
+
 	<TAG>
 		The git tag name for this release.
 
+## Examples:
+		$ hub release notes --from v1.2.0 --title v1.3.0 | hub release create -F - v1.3.0
+
 ## See also:
 
-hub(1), git-tag(1)
+hub(1), hub-tag(1), git-tag(1)
 `,
 		KnownFlags: `
 		-d, --include-drafts
@@ -163,6 +248,8 @@ hub(1), git-tag(1)
 		-L, --limit N
 		-f, --format FMT
 		--color
+		--output FORMAT
+		-R, --repo REPO
 `,
 	}
 
@@ -170,9 +257,14 @@ hub(1), git-tag(1)
 		Key: "show",
 		Run: showRelease,
 		KnownFlags: `
+		--latest
 		-d, --show-downloads
+		--raw
 		-f, --format FMT
 		--color
+		-u, --url
+		-c, --copy
+		-R, --repo REPO
 `,
 	}
 
@@ -189,6 +281,11 @@ hub(1), git-tag(1)
 		-m, --message MSG
 		-F, --file FILE
 		-t, --commitish C
+		--generate-notes
+		--latest
+		--discussion-category CATEGORY
+		-s, --sign
+		-R, --repo REPO
 `,
 	}
 
@@ -203,17 +300,47 @@ hub(1), git-tag(1)
 		-m, --message MSG
 		-F, --file FILE
 		-t, --commitish C
+		--latest
+		-R, --repo REPO
 `,
 	}
 
 	cmdDownloadRelease = &Command{
 		Key: "download",
 		Run: downloadRelease,
+		KnownFlags: `
+		-i, --include PATTERN
+		-D, --dir DIR
+		-j, --jobs N
+		-R, --repo REPO
+`,
 	}
 
 	cmdDeleteRelease = &Command{
 		Key: "delete",
 		Run: deleteRelease,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+
+	cmdVerifyRelease = &Command{
+		Key: "verify",
+		Run: verifyRelease,
+		KnownFlags: `
+		-R, --repo REPO
+`,
+	}
+
+	cmdReleaseNotes = &Command{
+		Key: "notes",
+		Run: releaseNotes,
+		KnownFlags: `
+		--from REF
+		--to REF
+		--title TITLE
+		-R, --repo REPO
+`,
 	}
 )
 
@@ -223,14 +350,13 @@ func init() {
 	cmdRelease.Use(cmdEditRelease)
 	cmdRelease.Use(cmdDownloadRelease)
 	cmdRelease.Use(cmdDeleteRelease)
+	cmdRelease.Use(cmdVerifyRelease)
+	cmdRelease.Use(cmdReleaseNotes)
 	CmdRunner.Use(cmdRelease)
 }
 
 func listReleases(cmd *Command, args *Args) {
-	localRepo, err := github.LocalRepo()
-	utils.Check(err)
-
-	project, err := localRepo.MainProject()
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
 	gh := github.NewClient(project.Host)
@@ -248,7 +374,22 @@ func listReleases(cmd *Command, args *Args) {
 		})
 		utils.Check(err)
 
+		if args.Flag.HasReceived("--output") {
+			records := []ui.Record{}
+			for _, release := range releases {
+				records = append(records, releaseRecord(release))
+			}
+			printRecords(args.Flag.Value("--output"), records, releaseRecord(github.Release{}))
+			args.NoForward()
+			return
+		}
+
 		colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+		if !args.Flag.HasReceived("--format") && ui.IsTerminal(os.Stdout) {
+			utils.Check(releaseTable(releases, colorize).Render(ui.Stdout))
+			args.NoForward()
+			return
+		}
 		for _, release := range releases {
 			flagReleaseFormat := "%T%n"
 			if args.Flag.HasReceived("--format") {
@@ -261,6 +402,46 @@ func listReleases(cmd *Command, args *Args) {
 	args.NoForward()
 }
 
+func releaseTable(releases []github.Release, colorize bool) *ui.Table {
+	table := ui.NewTable(ui.TableColumn{}, ui.TableColumn{Truncate: true}, ui.TableColumn{}, ui.TableColumn{})
+	table.MaxWidth = ui.TerminalWidth(os.Stdout)
+	table.Colorize = colorize
+	for _, release := range releases {
+		state := ui.TableCell{}
+		if release.Draft {
+			state = ui.TableCell{Text: "draft", Color: "33"}
+		} else if release.Prerelease {
+			state = ui.TableCell{Text: "pre-release", Color: "31"}
+		}
+		date := release.PublishedAt
+		if date.IsZero() {
+			date = release.CreatedAt
+		}
+		when := ""
+		if !date.IsZero() {
+			when = utils.TimeAgo(date)
+		}
+		table.AddRow(
+			ui.TableCell{Text: release.TagName},
+			ui.TableCell{Text: release.Name},
+			state,
+			ui.TableCell{Text: when, Color: "90"},
+		)
+	}
+	return table
+}
+
+func releaseRecord(release github.Release) ui.Record {
+	return ui.Record{}.
+		With("tag_name", release.TagName).
+		With("name", release.Name).
+		With("draft", release.Draft).
+		With("prerelease", release.Prerelease).
+		With("url", release.HtmlUrl).
+		With("created_at", release.CreatedAt).
+		With("published_at", release.PublishedAt)
+}
+
 func formatRelease(release github.Release, format string, colorize bool) string {
 	state := ""
 	stateColorSwitch := ""
@@ -321,24 +502,40 @@ func showRelease(cmd *Command, args *Args) {
 	if args.ParamsSize() > 0 {
 		tagName = args.GetParam(0)
 	}
-	if tagName == "" {
+	latest := args.Flag.Bool("--latest")
+	if (tagName == "") == !latest {
 		utils.Check(cmd.UsageError(""))
 	}
 
-	localRepo, err := github.LocalRepo()
-	utils.Check(err)
-
-	project, err := localRepo.MainProject()
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
 	gh := github.NewClient(project.Host)
 
 	args.NoForward()
 
+	if args.Flag.Bool("--url") || args.Flag.Bool("--copy") {
+		path := "releases/tag/" + tagName
+		if latest {
+			path = "releases/latest"
+		}
+		printBrowseOrCopy(args, project.WebURL("", "", path), false, args.Flag.Bool("--copy"))
+		return
+	}
+
 	if args.Noop {
-		ui.Printf("Would display information for `%s' release\n", tagName)
+		if latest {
+			ui.Printf("Would display information for the latest release\n")
+		} else {
+			ui.Printf("Would display information for `%s' release\n", tagName)
+		}
 	} else {
-		release, err := gh.FetchRelease(project, tagName)
+		var release *github.Release
+		if latest {
+			release, err = gh.LatestRelease(project)
+		} else {
+			release, err = gh.FetchRelease(project, tagName)
+		}
 		utils.Check(err)
 
 		body := strings.TrimSpace(release.Body)
@@ -351,7 +548,7 @@ func showRelease(cmd *Command, args *Args) {
 
 		ui.Println(release.Name)
 		if body != "" {
-			ui.Printf("\n%s\n", body)
+			ui.Printf("\n%s\n", formatBody(args, body, colorize))
 		}
 		if args.Flag.Bool("--show-downloads") {
 			ui.Printf("\n## Downloads\n\n")
@@ -375,10 +572,22 @@ func downloadRelease(cmd *Command, args *Args) {
 		utils.Check(cmd.UsageError(""))
 	}
 
-	localRepo, err := github.LocalRepo()
-	utils.Check(err)
+	jobs := 1
+	if args.Flag.HasReceived("--jobs") {
+		jobs = args.Flag.Int("--jobs")
+		if jobs < 1 {
+			utils.Check(fmt.Errorf("invalid number of jobs: %s", args.Flag.Value("--jobs")))
+		}
+	}
 
-	project, err := localRepo.MainProject()
+	patterns := args.Flag.AllValues("--include")
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			utils.Check(fmt.Errorf("invalid pattern '%s': %v", pattern, err))
+		}
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
 	gh := github.NewClient(project.Host)
@@ -386,33 +595,137 @@ func downloadRelease(cmd *Command, args *Args) {
 	release, err := gh.FetchRelease(project, tagName)
 	utils.Check(err)
 
+	assets := []github.ReleaseAsset{}
 	for _, asset := range release.Assets {
-		ui.Printf("Downloading %s ...\n", asset.Name)
-		err := downloadReleaseAsset(asset, gh)
-		utils.Check(err)
+		if matchesAnyPattern(asset.Name, patterns) {
+			assets = append(assets, asset)
+		}
+	}
+	if len(patterns) > 0 && len(assets) == 0 {
+		utils.Check(fmt.Errorf("no assets of release %s match %s", tagName, strings.Join(patterns, ", ")))
+	}
+
+	dir := args.Flag.Value("--dir")
+	if dir != "" && len(assets) > 0 {
+		utils.Check(os.MkdirAll(dir, 0755))
+	}
+
+	var totalSize int64
+	for _, asset := range assets {
+		totalSize += asset.Size
+	}
+	progress := ui.NewProgressBar("Downloading", totalSize)
+
+	// A failed download doesn't stop the others, so errors are collected
+	// instead of returned.
+	downloadErrs := make([]error, len(assets))
+	github.ParallelFetch(len(assets), jobs, func(i int) error {
+		asset := assets[i]
+		filename := filepath.Join(dir, asset.Name)
+		upToDate, err := isAssetDownloaded(asset, filename)
+		if err == nil && upToDate {
+			progress.Add(asset.Size)
+			progress.Println(fmt.Sprintf("Skipping %s (already downloaded)", asset.Name))
+			return nil
+		}
+
+		progress.Println(fmt.Sprintf("Downloading %s ...", asset.Name))
+		if err == nil {
+			err = downloadReleaseAsset(asset, filename, gh, progress)
+		}
+		if err != nil {
+			downloadErrs[i] = fmt.Errorf("%s: %v", asset.Name, err)
+		}
+		return nil
+	})
+	progress.Done()
+
+	failed := false
+	for _, err := range downloadErrs {
+		if err != nil {
+			ui.Errorf("Error downloading %s\n", err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
 	}
 
 	args.NoForward()
 }
 
-func downloadReleaseAsset(asset github.ReleaseAsset, gh *github.Client) (err error) {
+func matchesAnyPattern(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isAssetDownloaded reports whether filename already holds the contents of
+// asset, comparing the SHA-256 digest if GitHub provides one and the size
+// otherwise.
+func isAssetDownloaded(asset github.ReleaseAsset, filename string) (bool, error) {
+	info, err := os.Stat(filename)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	} else if !info.Mode().IsRegular() {
+		return false, fmt.Errorf("%s is not a regular file", filename)
+	}
+
+	if info.Size() != asset.Size {
+		return false, nil
+	}
+	if !strings.HasPrefix(asset.Digest, "sha256:") {
+		return true, nil
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false, err
+	}
+	return hex.EncodeToString(h.Sum(nil)) == strings.TrimPrefix(asset.Digest, "sha256:"), nil
+}
+
+// downloadReleaseAsset writes to a temporary file next to filename first so
+// that an interrupted download doesn't leave a truncated asset behind.
+func downloadReleaseAsset(asset github.ReleaseAsset, filename string, gh *github.Client, progress io.Writer) (err error) {
 	assetReader, err := gh.DownloadReleaseAsset(asset.ApiUrl)
 	if err != nil {
 		return
 	}
 	defer assetReader.Close()
 
-	assetFile, err := os.OpenFile(asset.Name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	assetFile, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename)+".")
 	if err != nil {
 		return
 	}
-	defer assetFile.Close()
+	defer os.Remove(assetFile.Name())
 
-	_, err = io.Copy(assetFile, assetReader)
+	_, err = io.Copy(assetFile, io.TeeReader(assetReader, progress))
+	if closeErr := assetFile.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return
 	}
-	return
+
+	if err = os.Chmod(assetFile.Name(), 0644); err != nil {
+		return
+	}
+	return os.Rename(assetFile.Name(), filename)
 }
 
 func createRelease(cmd *Command, args *Args) {
@@ -425,10 +738,7 @@ func createRelease(cmd *Command, args *Args) {
 		return
 	}
 
-	localRepo, err := github.LocalRepo()
-	utils.Check(err)
-
-	project, err := localRepo.MainProject()
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
 	gh := github.NewClient(project.Host)
@@ -443,6 +753,7 @@ func createRelease(cmd *Command, args *Args) {
 Write a message for this release. The first block of
 text is the title and the rest is the description.`, tagName, project))
 
+	generateNotes := args.Flag.Bool("--generate-notes")
 	flagReleaseMessage := args.Flag.AllValues("--message")
 	if len(flagReleaseMessage) > 0 {
 		messageBuilder.Message = strings.Join(flagReleaseMessage, "\n\n")
@@ -452,30 +763,47 @@ text is the title and the rest is the description.`, tagName, project))
 		utils.Check(err)
 		messageBuilder.Edit = args.Flag.Bool("--edit")
 	} else {
-		messageBuilder.Edit = true
+		messageBuilder.Edit = !generateNotes || args.Flag.Bool("--edit")
 	}
 
 	title, body, err := messageBuilder.Extract()
 	utils.Check(err)
 
-	if title == "" {
+	if title == "" && !generateNotes {
 		utils.Check(fmt.Errorf("Aborting release due to empty release title"))
 	}
 
 	params := &github.Release{
-		TagName:         tagName,
-		TargetCommitish: args.Flag.Value("--commitish"),
-		Name:            title,
-		Body:            body,
-		Draft:           args.Flag.Bool("--draft"),
-		Prerelease:      args.Flag.Bool("--prerelease"),
+		TagName:                tagName,
+		TargetCommitish:        args.Flag.Value("--commitish"),
+		Name:                   title,
+		Body:                   body,
+		Draft:                  args.Flag.Bool("--draft"),
+		Prerelease:             args.Flag.Bool("--prerelease"),
+		GenerateNotes:          generateNotes,
+		DiscussionCategoryName: args.Flag.Value("--discussion-category"),
+	}
+	if args.Flag.HasReceived("--latest") {
+		params.MakeLatest = strconv.FormatBool(args.Flag.Bool("--latest"))
+	}
+
+	if args.Flag.Bool("--sign") {
+		tagMessage := title
+		if tagMessage == "" {
+			tagMessage = tagName
+		}
+		utils.Check(pushSignedTag(project, tagName, tagMessage, params.TargetCommitish, args.Noop))
 	}
 
 	var release *github.Release
 
 	args.NoForward()
 	if args.Noop {
-		ui.Printf("Would create release `%s' for %s with tag name `%s'\n", title, project, tagName)
+		if title == "" {
+			ui.Printf("Would create release for %s with tag name `%s'\n", project, tagName)
+		} else {
+			ui.Printf("Would create release `%s' for %s with tag name `%s'\n", title, project, tagName)
+		}
 	} else {
 		release, err = gh.CreateRelease(project, params)
 		utils.Check(err)
@@ -491,20 +819,85 @@ text is the title and the rest is the description.`, tagName, project))
 	uploadAssets(gh, release, flagReleaseAssets, args)
 }
 
-func editRelease(cmd *Command, args *Args) {
+// pushSignedTag creates tagName as a signed tag and pushes it to the git
+// remote for project, so that GitHub doesn't create an unsigned tag for the
+// release.
+func pushSignedTag(project *github.Project, tagName, message, target string, noop bool) error {
+	localRepo, err := github.LocalRepo()
+	if err != nil {
+		return err
+	}
+	remote, err := localRepo.RemoteForProject(project)
+	if err != nil {
+		return fmt.Errorf("Aborted: could not find a git remote for %s to push the signed tag to", project)
+	}
+	if git.Quiet("rev-parse", "--verify", "--quiet", "refs/tags/"+tagName) {
+		return fmt.Errorf("Aborted: git tag `%s' already exists", tagName)
+	}
+
+	tagArgs := []string{"tag", "--sign", "-m", message, tagName}
+	if target != "" {
+		tagArgs = append(tagArgs, target)
+	}
+	pushArgs := []string{"push", remote.Name, "refs/tags/" + tagName}
+
+	if noop {
+		ui.Printf("Would create signed tag `%s' and push it to %s\n", tagName, remote.Name)
+		return nil
+	}
+	if err := git.Spawn(tagArgs...); err != nil {
+		return err
+	}
+	return git.Spawn(pushArgs...)
+}
+
+func verifyRelease(cmd *Command, args *Args) {
 	tagName := ""
 	if args.ParamsSize() > 0 {
 		tagName = args.GetParam(0)
 	}
 	if tagName == "" {
 		utils.Check(cmd.UsageError(""))
-		return
 	}
 
-	localRepo, err := github.LocalRepo()
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	tag, err := gh.TagVerification(project, tagName)
 	utils.Check(err)
+	args.NoForward()
+
+	kind := "annotated"
+	if !tag.Annotated {
+		sha := tag.Sha
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		kind = fmt.Sprintf("lightweight, commit %s", sha)
+	}
+	status := "verified"
+	if !tag.Verification.Verified {
+		status = fmt.Sprintf("unverified (%s)", tag.Verification.Reason)
+	}
+	ui.Printf("%s (%s): %s\n", tagName, kind, status)
 
-	project, err := localRepo.MainProject()
+	if !tag.Verification.Verified {
+		os.Exit(1)
+	}
+}
+
+func editRelease(cmd *Command, args *Args) {
+	tagName := ""
+	if args.ParamsSize() > 0 {
+		tagName = args.GetParam(0)
+	}
+	if tagName == "" {
+		utils.Check(cmd.UsageError(""))
+		return
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
 	gh := github.NewClient(project.Host)
@@ -522,6 +915,9 @@ func editRelease(cmd *Command, args *Args) {
 	if args.Flag.HasReceived("--prerelease") {
 		params["prerelease"] = args.Flag.Bool("--prerelease")
 	}
+	if args.Flag.HasReceived("--latest") {
+		params["make_latest"] = strconv.FormatBool(args.Flag.Bool("--latest"))
+	}
 
 	messageBuilder := &github.MessageBuilder{
 		Filename: "RELEASE_EDITMSG",
@@ -586,10 +982,7 @@ func deleteRelease(cmd *Command, args *Args) {
 		return
 	}
 
-	localRepo, err := github.LocalRepo()
-	utils.Check(err)
-
-	project, err := localRepo.MainProject()
+	project, err := resolveProject(args.Flag.Value("--repo"))
 	utils.Check(err)
 
 	gh := github.NewClient(project.Host)
@@ -632,7 +1025,13 @@ func uploadAssets(gh *github.Client, release *github.Release, assets []string, a
 				}
 			}
 			ui.Errorf("Attaching release asset `%s'...\n", asset)
-			_, err := gh.UploadReleaseAsset(release, asset, label)
+			var size int64
+			if info, err := os.Stat(asset); err == nil {
+				size = info.Size()
+			}
+			progress := ui.NewProgressBar(filepath.Base(asset), size)
+			_, err := gh.UploadReleaseAsset(release, asset, label, progress)
+			progress.Done()
 			utils.Check(err)
 		}
 	}
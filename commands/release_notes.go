@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+// releaseNoteSections are the sections of release notes in the order they are
+// printed, each with the labels that put a pull request in it. A pull request
+// goes in the first section that any of its labels matches.
+var releaseNoteSections = []struct {
+	title  string
+	labels []string
+}{
+	{"Breaking changes", []string{"breaking", "breaking-change", "breaking change"}},
+	{"Features", []string{"feature", "enhancement"}},
+	{"Fixes", []string{"bug", "bugfix", "fix"}},
+}
+
+func releaseNotes(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	to := "HEAD"
+	if args.Flag.HasReceived("--to") {
+		to = args.Flag.Value("--to")
+	}
+	from := args.Flag.Value("--from")
+	if from == "" {
+		// Skip <to> itself in case it's the tag of the release being written.
+		if from, err = git.LatestTag(to + "^"); err != nil {
+			utils.Check(fmt.Errorf("no tags found before %s; pass --from", to))
+		}
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would generate release notes for %s from %s to %s\n", project, from, to)
+		return
+	}
+
+	shas, err := git.RefList(from, to)
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	commitPulls := make([][]github.PullRequest, len(shas))
+	progress := ui.NewProgressCounter("Fetching pull requests", len(shas))
+	err = github.ParallelFetch(len(shas), github.ParallelFetchLimit, func(i int) error {
+		defer progress.Add(1)
+		var err error
+		commitPulls[i], err = gh.CommitPullRequests(project, shas[i])
+		return err
+	})
+	progress.Done()
+	utils.Check(err)
+
+	pulls := []github.PullRequest{}
+	seen := map[int]bool{}
+	for _, commitPull := range commitPulls {
+		for _, pr := range commitPull {
+			if !pr.MergedAt.IsZero() && !seen[pr.Number] {
+				seen[pr.Number] = true
+				pulls = append(pulls, pr)
+			}
+		}
+	}
+
+	ui.Print(formatReleaseNotes(args.Flag.Value("--title"), pulls))
+}
+
+// formatReleaseNotes lists pull requests in markdown, grouped by their labels
+// into releaseNoteSections and ordered by when they were merged.
+func formatReleaseNotes(title string, pulls []github.PullRequest) string {
+	sort.SliceStable(pulls, func(i, j int) bool {
+		return pulls[i].MergedAt.Before(pulls[j].MergedAt)
+	})
+
+	sections := make([][]string, len(releaseNoteSections)+1)
+	for _, pr := range pulls {
+		section := releaseNoteSection(pr.Labels)
+		entry := fmt.Sprintf("* %s", pr.Title)
+		if pr.User != nil {
+			entry += fmt.Sprintf(" by @%s", pr.User.Login)
+		}
+		entry += fmt.Sprintf(" in #%d", pr.Number)
+		sections[section] = append(sections[section], entry)
+	}
+
+	blocks := []string{}
+	if title != "" {
+		blocks = append(blocks, title)
+	}
+	for i, entries := range sections {
+		if len(entries) == 0 {
+			continue
+		}
+		heading := "Other changes"
+		if i < len(releaseNoteSections) {
+			heading = releaseNoteSections[i].title
+		}
+		blocks = append(blocks, fmt.Sprintf("## %s\n\n%s", heading, strings.Join(entries, "\n")))
+	}
+	if len(pulls) == 0 {
+		blocks = append(blocks, "No pull requests were merged in this release.")
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+func releaseNoteSection(labels []github.IssueLabel) int {
+	for i, section := range releaseNoteSections {
+		for _, label := range labels {
+			for _, name := range section.labels {
+				if strings.EqualFold(label.Name, name) {
+					return i
+				}
+			}
+		}
+	}
+	return len(releaseNoteSections)
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+	"github.com/github/hub/github"
+)
+
+func TestFormatReleaseNotes(t *testing.T) {
+	merged := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	pulls := []github.PullRequest{
+		{Number: 3, Title: "Add feature", User: &github.User{Login: "a"}, MergedAt: merged.Add(2 * time.Hour),
+			Labels: []github.IssueLabel{{Name: "Enhancement"}}},
+		{Number: 1, Title: "Remove flag", User: &github.User{Login: "b"}, MergedAt: merged.Add(3 * time.Hour),
+			Labels: []github.IssueLabel{{Name: "bug"}, {Name: "breaking-change"}}},
+		{Number: 2, Title: "Add other feature", User: &github.User{Login: "c"}, MergedAt: merged.Add(time.Hour),
+			Labels: []github.IssueLabel{{Name: "feature"}}},
+		{Number: 4, Title: "Chore", MergedAt: merged},
+	}
+
+	assert.Equal(t, `## Breaking changes
+
+* Remove flag by @b in #1
+
+## Features
+
+* Add other feature by @c in #2
+* Add feature by @a in #3
+
+## Other changes
+
+* Chore in #4
+`, formatReleaseNotes("", pulls))
+}
+
+func TestFormatReleaseNotes_Empty(t *testing.T) {
+	assert.Equal(t, "v1.0.0\n\nNo pull requests were merged in this release.\n", formatReleaseNotes("v1.0.0", nil))
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/github/hub/git"
 	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
 )
 
@@ -14,7 +15,7 @@ var cmdRemote = &Command{
 	Run:          remote,
 	GitExtension: true,
 	Usage: `
-remote add [-p] [<OPTIONS>] <USER>[/<REPOSITORY>]
+remote add [-p] [--with-pr-refs] [<OPTIONS>] <USER>[/<REPOSITORY>]
 remote set-url [-p] [<OPTIONS>] <NAME> <USER>[/<REPOSITORY>]
 `,
 	Long: `Add a git remote for a GitHub repository.
@@ -25,10 +26,17 @@ remote set-url [-p] [<OPTIONS>] <NAME> <USER>[/<REPOSITORY>]
 		The writeable 'ssh:' protocol is automatically used for own repos, GitHub
 		Enterprise remotes, and private or pushable repositories.
 
+	--with-pr-refs
+		Also fetch the head of every pull request of the repository into
+		"refs/remotes/<NAME>/pr/<NUMBER>", so that e.g. 'git checkout pr/123' works.
+
 	<USER>[/<REPOSITORY>]
 		If <USER> is "origin", that value will be substituted for your GitHub
 		username. <REPOSITORY> defaults to the name of the current working directory.
 
+		When adding a remote for your own fork of the current repository that
+		doesn't exist yet, hub offers to create the fork.
+
 ## Examples:
 		$ hub remote add jingweno
 		> git remote add jingweno git://github.com/jingweno/REPO.git
@@ -36,6 +44,10 @@ remote set-url [-p] [<OPTIONS>] <NAME> <USER>[/<REPOSITORY>]
 		$ hub remote add origin
 		> git remote add origin git@github.com:USER/REPO.git
 
+		$ hub remote add --with-pr-refs upstream github/REPO
+		> git remote add upstream git://github.com/github/REPO.git
+		> git config --add remote.upstream.fetch "+refs/pull/*/head:refs/remotes/upstream/pr/*"
+
 ## See also:
 
 hub-fork(1), hub(1), git-remote(1)
@@ -55,6 +67,14 @@ func remote(command *Command, args *Args) {
 }
 
 func transformRemoteArgs(args *Args) {
+	withPrRefs := false
+	if args.FirstParam() == "add" {
+		if i := args.IndexOfParam("--with-pr-refs"); i != -1 {
+			args.RemoveParam(i)
+			withPrRefs = true
+		}
+	}
+
 	ownerWithName := args.LastParam()
 
 	re := regexp.MustCompile(fmt.Sprintf(`^%s(/%s)?$`, OwnerRe, NameRe))
@@ -113,6 +133,10 @@ func transformRemoteArgs(args *Args) {
 			args.RemoveParam(pi)
 		}
 	}
+	remoteName := ""
+	if len(p.PositionalIndices) > 1 {
+		remoteName = args.Params[p.PositionalIndices[1]]
+	}
 	if len(params) == 2 && owner == "origin" {
 		owner = hostConfig.User
 	}
@@ -123,6 +147,10 @@ func transformRemoteArgs(args *Args) {
 
 	project := github.NewProject(owner, name, host)
 
+	if args.FirstParam() == "add" && owner == hostConfig.User && mainProject != nil && !strings.EqualFold(mainProject.Owner, owner) {
+		project = ensureFork(args, mainProject, project)
+	}
+
 	isPrivate := parseRemotePrivateFlag(args) || owner == hostConfig.User || project.Host != github.GitHubHost
 	if !isPrivate {
 		gh := github.NewClient(project.Host)
@@ -138,6 +166,38 @@ func transformRemoteArgs(args *Args) {
 
 	url := project.GitURL("", "", isPrivate)
 	args.AppendParams(url)
+
+	if withPrRefs && remoteName != "" {
+		refspec := fmt.Sprintf("+refs/pull/*/head:refs/remotes/%s/pr/*", remoteName)
+		args.After("git", "config", "--add", fmt.Sprintf("remote.%s.fetch", remoteName), refspec)
+		if args.IndexOfParam("-f") != -1 || args.IndexOfParam("--fetch") != -1 {
+			args.After("git", "fetch", remoteName)
+		}
+	}
+}
+
+// ensureFork checks that the fork of parent on GitHub exists and offers to
+// create it otherwise. It returns the project of the fork, whose name might
+// differ from the requested one if the fork was created earlier.
+func ensureFork(args *Args, parent, fork *github.Project) *github.Project {
+	gh := github.NewClient(fork.Host)
+	repo, err := gh.Repository(fork)
+	if err == nil {
+		return github.NewProject(repo.Owner.Login, repo.Name, fork.Host)
+	} else if !strings.Contains(err.Error(), "HTTP 404") {
+		utils.Check(err)
+	}
+
+	if !confirm(fmt.Sprintf("Repository %s doesn't exist. Fork %s", fork, parent)) {
+		utils.Check(fmt.Errorf("Aborted: repository %s doesn't exist", fork))
+	}
+	if args.Noop {
+		ui.Printf("Would fork %s\n", parent)
+		return fork
+	}
+	repo, err = gh.ForkRepository(parent, map[string]interface{}{})
+	utils.Check(err)
+	return github.NewProject(repo.Owner.Login, repo.Name, fork.Host)
 }
 
 func parseRemotePrivateFlag(args *Args) bool {
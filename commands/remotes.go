@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var cmdRemotes = &Command{
+	Run:   remotes,
+	Usage: "remotes [--json]",
+	Long: `Show how hub maps the git remotes to GitHub repositories.
+
+Remotes are listed in the order that hub considers them in, as
+"<NAME>  <OWNER>/<REPO>  <URL>" lines. The remote marked with "*" points to
+the repository that commands such as hub-issue(1) and hub-pr(1) act on.
+Remotes that don't point to a GitHub repository are shown with "-".
+
+The order starts with the remote given with the '--remote <NAME>' global flag,
+followed by the remotes named in the comma-separated "hub.remotePriority" git
+config (default: "upstream,github,origin"), and then the rest of the remotes
+in alphabetical order.
+
+## Options:
+	--json
+		Output the remotes as JSON instead of text.
+
+## Examples:
+		$ hub remotes
+		* upstream  github/hub  git@github.com:github/hub.git
+		  origin    mislav/hub  git@github.com:mislav/hub.git
+
+		$ git config hub.remotePriority origin,upstream
+		$ hub --remote upstream issue
+
+## See also:
+
+hub-remote(1), hub(1), git-remote(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdRemotes)
+}
+
+// remoteMapping describes which GitHub repository a git remote points to.
+type remoteMapping struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	PushURL string `json:"push_url,omitempty"`
+	Host    string `json:"host,omitempty"`
+	Project string `json:"project,omitempty"`
+	Main    bool   `json:"main"`
+}
+
+func remotes(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	args.NoForward()
+
+	gitRemotes, err := github.Remotes()
+	utils.Check(err)
+
+	mappings := []remoteMapping{}
+	hasMain := false
+	for _, remote := range gitRemotes {
+		mapping := remoteMapping{Name: remote.Name}
+		if remote.URL != nil {
+			mapping.URL = remote.URL.String()
+		}
+		if remote.PushURL != nil && (remote.URL == nil || remote.PushURL.String() != mapping.URL) {
+			mapping.PushURL = remote.PushURL.String()
+		}
+		if project, err := remote.Project(); err == nil {
+			mapping.Host = project.Host
+			mapping.Project = project.String()
+			mapping.Main = !hasMain
+			hasMain = true
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	if args.Flag.Bool("--json") {
+		out, err := encodeJSON(mappings)
+		utils.Check(err)
+		ui.Println(string(out))
+		return
+	}
+
+	nameWidth, projectWidth := 0, 1
+	for _, mapping := range mappings {
+		if w := len(mapping.Name); w > nameWidth {
+			nameWidth = w
+		}
+		if w := len(mapping.Project); w > projectWidth {
+			projectWidth = w
+		}
+	}
+	for _, mapping := range mappings {
+		marker, project, url := " ", mapping.Project, mapping.URL
+		if mapping.Main {
+			marker = "*"
+		}
+		if project == "" {
+			project = "-"
+		}
+		if url == "" {
+			url = mapping.PushURL
+		}
+		ui.Printf("%s %-*s  %-*s  %s\n", marker, nameWidth, mapping.Name, projectWidth, project, url)
+	}
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var cmdRenameDefaultBranch = &Command{
+	Run:   renameDefaultBranch,
+	Usage: "rename-default-branch [-R <REPO>] <NEW-NAME>",
+	Long: `Rename the default branch of a repository on GitHub and in the local clone.
+
+GitHub retargets open pull requests and updates branch protection rules to use
+<NEW-NAME>. Then, in the local clone:
+
+- the local branch with the old name is renamed to <NEW-NAME>;
+- "refs/remotes/<REMOTE>/HEAD" is updated to point to the renamed branch;
+- local branches that track the old branch are set to track <NEW-NAME> instead.
+
+Other clones of the repository have to be updated separately, e.g. by running
+this command with the current name of the default branch as <NEW-NAME>.
+
+## Options:
+	-R, --repo <REPO>
+		Rename the default branch of <REPO>, given in "OWNER/NAME" format,
+		instead of the repository of the current directory. The local clone is
+		only updated if it has a git remote for <REPO>.
+
+## Examples:
+		$ hub rename-default-branch main
+
+## See also:
+
+hub-sync(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdRenameDefaultBranch)
+}
+
+func renameDefaultBranch(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	newName := args.GetParam(0)
+
+	project, err := resolveProject(args.Flag.Value("--repo"))
+	utils.Check(err)
+
+	var remote *github.Remote
+	if localRepo, err := github.LocalRepo(); err == nil {
+		remote, _ = localRepo.RemoteForProject(project)
+	}
+
+	gh := github.NewClient(project.Host)
+	repo, err := gh.Repository(project)
+	utils.Check(err)
+	oldName := repo.DefaultBranch
+
+	args.NoForward()
+	if oldName != newName {
+		if args.Noop {
+			ui.Printf("Would rename default branch of %s from %s to %s\n", project, oldName, newName)
+		} else {
+			utils.Check(gh.RenameBranch(project, oldName, newName))
+			ui.Infof("Renamed default branch of %s from %s to %s\n", project, oldName, newName)
+		}
+	} else if remote == nil {
+		utils.Check(fmt.Errorf("The default branch of %s is already named %s", project, newName))
+	}
+
+	if remote == nil {
+		return
+	}
+	if args.Noop {
+		ui.Printf("Would update the local clone to use %s/%s\n", remote.Name, newName)
+		return
+	}
+	updateLocalDefaultBranch(remote.Name, oldName, newName)
+}
+
+// updateLocalDefaultBranch switches a clone over to the renamed default branch
+// of the remote named remoteName.
+func updateLocalDefaultBranch(remoteName, oldName, newName string) {
+	utils.Check(git.Spawn("fetch", "--prune", "--quiet", remoteName))
+
+	oldRef := "refs/heads/" + oldName
+	newRef := "refs/heads/" + newName
+	if oldName != newName && git.Quiet("show-ref", "--verify", "--quiet", oldRef) {
+		if git.Quiet("show-ref", "--verify", "--quiet", newRef) {
+			ui.Errorf("warning: not renaming local branch '%s' since '%s' already exists\n", oldName, newName)
+		} else {
+			utils.Check(git.Spawn("branch", "-m", oldName, newName))
+			ui.Infof("Renamed local branch %s to %s\n", oldName, newName)
+		}
+	}
+
+	utils.Check(git.Spawn("remote", "set-head", remoteName, newName))
+
+	// retarget branches tracking the old branch, including the renamed one
+	if lines, err := git.ConfigAll("branch.*.merge"); err == nil {
+		configRe := regexp.MustCompile(`^branch\.(.+?)\.merge (.+)`)
+		for _, line := range lines {
+			m := configRe.FindStringSubmatch(line)
+			if m == nil || m[2] != oldRef {
+				continue
+			}
+			if branchRemote, _ := git.Config(fmt.Sprintf("branch.%s.remote", m[1])); branchRemote != remoteName {
+				continue
+			}
+			utils.Check(git.SetConfig(fmt.Sprintf("branch.%s.merge", m[1]), newRef))
+			ui.Printf("Branch %s now tracks %s/%s\n", m[1], remoteName, newName)
+		}
+	}
+}
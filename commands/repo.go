@@ -0,0 +1,552 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdRepo = &Command{
+		Run: printHelp,
+		Usage: `
+repo view [-f <FORMAT>] [<OWNER>/<REPO>]
+repo edit [-d <DESCRIPTION>] [-h <HOMEPAGE>] [-b <BRANCH>] [--merge-commit <BOOL>] [--squash-merge <BOOL>] [--rebase-merge <BOOL>] [--delete-branch-on-merge <BOOL>] [<OWNER>/<REPO>]
+repo topics [--add <TOPICS>] [--remove <TOPICS>] [--set <TOPICS>] [<OWNER>/<REPO>]
+repo archive [-y] [<OWNER>/<REPO>]
+repo unarchive [<OWNER>/<REPO>]
+repo transfer [-y] <NEW-OWNER> [<OWNER>/<REPO>]
+repo mirror [-p] [--issues] <SRC> <DEST>
+`,
+		Long: `View and manage the settings of a GitHub repository.
+
+## Commands:
+
+When <OWNER>/<REPO> is not given, the repository of the current git remote
+configuration is used.
+
+	* _view_:
+		Show information about the repository.
+
+	* _edit_:
+		Update repository settings. Only the settings passed as options are changed.
+
+	* _topics_:
+		List the topics of the repository. With '--add', '--remove', or '--set',
+		update the topics before listing them.
+
+	* _archive_:
+		Mark the repository as archived, making it read-only.
+
+	* _unarchive_:
+		Make an archived repository writeable again.
+
+	* _transfer_:
+		Transfer ownership of the repository to <NEW-OWNER>, which can be a user or
+		an organization.
+
+	* _mirror_:
+		Create the repository <DEST> as a copy of <SRC>, both given as
+		<OWNER>/<REPO>. All branches and tags are pushed to <DEST> with
+		'git push --mirror', and the description, homepage, topics, labels, and
+		milestones of <SRC> are copied over. <SRC> itself is left unchanged.
+
+## Options:
+
+	-f, --format <FORMAT>
+		Pretty print repository information using format <FORMAT>. See the "PRETTY
+		FORMATS" section of git-log(1) for some additional details on how
+		placeholders are used in format. The available placeholders are:
+
+		%N: name with owner
+
+		%U: the URL of this repository
+
+		%d: description
+
+		%h: homepage
+
+		%B: default branch
+
+		%v: visibility ("public" or "private")
+
+		%a: "archived" if the repository is archived, otherwise blank
+
+		%T: comma-separated topics
+
+		%st: number of stargazers
+
+		%fk: number of forks
+
+		%oi: number of open issues
+
+		%n: newline
+
+		%%: a literal %
+
+	-d, --description <DESCRIPTION>
+		Set the repository description.
+
+	-h, --homepage <HOMEPAGE>
+		Set the repository homepage URL.
+
+	-b, --default-branch <BRANCH>
+		Set the default branch of the repository.
+
+	--merge-commit <BOOL>
+		Allow or disallow merging pull requests with a merge commit.
+
+	--squash-merge <BOOL>
+		Allow or disallow squash-merging pull requests.
+
+	--rebase-merge <BOOL>
+		Allow or disallow rebase-merging pull requests.
+
+	--delete-branch-on-merge <BOOL>
+		Automatically delete head branches after pull requests are merged.
+
+	--add <TOPICS>
+		A comma-separated list of topics to add to the repository.
+
+	--remove <TOPICS>
+		A comma-separated list of topics to remove from the repository.
+
+	--set <TOPICS>
+		A comma-separated list of topics to replace all existing topics with.
+
+	-y, --yes
+		Skip the confirmation prompt.
+
+	-p, --private
+		Create <DEST> as a private repository even if <SRC> is public.
+
+	--issues
+		Also move the open issues of <SRC> to <DEST>. Issues are transferred when
+		both repositories have the same owner. Otherwise, they are copied along
+		with their comments as by 'hub issue import', and stay open in <SRC>.
+
+## Examples:
+		$ hub repo view
+		$ hub repo edit --description "My project" --squash-merge true
+		$ hub repo topics --add cli,git
+		$ hub repo transfer my-org
+		$ hub repo mirror my-org/tool new-org/tool --issues
+
+## See also:
+
+hub-create(1), hub-delete(1), hub-issue(1), hub(1)
+`,
+	}
+
+	cmdViewRepo = &Command{
+		Key: "view",
+		Run: viewRepo,
+		KnownFlags: `
+		-f, --format FORMAT
+		--color
+`,
+	}
+
+	cmdEditRepo = &Command{
+		Key: "edit",
+		Run: editRepo,
+		KnownFlags: `
+		-d, --description DESC
+		-h, --homepage URL
+		-b, --default-branch BRANCH
+		--merge-commit BOOL
+		--squash-merge BOOL
+		--rebase-merge BOOL
+		--delete-branch-on-merge BOOL
+`,
+	}
+
+	cmdRepoTopics = &Command{
+		Key: "topics",
+		Run: repoTopics,
+		KnownFlags: `
+		--add TOPICS
+		--remove TOPICS
+		--set TOPICS
+`,
+	}
+
+	cmdArchiveRepo = &Command{
+		Key: "archive",
+		Run: archiveRepo,
+		KnownFlags: `
+		-y, --yes
+`,
+	}
+
+	cmdUnarchiveRepo = &Command{
+		Key:        "unarchive",
+		Run:        unarchiveRepo,
+		KnownFlags: "\n",
+	}
+
+	cmdTransferRepo = &Command{
+		Key: "transfer",
+		Run: transferRepo,
+		KnownFlags: `
+		-y, --yes
+`,
+	}
+
+	cmdMirrorRepo = &Command{
+		Key: "mirror",
+		Run: mirrorRepo,
+		KnownFlags: `
+		-p, --private
+		--issues
+`,
+	}
+)
+
+func init() {
+	cmdRepo.Use(cmdViewRepo)
+	cmdRepo.Use(cmdEditRepo)
+	cmdRepo.Use(cmdRepoTopics)
+	cmdRepo.Use(cmdArchiveRepo)
+	cmdRepo.Use(cmdUnarchiveRepo)
+	cmdRepo.Use(cmdTransferRepo)
+	cmdRepo.Use(cmdMirrorRepo)
+	CmdRunner.Use(cmdRepo)
+}
+
+func repoProjectFromArgs(args *Args, index int) *github.Project {
+	name := ""
+	if args.ParamsSize() > index {
+		name = args.GetParam(index)
+	}
+	project, err := resolveProject(name)
+	utils.Check(err)
+	return project
+}
+
+func viewRepo(cmd *Command, args *Args) {
+	project := repoProjectFromArgs(args, 0)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request repository information for %s\n", project)
+		return
+	}
+
+	repo, err := gh.Repository(project)
+	utils.Check(err)
+	topics, err := gh.RepositoryTopics(project)
+	utils.Check(err)
+
+	format := "%N%+d%+h%n%nDefault branch: %B%nVisibility: %v% a%nTopics: %T%nStars: %st  Forks: %fk  Open issues: %oi%n%n%U%n"
+	if args.Flag.HasReceived("--format") {
+		format = args.Flag.Value("--format")
+	}
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	ui.Print(ui.Expand(format, formatRepoPlaceholders(repo, topics), colorize))
+}
+
+func formatRepoPlaceholders(repo *github.Repository, topics []string) map[string]string {
+	visibility := "public"
+	if repo.Private {
+		visibility = "private"
+	}
+	archived := ""
+	if repo.Archived {
+		archived = "archived"
+	}
+
+	return map[string]string{
+		"N":  repo.FullName,
+		"U":  repo.HtmlUrl,
+		"d":  repo.Description,
+		"h":  repo.Homepage,
+		"B":  repo.DefaultBranch,
+		"v":  visibility,
+		"a":  archived,
+		"T":  strings.Join(topics, ", "),
+		"st": strconv.Itoa(repo.StargazersCount),
+		"fk": strconv.Itoa(repo.ForksCount),
+		"oi": strconv.Itoa(repo.OpenIssuesCount),
+	}
+}
+
+func editRepo(cmd *Command, args *Args) {
+	project := repoProjectFromArgs(args, 0)
+	gh := github.NewClient(project.Host)
+
+	params := map[string]interface{}{}
+	if args.Flag.HasReceived("--description") {
+		params["description"] = args.Flag.Value("--description")
+	}
+	if args.Flag.HasReceived("--homepage") {
+		params["homepage"] = args.Flag.Value("--homepage")
+	}
+	if args.Flag.HasReceived("--default-branch") {
+		params["default_branch"] = args.Flag.Value("--default-branch")
+	}
+	boolSettings := map[string]string{
+		"--merge-commit":           "allow_merge_commit",
+		"--squash-merge":           "allow_squash_merge",
+		"--rebase-merge":           "allow_rebase_merge",
+		"--delete-branch-on-merge": "delete_branch_on_merge",
+	}
+	for flag, field := range boolSettings {
+		if args.Flag.HasReceived(flag) {
+			value, err := strconv.ParseBool(args.Flag.Value(flag))
+			if err != nil {
+				utils.Check(fmt.Errorf("invalid value for %s: '%s'", flag, args.Flag.Value(flag)))
+			}
+			params[field] = value
+		}
+	}
+
+	if len(params) == 0 {
+		utils.Check(cmd.UsageError("no settings to edit"))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would edit repository settings for %s\n", project)
+		return
+	}
+
+	_, err := gh.EditRepository(project, params)
+	utils.Check(err)
+}
+
+func repoTopics(cmd *Command, args *Args) {
+	project := repoProjectFromArgs(args, 0)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+
+	add := commaSeparated(args.Flag.AllValues("--add"))
+	remove := commaSeparated(args.Flag.AllValues("--remove"))
+	isUpdate := args.Flag.HasReceived("--set") || len(add) > 0 || len(remove) > 0
+
+	if args.Noop {
+		if isUpdate {
+			ui.Printf("Would update topics for %s\n", project)
+		} else {
+			ui.Printf("Would request list of topics for %s\n", project)
+		}
+		return
+	}
+
+	var topics []string
+	var err error
+	if args.Flag.HasReceived("--set") {
+		topics = commaSeparated(args.Flag.AllValues("--set"))
+	} else {
+		topics, err = gh.RepositoryTopics(project)
+		utils.Check(err)
+	}
+
+	if isUpdate {
+		topics = updateTopics(topics, add, remove)
+		topics, err = gh.ReplaceRepositoryTopics(project, topics)
+		utils.Check(err)
+	}
+
+	for _, topic := range topics {
+		ui.Println(topic)
+	}
+}
+
+func updateTopics(topics, add, remove []string) []string {
+	result := []string{}
+	seen := map[string]bool{}
+	for _, topic := range remove {
+		seen[strings.ToLower(topic)] = true
+	}
+	for _, topic := range append(topics, add...) {
+		topic = strings.ToLower(topic)
+		if !seen[topic] {
+			seen[topic] = true
+			result = append(result, topic)
+		}
+	}
+	return result
+}
+
+func archiveRepo(cmd *Command, args *Args) {
+	project := repoProjectFromArgs(args, 0)
+	gh := github.NewClient(project.Host)
+
+	if !args.Flag.Bool("--yes") && !confirm(fmt.Sprintf("Really archive repository '%s'", project)) {
+		utils.Check(fmt.Errorf("Please type 'yes' for confirmation."))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would archive repository '%s'.\n", project)
+		return
+	}
+
+	_, err := gh.EditRepository(project, map[string]interface{}{"archived": true})
+	utils.Check(err)
+	ui.Infof("Archived repository '%s'.\n", project)
+}
+
+func unarchiveRepo(cmd *Command, args *Args) {
+	project := repoProjectFromArgs(args, 0)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would unarchive repository '%s'.\n", project)
+		return
+	}
+
+	_, err := gh.EditRepository(project, map[string]interface{}{"archived": false})
+	utils.Check(err)
+	ui.Infof("Unarchived repository '%s'.\n", project)
+}
+
+func transferRepo(cmd *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError("you must specify the new owner"))
+	}
+	newOwner := args.GetParam(0)
+	project := repoProjectFromArgs(args, 1)
+	gh := github.NewClient(project.Host)
+
+	if !args.Flag.Bool("--yes") && !confirm(fmt.Sprintf("Really transfer repository '%s' to '%s'", project, newOwner)) {
+		utils.Check(fmt.Errorf("Please type 'yes' for confirmation."))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would transfer repository '%s' to '%s'.\n", project, newOwner)
+		return
+	}
+
+	_, err := gh.TransferRepository(project, newOwner)
+	utils.Check(err)
+	ui.Infof("Transferred repository '%s' to '%s'.\n", project, newOwner)
+}
+
+func mirrorRepo(cmd *Command, args *Args) {
+	if args.ParamsSize() != 2 {
+		utils.Check(cmd.UsageError("you must specify the source and destination repositories"))
+	}
+	src := repoProjectFromArgs(args, 0)
+	dest := repoProjectFromArgs(args, 1)
+	gh := github.NewClient(src.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would mirror repository '%s' to '%s'.\n", src, dest)
+		return
+	}
+
+	repo, err := gh.Repository(src)
+	utils.Check(err)
+	topics, err := gh.RepositoryTopics(src)
+	utils.Check(err)
+	labels, err := gh.FetchLabels(src)
+	utils.Check(err)
+	milestones, err := gh.FetchAllMilestones(src)
+	utils.Check(err)
+
+	params := map[string]interface{}{
+		"description": repo.Description,
+		"homepage":    repo.Homepage,
+		"private":     repo.Private || args.Flag.Bool("--private"),
+	}
+	newRepo, err := gh.CreateRepository(dest, params)
+	utils.Check(err)
+	ui.Infof("Created repository '%s'.\n", dest)
+
+	utils.Check(pushMirror(src, dest))
+	if repo.DefaultBranch != "" {
+		_, err = gh.EditRepository(dest, map[string]interface{}{"default_branch": repo.DefaultBranch})
+		utils.Check(err)
+	}
+
+	if len(topics) > 0 {
+		_, err = gh.ReplaceRepositoryTopics(dest, topics)
+		utils.Check(err)
+	}
+	utils.Check(createMissingLabels(gh, dest, labels))
+	_, err = createMissingMilestones(gh, dest, mirroredMilestones(milestones))
+	utils.Check(err)
+
+	if args.Flag.Bool("--issues") {
+		job := newBulkJob(args, fmt.Sprintf("repo-mirror-%s-%s", src, dest))
+		if strings.EqualFold(src.Owner, dest.Owner) {
+			utils.Check(transferOpenIssues(gh, src, newRepo, job))
+		} else {
+			issues, err := fetchExportedIssues(gh, src, "open", -1)
+			utils.Check(err)
+			utils.Check(importExportedIssues(gh, dest, issues, job))
+		}
+	}
+
+	ui.Println(newRepo.HtmlUrl)
+}
+
+// mirroredMilestones converts the milestones of the source repository to the
+// form that createMissingMilestones recreates in the mirror.
+func mirroredMilestones(milestones []github.Milestone) []exportedMilestone {
+	mirrored := []exportedMilestone{}
+	for _, m := range milestones {
+		mirrored = append(mirrored, exportedMilestone{
+			Title:       m.Title,
+			Description: m.Description,
+			State:       m.State,
+			DueOn:       m.DueOn,
+		})
+	}
+	return mirrored
+}
+
+// pushMirror pushes all branches and tags of src to dest by way of a
+// temporary bare clone.
+func pushMirror(src, dest *github.Project) error {
+	dir, err := ioutil.TempDir("", "hub-mirror-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := git.Spawn("clone", "--bare", src.GitURL("", "", true), dir); err != nil {
+		return err
+	}
+	return git.Spawn("--git-dir", dir, "push", "--mirror", dest.GitURL("", "", true))
+}
+
+func transferOpenIssues(gh *github.Client, src *github.Project, target *github.Repository, job *github.BulkJob) error {
+	issues, err := gh.FetchIssues(src, map[string]interface{}{"state": "open"}, -1, func(issue *github.Issue) bool {
+		return issue.PullRequest == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	byNumber := map[string]github.Issue{}
+	numbers := []string{}
+	for _, issue := range issues {
+		number := strconv.Itoa(issue.Number)
+		byNumber[number] = issue
+		numbers = append(numbers, number)
+	}
+	return job.Run(numbers, func(number string) error {
+		issue := byNumber[number]
+		transferred, err := gh.TransferIssue(&issue, target)
+		if err != nil {
+			return err
+		}
+		ui.Infof("Transferred issue #%s to %s\n", number, transferred.HtmlUrl)
+		return nil
+	})
+}
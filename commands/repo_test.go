@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+	"github.com/github/hub/github"
+)
+
+func TestUpdateTopics(t *testing.T) {
+	topics := updateTopics([]string{"git", "cli", "go"}, []string{"GitHub", "cli"}, []string{"go"})
+	assert.Equal(t, []string{"git", "cli", "github"}, topics)
+}
+
+func TestMirroredMilestones(t *testing.T) {
+	dueOn := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	milestones := mirroredMilestones([]github.Milestone{
+		{Number: 3, Title: "v1.0", Description: "First", State: "open", DueOn: &dueOn},
+		{Number: 7, Title: "v0.9", State: "closed"},
+	})
+	assert.Equal(t, []exportedMilestone{
+		{Title: "v1.0", Description: "First", State: "open", DueOn: &dueOn},
+		{Title: "v0.9", State: "closed"},
+	}, milestones)
+
+	assert.Equal(t, []exportedMilestone{}, mirroredMilestones(nil))
+}
@@ -2,10 +2,14 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/github/hub/cmd"
 	"github.com/github/hub/git"
+	"github.com/github/hub/github"
 	"github.com/github/hub/ui"
 	"github.com/kballard/go-shellquote"
 )
@@ -50,9 +54,39 @@ func (r *Runner) Execute(cliArgs []string) error {
 		cmdName = strings.SplitN(cmdName, "=", 2)[0]
 	}
 
+	if args.Verbose {
+		os.Setenv("HUB_VERBOSE", "1")
+	}
+	if args.Quiet {
+		os.Setenv("HUB_QUIET", "1")
+	}
+	if args.Offline {
+		os.Setenv("HUB_OFFLINE", "1")
+	}
+	if args.NoCache {
+		os.Setenv("HUB_NO_CACHE", "1")
+	}
+	if args.Account != "" {
+		os.Setenv("HUB_PROFILE", args.Account)
+	}
+	if args.Remote != "" {
+		os.Setenv("HUB_REMOTE", args.Remote)
+	}
+
+	defer github.CancelOnInterrupt()()
+
 	git.GlobalFlags = args.GlobalFlags // preserve git global flags
 	if !isBuiltInHubCommand(cmdName) {
-		expandAlias(args)
+		if expansion := github.ConfigSetting("aliases." + cmdName); expansion != "" {
+			if strings.HasPrefix(expansion, "!") {
+				return runShellAlias(cmdName, expansion[1:], args)
+			}
+			if err := expandHubAlias(args, expansion); err != nil {
+				return err
+			}
+		} else {
+			expandAlias(args)
+		}
 		cmdName = args.Command
 	}
 
@@ -65,6 +99,12 @@ func (r *Runner) Execute(cliArgs []string) error {
 		return err
 	}
 
+	if cmd == nil && !forceFail {
+		if path := findExtension(cmdName); path != "" {
+			return runExtension(path, args)
+		}
+	}
+
 	gitArgs := []string{}
 	if args.Command != "" {
 		gitArgs = append(gitArgs, args.Command)
@@ -136,6 +176,62 @@ func expandAlias(args *Args) {
 	}
 }
 
+// expandHubAlias replaces the command with an alias defined via `hub alias set`.
+func expandHubAlias(args *Args, expansion string) error {
+	words, err := splitAliasCmd(expansion)
+	if err != nil {
+		return err
+	}
+	words, err = interpolateAlias(words, args.Params)
+	if err != nil {
+		return fmt.Errorf("error expanding alias %s: %s", args.Command, err)
+	}
+
+	args.Command = words[0]
+	args.Params = words[1:]
+	return nil
+}
+
+var aliasPlaceholderRegexp = regexp.MustCompile(`\$(\d+)`)
+
+// interpolateAlias substitutes "$1", "$2", etc. in the alias words with the
+// positional arguments. Arguments beyond the highest placeholder are appended.
+func interpolateAlias(words, params []string) ([]string, error) {
+	used := 0
+	result := []string{}
+	for _, word := range words {
+		var err error
+		word = aliasPlaceholderRegexp.ReplaceAllStringFunc(word, func(placeholder string) string {
+			n, _ := strconv.Atoi(placeholder[1:])
+			if n < 1 || n > len(params) {
+				err = fmt.Errorf("not enough arguments for %s", placeholder)
+				return placeholder
+			}
+			if n > used {
+				used = n
+			}
+			return params[n-1]
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, word)
+	}
+
+	return append(result, params[used:]...), nil
+}
+
+// runShellAlias runs an alias starting with "!" through sh, passing the
+// arguments on as positional parameters.
+func runShellAlias(name, script string, args *Args) error {
+	c := cmd.New("sh").WithArgs("-c", script, name).WithArgs(args.Params...)
+	if args.Noop {
+		ui.Println(c)
+		return nil
+	}
+	return c.Run()
+}
+
 func isBuiltInHubCommand(command string) bool {
 	for hubCommand := range CmdRunner.All() {
 		if hubCommand == command {
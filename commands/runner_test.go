@@ -17,3 +17,20 @@ func TestRunner_splitAliasCmd(t *testing.T) {
 	_, err = splitAliasCmd("")
 	assert.NotEqual(t, nil, err)
 }
+
+func TestRunner_interpolateAlias(t *testing.T) {
+	words, err := interpolateAlias([]string{"pr", "checkout", "$1"}, []string{"123", "my-branch"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"pr", "checkout", "123", "my-branch"}, words)
+
+	words, err = interpolateAlias([]string{"issue", "-l", "$2,$1"}, []string{"bug", "ui", "-a", "me"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"issue", "-l", "ui,bug", "-a", "me"}, words)
+
+	words, err = interpolateAlias([]string{"pr", "list"}, []string{"-s", "closed"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"pr", "list", "-s", "closed"}, words)
+
+	_, err = interpolateAlias([]string{"pr", "checkout", "$1"}, []string{})
+	assert.Equal(t, "not enough arguments for $1", err.Error())
+}
@@ -0,0 +1,344 @@
+package commands
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdSearch = &Command{
+		Run: printHelp,
+		Usage: `
+search issues [-f <FORMAT>|--output <FORMAT>] [-o <SORT_KEY> [-^]] [-L <LIMIT>] <QUERY>
+search prs [-f <FORMAT>|--output <FORMAT>] [-o <SORT_KEY> [-^]] [-L <LIMIT>] <QUERY>
+search repos [-f <FORMAT>|--output <FORMAT>] [-o <SORT_KEY> [-^]] [-L <LIMIT>] <QUERY>
+search code [-f <FORMAT>|--output <FORMAT>] [-L <LIMIT>] <QUERY>
+`,
+		Long: `Search GitHub for issues, pull requests, repositories, or code.
+
+## Commands:
+
+<QUERY> uses the GitHub search syntax, e.g. "org:github is:open" or
+"assignee:@me". Multiple arguments are joined into a single query. The
+"mentions" and "review-requested" qualifiers also accept a team in "ORG/TEAM"
+format.
+
+	* _issues_:
+		List issues matching <QUERY> across all repositories.
+
+	* _prs_:
+		List pull requests matching <QUERY> across all repositories.
+
+	* _repos_:
+		List repositories matching <QUERY>.
+
+	* _code_:
+		List files whose contents match <QUERY>. GitHub requires code searches
+		to be scoped with a "repo:", "org:", or "user:" qualifier.
+
+## Options:
+
+	-f, --format <FORMAT>
+		Pretty print the results using <FORMAT>. For issues and pull requests,
+		all placeholders of hub-issue(1) are available, as well as:
+
+		%R: the "OWNER/REPO" name of the repository
+
+		For repositories, the placeholders are:
+
+		%N: the "OWNER/REPO" name of the repository
+
+		%U: the URL of this repository
+
+		%d: description
+
+		%h: homepage
+
+		%B: default branch
+
+		%v: visibility ("public" or "private")
+
+		%a: "archived" if the repository is archived
+
+		%T: comma-separated list of topics
+
+		%st: number of stars
+
+		%fk: number of forks
+
+		%oi: number of open issues
+
+		For code, the placeholders are:
+
+		%R: the "OWNER/REPO" name of the repository
+
+		%p: path of the file
+
+		%N: name of the file
+
+		%U: the URL of this file
+
+		%sH: SHA of the file
+
+		%n: newline
+
+		%%: a literal %
+
+	--output <FORMAT>
+		Print the results in a structured format instead: "json", "yaml", "csv",
+		or "tsv".
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for '--color'), "never", or "auto" (default).
+
+	-o, --sort <KEY>
+		Sort results by <KEY> instead of by best match. Issues and pull requests
+		can be sorted by "comments", "reactions", "interactions", "created", or
+		"updated"; repositories by "stars", "forks", "help-wanted-issues", or
+		"updated".
+
+	-^, --sort-ascending
+		Sort in ascending order instead of descending.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> results (default: 30). The search API
+		never returns more than 1000 results.
+
+## Examples:
+		$ hub search prs org:acme is:open assignee:@me
+		$ hub search issues -o comments "repo:github/hub is:open label:bug"
+		$ hub search repos -L 5 -o stars topic:cli language:go
+		$ hub search code -f "%R/%p%n" "org:acme filename:Dockerfile"
+
+## See also:
+
+hub-issue(1), hub(1)
+`,
+	}
+
+	cmdSearchIssues = &Command{
+		Key:  "issues",
+		Run:  searchIssues,
+		Long: cmdSearch.Long,
+	}
+
+	cmdSearchPrs = &Command{
+		Key:  "prs",
+		Run:  searchIssues,
+		Long: cmdSearch.Long,
+	}
+
+	cmdSearchRepos = &Command{
+		Key:  "repos",
+		Run:  searchRepos,
+		Long: cmdSearch.Long,
+	}
+
+	cmdSearchCode = &Command{
+		Key:  "code",
+		Run:  searchCode,
+		Long: cmdSearch.Long,
+	}
+)
+
+func init() {
+	cmdSearch.Use(cmdSearchIssues)
+	cmdSearch.Use(cmdSearchPrs)
+	cmdSearch.Use(cmdSearchRepos)
+	cmdSearch.Use(cmdSearchCode)
+	CmdRunner.Use(cmdSearch)
+}
+
+// searchQuery joins the arguments into a query and reads the sorting and
+// limit options shared by all search commands.
+func searchQuery(cmd *Command, args *Args) (query string, params map[string]interface{}, limit int) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError("you must specify a search query"))
+	}
+	query = strings.Join(args.Params, " ")
+
+	params = map[string]interface{}{}
+	if args.Flag.HasReceived("--sort") {
+		params["sort"] = args.Flag.Value("--sort")
+		if args.Flag.Bool("--sort-ascending") {
+			params["order"] = "asc"
+		} else {
+			params["order"] = "desc"
+		}
+	}
+
+	limit = 30
+	if args.Flag.HasReceived("--limit") {
+		limit = args.Flag.Int("--limit")
+	}
+	return
+}
+
+var searchTeamQualifierRegexp = regexp.MustCompile(`(^|\s)(-?)(mentions|review-requested):(\S+/\S+)`)
+
+// searchTeamQualifiers maps the qualifiers that also accept an "ORG/TEAM" team
+// to the ones GitHub search uses for teams.
+var searchTeamQualifiers = map[string]string{
+	"mentions":         "team",
+	"review-requested": "team-review-requested",
+}
+
+// resolveSearchTeams rewrites the qualifiers of query that name a team into
+// the ones GitHub search uses for teams. "@me" is left for GitHub to resolve.
+func resolveSearchTeams(query string) string {
+	return searchTeamQualifierRegexp.ReplaceAllStringFunc(query, func(match string) string {
+		m := searchTeamQualifierRegexp.FindStringSubmatch(match)
+		return m[1] + m[2] + searchTeamQualifiers[m[3]] + ":" + m[4]
+	})
+}
+
+// searchClient returns a client for the host of the current repository, or
+// for the default host outside of a repository.
+func searchClient() *github.Client {
+	if localRepo, err := github.LocalRepo(); err == nil {
+		if project, err := localRepo.MainProject(); err == nil {
+			return github.NewClient(project.Host)
+		}
+	}
+	host, err := github.CurrentConfig().DefaultHostNoPrompt()
+	utils.Check(err)
+	return github.NewClient(host.Host)
+}
+
+func searchIssues(cmd *Command, args *Args) {
+	query, params, limit := searchQuery(cmd, args)
+	if cmd.Key == "prs" {
+		query = "type:pr " + query
+	} else {
+		query = "type:issue " + query
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would search %s for `%s'\n", cmd.Key, query)
+		return
+	}
+
+	result, err := searchClient().SearchIssues(resolveSearchTeams(query), params, limit)
+	utils.Check(err)
+
+	if args.Flag.HasReceived("--output") {
+		records := []ui.Record{}
+		for _, issue := range result.Items {
+			records = append(records, searchIssueRecord(issue))
+		}
+		printRecords(args.Flag.Value("--output"), records, searchIssueRecord(github.Issue{}))
+		return
+	}
+
+	format := "%sC%R%i%Creset  %t%  l%n"
+	if args.Flag.HasReceived("--format") {
+		format = args.Flag.Value("--format")
+	}
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, issue := range result.Items {
+		placeholders := formatIssuePlaceholders(issue, colorize)
+		placeholders["R"] = issue.RepositoryName()
+		ui.Print(ui.Expand(format, placeholders, colorize))
+	}
+}
+
+func searchRepos(cmd *Command, args *Args) {
+	query, params, limit := searchQuery(cmd, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would search repositories for `%s'\n", query)
+		return
+	}
+
+	result, err := searchClient().SearchRepositories(query, params, limit)
+	utils.Check(err)
+
+	if args.Flag.HasReceived("--output") {
+		records := []ui.Record{}
+		for _, repo := range result.Items {
+			records = append(records, searchRepoRecord(repo))
+		}
+		printRecords(args.Flag.Value("--output"), records, searchRepoRecord(github.Repository{}))
+		return
+	}
+
+	format := "%N%  d%n"
+	if args.Flag.HasReceived("--format") {
+		format = args.Flag.Value("--format")
+	}
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for i := range result.Items {
+		repo := &result.Items[i]
+		ui.Print(ui.Expand(format, formatRepoPlaceholders(repo, repo.Topics), colorize))
+	}
+}
+
+func searchCode(cmd *Command, args *Args) {
+	query, params, limit := searchQuery(cmd, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would search code for `%s'\n", query)
+		return
+	}
+
+	result, err := searchClient().SearchCode(query, params, limit)
+	utils.Check(err)
+
+	if args.Flag.HasReceived("--output") {
+		records := []ui.Record{}
+		for _, item := range result.Items {
+			records = append(records, searchCodeRecord(item))
+		}
+		printRecords(args.Flag.Value("--output"), records, searchCodeRecord(github.CodeSearchItem{}))
+		return
+	}
+
+	format := "%R:%p%n"
+	if args.Flag.HasReceived("--format") {
+		format = args.Flag.Value("--format")
+	}
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, item := range result.Items {
+		ui.Print(ui.Expand(format, map[string]string{
+			"R":  item.Repository.FullName,
+			"p":  item.Path,
+			"N":  item.Name,
+			"U":  item.HtmlUrl,
+			"sH": item.Sha,
+		}, colorize))
+	}
+}
+
+func searchIssueRecord(issue github.Issue) ui.Record {
+	record := ui.Record{}.With("repository", issue.RepositoryName())
+	return append(record, issueRecord(issue)...)
+}
+
+func searchRepoRecord(repo github.Repository) ui.Record {
+	return ui.Record{}.
+		With("name", repo.FullName).
+		With("description", repo.Description).
+		With("url", repo.HtmlUrl).
+		With("private", repo.Private).
+		With("fork", repo.Fork).
+		With("archived", repo.Archived).
+		With("stars", repo.StargazersCount).
+		With("forks", repo.ForksCount).
+		With("topics", repo.Topics)
+}
+
+func searchCodeRecord(item github.CodeSearchItem) ui.Record {
+	return ui.Record{}.
+		With("repository", item.Repository.FullName).
+		With("path", item.Path).
+		With("url", item.HtmlUrl).
+		With("sha", item.Sha)
+}
@@ -0,0 +1,15 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestResolveSearchTeams(t *testing.T) {
+	query := resolveSearchTeams("review-requested:acme/core -mentions:acme/docs involves:octocat")
+	assert.Equal(t, "team-review-requested:acme/core -team:acme/docs involves:octocat", query)
+
+	query = resolveSearchTeams("is:open author:@me -assignee:@me review-requested:@me")
+	assert.Equal(t, "is:open author:@me -assignee:@me review-requested:@me", query)
+}
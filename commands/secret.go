@@ -0,0 +1,263 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	cmdSecret = &Command{
+		Run: printHelp,
+		Usage: `
+secret list [--org <ORG>] [--app <APP>] [<OWNER>/<REPO>]
+secret set [-b <VALUE>|-f <FILE>] [--app <APP>] <NAME> [<OWNER>/<REPO>]
+secret set --org <ORG> [--visibility <VISIBILITY>] [--repos <REPOS>] [-b <VALUE>|-f <FILE>] [--app <APP>] <NAME>
+secret remove [--org <ORG>] [--app <APP>] <NAME> [<OWNER>/<REPO>]
+`,
+		Long: `Manage GitHub Actions and Dependabot secrets.
+
+## Commands:
+
+When neither '--org' nor <OWNER>/<REPO> are given, the secrets of the
+repository of the current git remote configuration are managed.
+
+	* _list_:
+		List the names of secrets. Secret values can never be read back.
+
+	* _set_:
+		Create or update the secret <NAME>. The value is encrypted locally with the
+		public key of the repository or organization before it is sent to GitHub.
+
+	* _remove_:
+		Delete the secret <NAME>.
+
+## Options:
+	-b, --body <VALUE>
+		Use <VALUE> as the value of the secret.
+
+	-f, --file <FILE>
+		Read the value of the secret from <FILE>. Pass "-" to read from standard
+		input instead.
+
+		When neither '--body' nor '--file' are given, the value is read from
+		standard input, or prompted for if standard input is a terminal.
+
+	--org <ORG>
+		Manage the secrets of organization <ORG> instead of a repository.
+
+	--visibility <VISIBILITY>
+		Which repositories of the organization can use the secret: "all",
+		"private" (default), or "selected".
+
+	--repos <REPOS>
+		A comma-separated list of repositories in <ORG> that can use the secret.
+		Implies '--visibility selected'.
+
+	--app <APP>
+		Either "actions" (default) or "dependabot".
+
+## Examples:
+		$ hub secret set NPM_TOKEN --body "$token"
+		$ hub secret set DEPLOY_KEY -f ~/.ssh/deploy_key
+		$ hub secret set --org my-org --repos api,web REGISTRY_PASSWORD < password.txt
+		$ hub secret list --app dependabot
+		$ hub secret remove NPM_TOKEN
+
+## See also:
+
+hub(1)
+`,
+	}
+
+	cmdListSecrets = &Command{
+		Key: "list",
+		Run: listSecrets,
+		KnownFlags: `
+		--org ORG
+		--app APP
+`,
+	}
+
+	cmdSetSecret = &Command{
+		Key: "set",
+		Run: setSecret,
+		KnownFlags: `
+		-b, --body VALUE
+		-f, --file FILE
+		--org ORG
+		--visibility VISIBILITY
+		--repos REPOS
+		--app APP
+`,
+	}
+
+	cmdRemoveSecret = &Command{
+		Key: "remove",
+		Run: removeSecret,
+		KnownFlags: `
+		--org ORG
+		--app APP
+`,
+	}
+)
+
+func init() {
+	cmdSecret.Use(cmdListSecrets)
+	cmdSecret.Use(cmdSetSecret)
+	cmdSecret.Use(cmdRemoveSecret)
+	CmdRunner.Use(cmdSecret)
+}
+
+// secretScopeFromArgs determines whose secrets to manage from the '--org' and
+// '--app' flags or from the optional <OWNER>/<REPO> parameter at `index`.
+func secretScopeFromArgs(args *Args, index int) (*github.SecretScope, *github.Client) {
+	scope := &github.SecretScope{App: "actions"}
+	if args.Flag.HasReceived("--app") {
+		scope.App = args.Flag.Value("--app")
+		if scope.App != "actions" && scope.App != "dependabot" {
+			utils.Check(fmt.Errorf("invalid app: '%s' (expected \"actions\" or \"dependabot\")", scope.App))
+		}
+	}
+
+	if !args.Flag.HasReceived("--org") {
+		scope.Project = repoProjectFromArgs(args, index)
+		return scope, github.NewClient(scope.Project.Host)
+	}
+
+	if args.ParamsSize() > index {
+		utils.Check(fmt.Errorf("can't use both --org and <OWNER>/<REPO>"))
+	}
+	scope.Org = args.Flag.Value("--org")
+
+	host := ""
+	if localRepo, err := github.LocalRepo(); err == nil {
+		if project, err := localRepo.MainProject(); err == nil {
+			host = project.Host
+		}
+	}
+	if host == "" {
+		defaultHost, err := github.CurrentConfig().DefaultHostNoPrompt()
+		utils.Check(err)
+		host = defaultHost.Host
+	}
+	return scope, github.NewClient(host)
+}
+
+func listSecrets(cmd *Command, args *Args) {
+	scope, gh := secretScopeFromArgs(args, 0)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of %s secrets for %s\n", scope.App, scope)
+		return
+	}
+
+	secrets, err := gh.Secrets(scope)
+	utils.Check(err)
+
+	nameWidth := 0
+	for _, secret := range secrets {
+		if len(secret.Name) > nameWidth {
+			nameWidth = len(secret.Name)
+		}
+	}
+	for _, secret := range secrets {
+		line := fmt.Sprintf("%-*s  Updated %s", nameWidth, secret.Name, secret.UpdatedAt.Format("2006-01-02"))
+		if secret.Visibility != "" {
+			line += fmt.Sprintf("  (%s)", secret.Visibility)
+		}
+		ui.Println(line)
+	}
+}
+
+func setSecret(cmd *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError("you must specify a secret name"))
+	}
+	name := args.GetParam(0)
+	scope, gh := secretScopeFromArgs(args, 1)
+
+	visibility := args.Flag.Value("--visibility")
+	repos := commaSeparated(args.Flag.AllValues("--repos"))
+	if scope.Org == "" && (visibility != "" || len(repos) > 0) {
+		utils.Check(fmt.Errorf("--visibility and --repos can only be used with --org"))
+	}
+	if len(repos) > 0 {
+		if visibility != "" && visibility != "selected" {
+			utils.Check(fmt.Errorf("--repos requires visibility \"selected\""))
+		}
+		visibility = "selected"
+	}
+	switch visibility {
+	case "", "all", "private", "selected":
+	default:
+		utils.Check(fmt.Errorf("invalid visibility: '%s' (expected \"all\", \"private\", or \"selected\")", visibility))
+	}
+	if visibility == "selected" && len(repos) == 0 {
+		utils.Check(fmt.Errorf("visibility \"selected\" requires --repos"))
+	}
+
+	value, err := readSecretValue(args, name)
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set %s secret %s for %s\n", scope.App, name, scope)
+		return
+	}
+
+	var repositoryIDs []int
+	for _, repo := range repos {
+		repository, err := gh.Repository(github.NewProject(scope.Org, repo, gh.Host.Host))
+		utils.Check(err)
+		repositoryIDs = append(repositoryIDs, repository.Id)
+	}
+
+	utils.Check(gh.SetSecret(scope, name, value, visibility, repositoryIDs))
+	ui.Infof("Set secret %s for %s\n", name, scope)
+}
+
+func readSecretValue(args *Args, name string) ([]byte, error) {
+	if args.Flag.HasReceived("--body") {
+		return []byte(args.Flag.Value("--body")), nil
+	}
+
+	file := args.Flag.Value("--file")
+	if file != "" && file != "-" {
+		return ioutil.ReadFile(file)
+	}
+
+	if file == "" && ui.IsTerminal(os.Stdin) {
+		ui.Printf("Value for %s: ", name)
+		value, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+		ui.Println()
+		return value, err
+	}
+
+	value, err := ioutil.ReadAll(os.Stdin)
+	return bytes.TrimRight(value, "\r\n"), err
+}
+
+func removeSecret(cmd *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError("you must specify a secret name"))
+	}
+	name := args.GetParam(0)
+	scope, gh := secretScopeFromArgs(args, 1)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would remove %s secret %s from %s\n", scope.App, name, scope)
+		return
+	}
+
+	utils.Check(gh.DeleteSecret(scope, name))
+	ui.Infof("Removed secret %s from %s\n", name, scope)
+}
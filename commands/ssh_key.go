@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdSSHKey = &Command{
+		Run: printHelp,
+		Usage: `
+ssh-key list
+ssh-key add [--title <TITLE>] <FILE>
+ssh-key remove <ID>
+`,
+		Long: `Manage the SSH keys of your GitHub account.
+
+## Commands:
+
+	* _list_:
+		List SSH keys as "<ID>  <TITLE>  <FINGERPRINT>" lines.
+
+	* _add_:
+		Add the public key in <FILE>, or standard input if <FILE> is "-". Adding
+		a key that is already on your account is not an error, which makes this
+		safe to use in provisioning scripts.
+
+	* _remove_:
+		Remove the SSH key with <ID>.
+
+## Options:
+	--title <TITLE>
+		A name for the key (default: the comment of the key, or the hostname of
+		this machine).
+
+## Examples:
+		$ hub ssh-key add ~/.ssh/id_ed25519.pub --title laptop
+
+## See also:
+
+hub-gpg-key(1), hub(1)
+`,
+	}
+
+	cmdListSSHKeys = &Command{
+		Key: "list",
+		Run: listSSHKeys,
+	}
+
+	cmdAddSSHKey = &Command{
+		Key: "add",
+		Run: addSSHKey,
+		KnownFlags: `
+		--title TITLE
+`,
+	}
+
+	cmdRemoveSSHKey = &Command{
+		Key: "remove",
+		Run: removeSSHKey,
+	}
+)
+
+func init() {
+	cmdSSHKey.Use(cmdListSSHKeys)
+	cmdSSHKey.Use(cmdAddSSHKey)
+	cmdSSHKey.Use(cmdRemoveSSHKey)
+	CmdRunner.Use(cmdSSHKey)
+}
+
+// readKeyFile reads a public key from file, or from standard input if file
+// is "-".
+func readKeyFile(file string) string {
+	var content []byte
+	var err error
+	if file == "-" {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = ioutil.ReadFile(file)
+	}
+	utils.Check(err)
+	return strings.TrimSpace(string(content))
+}
+
+// sshKeyFingerprint computes the SHA256 fingerprint of an authorized_keys
+// line, in the format used by `ssh-keygen -l`.
+func sshKeyFingerprint(key string) string {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return ""
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+func listSSHKeys(cmd *Command, args *Args) {
+	if !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Println("Would request list of SSH keys")
+		return
+	}
+
+	gh := accountClient("fetching SSH keys")
+	keys, err := gh.SSHKeys()
+	utils.Check(err)
+
+	idWidth, titleWidth := 0, 0
+	for _, key := range keys {
+		if width := len(strconv.FormatInt(key.Id, 10)); width > idWidth {
+			idWidth = width
+		}
+		if len(key.Title) > titleWidth {
+			titleWidth = len(key.Title)
+		}
+	}
+	for _, key := range keys {
+		ui.Printf("%*d  %-*s  %s\n", idWidth, key.Id, titleWidth, key.Title, sshKeyFingerprint(key.Key))
+	}
+}
+
+func addSSHKey(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	file := args.GetParam(0)
+	key := readKeyFile(file)
+	if strings.Contains(key, "PRIVATE KEY") {
+		utils.Check(fmt.Errorf("%s contains a private key; refusing to upload it (did you mean %s.pub?)", file, file))
+	}
+	fields := strings.Fields(key)
+	if len(fields) < 2 || sshKeyFingerprint(key) == "" {
+		utils.Check(fmt.Errorf("invalid SSH public key: %s", file))
+	}
+
+	title := args.Flag.Value("--title")
+	if title == "" && len(fields) > 2 {
+		title = strings.Join(fields[2:], " ")
+	}
+	if title == "" {
+		title, _ = os.Hostname()
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would add SSH key %s titled %q\n", sshKeyFingerprint(key), title)
+		return
+	}
+
+	gh := accountClient("adding SSH key")
+	keys, err := gh.SSHKeys()
+	utils.Check(err)
+	for _, existing := range keys {
+		if sshKeyFingerprint(existing.Key) == sshKeyFingerprint(key) {
+			ui.Printf("SSH key already added as %d (%s)\n", existing.Id, existing.Title)
+			return
+		}
+	}
+
+	sshKey, err := gh.AddSSHKey(title, strings.Join(fields[:2], " "))
+	utils.Check(err)
+	ui.Infof("Added SSH key %d (%s)\n", sshKey.Id, sshKey.Title)
+}
+
+func removeSSHKey(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	id, err := strconv.ParseInt(args.GetParam(0), 10, 64)
+	if err != nil {
+		utils.Check(fmt.Errorf("invalid SSH key ID: '%s'", args.GetParam(0)))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would remove SSH key %d\n", id)
+		return
+	}
+
+	gh := accountClient("removing SSH key")
+	utils.Check(gh.DeleteSSHKey(id))
+	ui.Infof("Removed SSH key %d\n", id)
+}
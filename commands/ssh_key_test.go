@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestSSHKeyFingerprint(t *testing.T) {
+	key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGjK8ufTkf5Krs5IKMtgmhQ2ky/jEsKp8OeY2ZAJPnGg mislav@laptop"
+	assert.Equal(t, "SHA256:wIh7so+XO1I/VMTiPtTZ9ixEMlNWsNSrh5sWZNvCEgE", sshKeyFingerprint(key))
+	assert.Equal(t, "", sshKeyFingerprint("ssh-ed25519"))
+	assert.Equal(t, "", sshKeyFingerprint("ssh-ed25519 not-base64!"))
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var cmdStar = &Command{
+	Run: star,
+	Usage: `
+star [<OWNER>/<REPO>]
+star --list [-L <LIMIT>] [<USER>]
+`,
+	Long: `Star a repository on GitHub, or list starred repositories.
+
+## Options:
+	-l, --list
+		List the repositories starred by <USER> (default: you), most recently
+		starred first, as "<OWNER>/<REPO>" lines.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> repositories.
+
+	<OWNER>/<REPO>
+		The repository to star (default: the current repository).
+
+## Examples:
+		$ hub star
+		$ hub star github/hub
+		$ hub star --list -L 10 mislav
+
+## See also:
+
+hub-unstar(1), hub-watch(1), hub(1)
+`,
+}
+
+var cmdUnstar = &Command{
+	Run:   unstar,
+	Usage: "unstar [<OWNER>/<REPO>]",
+	Long: `Remove the star from a repository on GitHub.
+
+## Options:
+	<OWNER>/<REPO>
+		The repository to unstar (default: the current repository).
+
+## See also:
+
+hub-star(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdStar)
+	CmdRunner.Use(cmdUnstar)
+}
+
+func star(command *Command, args *Args) {
+	if args.Flag.Bool("--list") {
+		listStarred(command, args)
+		return
+	}
+	if args.ParamsSize() > 1 {
+		utils.Check(command.UsageError(""))
+	}
+
+	nameWithOwner := ""
+	if !args.IsParamsEmpty() {
+		nameWithOwner = args.FirstParam()
+	}
+	project, err := resolveProject(nameWithOwner)
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would star %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	utils.Check(gh.StarRepository(project))
+	ui.Infof("Starred %s\n", project)
+}
+
+func unstar(command *Command, args *Args) {
+	if args.ParamsSize() > 1 {
+		utils.Check(command.UsageError(""))
+	}
+
+	nameWithOwner := ""
+	if !args.IsParamsEmpty() {
+		nameWithOwner = args.FirstParam()
+	}
+	project, err := resolveProject(nameWithOwner)
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would unstar %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	utils.Check(gh.UnstarRepository(project))
+	ui.Infof("Unstarred %s\n", project)
+}
+
+func listStarred(command *Command, args *Args) {
+	if args.ParamsSize() > 1 {
+		utils.Check(command.UsageError(""))
+	}
+	user := ""
+	if !args.IsParamsEmpty() {
+		user = args.FirstParam()
+	}
+	if user != "" && !regexp.MustCompile(fmt.Sprintf("^%s$", OwnerRe)).MatchString(user) {
+		utils.Check(fmt.Errorf("invalid user name: '%s'", user))
+	}
+
+	host, err := github.CurrentConfig().DefaultHost()
+	if err != nil {
+		utils.Check(github.FormatError("fetching starred repositories", err))
+	}
+	gh := github.NewClient(host.Host)
+
+	args.NoForward()
+	repos, err := gh.StarredRepositories(user, args.Flag.Int("--limit"))
+	utils.Check(err)
+	for _, repo := range repos {
+		ui.Println(repo.FullName)
+	}
+}
@@ -0,0 +1,312 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdStats = &Command{
+		Run: printHelp,
+		Usage: `
+stats contributors [-L <LIMIT>] [--json] [<OWNER>/<REPO>]
+stats commit-activity [--weeks <WEEKS>] [--json] [<OWNER>/<REPO>]
+stats punch-card [--json] [<OWNER>/<REPO>]
+`,
+		Long: `Report statistics about the contributions to a repository.
+
+GitHub computes statistics in the background when they are first requested,
+and hub waits for up to a minute for them to be ready.
+
+## Commands:
+
+	* _contributors_:
+		List the contributors with the most commits first, as
+		"<COMMITS>  +<ADDITIONS>  -<DELETIONS>  <LOGIN>" lines.
+
+	* _commit-activity_:
+		List the number of commits per week over the last year as
+		"<WEEK>  <TOTAL>  <SUN> <MON> <TUE> <WED> <THU> <FRI> <SAT>" lines, where
+		<WEEK> is the date of the Sunday that the week starts with.
+
+	* _punch-card_:
+		Show the number of commits for each hour of each day of the week, in UTC.
+
+## Options:
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> contributors.
+
+	--weeks <WEEKS>
+		Only list the last <WEEKS> weeks of commit activity (default: 52).
+
+	--json
+		Output the statistics as a JSON array instead of a table.
+
+	<OWNER>/<REPO>
+		The repository to report on (default: the current repository).
+
+## Examples:
+		$ hub stats contributors -L 10
+		$ hub stats commit-activity --weeks 4 --json github/hub
+
+## See also:
+
+hub-traffic(1), hub-community(1), hub(1)
+`,
+	}
+
+	cmdStatsContributors = &Command{
+		Key: "contributors",
+		Run: statsContributors,
+		KnownFlags: `
+		-L, --limit N
+		--json
+`,
+	}
+
+	cmdStatsCommitActivity = &Command{
+		Key: "commit-activity",
+		Run: statsCommitActivity,
+		KnownFlags: `
+		--weeks N
+		--json
+`,
+	}
+
+	cmdStatsPunchCard = &Command{
+		Key: "punch-card",
+		Run: statsPunchCard,
+		KnownFlags: `
+		--json
+`,
+	}
+)
+
+func init() {
+	cmdStats.Use(cmdStatsContributors)
+	cmdStats.Use(cmdStatsCommitActivity)
+	cmdStats.Use(cmdStatsPunchCard)
+	CmdRunner.Use(cmdStats)
+}
+
+var (
+	statsPollInterval = 2 * time.Second
+	statsTimeout      = time.Minute
+)
+
+// waitForStats calls fetch until GitHub is done computing the statistics of
+// project, or gives up after statsTimeout.
+func waitForStats(project *github.Project, fetch func() error) {
+	var spinner *ui.Spinner
+	deadline := time.Now().Add(statsTimeout)
+	for {
+		err := fetch()
+		if err == github.ErrStatsComputing && time.Now().Before(deadline) {
+			if spinner == nil {
+				spinner = ui.StartSpinner(fmt.Sprintf("Waiting for GitHub to compute the statistics of %s ...", project))
+			}
+			time.Sleep(statsPollInterval)
+			continue
+		}
+		if spinner != nil {
+			spinner.Stop()
+		}
+		if err == github.ErrStatsComputing {
+			err = fmt.Errorf("GitHub is still computing the statistics of %s; try again later", project)
+		}
+		utils.Check(err)
+		return
+	}
+}
+
+type contributorSummary struct {
+	Login     string `json:"login"`
+	Commits   int    `json:"commits"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// summarizeContributors adds up the weekly activity of each contributor, and
+// sorts them by the number of commits.
+func summarizeContributors(stats []github.ContributorStats) []contributorSummary {
+	summaries := []contributorSummary{}
+	for _, contributor := range stats {
+		summary := contributorSummary{Login: "ghost", Commits: contributor.Total}
+		if contributor.Author != nil {
+			summary.Login = contributor.Author.Login
+		}
+		for _, week := range contributor.Weeks {
+			summary.Additions += week.Additions
+			summary.Deletions += week.Deletions
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].Commits != summaries[j].Commits {
+			return summaries[i].Commits > summaries[j].Commits
+		}
+		return summaries[i].Login < summaries[j].Login
+	})
+	return summaries
+}
+
+func statsContributors(cmd *Command, args *Args) {
+	project := optionalProjectFromArgs(cmd, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request contributor statistics for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	var stats []github.ContributorStats
+	waitForStats(project, func() (err error) {
+		stats, err = gh.ContributorStats(project)
+		return
+	})
+
+	summaries := summarizeContributors(stats)
+	if limit := args.Flag.Int("--limit"); limit > 0 && len(summaries) > limit {
+		summaries = summaries[:limit]
+	}
+
+	if printJSONIfRequested(args, summaries) {
+		return
+	}
+
+	table := ui.NewTable(ui.TableColumn{AlignRight: true}, ui.TableColumn{AlignRight: true}, ui.TableColumn{AlignRight: true}, ui.TableColumn{})
+	table.Colorize = colorizeOutput(false, "")
+	for _, summary := range summaries {
+		table.AddRow(
+			ui.TableCell{Text: strconv.Itoa(summary.Commits)},
+			ui.TableCell{Text: fmt.Sprintf("+%d", summary.Additions), Color: "32"},
+			ui.TableCell{Text: fmt.Sprintf("-%d", summary.Deletions), Color: "31"},
+			ui.TableCell{Text: summary.Login},
+		)
+	}
+	utils.Check(table.Render(ui.Stdout))
+}
+
+type weeklyActivity struct {
+	Week  string `json:"week"`
+	Total int    `json:"total"`
+	Days  []int  `json:"days"`
+}
+
+func statsCommitActivity(cmd *Command, args *Args) {
+	project := optionalProjectFromArgs(cmd, args)
+
+	weeks := 52
+	if args.Flag.HasReceived("--weeks") {
+		weeks = args.Flag.Int("--weeks")
+		if weeks < 1 {
+			utils.Check(fmt.Errorf("invalid number of weeks: %d", weeks))
+		}
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request commit activity for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	var activity []github.CommitActivity
+	waitForStats(project, func() (err error) {
+		activity, err = gh.CommitActivity(project)
+		return
+	})
+	if len(activity) > weeks {
+		activity = activity[len(activity)-weeks:]
+	}
+
+	summaries := []weeklyActivity{}
+	for _, week := range activity {
+		summaries = append(summaries, weeklyActivity{
+			Week:  week.WeekStart().Format("2006-01-02"),
+			Total: week.Total,
+			Days:  week.Days,
+		})
+	}
+	if printJSONIfRequested(args, summaries) {
+		return
+	}
+
+	columns := []ui.TableColumn{{}}
+	for i := 0; i < 8; i++ {
+		columns = append(columns, ui.TableColumn{AlignRight: true})
+	}
+	table := ui.NewTable(columns...)
+	for _, week := range summaries {
+		cells := []ui.TableCell{{Text: week.Week}, {Text: strconv.Itoa(week.Total)}}
+		for _, commits := range week.Days {
+			cells = append(cells, ui.TableCell{Text: strconv.Itoa(commits)})
+		}
+		table.AddRow(cells...)
+	}
+	utils.Check(table.Render(ui.Stdout))
+}
+
+var weekdayAbbreviations = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+type punchCardHour struct {
+	Day     string `json:"day"`
+	Hour    int    `json:"hour"`
+	Commits int    `json:"commits"`
+}
+
+func statsPunchCard(cmd *Command, args *Args) {
+	project := optionalProjectFromArgs(cmd, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request punch card for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	var hours []github.PunchCardHour
+	waitForStats(project, func() (err error) {
+		hours, err = gh.PunchCard(project)
+		return
+	})
+
+	grid := [7][24]int{}
+	summaries := []punchCardHour{}
+	for _, hour := range hours {
+		day, h, commits := hour[0], hour[1], hour[2]
+		if day < 0 || day > 6 || h < 0 || h > 23 {
+			continue
+		}
+		grid[day][h] = commits
+		summaries = append(summaries, punchCardHour{Day: weekdayAbbreviations[day], Hour: h, Commits: commits})
+	}
+	if printJSONIfRequested(args, summaries) {
+		return
+	}
+
+	columns := []ui.TableColumn{{}}
+	header := []ui.TableCell{{}}
+	for h := 0; h < 24; h++ {
+		columns = append(columns, ui.TableColumn{AlignRight: true})
+		header = append(header, ui.TableCell{Text: strconv.Itoa(h)})
+	}
+	table := ui.NewTable(columns...)
+	table.Separator = " "
+	table.AddRow(header...)
+	for day, counts := range grid {
+		cells := []ui.TableCell{{Text: weekdayAbbreviations[day]}}
+		for _, commits := range counts {
+			cells = append(cells, ui.TableCell{Text: strconv.Itoa(commits)})
+		}
+		table.AddRow(cells...)
+	}
+	utils.Check(table.Render(ui.Stdout))
+}
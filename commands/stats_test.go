@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/github/hub/github"
+)
+
+func TestSummarizeContributors(t *testing.T) {
+	stats := []github.ContributorStats{
+		{Author: &github.User{Login: "octocat"}, Total: 2, Weeks: []github.ContributorWeekly{
+			{Additions: 10, Deletions: 2, Commits: 1},
+			{Additions: 5, Deletions: 1, Commits: 1},
+		}},
+		{Author: nil, Total: 5},
+		{Author: &github.User{Login: "hubot"}, Total: 2},
+	}
+
+	assert.Equal(t, []contributorSummary{
+		{Login: "ghost", Commits: 5},
+		{Login: "hubot", Commits: 2},
+		{Login: "octocat", Commits: 2, Additions: 15, Deletions: 3},
+	}, summarizeContributors(stats))
+}
@@ -6,10 +6,21 @@ var cmdSubmodule = &Command{
 	Usage:        "submodule add [-p] [<OPTIONS>] [<USER>/]<REPOSITORY> <DESTINATION>",
 	Long: `Add a git submodule for a GitHub repository.
 
+The submodule URL uses the git protocol for public repositories and SSH for
+private ones, or the protocol set in "hub.protocol" if any. With '-p', SSH is
+used regardless.
+
 ## Examples:
 		$ hub submodule add jingweno/gh vendor/gh
 		> git submodule add git://github.com/jingweno/gh.git vendor/gh
 
+		$ hub submodule add github/private-lib vendor/lib
+		> git submodule add git@github.com:github/private-lib.git vendor/lib
+
+		$ git config hub.protocol https
+		$ hub submodule add jingweno/gh vendor/gh
+		> git submodule add https://github.com/jingweno/gh.git vendor/gh
+
 ## See also:
 
 hub-remote(1), hub(1), git-submodule(1)
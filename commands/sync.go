@@ -13,17 +13,28 @@ import (
 
 var cmdSync = &Command{
 	Run:   sync,
-	Usage: "sync [--color]",
+	Usage: "sync [--rebase] [--dry-run] [--color]",
 	Long: `Fetch git objects from upstream and update local branches.
 
 - If the local branch is outdated, fast-forward it;
-- If the local branch contains unpushed work, warn about it;
+- If the local branch contains unpushed work, warn about it along with how many
+  commits it is ahead of and behind its upstream branch;
+- If the local branch has diverged from its upstream branch, warn about it, or
+  rebase it with '--rebase';
 - If the branch seems merged and its upstream branch was deleted, delete it.
 
 If a local branch does not have any upstream configuration, but has a
 same-named branch on the remote, treat that as its upstream branch.
 
 ## Options:
+	--rebase
+		Rebase local branches that have diverged from their upstream branch onto
+		it. If a rebase stops due to conflicts, it is aborted and the branch is
+		left as it was.
+
+	--dry-run
+		Fetch from upstream, but only report what would be done to local branches.
+
 	--color[=<WHEN>]
 		Enable colored output even if stdout is not a terminal. <WHEN> can be one
 		of "always" (default for '--color'), "never", or "auto" (default).
@@ -69,6 +80,9 @@ func sync(cmd *Command, args *Args) {
 	branches, err := git.LocalBranches()
 	utils.Check(err)
 
+	rebase := args.Flag.Bool("--rebase")
+	dryRun := args.Flag.Bool("--dry-run")
+
 	var green,
 		lightGreen,
 		red,
@@ -84,43 +98,58 @@ func sync(cmd *Command, args *Args) {
 		resetColor = "\033[0m"
 	}
 
-	for _, branch := range branches {
+	// Comparing a branch with its upstream takes a few git commands, which
+	// are run for several branches at once before any of them is updated.
+	states := make([]syncBranchState, len(branches))
+	github.ParallelFetch(len(branches), github.ParallelFetchLimit, func(i int) error {
+		states[i] = inspectSyncBranch(branches[i], remote.Name, branchToRemote[branches[i]] == remote.Name, fullDefaultBranch)
+		return nil
+	})
+
+	for _, state := range states {
+		utils.Check(state.err)
+		branch := state.name
 		fullBranch := fmt.Sprintf("refs/heads/%s", branch)
-		remoteBranch := fmt.Sprintf("refs/remotes/%s/%s", remote.Name, branch)
-		gone := false
-
-		if branchToRemote[branch] == remote.Name {
-			if upstream, err := git.SymbolicFullName(fmt.Sprintf("%s@{upstream}", branch)); err == nil {
-				remoteBranch = upstream
-			} else {
-				remoteBranch = ""
-				gone = true
-			}
-		} else if !git.HasFile(strings.Split(remoteBranch, "/")...) {
-			remoteBranch = ""
-		}
+		remoteBranch := state.remoteBranch
+		diff := state.diff
 
 		if remoteBranch != "" {
-			diff, err := git.NewRange(fullBranch, remoteBranch)
-			utils.Check(err)
-
 			if diff.IsIdentical() {
 				continue
-			} else if diff.IsAncestor() {
+			} else if state.isAncestor {
+				if dryRun {
+					ui.Printf("%sWould update branch %s%s%s (was %s).\n", green, lightGreen, branch, resetColor, diff.A[0:7])
+					continue
+				}
 				if branch == currentBranch {
 					git.Quiet("merge", "--ff-only", "--quiet", remoteBranch)
 				} else {
 					git.Quiet("update-ref", fullBranch, remoteBranch)
 				}
 				ui.Printf("%sUpdated branch %s%s%s (was %s).\n", green, lightGreen, branch, resetColor, diff.A[0:7])
-			} else {
-				ui.Errorf("warning: '%s' seems to contain unpushed commits\n", branch)
+				continue
 			}
-		} else if gone {
-			diff, err := git.NewRange(fullBranch, fullDefaultBranch)
-			utils.Check(err)
 
-			if diff.IsAncestor() {
+			ahead, behind := state.ahead, state.behind
+			upstreamName := strings.TrimPrefix(remoteBranch, "refs/remotes/")
+
+			if behind == 0 {
+				ui.Errorf("warning: '%s' seems to contain unpushed commits (%d ahead)\n", branch, ahead)
+			} else if !rebase {
+				ui.Errorf("warning: '%s' has diverged from '%s' (%d ahead, %d behind)\n", branch, upstreamName, ahead, behind)
+			} else if dryRun {
+				ui.Printf("%sWould rebase branch %s%s%s onto %s (%d ahead, %d behind).\n", green, lightGreen, branch, resetColor, upstreamName, ahead, behind)
+			} else if rebaseBranch(branch, remoteBranch, branch == currentBranch) {
+				ui.Printf("%sRebased branch %s%s%s onto %s (was %s).\n", green, lightGreen, branch, resetColor, upstreamName, diff.A[0:7])
+			} else {
+				ui.Errorf("warning: '%s' could not be rebased onto '%s' (%d ahead, %d behind)\n", branch, upstreamName, ahead, behind)
+			}
+		} else if state.gone {
+			if state.isAncestor {
+				if dryRun {
+					ui.Printf("%sWould delete branch %s%s%s (was %s).\n", red, lightRed, branch, resetColor, diff.A[0:7])
+					continue
+				}
 				if branch == currentBranch {
 					git.Quiet("checkout", "--quiet", defaultBranch)
 					currentBranch = defaultBranch
@@ -135,3 +164,73 @@ func sync(cmd *Command, args *Args) {
 
 	args.NoForward()
 }
+
+// syncBranchState is what sync found out about a local branch by comparing it
+// with its upstream branch, or with the default branch if the upstream branch
+// is gone.
+type syncBranchState struct {
+	name          string
+	remoteBranch  string
+	gone          bool
+	diff          *git.Range
+	isAncestor    bool
+	ahead, behind int
+	err           error
+}
+
+func inspectSyncBranch(branch, remoteName string, tracksRemote bool, fullDefaultBranch string) (state syncBranchState) {
+	state.name = branch
+	state.remoteBranch = fmt.Sprintf("refs/remotes/%s/%s", remoteName, branch)
+
+	if tracksRemote {
+		if upstream, err := git.SymbolicFullName(fmt.Sprintf("%s@{upstream}", branch)); err == nil {
+			state.remoteBranch = upstream
+		} else {
+			state.remoteBranch = ""
+			state.gone = true
+		}
+	} else if !git.HasFile(strings.Split(state.remoteBranch, "/")...) {
+		state.remoteBranch = ""
+	}
+
+	compareTo := state.remoteBranch
+	if state.gone {
+		compareTo = fullDefaultBranch
+	} else if compareTo == "" {
+		return
+	}
+
+	state.diff, state.err = git.NewRange(fmt.Sprintf("refs/heads/%s", branch), compareTo)
+	if state.err != nil {
+		return
+	}
+	state.isAncestor = state.diff.IsIdentical() || state.diff.IsAncestor()
+	if !state.isAncestor && !state.gone {
+		state.ahead, state.behind, state.err = state.diff.Counts()
+	}
+	return
+}
+
+// rebaseBranch rebases branch onto upstream, aborting the rebase if it fails.
+// Unless branch is the current branch, the original HEAD is restored after.
+func rebaseBranch(branch, upstream string, isCurrent bool) bool {
+	rebaseArgs := []string{"rebase", "--quiet", upstream}
+	originalHead := ""
+	if !isCurrent {
+		rebaseArgs = append(rebaseArgs, branch)
+		if head, err := git.Head(); err == nil && strings.HasPrefix(head, "refs/heads/") {
+			originalHead = strings.TrimPrefix(head, "refs/heads/")
+		} else {
+			originalHead, _ = git.Ref("HEAD")
+		}
+	}
+
+	ok := git.Quiet(rebaseArgs...)
+	if !ok {
+		git.Quiet("rebase", "--abort")
+	}
+	if originalHead != "" {
+		git.Quiet("checkout", "--quiet", originalHead)
+	}
+	return ok
+}
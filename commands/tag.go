@@ -0,0 +1,331 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/git"
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var cmdTag = &Command{
+	Run:          tag,
+	GitExtension: true,
+	Usage: `
+tag list [--sort <KEY>]
+tag create [-m <MESSAGE>] [-s] [--push] <TAG> [<COMMIT>]
+tag create --next <PART> [-m <MESSAGE>] [-s] [--push] [<COMMIT>]
+tag delete [--remote] <TAG>...
+`,
+	Long: `List, create, and delete version tags.
+
+Invocations that don't start with one of the commands below are passed through
+to git-tag(1).
+
+## Commands:
+
+	* _list_:
+		Print the names of all tags.
+
+	* _create_:
+		Create the tag <TAG> at <COMMIT> (default: HEAD). The tag is lightweight
+		unless a message is given or it is signed.
+
+	* _delete_:
+		Delete the local tags <TAG>.
+
+## Options:
+
+	--sort <KEY>
+		Sort tags by <KEY>: "name" (default) or "semver". With "semver", tags that
+		are semantic versions such as "v1.2.3" or "2.0.0-rc.1" are listed from the
+		newest version to the oldest, followed by the remaining tags by name.
+
+	-m, --message <MESSAGE>
+		Create an annotated tag with <MESSAGE>.
+
+	-s, --sign
+		Create a GPG-signed tag. git opens an editor for the message unless
+		'--message' is given.
+
+	--push
+		Push the new tag to the main git remote, which is "upstream", "github", or
+		"origin", in that order of preference.
+
+	--next <PART>
+		Name the tag after the version that follows the newest semantic version
+		among existing tags, increasing its <PART>: "patch", "minor", or "major".
+		Pre-release versions are skipped, and the "v" prefix of the newest version
+		is kept. Without any version tags, the next version is based on "v0.0.0".
+		The name of the new tag is printed.
+
+	--remote
+		Also delete the tags from the main git remote, whether or not they exist
+		locally.
+
+## Examples:
+		$ hub tag list --sort semver
+		$ hub tag create v1.2.3 -m "Version 1.2.3" --push
+		$ hub tag create --next minor --push
+		$ hub tag delete --remote v1.2.3
+
+## See also:
+
+hub-release(1), hub(1), git-tag(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdTag)
+}
+
+func tag(command *Command, args *Args) {
+	p := utils.NewArgsParser()
+	switch args.FirstParam() {
+	case "list":
+		p.RegisterValue("--sort")
+	case "create":
+		p.RegisterValue("--message", "-m")
+		p.RegisterBool("--sign", "-s")
+		p.RegisterBool("--push")
+		p.RegisterValue("--next")
+	case "delete":
+		p.RegisterBool("--remote")
+	default:
+		return
+	}
+
+	rest, err := p.Parse(args.Params[1:])
+	if err != nil {
+		utils.Check(command.UsageError(err.Error()))
+	}
+
+	switch args.FirstParam() {
+	case "list":
+		listTags(command, args, p, rest)
+	case "create":
+		createTag(command, args, p, rest)
+	case "delete":
+		deleteTags(command, args, p, rest)
+	}
+}
+
+func listTags(command *Command, args *Args, p *utils.ArgsParser, rest []string) {
+	if len(rest) > 0 {
+		utils.Check(command.UsageError(""))
+	}
+	sortKey := p.Value("--sort")
+	if sortKey != "" && sortKey != "name" && sortKey != "semver" {
+		utils.Check(command.UsageError(fmt.Sprintf("invalid sort key: '%s'", sortKey)))
+	}
+
+	args.NoForward()
+	tags, err := git.Tags()
+	utils.Check(err)
+	if sortKey == "semver" {
+		tags = sortTagsBySemver(tags)
+	}
+	for _, name := range tags {
+		ui.Println(name)
+	}
+}
+
+func createTag(command *Command, args *Args, p *utils.ArgsParser, rest []string) {
+	var name string
+	if p.HasReceived("--next") {
+		if len(rest) > 1 {
+			utils.Check(command.UsageError("--next can't be combined with a tag name"))
+		}
+		tags, err := git.Tags()
+		utils.Check(err)
+		name, err = nextVersionTag(tags, p.Value("--next"))
+		utils.Check(err)
+	} else {
+		if len(rest) == 0 || len(rest) > 2 {
+			utils.Check(command.UsageError(""))
+		}
+		name, rest = rest[0], rest[1:]
+	}
+
+	tagArgs := []string{}
+	if p.Bool("--sign") {
+		tagArgs = append(tagArgs, "--sign")
+	} else if p.HasReceived("--message") {
+		tagArgs = append(tagArgs, "--annotate")
+	}
+	if p.HasReceived("--message") {
+		tagArgs = append(tagArgs, "--message", p.Value("--message"))
+	}
+	tagArgs = append(tagArgs, name)
+	tagArgs = append(tagArgs, rest...)
+	args.Replace("git", "tag", tagArgs...)
+
+	if p.Bool("--push") {
+		args.After("git", "push", mainRemoteName(), "refs/tags/"+name)
+	}
+	if p.HasReceived("--next") {
+		args.AfterFn(func() error {
+			ui.Println(name)
+			return nil
+		})
+	}
+}
+
+func deleteTags(command *Command, args *Args, p *utils.ArgsParser, rest []string) {
+	if len(rest) == 0 {
+		utils.Check(command.UsageError(""))
+	}
+	remote := p.Bool("--remote")
+
+	local := []string{}
+	for _, name := range rest {
+		if git.Quiet("rev-parse", "--verify", "--quiet", "refs/tags/"+name) {
+			local = append(local, name)
+		} else if !remote {
+			utils.Check(fmt.Errorf("tag '%s' not found", name))
+		}
+	}
+
+	if len(local) > 0 {
+		args.Replace("git", "tag", append([]string{"--delete"}, local...)...)
+	} else {
+		args.NoForward()
+	}
+
+	if remote {
+		refs := []string{}
+		for _, name := range rest {
+			refs = append(refs, "refs/tags/"+name)
+		}
+		args.After(append([]string{"git", "push", mainRemoteName(), "--delete"}, refs...)...)
+	}
+}
+
+func mainRemoteName() string {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	remote, err := localRepo.MainRemote()
+	utils.Check(err)
+	return remote.Name
+}
+
+var semverRegexp = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// semver is a semantic version as described by https://semver.org, parsed from
+// a tag name that may have a "v" prefix.
+type semver struct {
+	prefix     string
+	major      int
+	minor      int
+	patch      int
+	prerelease []string
+}
+
+func parseSemver(name string) (*semver, bool) {
+	m := semverRegexp.FindStringSubmatch(name)
+	if m == nil {
+		return nil, false
+	}
+	v := &semver{prefix: m[1]}
+	v.major, _ = strconv.Atoi(m[2])
+	v.minor, _ = strconv.Atoi(m[3])
+	v.patch, _ = strconv.Atoi(m[4])
+	if m[5] != "" {
+		v.prerelease = strings.Split(m[5], ".")
+	}
+	return v, true
+}
+
+func (v *semver) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.prefix, v.major, v.minor, v.patch)
+	if len(v.prerelease) > 0 {
+		s += "-" + strings.Join(v.prerelease, ".")
+	}
+	return s
+}
+
+// less reports whether v has a lower precedence than other.
+func (v *semver) less(other *semver) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+	if v.patch != other.patch {
+		return v.patch < other.patch
+	}
+	// A pre-release version comes before the release itself.
+	if len(v.prerelease) == 0 || len(other.prerelease) == 0 {
+		return len(v.prerelease) > len(other.prerelease)
+	}
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		a, b := v.prerelease[i], other.prerelease[i]
+		if a == b {
+			continue
+		}
+		an, aErr := strconv.Atoi(a)
+		bn, bErr := strconv.Atoi(b)
+		switch {
+		case aErr == nil && bErr == nil:
+			return an < bn
+		case aErr == nil || bErr == nil:
+			// Numeric identifiers come before alphanumeric ones.
+			return aErr == nil
+		default:
+			return a < b
+		}
+	}
+	return len(v.prerelease) < len(other.prerelease)
+}
+
+// sortTagsBySemver orders tags that are semantic versions from the newest to
+// the oldest, followed by the other tags by name.
+func sortTagsBySemver(tags []string) []string {
+	versions := map[string]*semver{}
+	others := []string{}
+	sorted := []string{}
+	for _, name := range tags {
+		if v, ok := parseSemver(name); ok {
+			versions[name] = v
+			sorted = append(sorted, name)
+		} else {
+			others = append(others, name)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return versions[sorted[j]].less(versions[sorted[i]])
+	})
+	sort.Strings(others)
+	return append(sorted, others...)
+}
+
+// nextVersionTag returns the version that follows the newest release among
+// tags when increasing its part "major", "minor", or "patch".
+func nextVersionTag(tags []string, part string) (string, error) {
+	latest := &semver{prefix: "v"}
+	for _, name := range tags {
+		if v, ok := parseSemver(name); ok && len(v.prerelease) == 0 && latest.less(v) {
+			latest = v
+		}
+	}
+
+	next := &semver{prefix: latest.prefix}
+	switch part {
+	case "major":
+		next.major = latest.major + 1
+	case "minor":
+		next.major, next.minor = latest.major, latest.minor+1
+	case "patch":
+		next.major, next.minor, next.patch = latest.major, latest.minor, latest.patch+1
+	default:
+		return "", fmt.Errorf("invalid version part: '%s'", part)
+	}
+	return next.String(), nil
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestSortTagsBySemver(t *testing.T) {
+	tags := []string{"v1.2.3", "latest", "v1.10.0", "v1.10.0-rc.2", "v1.10.0-rc.10", "v1.10.0-beta", "1.9.0", "v2.0.0+build.5"}
+	assert.Equal(t, []string{
+		"v2.0.0+build.5",
+		"v1.10.0",
+		"v1.10.0-rc.10",
+		"v1.10.0-rc.2",
+		"v1.10.0-beta",
+		"1.9.0",
+		"v1.2.3",
+		"latest",
+	}, sortTagsBySemver(tags))
+}
+
+func TestNextVersionTag(t *testing.T) {
+	tags := []string{"v1.2.3", "v1.10.0", "v2.0.0-rc.1", "nightly"}
+
+	next, err := nextVersionTag(tags, "patch")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "v1.10.1", next)
+
+	next, _ = nextVersionTag(tags, "minor")
+	assert.Equal(t, "v1.11.0", next)
+
+	next, _ = nextVersionTag(tags, "major")
+	assert.Equal(t, "v2.0.0", next)
+
+	next, _ = nextVersionTag([]string{"0.3.1"}, "minor")
+	assert.Equal(t, "0.4.0", next)
+
+	next, _ = nextVersionTag([]string{}, "patch")
+	assert.Equal(t, "v0.0.1", next)
+
+	_, err = nextVersionTag(tags, "build")
+	assert.Equal(t, "invalid version part: 'build'", err.Error())
+}
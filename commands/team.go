@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdTeam = &Command{
+		Run: printHelp,
+		Usage: `
+team list [-L <LIMIT>] [<ORG>]
+team members [--role <ROLE>] [-L <LIMIT>] <ORG>/<TEAM>
+team add [--maintainer] <ORG>/<TEAM> <USER>...
+team remove <ORG>/<TEAM> <USER>...
+`,
+		Long: `Manage the teams of a GitHub organization and their members.
+
+## Commands:
+
+	* _list_:
+		List the teams of <ORG> as "<ORG>/<TEAM>  <NAME>" lines.
+
+	* _members_:
+		List the logins of the members of a team, one per line.
+
+	* _add_:
+		Add each <USER> to a team. Users who aren't members of the organization
+		yet are invited to join it, and become team members once they accept.
+
+	* _remove_:
+		Remove each <USER> from a team. They stay members of the organization.
+
+## Options:
+	--role <ROLE>
+		Only list team members with <ROLE>: "maintainer" or "member" (default:
+		"all").
+
+	--maintainer
+		Add users as team maintainers, who can manage the team, instead of as
+		regular members.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> teams or members.
+
+	<ORG>
+		The login of the organization (default: the owner of the repository of
+		the current directory).
+
+	<TEAM>
+		The slug of a team, as in "@<ORG>/<TEAM>" mentions.
+
+## Examples:
+		$ hub team list github
+		$ hub team members github/hubbers
+		$ hub team add github/hubbers mislav octocat
+
+## See also:
+
+hub-org(1), hub(1)
+`,
+	}
+
+	cmdListTeams = &Command{
+		Key: "list",
+		Run: listTeams,
+		KnownFlags: `
+		-L, --limit N
+`,
+	}
+
+	cmdTeamMembers = &Command{
+		Key: "members",
+		Run: listTeamMembers,
+		KnownFlags: `
+		--role ROLE
+		-L, --limit N
+`,
+	}
+
+	cmdAddTeamMember = &Command{
+		Key: "add",
+		Run: addTeamMembers,
+		KnownFlags: `
+		--maintainer
+`,
+	}
+
+	cmdRemoveTeamMember = &Command{
+		Key: "remove",
+		Run: removeTeamMembers,
+	}
+)
+
+func init() {
+	cmdTeam.Use(cmdListTeams)
+	cmdTeam.Use(cmdTeamMembers)
+	cmdTeam.Use(cmdAddTeamMember)
+	cmdTeam.Use(cmdRemoveTeamMember)
+	CmdRunner.Use(cmdTeam)
+}
+
+// teamFromArgs parses the "<ORG>/<TEAM>" parameter of team subcommands.
+func teamFromArgs(cmd *Command, args *Args) (org, team string, gh *github.Client) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(args.GetParam(0), "@"), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		utils.Check(fmt.Errorf("invalid team: '%s' (expected <ORG>/<TEAM>)", args.GetParam(0)))
+	}
+
+	host, err := github.CurrentConfig().DefaultHost()
+	if err != nil {
+		utils.Check(github.FormatError("fetching team", err))
+	}
+	return parts[0], parts[1], github.NewClient(host.Host)
+}
+
+func listTeams(cmd *Command, args *Args) {
+	org, host := orgFromArgs(cmd, args, 0)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of teams of %s\n", org)
+		return
+	}
+
+	gh := github.NewClient(host)
+	teams, err := gh.Teams(org, args.Flag.Int("--limit"))
+	utils.Check(err)
+
+	slugWidth := 0
+	for _, team := range teams {
+		if width := len(org) + 1 + len(team.Slug); width > slugWidth {
+			slugWidth = width
+		}
+	}
+	for _, team := range teams {
+		ui.Printf("%-*s  %s\n", slugWidth, org+"/"+team.Slug, team.Name)
+	}
+}
+
+func listTeamMembers(cmd *Command, args *Args) {
+	if args.ParamsSize() > 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	org, team, gh := teamFromArgs(cmd, args)
+
+	role := "all"
+	if args.Flag.HasReceived("--role") {
+		role = args.Flag.Value("--role")
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of members of %s/%s\n", org, team)
+		return
+	}
+
+	members, err := gh.TeamMembers(org, team, role, args.Flag.Int("--limit"))
+	utils.Check(err)
+	for _, member := range members {
+		ui.Println(member.Login)
+	}
+}
+
+func addTeamMembers(cmd *Command, args *Args) {
+	if args.ParamsSize() < 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+	org, team, gh := teamFromArgs(cmd, args)
+
+	role := "member"
+	if args.Flag.Bool("--maintainer") {
+		role = "maintainer"
+	}
+
+	args.NoForward()
+	for _, user := range args.Params[1:] {
+		user = strings.TrimPrefix(user, "@")
+		if args.Noop {
+			ui.Printf("Would add %s to %s/%s as %s\n", user, org, team, role)
+			continue
+		}
+
+		state, err := gh.AddTeamMember(org, team, user, role)
+		utils.Check(err)
+		if state == "pending" {
+			ui.Infof("Invited %s to %s; they will join %s/%s once they accept\n", user, org, org, team)
+		} else {
+			ui.Infof("Added %s to %s/%s as %s\n", user, org, team, role)
+		}
+	}
+}
+
+func removeTeamMembers(cmd *Command, args *Args) {
+	if args.ParamsSize() < 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+	org, team, gh := teamFromArgs(cmd, args)
+
+	args.NoForward()
+	for _, user := range args.Params[1:] {
+		user = strings.TrimPrefix(user, "@")
+		if args.Noop {
+			ui.Printf("Would remove %s from %s/%s\n", user, org, team)
+			continue
+		}
+
+		utils.Check(gh.RemoveTeamMember(org, team, user))
+		ui.Infof("Removed %s from %s/%s\n", user, org, team)
+	}
+}
@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdTraffic = &Command{
+		Run: printHelp,
+		Usage: `
+traffic views [--days <DAYS>] [--json] [<OWNER>/<REPO>]
+traffic clones [--days <DAYS>] [--json] [<OWNER>/<REPO>]
+traffic paths [--json] [<OWNER>/<REPO>]
+traffic referrers [--json] [<OWNER>/<REPO>]
+`,
+		Long: `Report on the traffic of a repository.
+
+GitHub only keeps traffic data for 14 days, so keeping track of trends takes
+collecting it regularly, for instance by running 'hub traffic views --json'
+from a cron job. Reading traffic data requires push access to the repository.
+
+## Commands:
+
+	* _views_:
+		List the daily views of the repository as "<DATE>  <COUNT>  <UNIQUES>"
+		lines, where <UNIQUES> is the number of unique visitors.
+
+	* _clones_:
+		List the daily clones of the repository as "<DATE>  <COUNT>  <UNIQUES>"
+		lines, where <UNIQUES> is the number of unique cloners.
+
+	* _paths_:
+		List the 10 most viewed paths of the last 14 days as
+		"<COUNT>  <UNIQUES>  <PATH>" lines.
+
+	* _referrers_:
+		List the 10 sites that referred the most visitors in the last 14 days as
+		"<COUNT>  <UNIQUES>  <REFERRER>" lines.
+
+## Options:
+	--days <DAYS>
+		Only list views or clones of the last <DAYS> days, including today
+		(default: 14).
+
+	--json
+		Output the traffic data as a JSON array instead of a table.
+
+	<OWNER>/<REPO>
+		The repository to report on (default: the current repository).
+
+## Examples:
+		$ hub traffic views --days 7
+		$ hub traffic clones --json github/hub >> clones.jsonl
+
+## See also:
+
+hub-community(1), hub-repo(1), hub(1)
+`,
+	}
+
+	cmdTrafficViews = &Command{
+		Key: "views",
+		Run: trafficCounts,
+		KnownFlags: `
+		--days N
+		--json
+`,
+	}
+
+	cmdTrafficClones = &Command{
+		Key: "clones",
+		Run: trafficCounts,
+		KnownFlags: `
+		--days N
+		--json
+`,
+	}
+
+	cmdTrafficPaths = &Command{
+		Key: "paths",
+		Run: trafficPaths,
+		KnownFlags: `
+		--json
+`,
+	}
+
+	cmdTrafficReferrers = &Command{
+		Key: "referrers",
+		Run: trafficReferrers,
+		KnownFlags: `
+		--json
+`,
+	}
+)
+
+func init() {
+	cmdTraffic.Use(cmdTrafficViews)
+	cmdTraffic.Use(cmdTrafficClones)
+	cmdTraffic.Use(cmdTrafficPaths)
+	cmdTraffic.Use(cmdTrafficReferrers)
+	CmdRunner.Use(cmdTraffic)
+}
+
+// optionalProjectFromArgs resolves the optional <OWNER>/<REPO> parameter of
+// commands that report on a repository.
+func optionalProjectFromArgs(cmd *Command, args *Args) *github.Project {
+	if args.ParamsSize() > 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	return repoProjectFromArgs(args, 0)
+}
+
+// printJSONIfRequested prints v as JSON if the --json flag was passed, and
+// reports whether it did.
+func printJSONIfRequested(args *Args, v interface{}) bool {
+	if !args.Flag.Bool("--json") {
+		return false
+	}
+	out, err := encodeJSON(v)
+	utils.Check(err)
+	ui.Println(string(out))
+	return true
+}
+
+func trafficCounts(cmd *Command, args *Args) {
+	project := optionalProjectFromArgs(cmd, args)
+
+	days := 14
+	if args.Flag.HasReceived("--days") {
+		days = args.Flag.Int("--days")
+		if days < 1 || days > 14 {
+			utils.Check(fmt.Errorf("invalid number of days: %d (expected 1 to 14)", days))
+		}
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request traffic %s for %s\n", cmd.Key, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	var counts []github.TrafficCount
+	var err error
+	if cmd.Key == "clones" {
+		counts, err = gh.TrafficClones(project)
+	} else {
+		counts, err = gh.TrafficViews(project)
+	}
+	utils.Check(err)
+	counts = recentTrafficCounts(counts, days, time.Now())
+
+	if printJSONIfRequested(args, counts) {
+		return
+	}
+
+	table := ui.NewTable(ui.TableColumn{}, ui.TableColumn{AlignRight: true}, ui.TableColumn{AlignRight: true})
+	for _, count := range counts {
+		table.AddRow(
+			ui.TableCell{Text: count.Timestamp.UTC().Format("2006-01-02")},
+			ui.TableCell{Text: strconv.Itoa(count.Count)},
+			ui.TableCell{Text: strconv.Itoa(count.Uniques)},
+		)
+	}
+	utils.Check(table.Render(ui.Stdout))
+}
+
+// recentTrafficCounts keeps the counts of the last days up to and including
+// the day of now. GitHub counts traffic by days in UTC.
+func recentTrafficCounts(counts []github.TrafficCount, days int, now time.Time) []github.TrafficCount {
+	now = now.UTC()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1-days)
+	recent := []github.TrafficCount{}
+	for _, count := range counts {
+		if !count.Timestamp.Before(since) {
+			recent = append(recent, count)
+		}
+	}
+	return recent
+}
+
+func trafficPaths(cmd *Command, args *Args) {
+	project := optionalProjectFromArgs(cmd, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request popular paths for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	paths, err := gh.TrafficPaths(project)
+	utils.Check(err)
+
+	if printJSONIfRequested(args, paths) {
+		return
+	}
+
+	table := ui.NewTable(ui.TableColumn{AlignRight: true}, ui.TableColumn{AlignRight: true}, ui.TableColumn{})
+	for _, path := range paths {
+		table.AddRow(
+			ui.TableCell{Text: strconv.Itoa(path.Count)},
+			ui.TableCell{Text: strconv.Itoa(path.Uniques)},
+			ui.TableCell{Text: path.Path},
+		)
+	}
+	utils.Check(table.Render(ui.Stdout))
+}
+
+func trafficReferrers(cmd *Command, args *Args) {
+	project := optionalProjectFromArgs(cmd, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request referrers for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	referrers, err := gh.TrafficReferrers(project)
+	utils.Check(err)
+
+	if printJSONIfRequested(args, referrers) {
+		return
+	}
+
+	table := ui.NewTable(ui.TableColumn{AlignRight: true}, ui.TableColumn{AlignRight: true}, ui.TableColumn{})
+	for _, referrer := range referrers {
+		table.AddRow(
+			ui.TableCell{Text: strconv.Itoa(referrer.Count)},
+			ui.TableCell{Text: strconv.Itoa(referrer.Uniques)},
+			ui.TableCell{Text: referrer.Referrer},
+		)
+	}
+	utils.Check(table.Render(ui.Stdout))
+}
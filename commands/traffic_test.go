@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+	"github.com/github/hub/github"
+)
+
+func TestRecentTrafficCounts(t *testing.T) {
+	day := func(date string) github.TrafficCount {
+		timestamp, _ := time.Parse("2006-01-02", date)
+		return github.TrafficCount{Timestamp: timestamp}
+	}
+	counts := []github.TrafficCount{day("2020-03-01"), day("2020-03-08"), day("2020-03-09"), day("2020-03-10")}
+	now := time.Date(2020, 3, 10, 23, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, counts, recentTrafficCounts(counts, 14, now))
+	assert.Equal(t, counts[2:], recentTrafficCounts(counts, 2, now))
+	assert.Equal(t, counts[3:], recentTrafficCounts(counts, 1, now.In(time.FixedZone("CET", 3600))))
+}
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	cmdUser = &Command{
+		Run: printHelp,
+		Usage: `
+user view [<LOGIN>]
+user repos [--sort <KEY>] [--forks] [-f <FORMAT>] [-L <LIMIT>] [<LOGIN>]
+user gists [-L <LIMIT>] [<LOGIN>]
+`,
+		Long: `Inspect the profile of a GitHub user or organization.
+
+## Commands:
+
+	* _view_:
+		Show the profile of <LOGIN>, along with the fingerprints of the public SSH
+		keys of a user.
+
+	* _repos_:
+		List the repositories owned by <LOGIN>. Your own private repositories are
+		listed too.
+
+	* _gists_:
+		List the public gists of <LOGIN>, or all of your own gists, as
+		"<ID>  <DESCRIPTION>  (<FILES>, <VISIBILITY>)" lines.
+
+## Options:
+	--sort <KEY>
+		Sort repositories by <KEY>: "created", "updated", "pushed", or
+		"full_name" (default).
+
+	--forks
+		List only repositories that are forks, such as to find a colleague's fork
+		of a project.
+
+	-f, --format <FORMAT>
+		Pretty print repositories using <FORMAT> (default: "%N%  d%n"). See the
+		"--format" option of hub-search(1) for the placeholders of repositories.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> repositories or gists.
+
+	<LOGIN>
+		The login of a user or an organization (default: you). "@me" also stands
+		for you.
+
+## Examples:
+		$ hub user view mislav
+		$ hub user repos --sort pushed -L 10
+		$ hub user repos --forks mislav
+
+## See also:
+
+hub-org(1), hub-ssh-key(1), hub-gist(1), hub(1)
+`,
+	}
+
+	cmdUserView = &Command{
+		Key: "view",
+		Run: viewUser,
+	}
+
+	cmdUserRepos = &Command{
+		Key: "repos",
+		Run: listUserRepos,
+		KnownFlags: `
+		--sort KEY
+		--forks
+		-f, --format FORMAT
+		-L, --limit N
+`,
+	}
+
+	cmdUserGists = &Command{
+		Key: "gists",
+		Run: listUserGists,
+		KnownFlags: `
+		-L, --limit N
+`,
+	}
+)
+
+func init() {
+	cmdUser.Use(cmdUserView)
+	cmdUser.Use(cmdUserRepos)
+	cmdUser.Use(cmdUserGists)
+	CmdRunner.Use(cmdUser)
+}
+
+// userFromArgs returns the <LOGIN> parameter, which is empty for the
+// authenticated user.
+func userFromArgs(cmd *Command, args *Args) string {
+	if args.ParamsSize() > 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	if args.IsParamsEmpty() || args.FirstParam() == "@me" {
+		return ""
+	}
+
+	login := args.FirstParam()
+	if !regexp.MustCompile(fmt.Sprintf("^%s$", OwnerRe)).MatchString(login) {
+		utils.Check(fmt.Errorf("invalid user name: '%s'", login))
+	}
+	return login
+}
+
+// userDescription names login in messages, where an empty login is you.
+func userDescription(login string) string {
+	if login == "" {
+		return "you"
+	}
+	return login
+}
+
+func viewUser(cmd *Command, args *Args) {
+	login := userFromArgs(cmd, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request the profile of %s\n", userDescription(login))
+		return
+	}
+
+	gh := accountClient("fetching user")
+	profile, err := gh.UserProfile(login)
+	utils.Check(err)
+
+	var keys []github.SSHKey
+	if !profile.IsOrganization() {
+		keys, err = gh.UserSSHKeys(profile.Login)
+		utils.Check(err)
+	}
+
+	ui.Print(formatProfile(profile, keys))
+}
+
+// formatProfile describes profile with a line for each of the fields that are
+// filled in, followed by the fingerprints of the SSH keys.
+func formatProfile(profile *github.Profile, keys []github.SSHKey) string {
+	heading := profile.Login
+	if profile.Name != "" {
+		heading = fmt.Sprintf("%s (%s)", profile.Login, profile.Name)
+	}
+	if profile.IsOrganization() {
+		heading += " [organization]"
+	}
+	lines := []string{heading}
+
+	field := func(label, value string) {
+		if value != "" {
+			lines = append(lines, fmt.Sprintf("%-11s %s", label+":", value))
+		}
+	}
+	field("Bio", profile.Bio)
+	field("Company", profile.Company)
+	field("Location", profile.Location)
+	field("Email", profile.Email)
+	field("Blog", profile.Blog)
+	field("Repos", fmt.Sprintf("%d public", profile.PublicRepos))
+	field("Gists", fmt.Sprintf("%d public", profile.PublicGists))
+	if !profile.IsOrganization() {
+		field("Followers", fmt.Sprintf("%d, following %d", profile.Followers, profile.Following))
+	}
+	if !profile.CreatedAt.IsZero() {
+		field("Joined", profile.CreatedAt.Format("2006-01-02"))
+	}
+	field("URL", profile.HtmlUrl)
+
+	if len(keys) > 0 {
+		lines = append(lines, "", "SSH keys:")
+		for _, key := range keys {
+			lines = append(lines, "  "+sshKeyFingerprint(key.Key))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func listUserRepos(cmd *Command, args *Args) {
+	login := userFromArgs(cmd, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of repositories of %s\n", userDescription(login))
+		return
+	}
+
+	onlyForks := args.Flag.Bool("--forks")
+	gh := accountClient("fetching repositories")
+	repos, err := gh.UserRepositories(login, args.Flag.Value("--sort"), args.Flag.Int("--limit"), func(repo *github.Repository) bool {
+		return !onlyForks || repo.Fork
+	})
+	utils.Check(err)
+
+	format := "%N%  d%n"
+	if args.Flag.HasReceived("--format") {
+		format = args.Flag.Value("--format")
+	}
+	colorize := colorizeOutput(false, "")
+	for i := range repos {
+		repo := &repos[i]
+		ui.Print(ui.Expand(format, formatRepoPlaceholders(repo, repo.Topics), colorize))
+	}
+}
+
+func listUserGists(cmd *Command, args *Args) {
+	login := userFromArgs(cmd, args)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of gists of %s\n", userDescription(login))
+		return
+	}
+
+	gh := accountClient("fetching gists")
+	var gists []github.Gist
+	var err error
+	if login == "" {
+		gists, err = gh.FetchGists(args.Flag.Int("--limit"), nil)
+	} else {
+		gists, err = gh.UserGists(login, args.Flag.Int("--limit"))
+	}
+	utils.Check(err)
+
+	for _, gist := range gists {
+		ui.Println(formatGist(gist))
+	}
+}
@@ -2,14 +2,17 @@ package commands
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
-	"github.com/atotto/clipboard"
 	"github.com/github/hub/git"
+	"github.com/github/hub/github"
 	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
 )
@@ -104,7 +107,7 @@ func msgFromFile(filename string) (string, error) {
 
 func printBrowseOrCopy(args *Args, msg string, openBrowser bool, performCopy bool) {
 	if performCopy {
-		if err := clipboard.WriteAll(msg); err != nil {
+		if err := utils.CopyToClipboard(msg); err != nil {
 			ui.Errorf("Error copying %s to clipboard:\n%s\n", msg, err.Error())
 		}
 	}
@@ -123,3 +126,211 @@ func printBrowseOrCopy(args *Args, msg string, openBrowser bool, performCopy boo
 		})
 	}
 }
+
+// prefilledFormURL adds the non-empty fields to the URL of the web form for a
+// new issue or pull request, which GitHub uses to fill in the form.
+func prefilledFormURL(formURL string, fields map[string]string) string {
+	query := url.Values{}
+	for name, value := range fields {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	if len(query) == 0 {
+		return formURL
+	}
+	return formURL + "?" + query.Encode()
+}
+
+// resolveProject returns the project named by an "OWNER/REPO" argument, or
+// the main project of the current git repository if the name is empty.
+func resolveProject(nameWithOwner string) (*github.Project, error) {
+	if nameWithOwner != "" {
+		if !regexp.MustCompile(NameWithOwnerRe).MatchString(nameWithOwner) {
+			return nil, fmt.Errorf("invalid repository name: '%s'", nameWithOwner)
+		}
+		return github.NewProject("", nameWithOwner, ""), nil
+	}
+
+	localRepo, err := github.LocalRepo()
+	if err != nil {
+		return nil, err
+	}
+	return localRepo.MainProject()
+}
+
+// userFilter resolves the values of options that filter by user, where "@me"
+// stands for the authenticated user. Its login is requested at most once.
+type userFilter struct {
+	gh *github.Client
+	me string
+}
+
+// resolve returns the login named by value of flag, which may also be an
+// "ORG/TEAM" team if the API accepts teams for it.
+func (f *userFilter) resolve(flag, value string, teams bool) (string, error) {
+	if value == "@me" {
+		if f.me == "" {
+			user, err := f.gh.CurrentUser()
+			if err != nil {
+				return "", err
+			}
+			f.me = user.Login
+		}
+		return f.me, nil
+	}
+	if !teams && strings.Contains(value, "/") {
+		return "", fmt.Errorf("%s only accepts users, not teams: '%s'", flag, value)
+	}
+	return value, nil
+}
+
+// accountClient returns a client for the default host, for commands that act
+// on the authenticated account rather than on a repository.
+func accountClient(action string) *github.Client {
+	host, err := github.CurrentConfig().DefaultHost()
+	if err != nil {
+		utils.Check(github.FormatError(action, err))
+	}
+	return github.NewClient(host.Host)
+}
+
+// confirm prompts the user with a yes/no question and reports whether they
+// typed "yes" in response.
+func confirm(question string) bool {
+	ui.Printf("%s (yes/N)? ", question)
+	answer := ""
+	scanner := bufio.NewScanner(os.Stdin)
+	if scanner.Scan() {
+		answer = strings.TrimSpace(scanner.Text())
+	}
+	utils.Check(scanner.Err())
+	return answer == "yes"
+}
+
+// startPager sends the rest of the output of a command through a pager when
+// stdout is a terminal, the same way git does. The pager is the "pager"
+// setting, or else $PAGER, or else less. Setting it to "cat" or passing the
+// '--no-pager' global flag turns paging off.
+func startPager(args *Args) {
+	if args.NoPager || args.Noop || !ui.IsTerminal(os.Stdout) {
+		return
+	}
+
+	pager := github.ConfigSetting("pager")
+	if pager == "" {
+		pager = os.Getenv("PAGER")
+	}
+	if pager == "" {
+		pager = "less"
+	}
+	if pager == "cat" {
+		return
+	}
+	if err := ui.StartPager(pager); err != nil {
+		ui.Errorf("warning: can't start pager %q: %s\n", pager, err)
+	}
+}
+
+// formatBody formats the markdown of an issue, release, or discussion body for
+// reading in a terminal, unless stdout is not one or '--raw' was passed.
+func formatBody(args *Args, body string, colorize bool) string {
+	if args.Flag.Bool("--raw") || !ui.IsTerminal(os.Stdout) {
+		return body
+	}
+	return ui.RenderMarkdown(body, colorize)
+}
+
+// newBulkJob prepares a resumable bulk operation for commands that accept the
+// '--resume' flag. The job name should identify the operation and its target
+// so that resuming picks up the state of the right job.
+func newBulkJob(args *Args, name string) *github.BulkJob {
+	job, err := github.NewBulkJob(name, args.Flag.Bool("--resume"))
+	utils.Check(err)
+	return job
+}
+
+// printRecords prints list items in one of the structured formats accepted by
+// the '--output' flag of listing commands. The fields of columns, if given,
+// make the header of CSV and TSV even if the list is empty.
+func printRecords(format string, records []ui.Record, columns ui.Record) {
+	buf := &bytes.Buffer{}
+	utils.Check(ui.WriteRecordsWithColumns(buf, format, records, columns))
+	ui.Print(buf.String())
+}
+
+func userLogins(users []github.User) []string {
+	logins := []string{}
+	for _, user := range users {
+		logins = append(logins, user.Login)
+	}
+	return logins
+}
+
+func issueRecord(issue github.Issue) ui.Record {
+	labels := []string{}
+	for _, label := range issue.Labels {
+		labels = append(labels, label.Name)
+	}
+	author := ""
+	if issue.User != nil {
+		author = issue.User.Login
+	}
+	milestone := ""
+	if issue.Milestone != nil {
+		milestone = issue.Milestone.Title
+	}
+
+	return ui.Record{}.
+		With("number", issue.Number).
+		With("state", issue.State).
+		With("title", issue.Title).
+		With("url", issue.HtmlUrl).
+		With("author", author).
+		With("labels", labels).
+		With("assignees", userLogins(issue.Assignees)).
+		With("milestone", milestone).
+		With("comments", issue.Comments).
+		With("created_at", issue.CreatedAt).
+		With("updated_at", issue.UpdatedAt)
+}
+
+func labelRecord(label github.IssueLabel) ui.Record {
+	return ui.Record{}.With("name", label.Name).With("color", label.Color)
+}
+
+func pullRequestRecord(pr github.PullRequest) ui.Record {
+	placeholders := formatPullRequestPlaceholders(pr, false)
+	reviewers := userLogins(pr.RequestedReviewers)
+	for _, team := range pr.RequestedTeams {
+		reviewers = append(reviewers, fmt.Sprintf("%s/%s", pr.Base.Repo.Owner.Login, team.Slug))
+	}
+
+	record := ui.Record{}
+	for _, f := range issueRecord(github.Issue(pr)) {
+		switch f.Name {
+		case "state":
+			f.Value = placeholders["pS"]
+		case "comments":
+			continue
+		}
+		record = append(record, f)
+		if f.Name == "url" {
+			record = record.
+				With("draft", pr.Draft).
+				With("base", placeholders["B"]).
+				With("head", placeholders["H"])
+		} else if f.Name == "assignees" {
+			record = record.With("requested_reviewers", reviewers)
+		}
+	}
+	return record.With("merged_at", pr.MergedAt)
+}
+
+// pluralize returns word with an "s" appended unless n is 1.
+func pluralize(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/github/hub/github"
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var cmdWatch = &Command{
+	Run: watch,
+	Usage: `
+watch [--all-activity|--participating|--ignore|--releases-only] [<OWNER>/<REPO>]
+watch --show [<OWNER>/<REPO>]
+`,
+	Long: `Change which notifications you receive for a repository on GitHub.
+
+## Options:
+	--all-activity
+		Be notified of all conversations in the repository. This is the default.
+
+	--participating
+		Only be notified when participating or @mentioned, i.e. stop watching.
+
+	--ignore
+		Never be notified, not even when participating or @mentioned.
+
+	--releases-only
+		Only be notified of new releases. The GitHub API doesn't support this
+		setting, so hub aborts with the URL of the web page to change it on.
+
+	--show
+		Print the current notification setting.
+
+	<OWNER>/<REPO>
+		The repository to change notifications for (default: the current
+		repository).
+
+## Examples:
+		$ hub watch
+		$ hub watch --ignore github/hub
+		$ hub watch --show
+
+## See also:
+
+hub-star(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdWatch)
+}
+
+func watch(command *Command, args *Args) {
+	if args.ParamsSize() > 1 {
+		utils.Check(command.UsageError(""))
+	}
+	nameWithOwner := ""
+	if !args.IsParamsEmpty() {
+		nameWithOwner = args.FirstParam()
+	}
+	project, err := resolveProject(nameWithOwner)
+	utils.Check(err)
+
+	modes := []string{}
+	for _, flag := range []string{"--all-activity", "--participating", "--ignore", "--releases-only", "--show"} {
+		if args.Flag.Bool(flag) {
+			modes = append(modes, flag)
+		}
+	}
+	if len(modes) > 1 {
+		utils.Check(command.UsageError(fmt.Sprintf("%s and %s are mutually exclusive", modes[0], modes[1])))
+	}
+	mode := "--all-activity"
+	if len(modes) == 1 {
+		mode = modes[0]
+	}
+
+	gh := github.NewClient(project.Host)
+	args.NoForward()
+
+	switch mode {
+	case "--show":
+		subscription, err := gh.RepositorySubscription(project)
+		utils.Check(err)
+		ui.Println(subscriptionDescription(subscription))
+		return
+	case "--releases-only":
+		utils.Check(fmt.Errorf("Aborted: the GitHub API doesn't support watching only releases; change it at %s",
+			project.WebURL("", "", "")))
+	}
+
+	if args.Noop {
+		ui.Printf("Would change notifications for %s to %s\n", project, mode[2:])
+		return
+	}
+
+	var subscription *github.Subscription
+	switch mode {
+	case "--all-activity":
+		subscription = &github.Subscription{Subscribed: true}
+		utils.Check(gh.SetRepositorySubscription(project, subscription))
+	case "--ignore":
+		subscription = &github.Subscription{Ignored: true}
+		utils.Check(gh.SetRepositorySubscription(project, subscription))
+	case "--participating":
+		utils.Check(gh.DeleteRepositorySubscription(project))
+	}
+	ui.Printf("%s: %s\n", project, subscriptionDescription(subscription))
+}
+
+func subscriptionDescription(subscription *github.Subscription) string {
+	if subscription == nil {
+		return "participating and @mentions"
+	} else if subscription.Ignored {
+		return "ignoring"
+	} else if subscription.Subscribed {
+		return "all activity"
+	}
+	return "participating and @mentions"
+}
@@ -0,0 +1,41 @@
+Feature: hub --account
+  Background:
+    Given I am in "git://github.com/work-org/project.git" git repo
+    And I am "mislav" on github.com with OAuth token "PERSONAL"
+    And I am also "mislav-work" on github.com with OAuth token "WORK" in profile "work" for owner "work-org"
+
+  Scenario: Account selected by repository owner
+    Given the GitHub API server:
+      """
+      get('/repos/work-org/project/issues') {
+        halt 401 unless request.env['HTTP_AUTHORIZATION'] == 'token WORK'
+        json []
+      }
+      """
+    When I successfully run `hub issue`
+    Then the output should not contain anything
+
+  Scenario: Account selected explicitly
+    Given the "origin" remote has url "git://github.com/mislav/dotfiles.git"
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/issues') {
+        halt 401 unless request.env['HTTP_AUTHORIZATION'] == 'token WORK'
+        json []
+      }
+      """
+    When I successfully run `hub --account work issue`
+    Then the output should not contain anything
+
+  Scenario: Account selected through the environment
+    Given the "origin" remote has url "git://github.com/mislav/dotfiles.git"
+    And $HUB_PROFILE is "work"
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/issues') {
+        halt 401 unless request.env['HTTP_AUTHORIZATION'] == 'token WORK'
+        json []
+      }
+      """
+    When I successfully run `hub issue`
+    Then the output should not contain anything
@@ -0,0 +1,88 @@
+Feature: hub advisory
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List security advisories
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/security-advisories') {
+        assert :state => "published", :per_page => "100"
+        json [
+          { :ghsa_id => "GHSA-xvch-5gv4-984h", :severity => "high", :state => "published", :summary => "Command injection" },
+          { :ghsa_id => "GHSA-2222-3333-4444", :severity => "low", :state => "published", :summary => "Token in logs" },
+        ]
+      }
+      """
+    When I successfully run `hub advisory list --state published`
+    Then the output should contain exactly:
+      """
+      GHSA-xvch-5gv4-984h  high  published  Command injection
+      GHSA-2222-3333-4444  low   published  Token in logs\n
+      """
+
+  Scenario: View a security advisory
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/security-advisories/GHSA-xvch-5gv4-984h') {
+        json :ghsa_id => "GHSA-xvch-5gv4-984h", :cve_id => "CVE-2020-1234",
+          :severity => "high", :state => "published",
+          :summary => "Command injection", :description => "Remote names are not escaped.",
+          :published_at => "2020-01-02T03:04:05Z",
+          :vulnerabilities => [{
+            :package => { :ecosystem => "go", :name => "github.com/github/hub" },
+            :vulnerable_version_range => "< 2.14.2", :patched_versions => "2.14.2",
+          }]
+      }
+      """
+    When I successfully run `hub advisory view GHSA-xvch-5gv4-984h`
+    Then the output should contain exactly:
+      """
+      # Command injection
+
+      * advisory: GHSA-xvch-5gv4-984h (CVE-2020-1234)
+      * severity: high
+      * state: published
+      * published on 2020-01-02 03:04:05 +0000 UTC
+      * go/github.com/github/hub: < 2.14.2 (patched: 2.14.2)
+
+      Remote names are not escaped.\n
+      """
+
+  Scenario: View a Dependabot alert
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/dependabot/alerts/12') {
+        json :number => 12, :state => "open",
+          :dependency => { :package => { :ecosystem => "pip", :name => "django" }, :manifest_path => "requirements.txt" },
+          :security_advisory => { :ghsa_id => "GHSA-2222-3333-4444", :summary => "SQL injection", :description => "Details.", :severity => "critical" },
+          :security_vulnerability => { :vulnerable_version_range => "< 3.0", :first_patched_version => { :identifier => "3.0" } },
+          :html_url => "https://github.com/github/hub/security/dependabot/12"
+      }
+      """
+    When I successfully run `hub advisory view 12`
+    Then the output should contain exactly:
+      """
+      # SQL injection
+
+      * alert #12: open
+      * package: pip/django in requirements.txt
+      * vulnerable: < 3.0 (patched: 3.0)
+      * advisory: GHSA-2222-3333-4444
+      * severity: critical
+      * https://github.com/github/hub/security/dependabot/12
+
+      Details.\n
+      """
+
+  Scenario: Dependabot alert not found
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/dependabot/alerts/13') { status 404 }
+      """
+    When I run `hub advisory view 13`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Unable to find Dependabot alert 13 in github/hub\n
+      """
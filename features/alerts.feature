@@ -0,0 +1,106 @@
+Feature: hub alerts
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List Dependabot alerts
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/dependabot/alerts') {
+        assert :state => "open", :severity => nil, :per_page => "100"
+        json [
+          { :number => 3, :state => "open", :html_url => "https://github.com/github/hub/security/dependabot/3",
+            :dependency => { :package => { :ecosystem => "npm", :name => "lodash" } },
+            :security_advisory => { :summary => "Prototype pollution", :severity => "critical" } },
+          { :number => 12, :state => "open", :html_url => "https://github.com/github/hub/security/dependabot/12",
+            :dependency => { :package => { :ecosystem => "pip", :name => "django" } },
+            :security_advisory => { :summary => "SQL injection", :severity => "high" } },
+        ]
+      }
+      """
+    When I successfully run `hub alerts list --state open`
+    Then the output should contain exactly:
+      """
+       #3  critical  open  Prototype pollution  (npm/lodash)
+      #12  high      open  SQL injection  (pip/django)\n
+      """
+
+  Scenario: List alerts of multiple types
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/code-scanning/alerts') {
+        json [
+          { :number => 7, :state => "open", :html_url => "https://github.com/github/hub/security/code-scanning/7",
+            :rule => { :severity => "error", :security_severity_level => "medium", :description => "Reflected XSS" },
+            :most_recent_instance => { :location => { :path => "app.js", :start_line => 10 } } },
+        ]
+      }
+      get('/repos/github/hub/secret-scanning/alerts') {
+        assert :severity => nil
+        json [
+          { :number => 1, :state => "open", :html_url => "https://github.com/github/hub/security/secret-scanning/1",
+            :secret_type_display_name => "GitHub Personal Access Token" },
+        ]
+      }
+      """
+    When I successfully run `hub alerts list -t code-scanning,secret-scanning`
+    Then the output should contain exactly:
+      """
+      code-scanning    #7  medium  open  Reflected XSS  (app.js:10)
+      secret-scanning  #1  -       open  Leaked secret  (GitHub Personal Access Token)\n
+      """
+
+  Scenario: Structured output
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/code-scanning/alerts') {
+        json [
+          { :number => 7, :state => "open", :html_url => "https://github.com/github/hub/security/code-scanning/7",
+            :rule => { :severity => "warning", :description => "Unused variable" },
+            :most_recent_instance => { :location => { :path => "app.js", :start_line => 10 } } },
+        ]
+      }
+      """
+    When I successfully run `hub alerts list -t code-scanning --output csv`
+    Then the output should contain exactly:
+      """
+      type,number,state,severity,summary,location,url
+      code-scanning,7,open,warning,Unused variable,app.js:10,https://github.com/github/hub/security/code-scanning/7\n
+      """
+
+  Scenario: Fail when there are matching alerts
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/dependabot/alerts') {
+        assert :state => "open", :severity => "critical"
+        json [
+          { :number => 3, :state => "open", :html_url => "https://github.com/github/hub/security/dependabot/3",
+            :dependency => { :package => { :ecosystem => "npm", :name => "lodash" } },
+            :security_advisory => { :summary => "Prototype pollution", :severity => "critical" } },
+        ]
+      }
+      """
+    When I run `hub alerts list --state open --severity critical --exit-status`
+    Then the exit status should be 1
+    And the output should contain exactly:
+      """
+      #3  critical  open  Prototype pollution  (npm/lodash)\n
+      """
+
+  Scenario: Succeed when there are no matching alerts
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/dependabot/alerts') {
+        json []
+      }
+      """
+    When I successfully run `hub alerts list --state open --severity critical --exit-status`
+    Then the output should contain exactly ""
+
+  Scenario: Invalid alert type
+    When I run `hub alerts list -t virus`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid alert type: 'virus' (expected one of: dependabot, code-scanning, secret-scanning)\n
+      """
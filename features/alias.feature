@@ -137,3 +137,53 @@ Feature: hub alias
       Error: couldn't detect shell type. Please specify your shell with `hub alias -s <shell>`\n
       """
     And the exit status should be 1
+
+  Scenario: set and list aliases
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+    When I successfully run `hub alias set co 'pr checkout $1'`
+    And I successfully run `hub alias set rl '!hub api repos/{owner}/{repo}/releases'`
+    And I successfully run `hub alias list`
+    Then the output should contain exactly:
+      """
+      co	pr checkout $1
+      rl	!hub api repos/{owner}/{repo}/releases\n
+      """
+
+  Scenario: alias with positional arguments
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And I successfully run `hub alias set bugs 'issue -l bug,$1'`
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues') {
+        assert :labels => "bug,ui", :assignee => "mislav"
+        json []
+      }
+      """
+    When I successfully run `hub bugs ui -a mislav`
+    Then the output should not contain anything
+
+  Scenario: shell alias
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+    And I successfully run `hub alias set hi '!echo "hello $1"'`
+    When I successfully run `hub hi world`
+    Then the output should contain exactly:
+      """
+      hello world\n
+      """
+
+  Scenario: alias can't override commands
+    When I run `hub alias set pr 'issue'`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: "pr" is already a command\n
+      """
+
+  Scenario: delete unknown alias
+    When I run `hub alias delete nope`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      no such alias: nope\n
+      """
@@ -19,6 +19,18 @@ Feature: hub am
     Then the output should not contain anything
     Then the latest commit message should be "Create a README"
 
+  Scenario: Apply commits from a comparison
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/compare/master...feature') {
+        halt 400 unless request.env['HTTP_ACCEPT'] == 'application/vnd.github.v3.patch;charset=utf-8'
+        halt 401 unless request.env['HTTP_AUTHORIZATION'] == 'token OTOKEN'
+        generate_patch "Create a README"
+      }
+      """
+    When I successfully run `hub am -q -3 https://github.com/mislav/dotfiles/compare/master...feature`
+    Then the latest commit message should be "Create a README"
+
   Scenario: Apply commits when TMPDIR is empty
     Given $TMPDIR is ""
     Given the GitHub API server:
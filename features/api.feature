@@ -34,6 +34,36 @@ Feature: hub api
       {"name":"Ed"}
       """
 
+  Scenario: GET Enterprise resource with --hostname
+    Given I am "octokitten" on git.my.org with OAuth token "FITOKEN"
+    Given the GitHub API server:
+      """
+      get('/api/v3/hello/world', :host_name => 'git.my.org') {
+        halt 401 unless request.env['HTTP_AUTHORIZATION'] == 'token FITOKEN'
+        json :name => "Ed"
+      }
+      """
+    When I successfully run `hub api --hostname git.my.org hello/world`
+    Then the output should contain exactly:
+      """
+      {"name":"Ed"}
+      """
+
+  Scenario: GET Enterprise resource by full URL
+    Given I am "octokitten" on git.my.org with OAuth token "FITOKEN"
+    Given the GitHub API server:
+      """
+      get('/api/v3/hello/world', :host_name => 'git.my.org') {
+        halt 401 unless request.env['HTTP_AUTHORIZATION'] == 'token FITOKEN'
+        json :name => "Ed"
+      }
+      """
+    When I successfully run `hub api https://git.my.org/api/v3/hello/world`
+    Then the output should contain exactly:
+      """
+      {"name":"Ed"}
+      """
+
   Scenario: Non-success response
     Given the GitHub API server:
       """
@@ -83,6 +113,36 @@ Feature: hub api
       """
     And the stderr should contain exactly ""
 
+  Scenario: Non-success response with fail-fast
+    Given the GitHub API server:
+      """
+      get('/hello/world') {
+        status 422
+        json :message => "Validation Failed",
+             :errors => [{ :code => "invalid", :field => "name" }]
+      }
+      """
+    When I run `hub api --fail-fast hello/world`
+    Then the exit status should be 22
+    And the stdout should contain exactly ""
+    And the stderr should contain exactly:
+      """
+      Error requesting hello/world: Unprocessable Entity (HTTP 422)
+      Invalid value for "name"\n
+      """
+
+  Scenario: Status only
+    Given the GitHub API server:
+      """
+      get('/hello/world') {
+        status 404
+        json :message => "Not Found"
+      }
+      """
+    When I run `hub api --status-only hello/world`
+    Then the exit status should be 22
+    And the stdout should contain exactly "404\n"
+
   Scenario: GET query string
     Given the GitHub API server:
       """
@@ -150,6 +210,126 @@ Feature: hub api
       {"data":{"pageInfo":{"hasNextPage":false,"endCursor":"4"}}}
       """
 
+  Scenario: Paginate REST with slurp
+    Given the GitHub API server:
+      """
+      get('/comments') {
+        page = (params[:page] || 1).to_i
+        response.headers["Link"] = %(<#{request.url}?page=#{page+1}>; rel="next") if page < 3
+        json [{:page => page}]
+      }
+      """
+    When I successfully run `hub api --paginate --slurp comments`
+    Then the output should contain exactly:
+      """
+      [{"page":1},{"page":2},{"page":3}]
+      """
+
+  Scenario: Paginate GraphQL with slurp
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        variables = params[:variables] || {}
+        page = (variables["endCursor"] || 1).to_i
+        json :data => { :repos => {
+          :nodes => [{ :page => page }],
+          :pageInfo => {
+            :hasNextPage => page < 2,
+            :endCursor => (page+1).to_s
+          }
+        } }
+      }
+      """
+    When I successfully run `hub api --paginate --slurp graphql -f query=QUERY`
+    Then the output should contain exactly:
+      """
+      {"data":{"repos":{"nodes":[{"page":1},{"page":2}],"pageInfo":{"endCursor":"3","hasNextPage":false}}}}
+      """
+
+  Scenario: Format response with a template
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues') {
+        json [
+          { :number => 1, :title => "Fix the thing" },
+          { :number => 1234, :title => "A much longer title" },
+        ]
+      }
+      """
+    When I successfully run `hub api repos/github/hub/issues --format '{{range .}}{{tablerow (printf "#%v" .number) (truncate 10 .title)}}{{end}}'`
+    Then the output should contain exactly:
+      """
+      #1     Fix the...
+      #1234  A much ...\n
+      """
+
+  Scenario: Show a spinner while waiting for a response in a terminal
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub') {
+        sleep 0.5
+        json :name => "hub"
+      }
+      """
+    When I run `hub --no-pager api repos/github/hub` in a terminal
+    Then the terminal output should contain:
+      """
+      ⠋ GET repos/github/hub ...
+      """
+    And the terminal output should contain:
+      """
+      \e[K{"name":"hub"}
+      """
+
+  Scenario: No spinner when stderr isn't a terminal
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub') {
+        sleep 0.5
+        json :name => "hub"
+      }
+      """
+    When I successfully run `hub api repos/github/hub`
+    Then the stderr should not contain "GET repos/github/hub"
+    And the stdout should contain exactly:
+      """
+      {"name":"hub"}
+      """
+
+  Scenario: Only align table rows in a template
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues') {
+        json [
+          { :number => 1, :title => "Fix the thing" },
+          { :number => 1234, :title => "A much longer title" },
+        ]
+      }
+      """
+    When I successfully run `hub api repos/github/hub/issues --format '{{printf "number\ttitle\n"}}{{range .}}{{tablerow (printf "#%v" .number) .title}}{{end}}'`
+    Then the output should contain exactly:
+      """
+      number	title
+      #1     Fix the thing
+      #1234  A much longer title\n
+      """
+
+  Scenario: Filter response with jq
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/pulls') {
+        json [
+          { :number => 1, :title => "Fix", :draft => false, :user => { :login => "mislav" } },
+          { :number => 2, :title => "WIP", :draft => true, :user => { :login => "octocat" } },
+        ]
+      }
+      """
+    When I successfully run `hub api repos/mislav/dotfiles/pulls --jq '.[] | select(.draft | not) | "#\(.number) \(.title) by \(.user.login)"'`
+    Then the output should contain exactly:
+      """
+      #1 Fix by mislav\n
+      """
+
   Scenario: Avoid leaking token to a 3rd party
     Given the GitHub API server:
       """
@@ -251,6 +431,58 @@ Feature: hub api
       ["one", 2, nil]
       """
 
+  Scenario: POST nested fields
+    Given the GitHub API server:
+      """
+      post('/create') {
+        json :labels => params[:labels], :draft => params[:pull_request][:draft]
+      }
+      """
+    When I successfully run `hub api create -f 'labels[]=bug' -f 'labels[]=ui' -F pull_request.draft=true`
+    Then the output should contain exactly:
+      """
+      {"labels":["bug","ui"],"draft":true}
+      """
+
+  Scenario: POST body from YAML file
+    Given the GitHub API server:
+      """
+      post('/create') {
+        halt 400 unless request.env['CONTENT_TYPE'] == 'application/json; charset=utf-8'
+        params[:obj].inspect
+      }
+      """
+    Given a file named "payload.yml" with:
+      """
+      obj:
+        - one
+        - 2
+      """
+    When I successfully run `hub api create --input payload.yml`
+    Then the output should contain exactly:
+      """
+      ["one", 2]
+      """
+
+  Scenario: Upload a file
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/dotfiles/releases/12/assets', :host_name => 'uploads.github.com') {
+        halt 401 unless request.env['HTTP_AUTHORIZATION'] == 'token OTOKEN'
+        assert :name => "hub.zip", :label => "Linux"
+        json :content_type => request.env['CONTENT_TYPE'], :size => request.body.read.size
+      }
+      """
+    Given a file named "dist/hub.zip" with:
+      """
+      ZIP
+      """
+    When I successfully run `hub api 'repos/mislav/dotfiles/releases/12/assets{?name,label}' -F file=@dist/hub.zip -f label=Linux`
+    Then the output should contain exactly:
+      """
+      {"content_type":"application/zip","size":4}
+      """
+
   Scenario: POST body from stdin
     Given the GitHub API server:
       """
@@ -421,3 +653,129 @@ Feature: hub api
     Given I am "octocat" on github.com with OAuth token "TOKEN2"
     When I run `hub api -t count --cache 5`
     Then it should pass with ".count	2"
+
+  Scenario: Verbose output redacts credentials
+    Given the GitHub API server:
+      """
+      get('/authorizations/1') {
+        json :id => 1, :token => "SECRET"
+      }
+      """
+    When I successfully run `hub --verbose api authorizations/1`
+    Then the stderr should contain:
+      """
+      > GET https://api.github.com/authorizations/1
+      > Authorization: token [REDACTED]
+      """
+    And the stderr should contain:
+      """
+      {"id":1,"token":"[REDACTED]"}
+      """
+    And the stderr should not contain "SECRET"
+    And the output should contain exactly:
+      """
+      {"id":1,"token":"SECRET"}
+      """
+
+  Scenario: Revalidate cached responses
+    Given the GitHub API server:
+      """
+      served = false
+      get('/repos/octocat/hello/issues') {
+        if request.env['HTTP_IF_NONE_MATCH'] == '"v1"'
+          status 304
+        else
+          halt 400 if served
+          served = true
+          response.headers['ETag'] = '"v1"'
+          json [{ :number => 1 }]
+        end
+      }
+      """
+    When I successfully run `hub api repos/octocat/hello/issues`
+    Then the output should contain exactly:
+      """
+      [{"number":1}]
+      """
+    When I successfully run `hub api -i repos/octocat/hello/issues`
+    Then the output should contain "HTTP/1.1 200 OK"
+    And the output should contain "Etag: \"v1\""
+    And the output should contain:
+      """
+
+      [{"number":1}]
+      """
+
+  Scenario: Skip revalidation
+    Given the GitHub API server:
+      """
+      get('/repos/octocat/hello/issues') {
+        halt 400 if request.env['HTTP_IF_NONE_MATCH']
+        response.headers['ETag'] = '"v1"'
+        json [{ :number => 1 }]
+      }
+      """
+    When I successfully run `hub api repos/octocat/hello/issues`
+    And I successfully run `hub --no-cache api repos/octocat/hello/issues`
+    Then the output should contain exactly:
+      """
+      [{"number":1}]
+      """
+
+  Scenario: Skip revalidation with HUB_NO_CACHE
+    Given the GitHub API server:
+      """
+      get('/repos/octocat/hello/issues') {
+        halt 400 if request.env['HTTP_IF_NONE_MATCH']
+        response.headers['ETag'] = '"v1"'
+        json [{ :number => 1 }]
+      }
+      """
+    When I successfully run `hub api repos/octocat/hello/issues`
+    Given $HUB_NO_CACHE is "1"
+    When I successfully run `hub api repos/octocat/hello/issues`
+    Then the output should contain exactly:
+      """
+      [{"number":1}]
+      """
+
+  Scenario: Give up on a slow response
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub') {
+        sleep 2
+        json :name => "hub"
+      }
+      """
+    Given $HUB_HTTP_TIMEOUT is "200ms"
+    When I run `hub api repos/github/hub`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      no response within 200ms (see HUB_HTTP_TIMEOUT)\n
+      """
+
+  Scenario: Invalid HTTP timeout
+    Given $HUB_HTTP_TIMEOUT is "soon"
+    When I run `hub api repos/github/hub`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid HUB_HTTP_TIMEOUT: "soon" (expected a number of seconds or a duration such as 1m30s)\n
+      """
+
+  Scenario: Abort a request in flight on interrupt
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub') {
+        sleep 3
+        json :name => "hub"
+      }
+      """
+    When I run `bash -c 'hub api repos/github/hub & sleep 1; kill -INT $!; wait $!'`
+    Then the exit status should be 130
+    And the stderr should contain exactly:
+      """
+      interrupted\n
+      """
+    And the stdout should not contain "hub"
@@ -0,0 +1,46 @@
+Feature: hub audit-log
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List events of the organization of the current repository
+    Given the GitHub API server:
+      """
+      get('/orgs/github/audit-log') {
+        assert :include => "all", :per_page => "45", :phrase => nil
+        json [
+          { :"@timestamp" => 1600000000000, :action => "repo.destroy", :actor => "octocat", :repo => "github/old" },
+          { :"@timestamp" => 1600000001000, :action => "org.invite_member", :actor => "hubot", :user => "monalisa" },
+        ]
+      }
+      """
+    When I successfully run `hub audit-log`
+    Then the output should contain exactly:
+      """
+      2020-09-13T12:26:40Z  octocat  repo.destroy       github/old
+      2020-09-13T12:26:41Z  hubot    org.invite_member  monalisa\n
+      """
+
+  Scenario: Search events of another organization as JSON
+    Given the GitHub API server:
+      """
+      get('/orgs/acme/audit-log') {
+        assert :phrase => "action:repo.destroy", :per_page => "2"
+        json [
+          { :"@timestamp" => 1600000000000, :action => "repo.destroy", :actor => "wile", :repo => "acme/rockets" },
+        ]
+      }
+      """
+    When I successfully run `hub audit-log --org acme --phrase action:repo.destroy -L 1 --json`
+    Then the output should contain exactly:
+      """
+      [{"@timestamp":1600000000000,"action":"repo.destroy","actor":"wile","repo":"acme/rockets"}]\n
+      """
+
+  Scenario: Invalid organization name
+    When I run `hub audit-log --org "a b"`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid organization name: 'a b'\n
+      """
@@ -257,7 +257,7 @@ Feature: OAuth authentication
     Then the output should not contain "github.com password"
     And the output should not contain "github.com username"
     And the file "../home/.config/hub" should not exist
-    And the exit status should be 1
+    And the exit status should be 4
     And the stderr should contain exactly:
       """
       Error getting current user: Forbidden (HTTP 403)
@@ -351,7 +351,7 @@ Feature: OAuth authentication
       Bad credentials
 
       """
-    And the exit status should be 1
+    And the exit status should be 4
     And the file "../home/.config/hub" should not exist
 
   Scenario: Two-factor authentication, create authorization
@@ -4,7 +4,7 @@ Feature: hub browse
 
   Scenario: No repo
     When I run `hub browse`
-    Then the exit status should be 1
+    Then the exit status should be 2
     Then the output should contain exactly "Usage: hub browse [-uc] [[<USER>/]<REPOSITORY>|--] [<SUBPAGE>]\n"
 
   Scenario: Project with owner
@@ -23,6 +23,12 @@ Feature: hub browse
     When I successfully run `hub browse dotfiles`
     Then "open https://github.com/mislav/dotfiles" should be run
 
+  Scenario: Offline
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And $HUB_OFFLINE is "1"
+    When I successfully run `hub browse -u issues`
+    Then the output should contain exactly "https://github.com/mislav/dotfiles/issues\n"
+
   Scenario: Project issues
     When I successfully run `hub browse mislav/dotfiles issues`
     Then "open https://github.com/mislav/dotfiles/issues" should be run
@@ -55,6 +61,35 @@ Feature: hub browse
     When I successfully run `hub browse -- commit/abcd1234`
     Then "open https://github.com/mislav/dotfiles/commit/abcd1234" should be run
 
+  Scenario: Permalink to lines of a local file
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And a file named "lib/parser.rb" with:
+      """
+      class Parser
+      end
+      """
+    And I successfully run `git add lib/parser.rb`
+    And I successfully run `git commit -m "Add parser"`
+    When I successfully run `hub browse -u -- lib/parser.rb:1-2`
+    Then the output should match /^https:\/\/github.com\/mislav\/dotfiles\/blob\/[0-9a-f]{40}\/lib\/parser.rb#L1-L2$/
+
+  Scenario: Permalink to a local file without being logged in
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And I remove the file "~/.config/hub"
+    And a file named "README.md" with:
+      """
+      # dotfiles
+      """
+    And I successfully run `git add README.md`
+    And I successfully run `git commit -m "Add readme"`
+    When I successfully run `hub browse -u -- README.md:1`
+    Then the output should match /^https:\/\/github.com\/mislav\/dotfiles\/blob\/[0-9a-f]{40}\/README.md#L1$/
+
+  Scenario: Actions subpage
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    When I successfully run `hub browse -- actions`
+    Then "open https://github.com/mislav/dotfiles/actions" should be run
+
   Scenario: Current branch
     Given I am in "git://github.com/mislav/dotfiles.git" git repo
     And git "push.default" is set to "upstream"
@@ -217,3 +252,19 @@ Feature: hub browse
     Given I am in "git@github.com:suan/git-sanity.git" git repo
     When I successfully run `hub browse`
     Then "open https://github.com/suan/git-sanity" should be run
+
+  Scenario: SSH alias pattern
+    Given the SSH config:
+      """
+      Host gh-*
+        HostName github.com
+      """
+    Given I am in "git@gh-work:singingwolfboy/sekrit.git" git repo
+    When I successfully run `hub browse`
+    Then "open https://github.com/singingwolfboy/sekrit" should be run
+
+  Scenario: Remote URL shorthand configured with insteadOf
+    Given I am in "gh:singingwolfboy/sekrit.git" git repo
+    And git "url.git@github.com:.insteadOf" is set to "gh:"
+    When I successfully run `hub browse`
+    Then "open https://github.com/singingwolfboy/sekrit" should be run
@@ -1,4 +1,4 @@
-Feature: hub checkout <PULLREQ-URL>
+Feature: hub checkout <URL>
   Background:
     Given I am in "git://github.com/mojombo/jekyll.git" git repo
     And I am "mislav" on github.com with OAuth token "OTOKEN"
@@ -333,3 +333,54 @@ Feature: hub checkout <PULLREQ-URL>
     Then "git fetch origin refs/pull/77/head:fixes" should be run
     And "git checkout -f fixes -q" should be run
     And "fixes" should merge "refs/heads/fixes" from remote "https://github.com/mislav/jekyll.git"
+
+  Scenario: Checkout a commit
+    When I successfully run `hub checkout https://github.com/mojombo/jekyll/commit/a1b2c3d`
+    Then "git fetch -q --no-tags origin" should be run
+    And "git checkout --detach a1b2c3d" should be run
+
+  Scenario: Checkout a commit of a fork as a new branch
+    When I successfully run `hub checkout https://github.com/mislav/jekyll/commit/a1b2c3d4e5f60718293a4b5c6d7e8f9012345678 review`
+    Then "git remote add _hub-checkout git://github.com/mislav/jekyll.git" should be run
+    And "git fetch -q --no-tags _hub-checkout a1b2c3d4e5f60718293a4b5c6d7e8f9012345678" should be run
+    And "git remote rm _hub-checkout" should be run
+    And "git checkout -b review a1b2c3d4e5f60718293a4b5c6d7e8f9012345678" should be run
+
+  Scenario: Checkout the commit of a workflow run
+    Given the GitHub API server:
+      """
+      get('/repos/mojombo/jekyll/actions/runs/123') {
+        json :head_sha => "a1b2c3d4e5f60718293a4b5c6d7e8f9012345678"
+      }
+      """
+    When I successfully run `hub checkout https://github.com/mojombo/jekyll/actions/runs/123`
+    Then "git fetch -q --no-tags origin a1b2c3d4e5f60718293a4b5c6d7e8f9012345678" should be run
+    And "git checkout --detach a1b2c3d4e5f60718293a4b5c6d7e8f9012345678" should be run
+
+  Scenario: Checkout the commit of a check run
+    Given the GitHub API server:
+      """
+      get('/repos/mojombo/jekyll/check-runs/456') {
+        json :head_sha => "a1b2c3d4e5f60718293a4b5c6d7e8f9012345678"
+      }
+      """
+    When I successfully run `hub checkout https://github.com/mojombo/jekyll/runs/456`
+    Then "git fetch -q --no-tags origin a1b2c3d4e5f60718293a4b5c6d7e8f9012345678" should be run
+    And "git checkout --detach a1b2c3d4e5f60718293a4b5c6d7e8f9012345678" should be run
+
+  Scenario: Checkout a branch
+    When I successfully run `hub checkout https://github.com/mojombo/jekyll/tree/feature/docs`
+    Then "git fetch origin +refs/heads/feature/docs:refs/remotes/origin/feature/docs" should be run
+    And "git checkout -b feature/docs --no-track origin/feature/docs" should be run
+    And "feature/docs" should merge "refs/heads/feature/docs" from remote "origin"
+
+  Scenario: Checkout the head of a comparison with a fork
+    When I successfully run `hub checkout https://github.com/mojombo/jekyll/compare/master...mislav:fixes`
+    Then "git fetch git://github.com/mislav/jekyll.git refs/heads/fixes:mislav-fixes" should be run
+    And "git checkout mislav-fixes" should be run
+    And "mislav-fixes" should merge "refs/heads/fixes" from remote "git://github.com/mislav/jekyll.git"
+
+  Scenario: Unsupported flag when checking out a branch
+    When I run `hub checkout https://github.com/mojombo/jekyll/tree/fixes --orphan`
+    Then the stderr should contain exactly "Unsupported flag --orphan when checking out branch\n"
+    And the exit status should be 1
@@ -17,6 +17,29 @@ Feature: hub cherry-pick
     And "git fetch -q --no-tags origin refs/pull/560/head" should be run
     And "git cherry-pick a319d88" should be run
 
+  Scenario: All commits of a pull request
+    Given the GitHub API server:
+      """
+      get('/repos/rtomayko/ronn/pulls/560/commits') {
+        json [{ :sha => "a319d88" }, { :sha => "b4c5d6e" }]
+      }
+      """
+    When I run `hub cherry-pick https://github.com/rtomayko/ronn/pull/560`
+    Then "git fetch -q --no-tags origin refs/pull/560/head" should be run
+    And "git cherry-pick a319d88 b4c5d6e" should be run
+
+  Scenario: Commits of a comparison with a fork
+    Given the "mislav" remote has url "git@github.com:mislav/ronn.git"
+    Given the GitHub API server:
+      """
+      get('/repos/rtomayko/ronn/compare/master...mislav:fixes') {
+        json :commits => [{ :sha => "a319d88" }, { :sha => "b4c5d6e" }]
+      }
+      """
+    When I run `hub cherry-pick https://github.com/rtomayko/ronn/compare/master...mislav:fixes`
+    Then "git fetch -q --no-tags mislav fixes" should be run
+    And "git cherry-pick a319d88 b4c5d6e" should be run
+
   Scenario: From fork that has existing remote
     Given the "mislav" remote has url "git@github.com:mislav/ronn.git"
     When I run `hub cherry-pick https://github.com/mislav/ronn/commit/a319d88`
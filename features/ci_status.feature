@@ -11,6 +11,17 @@ Feature: hub ci-status
     Then the output should contain exactly "success\n"
     And the exit status should be 0
 
+  Scenario: Fetch status of another repository
+    Given the remote commit state of "octocat/spoon-knife" "main" is "success"
+    When I run `hub ci-status -R octocat/spoon-knife main`
+    Then the output should contain exactly "success\n"
+    And the exit status should be 0
+
+  Scenario: Commit is required for another repository
+    When I run `hub ci-status -R octocat/spoon-knife`
+    Then the stderr should contain exactly "Aborted: <COMMIT> is required with --repo\n"
+    And the exit status should be 1
+
   Scenario: Fetch commit SHA with URL
     Given there is a commit named "the_sha"
     Given the remote commit state of "michiels/pencilbox" "the_sha" is "success"
@@ -98,22 +109,22 @@ Feature: hub ci-status
   Scenario: Use HEAD when no sha given
     Given the remote commit state of "michiels/pencilbox" "HEAD" is "pending"
     When I run `hub ci-status`
-    Then the exit status should be 2
+    Then the exit status should be 8
     And the output should contain exactly "pending\n"
 
-  Scenario: Exit status 3 for no statuses available
+  Scenario: Exit status 9 for no statuses available
     Given there is a commit named "the_sha"
     Given the remote commit state of "michiels/pencilbox" "the_sha" is nil
     When I run `hub ci-status the_sha`
     Then the output should contain exactly "no status\n"
-    And the exit status should be 3
+    And the exit status should be 9
 
-  Scenario: Exit status 3 for no statuses available without URL
+  Scenario: Exit status 9 for no statuses available without URL
     Given there is a commit named "the_sha"
     Given the remote commit state of "michiels/pencilbox" "the_sha" is nil
     When I run `hub ci-status -v the_sha`
     Then the output should contain exactly "no status\n"
-    And the exit status should be 3
+    And the exit status should be 9
 
   Scenario: Abort with message when invalid ref given
     When I run `hub ci-status this-is-an-invalid-ref`
@@ -200,3 +211,153 @@ Feature: hub ci-status
       """
     When I successfully run `hub ci-status the_sha`
     Then the output should contain exactly "success\n"
+
+  Scenario: Set commit status
+    Given the GitHub API server:
+      """
+      post('/repos/octocat/spoon-knife/statuses/main') {
+        assert :state => "success",
+               :context => "deploy/prod",
+               :target_url => "https://example.com/deploys/42",
+               :description => :no
+        status 201
+        json :state => "success", :context => "deploy/prod"
+      }
+      """
+    When I successfully run `hub ci-status set -R octocat/spoon-knife --state success --context deploy/prod --target-url https://example.com/deploys/42 main`
+    Then the output should contain exactly "Set deploy/prod status of main to success\n"
+
+  Scenario: Set commit status with an invalid state
+    When I run `hub ci-status set --state done`
+    Then the stderr should contain exactly "invalid state: 'done' (expected one of: pending, success, failure, error)\n"
+    And the exit status should be 1
+
+  Scenario: Verbose output with check run details
+    Given there is a commit named "the_sha"
+    And the GitHub API server:
+      """
+      get('/repos/michiels/pencilbox/commits/:sha/status') {
+        json({ :state => "pending", :statuses => [] })
+      }
+      get('/repos/michiels/pencilbox/commits/:sha/check-runs') {
+        json({ :check_runs => [
+                 { :id => 11, :status => "completed", :conclusion => "failure",
+                   :name => "lint", :html_url => "the://url/11",
+                   :started_at => "2020-01-01T00:00:00Z",
+                   :completed_at => "2020-01-01T00:01:23Z",
+                   :output => { :annotations_count => 2 },
+                   :app => { :slug => "github-actions" } },
+                 { :id => 12, :status => "in_progress", :conclusion => nil,
+                   :name => "test", :html_url => "the://url/12",
+                   :started_at => "2020-01-01T00:00:00Z",
+                   :output => { :annotations_count => 0 },
+                   :app => { :slug => "github-actions" } },
+               ]
+        })
+      }
+      get('/repos/michiels/pencilbox/check-runs/11/annotations') {
+        json [
+          { :path => "main.go", :start_line => 12, :annotation_level => "failure",
+            :message => "undefined: foo" },
+          { :path => "util.go", :start_line => 3, :annotation_level => "warning",
+            :message => "exported func Bar should have comment" },
+        ]
+      }
+      """
+    When I run `hub ci-status -v the_sha`
+    Then the output should contain exactly:
+      """
+      ✖︎	lint	the://url/11 (1m23s)
+      	  main.go:12: failure: undefined: foo
+      	  util.go:3: warning: exported func Bar should have comment
+      ●	test	the://url/12\n
+      """
+    And the exit status should be 1
+
+  Scenario: Print the log of a check
+    Given there is a commit named "the_sha"
+    And the GitHub API server:
+      """
+      get('/repos/michiels/pencilbox/commits/:sha/status') {
+        json({ :state => "pending", :statuses => [] })
+      }
+      get('/repos/michiels/pencilbox/commits/:sha/check-runs') {
+        json({ :check_runs => [
+                 { :id => 11, :status => "completed", :conclusion => "failure",
+                   :name => "lint", :html_url => "the://url/11",
+                   :app => { :slug => "github-actions" } },
+               ]
+        })
+      }
+      get('/repos/michiels/pencilbox/actions/jobs/11/logs') {
+        content_type "text/plain"
+        "Run golint\nmain.go:12: undefined: foo\n"
+      }
+      """
+    When I successfully run `hub ci-status --log lint the_sha`
+    Then the output should contain exactly:
+      """
+      Run golint
+      main.go:12: undefined: foo\n
+      """
+
+  Scenario: Re-run failed checks
+    Given there is a commit named "the_sha"
+    And the GitHub API server:
+      """
+      get('/repos/michiels/pencilbox/commits/:sha/status') {
+        json({ :state => "pending", :statuses => [] })
+      }
+      get('/repos/michiels/pencilbox/commits/:sha/check-runs') {
+        json({ :check_runs => [
+                 { :id => 11, :status => "completed", :conclusion => "failure",
+                   :name => "lint", :app => { :slug => "github-actions" } },
+                 { :id => 12, :status => "completed", :conclusion => "timed_out",
+                   :name => "external", :app => { :slug => "circleci" } },
+                 { :id => 13, :status => "completed", :conclusion => "success",
+                   :name => "build", :app => { :slug => "github-actions" } },
+               ]
+        })
+      }
+      post('/repos/michiels/pencilbox/actions/jobs/11/rerun') { status 201 }
+      post('/repos/michiels/pencilbox/check-runs/12/rerequest') { status 201 }
+      """
+    When I successfully run `hub ci-status --rerun-failed the_sha`
+    Then the output should contain exactly:
+      """
+      Re-running external
+      Re-running lint\n
+      """
+
+  Scenario: Print annotations for editors
+    Given there is a commit named "the_sha"
+    And the GitHub API server:
+      """
+      get('/repos/michiels/pencilbox/commits/:sha/status') {
+        json({ :state => "pending", :statuses => [] })
+      }
+      get('/repos/michiels/pencilbox/commits/:sha/check-runs') {
+        json({ :check_runs => [
+                 { :id => 11, :status => "completed", :conclusion => "failure",
+                   :name => "lint", :output => { :annotations_count => 2 } },
+                 { :id => 12, :status => "completed", :conclusion => "success",
+                   :name => "build", :output => { :annotations_count => 0 } },
+               ]
+        })
+      }
+      get('/repos/michiels/pencilbox/check-runs/11/annotations') {
+        json [
+          { :path => "main.go", :start_line => 12, :start_column => 5,
+            :annotation_level => "failure", :message => "undefined: foo" },
+          { :path => "util.go", :start_line => 3, :start_column => nil,
+            :annotation_level => "warning", :message => "exported func Bar should have comment" },
+        ]
+      }
+      """
+    When I run `hub ci-status --annotations the_sha`
+    Then the output should contain exactly:
+      """
+      main.go:12:5: failure: undefined: foo
+      util.go:3:1: warning: exported func Bar should have comment\n
+      """
+    And the exit status should be 1
@@ -187,6 +187,40 @@ Feature: hub clone
     Then "git clone --bare -o master git@github.com:mislav/dotfiles.git" should be run
     And the output should not contain anything
 
+  Scenario: Clone a fork
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/ronn') {
+        json :private => false,
+             :name => 'ronn', :owner => { :login => 'mislav' },
+             :permissions => { :push => true },
+             :parent => { :name => 'ronn', :owner => { :login => 'rtomayko' }, :private => false }
+      }
+      """
+    When I successfully run `hub --noop clone ronn my-ronn`
+    Then the output should contain exactly:
+      """
+      git clone git@github.com:mislav/ronn.git my-ronn
+      git -C my-ronn remote add -f upstream git://github.com/rtomayko/ronn.git\n
+      """
+
+  Scenario: Partial clone of a fork
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/ronn') {
+        json :private => false,
+             :name => 'ronn', :owner => { :login => 'mislav' },
+             :permissions => { :push => true },
+             :parent => { :name => 'ronn', :owner => { :login => 'rtomayko' }, :private => false }
+      }
+      """
+    When I successfully run `hub --noop clone --filter blob:none --sparse mislav/ronn`
+    Then the output should contain exactly:
+      """
+      git clone --filter blob:none --sparse git@github.com:mislav/ronn.git
+      git -C ronn remote add upstream git://github.com/rtomayko/ronn.git\n
+      """
+
   Scenario: Clone repo to which I have push access to
     Given the GitHub API server:
       """
@@ -254,6 +288,23 @@ Feature: hub clone
     Then it should clone "git://github.com/RTomayko/ronin.wiki.git"
     And the output should not contain anything
 
+  Scenario: Clone the wiki of a fork
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/ronn') {
+        json :private => false,
+             :name => 'ronn', :owner => { :login => 'mislav' },
+             :permissions => { :push => true },
+             :has_wiki => true,
+             :parent => { :name => 'ronn', :owner => { :login => 'rtomayko' }, :private => false }
+      }
+      """
+    When I successfully run `hub --noop clone mislav/ronn.wiki`
+    Then the output should contain exactly:
+      """
+      git clone git@github.com:mislav/ronn.wiki.git\n
+      """
+
   Scenario: Clone a nonexisting wiki
     Given the GitHub API server:
       """
@@ -0,0 +1,85 @@
+Feature: hub code-scanning
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Upload a SARIF file
+    Given a file named "results.sarif" with:
+      """
+      {"version":"2.1.0","runs":[]}
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/octocat/spoon-knife/code-scanning/sarifs') {
+        assert :commit_sha => "deadbeef", :ref => "refs/heads/main"
+        sarif = Zlib::GzipReader.new(StringIO.new(Base64.decode64(params[:sarif]))).read
+        halt 400 unless sarif == %({"version":"2.1.0","runs":[]})
+        status 202
+        json :id => "47177e22-5596-11eb-80a1-c1e54ef945c6"
+      }
+      """
+    When I successfully run `hub code-scanning upload -R octocat/spoon-knife -c deadbeef -r refs/heads/main results.sarif`
+    Then the output should contain exactly:
+      """
+      47177e22-5596-11eb-80a1-c1e54ef945c6\n
+      """
+
+  Scenario: Upload an invalid SARIF file
+    Given a file named "results.sarif" with:
+      """
+      {"version":"2.0.0","runs":[]}
+      """
+    When I run `hub code-scanning upload -R octocat/spoon-knife -c deadbeef -r refs/heads/main results.sarif`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid SARIF file: unsupported version '2.0.0' (expected 2.1.0)\n
+      """
+
+  Scenario: Upload requires a commit and ref for another repository
+    When I run `hub code-scanning upload -R octocat/spoon-knife results.sarif`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: --commit and --ref are required with --repo\n
+      """
+
+  Scenario: List alerts
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/code-scanning/alerts') {
+        assert :state => "open", :tool_name => "semgrep", :per_page => "100"
+        json [
+          { :number => 7, :state => "open", :html_url => "https://github.com/github/hub/security/code-scanning/7",
+            :rule => { :severity => "warning", :description => "Unused variable" },
+            :most_recent_instance => { :location => { :path => "main.go", :start_line => 10 } } },
+        ]
+      }
+      """
+    When I successfully run `hub code-scanning alerts -s open --tool semgrep`
+    Then the output should contain exactly:
+      """
+      #7  warning  open  Unused variable  (main.go:10)\n
+      """
+
+  Scenario: Dismiss an alert
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/code-scanning/alerts/7') {
+        assert :state => "dismissed", :dismissed_reason => "won't fix", :dismissed_comment => "legacy code"
+        json :number => 7
+      }
+      """
+    When I successfully run `hub code-scanning dismiss --reason wont-fix -m "legacy code" 7`
+    Then the output should contain exactly:
+      """
+      Dismissed alert #7 as won't fix\n
+      """
+
+  Scenario: Invalid dismiss reason
+    When I run `hub code-scanning dismiss --reason nah 7`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid reason: 'nah' (expected one of: false positive, won't fix, used in tests)\n
+      """
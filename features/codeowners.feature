@@ -0,0 +1,62 @@
+Feature: hub codeowners
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And a file named ".github/CODEOWNERS" with:
+      """
+      # default owners
+      *            @github/cli
+      *.go         @mislav
+      /docs/       docs@example.com
+      /vendor/**
+      """
+
+  Scenario: Show owners of paths
+    When I successfully run `hub codeowners main.go docs/README.md vendor/x/y.go share/hub.1`
+    Then the output should contain exactly:
+      """
+      main.go         @mislav
+      docs/README.md  docs@example.com
+      vendor/x/y.go   (no owners)
+      share/hub.1     @github/cli\n
+      """
+
+  Scenario: Paths are relative to the current directory
+    Given a directory named "docs"
+    And I cd to "docs"
+    When I successfully run `hub codeowners README.md ../script/build.go`
+    Then the output should contain exactly:
+      """
+      README.md           docs@example.com
+      ../script/build.go  @mislav\n
+      """
+
+  Scenario: Validate CODEOWNERS
+    Given a file named ".github/CODEOWNERS" with:
+      """
+      *            @github/cli
+      !*.md        @mislav
+      *.go         @mislav @nobody
+      """
+    Given the GitHub API server:
+      """
+      get('/orgs/github/teams/cli') { json :slug => 'cli' }
+      get('/users/mislav') { json :login => 'mislav' }
+      get('/users/nobody') { status 404; json :message => 'Not Found' }
+      """
+    When I run `hub codeowners --validate`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      .github/CODEOWNERS:2: negated pattern '!*.md' is not supported
+      .github/CODEOWNERS:3: unknown owner '@nobody'\n
+      """
+
+  Scenario: No CODEOWNERS file
+    Given I remove the file ".github/CODEOWNERS"
+    When I run `hub codeowners main.go`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Unable to find a CODEOWNERS file in .github/CODEOWNERS, CODEOWNERS, docs/CODEOWNERS\n
+      """
@@ -0,0 +1,81 @@
+Feature: hub collab
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List collaborators
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/collaborators') {
+        assert :permission => "push"
+        json [
+          { :login => "mislav", :role_name => "admin" },
+          { :login => "octocat", :role_name => "write" },
+        ]
+      }
+      """
+    When I successfully run `hub collab list --permission write`
+    Then the output should contain exactly:
+      """
+      mislav   admin
+      octocat  write\n
+      """
+
+  Scenario: Add collaborators
+    Given the GitHub API server:
+      """
+      put('/repos/github/hub/collaborators/octocat') {
+        assert :permission => "triage"
+        status 204
+      }
+      put('/repos/github/hub/collaborators/newbie') {
+        assert :permission => "triage"
+        status 201
+        json :id => 1
+      }
+      """
+    When I successfully run `hub collab add --permission triage octocat @newbie`
+    Then the output should contain exactly:
+      """
+      Granted octocat triage access to github/hub
+      Invited newbie to github/hub with triage access\n
+      """
+
+  Scenario: Invalid permission
+    When I run `hub collab add --permission owner octocat`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid permission: 'owner' (expected one of: pull, triage, push, maintain, admin)\n
+      """
+
+  Scenario: Remove a collaborator
+    Given the GitHub API server:
+      """
+      delete('/repos/github/hub/collaborators/octocat') {
+        status 204
+      }
+      """
+    When I successfully run `hub collab remove octocat`
+    Then the output should contain exactly "Removed octocat from github/hub\n"
+
+  Scenario: Check permission
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/collaborators/octocat/permission') {
+        json :permission => "write", :role_name => "maintain"
+      }
+      """
+    When I successfully run `hub collab check octocat`
+    Then the output should contain exactly "maintain\n"
+
+  Scenario: Check permission of an unknown user
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/collaborators/nobody/permission') {
+        status 404
+      }
+      """
+    When I run `hub collab check nobody`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Unable to find user 'nobody'\n"
@@ -0,0 +1,35 @@
+Feature: hub community
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Community report as JSON
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/community/profile') {
+        json :health_percentage => 71,
+             :files => {
+               :readme => { :html_url => "https://github.com/github/hub/blob/master/README.md" },
+               :contributing => { :html_url => "https://github.com/github/hub/blob/master/CONTRIBUTING.md" },
+               :code_of_conduct => nil,
+             }
+      }
+      get('/search/issues') {
+        if params[:q].include?("type:pr")
+          assert :q => "repo:github/hub type:pr created:>=2020-01-01"
+          json :total_count => 3, :items => [
+            { :number => 3, :user => { :login => "zoe" }, :author_association => "FIRST_TIME_CONTRIBUTOR" },
+            { :number => 2, :user => { :login => "mislav" }, :author_association => "MEMBER" },
+            { :number => 1, :user => { :login => "adam" }, :author_association => "FIRST_TIMER" },
+          ]
+        else
+          assert :q => 'repo:github/hub type:issue state:open label:"good first issue"'
+          json :total_count => 5, :items => [{ :number => 10 }]
+        end
+      }
+      """
+    When I successfully run `hub community --since 2020-01-01 --json`
+    Then the output should contain exactly:
+      """
+      {"repository":"github/hub","health_percentage":71,"files":{"code_of_conduct":false,"contributing":true,"issue_template":false,"license":false,"pull_request_template":false,"readme":true},"since":"2020-01-01","first_time_contributors":["adam","zoe"],"good_first_issue_label":"good first issue","good_first_issues":5}\n
+      """
@@ -97,7 +97,7 @@ Feature: hub compare
     Given I am on the "master" branch with upstream "origin/master"
     When I run `hub compare -b master experimental..master`
     Then "open https://github.com/mislav/dotfiles/compare/experimental...master" should not be run
-    And the exit status should be 1
+    And the exit status should be 2
     And the stderr should contain "Usage: hub compare"
 
   Scenario: Compare 2-dots range for tags
@@ -169,3 +169,47 @@ Feature: hub compare
     Then the exit status should be 0
     And the output should not contain anything
     And "open https://github.com/mislav/dotfiles/compare/refactor...master" should be run
+
+  Scenario: Print comparison with file stats
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/compare/mislav:master...jingweno:feature') {
+        json :status => "ahead", :ahead_by => 2, :behind_by => 0,
+          :commits => [
+            { :sha => "abcdef1234567890", :commit => { :message => "Add feature\n\nDetails", :author => { :name => "Jingwen" } } },
+            { :sha => "1234567abcdef890", :commit => { :message => "Fix tests", :author => { :name => "Jingwen" } } },
+          ],
+          :files => [
+            { :filename => "lib/feature.rb", :additions => 3, :deletions => 1, :changes => 4 },
+            { :filename => "README", :additions => 1, :deletions => 0, :changes => 1 },
+          ]
+      }
+      """
+    When I successfully run `hub compare --print --stat mislav:master...jingweno:feature`
+    Then the output should contain exactly:
+      """
+      Comparing mislav:master...jingweno:feature: 2 commits ahead, 0 behind
+
+      abcdef1 Add feature (Jingwen)
+      1234567 Fix tests (Jingwen)
+
+       lib/feature.rb | 4 +++-
+       README         | 1 +
+       2 files changed, 4 insertions(+), 1 deletions(-)\n
+      """
+    And "open https://github.com/mislav/dotfiles/compare/mislav:master...jingweno:feature" should not be run
+
+  Scenario: Comparison as JSON against the default branch
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/compare/master...feature') {
+        json :status => "behind", :ahead_by => 0, :behind_by => 1, :total_commits => 0,
+          :html_url => "https://github.com/mislav/dotfiles/compare/master...feature",
+          :commits => [], :files => []
+      }
+      """
+    When I successfully run `hub compare --json feature`
+    Then the output should contain:
+      """
+      "ahead_by":0,"behind_by":1
+      """
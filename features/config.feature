@@ -0,0 +1,52 @@
+Feature: hub config
+  Background:
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Set and get a setting
+    When I successfully run `hub config set git_protocol ssh`
+    And I successfully run `hub config get git_protocol`
+    Then the output should contain exactly:
+      """
+      ssh\n
+      """
+
+  Scenario: Settings for a host take precedence
+    Given I successfully run `hub config set editor vim`
+    And I successfully run `hub config set --host github.com editor nano`
+    When I successfully run `hub config list`
+    Then the output should contain exactly:
+      """
+      editor=nano\n
+      """
+
+  Scenario: Invalid value
+    When I run `hub config set git_protocol ftp`
+    Then the exit status should be 1
+    And the stderr should contain "invalid value"
+
+  Scenario: Unknown host
+    When I run `hub config set --host git.example.com editor nano`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      no credentials found for host git.example.com\n
+      """
+
+  Scenario: Pass through git configuration keys
+    Given I am in "git://github.com/github/hub.git" git repo
+    And git "user.name" is set to "Mona"
+    When I successfully run `hub config get user.name`
+    Then the output should contain exactly:
+      """
+      Mona\n
+      """
+
+  Scenario: Invalid proxy
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I successfully run `hub config set proxy ftp://proxy.example.com`
+    When I run `hub issue`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid proxy for github.com: "ftp://proxy.example.com" (expected an http, https, or socks5 URL)\n
+      """
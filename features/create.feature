@@ -28,6 +28,77 @@ Feature: hub create
     When I successfully run `hub create -p`
     Then the url for "origin" should be "git@github.com:mislav/dotfiles.git"
 
+  Scenario: Create internal repo
+    Given the GitHub API server:
+      """
+      post('/orgs/acme/repos') {
+        assert :private => false, :visibility => "internal"
+        status 201
+        json :full_name => 'acme/dotfiles'
+      }
+      """
+    When I successfully run `hub create --internal acme/dotfiles`
+    Then the url for "origin" should be "git@github.com:acme/dotfiles.git"
+
+  Scenario: Private and internal
+    When I run `hub create -p --internal`
+    Then the exit status should be 1
+    And the stderr should contain "--private and --internal are mutually exclusive"
+
+  Scenario: Create repo from template
+    Given the GitHub API server:
+      """
+      post('/repos/acme/starter/generate') {
+        halt 415 unless request.accept?('application/vnd.github.baptiste-preview+json')
+        assert :owner => "mislav", :name => "dotfiles", :private => true
+        status 201
+        json :full_name => 'mislav/dotfiles'
+      }
+      patch('/repos/mislav/dotfiles') {
+        assert :homepage => "https://example.com"
+        json :full_name => 'mislav/dotfiles'
+      }
+      """
+    When I successfully run `hub create -p --template acme/starter -h https://example.com`
+    Then the url for "origin" should be "git@github.com:mislav/dotfiles.git"
+    And the output should contain exactly "https://github.com/mislav/dotfiles\n"
+
+  Scenario: Create repo with gitignore and license
+    Given git "user.name" is set to "Mislav Marohnić"
+    Given the GitHub API server:
+      """
+      get('/gitignore/templates/Go') {
+        json :name => "Go", :source => "*.exe\n"
+      }
+      get('/licenses/mit') {
+        json :key => "mit", :body => "Copyright (c) [year] [fullname]\n"
+      }
+      post('/user/repos') {
+        assert :gitignore_template => nil, :license_template => nil
+        status 201
+        json :full_name => 'mislav/dotfiles'
+      }
+      """
+    When I successfully run `hub create --gitignore Go --license mit`
+    Then the file ".gitignore" should contain exactly "*.exe\n"
+    And the file "LICENSE" should contain "Mislav Marohnić\n"
+    And the latest commit message should be "Add .gitignore and LICENSE"
+
+  Scenario: Starter file already exists
+    Given a file named "LICENSE" with:
+      """
+      All rights reserved.
+      """
+    And the GitHub API server:
+      """
+      get('/licenses/mit') {
+        json :key => "mit", :body => "Copyright (c) [year] [fullname]\n"
+      }
+      """
+    When I run `hub create --license mit`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Aborted: LICENSE already exists\n"
+
   Scenario: Alternate origin remote name
     Given the GitHub API server:
       """
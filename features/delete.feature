@@ -5,10 +5,11 @@ Feature: hub delete
   Scenario: No argument in current repo
     Given I am in "git://github.com/github/hub.git" git repo
     When I run `hub delete`
-    Then the exit status should be 1
+    Then the exit status should be 2
     And the stderr should contain exactly:
       """
-      Usage: hub delete [-y] [<ORGANIZATION>/]<NAME>\n
+      Usage: hub delete [-y] [--dry-run] [<ORGANIZATION>/]<NAME>
+             hub delete --stdin (-y|--dry-run) [--resume]\n
       """
 
   Scenario: Successful confirmation
@@ -19,11 +20,11 @@ Feature: hub delete
       }
       """
     When I run `hub delete my-repo` interactively
-    And I type "yes"
+    And I type "andreasbaumann/my-repo"
     Then the exit status should be 0
     And the output should contain:
       """
-      Really delete repository 'andreasbaumann/my-repo' (yes/N)?
+      Really delete repository 'andreasbaumann/my-repo'? Type its full name to confirm:
       """
     And the output should contain:
       """
@@ -38,11 +39,11 @@ Feature: hub delete
       }
       """
     When I run `hub delete our-org/my-repo` interactively
-    And I type "yes"
+    And I type "our-org/my-repo"
     Then the exit status should be 0
     And the output should contain:
       """
-      Really delete repository 'our-org/my-repo' (yes/N)?
+      Really delete repository 'our-org/my-repo'? Type its full name to confirm:
       """
     And the output should contain:
       """
@@ -51,15 +52,15 @@ Feature: hub delete
 
   Scenario: Invalid confirmation
     When I run `hub delete my-repo` interactively
-    And I type "y"
+    And I type "yes"
     Then the exit status should be 1
     And the output should contain:
       """
-      Really delete repository 'andreasbaumann/my-repo' (yes/N)?
+      Really delete repository 'andreasbaumann/my-repo'? Type its full name to confirm:
       """
     And the stderr should contain exactly:
       """
-      Please type 'yes' for confirmation.\n
+      Please type 'andreasbaumann/my-repo' for confirmation.\n
       """
 
   Scenario: HTTP 403
@@ -93,3 +94,69 @@ Feature: hub delete
       Please edit the token used for hub at https://git.my.org/settings/tokens
       and verify that the `delete_repo` scope is enabled.
       """
+
+  Scenario: Dry run
+    Given the GitHub API server:
+      """
+      get('/repos/andreasbaumann/my-repo') {
+        json :full_name => 'andreasbaumann/my-repo',
+             :parent => { :full_name => 'github/my-repo' }
+      }
+      """
+    When I successfully run `hub delete --dry-run my-repo`
+    Then the output should contain exactly:
+      """
+      Would delete repository 'andreasbaumann/my-repo' (fork of 'github/my-repo').\n
+      """
+
+  Scenario: Delete repositories from stdin
+    Given the GitHub API server:
+      """
+      delete('/repos/andreasbaumann/old-fork') { status 204 }
+      delete('/repos/our-org/old-repo') { status 204 }
+      """
+    When I run `hub delete --stdin --yes` interactively
+    And I type "# cleanup"
+    And I type "old-fork"
+    And I type "our-org/old-repo"
+    And I close the stdin stream
+    Then the exit status should be 0
+    And the output should contain exactly:
+      """
+      Deleted repository 'andreasbaumann/old-fork'.
+      Deleted repository 'our-org/old-repo'.\n
+      """
+
+  Scenario: Resume only the same list of repositories
+    Given the GitHub API server:
+      """
+      delete('/repos/andreasbaumann/old-fork') { status 204 }
+      delete('/repos/our-org/old-repo') {
+        status 500
+        json :message => "Server Error"
+      }
+      delete('/repos/our-org/new-repo') { status 204 }
+      """
+    When I run `hub delete --stdin --yes` interactively
+    And I type "old-fork"
+    And I type "our-org/old-repo"
+    And I close the stdin stream
+    Then the exit status should be 1
+    When I run `hub delete --stdin --yes --resume` interactively
+    And I type "old-fork"
+    And I type "our-org/new-repo"
+    And I close the stdin stream
+    Then the exit status should be 0
+    And the stdout should contain exactly:
+      """
+      Deleted repository 'andreasbaumann/old-fork'.
+      Deleted repository 'our-org/new-repo'.\n
+      """
+
+  Scenario: Delete from stdin without confirmation
+    When I run `hub delete --stdin`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: --stdin requires --yes or --dry-run\n
+      """
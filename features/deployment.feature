@@ -0,0 +1,100 @@
+Feature: hub deployment
+  Background:
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Create a deployment
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/dotfiles/deployments') {
+        assert :ref => 'v1.2.0',
+               :environment => 'staging',
+               :auto_merge => false,
+               :description => 'Nightly'
+        status 201
+        json :id => 1234, :ref => 'v1.2.0', :environment => 'staging'
+      }
+      """
+    When I successfully run `hub deployment create -e staging --ref v1.2.0 -d Nightly`
+    Then the output should contain exactly:
+      """
+      1234\n
+      """
+
+  Scenario: Deploy the current branch to production
+    Given I am on the "topic" branch
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/dotfiles/deployments') {
+        assert :ref => 'topic',
+               :environment => 'production',
+               :required_contexts => []
+        status 201
+        json :id => 1234
+      }
+      """
+    When I successfully run `hub deployment create --skip-checks`
+    Then the output should contain exactly:
+      """
+      1234\n
+      """
+
+  Scenario: Deployment blocked by auto-merge
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/dotfiles/deployments') {
+        status 202
+        json :message => 'Auto-merged master into topic on deployment.'
+      }
+      """
+    When I run `hub deployment create --ref topic --auto-merge`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Error creating deployment: Auto-merged master into topic on deployment.\n
+      """
+
+  Scenario: List deployments
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/deployments') {
+        assert :environment => 'staging', :per_page => '100'
+        json [
+          { :id => 1234, :environment => 'staging', :ref => 'main',
+            :sha => '1a2b3c4d5e6f', :created_at => '2020-05-01T10:00:00Z' },
+          { :id => 98, :environment => 'staging', :ref => 'v1.2.0',
+            :sha => 'f6e5d4c3b2a1', :created_at => '2020-04-01T10:00:00Z' },
+        ]
+      }
+      """
+    When I successfully run `hub deployment list -e staging`
+    Then the output should contain exactly:
+      """
+      1234  staging  main   (1a2b3c4)  2020-05-01
+        98  staging  v1.2.0 (f6e5d4c)  2020-04-01\n
+      """
+
+  Scenario: Report deployment status
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/dotfiles/deployments/1234/statuses') {
+        assert :state => 'success',
+               :log_url => 'https://ci.example.com/builds/9',
+               :environment_url => 'https://staging.example.com'
+        status 201
+        json :id => 1, :state => 'success'
+      }
+      """
+    When I successfully run `hub deployment status set 1234 success --log-url https://ci.example.com/builds/9 --environment-url https://staging.example.com`
+    Then the output should contain exactly:
+      """
+      Deployment 1234: success\n
+      """
+
+  Scenario: Invalid deployment state
+    When I run `hub deployment status set 1234 done`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid state: 'done' (expected one of: queued, pending, in_progress, success, failure, error, inactive)\n
+      """
@@ -0,0 +1,105 @@
+Feature: hub discussion
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "cornwe19" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List discussions in a category
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query].include?("discussionCategories")
+          json :data => { :repository => {
+            :id => "REPO_NODE",
+            :hasDiscussionsEnabled => true,
+            :discussionCategories => { :nodes => [
+              { :id => "CAT_QA", :name => "Q&A", :slug => "q-a", :isAnswerable => true },
+              { :id => "CAT_IDEAS", :name => "Ideas", :slug => "ideas", :isAnswerable => false },
+            ] },
+          } }
+        else
+          assert :variables => { :owner => "github", :name => "hub", :first => 100, :categoryId => "CAT_QA" }
+          json :data => { :repository => { :discussions => {
+            :nodes => [
+              { :number => 7, :title => "How do I alias hub?", :category => { :name => "Q&A" }, :answer => { :databaseId => 5 } },
+              { :number => 12, :title => "Proxy support?", :category => { :name => "Q&A" }, :answer => nil },
+            ],
+            :pageInfo => { :hasNextPage => false },
+          } } }
+        end
+      }
+      """
+    When I successfully run `hub discussion list --category "Q&A"`
+    Then the output should contain exactly:
+      """
+       #7  How do I alias hub?  (Q&A) [answered]
+      #12  Proxy support?  (Q&A)\n
+      """
+
+  Scenario: Start a discussion
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query].include?("discussionCategories")
+          json :data => { :repository => {
+            :id => "REPO_NODE",
+            :hasDiscussionsEnabled => true,
+            :discussionCategories => { :nodes => [
+              { :id => "CAT_IDEAS", :name => "Ideas", :slug => "ideas", :isAnswerable => false },
+            ] },
+          } }
+        else
+          halt 400 unless params[:query].include?("createDiscussion")
+          assert :variables => {
+            :repositoryId => "REPO_NODE", :categoryId => "CAT_IDEAS",
+            :title => "Plugins", :body => "Let's support them."
+          }
+          json :data => { :createDiscussion => { :discussion => {
+            :number => 13, :url => "https://github.com/github/hub/discussions/13"
+          } } }
+        end
+      }
+      """
+    When I successfully run `hub discussion create --category ideas -m Plugins -m "Let's support them."`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/discussions/13\n
+      """
+
+  Scenario: Discussions are disabled
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => { :repository => { :id => "REPO_NODE", :hasDiscussionsEnabled => false } }
+      }
+      """
+    When I run `hub discussion create --category ideas -m Plugins`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Discussions are disabled for github/hub\n
+      """
+
+  Scenario: Mark a comment as the answer
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query].include?("markDiscussionCommentAsAnswer")
+          assert :variables => { :id => "COMMENT_NODE" }
+          json :data => { :markDiscussionCommentAsAnswer => { :discussion => { :number => 7 } } }
+        else
+          assert :variables => { :owner => "github", :name => "hub", :number => 7 }
+          json :data => { :repository => { :discussion => {
+            :number => 7, :title => "How do I alias hub?",
+            :category => { :name => "Q&A", :isAnswerable => true },
+            :comments => { :totalCount => 1, :nodes => [
+              { :id => "COMMENT_NODE", :databaseId => 1234, :body => "Use `eval`." },
+            ] },
+          } } }
+        end
+      }
+      """
+    When I successfully run `hub discussion answer 7 https://github.com/github/hub/discussions/7#discussioncomment-1234`
+    Then the output should contain exactly:
+      """
+      Marked comment 1234 as the answer to discussion #7\n
+      """
@@ -0,0 +1,87 @@
+Feature: hub env
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Create a codespace for a branch
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/codespaces') {
+        assert :ref => "fix-login", :machine => "basicLinux32gb"
+        status 201
+        json :name => "mislav-hub-x7q", :state => "Provisioning",
+          :web_url => "https://mislav-hub-x7q.github.dev"
+      }
+      """
+    When I successfully run `hub env create --ref fix-login --machine basicLinux32gb`
+    Then the stdout should contain exactly:
+      """
+      mislav-hub-x7q\n
+      """
+    And the stderr should contain exactly:
+      """
+      Open it in the browser: https://mislav-hub-x7q.github.dev
+      Connect over SSH: gh codespace ssh --codespace mislav-hub-x7q\n
+      """
+
+  Scenario: Create a codespace to review a pull request
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/pulls/12/codespaces') {
+        status 202
+        json :name => "mislav-hub-pr12", :state => "Queued",
+          :web_url => "https://mislav-hub-pr12.github.dev"
+      }
+      """
+    When I successfully run `hub env create --pr 12`
+    Then the stdout should contain exactly:
+      """
+      mislav-hub-pr12\n
+      """
+
+  Scenario: Branch and pull request are exclusive
+    When I run `hub env create --ref main --pr 12`
+    Then the exit status should be 1
+    And the stderr should contain "--ref and --pr can't be used together"
+
+  Scenario: List codespaces
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/codespaces') {
+        json :total_count => 2, :codespaces => [
+          { :name => "mislav-hub-x7q", :state => "Available", :git_status => { :ref => "fix-login" } },
+          { :name => "mislav-hub-pr12", :state => "Shutdown", :git_status => { :ref => "feature" } },
+        ]
+      }
+      """
+    When I successfully run `hub env list`
+    Then the output should contain exactly:
+      """
+      mislav-hub-x7q   Available  fix-login
+      mislav-hub-pr12  Shutdown   feature\n
+      """
+
+  Scenario: Delete codespaces
+    Given the GitHub API server:
+      """
+      delete('/user/codespaces/mislav-hub-x7q') {
+        status 202
+      }
+      """
+    When I successfully run `hub env delete mislav-hub-x7q`
+    Then the output should contain exactly:
+      """
+      Deleted codespace mislav-hub-x7q\n
+      """
+
+  Scenario: Use another backend
+    Given an executable "hub-env-docker" on PATH with:
+      """
+      echo "$HUB_REPO $HUB_HOST $HUB_TOKEN $*"
+      """
+    And I successfully run `git config hub.envBackend hub-env-docker`
+    When I successfully run `hub env create --ref fix-login -R acme/anvils`
+    Then the output should contain exactly:
+      """
+      acme/anvils github.com OTOKEN create --ref fix-login\n
+      """
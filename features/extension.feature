@@ -0,0 +1,35 @@
+Feature: hub extension
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Run an extension from PATH
+    Given an executable "hub-hello" on PATH with:
+      """
+      echo "$HUB_REPO $HUB_HOST $HUB_TOKEN $*"
+      """
+    When I successfully run `hub hello world`
+    Then the output should contain exactly:
+      """
+      github/hub github.com OTOKEN world\n
+      """
+
+  Scenario: Commands of hub take precedence
+    Given an executable "hub-version" on PATH with:
+      """
+      echo "extension"
+      """
+    When I successfully run `hub version`
+    Then the output should not contain "extension"
+
+  Scenario: No installed extensions
+    When I successfully run `hub extension`
+    Then the output should not contain anything
+
+  Scenario: Extension repositories have to be prefixed
+    When I run `hub extension install mislav/triage`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: the name of an extension repository has to start with "hub-"\n
+      """
@@ -219,7 +219,7 @@ Feature: hub fork
       """
     And I am "mislav" on github.com with OAuth token "WRONGTOKEN"
     When I run `hub fork`
-    Then the exit status should be 1
+    Then the exit status should be 4
     And the stderr should contain exactly:
       """
       Error creating fork: Unauthorized (HTTP 401)\n
@@ -313,3 +313,48 @@ Feature: hub fork
     When I successfully run `hub fork --org=acme`
     Then the output should contain exactly "new remote: acme\n"
     Then the url for "acme" should be "git@github.com:acme/dotfiles.git"
+
+  Scenario: Fork with a custom name and only the default branch
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/my-dotfiles') { 404 }
+      post('/repos/evilchelu/dotfiles/forks') {
+        assert :name => "my-dotfiles", :default_branch_only => true
+        status 202
+        json :name => 'my-dotfiles', :owner => { :login => 'mislav' }
+      }
+      """
+    When I successfully run `hub fork --fork-name=my-dotfiles --default-branch-only`
+    Then the output should contain exactly "new remote: mislav\n"
+    And the url for "mislav" should be "git@github.com:mislav/my-dotfiles.git"
+
+  Scenario: Fork another repository and clone it
+    Given the current dir is not a repo
+    And the GitHub API server:
+      """
+      get('/repos/mislav/ronn') { 404 }
+      post('/repos/rtomayko/ronn/forks') {
+        status 202
+        json :name => 'ronn', :owner => { :login => 'mislav' }
+      }
+      """
+    When I successfully run `hub --noop fork --clone rtomayko/ronn`
+    Then the output should contain exactly:
+      """
+      git clone git@github.com:mislav/ronn.git ronn
+      git -C ronn remote add -f upstream git://github.com/rtomayko/ronn.git
+      cloned fork into ronn\n
+      """
+
+  Scenario: Fork another repository without adding a remote
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/ronn') { 404 }
+      post('/repos/rtomayko/ronn/forks') {
+        status 202
+        json :name => 'ronn', :owner => { :login => 'mislav' }
+      }
+      """
+    When I successfully run `hub fork rtomayko/ronn`
+    Then the output should contain exactly "https://github.com/mislav/ronn\n"
+    And there should be no "mislav" remote
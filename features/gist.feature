@@ -166,7 +166,7 @@ Feature: hub gist
       this is a test file
       """
     When I run `hub gist create testfile.txt`
-    Then the exit status should be 1
+    Then the exit status should be 4
     And the stderr should contain exactly:
       """
       Error creating gist: Not Found (HTTP 404)
@@ -187,9 +187,92 @@ Feature: hub gist
       this is a test file
       """
     When I run `hub gist create testfile.txt`
-    Then the exit status should be 1
+    Then the exit status should be 3
     And the stderr should contain exactly:
       """
       Error creating gist: Not Found (HTTP 404)\n
       """
 
+
+  Scenario: List secret gists
+    Given the GitHub API server:
+      """
+      get('/gists') {
+        json [
+          { :id => "aa5a315d", :description => "crash log", :public => false,
+            :files => { "output.log" => {} } },
+          { :id => "bb6b426e", :description => "", :public => true,
+            :files => { "a.rb" => {}, "b.rb" => {} } },
+          { :id => "cc7c537f", :description => "", :public => false,
+            :files => { "notes.md" => {}, "todo.md" => {} } },
+        ]
+      }
+      """
+    When I successfully run `hub gist list --secret`
+    Then the output should contain exactly:
+      """
+      aa5a315d  crash log  (1 file, secret)
+      cc7c537f  notes.md, todo.md  (2 files, secret)\n
+      """
+
+  Scenario: Update gist files
+    Given the GitHub API server:
+      """
+      patch('/gists/myhash') {
+        assert :files => { "testfile.txt" => { "content" => "updated content" } }
+        json :html_url => 'http://gists.github.com/myhash'
+      }
+      """
+    Given a file named "testfile.txt" with:
+      """
+      updated content
+      """
+    When I successfully run `hub gist edit https://gist.github.com/octokitten/myhash testfile.txt`
+    Then the output should contain exactly:
+      """
+      http://gists.github.com/myhash\n
+      """
+
+  Scenario: Delete a gist
+    Given the GitHub API server:
+      """
+      delete('/gists/myhash') {
+        status 204
+      }
+      """
+    When I successfully run `hub gist delete --yes myhash`
+    Then the output should contain exactly:
+      """
+      Deleted gist myhash\n
+      """
+
+  Scenario: Clone a gist
+    Given the GitHub API server:
+      """
+      get('/gists/myhash') {
+        json :git_pull_url => 'https://gist.github.com/myhash.git'
+      }
+      """
+    When I successfully run `hub gist clone myhash notes`
+    Then "git clone https://gist.github.com/myhash.git notes" should be run
+
+  Scenario: Create a named gist from stdin with a description
+    Given the GitHub API server:
+      """
+      post('/gists') {
+        assert :description => "crash log",
+               :public => false
+        halt 400 unless params[:files]["output.log"]["content"] == "segfault\n"
+        status 201
+        json :html_url => 'http://gists.github.com/somehash'
+      }
+      """
+    When I run `hub gist create --filename output.log --desc "crash log"` interactively
+    And I pass in:
+      """
+      segfault
+      """
+    Then the output should contain exactly:
+      """
+      http://gists.github.com/somehash
+      """
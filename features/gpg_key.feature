@@ -0,0 +1,52 @@
+Feature: hub gpg-key
+  Background:
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List GPG keys
+    Given the GitHub API server:
+      """
+      get('/user/gpg_keys') {
+        json [
+          { :id => 3, :key_id => "3262EFF25BA0D270",
+            :emails => [{ :email => "mislav@example.com", :verified => true }],
+            :expires_at => "2030-01-01T00:00:00Z" },
+          { :id => 14, :key_id => "A1B2C3D4E5F60718", :emails => [] },
+        ]
+      }
+      """
+    When I successfully run `hub gpg-key list`
+    Then the output should contain exactly:
+      """
+       3  3262EFF25BA0D270  mislav@example.com  (expires 2030-01-01)
+      14  A1B2C3D4E5F60718\n
+      """
+
+  Scenario: Add a GPG key
+    Given a file named "key.asc" with:
+      """
+      -----BEGIN PGP PUBLIC KEY BLOCK-----
+      mQINBF...
+      -----END PGP PUBLIC KEY BLOCK-----
+      """
+    Given the GitHub API server:
+      """
+      post('/user/gpg_keys') {
+        assert :name => "work",
+               :armored_public_key => "-----BEGIN PGP PUBLIC KEY BLOCK-----\nmQINBF...\n-----END PGP PUBLIC KEY BLOCK-----"
+        status 201
+        json :id => 15, :key_id => "3262EFF25BA0D270"
+      }
+      """
+    When I successfully run `hub gpg-key add --name work key.asc`
+    Then the output should contain exactly "Added GPG key 15 (3262EFF25BA0D270)\n"
+
+  Scenario: Remove a GPG key by its key ID
+    Given the GitHub API server:
+      """
+      get('/user/gpg_keys') {
+        json [{ :id => 3, :key_id => "3262EFF25BA0D270" }]
+      }
+      delete('/user/gpg_keys/3') { status 204 }
+      """
+    When I successfully run `hub gpg-key remove 3262eff25ba0d270`
+    Then the output should contain exactly "Removed GPG key 3 (3262EFF25BA0D270)\n"
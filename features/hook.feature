@@ -0,0 +1,101 @@
+Feature: hub hook
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List webhooks
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/hooks') {
+        json [
+          { :id => 1, :active => true, :events => ["push", "pull_request"],
+            :config => { :url => "https://ci.example.com/hook" } },
+          { :id => 23, :active => false, :events => ["*"],
+            :config => { :url => "https://chat.example.com" } },
+        ]
+      }
+      """
+    When I successfully run `hub hook list`
+    Then the output should contain exactly:
+      """
+       1  https://ci.example.com/hook  push,pull_request
+      23  https://chat.example.com     * (inactive)\n
+      """
+
+  Scenario: Create a webhook
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/hooks') {
+        assert :name => "web",
+               :active => true,
+               :events => ["push", "pull_request", "release"],
+               :config => { :url => "https://ci.example.com/hook",
+                            :content_type => "json",
+                            :secret => "s3cr3t" }
+        status 201
+        json :id => 42
+      }
+      """
+    When I successfully run `hub hook create --url https://ci.example.com/hook --event push,pull_request --event release --secret s3cr3t`
+    Then the output should contain exactly "42\n"
+
+  Scenario: Create a webhook without a URL
+    When I run `hub hook create --event push`
+    Then the exit status should be 1
+    And the stderr should contain "missing required option --url"
+
+  Scenario: Delete a webhook
+    Given the GitHub API server:
+      """
+      delete('/repos/github/hub/hooks/42') {
+        status 204
+      }
+      """
+    When I successfully run `hub hook delete 42`
+    Then the output should contain exactly "Deleted webhook 42\n"
+
+  Scenario: Ping a webhook
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/hooks/42/pings') {
+        status 204
+      }
+      """
+    When I successfully run `hub hook ping 42`
+    Then the output should contain exactly "Pinged webhook 42; see `hub hook deliveries 42` for the response\n"
+
+  Scenario: List deliveries
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/hooks/42/deliveries') {
+        assert :per_page => "3"
+        json [
+          { :id => 1002, :delivered_at => "2020-05-01T10:00:05Z", :status_code => 502,
+            :event => "pull_request", :action => "opened", :duration => 10.0, :redelivery => true },
+          { :id => 999, :delivered_at => "2020-05-01T09:59:00Z", :status_code => 200,
+            :event => "push", :duration => 0.314 },
+        ]
+      }
+      """
+    When I successfully run `hub hook deliveries -L 2 42`
+    Then the output should contain exactly:
+      """
+      1002  2020-05-01T10:00:05Z  502  pull_request.opened  10.00s  (redelivery)
+       999  2020-05-01T09:59:00Z  200  push                 0.31s\n
+      """
+
+  Scenario: Redeliver a payload
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/hooks/42/deliveries/1002/attempts') {
+        status 202
+        json({})
+      }
+      """
+    When I successfully run `hub hook deliveries --redeliver 1002 42`
+    Then the output should contain exactly "Redelivered 1002 of webhook 42\n"
+
+  Scenario: Forward without a local server
+    When I run `hub hook forward --event pull_request`
+    Then the exit status should be 1
+    And the stderr should contain "missing required option --port or --url"
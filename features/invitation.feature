@@ -0,0 +1,62 @@
+Feature: hub invitation
+  Background:
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List invitations
+    Given the GitHub API server:
+      """
+      get('/user/repository_invitations') {
+        json [
+          { :id => 7, :repository => { :full_name => "github/hub" },
+            :inviter => { :login => "octocat" }, :permissions => "write" },
+          { :id => 12, :repository => { :full_name => "mislav/dotfiles" },
+            :inviter => { :login => "mislav" }, :permissions => "admin" },
+        ]
+      }
+      """
+    When I successfully run `hub invitation list`
+    Then the output should contain exactly:
+      """
+       7  github/hub       write  (invited by octocat)
+      12  mislav/dotfiles  admin  (invited by mislav)\n
+      """
+
+  Scenario: Accept an invitation
+    Given the GitHub API server:
+      """
+      patch('/user/repository_invitations/7') {
+        status 204
+      }
+      """
+    When I successfully run `hub invitation accept 7`
+    Then the output should contain exactly "Accepted invitation 7\n"
+
+  Scenario: Accept all invitations
+    Given the GitHub API server:
+      """
+      get('/user/repository_invitations') {
+        json [
+          { :id => 7, :repository => { :full_name => "github/hub" } },
+          { :id => 12, :repository => { :full_name => "mislav/dotfiles" } },
+        ]
+      }
+      patch('/user/repository_invitations/7') { status 204 }
+      patch('/user/repository_invitations/12') { status 204 }
+      """
+    When I successfully run `hub invitation accept all`
+    Then the output should contain exactly:
+      """
+      Accepted invitation to github/hub
+      Accepted invitation to mislav/dotfiles\n
+      """
+
+  Scenario: Accept a missing invitation
+    Given the GitHub API server:
+      """
+      patch('/user/repository_invitations/99') {
+        status 404
+      }
+      """
+    When I run `hub invitation accept 99`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Unable to find invitation 99\n"
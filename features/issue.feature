@@ -3,6 +3,11 @@ Feature: hub issue
     Given I am in "git://github.com/github/hub.git" git repo
     And I am "cornwe19" on github.com with OAuth token "OTOKEN"
 
+  Scenario: Fetch issues offline
+    When I run `hub --offline issue`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Aborted: github.com can't be reached in offline mode (unset HUB_OFFLINE to connect)\n"
+
   Scenario: Fetch issues
     Given the GitHub API server:
     """
@@ -38,6 +43,89 @@ Feature: hub issue
            #13  Second issue\n
       """
 
+  Scenario: Fetch issues of another repository
+    Given the GitHub API server:
+    """
+    get('/repos/octocat/spoon-knife/issues') {
+      json [
+        { :number => 7,
+          :title => "Elsewhere",
+          :state => "open",
+          :user => { :login => "octocat" },
+        },
+      ]
+    }
+    """
+    When I successfully run `hub issue -R octocat/spoon-knife`
+    Then the output should contain exactly:
+      """
+           #7  Elsewhere\n
+      """
+
+  Scenario: Fetch issues as CSV
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/issues') {
+      json [
+        { :number => 102,
+          :title => "First issue, with a comma",
+          :state => "open",
+          :html_url => "https://github.com/github/hub/issues/102",
+          :user => { :login => "octocat" },
+          :labels => [{ :name => "bug" }, { :name => "feature" }],
+          :comments => 2,
+          :created_at => "2020-04-01T12:30:00Z",
+          :updated_at => "2020-04-02T08:00:00Z",
+        },
+      ]
+    }
+    """
+    When I successfully run `hub issue --output csv`
+    Then the output should contain exactly:
+      """
+      number,state,title,url,author,labels,assignees,milestone,comments,created_at,updated_at
+      102,open,"First issue, with a comma",https://github.com/github/hub/issues/102,octocat,"bug,feature",,,2,2020-04-01T12:30:00Z,2020-04-02T08:00:00Z
+
+      """
+
+  Scenario: Fetch issues as TSV
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/issues') {
+      json [
+        { :number => 102,
+          :title => "Tabs\tin title",
+          :state => "open",
+          :html_url => "https://github.com/github/hub/issues/102",
+          :user => { :login => "octocat" },
+          :labels => [],
+          :comments => 0,
+          :created_at => "2020-04-01T12:30:00Z",
+          :updated_at => "2020-04-02T08:00:00Z",
+        },
+      ]
+    }
+    """
+    When I successfully run `hub issue --output tsv`
+    Then the output should contain exactly:
+      """
+      number	state	title	url	author	labels	assignees	milestone	comments	created_at	updated_at
+      102	open	Tabs\tin title	https://github.com/github/hub/issues/102	octocat				0	2020-04-01T12:30:00Z	2020-04-02T08:00:00Z
+
+      """
+
+  Scenario: Fetch no issues as CSV
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/issues') { json [] }
+    """
+    When I successfully run `hub issue --output csv`
+    Then the output should contain exactly:
+      """
+      number,state,title,url,author,labels,assignees,milestone,comments,created_at,updated_at
+
+      """
+
   Scenario: List limited number of issues
     Given the GitHub API server:
     """
@@ -164,6 +252,19 @@ Feature: hub issue
     """
     When I successfully run `hub issue -@ octocat`
 
+  Scenario: Fetch issues assigned to and mentioning the current user
+    Given the GitHub API server:
+    """
+    get('/user') {
+      json :login => "mislav"
+    }
+    get('/repos/github/hub/issues') {
+      assert :assignee => "mislav", :mentioned => "mislav"
+      json []
+    }
+    """
+    When I successfully run `hub issue -a @me -@ @me`
+
   Scenario: Fetch issues with certain labels
     Given the GitHub API server:
     """
@@ -369,6 +470,11 @@ Feature: hub issue
     Then the output should contain exactly ""
     Then "open the://url" should be run
 
+  Scenario: Fill in the form for a new issue on the web
+    When I successfully run `hub issue create --web -m "Not workie" -m "Pls fix" -l bug,crash -M v2`
+    Then the output should contain exactly ""
+    Then "open https://github.com/github/hub/issues/new?body=Pls+fix&labels=bug%2Ccrash&milestone=v2&title=Not+workie" should be run
+
   Scenario: Create an issue with labels
     Given the GitHub API server:
       """
@@ -540,6 +646,56 @@ Feature: hub issue
       https://github.com/github/hub/issues/1337\n
       """
 
+  Scenario: Choose an issue template by name
+    Given the git commit editor is "vim"
+    And the text editor adds:
+      """
+      hello
+      """
+    And a file named ".github/ISSUE_TEMPLATE/bug_report.md" with:
+      """
+      ---
+      name: Bug report
+      about: Report a bug
+      labels: bug, triage
+      assignees: octocat
+      ---
+      I want to report a bug
+      """
+    And a file named ".github/ISSUE_TEMPLATE/feature_request.md" with:
+      """
+      There is a feature that I need!
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues') {
+        assert :title => "hello",
+               :body => "I want to report a bug",
+               :labels => ["bug", "triage"],
+               :assignees => ["octocat"]
+
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337"
+      }
+      """
+    When I successfully run `hub issue create --template "bug report"`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337\n
+      """
+
+  Scenario: Unknown issue template
+    Given a file named ".github/ISSUE_TEMPLATE/bug_report.md" with:
+      """
+      I want to report a bug
+      """
+    When I run `hub issue create --template question`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      no issue template found named 'question'\n
+      """
+
   Scenario: Multiple issue templates with default
     Given the git commit editor is "vim"
     And the text editor adds:
@@ -734,7 +890,7 @@ Feature: hub issue
 
   Scenario: Did not supply an issue number
     When I run `hub issue show`
-    Then the exit status should be 1
+    Then the exit status should be 2
     Then the stderr should contain "Usage: hub issue"
 
   Scenario: Show error message if http code is not 200 for issues endpoint
@@ -770,3 +926,517 @@ Feature: hub issue
       """
       Error fetching comments for issue: Not Found (HTTP 404)\n
       """
+
+  Scenario: Format the markdown of an issue in a terminal
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/102') {
+        json \
+          :number => 102,
+          :state => "open",
+          :body => "<!-- describe the feature -->\nI want **this** feature:\n\n* `hub pr`\n* `hub issue`",
+          :title => "Feature request",
+          :created_at => "2017-04-14T16:00:49Z",
+          :user => { :login => "royels" },
+          :comments => 0
+      }
+      get('/repos/github/hub/issues/102/comments') {
+        json []
+      }
+      """
+    When I run `hub issue show 102 --color=never` in a terminal
+    Then the terminal output should contain exactly:
+      """
+      # Feature request
+
+      * created by @royels on 2017-04-14 16:00:49 +0000 UTC
+
+      I want this feature:
+
+      • `hub pr`
+      • `hub issue`\n
+      """
+
+  Scenario: Style the markdown of an issue in a color terminal
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/102') {
+        json \
+          :number => 102,
+          :state => "open",
+          :body => "<!-- describe the feature -->\nI want **this** feature:\n\n* `hub pr`\n* `hub issue`",
+          :title => "Feature request",
+          :created_at => "2017-04-14T16:00:49Z",
+          :user => { :login => "royels" },
+          :comments => 0
+      }
+      get('/repos/github/hub/issues/102/comments') {
+        json []
+      }
+      """
+    When I run `hub issue show 102` in a terminal
+    Then the terminal output should contain:
+      """
+      I want \e[1mthis\e[22m feature:
+
+      • \e[36mhub pr\e[39m
+      """
+
+  Scenario: Show the raw markdown of an issue in a terminal
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/102') {
+        json \
+          :number => 102,
+          :state => "open",
+          :body => "<!-- describe the feature -->\nI want **this** feature:\n\n* `hub pr`\n* `hub issue`",
+          :title => "Feature request",
+          :created_at => "2017-04-14T16:00:49Z",
+          :user => { :login => "royels" },
+          :comments => 0
+      }
+      get('/repos/github/hub/issues/102/comments') {
+        json []
+      }
+      """
+    When I run `hub issue show 102 --raw` in a terminal
+    Then the terminal output should contain exactly:
+      """
+      # Feature request
+
+      * created by @royels on 2017-04-14 16:00:49 +0000 UTC
+
+      <!-- describe the feature -->
+      I want **this** feature:
+
+      * `hub pr`
+      * `hub issue`\n
+      """
+
+  Scenario: Show issue as JSON with comments and reactions
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/102') {
+        json \
+          :number => 102,
+          :state => "open",
+          :body => "I want this feature",
+          :title => "Feature request",
+          :html_url => "https://github.com/github/hub/issues/102",
+          :created_at => "2017-04-14T16:00:49Z",
+          :updated_at => "2017-04-14T16:00:49Z",
+          :user => { :login => "royels" },
+          :comments => 1,
+          :reactions => { :total_count => 2, :"+1" => 2 }
+      }
+      get('/repos/github/hub/issues/102/comments') {
+        json [
+          { :id => 7,
+            :body => "Me too",
+            :html_url => "https://github.com/github/hub/issues/102#issuecomment-7",
+            :author_association => "NONE",
+            :created_at => "2017-04-15T10:00:00Z",
+            :updated_at => "2017-04-15T10:00:00Z",
+            :user => { :login => "octocat" },
+            :reactions => { :total_count => 1, :heart => 1 }
+          },
+        ]
+      }
+      """
+    When I successfully run `hub issue show 102 --format json`
+    Then the output should contain exactly:
+      """
+      {"number":102,"state":"open","title":"Feature request","url":"https://github.com/github/hub/issues/102","author":"royels","labels":[],"assignees":[],"milestone":"","comments":[{"id":7,"author":"octocat","author_association":"NONE","body":"Me too","url":"https://github.com/github/hub/issues/102#issuecomment-7","reactions":{"+1":0,"-1":0,"laugh":0,"hooray":0,"confused":0,"heart":1,"rocket":0,"eyes":0},"created_at":"2017-04-15T10:00:00Z","updated_at":"2017-04-15T10:00:00Z"}],"created_at":"2017-04-14T16:00:49Z","updated_at":"2017-04-14T16:00:49Z","body":"I want this feature","reactions":{"+1":2,"-1":0,"laugh":0,"hooray":0,"confused":0,"heart":0,"rocket":0,"eyes":0}}\n
+      """
+
+  Scenario: Format single issue with comments
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/102') {
+        json \
+          :number => 102,
+          :state => "open",
+          :title => "Feature request",
+          :user => { :login => "royels" },
+          :comments => 1
+      }
+      get('/repos/github/hub/issues/102/comments') {
+        json [
+          { :body => "Me too",
+            :created_at => "2017-04-15T10:00:00Z",
+            :user => { :login => "octocat" },
+            :reactions => { :total_count => 1, :heart => 1 }
+          },
+        ]
+      }
+      """
+    When I successfully run `hub issue show 102 --comments --format='%i %t%n'`
+    Then the output should contain exactly:
+      """
+      #102 Feature request
+
+      ## Comments:
+
+      ### comment by @octocat on 2017-04-15 10:00:00 +0000 UTC
+
+      Me too
+
+      * reactions: heart (1)\n
+      """
+
+  Scenario: Edit an issue
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/issues/102') {
+        assert :title => "New title",
+               :body => "New body"
+        json({})
+      }
+      post('/repos/github/hub/issues/102/labels') {
+        assert :labels => ["bug", "help wanted"]
+        json [{ :name => "bug" }, { :name => "help wanted" }]
+      }
+      delete('/repos/github/hub/issues/102/labels/needs%20triage') {
+        json []
+      }
+      delete('/repos/github/hub/issues/102/assignees') {
+        assert :assignees => ["octocat"]
+        json({})
+      }
+      """
+    When I successfully run `hub issue edit 102 -m "New title" -m "New body" --add-label "bug,help wanted" --remove-label "needs triage" --remove-assignee octocat`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/102\n
+      """
+
+  Scenario: Close an issue with a comment
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues/102/comments') {
+        assert :body => "Fixed in #103"
+        status 201
+        json :id => 1
+      }
+      patch('/repos/github/hub/issues/102') {
+        assert :state => "closed"
+        json({})
+      }
+      """
+    When I successfully run `hub issue close --comment "Fixed in #103" 102`
+    Then the output should contain exactly:
+      """
+      Closed issue #102\n
+      """
+
+  Scenario: Lock an issue
+    Given the GitHub API server:
+      """
+      put('/repos/github/hub/issues/102/lock') {
+        assert :lock_reason => "too heated"
+        status 204
+      }
+      """
+    When I successfully run `hub issue lock --reason "too heated" 102`
+    Then the output should contain exactly:
+      """
+      Locked issue #102\n
+      """
+
+  Scenario: Close issues from stdin
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/issues/102') {
+        assert :state => "closed"
+        json({})
+      }
+      patch('/repos/github/hub/issues/103') {
+        assert :state => "closed"
+        json({})
+      }
+      patch('/repos/github/hub-docs/issues/7') {
+        assert :state => "closed"
+        json({})
+      }
+      """
+    When I run `hub issue close --stdin` interactively
+    And I type "# stale issues"
+    And I type "102"
+    And I type "#103"
+    And I type "https://github.com/github/hub-docs/issues/7"
+    And I close the stdin stream
+    Then the exit status should be 0
+    And the output should contain exactly:
+      """
+      Closed issue #102
+      Closed issue #103
+      Closed issue github/hub-docs#7\n
+      """
+
+  Scenario: Label issues from stdin with failures
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues/102/labels') {
+        assert :labels => ["triaged"]
+        json [{ :name => "triaged" }]
+      }
+      post('/repos/github/hub/issues/999/labels') {
+        status 404
+        json :message => "Not Found"
+      }
+      """
+    When I run `hub issue edit --add-label triaged --stdin` interactively
+    And I type "999"
+    And I type "102"
+    And I close the stdin stream
+    Then the exit status should be 1
+    And the stdout should contain exactly:
+      """
+      Updated issue #102\n
+      """
+    And the stderr should contain:
+      """
+      Error: 1 operation(s) failed:
+      """
+    And the stderr should contain "#999: "
+
+  Scenario: Invalid issue from stdin
+    When I run `hub issue lock --stdin` interactively
+    And I type "102"
+    And I type "not-an-issue"
+    And I close the stdin stream
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid issue on line 2: 'not-an-issue'\n
+      """
+
+  Scenario: Export issues with comments
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues') {
+        assert :state => "all", :sort => "created", :direction => "asc"
+        json [
+          { :number => 102,
+            :title => "Crash",
+            :body => "It crashes.",
+            :state => "closed",
+            :html_url => "https://github.com/github/hub/issues/102",
+            :user => { :login => "octocat" },
+            :labels => [{ :name => "bug", :color => "d73a4a" }],
+            :assignees => [],
+            :comments => 1,
+            :created_at => "2020-04-01T12:30:00Z",
+            :closed_at => "2020-04-02T08:00:00Z",
+          },
+          { :number => 103,
+            :title => "A pull request",
+            :pull_request => {},
+          },
+        ]
+      }
+      get('/repos/github/hub/issues/102/comments') {
+        json [
+          { :body => "Fixed.",
+            :user => { :login => "mislav" },
+            :created_at => "2020-04-02T08:00:00Z",
+            :html_url => "https://github.com/github/hub/issues/102#issuecomment-1",
+          },
+        ]
+      }
+      """
+    When I successfully run `hub issue export --ndjson`
+    Then the output should contain exactly:
+      """
+      {"number":102,"url":"https://github.com/github/hub/issues/102","title":"Crash","body":"It crashes.","state":"closed","author":"octocat","labels":[{"name":"bug","color":"d73a4a"}],"assignees":[],"milestone":null,"created_at":"2020-04-01T12:30:00Z","closed_at":"2020-04-02T08:00:00Z","comments":[{"author":"mislav","body":"Fixed.","created_at":"2020-04-02T08:00:00Z","url":"https://github.com/github/hub/issues/102#issuecomment-1"}]}
+
+      """
+
+  Scenario: Import issues
+    Given a file named "issues.json" with:
+      """
+      [{"number":7,"url":"https://github.com/mislav/old/issues/7","title":"Crash","body":"It crashes.","state":"closed","author":"octocat","labels":[{"name":"bug","color":"d73a4a"}],"assignees":[],"milestone":{"title":"v2","description":"","state":"open","due_on":null},"created_at":"2020-04-01T12:30:00Z","closed_at":"2020-04-02T08:00:00Z","comments":[{"author":"mislav","body":"Fixed.","created_at":"2020-04-02T08:00:00Z","url":"https://github.com/mislav/old/issues/7#issuecomment-1"}]}]
+      """
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/labels') {
+        json [{ :name => "bug", :color => "d73a4a" }]
+      }
+      get('/repos/github/hub/milestones') {
+        assert :state => "all"
+        json [{ :number => 3, :title => "v1" }]
+      }
+      post('/repos/github/hub/milestones') {
+        assert :title => "v2"
+        status 201
+        json :number => 4, :title => "v2"
+      }
+      post('/repos/github/hub/issues') {
+        assert :title => "Crash",
+               :body => "_Imported from https://github.com/mislav/old/issues/7, originally opened by @octocat on 2020-04-01._\n\nIt crashes.",
+               :labels => ["bug"],
+               :milestone => 4
+        status 201
+        json :number => 12, :state => "open", :html_url => "https://github.com/github/hub/issues/12"
+      }
+      post('/repos/github/hub/issues/12/comments') {
+        assert :body => "_Originally posted by @mislav on 2020-04-02 in https://github.com/mislav/old/issues/7#issuecomment-1._\n\nFixed."
+        status 201
+        json :id => 1
+      }
+      patch('/repos/github/hub/issues/12') {
+        assert :state => "closed"
+        json({})
+      }
+      """
+    When I successfully run `hub issue import issues.json`
+    Then the output should contain exactly:
+      """
+      Created milestone 'v2'
+      Imported https://github.com/mislav/old/issues/7 as https://github.com/github/hub/issues/12\n
+      """
+
+  Scenario: Transfer an issue
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/102') {
+        json :number => 102, :node_id => "ISSUE_NODE"
+      }
+      get('/repos/github/hub-docs') {
+        json :name => "hub-docs", :node_id => "REPO_NODE"
+      }
+      post('/graphql') {
+        halt 400 unless params[:query].include?("transferIssue")
+        assert :variables => { :issueId => "ISSUE_NODE", :repositoryId => "REPO_NODE" }
+        json :data => {
+          :transferIssue => { :issue => { :number => 7, :url => "https://github.com/github/hub-docs/issues/7" } }
+        }
+      }
+      """
+    When I successfully run `hub issue transfer 102 hub-docs`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub-docs/issues/7\n
+      """
+
+  Scenario: Pin an issue
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/102') {
+        json :number => 102, :node_id => "ISSUE_NODE"
+      }
+      post('/graphql') {
+        halt 400 unless params[:query].include?("pinIssue")
+        assert :variables => { :issueId => "ISSUE_NODE" }
+        json :data => { :pinIssue => { :issue => { :number => 102 } } }
+      }
+      """
+    When I successfully run `hub issue pin 102`
+    Then the output should contain exactly:
+      """
+      Pinned issue #102\n
+      """
+
+  Scenario: GraphQL errors when pinning an issue
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/102') {
+        json :number => 102, :node_id => "ISSUE_NODE"
+      }
+      post('/graphql') {
+        json :data => nil, :errors => [{ :message => "Issues can only be pinned in public repositories" }]
+      }
+      """
+    When I run `hub issue pin 102`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Error pinning issue: Issues can only be pinned in public repositories\n
+      """
+
+  Scenario: Print the URL of an issue
+    When I successfully run `hub issue show -u 102`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/102\n
+      """
+
+  Scenario: React to an issue
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues/42/reactions') {
+        assert :content => "hooray"
+        status 201
+        json :id => 1, :content => "hooray"
+      }
+      """
+    When I successfully run `hub issue react 42 :tada:`
+    Then the output should contain exactly:
+      """
+      Reacted with hooray to issue #42\n
+      """
+
+  Scenario: React to an issue comment
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues/comments/1234/reactions') {
+        assert :content => "+1"
+        status 200
+        json :id => 1, :content => "+1"
+      }
+      """
+    When I successfully run `hub issue react --comment 1234 42 +1`
+    Then the output should contain exactly:
+      """
+      Already reacted with +1 to comment 1234 on issue #42\n
+      """
+
+  Scenario: Remove a reaction from an issue
+    Given the GitHub API server:
+      """
+      get('/user') { json :login => 'cornwe19' }
+      get('/repos/github/hub/issues/42/reactions') {
+        assert :content => "heart"
+        json [
+          { :id => 7, :content => "heart", :user => { :login => "octocat" } },
+          { :id => 8, :content => "heart", :user => { :login => "Cornwe19" } },
+        ]
+      }
+      delete('/repos/github/hub/issues/42/reactions/8') { status 204 }
+      """
+    When I successfully run `hub issue react --remove 42 heart`
+    Then the output should contain exactly:
+      """
+      Removed heart reaction from issue #42\n
+      """
+
+  Scenario: Invalid reaction
+    When I run `hub issue react 42 party`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid reaction: 'party' (expected one of: +1, -1, laugh, hooray, confused, heart, rocket, eyes)\n
+      """
+
+  Scenario: List issues with reactions
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues') {
+        json [
+          { :number => 102, :title => "First issue", :state => "open",
+            :user => { :login => "octocat" },
+            :reactions => { :total_count => 4, :"+1" => 3, :heart => 1 },
+          },
+          { :number => 13, :title => "Second issue", :state => "open",
+            :user => { :login => "octocat" },
+            :reactions => { :total_count => 0 },
+          },
+        ]
+      }
+      """
+    When I successfully run `hub issue --reactions`
+    Then the output should contain exactly:
+      """
+          #102  First issue   +1 (3), heart (1)
+           #13  Second issue\n
+      """
@@ -0,0 +1,39 @@
+Feature: hub org
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List members of the organization of the current repository
+    Given the GitHub API server:
+      """
+      get('/orgs/github/members') {
+        assert :role => "all", :per_page => "100"
+        json [{ :login => "mislav" }, { :login => "octocat" }]
+      }
+      """
+    When I successfully run `hub org members`
+    Then the output should contain exactly:
+      """
+      mislav
+      octocat\n
+      """
+
+  Scenario: List admins of another organization
+    Given the GitHub API server:
+      """
+      get('/orgs/acme/members') {
+        assert :role => "admin", :per_page => "2"
+        json [{ :login => "wile" }, { :login => "coyote" }]
+      }
+      """
+    When I successfully run `hub org members --role admin -L 2 acme`
+    Then the output should contain exactly:
+      """
+      wile
+      coyote\n
+      """
+
+  Scenario: Invalid organization name
+    When I run `hub org members acme/anvils`
+    Then the stderr should contain exactly "invalid organization name: 'acme/anvils'\n"
+    And the exit status should be 1
@@ -0,0 +1,61 @@
+Feature: Paging output
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => { :repository => { :pullRequests => {
+          :nodes => [
+            { :number => 102, :title => "Fix login", :state => "OPEN" },
+          ],
+          :pageInfo => { :hasNextPage => false },
+        } } }
+      }
+      """
+    And an executable "mypager" on PATH with:
+      """
+      sed 's/^/| /'
+      """
+    And $PAGER is "mypager"
+
+  Scenario: Page output through $PAGER in a terminal
+    When I run `hub pr list --format "%i %t%n"` in a terminal
+    Then the terminal output should contain exactly:
+      """
+      | #102 Fix login\n
+      """
+
+  Scenario: Don't page output that isn't going to a terminal
+    When I successfully run `hub pr list --format "%i %t%n"`
+    Then the output should contain exactly:
+      """
+      #102 Fix login\n
+      """
+
+  Scenario: Turn off paging for one command
+    When I run `hub --no-pager pr list --format "%i %t%n"` in a terminal
+    Then the terminal output should contain exactly:
+      """
+      #102 Fix login\n
+      """
+
+  Scenario: Pager setting takes precedence over $PAGER
+    Given an executable "otherpager" on PATH with:
+      """
+      sed 's/^/> /'
+      """
+    And I successfully run `hub config set pager otherpager`
+    When I run `hub pr list --format "%i %t%n"` in a terminal
+    Then the terminal output should contain exactly:
+      """
+      > #102 Fix login\n
+      """
+
+  Scenario: Turn off paging with the pager setting
+    Given I successfully run `hub config set pager cat`
+    When I run `hub pr list --format "%i %t%n"` in a terminal
+    Then the terminal output should contain exactly:
+      """
+      #102 Fix login\n
+      """
@@ -0,0 +1,203 @@
+Feature: hub pr apply-suggestions
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And I am on the "fix-typo" branch with upstream "origin/fix-typo"
+    And a file named "README.md" with:
+      """
+      # Hub
+      A wrapper fro git
+      Install it with brew
+      """
+    And I successfully run `git add README.md`
+    And I successfully run `git commit -q -m "Add readme"`
+
+  Scenario: Apply a single suggestion
+    Given the GitHub API server at the current HEAD:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "fix-typo", :sha => "<HEAD>" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/pulls/12/comments') {
+        json [
+          { :id => 1, :path => "README.md", :line => 2, :side => "RIGHT",
+            :body => "Typo:\r\n```suggestion\r\nA wrapper for git\r\n```",
+            :html_url => "https://github.com/github/hub/pull/12#discussion_r1",
+            :user => { :login => "octocat", :id => 583231 } },
+          { :id => 2, :path => "README.md", :line => 2, :side => "RIGHT",
+            :in_reply_to_id => 1, :body => "Good catch!",
+            :html_url => "https://github.com/github/hub/pull/12#discussion_r2",
+            :user => { :login => "mislav", :id => 887 } },
+        ]
+      }
+      """
+    When I successfully run `hub pr apply-suggestions 12`
+    Then the output should contain exactly:
+      """
+      Applied 1 suggestion from pull request #12\n
+      """
+    And the file "README.md" should contain exactly:
+      """
+      # Hub
+      A wrapper for git
+      Install it with brew
+      """
+    When I successfully run `git log -1 --format=%B`
+    Then the output should contain:
+      """
+      Apply suggestion from code review
+
+      https://github.com/github/hub/pull/12#discussion_r1
+
+      Co-authored-by: octocat <583231+octocat@users.noreply.github.com>
+      """
+
+  Scenario: Commit each suggestion separately
+    Given the GitHub API server at the current HEAD:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "fix-typo", :sha => "<HEAD>" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/pulls/12/comments') {
+        json [
+          { :id => 1, :path => "README.md", :line => 2, :side => "RIGHT",
+            :body => "```suggestion\nA wrapper for git\n```",
+            :html_url => "https://github.com/github/hub/pull/12#discussion_r1",
+            :user => { :login => "octocat", :id => 583231 } },
+          { :id => 3, :path => "README.md", :line => 3, :side => "RIGHT",
+            :body => "```suggestion\nInstall it with Homebrew\n```",
+            :html_url => "https://github.com/github/hub/pull/12#discussion_r3",
+            :user => { :login => "hubot", :id => 480938 } },
+        ]
+      }
+      """
+    When I successfully run `hub pr apply-suggestions 12`
+    Then the output should contain exactly:
+      """
+      Applied 2 suggestions from pull request #12\n
+      """
+    When I successfully run `git log -3 --format=%s`
+    Then the output should contain:
+      """
+      Apply suggestion from code review
+      Apply suggestion from code review
+      Add readme
+      """
+
+  Scenario: Batch suggestions into one commit
+    Given the GitHub API server at the current HEAD:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "fix-typo", :sha => "<HEAD>" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/pulls/12/comments') {
+        json [
+          { :id => 1, :path => "README.md", :line => 2, :side => "RIGHT",
+            :body => "```suggestion\nA wrapper for git\n```",
+            :html_url => "https://github.com/github/hub/pull/12#discussion_r1",
+            :user => { :login => "octocat", :id => 583231 } },
+          { :id => 3, :path => "README.md", :line => 3, :side => "RIGHT",
+            :body => "```suggestion\nInstall it with Homebrew\n```",
+            :html_url => "https://github.com/github/hub/pull/12#discussion_r3",
+            :user => { :login => "hubot", :id => 480938 } },
+        ]
+      }
+      """
+    When I successfully run `hub pr apply-suggestions --batch 12`
+    Then the output should contain exactly:
+      """
+      Applied 2 suggestions from pull request #12\n
+      """
+    And the file "README.md" should contain exactly:
+      """
+      # Hub
+      A wrapper for git
+      Install it with Homebrew
+      """
+    When I successfully run `git log -2 --format=%B`
+    Then the output should contain:
+      """
+      Apply suggestions from code review
+
+      Co-authored-by: hubot <480938+hubot@users.noreply.github.com>
+      Co-authored-by: octocat <583231+octocat@users.noreply.github.com>
+
+      Add readme
+      """
+
+  Scenario: Push the applied suggestions
+    Given the GitHub API server at the current HEAD:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "fix-typo", :sha => "<HEAD>" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/pulls/12/comments') {
+        json [
+          { :id => 1, :path => "README.md", :line => 2, :side => "RIGHT",
+            :body => "```suggestion\nA wrapper for git\n```",
+            :html_url => "https://github.com/github/hub/pull/12#discussion_r1",
+            :user => { :login => "octocat", :id => 583231 } },
+        ]
+      }
+      """
+    When I successfully run `hub pr apply-suggestions --push 12`
+    Then "git push origin HEAD:refs/heads/fix-typo" should be run
+
+  Scenario: Skip suggestions that no longer apply
+    Given the GitHub API server at the current HEAD:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "fix-typo", :sha => "<HEAD>" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/pulls/12/comments') {
+        json [
+          { :id => 1, :path => "README.md", :line => 2, :side => "RIGHT",
+            :body => "```suggestion\nA wrapper fro git\n```",
+            :html_url => "https://github.com/github/hub/pull/12#discussion_r1",
+            :user => { :login => "octocat", :id => 583231 } },
+          { :id => 4, :path => "README.md", :line => 9, :side => "RIGHT",
+            :body => "```suggestion\nLicensed under MIT\n```",
+            :html_url => "https://github.com/github/hub/pull/12#discussion_r4",
+            :user => { :login => "hubot", :id => 480938 } },
+        ]
+      }
+      """
+    When I successfully run `hub pr apply-suggestions --push 12`
+    Then the stdout should contain exactly:
+      """
+      No suggestions to apply\n
+      """
+    And the stderr should contain exactly:
+      """
+      warning: skipping suggestion https://github.com/github/hub/pull/12#discussion_r4: README.md has no line 9
+      warning: skipping suggestion https://github.com/github/hub/pull/12#discussion_r1 that is already applied\n
+      """
+    And "git push" should not be run
+    And the latest commit message should be "Add readme"
+
+  Scenario: Refuse to apply suggestions to an outdated branch
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "fix-typo", :sha => "abcdef1234567890abcdef1234567890abcdef12" },
+          :base => { :ref => "master" }
+      }
+      """
+    When I run `hub pr apply-suggestions 12`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: the current branch isn't at the head of pull request #12
+      (use `hub pr checkout 12` to check it out)\n
+      """
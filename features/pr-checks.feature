@@ -0,0 +1,103 @@
+Feature: hub pr checks
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: All checks passed
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "fix-login", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/commits/HEADSHA/status') {
+        json :state => "success", :statuses => [
+          { :state => "success", :context => "lint", :target_url => "https://ci/lint" }
+        ]
+      }
+      get('/repos/github/hub/commits/HEADSHA/check-runs') {
+        json :check_runs => [
+          { :id => 1, :status => "completed", :conclusion => "failure", :name => "build", :html_url => "https://ci/build/1" },
+          { :id => 2, :status => "completed", :conclusion => "success", :name => "build", :html_url => "https://ci/build/2" },
+        ]
+      }
+      get('/repos/github/hub/branches/master') {
+        json :name => "master", :protected => false
+      }
+      """
+    When I run `hub pr checks 12`
+    Then the exit status should be 0
+    And the output should contain exactly:
+      """
+      ✔︎	build 	https://ci/build/2
+      ✔︎	lint  	https://ci/lint\n
+      """
+
+  Scenario: Required check hasn't started
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "fix-login", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/commits/HEADSHA/status') {
+        json :state => "success", :statuses => [
+          { :state => "success", :context => "lint", :target_url => "https://ci/lint" }
+        ]
+      }
+      get('/repos/github/hub/commits/HEADSHA/check-runs') {
+        json :check_runs => [
+          { :id => 1, :status => "completed", :conclusion => "failure", :name => "build", :html_url => "https://ci/build/1" },
+          { :id => 2, :status => "completed", :conclusion => "success", :name => "build", :html_url => "https://ci/build/2" },
+        ]
+      }
+      get('/repos/github/hub/branches/master') {
+        json :name => "master", :protected => true,
+          :protection => { :required_status_checks => { :contexts => ["build", "deploy"] } }
+      }
+      """
+    When I run `hub pr checks --required-only 12`
+    Then the exit status should be 8
+    And the output should contain exactly:
+      """
+      ●	deploy
+      ✔︎	build 	https://ci/build/2\n
+      """
+
+  Scenario: No checks
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "fix-login", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/commits/HEADSHA/status') {
+        json :state => "pending", :statuses => []
+      }
+      get('/repos/github/hub/commits/HEADSHA/check-runs') {
+        json :check_runs => []
+      }
+      get('/repos/github/hub/branches/master') {
+        json :name => "master", :protected => false
+      }
+      """
+    When I run `hub pr checks 12`
+    Then the exit status should be 9
+    And the output should contain exactly:
+      """
+      no checks\n
+      """
+
+  Scenario: Pull request not found
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/999') {
+        status 404
+        json :message => "Not Found"
+      }
+      """
+    When I run `hub pr checks 999`
+    Then the exit status should be 3
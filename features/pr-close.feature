@@ -0,0 +1,54 @@
+Feature: hub pr close and reopen
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Close a pull request with a comment and delete its branch
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :state => "open",
+          :head => { :ref => "experiment", :label => "github:experiment", :sha => "HEADSHA",
+                     :repo => { :name => "hub", :owner => { :login => "github" } } },
+          :base => { :ref => "master", :label => "github:master",
+                     :repo => { :name => "hub", :owner => { :login => "github" } } }
+      }
+      post('/repos/github/hub/issues/12/comments') {
+        assert :body => "Superseded by #13"
+        status 201
+        json :id => 1
+      }
+      patch('/repos/github/hub/issues/12') {
+        assert :state => "closed"
+        json :number => 12, :state => "closed"
+      }
+      delete('/repos/github/hub/git/refs/heads/experiment') {
+        status 204
+      }
+      """
+    When I successfully run `hub pr close -d -m "Superseded by #13" 12`
+    Then the output should contain exactly:
+      """
+      Closed pull request #12
+      Deleted branch experiment on github/hub\n
+      """
+
+  Scenario: Reopen a pull request
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/issues/12') {
+        assert :state => "open"
+        json :number => 12, :state => "open"
+      }
+      """
+    When I successfully run `hub pr reopen 12`
+    Then the output should contain exactly:
+      """
+      Reopened pull request #12\n
+      """
+
+  Scenario: Deleting the branch requires closing
+    When I run `hub pr reopen -d 12`
+    Then the exit status should be 2
+    And the stderr should contain "unknown shorthand flag: 'd'"
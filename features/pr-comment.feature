@@ -0,0 +1,75 @@
+Feature: hub pr comment
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Comment on a pull request
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues/12/comments') {
+        assert :body => "Looks good\n\nThanks!"
+        status 201
+        json :id => 1, :html_url => "https://github.com/github/hub/pull/12#issuecomment-1"
+      }
+      """
+    When I successfully run `hub pr comment 12 -m "Looks good" -m "Thanks!"`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/pull/12#issuecomment-1\n
+      """
+
+  Scenario: Comment on a line of the diff
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "experiment", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      post('/repos/github/hub/pulls/12/comments') {
+        assert :body => "Typo",
+          :commit_id => "HEADSHA",
+          :path => "README.md",
+          :line => 42,
+          :side => "LEFT"
+        status 201
+        json :id => 2, :html_url => "https://github.com/github/hub/pull/12#discussion_r2"
+      }
+      """
+    When I successfully run `hub pr comment 12 --file README.md --line 42 --side left -m Typo`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/pull/12#discussion_r2\n
+      """
+
+  Scenario: Reply to a review thread
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/pulls/12/comments/2/replies') {
+        assert :body => "Fixed"
+        status 201
+        json :id => 3, :html_url => "https://github.com/github/hub/pull/12#discussion_r3"
+      }
+      """
+    When I successfully run `hub pr comment 12 --reply-to 2 -m Fixed`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/pull/12#discussion_r3\n
+      """
+
+  Scenario: Line comment without a line
+    When I run `hub pr comment 12 --file README.md -m Typo`
+    Then the exit status should be 1
+    And the stderr should contain "--file requires --line"
+
+  Scenario: Empty comment
+    Given the text editor adds:
+      """
+
+      """
+    When I run `hub pr comment 12`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborting due to empty comment\n
+      """
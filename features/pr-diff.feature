@@ -0,0 +1,74 @@
+Feature: hub pr diff
+  Background:
+    Given I am in "git://github.com/ashemesh/hub.git" git repo
+    And I am "ashemesh" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Diff of a pull request
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/12') {
+        halt 400 unless request.env['HTTP_ACCEPT'] == 'application/vnd.github.v3.diff;charset=utf-8'
+        content_type 'text/plain'
+        body "diff --git a/README b/README\n--- a/README\n+++ b/README\n@@ -1 +1 @@\n-hello\n+hello world\n"
+      }
+      """
+    When I successfully run `hub pr diff 12`
+    Then the output should contain exactly:
+      """
+      diff --git a/README b/README
+      --- a/README
+      +++ b/README
+      @@ -1 +1 @@
+      -hello
+      +hello world\n
+      """
+
+  Scenario: Colored diff
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/12') {
+        content_type 'text/plain'
+        body "@@ -1 +1 @@\n-hello\n+hello world\n"
+      }
+      """
+    When I successfully run `hub pr diff 12 --color`
+    Then the output should contain exactly:
+      """
+      \e[36m@@ -1 +1 @@\e[m
+      \e[31m-hello\e[m
+      \e[32m+hello world\e[m\n
+      """
+
+  Scenario: Names of changed files
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12/files') {
+        json [
+          { :filename => "README", :status => "modified", :additions => 1, :deletions => 1 },
+          { :filename => "docs/new.md", :status => "renamed", :previous_filename => "docs/old.md", :additions => 12, :deletions => 0 },
+        ]
+      }
+      """
+    When I successfully run `hub pr diff --name-only -R github/hub 12`
+    Then the output should contain exactly:
+      """
+      README
+      docs/new.md\n
+      """
+
+  Scenario: Changed files with line counts
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/12/files') {
+        json [
+          { :filename => "README", :status => "modified", :additions => 1, :deletions => 1 },
+          { :filename => "docs/new.md", :status => "renamed", :previous_filename => "docs/old.md", :additions => 12, :deletions => 0 },
+        ]
+      }
+      """
+    When I successfully run `hub pr files 12`
+    Then the output should contain exactly:
+      """
+       +1  -1  README
+      +12  -0  docs/old.md => docs/new.md\n
+      """
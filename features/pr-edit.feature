@@ -0,0 +1,67 @@
+Feature: hub pr edit
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Change reviewers
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :html_url => "https://github.com/github/hub/pull/12",
+          :requested_reviewers => [{ :login => "josh" }],
+          :requested_teams => []
+      }
+      delete('/repos/github/hub/pulls/12/requested_reviewers') {
+        assert :reviewers => ["pcorpet"],
+               :team_reviewers => []
+        json :number => 12
+      }
+      post('/repos/github/hub/pulls/12/requested_reviewers') {
+        assert :reviewers => ["mislav"],
+               :team_reviewers => ["hubbers"]
+        status 201
+        json :number => 12
+      }
+      """
+    When I successfully run `hub pr edit 12 --add-reviewer mislav,josh,github/hubbers --remove-reviewer pcorpet`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/pull/12\n
+      """
+
+  Scenario: Change title, base, labels, and milestone
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :html_url => "https://github.com/github/hub/pull/12"
+      }
+      patch('/repos/github/hub/pulls/12') {
+        assert :title => "Better title",
+               :base => "develop"
+        json :number => 12,
+          :html_url => "https://github.com/github/hub/pull/12"
+      }
+      patch('/repos/github/hub/issues/12') {
+        assert :milestone => nil
+        json :number => 12
+      }
+      post('/repos/github/hub/issues/12/labels') {
+        assert :labels => ["bug"]
+        json []
+      }
+      delete('/repos/github/hub/issues/12/labels/wip') {
+        json []
+      }
+      """
+    When I successfully run `hub pr edit 12 -t "Better title" -b develop --add-label bug --remove-label wip -M none`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/pull/12\n
+      """
+
+  Scenario: Nothing to edit
+    When I run `hub pr edit 12`
+    Then the exit status should be 1
+    And the stderr should contain "nothing to edit"
@@ -0,0 +1,42 @@
+Feature: hub pr links
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List linked issues
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        halt 400 unless params[:query].include?("closingIssuesReferences")
+        assert :variables => { :owner => "github", :name => "hub", :number => 12 }
+        json :data => { :repository => { :pullRequest => { :closingIssuesReferences => { :nodes => [
+          { :number => 42, :title => "Parser crashes", :state => "OPEN",
+            :repository => { :nameWithOwner => "github/hub" } },
+          { :number => 3, :title => "Document the parser", :state => "CLOSED",
+            :repository => { :nameWithOwner => "github/docs" } },
+        ] } } } }
+      }
+      """
+    When I successfully run `hub pr links 12`
+    Then the output should contain exactly:
+      """
+                #42  open    Parser crashes
+      github/docs#3  closed  Document the parser\n
+      """
+
+  Scenario: Change linked issues
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12, :body => "Refactor the parser.\n\nFixes #42"
+      }
+      patch('/repos/github/hub/pulls/12') {
+        assert :body => "Refactor the parser.\n\nCloses #57"
+        json :number => 12
+      }
+      """
+    When I successfully run `hub pr links --remove 42 --add 57 12`
+    Then the output should contain exactly:
+      """
+      Updated the linked issues of pull request #12\n
+      """
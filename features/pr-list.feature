@@ -6,52 +6,33 @@ Feature: hub pr list
   Scenario: List pulls
     Given the GitHub API server:
     """
-    get('/repos/github/hub/pulls') {
-      assert :per_page => "100",
-             :page => :no,
-             :sort => nil,
-             :direction => "desc"
+    post('/graphql') {
+      halt 400 if params[:query].include?("body")
+      halt 400 unless params[:query].include?("labels(first: 100)")
+      vars = params[:variables]
+      assert_variables :owner => "github", :name => "hub", :states => ["OPEN"],
+                       :orderBy => { :field => "CREATED_AT", :direction => "DESC" }
 
-      response.headers["Link"] = %(<https://api.github.com/repositories/12345?per_page=100&page=2>; rel="next")
-
-      json [
-        { :number => 999,
-          :title => "First",
-          :state => "open",
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-1", :label => "octocat:patch-1" },
-          :user => { :login => "octocat" },
-        },
-        { :number => 102,
-          :title => "Second",
-          :state => "open",
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-2", :label => "octocat:patch-2" },
-          :user => { :login => "octocat" },
-        },
-        { :number => 13,
-          :title => "Third",
-          :state => "open",
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-3", :label => "octocat:patch-3" },
-          :user => { :login => "octocat" },
-        },
-      ]
-    }
-
-    get('/repositories/12345') {
-      assert :per_page => "100",
-             :page => "2"
-
-      json [
-        { :number => 7,
-          :title => "Fourth",
-          :state => "open",
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-4", :label => "octocat:patch-4" },
-          :user => { :login => "octocat" },
-        },
-      ]
+      pr = lambda { |number, title, branch| {
+        :number => number, :title => title, :state => "OPEN",
+        :baseRefName => "master", :headRefName => branch,
+        :author => { :login => "octocat" },
+        :headRepositoryOwner => { :login => "octocat" },
+        :labels => { :nodes => [] },
+      } }
+
+      if vars[:after].nil?
+        json :data => { :repository => { :pullRequests => {
+          :nodes => [pr.(999, "First", "patch-1"), pr.(102, "Second", "patch-2"), pr.(13, "Third", "patch-3")],
+          :pageInfo => { :hasNextPage => true, :endCursor => "CURSOR" },
+        } } }
+      else
+        halt 400 unless vars[:after] == "CURSOR"
+        json :data => { :repository => { :pullRequests => {
+          :nodes => [pr.(7, "Fourth", "patch-4")],
+          :pageInfo => { :hasNextPage => false },
+        } } }
+      end
     }
     """
     When I successfully run `hub pr list`
@@ -66,43 +47,27 @@ Feature: hub pr list
   Scenario: List pull requests with requested reviewers
     Given the GitHub API server:
     """
-    get('/repos/github/hub/pulls') {
-      assert :per_page => "100",
-             :page => :no,
-             :sort => nil,
-             :direction => "desc"
-
-      json [
-        { :number => 999,
-          :title => "First",
-          :state => "open",
-          :base => {
-            :ref => "master",
-            :label => "github:master",
-            :repo => { :owner => { :login => "github" } }
+    post('/graphql') {
+      halt 400 unless params[:query].include?("reviewRequests(first: 100)")
+      halt 400 if params[:query].include?("labels(first: 100)")
+      json :data => { :repository => { :pullRequests => {
+        :nodes => [
+          { :number => 999, :state => "OPEN",
+            :reviewRequests => { :nodes => [
+              { :requestedReviewer => { :__typename => "User", :login => "rey" } },
+              { :requestedReviewer => { :__typename => "Team", :slug => "troopers" } },
+              { :requestedReviewer => { :__typename => "Team", :slug => "cantina-band" } },
+            ] },
+          },
+          { :number => 102, :state => "OPEN",
+            :reviewRequests => { :nodes => [
+              { :requestedReviewer => { :__typename => "User", :login => "luke" } },
+              { :requestedReviewer => { :__typename => "User", :login => "jyn" } },
+            ] },
           },
-          :head => { :ref => "patch-1", :label => "octocat:patch-1" },
-          :user => { :login => "octocat" },
-          :requested_reviewers => [
-            { :login => "rey" },
-          ],
-          :requested_teams => [
-            { :slug => "troopers" },
-            { :slug => "cantina-band" },
-          ]
-        },
-        { :number => 102,
-          :title => "Second",
-          :state => "open",
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-2", :label => "octocat:patch-2" },
-          :user => { :login => "octocat" },
-          :requested_reviewers => [
-            { :login => "luke" },
-            { :login => "jyn" },
-          ]
-        },
-      ]
+        ],
+        :pageInfo => { :hasNextPage => false },
+      } } }
     }
     """
     When I successfully run `hub pr list -f "%sC%>(8)%i %rs%n"`
@@ -115,50 +80,43 @@ Feature: hub pr list
   Scenario: List draft status
     Given the GitHub API server:
     """
-    get('/repos/github/hub/pulls') {
-      halt 400 unless env['HTTP_ACCEPT'] == 'application/vnd.github.shadow-cat-preview+json;charset=utf-8'
-
-      json [
-        { :number => 999,
-          :state => "open",
-          :draft => true,
-          :merged_at => nil,
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-2", :label => "octocat:patch-2" },
-          :user => { :login => "octocat" },
-        },
-        { :number => 102,
-          :state => "open",
-          :draft => false,
-          :merged_at => nil,
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-1", :label => "octocat:patch-1" },
-          :user => { :login => "octocat" },
-        },
-        { :number => 42,
-          :state => "closed",
-          :draft => false,
-          :merged_at => "2018-12-11T10:50:33Z",
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-3", :label => "octocat:patch-3" },
-          :user => { :login => "octocat" },
-        },
-        { :number => 8,
-          :state => "closed",
-          :draft => false,
-          :merged_at => nil,
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-4", :label => "octocat:patch-4" },
-          :user => { :login => "octocat" },
-        },
-      ]
+    post('/graphql') {
+      halt 400 unless params[:query].include?("isDraft")
+      assert_variables :states => ["OPEN"]
+      json :data => { :repository => { :pullRequests => {
+        :nodes => [
+          { :number => 999, :state => "OPEN", :isDraft => true },
+          { :number => 102, :state => "OPEN", :isDraft => false },
+        ],
+        :pageInfo => { :hasNextPage => false },
+      } } }
     }
     """
     When I successfully run `hub pr list --format "%I %pC %pS %Creset%n" --color`
     Then the output should contain exactly:
       """
       999 \e[37m draft \e[m
-      102 \e[32m open \e[m
+      102 \e[32m open \e[m\n
+      """
+
+  Scenario: List merged and closed status
+    Given the GitHub API server:
+    """
+    post('/graphql') {
+      halt 400 unless params[:query].include?("mergedAt")
+      assert_variables :states => ["CLOSED", "MERGED"]
+      json :data => { :repository => { :pullRequests => {
+        :nodes => [
+          { :number => 42, :state => "MERGED", :isDraft => false, :mergedAt => "2018-12-11T10:50:33Z" },
+          { :number => 8, :state => "CLOSED", :isDraft => false, :mergedAt => nil },
+        ],
+        :pageInfo => { :hasNextPage => false },
+      } } }
+    }
+    """
+    When I successfully run `hub pr list -s closed --format "%I %pC %pS %Creset%n" --color`
+    Then the output should contain exactly:
+      """
       42 \e[35m merged \e[m
       8 \e[31m closed \e[m\n
       """
@@ -166,73 +124,132 @@ Feature: hub pr list
   Scenario: Sort by number of comments ascending
     Given the GitHub API server:
     """
-    get('/repos/github/hub/pulls') {
-      assert :sort => "comments",
-             :direction => "asc"
-
-      json []
+    post('/graphql') {
+      assert_variables :orderBy => { :field => "COMMENTS", :direction => "ASC" }
+      json :data => { :repository => { :pullRequests => {
+        :nodes => [], :pageInfo => { :hasNextPage => false },
+      } } }
     }
     """
     When I successfully run `hub pr list -o comments -^`
     Then the output should contain exactly ""
 
-  Scenario: Filter by base and head
+  Scenario: Sort by a key that only the REST API supports
     Given the GitHub API server:
     """
     get('/repos/github/hub/pulls') {
-      assert :base => "develop",
-             :head => "github:patch-1"
+      assert :sort => "long-running",
+             :direction => "desc"
 
       json []
     }
     """
-    When I successfully run `hub pr list -b develop -h patch-1`
+    When I successfully run `hub pr list -o long-running`
     Then the output should contain exactly ""
 
-  Scenario: Filter by head with owner
+  Scenario: Fetch the checks of many pull requests at once
     Given the GitHub API server:
     """
+    status_requests = Queue.new
+    pull = lambda { |number| {
+      :number => number, :title => "PR #{number}", :state => "open",
+      :user => { :login => "octocat" },
+      :base => { :ref => "master", :repo => { :name => "hub", :owner => { :login => "github" } } },
+      :head => { :ref => "patch-#{number}", :label => "github:patch-#{number}",
+                 :repo => { :name => "hub", :owner => { :login => "github" } } },
+    } }
     get('/repos/github/hub/pulls') {
-      assert :head => "mislav:patch-1"
+      assert :sort => "long-running"
+      response.headers["Link"] = %(<https://api.github.com/repositories/12345/pulls?per_page=100&page=2>; rel="next")
+      json((1..100).map(&pull))
+    }
+    get('/repositories/12345/pulls') {
+      json((101..150).map(&pull))
+    }
+    post('/graphql') {
+      # the batches of 100 and 50 pull requests have to be requested together
+      status_requests << params[:query]
+      deadline = Time.now + 2
+      sleep 0.05 until status_requests.size == 2 || Time.now > deadline
+      halt 400 unless status_requests.size == 2
 
-      json []
+      numbers = params[:query].scan(/pr(\d+): pullRequest/).flatten.map(&:to_i)
+      json :data => { :repository => Hash[numbers.map { |number| ["pr#{number}", {
+        :number => number,
+        :commits => { :nodes => [{ :commit => { :statusCheckRollup => { :state => "SUCCESS" } } }] },
+      }] }] }
+    }
+    """
+    When I successfully run `hub pr list -o long-running -L 150 --format "%I %ck%n"`
+    Then the output should contain:
+      """
+      99 passing
+      100 passing
+      101 passing
+      """
+    And the output should contain:
+      """
+      149 passing
+      150 passing\n
+      """
+
+  Scenario: Filter by base and head
+    Given the GitHub API server:
+    """
+    post('/graphql') {
+      assert_variables :baseRefName => "develop",
+                       :headRefName => "patch-1"
+      json :data => { :repository => { :pullRequests => {
+        :nodes => [
+          { :number => 1, :state => "OPEN", :title => "Same repo",
+            :headRefName => "patch-1", :headRepositoryOwner => { :login => "github" } },
+          { :number => 2, :state => "OPEN", :title => "Fork",
+            :headRefName => "patch-1", :headRepositoryOwner => { :login => "mislav" } },
+        ],
+        :pageInfo => { :hasNextPage => false },
+      } } }
+    }
+    """
+    When I successfully run `hub pr list -b develop -h patch-1`
+    Then the output should contain exactly:
+      """
+            #1  Same repo\n
+      """
+
+  Scenario: Filter by head with owner
+    Given the GitHub API server:
+    """
+    post('/graphql') {
+      assert_variables :headRefName => "patch-1"
+      json :data => { :repository => { :pullRequests => {
+        :nodes => [
+          { :number => 1, :state => "OPEN", :title => "Same repo",
+            :headRefName => "patch-1", :headRepositoryOwner => { :login => "github" } },
+          { :number => 2, :state => "OPEN", :title => "Fork",
+            :headRefName => "patch-1", :headRepositoryOwner => { :login => "mislav" } },
+        ],
+        :pageInfo => { :hasNextPage => false },
+      } } }
     }
     """
     When I successfully run `hub pr list -h mislav:patch-1`
-    Then the output should contain exactly ""
+    Then the output should contain exactly:
+      """
+            #2  Fork\n
+      """
 
   Scenario: Filter by merged state
     Given the GitHub API server:
     """
-    get('/repos/github/hub/pulls') {
-      assert :state => "closed"
-
-      json [
-        { :number => 999,
-          :title => "First",
-          :state => "closed",
-          :merged_at => "2018-12-11T10:50:33Z",
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-1", :label => "octocat:patch-1" },
-          :user => { :login => "octocat" },
-        },
-        { :number => 102,
-          :title => "Second",
-          :state => "closed",
-          :merged_at => nil,
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-2", :label => "octocat:patch-2" },
-          :user => { :login => "octocat" },
-        },
-        { :number => 13,
-          :title => "Third",
-          :state => "closed",
-          :merged_at => "2018-12-11T10:50:33Z",
-          :base => { :ref => "master", :label => "github:master" },
-          :head => { :ref => "patch-3", :label => "octocat:patch-3" },
-          :user => { :login => "octocat" },
-        },
-      ]
+    post('/graphql') {
+      assert_variables :states => ["MERGED"]
+      json :data => { :repository => { :pullRequests => {
+        :nodes => [
+          { :number => 999, :title => "First", :state => "MERGED", :mergedAt => "2018-12-11T10:50:33Z" },
+          { :number => 13, :title => "Third", :state => "MERGED", :mergedAt => "2018-12-11T10:50:33Z" },
+        ],
+        :pageInfo => { :hasNextPage => false },
+      } } }
     }
     """
     When I successfully run `hub pr list --state=merged`
@@ -241,3 +258,137 @@ Feature: hub pr list
           #999  First
            #13  Third\n
       """
+
+  Scenario: Filter by draft state, label, and requested reviewer
+    Given the GitHub API server:
+    """
+    get('/user') {
+      json :login => "defunkt"
+    }
+    post('/graphql') {
+      assert_variables :labels => ["bug"], :first => 100
+      reviewers = { :nodes => [{ :requestedReviewer => { :__typename => "User", :login => "defunkt" } }] }
+      json :data => { :repository => { :pullRequests => {
+        :nodes => [
+          { :number => 999, :state => "OPEN", :isDraft => false,
+            :labels => { :nodes => [{ :name => "bug" }] }, :reviewRequests => reviewers },
+          { :number => 102, :state => "OPEN", :isDraft => true,
+            :labels => { :nodes => [{ :name => "bug" }] }, :reviewRequests => reviewers },
+          { :number => 13, :state => "OPEN", :isDraft => false,
+            :labels => { :nodes => [{ :name => "bug" }] }, :reviewRequests => { :nodes => [] } },
+        ],
+        :pageInfo => { :hasNextPage => false },
+      } } }
+    }
+    """
+    When I successfully run `hub pr list --no-draft -l bug --review-requested @me -f "%I%n"`
+    Then the output should contain exactly:
+      """
+      999\n
+      """
+
+  Scenario: Filter by author and assignee
+    Given the GitHub API server:
+    """
+    get('/user') {
+      json :login => "defunkt"
+    }
+    post('/graphql') {
+      halt 400 unless params[:query].include?("assignees")
+      json :data => { :repository => { :pullRequests => {
+        :nodes => [
+          { :number => 999, :state => "OPEN", :author => { :login => "defunkt" },
+            :assignees => { :nodes => [{ :login => "mislav" }] } },
+          { :number => 102, :state => "OPEN", :author => { :login => "defunkt" },
+            :assignees => { :nodes => [] } },
+          { :number => 13, :state => "OPEN", :author => { :login => "mislav" },
+            :assignees => { :nodes => [{ :login => "mislav" }] } },
+        ],
+        :pageInfo => { :hasNextPage => false },
+      } } }
+    }
+    """
+    When I successfully run `hub pr list --author @me --assignee mislav -f "%I%n"`
+    Then the output should contain exactly:
+      """
+      999\n
+      """
+
+  Scenario: Teams can't be assignees
+    Given the GitHub API server:
+    """
+    """
+    When I run `hub pr list --assignee github/core`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      --assignee only accepts users, not teams: 'github/core'\n
+      """
+
+  Scenario: Filter by checks status
+    Given the GitHub API server:
+    """
+    post('/graphql') {
+      halt 400 unless params[:query].include?("statusCheckRollup")
+      json :data => { :repository => { :pullRequests => {
+        :nodes => [
+          { :number => 999, :state => "OPEN", :reviewDecision => "APPROVED",
+            :commits => { :nodes => [{ :commit => { :statusCheckRollup => { :state => "FAILURE" } } }] } },
+          { :number => 102, :state => "OPEN", :reviewDecision => "REVIEW_REQUIRED",
+            :commits => { :nodes => [{ :commit => { :statusCheckRollup => { :state => "SUCCESS" } } }] } },
+        ],
+        :pageInfo => { :hasNextPage => false },
+      } } }
+    }
+    """
+    When I successfully run `hub pr list --checks failing -f "%I %ck %rv%n"`
+    Then the output should contain exactly:
+      """
+      999 failing approved\n
+      """
+
+  Scenario: List pull requests with reactions
+    Given the GitHub API server:
+    """
+    post('/graphql') {
+      if params[:query].include?("reactionGroups")
+        halt 400 unless params[:query].include?("n102: issueOrPullRequest(number: 102)")
+        json :data => { :repository => {
+          :n102 => { :reactionGroups => [
+            { :content => "THUMBS_UP", :reactors => { :totalCount => 2 } },
+            { :content => "ROCKET", :reactors => { :totalCount => 1 } },
+          ] },
+        } }
+      else
+        json :data => { :repository => { :pullRequests => {
+          :nodes => [{
+            :number => 102, :title => "Second", :state => "OPEN",
+            :baseRefName => "master", :headRefName => "patch-2",
+            :author => { :login => "octocat" },
+            :labels => { :nodes => [] },
+          }],
+          :pageInfo => { :hasNextPage => false },
+        } } }
+      end
+    }
+    """
+    When I successfully run `hub pr list --reactions`
+    Then the output should contain exactly:
+      """
+          #102  Second  +1 (2), rocket (1)\n
+      """
+
+  Scenario: React to a pull request
+    Given the GitHub API server:
+    """
+    post('/repos/github/hub/issues/7/reactions') {
+      assert :content => "rocket"
+      status 201
+      json :id => 1, :content => "rocket"
+    }
+    """
+    When I successfully run `hub pr react 7 rocket`
+    Then the output should contain exactly:
+      """
+      Reacted with rocket to pull request #7\n
+      """
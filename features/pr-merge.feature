@@ -0,0 +1,78 @@
+Feature: hub pr merge
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Squash and merge with a custom message
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :state => "open",
+          :node_id => "PR_NODE",
+          :head => { :ref => "fix-login", :label => "github:fix-login", :sha => "HEADSHA",
+                     :repo => { :name => "hub", :owner => { :login => "github" } } },
+          :base => { :ref => "master", :label => "github:master",
+                     :repo => { :name => "hub", :owner => { :login => "github" } } }
+      }
+      put('/repos/github/hub/pulls/12/merge') {
+        assert :merge_method => "squash",
+               :sha => "HEADSHA",
+               :commit_title => "Fix login (#12)",
+               :commit_message => "Closes #10"
+        json :merged => true, :sha => "abcdef1234567890abcdef1234567890abcdef12"
+      }
+      delete('/repos/github/hub/git/refs/heads/fix-login') {
+        status 204
+      }
+      """
+    When I successfully run `hub pr merge --squash -d -m "Fix login (#12)" -m "Closes #10" 12`
+    Then the output should contain exactly:
+      """
+      Squashed and merged pull request #12 (abcdef1)
+      Deleted branch fix-login on github/hub\n
+      """
+
+  Scenario: Enable auto-merge
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12, :state => "open", :node_id => "PR_NODE",
+          :head => { :ref => "fix-login", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      post('/graphql') {
+        halt 400 unless params[:query].include?("enablePullRequestAutoMerge")
+        assert :variables => { :input => { :pullRequestId => "PR_NODE", :mergeMethod => "REBASE" } }
+        json :data => { :enablePullRequestAutoMerge => { :clientMutationId => nil } }
+      }
+      """
+    When I successfully run `hub pr merge --rebase --auto 12`
+    Then the output should contain exactly:
+      """
+      Enabled auto-merge (rebase) for pull request #12\n
+      """
+
+  Scenario: Merge a closed pull request
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12, :state => "closed",
+          :head => { :ref => "fix-login", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      """
+    When I run `hub pr merge 12`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Error merging pull request: #12 is closed\n
+      """
+
+  Scenario: Conflicting merge methods
+    When I run `hub pr merge --squash --rebase 12`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      only one of --merge, --squash, or --rebase can be used\n
+      """
@@ -218,3 +218,12 @@ Feature: hub pr show
       """
       invalid pull request number: 'XYZ'\n
       """
+
+  Scenario: Current branch with a recorded pull request
+    Given I am on the "topic" branch
+    And git "branch.topic.hub-pr" is set to "102"
+    When I successfully run `hub pr show -u`
+    Then the output should contain exactly:
+      """
+      https://github.com/ashemesh/hub/pull/102\n
+      """
@@ -0,0 +1,80 @@
+Feature: hub pr stack
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And the "origin" remote has url "git@github.com:github/hub.git"
+    And I am on the "master" branch pushed to "origin/master"
+
+  Scenario: Create a stack of pull requests
+    Given I successfully run `git checkout --quiet -b part-1`
+    And I make a commit with message:
+      """
+      Extract parser
+
+      First step.
+      """
+    And I successfully run `git checkout --quiet -b part-2`
+    And I make a commit with message:
+      """
+      Use new parser
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/pulls') {
+        if params[:head] == "github:part-1"
+          assert :base => "master", :title => "Extract parser", :body => "First step."
+          status 201
+          json :number => 1, :html_url => "https://github.com/github/hub/pull/1"
+        else
+          assert :base => "part-1", :head => "github:part-2", :title => "Use new parser"
+          status 201
+          json :number => 2, :html_url => "https://github.com/github/hub/pull/2"
+        end
+      }
+      """
+    When I successfully run `hub pr stack create part-1 part-2`
+    Then the output should contain:
+      """
+      https://github.com/github/hub/pull/1
+      https://github.com/github/hub/pull/2\n
+      """
+    When I successfully run `hub pr stack`
+    Then the output should contain exactly:
+      """
+        master
+        └ part-1 #1
+      *   └ part-2 #2\n
+      """
+
+  Scenario: Retarget pull requests after their base was merged
+    Given git "branch.part-1.hub-stack-parent" is set to "master"
+    And git "branch.part-1.hub-pr" is set to "1"
+    And git "branch.part-2.hub-stack-parent" is set to "part-1"
+    And git "branch.part-2.hub-pr" is set to "2"
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/1') {
+        json :number => 1, :state => "closed", :merged_at => "2020-01-01T00:00:00Z",
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/pulls/2') {
+        json :number => 2, :state => "open", :base => { :ref => "part-1" }
+      }
+      patch('/repos/github/hub/pulls/2') {
+        assert :base => "master"
+        json :number => 2
+      }
+      """
+    When I successfully run `hub pr stack sync`
+    Then the output should contain exactly:
+      """
+      Retargeted pull request #2 onto master\n
+      """
+
+  Scenario: Not in a stack
+    When I run `hub pr stack`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: branch 'master' is not part of a stack\n
+      """
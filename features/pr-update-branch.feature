@@ -0,0 +1,131 @@
+Feature: hub pr status and update-branch
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Status of a pull request with conflicts
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12, :title => "Fix login", :mergeable => false,
+          :head => { :ref => "fix-login", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/compare/master...HEADSHA') {
+        json :behind_by => 3
+      }
+      post('/graphql') {
+        json :data => { :repository => {
+          :pr12 => { :number => 12, :reviewDecision => "CHANGES_REQUESTED",
+                     :commits => { :nodes => [{ :commit => { :statusCheckRollup => { :state => "SUCCESS" } } }] } }
+        } }
+      }
+      """
+    When I run `hub pr status 12`
+    Then the exit status should be 1
+    And the output should contain exactly:
+      """
+      #12 Fix login
+      Mergeable:   no, it has conflicts with master
+      Up to date:  no, 3 commits behind master
+      Review:      changes requested
+      Checks:      passing\n
+      """
+
+  Scenario: Status of a mergeable pull request
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12, :title => "Fix login", :mergeable => true,
+          :head => { :ref => "fix-login", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/compare/master...HEADSHA') {
+        json :behind_by => 0
+      }
+      post('/graphql') {
+        json :data => { :repository => { :pr12 => { :number => 12, :commits => { :nodes => [] } } } }
+      }
+      """
+    When I successfully run `hub pr status 12`
+    Then the output should contain exactly:
+      """
+      #12 Fix login
+      Mergeable:   yes
+      Up to date:  yes\n
+      """
+
+  Scenario: Update the branch of a pull request
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12, :node_id => "PR_NODE",
+          :head => { :ref => "fix-login", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/compare/master...HEADSHA') {
+        json :behind_by => 2
+      }
+      post('/graphql') {
+        halt 400 unless params[:query].include?("updatePullRequestBranch")
+        assert :variables => { :input => { :pullRequestId => "PR_NODE", :updateMethod => "REBASE", :expectedHeadOid => "HEADSHA" } }
+        json :data => { :updatePullRequestBranch => { :clientMutationId => nil } }
+      }
+      """
+    When I successfully run `hub pr update-branch --rebase 12`
+    Then the output should contain exactly:
+      """
+      Updated pull request #12 with master\n
+      """
+
+  Scenario: Branch already up to date
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "fix-login", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/github/hub/compare/master...HEADSHA') {
+        json :behind_by => 0
+      }
+      """
+    When I successfully run `hub pr update-branch 12`
+    Then the output should contain exactly:
+      """
+      Pull request #12 is already up to date with master\n
+      """
+
+  Scenario: Update all of my pull requests
+    Given the GitHub API server:
+      """
+      get('/user') {
+        json :login => "mislav"
+      }
+      get('/repos/github/hub/pulls') {
+        assert :state => "open"
+        json [
+          { :number => 12, :node_id => "PR12", :user => { :login => "mislav" },
+            :head => { :ref => "behind", :sha => "SHA12" }, :base => { :ref => "master" } },
+          { :number => 13, :node_id => "PR13", :user => { :login => "mislav" },
+            :head => { :ref => "current", :sha => "SHA13" }, :base => { :ref => "master" } },
+          { :number => 14, :node_id => "PR14", :user => { :login => "octocat" },
+            :head => { :ref => "theirs", :sha => "SHA14" }, :base => { :ref => "master" } },
+        ]
+      }
+      get('/repos/github/hub/compare/master...SHA12') {
+        json :behind_by => 4
+      }
+      get('/repos/github/hub/compare/master...SHA13') {
+        json :behind_by => 0
+      }
+      post('/graphql') {
+        assert :variables => { :input => { :pullRequestId => "PR12", :updateMethod => "MERGE", :expectedHeadOid => "SHA12" } }
+        json :data => { :updatePullRequestBranch => { :clientMutationId => nil } }
+      }
+      """
+    When I successfully run `hub pr update-branch --all`
+    Then the output should contain exactly:
+      """
+      Updated pull request #12 with master\n
+      """
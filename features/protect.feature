@@ -0,0 +1,209 @@
+Feature: hub protect
+  Background:
+    Given I am in "git://github.com/my-org/api.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Protect a branch
+    Given the GitHub API server:
+      """
+      put('/repos/my-org/api/branches/main/protection') {
+        assert :enforce_admins => false,
+               :required_linear_history => true,
+               :restrictions => nil
+        checks = params[:required_status_checks]
+        halt 400 unless checks["contexts"] == ["ci/test", "lint"] && checks["strict"] == true
+        reviews = params[:required_pull_request_reviews]
+        halt 400 unless reviews["required_approving_review_count"] == 2 &&
+          reviews["dismiss_stale_reviews"] == true &&
+          reviews["require_code_owner_reviews"] == false
+        json({})
+      }
+      post('/repos/my-org/api/branches/main/protection/required_signatures') {
+        json :enabled => true
+      }
+      """
+    When I successfully run `hub protect main -c ci/test,lint --strict -r 2 --dismiss-stale-reviews --signed-commits --linear-history`
+    Then the output should contain exactly:
+      """
+      Protected branch my-org/api@main\n
+      """
+
+  Scenario: Rules that are not passed are disabled
+    Given the GitHub API server:
+      """
+      put('/repos/other/repo/branches/release/protection') {
+        assert :required_status_checks => nil,
+               :required_pull_request_reviews => nil,
+               :required_linear_history => false,
+               :enforce_admins => true
+        json({})
+      }
+      delete('/repos/other/repo/branches/release/protection/required_signatures') {
+        status 204
+      }
+      """
+    When I successfully run `hub protect --enforce-admins release other/repo`
+    Then the output should contain exactly:
+      """
+      Protected branch other/repo@release\n
+      """
+
+  Scenario: Invalid number of required reviews
+    When I run `hub protect main -r many`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid number of required reviews: 'many'\n
+      """
+
+  Scenario: Show branch protection
+    Given the GitHub API server:
+      """
+      get('/repos/my-org/api/branches/main/protection') {
+        json :required_status_checks => { :strict => true, :contexts => ["ci/test", "lint"] },
+             :required_pull_request_reviews => {
+               :dismiss_stale_reviews => true,
+               :require_code_owner_reviews => false,
+               :required_approving_review_count => 2,
+             },
+             :enforce_admins => { :enabled => false },
+             :required_signatures => { :enabled => true },
+             :required_linear_history => { :enabled => true }
+      }
+      """
+    When I successfully run `hub protect --show main`
+    Then the output should contain exactly:
+      """
+      Branch protection for my-org/api@main
+
+        Required status checks:  ci/test, lint (strict)
+        Required reviews:        2 (dismiss stale)
+        Signed commits:          yes
+        Linear history:          yes
+        Enforce for admins:      no\n
+      """
+
+  Scenario: Show branch protection with the show verb
+    Given the GitHub API server:
+      """
+      get('/repos/other/repo/branches/main/protection') {
+        json :enforce_admins => { :enabled => true }
+      }
+      """
+    When I successfully run `hub protect show main other/repo`
+    Then the output should contain exactly:
+      """
+      Branch protection for other/repo@main
+
+        Required status checks:  none
+        Required reviews:        none
+        Signed commits:          no
+        Linear history:          no
+        Enforce for admins:      yes\n
+      """
+
+  Scenario: Unprotect a branch
+    Given the GitHub API server:
+      """
+      delete('/repos/my-org/api/branches/main/protection') {
+        status 204
+      }
+      """
+    When I successfully run `hub unprotect main`
+    Then the output should contain exactly:
+      """
+      Removed protection from branch my-org/api@main\n
+      """
+
+  Scenario: Apply a policy file
+    Given a file named "rules.yml" with:
+      """
+      - repos: [my-org/api, my-org/web]
+        branch: main
+        status_checks: [ci/test]
+        signed_commits: true
+      """
+    Given the GitHub API server:
+      """
+      put('/repos/my-org/:repo/branches/main/protection') {
+        halt 400 unless params[:required_status_checks]["contexts"] == ["ci/test"]
+        json({})
+      }
+      post('/repos/my-org/:repo/branches/main/protection/required_signatures') {
+        json :enabled => true
+      }
+      """
+    When I successfully run `hub protect -F rules.yml`
+    Then the output should contain exactly:
+      """
+      Protected branch my-org/api@main
+      Protected branch my-org/web@main\n
+      """
+
+  Scenario: Resume applying a policy file
+    Given a file named "rules.yml" with:
+      """
+      - repos: [my-org/api, my-org/web]
+        branch: main
+      """
+    Given the GitHub API server:
+      """
+      web_attempts = 0
+      put('/repos/my-org/api/branches/main/protection') { json({}) }
+      put('/repos/my-org/web/branches/main/protection') {
+        web_attempts += 1
+        if web_attempts == 1
+          status 500
+          json :message => "Server Error"
+        else
+          json({})
+        end
+      }
+      delete('/repos/my-org/:repo/branches/main/protection/required_signatures') {
+        status 204
+      }
+      """
+    When I run `hub protect -F rules.yml`
+    Then the exit status should be 1
+    And the stderr should contain:
+      """
+      Error: 1 operation(s) failed:
+        my-org/web@main: Error updating branch protection: Internal Server Error (HTTP 500)
+      """
+    When I successfully run `hub protect -F rules.yml --resume`
+    Then the stdout should contain exactly:
+      """
+      Protected branch my-org/web@main\n
+      """
+
+  Scenario: Changing the policy file starts over
+    Given a file named "rules.yml" with:
+      """
+      - repos: [my-org/api, my-org/web]
+        branch: main
+      """
+    Given the GitHub API server:
+      """
+      put('/repos/my-org/api/branches/main/protection') { json({}) }
+      put('/repos/my-org/web/branches/main/protection') {
+        status 500
+        json :message => "Server Error"
+      }
+      put('/repos/my-org/app/branches/main/protection') { json({}) }
+      delete('/repos/my-org/:repo/branches/main/protection/required_signatures') {
+        status 204
+      }
+      """
+    When I run `hub protect -F rules.yml`
+    Then the exit status should be 1
+    Given a file named "rules.yml" with:
+      """
+      - repos: [my-org/api, my-org/app]
+        branch: main
+      """
+    When I successfully run `hub protect -F rules.yml --resume`
+    Then the stdout should contain exactly:
+      """
+      Protected branch my-org/api@main
+      Protected branch my-org/app@main\n
+      """
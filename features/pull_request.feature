@@ -89,7 +89,7 @@ Feature: hub pull-request
   Scenario: Invalid flag
     When I run `hub pull-request -yelp`
     Then the stderr should contain "unknown shorthand flag: 'y' in -yelp\n"
-    And the exit status should be 1
+    And the exit status should be 2
 
   Scenario: With Unicode characters in the changelog
     Given the text editor adds:
@@ -273,6 +273,26 @@ Feature: hub pull-request
     When I successfully run `hub pull-request --push --no-edit`
     Then the output should contain exactly "the://url\n"
 
+  Scenario: Record the pull request number with "--push"
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        status 201
+        json :number => 12, :html_url => "the://url"
+      }
+      """
+    Given I am on the "master" branch pushed to "origin/master"
+    When I successfully run `git checkout --quiet -b topic`
+    Given I make a commit with message:
+      """
+      Commit title 1
+      """
+    When I successfully run `hub pull-request --push -m hello`
+    Then the output should contain exactly "the://url\n"
+    And "topic" should merge "refs/heads/topic" from remote "origin"
+    When I successfully run `git config branch.topic.hub-pr`
+    Then the output should contain "12"
+
   Scenario: No commits with "--no-edit"
     Given I am on the "master" branch pushed to "origin/master"
     When I successfully run `git checkout --quiet -b topic`
@@ -337,7 +357,7 @@ Feature: hub pull-request
       post('/repos/origin/coral/pulls') { 404 }
       """
     When I run `hub pull-request -b origin:master -h topic -m here`
-    Then the exit status should be 1
+    Then the exit status should be 3
     Then the stderr should contain:
       """
       Error creating pull request: Not Found (HTTP 404)
@@ -1012,6 +1032,43 @@ Feature: hub pull-request
     When I successfully run `hub pull-request -m hereyougo -r mislav,josh -rgithub/robots -rpcorpet -r github/js`
     Then the output should contain exactly "the://url\n"
 
+  Scenario: Pull request with suggested reviewers from git blame
+    Given a file named "lib.rb" with:
+      """
+      one
+      two
+      """
+    And I successfully run `git add lib.rb`
+    And I successfully run `git commit -q -m base --author "Alice <alice@example.com>"`
+    And I successfully run `git update-ref refs/remotes/origin/master HEAD`
+    And I successfully run `git checkout -q -b feature`
+    And a file named "lib.rb" with:
+      """
+      one
+      TWO
+      """
+    And I successfully run `git commit -q -a -m change`
+    And the "feature" branch is pushed to "origin/feature"
+    And I successfully run `git branch -q --set-upstream-to origin/feature`
+    Given the GitHub API server:
+      """
+      get(%r{/repos/mislav/coral/commits/(\h{40})}) { |sha|
+        json :sha => sha, :author => { :login => "alice" }
+      }
+      post('/repos/mislav/coral/pulls') {
+        assert :head  => "mislav:feature"
+        status 201
+        json :html_url => "the://url", :number => 1234
+      }
+      post('/repos/mislav/coral/pulls/1234/requested_reviewers') {
+        assert :reviewers => ["josh", "alice"]
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    When I successfully run `hub pull-request -m hereyougo -r josh --suggest-reviewers`
+    Then the output should contain exactly "the://url\n"
+
   Scenario: Pull request with reviewers from CODEOWNERS
     Given I am on the "feature" branch with upstream "origin/feature"
     Given the GitHub API server:
@@ -1265,6 +1322,39 @@ Feature: hub pull-request
     # TODO: the push should be to the "origin" remote instead
     And "git push --set-upstream upstream HEAD:topic" should be run
 
+  Scenario: Push to a fork when push access is denied with --push
+    Given the "upstream" remote has url "git://github.com/github/coral.git"
+    And I am on the "master" branch pushed to "upstream/master"
+    Given the GitHub API server:
+      """
+      get('/repos/github/coral') {
+        json :name => "coral", :owner => { :login => "github" },
+          :permissions => { :push => false }
+      }
+      get('/repos/mislav/coral') {
+        json :name => "coral", :owner => { :login => "mislav" },
+          :html_url => "https://github.com/mislav/coral",
+          :parent => { :html_url => "https://github.com/github/coral" }
+      }
+      post('/repos/github/coral/pulls') {
+        assert :base  => 'master',
+               :head  => 'mislav:topic',
+               :title => 'hereyougo'
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    When I successfully run `git checkout --quiet -b topic`
+    Given I make a commit with message "Fork commit"
+    When I run `hub pull-request -p -m hereyougo` interactively
+    And I type "yes"
+    Then the exit status should be 0
+    And the output should contain exactly:
+      """
+      You don't have push access to github/coral. Fork it and push to the fork instead (yes/N)? the://url\n
+      """
+    And "git push --set-upstream origin HEAD:topic" should be run
+
   Scenario: Automatically retry when --push resulted in 422
     Given the default aruba exit timeout is 7 seconds
     And the text editor adds:
@@ -1355,3 +1445,74 @@ Feature: hub pull-request
       """
     When I successfully run `hub pull-request -m hello --no-maintainer-edits`
     Then the output should contain exactly "the://url\n"
+
+  Scenario: Title and description checks from .github/hub.yml
+    Given I am on the "topic" branch pushed to "origin/topic"
+    And a file named ".github/hub.yml" with:
+      """
+      pull_request:
+        title_pattern: "^(feat|fix): "
+        required_sections: ["## Testing"]
+      """
+    When I run `hub pull-request -m "Fix the parser"`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: the pull request doesn't pass these checks:
+        the title doesn't match '^(feat|fix): '
+        the description is missing the section '## Testing'
+      (use `--no-verify` to skip the checks)\n
+      """
+
+  Scenario: Skip the checks
+    Given I am on the "topic" branch pushed to "origin/topic"
+    And I successfully run `git config hub.prTitlePattern "^(feat|fix): "`
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        assert :title => 'Fix the parser'
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    When I successfully run `hub pull-request -m "Fix the parser" --no-verify`
+    Then the output should contain exactly "the://url\n"
+
+  Scenario: Base branch left over from before renaming the default branch
+    Given I am on the "topic" branch pushed to "origin/topic"
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/coral') {
+        json :name => 'coral', :owner => { :login => 'mislav' }, :default_branch => 'main'
+      }
+      """
+    When I run `hub pull-request -m hello`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: the pull request doesn't pass these checks:
+        did you mean to target 'main' instead of 'master'?
+      (use `--no-verify` to skip the checks)\n
+      """
+
+  Scenario: Close issues
+    Given I am on the "topic" branch pushed to "origin/topic"
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        assert :title => "Fix the parser",
+               :body => "Fixes #42 too.\n\nCloses #57\nCloses mislav/dotfiles#3"
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    When I successfully run `hub pull-request -m "Fix the parser" -m "Fixes #42 too." --closes 42,#57 --closes mislav/dotfiles#3`
+    Then the output should contain exactly "the://url\n"
+
+  Scenario: Fill in the form for a new pull request on the web
+    Given I am on the "topic" branch pushed to "origin/topic"
+    Given the GitHub API server:
+      """
+      """
+    When I successfully run `hub pull-request --web -b develop -m "Fix the parser" -a mislav`
+    Then "open https://github.com/mislav/coral/compare/develop...mislav:topic?assignees=mislav&expand=1&title=Fix+the+parser" should be run
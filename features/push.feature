@@ -22,3 +22,76 @@ Feature: hub push
     When I successfully run `hub push origin,staging master new-feature`
     Then "git push origin master new-feature" should be run
     Then "git push staging master new-feature" should be run
+
+  Scenario: Fork and push there when push access is denied
+    Given I am "hubot" on github.com with OAuth token "OTOKEN"
+    And GitHub denies pushes to the "origin" remote
+    And the GitHub API server:
+      """
+      get('/repos/hubot/coral') { status 404 }
+      post('/repos/mislav/coral/forks') {
+        status 202
+        json :name => "coral", :owner => { :login => "hubot" }
+      }
+      """
+    When I run `hub push origin topic` interactively
+    And I type "yes"
+    Then the exit status should be 0
+    And the stdout should contain exactly:
+      """
+      You don't have push access to mislav/coral. Fork it and push to the fork instead (yes/N)? forked mislav/coral to hubot/coral
+      new remote: hubot\n
+      """
+    And the stderr should contain "Permission to mislav/coral.git denied"
+    And "git remote add hubot git@github.com:hubot/coral.git" should be run
+    And "git push hubot topic" should be run
+
+  Scenario: Push to an existing fork when push access is denied
+    Given I am "hubot" on github.com with OAuth token "OTOKEN"
+    And the "hubot" remote has url "git@github.com:hubot/coral.git"
+    And GitHub denies pushes to the "origin" remote
+    And the GitHub API server:
+      """
+      get('/repos/hubot/coral') {
+        json :name => "coral", :owner => { :login => "hubot" },
+          :html_url => "https://github.com/hubot/coral",
+          :parent => { :html_url => "https://github.com/mislav/coral" }
+      }
+      """
+    When I run `hub push origin topic` interactively
+    And I type "yes"
+    Then the exit status should be 0
+    And the stdout should contain "Fork it and push to the fork instead (yes/N)?"
+    And "git remote add hubot git@github.com:hubot/coral.git" should not be run
+    And "git push hubot topic" should be run
+
+  Scenario: Repository in the way of the fork
+    Given I am "hubot" on github.com with OAuth token "OTOKEN"
+    And GitHub denies pushes to the "origin" remote
+    And the GitHub API server:
+      """
+      get('/repos/hubot/coral') {
+        json :name => "coral", :owner => { :login => "hubot" },
+          :html_url => "https://github.com/hubot/coral"
+      }
+      """
+    When I run `hub push origin topic` interactively
+    And I type "yes"
+    Then the exit status should be 1
+    And the stderr should contain "Error creating fork: hubot/coral already exists on github.com"
+    And "git push hubot topic" should not be run
+
+  Scenario: Decline to fork when push access is denied
+    Given I am "hubot" on github.com with OAuth token "OTOKEN"
+    And GitHub denies pushes to the "origin" remote
+    When I run `hub push origin topic` interactively
+    And I type "no"
+    Then the exit status should be 128
+    And "git push hubot topic" should not be run
+
+  Scenario: Denied push without being logged in
+    Given GitHub denies pushes to the "origin" remote
+    When I run `hub push origin topic`
+    Then the exit status should be 128
+    And the stdout should contain exactly ""
+    And the stderr should contain "The requested URL returned error: 403"
@@ -0,0 +1,55 @@
+Feature: hub query
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: set and list queries
+    When I successfully run `hub query set stale 'issue -l stale -o updated'`
+    And I successfully run `hub query set --local mine 'pr list --review-requested @me'`
+    And I successfully run `hub query list`
+    Then the output should contain exactly:
+      """
+      mine	pr list --review-requested @me
+      stale	issue -l stale -o updated\n
+      """
+
+  Scenario: run a query with arguments
+    Given I successfully run `hub query set bugs 'issue -l bug,$1'`
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues') {
+        assert :labels => "bug,ui", :assignee => "mislav"
+        json []
+      }
+      """
+    When I successfully run `hub query bugs ui -- -a mislav`
+    Then the output should not contain anything
+
+  Scenario: query for the repository takes precedence
+    Given I successfully run `hub query set open 'issue -s open'`
+    And I successfully run `hub query set --local open 'issue -s closed'`
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues') {
+        assert :state => "closed"
+        json []
+      }
+      """
+    When I successfully run `hub query open`
+    Then the output should not contain anything
+
+  Scenario: query has to list issues or pull requests
+    When I run `hub query set nuke 'delete github/hub'`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      query has to be an 'issue' or 'pr' command: delete github/hub\n
+      """
+
+  Scenario: unknown query
+    When I run `hub query nope`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      no such query: nope\n
+      """
@@ -230,7 +230,7 @@ Feature: hub release
       Error fetching releases: Not Found (HTTP 404)
       Not Found\n
       """
-    And the exit status should be 1
+    And the exit status should be 3
 
   Scenario: Server error when listing releases
     Given the GitHub API server:
@@ -362,6 +362,45 @@ MARKDOWN
       - everything\n
       """
 
+  Scenario: Print the URL of a release
+    When I successfully run `hub release show -u v1.2.0`
+    Then the output should contain exactly:
+      """
+      https://github.com/mislav/will_paginate/releases/tag/v1.2.0\n
+      """
+
+  Scenario: Show the latest release
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases/latest') {
+        json :tag_name => 'v1.2.0',
+             :name => 'will_paginate 1.2.0',
+             :body => 'Fixes'
+      }
+      """
+    When I successfully run `hub release show --latest`
+    Then the output should contain exactly:
+      """
+      will_paginate 1.2.0
+
+      Fixes\n
+      """
+
+  Scenario: No latest release
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases/latest') {
+        status 404
+        json :message => "Not Found"
+      }
+      """
+    When I run `hub release show --latest`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Unable to find the latest release of mislav/will_paginate\n
+      """
+
   Scenario: Show release no tag
     When I run `hub release show`
     Then the exit status should be 1
@@ -387,6 +426,26 @@ MARKDOWN
       https://github.com/mislav/will_paginate/releases/v1.2.0\n
       """
 
+  Scenario: Create a release with generated notes and a discussion
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/will_paginate/releases') {
+        assert :tag_name => "v1.2.0",
+               :name => nil,
+               :generate_notes => true,
+               :make_latest => "true",
+               :discussion_category_name => "Announcements"
+
+        status 201
+        json :html_url => "https://github.com/mislav/will_paginate/releases/v1.2.0"
+      }
+      """
+    When I successfully run `hub release create --generate-notes --latest --discussion-category Announcements v1.2.0`
+    Then the output should contain exactly:
+      """
+      https://github.com/mislav/will_paginate/releases/v1.2.0\n
+      """
+
   Scenario: Create a release from file
     Given the GitHub API server:
       """
@@ -507,6 +566,26 @@ MARKDOWN
     When I successfully run `hub release edit --draft=false v1.2.0`
     Then the output should not contain anything
 
+  Scenario: Promote a pre-release to latest
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+        json [
+          { url: 'https://api.github.com/repos/mislav/will_paginate/releases/123',
+            tag_name: 'v1.2.0',
+            prerelease: true,
+          },
+        ]
+      }
+      patch('/repos/mislav/will_paginate/releases/123') {
+        assert :prerelease => false,
+               :make_latest => "true"
+        json({})
+      }
+      """
+    When I successfully run `hub release edit -m "" --prerelease=false --latest v1.2.0`
+    Then the output should not contain anything
+
   Scenario: Edit existing release when there is a fork
     Given the "doge" remote has url "git://github.com/doge/will_paginate.git"
     And I am on the "feature" branch with upstream "doge/feature"
@@ -637,6 +716,157 @@ MARKDOWN
           ASSET_TARBALL
           """
 
+  Scenario: Download selected release assets into a directory
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+        json [
+          { tag_name: 'v1.2.0',
+            assets: [
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9876',
+                name: 'hello-1.2.0.tar.gz',
+                size: 13,
+              },
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9877',
+                name: 'hello-1.2.0.zip',
+                size: 11,
+              },
+            ],
+          },
+        ]
+      }
+      get('/repos/mislav/will_paginate/assets/9876') {
+        halt 415 unless request.accept?('application/octet-stream')
+        headers['Content-Type'] = 'application/octet-stream'
+        "ASSET_TARBALL"
+      }
+      """
+    When I successfully run `hub release download v1.2.0 --include '*.tar.gz' --dir dist -j 2`
+    Then the output should contain exactly:
+      """
+      Downloading hello-1.2.0.tar.gz ...\n
+      """
+    And the file "dist/hello-1.2.0.tar.gz" should contain exactly:
+      """
+      ASSET_TARBALL
+      """
+
+  Scenario: Download release assets in parallel
+    Given the GitHub API server:
+      """
+      asset_requests = Queue.new
+      get('/repos/mislav/will_paginate/releases') {
+        json [
+          { tag_name: 'v1.2.0',
+            assets: [
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9876',
+                name: 'hello-1.2.0.tar.gz',
+                size: 13,
+              },
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9877',
+                name: 'hello-1.2.0.zip',
+                size: 11,
+              },
+            ],
+          },
+        ]
+      }
+      get('/repos/mislav/will_paginate/assets/:id') {
+        # with '-j 2', both assets have to be requested together
+        asset_requests << params[:id]
+        deadline = Time.now + 2
+        sleep 0.05 until asset_requests.size == 2 || Time.now > deadline
+        halt 400 unless asset_requests.size == 2
+
+        if params[:id] == '9877'
+          status 500
+          json :message => "Server Error"
+        else
+          headers['Content-Type'] = 'application/octet-stream'
+          "ASSET_TARBALL"
+        end
+      }
+      """
+    When I run `hub release download v1.2.0 -j 2`
+    Then the exit status should be 1
+    And the stdout should contain "Downloading hello-1.2.0.tar.gz ...\n"
+    And the stdout should contain "Downloading hello-1.2.0.zip ...\n"
+    And the stderr should contain:
+      """
+      Error downloading hello-1.2.0.zip: Error downloading asset: Internal Server Error (HTTP 500)
+      """
+    And the file "hello-1.2.0.tar.gz" should contain exactly:
+      """
+      ASSET_TARBALL
+      """
+    And the file "hello-1.2.0.zip" should not exist
+
+  Scenario: Skip release assets that were already downloaded
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+        json [
+          { tag_name: 'v1.2.0',
+            assets: [
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9876',
+                name: 'hello-1.2.0.tar.gz',
+                size: 13,
+                digest: 'sha256:0f4a9b8d0c9c7a8b1d6f7e4ad3f8b0c5e2f4b7e9a1d3c5b7e9f1a3c5d7e9f1a3',
+              },
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9877',
+                name: 'hello-1.2.0.zip',
+                size: 11,
+              },
+            ],
+          },
+        ]
+      }
+      get('/repos/mislav/will_paginate/assets/9876') {
+        headers['Content-Type'] = 'application/octet-stream'
+        "ASSET_TARBALL"
+      }
+      """
+    And a file named "hello-1.2.0.tar.gz" with:
+      """
+      STALE_TARBALL
+      """
+    And a file named "hello-1.2.0.zip" with:
+      """
+      ASSET_ZIPFL
+      """
+    When I successfully run `hub release download v1.2.0`
+    Then the output should contain exactly:
+      """
+      Downloading hello-1.2.0.tar.gz ...
+      Skipping hello-1.2.0.zip (already downloaded)\n
+      """
+    And the file "hello-1.2.0.tar.gz" should contain exactly:
+      """
+      ASSET_TARBALL
+      """
+
+  Scenario: Download release assets matching no pattern
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+        json [
+          { tag_name: 'v1.2.0',
+            assets: [
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9876',
+                name: 'hello-1.2.0.tar.gz',
+              },
+            ],
+          },
+        ]
+      }
+      """
+    When I run `hub release download v1.2.0 -i '*.exe'`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      no assets of release v1.2.0 match *.exe\n
+      """
+
   Scenario: Download release no tag
     When I run `hub release download`
     Then the exit status should be 1
@@ -704,3 +934,85 @@ MARKDOWN
       """
       v1.2.0\n
       """
+
+  Scenario: Verify a signed annotated tag
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/git/ref/tags/v1.2.0') {
+        json :ref => 'refs/tags/v1.2.0',
+          :object => { :type => 'tag', :sha => 'a1b2c3d4e5f6' }
+      }
+      get('/repos/mislav/will_paginate/git/tags/a1b2c3d4e5f6') {
+        json :sha => 'a1b2c3d4e5f6',
+          :verification => { :verified => true, :reason => 'valid' }
+      }
+      """
+    When I successfully run `hub release verify v1.2.0`
+    Then the output should contain exactly:
+      """
+      v1.2.0 (annotated): verified\n
+      """
+
+  Scenario: Verify an unsigned lightweight tag
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/git/ref/tags/v1.2.0') {
+        json :ref => 'refs/tags/v1.2.0',
+          :object => { :type => 'commit', :sha => 'deadbeef1234' }
+      }
+      get('/repos/mislav/will_paginate/git/commits/deadbeef1234') {
+        json :sha => 'deadbeef1234',
+          :verification => { :verified => false, :reason => 'unsigned' }
+      }
+      """
+    When I run `hub release verify v1.2.0`
+    Then the exit status should be 1
+    And the output should contain exactly:
+      """
+      v1.2.0 (lightweight, commit deadbee): unverified (unsigned)\n
+      """
+
+  Scenario: Create a release with a signed tag
+    When I successfully run `hub --noop release create --sign -m "will_paginate 1.2.0" v1.2.0`
+    Then the output should contain exactly:
+      """
+      Would create signed tag `v1.2.0' and push it to origin
+      Would create release `will_paginate 1.2.0' for mislav/will_paginate with tag name `v1.2.0'\n
+      """
+
+  Scenario: Release notes since the latest tag
+    Given I make a commit
+    And I successfully run `git tag v1.2.0`
+    And I make 2 commits
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/commits/:sha/pulls') {
+        json [
+          { :number => 12, :title => "Fix pagination links", :user => { :login => "jane" },
+            :labels => [{ :name => "bug" }], :merged_at => "2026-01-02T00:00:00Z" },
+          { :number => 10, :title => "Support cursors", :user => { :login => "joe" },
+            :labels => [{ :name => "enhancement" }], :merged_at => "2026-01-01T00:00:00Z" },
+          { :number => 11, :title => "Update README", :user => { :login => "joe" },
+            :labels => [], :merged_at => "2026-01-03T00:00:00Z" },
+          { :number => 13, :title => "Work in progress", :user => { :login => "joe" },
+            :labels => [{ :name => "bug" }], :merged_at => nil },
+        ]
+      }
+      """
+    When I successfully run `hub release notes --title "will_paginate 1.3.0"`
+    Then the output should contain exactly:
+      """
+      will_paginate 1.3.0
+
+      ## Features
+
+      * Support cursors by @joe in #10
+
+      ## Fixes
+
+      * Fix pagination links by @jane in #12
+
+      ## Other changes
+
+      * Update README by @joe in #11\n
+      """
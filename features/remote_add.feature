@@ -229,3 +229,42 @@ Feature: hub remote add
   Scenario: Avoid crash in argument parsing
     When I successfully run `hub --noop remote add a b evilchelu`
     Then the output should contain exactly "git remote add a b evilchelu\n"
+
+  Scenario: Add remote with pull request refs
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles') {
+        json :private => false,
+             :name => 'dotfiles', :owner => { :login => 'mislav' },
+             :permissions => { :push => false }
+      }
+      """
+    When I successfully run `hub remote add --with-pr-refs mislav`
+    Then the url for "mislav" should be "git://github.com/mislav/dotfiles.git"
+    And "git config --add remote.mislav.fetch +refs/pull/*/head:refs/remotes/mislav/pr/*" should be run
+
+  Scenario: Offer to fork when adding a remote for a missing fork
+    Given the "origin" remote has url "git://github.com/mislav/dotfiles.git"
+    And the GitHub API server:
+      """
+      get('/repos/EvilChelu/dotfiles') { status 404 }
+      post('/repos/mislav/dotfiles/forks') {
+        status 202
+        json :name => 'dotfiles', :owner => { :login => 'EvilChelu' }
+      }
+      """
+    When I run `hub remote add evilchelu` interactively
+    And I type "yes"
+    Then the exit status should be 0
+    And the url for "evilchelu" should be "git@github.com:EvilChelu/dotfiles.git"
+
+  Scenario: Decline to fork when adding a remote for a missing fork
+    Given the "origin" remote has url "git://github.com/mislav/dotfiles.git"
+    And the GitHub API server:
+      """
+      get('/repos/EvilChelu/dotfiles') { status 404 }
+      """
+    When I run `hub remote add evilchelu` interactively
+    And I type ""
+    Then the exit status should be 1
+    And the stderr should contain "Aborted: repository EvilChelu/dotfiles doesn't exist"
@@ -0,0 +1,52 @@
+Feature: hub remotes
+  Background:
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List remotes in priority order
+    Given the "upstream" remote has url "git@github.com:github/dotfiles.git"
+    And the "heroku" remote has url "https://git.heroku.com/dotfiles.git"
+    When I successfully run `hub remotes`
+    Then the output should contain exactly:
+      """
+      * upstream  github/dotfiles  ssh://git@github.com/github/dotfiles.git
+        origin    mislav/dotfiles  git://github.com/mislav/dotfiles.git
+        heroku    -                https://git.heroku.com/dotfiles.git\n
+      """
+
+  Scenario: Configured remote priority
+    Given the "upstream" remote has url "git@github.com:github/dotfiles.git"
+    And git "hub.remotePriority" is set to "origin,upstream"
+    When I successfully run `hub remotes --json`
+    Then the output should contain exactly:
+      """
+      [{"name":"origin","url":"git://github.com/mislav/dotfiles.git","host":"github.com","project":"mislav/dotfiles","main":true},{"name":"upstream","url":"ssh://git@github.com/github/dotfiles.git","host":"github.com","project":"github/dotfiles","main":false}]\n
+      """
+
+  Scenario: Act on the repository of a given remote
+    Given the "upstream" remote has url "git@github.com:github/dotfiles.git"
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/issues') {
+        json [
+          { :number => 102,
+            :title => "First issue",
+            :state => "open",
+            :user => { :login => "octocat" },
+          },
+        ]
+      }
+      """
+    When I successfully run `hub --remote origin issue`
+    Then the output should contain exactly:
+      """
+          #102  First issue\n
+      """
+
+  Scenario: Unknown remote
+    When I run `hub --remote nonexistent issue`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: no git remote named 'nonexistent'\n
+      """
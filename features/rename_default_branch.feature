@@ -0,0 +1,58 @@
+Feature: hub rename-default-branch
+  Background:
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And I make a commit
+    And I successfully run `git update-ref refs/remotes/origin/master HEAD`
+    And I successfully run `git branch --set-upstream-to origin/master`
+
+  Scenario: Rename the default branch
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles') {
+        json :name => "dotfiles", :owner => { :login => "mislav" },
+          :default_branch => "master"
+      }
+      post('/repos/mislav/dotfiles/branches/master/rename') {
+        assert :new_name => "main"
+        status 201
+        json :name => "main"
+      }
+      """
+    And I successfully run `git update-ref refs/remotes/origin/main HEAD`
+    When I successfully run `hub rename-default-branch main`
+    Then the output should contain exactly:
+      """
+      Renamed default branch of mislav/dotfiles from master to main
+      Renamed local branch master to main
+      Branch main now tracks origin/main\n
+      """
+    And "git fetch --prune --quiet origin" should be run
+    And "git remote set-head origin main" should be run
+
+  Scenario: Preview renaming the default branch
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles') {
+        json :name => "dotfiles", :owner => { :login => "mislav" },
+          :default_branch => "master"
+      }
+      """
+    When I successfully run `hub --noop rename-default-branch main`
+    Then the output should contain exactly:
+      """
+      Would rename default branch of mislav/dotfiles from master to main
+      Would update the local clone to use origin/main\n
+      """
+
+  Scenario: Default branch of another repository already has the name
+    Given the GitHub API server:
+      """
+      get('/repos/octocat/spoon-knife') {
+        json :name => "spoon-knife", :owner => { :login => "octocat" },
+          :default_branch => "main"
+      }
+      """
+    When I run `hub rename-default-branch -R octocat/spoon-knife main`
+    Then the stderr should contain exactly "The default branch of octocat/spoon-knife is already named main\n"
+    And the exit status should be 1
@@ -0,0 +1,394 @@
+Feature: hub repo
+  Background:
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: View the current repository
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles') {
+        json :name => "dotfiles", :full_name => "mislav/dotfiles",
+          :owner => { :login => "mislav" },
+          :description => "My dotfiles", :homepage => "https://mislav.net",
+          :default_branch => "main", :private => false, :archived => true,
+          :html_url => "https://github.com/mislav/dotfiles",
+          :stargazers_count => 12, :forks_count => 3, :open_issues_count => 1
+      }
+      get('/repos/mislav/dotfiles/topics') {
+        json :names => ["vim", "zsh"]
+      }
+      """
+    When I successfully run `hub repo view`
+    Then the output should contain exactly:
+      """
+      mislav/dotfiles
+      My dotfiles
+      https://mislav.net
+
+      Default branch: main
+      Visibility: public archived
+      Topics: vim, zsh
+      Stars: 12  Forks: 3  Open issues: 1
+
+      https://github.com/mislav/dotfiles\n
+      """
+
+  Scenario: View a repository with a custom format
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub') {
+        json :name => "hub", :full_name => "github/hub",
+          :owner => { :login => "github" },
+          :description => "A git wrapper", :homepage => "https://hub.github.com",
+          :default_branch => "master", :private => true, :archived => false,
+          :html_url => "https://github.com/github/hub",
+          :stargazers_count => 22000, :forks_count => 2000, :open_issues_count => 700
+      }
+      get('/repos/github/hub/topics') {
+        json :names => []
+      }
+      """
+    When I successfully run `hub repo view -f "%N|%U|%d|%h|%B|%v|%a|%T|%st|%fk|%oi%n" github/hub`
+    Then the output should contain exactly:
+      """
+      github/hub|https://github.com/github/hub|A git wrapper|https://hub.github.com|master|private|||22000|2000|700\n
+      """
+
+  Scenario: Edit repository settings
+    Given the GitHub API server:
+      """
+      patch('/repos/mislav/dotfiles') {
+        assert :description => "New description",
+               :default_branch => "trunk",
+               :allow_squash_merge => false,
+               :delete_branch_on_merge => true,
+               :homepage => :no,
+               :allow_merge_commit => :no,
+               :allow_rebase_merge => :no
+        json :name => "dotfiles"
+      }
+      """
+    When I successfully run `hub repo edit -d "New description" -b trunk --squash-merge false --delete-branch-on-merge true`
+    Then the output should contain exactly ""
+
+  Scenario: Edit with an invalid boolean
+    When I run `hub repo edit --merge-commit maybe`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid value for --merge-commit: 'maybe'\n
+      """
+
+  Scenario: Edit without any settings
+    When I run `hub repo edit`
+    Then the exit status should be 2
+    And the stderr should contain "no settings to edit\n"
+
+  Scenario: List topics
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/topics') {
+        json :names => ["vim", "zsh"]
+      }
+      """
+    When I successfully run `hub repo topics`
+    Then the output should contain exactly:
+      """
+      vim
+      zsh\n
+      """
+
+  Scenario: Add and remove topics
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/topics') {
+        json :names => ["vim", "zsh"]
+      }
+      put('/repos/mislav/dotfiles/topics') {
+        assert :names => ["zsh", "tmux"]
+        json :names => ["zsh", "tmux"]
+      }
+      """
+    When I successfully run `hub repo topics --add Tmux,zsh --remove vim`
+    Then the output should contain exactly:
+      """
+      zsh
+      tmux\n
+      """
+
+  Scenario: Replace all topics
+    Given the GitHub API server:
+      """
+      put('/repos/mislav/dotfiles/topics') {
+        assert :names => ["cli", "git"]
+        json :names => ["cli", "git"]
+      }
+      """
+    When I successfully run `hub repo topics --set cli,git`
+    Then the output should contain exactly:
+      """
+      cli
+      git\n
+      """
+
+  Scenario: Archive after confirmation
+    Given the GitHub API server:
+      """
+      patch('/repos/mislav/dotfiles') {
+        assert :archived => true
+        json :name => "dotfiles"
+      }
+      """
+    When I run `hub repo archive` interactively
+    And I type "yes"
+    Then the exit status should be 0
+    And the output should contain exactly:
+      """
+      Really archive repository 'mislav/dotfiles' (yes/N)? Archived repository 'mislav/dotfiles'.\n
+      """
+
+  Scenario: Decline to archive
+    When I run `hub repo archive` interactively
+    And I type "no"
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Please type 'yes' for confirmation.\n
+      """
+
+  Scenario: Archive without confirmation
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub') {
+        assert :archived => true
+        json :name => "hub"
+      }
+      """
+    When I successfully run `hub repo archive --yes github/hub`
+    Then the output should contain exactly:
+      """
+      Archived repository 'github/hub'.\n
+      """
+
+  Scenario: Unarchive
+    Given the GitHub API server:
+      """
+      patch('/repos/mislav/dotfiles') {
+        assert :archived => false
+        json :name => "dotfiles"
+      }
+      """
+    When I successfully run `hub repo unarchive`
+    Then the output should contain exactly:
+      """
+      Unarchived repository 'mislav/dotfiles'.\n
+      """
+
+  Scenario: Transfer after confirmation
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/dotfiles/transfer') {
+        assert :new_owner => "acme"
+        status 202
+        json :name => "dotfiles", :owner => { :login => "acme" }
+      }
+      """
+    When I run `hub repo transfer acme` interactively
+    And I type "yes"
+    Then the exit status should be 0
+    And the output should contain exactly:
+      """
+      Really transfer repository 'mislav/dotfiles' to 'acme' (yes/N)? Transferred repository 'mislav/dotfiles' to 'acme'.\n
+      """
+
+  Scenario: Decline to transfer
+    When I run `hub repo transfer acme` interactively
+    And I close the stdin stream
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Please type 'yes' for confirmation.\n
+      """
+
+  Scenario: Transfer without confirmation
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/transfer') {
+        assert :new_owner => "acme"
+        status 202
+        json :name => "hub", :owner => { :login => "acme" }
+      }
+      """
+    When I successfully run `hub repo transfer -y acme github/hub`
+    Then the output should contain exactly:
+      """
+      Transferred repository 'github/hub' to 'acme'.\n
+      """
+
+  Scenario: Transfer without a new owner
+    When I run `hub repo transfer`
+    Then the exit status should be 2
+    And the stderr should contain "you must specify the new owner\n"
+
+  Scenario: Mirror a repository
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/tool') {
+        json :name => "tool", :owner => { :login => "mislav" },
+          :description => "A tool", :homepage => "https://tool.dev",
+          :private => false, :default_branch => "main"
+      }
+      get('/repos/mislav/tool/topics') {
+        json :names => ["cli", "go"]
+      }
+      get('/repos/mislav/tool/labels') {
+        json [
+          { :name => "bug", :color => "d73a4a" },
+          { :name => "docs", :color => "0075ca" },
+        ]
+      }
+      get('/repos/mislav/tool/milestones') {
+        assert :state => "all"
+        json [{ :number => 3, :title => "v1.0", :state => "open", :description => "First" }]
+      }
+      post('/user/repos') {
+        assert :name => "tool-mirror", :description => "A tool",
+          :homepage => "https://tool.dev", :private => false
+        status 201
+        json :name => "tool-mirror", :owner => { :login => "mislav" },
+          :html_url => "https://github.com/mislav/tool-mirror"
+      }
+      patch('/repos/mislav/tool-mirror') {
+        assert :default_branch => "main"
+        json :name => "tool-mirror"
+      }
+      put('/repos/mislav/tool-mirror/topics') {
+        assert :names => ["cli", "go"]
+        json :names => ["cli", "go"]
+      }
+      get('/repos/mislav/tool-mirror/labels') {
+        json [{ :name => "bug", :color => "d73a4a" }]
+      }
+      post('/repos/mislav/tool-mirror/labels') {
+        assert :name => "docs", :color => "0075ca"
+        status 201
+        json :name => "docs"
+      }
+      get('/repos/mislav/tool-mirror/milestones') {
+        json []
+      }
+      post('/repos/mislav/tool-mirror/milestones') {
+        assert :title => "v1.0", :description => "First", :state => "open"
+        status 201
+        json :number => 1, :title => "v1.0"
+      }
+      """
+    When I successfully run `hub repo mirror mislav/tool mislav/tool-mirror`
+    Then the output should contain exactly:
+      """
+      Created repository 'mislav/tool-mirror'.
+      Created label 'docs'
+      Created milestone 'v1.0'
+      https://github.com/mislav/tool-mirror\n
+      """
+    And a command like "git clone --bare git@github.com:mislav/tool.git *" should be run
+    And a command like "git --git-dir * push --mirror git@github.com:mislav/tool-mirror.git" should be run
+
+  Scenario: Mirror into an organization and import the open issues
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/tool') {
+        json :name => "tool", :owner => { :login => "mislav" },
+          :private => false, :default_branch => "main"
+      }
+      get('/repos/mislav/tool/topics') { json :names => [] }
+      get('/repos/mislav/tool/labels') { json [] }
+      get('/repos/mislav/tool/milestones') { json [] }
+      post('/orgs/acme/repos') {
+        assert :name => "tool", :private => true
+        status 201
+        json :name => "tool", :owner => { :login => "acme" },
+          :html_url => "https://github.com/acme/tool"
+      }
+      patch('/repos/acme/tool') { json :name => "tool" }
+      get('/repos/acme/tool/labels') { json [] }
+      get('/repos/mislav/tool/issues') {
+        assert :state => "open", :sort => "created", :direction => "asc"
+        json [
+          { :number => 4, :title => "Crash", :body => "It crashes",
+            :user => { :login => "mislav" }, :created_at => "2020-04-01T10:00:00Z",
+            :html_url => "https://github.com/mislav/tool/issues/4",
+            :labels => [], :comments => 0 },
+          { :number => 5, :title => "Fix crash",
+            :pull_request => { :url => "https://api.github.com/repos/mislav/tool/pulls/5" } },
+        ]
+      }
+      post('/repos/acme/tool/issues') {
+        assert :title => "Crash"
+        status 201
+        json :number => 1, :html_url => "https://github.com/acme/tool/issues/1"
+      }
+      """
+    When I successfully run `hub repo mirror -p --issues mislav/tool acme/tool`
+    Then the output should contain exactly:
+      """
+      Created repository 'acme/tool'.
+      Imported https://github.com/mislav/tool/issues/4 as https://github.com/acme/tool/issues/1
+      https://github.com/acme/tool\n
+      """
+    And a command like "git --git-dir * push --mirror git@github.com:acme/tool.git" should be run
+
+  Scenario: Some issues fail to transfer
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/tool') {
+        json :name => "tool", :owner => { :login => "mislav" },
+          :private => false, :default_branch => "main"
+      }
+      get('/repos/mislav/tool/topics') { json :names => [] }
+      get('/repos/mislav/tool/labels') { json [] }
+      get('/repos/mislav/tool/milestones') { json [] }
+      post('/user/repos') {
+        status 201
+        json :name => "tool-mirror", :owner => { :login => "mislav" },
+          :node_id => "REPO_NODE", :html_url => "https://github.com/mislav/tool-mirror"
+      }
+      patch('/repos/mislav/tool-mirror') { json :name => "tool-mirror" }
+      get('/repos/mislav/tool-mirror/labels') { json [] }
+      get('/repos/mislav/tool/issues') {
+        assert :state => "open"
+        json [
+          { :number => 4, :node_id => "ISSUE_4", :title => "Crash" },
+          { :number => 6, :node_id => "ISSUE_6", :title => "Typo" },
+        ]
+      }
+      post('/graphql') {
+        halt 400 unless params[:query].include?("transferIssue")
+        vars = params[:variables]
+        halt 422 unless vars[:repositoryId] == "REPO_NODE"
+        if vars[:issueId] == "ISSUE_6"
+          json :data => nil, :errors => [{ :message => "Issue transfers are not allowed" }]
+        else
+          json :data => {
+            :transferIssue => { :issue => { :number => 1, :url => "https://github.com/mislav/tool-mirror/issues/1" } }
+          }
+        end
+      }
+      """
+    When I run `hub repo mirror --issues mislav/tool mislav/tool-mirror`
+    Then the exit status should be 1
+    And the stdout should contain exactly:
+      """
+      Created repository 'mislav/tool-mirror'.
+      Transferred issue #4 to https://github.com/mislav/tool-mirror/issues/1\n
+      """
+    And the stderr should contain exactly:
+      """
+      Error: 1 operation(s) failed:
+        6: Error transferring issue: Issue transfers are not allowed\n
+      """
+
+  Scenario: Source and destination are required
+    When I run `hub repo mirror mislav/tool`
+    Then the exit status should be 2
+    And the stderr should contain "you must specify the source and destination repositories"
@@ -0,0 +1,97 @@
+Feature: hub search
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Search pull requests
+    Given the GitHub API server:
+      """
+      get('/search/issues') {
+        assert :q => "type:pr org:acme is:open assignee:@me",
+               :sort => "updated",
+               :order => "desc",
+               :per_page => "45"
+        json :total_count => 2, :items => [
+          { :number => 12,
+            :title => "Fix login",
+            :state => "open",
+            :user => { :login => "octocat" },
+            :repository_url => "https://api.github.com/repos/acme/web" },
+          { :number => 7,
+            :title => "Bump deps",
+            :state => "open",
+            :user => { :login => "octocat" },
+            :repository_url => "https://api.github.com/repos/acme/api" },
+        ]
+      }
+      """
+    When I successfully run `hub search prs -o updated org:acme is:open assignee:@me`
+    Then the output should contain exactly:
+      """
+      acme/web#12  Fix login
+      acme/api#7  Bump deps\n
+      """
+
+  Scenario: Search pull requests awaiting review from a team
+    Given the GitHub API server:
+      """
+      get('/search/issues') {
+        assert :q => "type:pr is:open team-review-requested:acme/core -author:@me"
+        json :total_count => 1, :items => [
+          { :number => 12,
+            :title => "Fix login",
+            :state => "open",
+            :user => { :login => "octocat" },
+            :repository_url => "https://api.github.com/repos/acme/web" },
+        ]
+      }
+      """
+    When I successfully run `hub search prs is:open review-requested:acme/core -author:@me`
+    Then the output should contain exactly:
+      """
+      acme/web#12  Fix login\n
+      """
+
+  Scenario: Search repositories with a format
+    Given the GitHub API server:
+      """
+      get('/search/repositories') {
+        assert :q => "topic:cli language:go",
+               :per_page => "3"
+        json :total_count => 1, :items => [
+          { :full_name => "github/hub",
+            :stargazers_count => 22000,
+            :html_url => "https://github.com/github/hub" },
+        ]
+      }
+      """
+    When I successfully run `hub search repos -L 2 -f "%N %st%n" topic:cli language:go`
+    Then the output should contain exactly:
+      """
+      github/hub 22000\n
+      """
+
+  Scenario: Search code as JSON
+    Given the GitHub API server:
+      """
+      get('/search/code') {
+        assert :q => "org:acme filename:Dockerfile"
+        json :total_count => 1, :items => [
+          { :name => "Dockerfile",
+            :path => "deploy/Dockerfile",
+            :sha => "abc123",
+            :html_url => "https://github.com/acme/web/blob/main/deploy/Dockerfile",
+            :repository => { :full_name => "acme/web" } },
+        ]
+      }
+      """
+    When I successfully run `hub search code --output json "org:acme filename:Dockerfile"`
+    Then the output should contain exactly:
+      """
+      [{"repository":"acme/web","path":"deploy/Dockerfile","url":"https://github.com/acme/web/blob/main/deploy/Dockerfile","sha":"abc123"}]\n
+      """
+
+  Scenario: Search requires a query
+    When I run `hub search issues`
+    Then the exit status should be 1
+    And the stderr should contain "you must specify a search query"
@@ -0,0 +1,51 @@
+Feature: hub secret
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List repository secrets
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/secrets') {
+        json :total_count => 2, :secrets => [
+          { :name => "NPM_TOKEN", :updated_at => "2020-01-10T10:00:00Z" },
+          { :name => "DEPLOY_KEY", :updated_at => "2020-02-20T10:00:00Z" },
+        ]
+      }
+      """
+    When I successfully run `hub secret list`
+    Then the output should contain exactly:
+      """
+      NPM_TOKEN   Updated 2020-01-10
+      DEPLOY_KEY  Updated 2020-02-20\n
+      """
+
+  Scenario: Set an organization secret for selected repositories
+    Given the GitHub API server:
+      """
+      get('/orgs/acme/dependabot/secrets/public-key') {
+        json :key_id => "KEYID", :key => "2Sg8iYjAxxmI2LvUXpJjkYrMxURPc8r+dB7TJyvv1234="
+      }
+      get('/repos/acme/api') {
+        json :id => 1296269
+      }
+      put('/orgs/acme/dependabot/secrets/REGISTRY_PASSWORD') {
+        assert :key_id => "KEYID",
+               :visibility => "selected",
+               :selected_repository_ids => [1296269]
+        halt 400 unless params[:encrypted_value].to_s.length > 0
+        status 201
+      }
+      """
+    When I successfully run `hub secret set --org acme --app dependabot --repos api -b s3cret REGISTRY_PASSWORD`
+    Then the output should contain exactly "Set secret REGISTRY_PASSWORD for acme\n"
+
+  Scenario: Remove a secret
+    Given the GitHub API server:
+      """
+      delete('/repos/github/hub/actions/secrets/NPM_TOKEN') {
+        status 204
+      }
+      """
+    When I successfully run `hub secret remove NPM_TOKEN`
+    Then the output should contain exactly "Removed secret NPM_TOKEN from github/hub\n"
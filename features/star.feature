@@ -0,0 +1,115 @@
+Feature: hub star
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Star the current repository
+    Given the GitHub API server:
+      """
+      put('/user/starred/github/hub') {
+        status 204
+      }
+      """
+    When I successfully run `hub star`
+    Then the output should contain exactly "Starred github/hub\n"
+
+  Scenario: Star quietly
+    Given the GitHub API server:
+      """
+      put('/user/starred/github/hub') {
+        status 204
+      }
+      """
+    When I successfully run `hub --quiet star`
+    Then the output should not contain anything
+
+  Scenario: Star a repository that doesn't exist
+    Given the GitHub API server:
+      """
+      put('/user/starred/github/nope') {
+        status 404
+        json :message => "Not Found"
+      }
+      """
+    When I run `hub star github/nope`
+    Then the exit status should be 3
+
+  Scenario: Unstar another repository
+    Given the GitHub API server:
+      """
+      delete('/user/starred/rtomayko/ronn') {
+        status 204
+      }
+      """
+    When I successfully run `hub unstar rtomayko/ronn`
+    Then the output should contain exactly "Unstarred rtomayko/ronn\n"
+
+  Scenario: List repositories starred by a user
+    Given the GitHub API server:
+      """
+      get('/users/defunkt/starred') {
+        assert :per_page => "3"
+        json [
+          { :full_name => "github/hub" },
+          { :full_name => "rtomayko/ronn" },
+          { :full_name => "sinatra/sinatra" },
+        ]
+      }
+      """
+    When I successfully run `hub star --list -L 2 defunkt`
+    Then the output should contain exactly:
+      """
+      github/hub
+      rtomayko/ronn\n
+      """
+
+  Scenario: Watch all activity
+    Given the GitHub API server:
+      """
+      put('/repos/github/hub/subscription') {
+        assert :subscribed => true, :ignored => false
+        json :subscribed => true, :ignored => false
+      }
+      """
+    When I successfully run `hub watch`
+    Then the output should contain exactly "github/hub: all activity\n"
+
+  Scenario: Ignore a repository
+    Given the GitHub API server:
+      """
+      put('/repos/github/hub/subscription') {
+        assert :subscribed => false, :ignored => true
+        json :subscribed => false, :ignored => true
+      }
+      """
+    When I successfully run `hub watch --ignore`
+    Then the output should contain exactly "github/hub: ignoring\n"
+
+  Scenario: Stop watching
+    Given the GitHub API server:
+      """
+      delete('/repos/github/hub/subscription') {
+        status 204
+      }
+      """
+    When I successfully run `hub watch --participating`
+    Then the output should contain exactly "github/hub: participating and @mentions\n"
+
+  Scenario: Show the notification setting
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/subscription') {
+        status 404
+        json :message => "Not Found"
+      }
+      """
+    When I successfully run `hub watch --show`
+    Then the output should contain exactly "participating and @mentions\n"
+
+  Scenario: Releases only
+    When I run `hub watch --releases-only`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: the GitHub API doesn't support watching only releases; change it at https://github.com/github/hub\n
+      """
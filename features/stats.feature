@@ -0,0 +1,83 @@
+Feature: hub stats
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Wait for contributor statistics to be computed
+    Given the GitHub API server:
+      """
+      count = 0
+      get('/repos/github/hub/stats/contributors') {
+        count += 1
+        halt 202, json({}) if count == 1
+        json [
+          { :author => { :login => "octocat" }, :total => 3,
+            :weeks => [{ :w => 1577577600, :a => 10, :d => 2, :c => 1 }, { :w => 1578182400, :a => 5, :d => 0, :c => 2 }] },
+          { :author => { :login => "mislav" }, :total => 12,
+            :weeks => [{ :w => 1577577600, :a => 300, :d => 120, :c => 12 }] },
+        ]
+      }
+      """
+    When I successfully run `hub stats contributors`
+    Then the output should contain exactly:
+      """
+      12  +300  -120  mislav
+       3   +15    -2  octocat\n
+      """
+
+  Scenario: Contributors as JSON
+    Given the GitHub API server:
+      """
+      get('/repos/acme/anvils/stats/contributors') {
+        json [
+          { :author => { :login => "wile" }, :total => 1, :weeks => [{ :w => 1577577600, :a => 1, :d => 1, :c => 1 }] },
+          { :author => { :login => "coyote" }, :total => 2, :weeks => [] },
+        ]
+      }
+      """
+    When I successfully run `hub stats contributors -L 1 --json acme/anvils`
+    Then the output should contain exactly:
+      """
+      [{"login":"coyote","commits":2,"additions":0,"deletions":0}]\n
+      """
+
+  Scenario: Commit activity of the last weeks
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/stats/commit_activity') {
+        json [
+          { :week => 1577577600, :total => 9, :days => [0, 1, 2, 3, 2, 1, 0] },
+          { :week => 1578182400, :total => 14, :days => [0, 4, 2, 3, 2, 3, 0] },
+          { :week => 1578787200, :total => 0, :days => [0, 0, 0, 0, 0, 0, 0] },
+        ]
+      }
+      """
+    When I successfully run `hub stats commit-activity --weeks 2`
+    Then the output should contain exactly:
+      """
+      2020-01-05  14  0  4  2  3  2  3  0
+      2020-01-12   0  0  0  0  0  0  0  0\n
+      """
+
+  Scenario: Punch card as JSON
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/stats/punch_card') {
+        json [[0, 0, 5], [1, 13, 7]]
+      }
+      """
+    When I successfully run `hub stats punch-card --json`
+    Then the output should contain exactly:
+      """
+      [{"day":"Sun","hour":0,"commits":5},{"day":"Mon","hour":13,"commits":7}]\n
+      """
+
+  Scenario: Repository without commits
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/stats/contributors') {
+        status 204
+      }
+      """
+    When I successfully run `hub stats contributors`
+    Then the output should contain exactly ""
@@ -26,6 +26,10 @@ Given(/^the "([^"]*)" remote has(?: (push))? url "([^"]*)"$/) do |remote_name, p
   end
 end
 
+Given(/^GitHub denies pushes to the "([^"]*)" remote$/) do |remote_name|
+  File.open(expand_path('~/.push_denied'), 'a') { |f| f.puts remote_name }
+end
+
 Given(/^I am "([^"]*)" on ([\S]+)(?: with OAuth token "([^"]*)")?$/) do |name, host, token|
   edit_hub_config do |cfg|
     entry = {'user' => name}
@@ -36,6 +40,14 @@ Given(/^I am "([^"]*)" on ([\S]+)(?: with OAuth token "([^"]*)")?$/) do |name, h
   end
 end
 
+Given(/^I am also "([^"]*)" on ([\S]+) with OAuth token "([^"]*)" in profile "([^"]*)"(?: for owner "([^"]*)")?$/) do |name, host, token, profile, owner|
+  edit_hub_config do |cfg|
+    entry = {'user' => name, 'oauth_token' => token, 'profile' => profile}
+    entry['owners'] = [owner] if owner
+    (cfg[host.downcase] ||= []) << entry
+  end
+end
+
 Given(/^\$(\w+) is "([^"]*)"$/) do |name, value|
   expanded_value = value.gsub(/\$([A-Z_]+)/) { aruba.environment[$1] }
   set_environment_variable(name, expanded_value)
@@ -157,6 +169,13 @@ Given(/^the GitHub API server:$/) do |endpoints_str|
   set_environment_variable 'HUB_TEST_HOST', "http://127.0.0.1:#{@server.port}"
 end
 
+# `<HEAD>` in the endpoints stands for the SHA of the current commit
+Given(/^the GitHub API server at the current HEAD:$/) do |endpoints_str|
+  run_command_and_stop %(git rev-parse HEAD)
+  head = last_command_started.output.chomp
+  step %(the GitHub API server:), endpoints_str.gsub('<HEAD>', head)
+end
+
 Then(/^shell$/) do
   cd('.') do
     system '/bin/bash -i'
@@ -167,6 +186,12 @@ Then(/^"([^"]*)" should be run$/) do |cmd|
   assert_command_run cmd
 end
 
+# `*` stands for an argument that differs between runs, such as a temp dir
+Then(/^a command like "([^"]*)" should be run$/) do |pattern|
+  parts = pattern.split('*', -1).map { |part| Regexp.escape(part) }
+  expect(history).to include(a_string_matching(/\A#{parts.join('\S+')}\n\z/))
+end
+
 Then(/^it should clone "([^"]*)"$/) do |repo|
   step %("git clone #{repo}" should be run)
 end
@@ -269,6 +294,41 @@ Given(/^the text editor adds:$/) do |text|
   BASH
 end
 
+Given(/^an executable "([^"]+)" on PATH with:$/) do |name, code|
+  executable_script name, code
+end
+
+# runs the command attached to a pseudo-terminal, so that hub behaves as if
+# it was used interactively
+When(/^I run `([^`]*)` in a terminal$/) do |cmd|
+  require 'pty'
+  @terminal_output = ''
+  cd('.') do
+    PTY.spawn(aruba.environment.to_h, cmd) do |output, _input, pid|
+      begin
+        output.each_line { |line| @terminal_output << line }
+      rescue Errno::EIO
+        # raised on Linux once the command exits
+      end
+      Process.wait(pid)
+    end
+  end
+  @terminal_output.force_encoding('UTF-8').gsub!("\r\n", "\n")
+end
+
+Then(/^the terminal output should contain( exactly)?:$/) do |exactly, text|
+  expected = text.gsub('\n', "\n").gsub('\e', "\e")
+  if exactly
+    expect(@terminal_output).to eq(expected)
+  else
+    expect(@terminal_output).to include(expected)
+  end
+end
+
+Then(/^the terminal output should not contain "([^"]*)"$/) do |text|
+  expect(@terminal_output).not_to include(text)
+end
+
 When(/^I pass in:$/) do |input|
   type(input)
   close_input
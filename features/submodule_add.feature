@@ -71,3 +71,28 @@ Feature: hub submodule add
       """
     When I successfully run `hub submodule add --branch foo mojombo/grit vendor/grit`
     Then "git submodule add --branch foo git://github.com/mojombo/grit.git vendor/grit" should be run
+
+  Scenario: A private repo is added over SSH
+    Given the GitHub API server:
+      """
+      get('/repos/mojombo/grit') {
+        json :private => true,
+             :name => 'grit', :owner => { :login => 'mojombo' },
+             :permissions => { :push => false }
+      }
+      """
+    When I successfully run `hub submodule add mojombo/grit vendor/grit`
+    Then the "vendor/grit" submodule url should be "git@github.com:mojombo/grit.git"
+
+  Scenario: Add submodule over HTTPS
+    Given git "hub.protocol" is set to "https"
+    And the GitHub API server:
+      """
+      get('/repos/mojombo/grit') {
+        json :private => true,
+             :name => 'grit', :owner => { :login => 'mojombo' },
+             :permissions => { :push => false }
+      }
+      """
+    When I successfully run `hub submodule add mojombo/grit vendor/grit`
+    Then the "vendor/grit" submodule url should be "https://github.com/mojombo/grit.git"
@@ -36,6 +36,7 @@ Before do
     # https://specifications.freedesktop.org/basedir-spec/basedir-spec-latest.html#variables
     'XDG_CONFIG_HOME' => nil,
     'XDG_CONFIG_DIRS' => nil,
+    'XDG_CACHE_HOME' => nil,
     # used in fakebin/git
     'HUB_SYSTEM_GIT' => system_git,
     # ensure that api.github.com is actually never hit in tests
@@ -70,6 +71,8 @@ end
 After do
   @server.stop if defined? @server and @server
   FileUtils.rm_f("#{tmp_bin_dir}/vim")
+  FileUtils.rm_f(Dir["#{tmp_bin_dir}/hub-*"])
+  FileUtils.rm_f(Dir["#{tmp_bin_dir}/*pager"])
 end
 
 After('@cache_clear') do
@@ -133,6 +136,14 @@ World Module.new {
     }
   end
 
+  define_method(:executable_script) do |name, bash_code|
+    FileUtils.mkdir_p(tmp_bin_dir)
+    File.open("#{tmp_bin_dir}/#{name}", 'w', 0755) { |exe|
+      exe.puts "#!/bin/bash"
+      exe.puts bash_code
+    }
+  end
+
   def empty_commit(message = nil)
     unless message
       @empty_commit_count = defined?(@empty_commit_count) ? @empty_commit_count + 1 : 1
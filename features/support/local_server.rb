@@ -2,6 +2,9 @@
 require 'net/http'
 require 'rack/handler/webrick'
 require 'json'
+require 'zlib'
+require 'base64'
+require 'stringio'
 require 'sinatra/base'
 
 module Hub
@@ -76,7 +79,7 @@ module Hub
                   params[key].inspect
                 ]
               ) if params.key?(key.to_s)
-            elsif params[key] != value
+            elsif comparable(params[key]) != comparable(value)
               halt 422, json(
                 :message => "expected %s to be %s; got %s" % [
                   key.inspect,
@@ -88,6 +91,27 @@ module Hub
           end
         end
 
+        # checks some of the variables of a GraphQL request
+        def assert_variables(expected)
+          variables = params[:variables] || {}
+          expected.each do |key, value|
+            if comparable(variables[key.to_s]) != comparable(value)
+              halt 422, json(
+                :message => "expected variable %s to be %s; got %s" % [
+                  key.inspect,
+                  value.inspect,
+                  variables[key.to_s].inspect
+                ]
+              )
+            end
+          end
+        end
+
+        # nested params have string keys, while expected values use symbols
+        def comparable(value)
+          value.is_a?(Hash) ? JSON.parse(JSON.generate(value)) : value
+        end
+
         def assert_basic_auth(*expected)
           require 'rack/auth/basic'
           auth = Rack::Auth::Basic::Request.new(env)
@@ -26,15 +26,69 @@ Feature: hub sync
     Then the output should contain "Updated branch feature"
     And the output should contain "Updated branch bugfix"
 
+  Scenario: Fast-forwards more local branches than are inspected at once
+    Given I am on the "feature" branch pushed to "origin/feature"
+    And I successfully run `git reset -q --hard HEAD^`
+    And I am on the "bugfix" branch pushed to "origin/bugfix"
+    And I successfully run `git reset -q --hard HEAD^`
+    And I am on the "docs" branch pushed to "origin/docs"
+    And I successfully run `git reset -q --hard HEAD^`
+    And I am on the "refactor" branch pushed to "origin/refactor"
+    And I successfully run `git reset -q --hard HEAD^`
+    And I am on the "release" branch pushed to "origin/release"
+    And I successfully run `git reset -q --hard HEAD^`
+    And I make a commit with message "diverge"
+    And I successfully run `git checkout -q master`
+    When I successfully run `hub sync`
+    Then the output should contain "Updated branch bugfix"
+    And the output should contain "Updated branch docs"
+    And the output should contain "Updated branch feature"
+    And the output should contain "Updated branch refactor"
+    And the stderr should contain exactly:
+      """
+      warning: 'release' has diverged from 'origin/release' (1 ahead, 1 behind)\n
+      """
+
   Scenario: Refuses to update local branch which has diverged from upstream
     Given I am on the "feature" branch pushed to "origin/feature"
     And I make a commit with message "diverge"
     When I successfully run `hub sync`
     Then the stderr should contain exactly:
       """
-      warning: 'feature' seems to contain unpushed commits\n
+      warning: 'feature' seems to contain unpushed commits (1 ahead)\n
       """
 
+  Scenario: Reports local branch which has diverged from upstream
+    Given I am on the "feature" branch pushed to "origin/feature"
+    And I successfully run `git reset -q --hard HEAD^`
+    And I make a commit with message "diverge"
+    When I successfully run `hub sync`
+    Then the stderr should contain exactly:
+      """
+      warning: 'feature' has diverged from 'origin/feature' (1 ahead, 1 behind)\n
+      """
+
+  Scenario: Rebases local branch which has diverged from upstream
+    Given I am on the "feature" branch pushed to "origin/feature"
+    And I successfully run `git reset -q --hard HEAD^`
+    And I make a commit with message "diverge"
+    And I successfully run `git checkout -q master`
+    When I successfully run `hub sync --rebase`
+    Then the output should contain "Rebased branch feature onto origin/feature"
+    And "git rebase --quiet refs/remotes/origin/feature feature" should be run
+    And "git checkout --quiet master" should be run
+
+  Scenario: Previews actions on local branches
+    Given I am on the "feature" branch pushed to "origin/feature"
+    And I successfully run `git reset -q --hard HEAD^`
+    And I make a commit with message "diverge"
+    And I am on the "bugfix" branch pushed to "origin/bugfix"
+    And I successfully run `git reset -q --hard HEAD^`
+    When I successfully run `hub sync --rebase --dry-run`
+    Then the output should contain "Would update branch bugfix"
+    And the output should contain "Would rebase branch feature onto origin/feature (1 ahead, 1 behind)."
+    And "git rebase --quiet refs/remotes/origin/feature feature" should not be run
+
   Scenario: Deletes local branch that had its upstream deleted
     Given I am on the "feature" branch with upstream "origin/feature"
     And I successfully run `git checkout -q master`
@@ -45,6 +99,18 @@ Feature: hub sync
     When I successfully run `hub sync`
     Then the output should contain "Deleted branch feature"
 
+  Scenario: Previews deleting local branch that had its upstream deleted
+    Given I am on the "feature" branch with upstream "origin/feature"
+    And I successfully run `git checkout -q master`
+    And I successfully run `git merge --no-ff --no-edit feature`
+    And I successfully run `git update-ref refs/remotes/origin/master HEAD`
+    And I successfully run `rm .git/refs/remotes/origin/feature`
+    And I successfully run `git checkout -q feature`
+    When I successfully run `hub sync --dry-run`
+    Then the output should match /^Would delete branch feature \(was [0-9a-f]{7}\)\.$/
+    And "git branch -D feature" should not be run
+    And "git checkout --quiet master" should not be run
+
   Scenario: Refuses to delete local branch whose upstream was deleted but not merged to master
     Given I am on the "feature" branch with upstream "origin/feature"
     And I successfully run `rm .git/refs/remotes/origin/feature`
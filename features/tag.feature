@@ -0,0 +1,45 @@
+Feature: hub tag
+  Background:
+    Given I am in "git://github.com/mislav/coral.git" git repo
+    And I make a commit
+    And I successfully run `git tag v1.2.3`
+    And I successfully run `git tag v1.10.0`
+    And I successfully run `git tag v1.10.0-rc.1`
+    And I successfully run `git tag nightly`
+
+  Scenario: List tags by semantic version
+    When I successfully run `hub tag list --sort semver`
+    Then the output should contain exactly:
+      """
+      v1.10.0
+      v1.10.0-rc.1
+      v1.2.3
+      nightly\n
+      """
+
+  Scenario: Create the next version tag
+    When I successfully run `hub tag create --next minor -m "Release"`
+    Then the output should contain exactly "v1.11.0\n"
+    And "git tag --annotate --message Release v1.11.0" should be run
+
+  Scenario: Push the new tag
+    When I successfully run `hub tag create v1.2.4 --push`
+    Then "git tag v1.2.4" should be run
+    And "git push origin refs/tags/v1.2.4" should be run
+
+  Scenario: Delete a tag locally and from the remote
+    When I successfully run `hub --noop tag delete --remote v1.2.3`
+    Then the output should contain exactly:
+      """
+      git tag --delete v1.2.3
+      git push origin --delete refs/tags/v1.2.3\n
+      """
+
+  Scenario: Delete a missing tag
+    When I run `hub tag delete v9.9.9`
+    Then the exit status should be 1
+    And the stderr should contain exactly "tag 'v9.9.9' not found\n"
+
+  Scenario: Pass through to git tag
+    When I successfully run `hub tag v2.0.0`
+    Then the git command should be unchanged
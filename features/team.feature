@@ -0,0 +1,66 @@
+Feature: hub team
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List teams
+    Given the GitHub API server:
+      """
+      get('/orgs/github/teams') {
+        json [
+          { :slug => "hubbers", :name => "Hubbers" },
+          { :slug => "ops", :name => "Operations" },
+        ]
+      }
+      """
+    When I successfully run `hub team list`
+    Then the output should contain exactly:
+      """
+      github/hubbers  Hubbers
+      github/ops      Operations\n
+      """
+
+  Scenario: List team maintainers
+    Given the GitHub API server:
+      """
+      get('/orgs/github/teams/hubbers/members') {
+        assert :role => "maintainer"
+        json [{ :login => "mislav" }]
+      }
+      """
+    When I successfully run `hub team members --role maintainer @github/hubbers`
+    Then the output should contain exactly "mislav\n"
+
+  Scenario: Add team members
+    Given the GitHub API server:
+      """
+      put('/orgs/github/teams/hubbers/memberships/octocat') {
+        assert :role => "maintainer"
+        json :state => "active", :role => "maintainer"
+      }
+      put('/orgs/github/teams/hubbers/memberships/newbie') {
+        assert :role => "maintainer"
+        json :state => "pending", :role => "maintainer"
+      }
+      """
+    When I successfully run `hub team add --maintainer github/hubbers octocat @newbie`
+    Then the output should contain exactly:
+      """
+      Added octocat to github/hubbers as maintainer
+      Invited newbie to github; they will join github/hubbers once they accept\n
+      """
+
+  Scenario: Remove a team member
+    Given the GitHub API server:
+      """
+      delete('/orgs/github/teams/hubbers/memberships/octocat') {
+        status 204
+      }
+      """
+    When I successfully run `hub team remove github/hubbers octocat`
+    Then the output should contain exactly "Removed octocat from github/hubbers\n"
+
+  Scenario: Invalid team
+    When I run `hub team members hubbers`
+    Then the stderr should contain exactly "invalid team: 'hubbers' (expected <ORG>/<TEAM>)\n"
+    And the exit status should be 1
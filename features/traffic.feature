@@ -0,0 +1,67 @@
+Feature: hub traffic
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List views of the last days
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/traffic/views') {
+        assert :per => "day"
+        today = Time.now.utc.to_date
+        json :count => 150, :uniques => 40, :views => [
+          { :timestamp => "#{today - 5}T00:00:00Z", :count => 100, :uniques => 20 },
+          { :timestamp => "#{today - 1}T00:00:00Z", :count => 42, :uniques => 12 },
+          { :timestamp => "#{today}T00:00:00Z", :count => 8, :uniques => 8 },
+        ]
+      }
+      """
+    When I successfully run `hub traffic views --days 2`
+    Then the output should match /\A\d{4}-\d\d-\d\d  42  12\n\d{4}-\d\d-\d\d   8   8\n\z/
+
+  Scenario: List clones as JSON
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/traffic/clones') {
+        json :count => 3, :uniques => 2, :clones => [
+          { :timestamp => "#{Time.now.utc.to_date}T00:00:00Z", :count => 3, :uniques => 2 },
+        ]
+      }
+      """
+    When I successfully run `hub traffic clones --json`
+    Then the output should match /\A\[\{"timestamp":"\d{4}-\d\d-\d\dT00:00:00Z","count":3,"uniques":2\}\]\n\z/
+
+  Scenario: List popular paths
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/traffic/popular/paths') {
+        json [
+          { :path => "/github/hub", :title => "github/hub", :count => 1200, :uniques => 300 },
+          { :path => "/github/hub/releases", :title => "Releases", :count => 45, :uniques => 30 },
+        ]
+      }
+      """
+    When I successfully run `hub traffic paths`
+    Then the output should contain exactly:
+      """
+      1200  300  /github/hub
+        45   30  /github/hub/releases\n
+      """
+
+  Scenario: List referrers of another repository
+    Given the GitHub API server:
+      """
+      get('/repos/acme/anvils/traffic/popular/referrers') {
+        json [{ :referrer => "news.ycombinator.com", :count => 9, :uniques => 7 }]
+      }
+      """
+    When I successfully run `hub traffic referrers acme/anvils`
+    Then the output should contain exactly:
+      """
+      9  7  news.ycombinator.com\n
+      """
+
+  Scenario: Invalid number of days
+    When I run `hub traffic views --days 30`
+    Then the stderr should contain exactly "invalid number of days: 30 (expected 1 to 14)\n"
+    And the exit status should be 1
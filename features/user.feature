@@ -0,0 +1,105 @@
+Feature: hub user
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: View the profile of a user
+    Given the GitHub API server:
+      """
+      get('/users/octocat') {
+        json :login => "octocat", :type => "User", :name => "The Octocat",
+          :company => "@github", :location => "San Francisco",
+          :public_repos => 8, :public_gists => 2, :followers => 3000, :following => 9,
+          :html_url => "https://github.com/octocat", :created_at => "2011-01-25T18:44:36Z"
+      }
+      get('/users/octocat/keys') {
+        json [{ :id => 1, :key => "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5" }]
+      }
+      """
+    When I successfully run `hub user view octocat`
+    Then the output should contain exactly:
+      """
+      octocat (The Octocat)
+      Company:    @github
+      Location:   San Francisco
+      Repos:      8 public
+      Gists:      2 public
+      Followers:  3000, following 9
+      Joined:     2011-01-25
+      URL:        https://github.com/octocat
+
+      SSH keys:
+        SHA256:5/gdC8tsZ+1R7UnoiM4pMal9U3M82idPVzt95oIw3hQ\n
+      """
+
+  Scenario: View the profile of an organization
+    Given the GitHub API server:
+      """
+      get('/users/github') {
+        json :login => "github", :type => "Organization", :name => "GitHub",
+          :public_repos => 400, :public_gists => 0, :html_url => "https://github.com/github"
+      }
+      """
+    When I successfully run `hub user view github`
+    Then the output should contain exactly:
+      """
+      github (GitHub) [organization]
+      Repos:      400 public
+      Gists:      0 public
+      URL:        https://github.com/github\n
+      """
+
+  Scenario: List the forks of a user
+    Given the GitHub API server:
+      """
+      get('/users/octocat/repos') {
+        assert :type => "owner", :sort => "pushed", :per_page => "100"
+        json [
+          { :full_name => "octocat/hub", :fork => true, :description => "A git wrapper" },
+          { :full_name => "octocat/dotfiles", :fork => false },
+          { :full_name => "octocat/cli", :fork => true },
+        ]
+      }
+      """
+    When I successfully run `hub user repos --sort pushed --forks octocat`
+    Then the output should contain exactly:
+      """
+      octocat/hub  A git wrapper
+      octocat/cli\n
+      """
+
+  Scenario: List my own repositories
+    Given the GitHub API server:
+      """
+      get('/user/repos') {
+        assert :type => "owner", :per_page => "3"
+        json [{ :full_name => "mislav/dotfiles" }, { :full_name => "mislav/secret", :private => true }, { :full_name => "mislav/third" }]
+      }
+      """
+    When I successfully run `hub user repos -L 2 -f "%N %v%n" @me`
+    Then the output should contain exactly:
+      """
+      mislav/dotfiles public
+      mislav/secret private\n
+      """
+
+  Scenario: List the gists of a user
+    Given the GitHub API server:
+      """
+      get('/users/octocat/gists') {
+        json [
+          { :id => "aa1", :description => "notes", :public => true,
+            :files => { "a.md" => {}, "b.md" => {} } },
+        ]
+      }
+      """
+    When I successfully run `hub user gists octocat`
+    Then the output should contain exactly:
+      """
+      aa1  notes  (2 files, public)\n
+      """
+
+  Scenario: Invalid user name
+    When I run `hub user view octo/cat`
+    Then the stderr should contain exactly "invalid user name: 'octo/cat'\n"
+    And the exit status should be 1
@@ -0,0 +1,155 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BlameAuthor is an author that git-blame(1) attributes lines to.
+type BlameAuthor struct {
+	Name  string
+	Email string
+	// Sha is one of the commits by the author that the lines come from.
+	Sha   string
+	Lines int
+}
+
+type lineRange struct {
+	Start int
+	Count int
+}
+
+type fileChanges struct {
+	Path   string
+	Ranges []lineRange
+}
+
+// BlameChanges attributes the lines that head modifies or deletes, compared to
+// its merge base with base, to the authors who last changed them. For lines
+// that are only added, the line preceding them is attributed instead. Authors
+// are sorted by the number of lines attributed to them, most first.
+func BlameChanges(base, head string) ([]*BlameAuthor, error) {
+	mergeBaseCmd := gitCmd("merge-base", base, head)
+	mergeBaseCmd.Stderr = nil
+	output, err := mergeBaseCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Can't find merge base of %s and %s", base, head)
+	}
+	mergeBase := firstLine(output)
+
+	diffCmd := gitCmd("-c", "core.quotepath=false", "diff", "--no-color", "--no-ext-diff", "--no-renames", "--src-prefix=a/", "--dst-prefix=b/", "-U0", mergeBase, head)
+	output, err = diffCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Can't load git diff %s...%s", base, head)
+	}
+
+	authors := map[string]*BlameAuthor{}
+	for _, file := range parseDiffChanges(output) {
+		blameArgs := []string{"blame", "--porcelain"}
+		for _, r := range file.Ranges {
+			blameArgs = append(blameArgs, "-L", fmt.Sprintf("%d,+%d", r.Start, r.Count))
+		}
+		blameArgs = append(blameArgs, mergeBase, "--", file.Path)
+
+		blameCmd := gitCmd(blameArgs...)
+		blameCmd.Stderr = nil
+		output, err := blameCmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("Can't load git blame for %s", file.Path)
+		}
+		parseBlamePorcelain(output, authors)
+	}
+
+	result := []*BlameAuthor{}
+	for _, author := range authors {
+		result = append(result, author)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Lines != result[j].Lines {
+			return result[i].Lines > result[j].Lines
+		}
+		return result[i].Email < result[j].Email
+	})
+
+	return result, nil
+}
+
+var diffHunkRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+\d+(?:,\d+)? @@`)
+
+// parseDiffChanges extracts from a diff with no context lines the ranges of
+// lines in the original version of each file that the diff touches.
+func parseDiffChanges(diff string) []fileChanges {
+	files := []fileChanges{}
+	var file *fileChanges
+
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "diff ") {
+			file = nil
+		} else if strings.HasPrefix(line, "--- ") {
+			path := strings.TrimPrefix(line, "--- ")
+			if unquoted, err := strconv.Unquote(path); err == nil {
+				path = unquoted
+			}
+			if strings.HasPrefix(path, "a/") {
+				files = append(files, fileChanges{Path: strings.TrimPrefix(path, "a/")})
+				file = &files[len(files)-1]
+			}
+		} else if m := diffHunkRe.FindStringSubmatch(line); m != nil && file != nil {
+			start, _ := strconv.Atoi(m[1])
+			count := 1
+			if m[2] != "" {
+				count, _ = strconv.Atoi(m[2])
+			}
+			if count == 0 {
+				// lines were only added after line "start"
+				if start == 0 {
+					continue
+				}
+				count = 1
+			}
+			file.Ranges = append(file.Ranges, lineRange{start, count})
+		}
+	}
+
+	changed := []fileChanges{}
+	for _, file := range files {
+		if len(file.Ranges) > 0 {
+			changed = append(changed, file)
+		}
+	}
+	return changed
+}
+
+var blameHeaderRe = regexp.MustCompile(`^([0-9a-f]{40,64}) \d+ \d+`)
+
+// parseBlamePorcelain tallies the lines of "git blame --porcelain" output by
+// author e-mail.
+func parseBlamePorcelain(output string, authors map[string]*BlameAuthor) {
+	names := map[string]string{}
+	emails := map[string]string{}
+	sha := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			email := emails[sha]
+			if email == "" {
+				continue
+			}
+			author, ok := authors[email]
+			if !ok {
+				author = &BlameAuthor{Name: names[sha], Email: email, Sha: sha}
+				authors[email] = author
+			}
+			author.Lines++
+		} else if strings.HasPrefix(line, "author ") {
+			names[sha] = strings.TrimPrefix(line, "author ")
+		} else if strings.HasPrefix(line, "author-mail ") {
+			emails[sha] = strings.Trim(strings.TrimPrefix(line, "author-mail "), "<>")
+		} else if m := blameHeaderRe.FindStringSubmatch(line); m != nil {
+			sha = m[1]
+		}
+	}
+}
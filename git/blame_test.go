@@ -0,0 +1,75 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestParseDiffChanges(t *testing.T) {
+	diff := `diff --git a/f.txt b/f.txt
+index 1111111..2222222 100644
+--- a/f.txt
++++ b/f.txt
+@@ -1 +1 @@
+-a
++A
+@@ -3,2 +2,0 @@
+-c
+-d
+@@ -7,0 +6,2 @@ g
++h
++i
+diff --git a/new.txt b/new.txt
+new file mode 100644
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++x
+diff --git a/"sp ace.txt" b/"sp ace.txt"
+--- "a/sp ace.txt"
++++ "b/sp ace.txt"
+@@ -0,0 +1 @@
++first
+@@ -5 +6 @@
+-e
++E
+`
+	changes := parseDiffChanges(diff)
+	assert.Equal(t, 2, len(changes))
+
+	assert.Equal(t, "f.txt", changes[0].Path)
+	assert.Equal(t, []lineRange{{1, 1}, {3, 2}, {7, 1}}, changes[0].Ranges)
+
+	assert.Equal(t, "sp ace.txt", changes[1].Path)
+	assert.Equal(t, []lineRange{{5, 1}}, changes[1].Ranges)
+}
+
+func TestParseBlamePorcelain(t *testing.T) {
+	output := `1111111111111111111111111111111111111111 1 1 2
+author Alice
+author-mail <alice@example.com>
+author-time 1500000000
+summary First commit
+filename f.txt
+	a
+1111111111111111111111111111111111111111 2 2
+	b
+2222222222222222222222222222222222222222 7 7 1
+author Bob
+author-mail <bob@example.com>
+summary Second commit
+filename f.txt
+	g
+`
+	authors := map[string]*BlameAuthor{}
+	parseBlamePorcelain(output, authors)
+	parseBlamePorcelain("1111111111111111111111111111111111111111 3 3 1\nauthor Alice\nauthor-mail <alice@example.com>\nfilename g.txt\n\tz\n", authors)
+
+	assert.Equal(t, 2, len(authors))
+	alice := authors["alice@example.com"]
+	assert.Equal(t, "Alice", alice.Name)
+	assert.Equal(t, "1111111111111111111111111111111111111111", alice.Sha)
+	assert.Equal(t, 3, alice.Lines)
+	assert.Equal(t, 1, authors["bob@example.com"].Lines)
+}
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/github/hub/cmd"
@@ -77,27 +78,59 @@ func WorkdirName() (string, error) {
 	return dir, err
 }
 
-func HasFile(segments ...string) bool {
+// gitPath resolves a path within the git dir. Paths such as "refs" resolve to
+// the common dir of the repository when in a linked worktree or a submodule.
+func gitPath(segments ...string) (string, error) {
 	// The blessed way to resolve paths within git dir since Git 2.5.0
 	pathCmd := gitCmd("rev-parse", "-q", "--git-path", filepath.Join(segments...))
 	pathCmd.Stderr = nil
 	if output, err := pathCmd.Output(); err == nil {
 		if lines := outputLines(output); len(lines) == 1 {
-			if _, err := os.Stat(lines[0]); err == nil {
-				return true
-			}
+			return lines[0], nil
 		}
 	}
 
 	// Fallback for older git versions
 	dir, err := Dir()
 	if err != nil {
-		return false
+		return "", err
 	}
 
 	s := []string{dir}
 	s = append(s, segments...)
-	path := filepath.Join(s...)
+	return filepath.Join(s...), nil
+}
+
+// MainWorkdirName is the directory of the main working tree, which differs
+// from WorkdirName in a linked worktree created by `git worktree add`.
+func MainWorkdirName() (string, error) {
+	dir, err := WorkdirName()
+	if err != nil {
+		return dir, err
+	}
+
+	commonDirCmd := gitCmd("rev-parse", "--git-common-dir")
+	commonDirCmd.Stderr = nil
+	output, err := commonDirCmd.Output()
+	commonDir := firstLine(output)
+	if err != nil || filepath.Base(commonDir) != ".git" {
+		// submodules keep their git dir within the one of the superproject
+		return dir, nil
+	}
+
+	commonDir, err = filepath.Abs(commonDir)
+	if err != nil {
+		return dir, nil
+	}
+	return filepath.Dir(commonDir), nil
+}
+
+func HasFile(segments ...string) bool {
+	path, err := gitPath(segments...)
+	if err != nil {
+		return false
+	}
+
 	if _, err := os.Stat(path); err == nil {
 		return true
 	}
@@ -106,14 +139,11 @@ func HasFile(segments ...string) bool {
 }
 
 func BranchAtRef(paths ...string) (name string, err error) {
-	dir, err := Dir()
+	path, err := gitPath(paths...)
 	if err != nil {
 		return
 	}
 
-	segments := []string{dir}
-	segments = append(segments, paths...)
-	path := filepath.Join(segments...)
 	b, err := ioutil.ReadFile(path)
 	if err != nil {
 		return
@@ -180,6 +210,43 @@ func RefList(a, b string) ([]string, error) {
 	return outputLines(output), nil
 }
 
+// DiffLines counts the lines that head adds and removes since it diverged
+// from base.
+func DiffLines(base, head string) (int, error) {
+	ref := fmt.Sprintf("%s...%s", base, head)
+	diffCmd := gitCmd("diff", "--numstat", ref)
+	diffCmd.Stderr = nil
+	output, err := diffCmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("Can't load diff for %s", ref)
+	}
+
+	lines := 0
+	for _, line := range outputLines(output) {
+		// binary files are listed with "-" instead of line counts
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		added, _ := strconv.Atoi(fields[0])
+		removed, _ := strconv.Atoi(fields[1])
+		lines += added + removed
+	}
+	return lines, nil
+}
+
+// LatestTag returns the most recent tag that is reachable from ref.
+func LatestTag(ref string) (string, error) {
+	describeCmd := gitCmd("describe", "--tags", "--abbrev=0", ref)
+	describeCmd.Stderr = nil
+	output, err := describeCmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("No tags found in the history of %s", ref)
+	}
+
+	return firstLine(output), nil
+}
+
 func NewRange(a, b string) (*Range, error) {
 	parseCmd := gitCmd("rev-parse", "-q", a, b)
 	parseCmd.Stderr = nil
@@ -209,6 +276,21 @@ func (r *Range) IsAncestor() bool {
 	return cmd.Success()
 }
 
+// Counts returns the number of commits reachable from A but not from B, and
+// the other way around.
+func (r *Range) Counts() (ahead, behind int, err error) {
+	countCmd := gitCmd("rev-list", "--left-right", "--count", fmt.Sprintf("%s...%s", r.A, r.B))
+	countCmd.Stderr = nil
+	output, err := countCmd.Output()
+	if err == nil {
+		_, err = fmt.Sscanf(output, "%d\t%d", &ahead, &behind)
+	}
+	if err != nil {
+		err = fmt.Errorf("Can't count commits in %s...%s", r.A, r.B)
+	}
+	return
+}
+
 func CommentChar(text string) (string, error) {
 	char, err := Config("core.commentchar")
 	if err != nil {
@@ -291,6 +373,16 @@ func SetGlobalConfig(name, value string) error {
 	return err
 }
 
+func SetConfig(name, value string) error {
+	_, err := gitConfig(name, value)
+	return err
+}
+
+func UnsetConfig(name string) error {
+	_, err := gitConfig("--unset", name)
+	return err
+}
+
 func gitGetConfig(args ...string) (string, error) {
 	configCmd := gitCmd(gitConfigCommand(args)...)
 	output, err := configCmd.Output()
@@ -351,6 +443,14 @@ func LocalBranches() ([]string, error) {
 	return branches, nil
 }
 
+func Tags() ([]string, error) {
+	output, err := gitCmd("tag", "--list").Output()
+	if err != nil {
+		return nil, err
+	}
+	return outputLines(output), nil
+}
+
 func outputLines(output string) []string {
 	output = strings.TrimSuffix(output, "\n")
 	if output == "" {
@@ -3,10 +3,12 @@ package git
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/bmizerany/assert"
+	"github.com/github/hub/cmd"
 	"github.com/github/hub/fixtures"
 )
 
@@ -97,6 +99,12 @@ func TestGitConfig(t *testing.T) {
 	v, err = GlobalConfig("hub.test")
 	assert.Equal(t, nil, err)
 	assert.Equal(t, "", v)
+
+	err = SetConfig("branch.master.hub-pr", "12")
+	assert.Equal(t, nil, err)
+	v, err = Config("branch.master.hub-pr")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "12", v)
 }
 
 func TestRemotes(t *testing.T) {
@@ -184,3 +192,31 @@ func TestCommentChar(t *testing.T) {
 	_, err = CommentChar("#\n;\n@\n!\n$\n%\n^\n&\n|\n:")
 	assert.Equal(t, "unable to select a comment character that is not used in the current message", err.Error())
 }
+
+func TestBranchAtRefInWorktree(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+	cachedDir = ""
+	defer func() { cachedDir = "" }()
+
+	worktree := cmd.New("git").WithArgs("worktree", "add", "-b", "feature", "../worktree")
+	if output, err := worktree.CombinedOutput(); err != nil {
+		t.Fatalf("%s\n%s", err, output)
+	}
+	if err := os.Chdir("../worktree"); err != nil {
+		t.Fatal(err)
+	}
+
+	head, err := Head()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "refs/heads/feature", head)
+
+	defaultBranch, err := BranchAtRef("refs", "remotes", "origin", "HEAD")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "refs/remotes/origin/master", defaultBranch)
+	assert.T(t, HasFile("refs", "remotes", "origin", "HEAD"))
+
+	workdir, err := MainWorkdirName()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "test.git", filepath.Base(workdir))
+}
@@ -5,17 +5,61 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/mitchellh/go-homedir"
 )
 
 const (
-	hostReStr = "(?i)^[ \t]*(host|hostname)[ \t]+(.+)$"
+	hostReStr = "(?i)^[ \t]*(host|hostname|include)[ \t]+(.+)$"
+
+	// the same nesting limit that ssh imposes on Include directives
+	maxIncludeDepth = 16
 )
 
+// SSHConfig maps ssh host aliases to the host names they connect to. Aliases
+// may be wildcard patterns such as "github-*".
 type SSHConfig map[string]string
 
+// HostName returns the host name that the ssh alias resolves to, or an empty
+// string if none of the configured aliases match. Exact aliases take
+// precedence over patterns, and longer patterns over shorter ones.
+func (c SSHConfig) HostName(alias string) string {
+	if hostName, ok := c[alias]; ok {
+		return hostName
+	}
+
+	patterns := []string{}
+	for pattern := range c {
+		// a bare "*" holds options that precede any Host line
+		if pattern != "*" && strings.ContainsAny(pattern, "*?") {
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+
+	for _, pattern := range patterns {
+		if matchHostPattern(pattern, alias) {
+			return expandTokens(c[pattern], alias)
+		}
+	}
+
+	return ""
+}
+
+func matchHostPattern(pattern, host string) bool {
+	reStr := regexp.QuoteMeta(strings.ToLower(pattern))
+	reStr = strings.Replace(reStr, `\*`, ".*", -1)
+	reStr = strings.Replace(reStr, `\?`, ".", -1)
+	return regexp.MustCompile("^" + reStr + "$").MatchString(strings.ToLower(host))
+}
+
 func newSSHConfigReader() *SSHConfigReader {
 	configFiles := []string{
 		"/etc/ssh_config",
@@ -39,20 +83,20 @@ func (r *SSHConfigReader) Read() SSHConfig {
 	hostRe := regexp.MustCompile(hostReStr)
 
 	for _, filename := range r.Files {
-		r.readFile(config, hostRe, filename)
+		hosts := []string{"*"}
+		r.readFile(config, hostRe, filename, &hosts, 0)
 	}
 
 	return config
 }
 
-func (r *SSHConfigReader) readFile(c SSHConfig, re *regexp.Regexp, f string) error {
+func (r *SSHConfigReader) readFile(c SSHConfig, re *regexp.Regexp, f string, hosts *[]string, depth int) error {
 	file, err := os.Open(f)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	hosts := []string{"*"}
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -62,12 +106,36 @@ func (r *SSHConfigReader) readFile(c SSHConfig, re *regexp.Regexp, f string) err
 		}
 
 		names := strings.Fields(match[2])
-		if strings.EqualFold(match[1], "host") {
-			hosts = names
-		} else {
-			for _, host := range hosts {
+		switch strings.ToLower(match[1]) {
+		case "host":
+			*hosts = []string{}
+			for _, name := range names {
+				// negated patterns are not supported and are skipped
+				if !strings.HasPrefix(name, "!") {
+					*hosts = append(*hosts, name)
+				}
+			}
+		case "include":
+			if depth >= maxIncludeDepth {
+				continue
+			}
+			for _, name := range names {
+				for _, include := range includedFiles(f, name) {
+					r.readFile(c, re, include, hosts, depth+1)
+				}
+			}
+		default:
+			for _, host := range *hosts {
+				// like ssh, the first value obtained for a host is used
+				if _, ok := c[host]; ok {
+					continue
+				}
 				for _, name := range names {
-					c[host] = expandTokens(name, host)
+					if strings.ContainsAny(host, "*?") {
+						c[host] = name
+					} else {
+						c[host] = expandTokens(name, host)
+					}
 				}
 			}
 		}
@@ -76,6 +144,19 @@ func (r *SSHConfigReader) readFile(c SSHConfig, re *regexp.Regexp, f string) err
 	return scanner.Err()
 }
 
+// includedFiles expands the argument of an Include directive. Relative paths
+// are looked up in the directory of the including file.
+func includedFiles(from, pattern string) []string {
+	if expanded, err := homedir.Expand(pattern); err == nil {
+		pattern = expanded
+	}
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(filepath.Dir(from), pattern)
+	}
+	files, _ := filepath.Glob(pattern)
+	return files
+}
+
 func expandTokens(text, host string) string {
 	re := regexp.MustCompile(`%[%h]`)
 	return re.ReplaceAllStringFunc(text, func(match string) string {
@@ -3,6 +3,7 @@ package git
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/bmizerany/assert"
@@ -38,3 +39,85 @@ func TestSSHConfigReader_ExpandTokens(t *testing.T) {
 	assert.Equal(t, "1-github.com-2-%h-3-github.com-%", sc["github.com"])
 	assert.Equal(t, "1-example.org-2-%h-3-example.org-%", sc["example.org"])
 }
+
+func TestSSHConfigReader_Include(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "ssh-config")
+	defer os.RemoveAll(dir)
+
+	ioutil.WriteFile(filepath.Join(dir, "config"), []byte(`Include conf.d/*
+Host github.com
+  Hostname ignored.example.com
+`), os.ModePerm)
+	os.Mkdir(filepath.Join(dir, "conf.d"), os.ModePerm)
+	ioutil.WriteFile(filepath.Join(dir, "conf.d", "work"), []byte(`Host github-work gh-* !gh-personal
+  HostName github.com
+Host github.com
+  Hostname ssh.github.com
+`), os.ModePerm)
+
+	r := &SSHConfigReader{[]string{filepath.Join(dir, "config")}}
+	sc := r.Read()
+	assert.Equal(t, "github.com", sc["github-work"])
+	assert.Equal(t, "github.com", sc.HostName("gh-acme"))
+	assert.Equal(t, "ssh.github.com", sc["github.com"])
+	assert.Equal(t, "", sc.HostName("gitlab.com"))
+}
+
+func TestSSHConfigReader_FirstValueWins(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "ssh-config")
+	defer os.RemoveAll(dir)
+
+	ioutil.WriteFile(filepath.Join(dir, "config"), []byte(`Host github.com
+  Hostname ssh.github.com
+  Hostname ignored.example.com
+Host github.com gh
+  Hostname also-ignored.example.com
+`), os.ModePerm)
+	ioutil.WriteFile(filepath.Join(dir, "ssh_config"), []byte(`Host gh github.com
+  Hostname system.example.com
+`), os.ModePerm)
+
+	r := &SSHConfigReader{[]string{filepath.Join(dir, "config"), filepath.Join(dir, "ssh_config")}}
+	sc := r.Read()
+	assert.Equal(t, "ssh.github.com", sc["github.com"])
+	assert.Equal(t, "also-ignored.example.com", sc["gh"])
+}
+
+func TestSSHConfigReader_IncludeRelativeAndNested(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "ssh-config")
+	defer os.RemoveAll(dir)
+
+	ioutil.WriteFile(filepath.Join(dir, "config"), []byte(`Host work
+  Include work.conf
+`), os.ModePerm)
+	ioutil.WriteFile(filepath.Join(dir, "work.conf"), []byte(`Hostname github.com
+Include missing.conf more.conf
+`), os.ModePerm)
+	ioutil.WriteFile(filepath.Join(dir, "more.conf"), []byte(`Host personal
+  Hostname personal.github.com
+`), os.ModePerm)
+
+	r := &SSHConfigReader{[]string{filepath.Join(dir, "config")}}
+	sc := r.Read()
+	assert.Equal(t, "github.com", sc["work"])
+	assert.Equal(t, "personal.github.com", sc["personal"])
+}
+
+func TestSSHConfig_HostNamePatternTokens(t *testing.T) {
+	f, _ := ioutil.TempFile("", "ssh-config")
+	defer os.Remove(f.Name())
+	c := `Host *.corp
+  Hostname %h.example.com
+Host gh-?
+  Hostname github.com
+`
+
+	ioutil.WriteFile(f.Name(), []byte(c), os.ModePerm)
+
+	r := &SSHConfigReader{[]string{f.Name()}}
+	sc := r.Read()
+	assert.Equal(t, "%h.example.com", sc["*.corp"])
+	assert.Equal(t, "git.corp.example.com", sc.HostName("git.corp"))
+	assert.Equal(t, "github.com", sc.HostName("gh-1"))
+	assert.Equal(t, "", sc.HostName("gh-12"))
+}
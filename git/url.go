@@ -8,14 +8,20 @@ import (
 
 var (
 	cachedSSHConfig SSHConfig
+	cachedInsteadOf map[string]string
 	protocolRe      = regexp.MustCompile("^[a-zA-Z_+-]+://")
 )
 
 type URLParser struct {
 	SSHConfig SSHConfig
+	// InsteadOf maps URL prefixes to their replacements, as configured with
+	// `url.<base>.insteadOf` in git config.
+	InsteadOf map[string]string
 }
 
 func (p *URLParser) Parse(rawURL string) (u *url.URL, err error) {
+	rawURL = p.rewrite(rawURL)
+
 	if !protocolRe.MatchString(rawURL) &&
 		strings.Contains(rawURL, ":") &&
 		// not a Windows path
@@ -44,7 +50,7 @@ func (p *URLParser) Parse(rawURL string) (u *url.URL, err error) {
 		u.Host = u.Host[0:idx]
 	}
 
-	sshHost := p.SSHConfig[u.Host]
+	sshHost := p.SSHConfig.HostName(u.Host)
 	// ignore replacing host that fixes for limited network
 	// https://help.github.com/articles/using-ssh-over-the-https-port
 	ignoredHost := u.Host == "github.com" && sshHost == "ssh.github.com"
@@ -55,12 +61,47 @@ func (p *URLParser) Parse(rawURL string) (u *url.URL, err error) {
 	return
 }
 
+// rewrite applies the longest matching `insteadOf` prefix to rawURL, the same
+// way git does before connecting to a remote.
+func (p *URLParser) rewrite(rawURL string) string {
+	longest := ""
+	for prefix := range p.InsteadOf {
+		if strings.HasPrefix(rawURL, prefix) && len(prefix) > len(longest) {
+			longest = prefix
+		}
+	}
+	if longest == "" {
+		return rawURL
+	}
+	return p.InsteadOf[longest] + strings.TrimPrefix(rawURL, longest)
+}
+
 func ParseURL(rawURL string) (u *url.URL, err error) {
 	if cachedSSHConfig == nil {
 		cachedSSHConfig = newSSHConfigReader().Read()
 	}
+	if cachedInsteadOf == nil {
+		cachedInsteadOf = readInsteadOf()
+	}
 
-	p := &URLParser{cachedSSHConfig}
+	p := &URLParser{
+		SSHConfig: cachedSSHConfig,
+		InsteadOf: cachedInsteadOf,
+	}
 
 	return p.Parse(rawURL)
 }
+
+func readInsteadOf() map[string]string {
+	insteadOf := map[string]string{}
+	lines, _ := ConfigAll(`url\..*\.insteadof`)
+	for _, line := range lines {
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 || !strings.HasSuffix(parts[0], ".insteadof") {
+			continue
+		}
+		base := strings.TrimSuffix(strings.TrimPrefix(parts[0], "url."), ".insteadof")
+		insteadOf[parts[1]] = base
+	}
+	return insteadOf
+}
@@ -12,7 +12,7 @@ func createURLParser() *URLParser {
 	c["gh"] = "github.com"
 	c["git.company.com"] = "ssh.git.company.com"
 
-	return &URLParser{c}
+	return &URLParser{SSHConfig: c}
 }
 
 func TestURLParser_ParseURL_HTTPURL(t *testing.T) {
@@ -83,3 +83,50 @@ func TestURLParser_ParseURL_LocalPath(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, `c:\path\to\repo.git`, u.String())
 }
+
+func TestURLParser_ParseURL_HostPattern(t *testing.T) {
+	c := make(SSHConfig)
+	c["github-*"] = "github.com"
+	c["*.corp"] = "%h.example.com"
+	p := &URLParser{SSHConfig: c}
+
+	u, err := p.Parse("git@github-work:octokit/go-octokit.git")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "github.com", u.Host)
+	assert.Equal(t, "/octokit/go-octokit.git", u.Path)
+
+	u, err = p.Parse("ssh://git@ghe.corp/octokit/go-octokit.git")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ghe.corp.example.com", u.Host)
+
+	u, err = p.Parse("git@gitlab-work:octokit/go-octokit.git")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "gitlab-work", u.Host)
+}
+
+func TestURLParser_ParseURL_InsteadOf(t *testing.T) {
+	p := &URLParser{
+		SSHConfig: make(SSHConfig),
+		InsteadOf: map[string]string{
+			"gh:":            "git@github.com:",
+			"work://":        "https://git.company.com/",
+			"work://legacy/": "https://old.company.com/",
+		},
+	}
+
+	u, err := p.Parse("gh:octokit/go-octokit")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "github.com", u.Host)
+	assert.Equal(t, "ssh", u.Scheme)
+	assert.Equal(t, "/octokit/go-octokit", u.Path)
+
+	u, err = p.Parse("work://octokit/go-octokit")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "git.company.com", u.Host)
+	assert.Equal(t, "https", u.Scheme)
+	assert.Equal(t, "/octokit/go-octokit", u.Path)
+
+	u, err = p.Parse("work://legacy/octokit/go-octokit")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "old.company.com", u.Host)
+}
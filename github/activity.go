@@ -0,0 +1,159 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func starredPath(project *Project) string {
+	return fmt.Sprintf("user/starred/%s/%s", project.Owner, project.Name)
+}
+
+func (client *Client) StarRepository(project *Project) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.performRequest("PUT", starredPath(project), nil, nil)
+	return checkStatus(204, "starring repository", res, err)
+}
+
+func (client *Client) UnstarRepository(project *Project) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(starredPath(project))
+	return checkStatus(204, "unstarring repository", res, err)
+}
+
+// StarredRepositories lists the repositories starred by user, or by the
+// authenticated user if user is empty, most recently starred first.
+func (client *Client) StarredRepositories(user string, limit int) (repos []Repository, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("user/starred?per_page=%d", perPage(limit, 100))
+	if user != "" {
+		path = fmt.Sprintf("users/%s/starred?per_page=%d", user, perPage(limit, 100))
+	}
+
+	repos = []Repository{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching starred repositories", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		reposPage := []Repository{}
+		if err = res.Unmarshal(&reposPage); err != nil {
+			return
+		}
+		for _, repo := range reposPage {
+			repos = append(repos, repo)
+			if limit > 0 && len(repos) == limit {
+				path = ""
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// Subscription is the notification setting of the authenticated user for a
+// repository. Without a subscription, only notifications for participating
+// and @mentions are sent.
+type Subscription struct {
+	Subscribed bool `json:"subscribed"`
+	Ignored    bool `json:"ignored"`
+}
+
+func subscriptionPath(project *Project) string {
+	return fmt.Sprintf("repos/%s/%s/subscription", project.Owner, project.Name)
+}
+
+// RepositorySubscription returns nil if the authenticated user has no
+// subscription to project.
+func (client *Client) RepositorySubscription(project *Project) (subscription *Subscription, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(subscriptionPath(project))
+	if err == nil && res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, nil
+	}
+	if err = checkStatus(200, "fetching subscription", res, err); err != nil {
+		return
+	}
+
+	subscription = &Subscription{}
+	err = res.Unmarshal(subscription)
+	return
+}
+
+func (client *Client) SetRepositorySubscription(project *Project, subscription *Subscription) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.jsonRequest("PUT", subscriptionPath(project), subscription, nil)
+	if err = checkStatus(200, "updating subscription", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) DeleteRepositorySubscription(project *Project) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(subscriptionPath(project))
+	return checkStatus(204, "deleting subscription", res, err)
+}
+
+type Event struct {
+	Id        string          `json:"id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// RepositoryEvents fetches the most recent public events of project, most
+// recent first, along with how long to wait before polling for events again.
+func (client *Client) RepositoryEvents(project *Project) (events []Event, pollInterval time.Duration, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/events?per_page=100", project.Owner, project.Name))
+	if err = checkStatus(200, "fetching events", res, err); err != nil {
+		return
+	}
+
+	if seconds, convErr := strconv.Atoi(res.Header.Get("X-Poll-Interval")); convErr == nil {
+		pollInterval = time.Duration(seconds) * time.Second
+	}
+	events = []Event{}
+	err = res.Unmarshal(&events)
+	return
+}
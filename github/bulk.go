@@ -0,0 +1,228 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit describes the API rate limit status reported by the most recent
+// response received from the server.
+type RateLimit struct {
+	// Remaining is the number of requests left in the current window, or -1
+	// if the server didn't report it.
+	Remaining int
+	// Reset is the time when the current rate limit window resets.
+	Reset time.Time
+	// RetryAfter is set when the server asked the client to back off, which
+	// happens when secondary (abuse) rate limits are triggered.
+	RetryAfter time.Duration
+	// Exceeded is true when the request was refused due to rate limiting.
+	Exceeded bool
+}
+
+var (
+	lastRateLimit      = RateLimit{Remaining: -1}
+	lastRateLimitMutex sync.Mutex
+)
+
+func LastRateLimit() RateLimit {
+	lastRateLimitMutex.Lock()
+	defer lastRateLimitMutex.Unlock()
+	return lastRateLimit
+}
+
+func recordRateLimit(res *http.Response) {
+	rl := RateLimit{Remaining: -1}
+	if remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining")); err == nil {
+		rl.Remaining = remaining
+	}
+	if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	if res.StatusCode == 403 || res.StatusCode == 429 {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			rl.RetryAfter = time.Duration(seconds) * time.Second
+		}
+		rl.Exceeded = rl.RetryAfter > 0 || rl.Remaining == 0
+	}
+
+	lastRateLimitMutex.Lock()
+	lastRateLimit = rl
+	lastRateLimitMutex.Unlock()
+}
+
+var (
+	bulkSleep = time.Sleep
+	bulkNow   = time.Now
+)
+
+// BulkJob runs an operation over a list of items while checkpointing progress
+// to a state file, so that a long job that gets interrupted can be continued
+// with `Resume` instead of starting over. Operations are spaced out by `Delay`
+// and paused whenever the API reports that a rate limit was hit.
+type BulkJob struct {
+	Name       string
+	StateFile  string
+	Resume     bool
+	Delay      time.Duration
+	MaxRetries int
+
+	state   bulkState
+	lastRun time.Time
+}
+
+type bulkState struct {
+	Name      string            `json:"name"`
+	Completed []string          `json:"completed"`
+	Failed    map[string]string `json:"failed,omitempty"`
+}
+
+// BulkError is returned from BulkJob.Run when some of the items failed.
+type BulkError struct {
+	Failed map[string]string
+}
+
+func (e *BulkError) Error() string {
+	keys := make([]string, 0, len(e.Failed))
+	for key := range e.Failed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := []string{fmt.Sprintf("Error: %d operation(s) failed:", len(keys))}
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("  %s: %s", key, e.Failed[key]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+var bulkNameRe = regexp.MustCompile(`[^\w.-]+`)
+
+// NewBulkJob sets up a job whose state is kept in the user cache directory
+// under a filename derived from `name`.
+func NewBulkJob(name string, resume bool) (*BulkJob, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	filename := bulkNameRe.ReplaceAllString(name, "_") + ".json"
+
+	return &BulkJob{
+		Name:       name,
+		StateFile:  filepath.Join(cacheDir, "hub", "bulk", filename),
+		Resume:     resume,
+		Delay:      time.Second,
+		MaxRetries: 3,
+	}, nil
+}
+
+// Run calls `op` for each of the `items` that weren't already completed by a
+// previous run of the same job. Failures don't stop the job; they are
+// collected and reported in a BulkError after all items were attempted.
+func (j *BulkJob) Run(items []string, op func(item string) error) error {
+	if err := j.loadState(); err != nil {
+		return err
+	}
+
+	completed := map[string]bool{}
+	for _, item := range j.state.Completed {
+		completed[item] = true
+	}
+	j.state.Failed = map[string]string{}
+
+	for _, item := range items {
+		if completed[item] {
+			continue
+		}
+
+		err := j.runOne(item, op)
+		if err == nil {
+			completed[item] = true
+			j.state.Completed = append(j.state.Completed, item)
+		} else {
+			j.state.Failed[item] = err.Error()
+		}
+		if err := j.saveState(); err != nil {
+			return err
+		}
+	}
+
+	if len(j.state.Failed) > 0 {
+		return &BulkError{Failed: j.state.Failed}
+	}
+	if err := os.Remove(j.StateFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (j *BulkJob) runOne(item string, op func(string) error) (err error) {
+	for attempt := 0; attempt <= j.MaxRetries; attempt++ {
+		j.throttle()
+		j.lastRun = bulkNow()
+
+		if err = op(item); err == nil {
+			return
+		}
+		if !LastRateLimit().Exceeded {
+			return
+		}
+	}
+	return
+}
+
+func (j *BulkJob) throttle() {
+	rl := LastRateLimit()
+	if rl.RetryAfter > 0 {
+		bulkSleep(rl.RetryAfter)
+	} else if rl.Remaining == 0 && !rl.Reset.IsZero() {
+		if wait := rl.Reset.Sub(bulkNow()); wait > 0 {
+			bulkSleep(wait)
+		}
+	} else if !j.lastRun.IsZero() {
+		if wait := j.Delay - bulkNow().Sub(j.lastRun); wait > 0 {
+			bulkSleep(wait)
+		}
+	}
+}
+
+func (j *BulkJob) loadState() error {
+	j.state = bulkState{Name: j.Name}
+	if !j.Resume {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(j.StateFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, &j.state)
+}
+
+func (j *BulkJob) saveState() error {
+	if err := os.MkdirAll(filepath.Dir(j.StateFile), 0771); err != nil {
+		return err
+	}
+	content, err := json.Marshal(j.state)
+	if err != nil {
+		return err
+	}
+
+	tmpFile := j.StateFile + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, j.StateFile)
+}
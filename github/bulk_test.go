@@ -0,0 +1,81 @@
+package github
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+)
+
+func newTestBulkJob(t *testing.T, resume bool) (*BulkJob, func()) {
+	dir, err := ioutil.TempDir("", "hub-bulk-")
+	assert.Equal(t, nil, err)
+	bulkSleep = func(time.Duration) {}
+
+	job := &BulkJob{
+		Name:      "test",
+		StateFile: filepath.Join(dir, "test.json"),
+		Resume:    resume,
+	}
+	return job, func() {
+		bulkSleep = time.Sleep
+		os.RemoveAll(dir)
+	}
+}
+
+func TestBulkJob_Resume(t *testing.T) {
+	job, cleanup := newTestBulkJob(t, false)
+	defer cleanup()
+
+	items := []string{"a", "b", "c"}
+	processed := []string{}
+	err := job.Run(items, func(item string) error {
+		processed = append(processed, item)
+		if item == "b" {
+			return fmt.Errorf("boom")
+		}
+		return nil
+	})
+	assert.Equal(t, "Error: 1 operation(s) failed:\n  b: boom", err.Error())
+	assert.Equal(t, []string{"a", "b", "c"}, processed)
+
+	job.Resume = true
+	processed = []string{}
+	err = job.Run(items, func(item string) error {
+		processed = append(processed, item)
+		return nil
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"b"}, processed)
+
+	_, err = os.Stat(job.StateFile)
+	assert.T(t, os.IsNotExist(err))
+}
+
+func TestBulkJob_RetryOnRateLimit(t *testing.T) {
+	job, cleanup := newTestBulkJob(t, false)
+	defer cleanup()
+	job.MaxRetries = 2
+
+	var slept time.Duration
+	bulkSleep = func(d time.Duration) { slept += d }
+	defer func() { lastRateLimit = RateLimit{Remaining: -1} }()
+
+	attempts := 0
+	err := job.Run([]string{"a"}, func(item string) error {
+		attempts++
+		if attempts == 1 {
+			lastRateLimit = RateLimit{Remaining: -1, RetryAfter: 30 * time.Second, Exceeded: true}
+			return fmt.Errorf("secondary rate limit")
+		}
+		lastRateLimit = RateLimit{Remaining: -1}
+		return nil
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 30*time.Second, slept)
+}
@@ -0,0 +1,164 @@
+package github
+
+import (
+	"fmt"
+	"io"
+)
+
+type CheckRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	StartColumn     int    `json:"start_column"`
+	EndColumn       int    `json:"end_column"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+}
+
+var sarifLevels = map[string]string{
+	"failure": "error",
+	"warning": "warning",
+	"notice":  "note",
+}
+
+// SarifResult converts the annotation to a SARIF result.
+func (a *CheckRunAnnotation) SarifResult() SarifResult {
+	result := SarifResult{
+		RuleId: a.Title,
+		Level:  sarifLevels[a.AnnotationLevel],
+	}
+	if result.Level == "" {
+		result.Level = "none"
+	}
+	result.Message.Text = a.Message
+
+	location := SarifLocation{}
+	location.PhysicalLocation.ArtifactLocation.Uri = a.Path
+	location.PhysicalLocation.Region = SarifRegion{
+		StartLine:   a.StartLine,
+		StartColumn: a.StartColumn,
+		EndLine:     a.EndLine,
+		EndColumn:   a.EndColumn,
+	}
+	result.Locations = []SarifLocation{location}
+
+	return result
+}
+
+// CheckRunAnnotations fetches up to limit annotations of a check run.
+func (client *Client) CheckRunAnnotations(project *Project, checkRunId int64, limit int) (annotations []CheckRunAnnotation, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/check-runs/%d/annotations?per_page=%d", project.Owner, project.Name, checkRunId, perPage(limit, 100))
+	annotations = []CheckRunAnnotation{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.GetFile(path, checksType)
+		if err = checkStatus(200, "fetching annotations", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []CheckRunAnnotation{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, annotation := range page {
+			annotations = append(annotations, annotation)
+			if limit > 0 && len(annotations) == limit {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// CheckRunLog downloads the log of a check run, which has to be a GitHub
+// Actions job.
+func (client *Client) CheckRunLog(project *Project, run *CheckRun) (log io.ReadCloser, err error) {
+	if !run.IsActions() {
+		return nil, fmt.Errorf("The log of check `%s' is only available at %s", run.Name, run.HtmlUrl)
+	}
+
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs", project.Owner, project.Name, run.Id), "text/plain")
+	if err == nil && res.StatusCode == 410 {
+		res.Body.Close()
+		return nil, fmt.Errorf("The log of check `%s' has expired", run.Name)
+	}
+	if err = checkStatus(200, "downloading log", res, err); err != nil {
+		return
+	}
+
+	return res.Body, nil
+}
+
+// RerunCheckRun requests that a check run be run again. GitHub Actions jobs
+// are re-run directly; other check runs are re-requested from their app.
+func (client *Client) RerunCheckRun(project *Project, run *CheckRun) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/check-runs/%d/rerequest", project.Owner, project.Name, run.Id)
+	if run.IsActions() {
+		path = fmt.Sprintf("repos/%s/%s/actions/jobs/%d/rerun", project.Owner, project.Name, run.Id)
+	}
+
+	res, err := api.PostJSON(path, map[string]interface{}{})
+	if err = checkStatus(201, "re-running check", res, err); err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+// CheckRunHeadSha looks up the commit that a check run ran for.
+func (client *Client) CheckRunHeadSha(project *Project, checkRunId int64) (sha string, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/check-runs/%d", project.Owner, project.Name, checkRunId), checksType)
+	if err = checkStatus(200, "fetching check run", res, err); err != nil {
+		return
+	}
+
+	run := struct {
+		HeadSha string `json:"head_sha"`
+	}{}
+	err = res.Unmarshal(&run)
+	return run.HeadSha, err
+}
+
+// WorkflowRunHeadSha looks up the commit that a GitHub Actions workflow run
+// ran for.
+func (client *Client) WorkflowRunHeadSha(project *Project, runId int64) (sha string, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/actions/runs/%d", project.Owner, project.Name, runId))
+	if err = checkStatus(200, "fetching workflow run", res, err); err != nil {
+		return
+	}
+
+	run := struct {
+		HeadSha string `json:"head_sha"`
+	}{}
+	err = res.Unmarshal(&run)
+	return run.HeadSha, err
+}
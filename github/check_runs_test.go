@@ -0,0 +1,32 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestCheckRunAnnotation_SarifResult(t *testing.T) {
+	annotation := CheckRunAnnotation{
+		Path:            "src/main.go",
+		StartLine:       12,
+		EndLine:         14,
+		StartColumn:     3,
+		AnnotationLevel: "failure",
+		Title:           "SA4006",
+		Message:         "value is never used",
+	}
+
+	result := annotation.SarifResult()
+	assert.Equal(t, "SA4006", result.RuleId)
+	assert.Equal(t, "error", result.Level)
+	assert.Equal(t, "value is never used", result.Message.Text)
+	assert.Equal(t, 1, len(result.Locations))
+
+	location := result.Locations[0].PhysicalLocation
+	assert.Equal(t, "src/main.go", location.ArtifactLocation.Uri)
+	assert.Equal(t, SarifRegion{StartLine: 12, StartColumn: 3, EndLine: 14}, location.Region)
+
+	annotation.AnnotationLevel = "notice"
+	assert.Equal(t, "note", annotation.SarifResult().Level)
+}
@@ -2,6 +2,7 @@ package github
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,8 +15,10 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/github/hub/utils"
 	"github.com/github/hub/version"
 )
 
@@ -35,8 +38,15 @@ func NewClientWithHost(host *Host) *Client {
 }
 
 type Client struct {
-	Host         *Host
+	Host *Host
+	// Transport creates the transport for API requests (default:
+	// DefaultTransport).
+	Transport    TransportFactory
 	cachedClient *simpleClient
+	ctx          context.Context
+	// mutex guards the setup of cachedClient, since a client may be shared
+	// by the goroutines of ParallelFetch.
+	mutex sync.Mutex
 }
 
 type Gist struct {
@@ -45,6 +55,7 @@ type Gist struct {
 	Id          string              `json:"id,omitempty"`
 	Public      bool                `json:"public"`
 	HtmlUrl     string              `json:"html_url"`
+	GitPullUrl  string              `json:"git_pull_url,omitempty"`
 }
 
 type GistFile struct {
@@ -134,7 +145,10 @@ func (client *Client) CreatePullRequest(project *Project, params map[string]inte
 	if err = checkStatus(201, "creating pull request", res, err); err != nil {
 		if res != nil && res.StatusCode == 404 {
 			projectUrl := strings.SplitN(project.WebURL("", "", ""), "://", 2)[1]
-			err = fmt.Errorf("%s\nAre you sure that %s exists?", err, projectUrl)
+			err = &apiError{
+				message:  fmt.Sprintf("%s\nAre you sure that %s exists?", err, projectUrl),
+				exitCode: utils.ExitNotFound,
+			}
 		}
 		return
 	}
@@ -160,6 +174,252 @@ func (client *Client) RequestReview(project *Project, prNumber int, params map[s
 	return
 }
 
+func (client *Client) RemoveReviewRequest(project *Project, prNumber int, params map[string]interface{}) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.jsonRequest("DELETE", fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", project.Owner, project.Name, prNumber), params, nil)
+	if err = checkStatus(200, "removing review request", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) UpdatePullRequest(project *Project, prNumber int, params map[string]interface{}) (pr *PullRequest, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/pulls/%d", project.Owner, project.Name, prNumber), params)
+	if err = checkStatus(200, "updating pull request", res, err); err != nil {
+		return
+	}
+
+	pr = &PullRequest{}
+	err = res.Unmarshal(pr)
+	return
+}
+
+type PullRequestMergeResult struct {
+	Sha     string `json:"sha"`
+	Merged  bool   `json:"merged"`
+	Message string `json:"message"`
+}
+
+func (client *Client) MergePullRequest(project *Project, prNumber int, params map[string]interface{}) (result *PullRequestMergeResult, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.jsonRequest("PUT", fmt.Sprintf("repos/%s/%s/pulls/%d/merge", project.Owner, project.Name, prNumber), params, nil)
+	if err = checkStatus(200, "merging pull request", res, err); err != nil {
+		return
+	}
+
+	result = &PullRequestMergeResult{}
+	err = res.Unmarshal(result)
+	return
+}
+
+func (client *Client) DeleteBranch(project *Project, branch string) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/git/refs/heads/%s", project.Owner, project.Name, branch))
+	return checkStatus(204, "deleting branch", res, err)
+}
+
+// RenameBranch renames a branch on GitHub. Renaming the default branch also
+// retargets open pull requests and updates branch protection rules.
+func (client *Client) RenameBranch(project *Project, branch, newName string) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/branches/%s/rename", project.Owner, project.Name, branch), map[string]interface{}{
+		"new_name": newName,
+	})
+	if err = checkStatus(201, "renaming branch", res, err); err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+type Comparison struct {
+	Status       string             `json:"status"`
+	AheadBy      int                `json:"ahead_by"`
+	BehindBy     int                `json:"behind_by"`
+	TotalCommits int                `json:"total_commits"`
+	HtmlUrl      string             `json:"html_url"`
+	Commits      []ComparisonCommit `json:"commits"`
+	Files        []ComparisonFile   `json:"files"`
+}
+
+type ComparisonCommit struct {
+	Sha    string `json:"sha"`
+	Commit struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name string    `json:"name"`
+			Date time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+type ComparisonFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Changes          int    `json:"changes"`
+}
+
+// CompareCommits compares two refs, either of which may be given in
+// "OWNER:REF" format to refer to a fork.
+func (client *Client) CompareCommits(project *Project, base, head string) (comparison *Comparison, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/compare/%s...%s", project.Owner, project.Name, base, head))
+	if err = checkStatus(200, "comparing commits", res, err); err != nil {
+		return
+	}
+
+	comparison = &Comparison{}
+	err = res.Unmarshal(comparison)
+	return
+}
+
+// ComparePatch downloads the commits between two refs as a series of patches
+// in mbox format, such as `git format-patch` produces.
+func (client *Client) ComparePatch(project *Project, base, head string) (patch io.ReadCloser, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/compare/%s...%s", project.Owner, project.Name, base, head), patchMediaType)
+	if err = checkStatus(200, "getting comparison patch", res, err); err != nil {
+		return
+	}
+
+	return res.Body, nil
+}
+
+// PullRequestDiff downloads the changes of a pull request in unified diff
+// format.
+func (client *Client) PullRequestDiff(project *Project, id string) (diff io.ReadCloser, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/pulls/%s", project.Owner, project.Name, id), diffMediaType)
+	if err = checkStatus(200, "getting pull request diff", res, err); err != nil {
+		return
+	}
+
+	return res.Body, nil
+}
+
+// PullRequestFiles lists the files that a pull request changes. The API lists
+// at most 3000 files.
+func (client *Client) PullRequestFiles(project *Project, id string) (files []ComparisonFile, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/pulls/%s/files?per_page=100", project.Owner, project.Name, id)
+	files = []ComparisonFile{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching pull request files", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []ComparisonFile{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		files = append(files, page...)
+	}
+
+	return
+}
+
+func (client *Client) PullRequestReviewComments(project *Project, id string) (comments []ReviewComment, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/pulls/%s/comments?per_page=100", project.Owner, project.Name, id)
+	comments = []ReviewComment{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching pull request review comments", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []ReviewComment{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		comments = append(comments, page...)
+	}
+
+	return
+}
+
+// PullRequestCommits lists the commits of a pull request, oldest first. The
+// API lists at most 250 commits.
+func (client *Client) PullRequestCommits(project *Project, id string) (commits []ComparisonCommit, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/pulls/%s/commits?per_page=100", project.Owner, project.Name, id)
+	commits = []ComparisonCommit{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching pull request commits", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []ComparisonCommit{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		commits = append(commits, page...)
+	}
+
+	return
+}
+
 func (client *Client) CommitPatch(project *Project, sha string) (patch io.ReadCloser, err error) {
 	api, err := client.simpleApi()
 	if err != nil {
@@ -174,6 +434,53 @@ func (client *Client) CommitPatch(project *Project, sha string) (patch io.ReadCl
 	return res.Body, nil
 }
 
+// CommitAuthorLogin looks up the GitHub user that authored the commit sha.
+// It returns an empty login if the commit author isn't linked to an account.
+func (client *Client) CommitAuthorLogin(project *Project, sha string) (login string, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/commits/%s", project.Owner, project.Name, sha))
+	if err == nil && (res.StatusCode == 404 || res.StatusCode == 422) {
+		res.Body.Close()
+		return "", nil
+	}
+	if err = checkStatus(200, "fetching commit", res, err); err != nil {
+		return
+	}
+
+	commit := struct {
+		Author *User `json:"author"`
+	}{}
+	if err = res.Unmarshal(&commit); err != nil {
+		return
+	}
+	if commit.Author != nil {
+		login = commit.Author.Login
+	}
+	return
+}
+
+// CommitPullRequests lists the pull requests that contain the commit sha,
+// including the one it was merged with.
+func (client *Client) CommitPullRequests(project *Project, sha string) (pulls []PullRequest, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/commits/%s/pulls?per_page=100", project.Owner, project.Name, sha))
+	if err = checkStatus(200, "fetching pull requests for commit", res, err); err != nil {
+		return
+	}
+
+	pulls = []PullRequest{}
+	err = res.Unmarshal(&pulls)
+	return
+}
+
 func (client *Client) GistPatch(id string) (patch io.ReadCloser, err error) {
 	api, err := client.simpleApi()
 	if err != nil {
@@ -219,18 +526,12 @@ func (client *Client) Repository(project *Project) (repo *Repository, err error)
 	return
 }
 
-func (client *Client) CreateRepository(project *Project, description, homepage string, isPrivate bool) (repo *Repository, err error) {
+func (client *Client) CreateRepository(project *Project, params map[string]interface{}) (repo *Repository, err error) {
 	repoURL := "user/repos"
 	if project.Owner != client.Host.User {
 		repoURL = fmt.Sprintf("orgs/%s/repos", project.Owner)
 	}
-
-	params := map[string]interface{}{
-		"name":        project.Name,
-		"description": description,
-		"homepage":    homepage,
-		"private":     isPrivate,
-	}
+	params["name"] = project.Name
 
 	api, err := client.simpleApi()
 	if err != nil {
@@ -247,6 +548,151 @@ func (client *Client) CreateRepository(project *Project, description, homepage s
 	return
 }
 
+// CreateRepositoryFromTemplate creates project with the contents of the
+// template repository.
+func (client *Client) CreateRepositoryFromTemplate(template, project *Project, params map[string]interface{}) (repo *Repository, err error) {
+	params["owner"] = project.Owner
+	params["name"] = project.Name
+
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSONPreview(fmt.Sprintf("repos/%s/%s/generate", template.Owner, template.Name), params, templatesType)
+	if err = checkStatus(201, "creating repository from template", res, err); err != nil {
+		return
+	}
+
+	repo = &Repository{}
+	err = res.Unmarshal(repo)
+	return
+}
+
+type License struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+// License fetches the text of a license by its key, e.g. "mit". The body
+// contains placeholders such as "[year]" and "[fullname]".
+func (client *Client) License(key string) (license *License, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("licenses/%s", url.PathEscape(strings.ToLower(key))))
+	if err = checkStatus(200, "fetching license", res, err); err != nil {
+		return
+	}
+
+	license = &License{}
+	err = res.Unmarshal(license)
+	return
+}
+
+// GitignoreTemplate fetches the contents of a .gitignore template by name,
+// e.g. "Go".
+func (client *Client) GitignoreTemplate(name string) (source string, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("gitignore/templates/%s", url.PathEscape(name)))
+	if err = checkStatus(200, "fetching gitignore template", res, err); err != nil {
+		return
+	}
+
+	template := struct {
+		Source string `json:"source"`
+	}{}
+	err = res.Unmarshal(&template)
+	source = template.Source
+	return
+}
+
+func (client *Client) EditRepository(project *Project, params map[string]interface{}) (repo *Repository, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s", project.Owner, project.Name), params)
+	if err = checkStatus(200, "editing repository", res, err); err != nil {
+		return
+	}
+
+	repo = &Repository{}
+	err = res.Unmarshal(repo)
+	return
+}
+
+func (client *Client) TransferRepository(project *Project, newOwner string) (repo *Repository, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"new_owner": newOwner,
+	}
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/transfer", project.Owner, project.Name), params)
+	if err = checkStatus(202, "transferring repository", res, err); err != nil {
+		return
+	}
+
+	repo = &Repository{}
+	err = res.Unmarshal(repo)
+	return
+}
+
+type repositoryTopics struct {
+	Names []string `json:"names"`
+}
+
+func (client *Client) RepositoryTopics(project *Project) (topics []string, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/topics", project.Owner, project.Name), topicsType)
+	if err = checkStatus(200, "fetching repository topics", res, err); err != nil {
+		return
+	}
+
+	result := &repositoryTopics{}
+	if err = res.Unmarshal(result); err != nil {
+		return
+	}
+	topics = result.Names
+	return
+}
+
+func (client *Client) ReplaceRepositoryTopics(project *Project, topics []string) (updated []string, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.jsonRequest("PUT", fmt.Sprintf("repos/%s/%s/topics", project.Owner, project.Name), &repositoryTopics{Names: topics}, func(req *http.Request) {
+		req.Header.Set("Accept", topicsType)
+	})
+	if err = checkStatus(200, "updating repository topics", res, err); err != nil {
+		return
+	}
+
+	result := &repositoryTopics{}
+	if err = res.Unmarshal(result); err != nil {
+		return
+	}
+	updated = result.Names
+	return
+}
+
 func (client *Client) DeleteRepository(project *Project) error {
 	api, err := client.simpleApi()
 	if err != nil {
@@ -259,7 +705,7 @@ func (client *Client) DeleteRepository(project *Project) error {
 }
 
 type Release struct {
-	Name            string         `json:"name"`
+	Name            string         `json:"name,omitempty"`
 	TagName         string         `json:"tag_name"`
 	TargetCommitish string         `json:"target_commitish"`
 	Body            string         `json:"body"`
@@ -273,6 +719,11 @@ type Release struct {
 	ApiUrl          string         `json:"url"`
 	CreatedAt       time.Time      `json:"created_at"`
 	PublishedAt     time.Time      `json:"published_at"`
+	DiscussionUrl   string         `json:"discussion_url,omitempty"`
+
+	GenerateNotes          bool   `json:"generate_notes,omitempty"`
+	MakeLatest             string `json:"make_latest,omitempty"`
+	DiscussionCategoryName string `json:"discussion_category_name,omitempty"`
 }
 
 type ReleaseAsset struct {
@@ -280,6 +731,8 @@ type ReleaseAsset struct {
 	Label       string `json:"label"`
 	DownloadUrl string `json:"browser_download_url"`
 	ApiUrl      string `json:"url"`
+	Size        int64  `json:"size"`
+	Digest      string `json:"digest"`
 }
 
 func (client *Client) FetchReleases(project *Project, limit int, filter func(*Release) bool) (releases []Release, err error) {
@@ -334,6 +787,28 @@ func (client *Client) FetchRelease(project *Project, tagName string) (*Release,
 	}
 }
 
+// LatestRelease fetches the most recent published release that is neither a
+// draft nor a pre-release, unless another release was marked as latest.
+func (client *Client) LatestRelease(project *Project) (release *Release, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/releases/latest", project.Owner, project.Name))
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return nil, fmt.Errorf("Unable to find the latest release of %s", project)
+	}
+	if err = checkStatus(200, "fetching latest release", res, err); err != nil {
+		return
+	}
+
+	release = &Release{}
+	err = res.Unmarshal(release)
+	return
+}
+
 func (client *Client) CreateRelease(project *Project, releaseParams *Release) (release *Release, err error) {
 	api, err := client.simpleApi()
 	if err != nil {
@@ -366,6 +841,79 @@ func (client *Client) EditRelease(release *Release, releaseParams map[string]int
 	return
 }
 
+// Verification is GitHub's verdict on the signature of a commit or tag. Reason
+// is "valid" for verified signatures and e.g. "unsigned" or "unknown_key"
+// otherwise.
+type Verification struct {
+	Verified  bool   `json:"verified"`
+	Reason    string `json:"reason"`
+	Signature string `json:"signature"`
+}
+
+// TagVerification describes the signature of a tag. For lightweight tags, it
+// is the signature of the tagged commit.
+type TagVerification struct {
+	Annotated    bool
+	Sha          string
+	Verification Verification
+}
+
+type gitObject struct {
+	Sha          string        `json:"sha"`
+	Type         string        `json:"type"`
+	Object       *gitObject    `json:"object"`
+	Verification *Verification `json:"verification"`
+}
+
+func (client *Client) TagVerification(project *Project, tagName string) (tag *TagVerification, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/git/ref/tags/%s", project.Owner, project.Name, tagName))
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return nil, fmt.Errorf("Unable to find tag `%s' in %s", tagName, project)
+	}
+	if err = checkStatus(200, "fetching tag", res, err); err != nil {
+		return
+	}
+
+	ref := gitObject{}
+	if err = res.Unmarshal(&ref); err != nil {
+		return
+	}
+	if ref.Object == nil {
+		return nil, fmt.Errorf("Unable to find tag `%s' in %s", tagName, project)
+	}
+
+	tag = &TagVerification{
+		Annotated: ref.Object.Type == "tag",
+		Sha:       ref.Object.Sha,
+	}
+	objectPath := "commits"
+	if tag.Annotated {
+		objectPath = "tags"
+	}
+
+	res, err = api.Get(fmt.Sprintf("repos/%s/%s/git/%s/%s", project.Owner, project.Name, objectPath, tag.Sha))
+	if err = checkStatus(200, "fetching tag", res, err); err != nil {
+		return
+	}
+
+	object := gitObject{}
+	if err = res.Unmarshal(&object); err != nil {
+		return
+	}
+	if object.Verification != nil {
+		tag.Verification = *object.Verification
+	} else {
+		tag.Verification = Verification{Reason: "unsigned"}
+	}
+	return
+}
+
 func (client *Client) DeleteRelease(release *Release) (err error) {
 	api, err := client.simpleApi()
 	if err != nil {
@@ -380,7 +928,9 @@ func (client *Client) DeleteRelease(release *Release) (err error) {
 	return
 }
 
-func (client *Client) UploadReleaseAsset(release *Release, filename, label string) (asset *ReleaseAsset, err error) {
+// UploadReleaseAsset attaches the file to release. If progress isn't nil, the
+// bytes of the file are copied to it as they are uploaded.
+func (client *Client) UploadReleaseAsset(release *Release, filename, label string, progress io.Writer) (asset *ReleaseAsset, err error) {
 	api, err := client.simpleApi()
 	if err != nil {
 		return
@@ -393,7 +943,7 @@ func (client *Client) UploadReleaseAsset(release *Release, filename, label strin
 		uploadUrl += "&label=" + url.QueryEscape(label)
 	}
 
-	res, err := api.PostFile(uploadUrl, filename)
+	res, err := api.PostFile(uploadUrl, filename, progress)
 	if err = checkStatus(201, "uploading release asset", res, err); err != nil {
 		return
 	}
@@ -438,6 +988,26 @@ type CIStatus struct {
 	State     string `json:"state"`
 	Context   string `json:"context"`
 	TargetUrl string `json:"target_url"`
+	// CheckRun is set for statuses that represent a check run.
+	CheckRun *CheckRun `json:"-"`
+}
+
+// CreateCIStatus publishes a commit status for sha. A status replaces earlier
+// ones with the same context.
+func (client *Client) CreateCIStatus(project *Project, sha string, params map[string]interface{}) (status *CIStatus, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/statuses/%s", project.Owner, project.Name, sha), params)
+	if err = checkStatus(201, "creating status", res, err); err != nil {
+		return
+	}
+
+	status = &CIStatus{}
+	err = res.Unmarshal(status)
+	return
 }
 
 type CheckRunsResponse struct {
@@ -445,10 +1015,33 @@ type CheckRunsResponse struct {
 }
 
 type CheckRun struct {
-	Status     string `json:"status"`
-	Conclusion string `json:"conclusion"`
-	Name       string `json:"name"`
-	HtmlUrl    string `json:"html_url"`
+	Id          int64      `json:"id"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	Name        string     `json:"name"`
+	HtmlUrl     string     `json:"html_url"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	Output      struct {
+		Title            string `json:"title"`
+		AnnotationsCount int    `json:"annotations_count"`
+	} `json:"output"`
+	App struct {
+		Slug string `json:"slug"`
+	} `json:"app"`
+}
+
+// Duration is how long a completed check run took, or zero.
+func (run *CheckRun) Duration() time.Duration {
+	if run.StartedAt == nil || run.CompletedAt == nil {
+		return 0
+	}
+	return run.CompletedAt.Sub(*run.StartedAt)
+}
+
+// IsActions reports whether the check run is a GitHub Actions job.
+func (run *CheckRun) IsActions() bool {
+	return run.App.Slug == "github-actions"
 }
 
 func (client *Client) FetchCIStatus(project *Project, sha string) (status *CIStatusResponse, err error) {
@@ -494,7 +1087,7 @@ func (client *Client) FetchCIStatus(project *Project, sha string) (status *CISta
 		return
 	}
 
-	for _, checkRun := range checks.CheckRuns {
+	for i, checkRun := range checks.CheckRuns {
 		state := "pending"
 		if checkRun.Status == "completed" {
 			state = checkRun.Conclusion
@@ -503,6 +1096,7 @@ func (client *Client) FetchCIStatus(project *Project, sha string) (status *CISta
 			State:     state,
 			Context:   checkRun.Name,
 			TargetUrl: checkRun.HtmlUrl,
+			CheckRun:  &checks.CheckRuns[i],
 		}
 		status.Statuses = append(status.Statuses, checkStatus)
 	}
@@ -513,6 +1107,8 @@ func (client *Client) FetchCIStatus(project *Project, sha string) (status *CISta
 }
 
 type Repository struct {
+	Id            int                    `json:"id"`
+	NodeId        string                 `json:"node_id"`
 	Name          string                 `json:"name"`
 	FullName      string                 `json:"full_name"`
 	Parent        *Repository            `json:"parent"`
@@ -522,6 +1118,21 @@ type Repository struct {
 	Permissions   *RepositoryPermissions `json:"permissions"`
 	HtmlUrl       string                 `json:"html_url"`
 	DefaultBranch string                 `json:"default_branch"`
+
+	Description     string `json:"description"`
+	Homepage        string `json:"homepage"`
+	Fork            bool   `json:"fork"`
+	Archived        bool   `json:"archived"`
+	StargazersCount int    `json:"stargazers_count"`
+	ForksCount      int    `json:"forks_count"`
+	OpenIssuesCount int    `json:"open_issues_count"`
+
+	Topics []string `json:"topics"`
+
+	AllowMergeCommit    bool `json:"allow_merge_commit"`
+	AllowSquashMerge    bool `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool `json:"allow_rebase_merge"`
+	DeleteBranchOnMerge bool `json:"delete_branch_on_merge"`
 }
 
 type RepositoryPermissions struct {
@@ -548,13 +1159,66 @@ func (client *Client) ForkRepository(project *Project, params map[string]interfa
 }
 
 type Comment struct {
-	Id        int       `json:"id"`
-	Body      string    `json:"body"`
-	User      *User     `json:"user"`
-	CreatedAt time.Time `json:"created_at"`
+	Id        int        `json:"id"`
+	Body      string     `json:"body"`
+	User      *User      `json:"user"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	HtmlUrl   string     `json:"html_url"`
+	Reactions *Reactions `json:"reactions"`
+
+	AuthorAssociation string `json:"author_association"`
+}
+
+// ReviewComment is a comment on a line of the diff of a pull request. Line is
+// 0 when the comment is outdated.
+type ReviewComment struct {
+	Comment
+	Path        string `json:"path"`
+	Line        int    `json:"line"`
+	StartLine   int    `json:"start_line"`
+	Side        string `json:"side"`
+	CommitId    string `json:"commit_id"`
+	InReplyToId int    `json:"in_reply_to_id"`
+}
+
+type Reactions struct {
+	TotalCount int `json:"total_count"`
+	PlusOne    int `json:"+1"`
+	MinusOne   int `json:"-1"`
+	Laugh      int `json:"laugh"`
+	Hooray     int `json:"hooray"`
+	Confused   int `json:"confused"`
+	Heart      int `json:"heart"`
+	Rocket     int `json:"rocket"`
+	Eyes       int `json:"eyes"`
+}
+
+// Counts lists the reaction counts in the order that GitHub displays them,
+// keyed by the reaction names used in the API.
+func (r *Reactions) Counts() []ReactionCount {
+	if r == nil {
+		r = &Reactions{}
+	}
+	return []ReactionCount{
+		{"+1", r.PlusOne},
+		{"-1", r.MinusOne},
+		{"laugh", r.Laugh},
+		{"hooray", r.Hooray},
+		{"confused", r.Confused},
+		{"heart", r.Heart},
+		{"rocket", r.Rocket},
+		{"eyes", r.Eyes},
+	}
+}
+
+type ReactionCount struct {
+	Content string
+	Count   int
 }
 
 type Issue struct {
+	NodeId string `json:"node_id"`
 	Number int    `json:"number"`
 	State  string `json:"state"`
 	Title  string `json:"title"`
@@ -569,21 +1233,29 @@ type Issue struct {
 	MaintainerCanModify bool   `json:"maintainer_can_modify"`
 	Draft               bool   `json:"draft"`
 
+	// Mergeable is nil while GitHub is still checking for conflicts.
+	Mergeable      *bool  `json:"mergeable"`
+	MergeableState string `json:"mergeable_state"`
+
 	Comments  int          `json:"comments"`
 	Labels    []IssueLabel `json:"labels"`
 	Assignees []User       `json:"assignees"`
 	Milestone *Milestone   `json:"milestone"`
 	CreatedAt time.Time    `json:"created_at"`
 	UpdatedAt time.Time    `json:"updated_at"`
+	ClosedAt  time.Time    `json:"closed_at"`
 	MergedAt  time.Time    `json:"merged_at"`
 
 	RequestedReviewers []User `json:"requested_reviewers"`
 	RequestedTeams     []Team `json:"requested_teams"`
 
-	ApiUrl  string `json:"url"`
-	HtmlUrl string `json:"html_url"`
+	ApiUrl        string `json:"url"`
+	HtmlUrl       string `json:"html_url"`
+	RepositoryUrl string `json:"repository_url"`
 
-	ClosedBy *User `json:"closed_by"`
+	ClosedBy          *User      `json:"closed_by"`
+	AuthorAssociation string     `json:"author_association"`
+	Reactions         *Reactions `json:"reactions"`
 }
 
 type PullRequest Issue
@@ -625,17 +1297,22 @@ type IssueLabel struct {
 }
 
 type User struct {
+	Id    int    `json:"id"`
 	Login string `json:"login"`
 }
 
 type Team struct {
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
 }
 
 type Milestone struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	DueOn       *time.Time `json:"due_on"`
 }
 
 func (client *Client) FetchIssues(project *Project, filterParams map[string]interface{}, limit int, filter func(*Issue) bool) (issues []Issue, err error) {
@@ -683,7 +1360,7 @@ func (client *Client) FetchIssue(project *Project, number string) (issue *Issue,
 		return
 	}
 
-	res, err := api.Get(fmt.Sprintf("repos/%s/%s/issues/%s", project.Owner, project.Name, number))
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/issues/%s", project.Owner, project.Name, number), reactionsType)
 	if err = checkStatus(200, "fetching issue", res, err); err != nil {
 		return nil, err
 	}
@@ -693,19 +1370,58 @@ func (client *Client) FetchIssue(project *Project, number string) (issue *Issue,
 	return
 }
 
-func (client *Client) FetchComments(project *Project, number string) (comments []Comment, err error) {
+type CommunityProfile struct {
+	HealthPercentage int                       `json:"health_percentage"`
+	Description      string                    `json:"description"`
+	Documentation    string                    `json:"documentation"`
+	Files            map[string]*CommunityFile `json:"files"`
+	UpdatedAt        time.Time                 `json:"updated_at"`
+}
+
+type CommunityFile struct {
+	Name    string `json:"name"`
+	HtmlUrl string `json:"html_url"`
+}
+
+func (client *Client) CommunityProfile(project *Project) (profile *CommunityProfile, err error) {
 	api, err := client.simpleApi()
 	if err != nil {
 		return
 	}
 
-	res, err := api.Get(fmt.Sprintf("repos/%s/%s/issues/%s/comments", project.Owner, project.Name, number))
-	if err = checkStatus(200, "fetching comments for issue", res, err); err != nil {
-		return nil, err
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/community/profile", project.Owner, project.Name), communityProfileType)
+	if err = checkStatus(200, "fetching community profile", res, err); err != nil {
+		return
+	}
+
+	profile = &CommunityProfile{}
+	err = res.Unmarshal(profile)
+	return
+}
+
+func (client *Client) FetchComments(project *Project, number string) (comments []Comment, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
 	}
 
+	path := fmt.Sprintf("repos/%s/%s/issues/%s/comments?per_page=100", project.Owner, project.Name, number)
+
 	comments = []Comment{}
-	err = res.Unmarshal(&comments)
+	for path != "" {
+		res, err := api.GetFile(path, reactionsType)
+		if err = checkStatus(200, "fetching comments for issue", res, err); err != nil {
+			return nil, err
+		}
+		path = res.Link("next")
+
+		commentsPage := []Comment{}
+		if err = res.Unmarshal(&commentsPage); err != nil {
+			return nil, err
+		}
+		comments = append(comments, commentsPage...)
+	}
+
 	return
 }
 
@@ -740,6 +1456,148 @@ func (client *Client) UpdateIssue(project *Project, issueNumber int, params map[
 	return
 }
 
+func (client *Client) CreateComment(project *Project, issueNumber int, body string) (comment *Comment, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{"body": body}
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/issues/%d/comments", project.Owner, project.Name, issueNumber), params)
+	if err = checkStatus(201, "creating comment", res, err); err != nil {
+		return
+	}
+
+	comment = &Comment{}
+	err = res.Unmarshal(comment)
+	return
+}
+
+// CreateReviewComment comments on a line of the diff of a pull request.
+func (client *Client) CreateReviewComment(project *Project, prNumber int, params map[string]interface{}) (comment *Comment, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/pulls/%d/comments", project.Owner, project.Name, prNumber), params)
+	if err = checkStatus(201, "creating review comment", res, err); err != nil {
+		return
+	}
+
+	comment = &Comment{}
+	err = res.Unmarshal(comment)
+	return
+}
+
+// ReplyToReviewComment adds a reply to the thread of the review comment
+// commentID.
+func (client *Client) ReplyToReviewComment(project *Project, prNumber, commentID int, body string) (comment *Comment, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{"body": body}
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/pulls/%d/comments/%d/replies", project.Owner, project.Name, prNumber, commentID), params)
+	if err = checkStatus(201, "replying to review comment", res, err); err != nil {
+		return
+	}
+
+	comment = &Comment{}
+	err = res.Unmarshal(comment)
+	return
+}
+
+func (client *Client) AddLabels(project *Project, issueNumber int, labels []string) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{"labels": labels}
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/issues/%d/labels", project.Owner, project.Name, issueNumber), params)
+	if err = checkStatus(200, "adding labels", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) RemoveLabel(project *Project, issueNumber int, label string) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", project.Owner, project.Name, issueNumber, url.PathEscape(label)))
+	if err = checkStatus(200, "removing label", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) AddAssignees(project *Project, issueNumber int, assignees []string) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{"assignees": assignees}
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/issues/%d/assignees", project.Owner, project.Name, issueNumber), params)
+	if err = checkStatus(201, "adding assignees", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) RemoveAssignees(project *Project, issueNumber int, assignees []string) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{"assignees": assignees}
+	res, err := api.jsonRequest("DELETE", fmt.Sprintf("repos/%s/%s/issues/%d/assignees", project.Owner, project.Name, issueNumber), params, nil)
+	if err = checkStatus(200, "removing assignees", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+// LockIssue locks the conversation of an issue or pull request. The reason is
+// one of "off-topic", "too heated", "resolved", "spam", or blank.
+func (client *Client) LockIssue(project *Project, issueNumber int, reason string) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{}
+	if reason != "" {
+		params["lock_reason"] = reason
+	}
+	res, err := api.jsonRequest("PUT", fmt.Sprintf("repos/%s/%s/issues/%d/lock", project.Owner, project.Name, issueNumber), params, nil)
+	return checkStatus(204, "locking issue", res, err)
+}
+
+func (client *Client) UnlockIssue(project *Project, issueNumber int) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/issues/%d/lock", project.Owner, project.Name, issueNumber))
+	return checkStatus(204, "unlocking issue", res, err)
+}
+
 type sortedLabels []IssueLabel
 
 func (s sortedLabels) Len() int {
@@ -782,13 +1640,42 @@ func (client *Client) FetchLabels(project *Project) (labels []IssueLabel, err er
 	return
 }
 
+func (client *Client) CreateLabel(project *Project, label IssueLabel) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/labels", project.Owner, project.Name), label)
+	if err = checkStatus(201, "creating label", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+// FetchMilestones returns the open milestones of a repository.
 func (client *Client) FetchMilestones(project *Project) (milestones []Milestone, err error) {
+	return client.fetchMilestones(project, "")
+}
+
+// FetchAllMilestones returns the milestones of a repository including closed
+// ones.
+func (client *Client) FetchAllMilestones(project *Project) (milestones []Milestone, err error) {
+	return client.fetchMilestones(project, "all")
+}
+
+func (client *Client) fetchMilestones(project *Project, state string) (milestones []Milestone, err error) {
 	api, err := client.simpleApi()
 	if err != nil {
 		return
 	}
 
 	path := fmt.Sprintf("repos/%s/%s/milestones?per_page=100", project.Owner, project.Name)
+	if state != "" {
+		path += "&state=" + state
+	}
 
 	milestones = []Milestone{}
 	var res *simpleResponse
@@ -810,6 +1697,22 @@ func (client *Client) FetchMilestones(project *Project) (milestones []Milestone,
 	return
 }
 
+func (client *Client) CreateMilestone(project *Project, params map[string]interface{}) (milestone *Milestone, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/milestones", project.Owner, project.Name), params)
+	if err = checkStatus(201, "creating milestone", res, err); err != nil {
+		return
+	}
+
+	milestone = &Milestone{}
+	err = res.Unmarshal(milestone)
+	return
+}
+
 func (client *Client) GenericAPIRequest(method, path string, data interface{}, headers map[string]string, ttl int) (*simpleResponse, error) {
 	api, err := client.simpleApi()
 	if err != nil {
@@ -876,7 +1779,10 @@ func isToken(api *simpleClient, password string) bool {
 }
 
 func (client *Client) FindOrCreateToken(user, password, twoFactorCode string) (token string, err error) {
-	api := client.apiClient()
+	api, err := client.apiClient()
+	if err != nil {
+		return
+	}
 
 	if len(password) >= 40 && isToken(api, password) {
 		return password, nil
@@ -943,6 +1849,9 @@ func (client *Client) ensureAccessToken() error {
 }
 
 func (client *Client) simpleApi() (c *simpleClient, err error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
 	err = client.ensureAccessToken()
 	if err != nil {
 		return
@@ -953,7 +1862,10 @@ func (client *Client) simpleApi() (c *simpleClient, err error) {
 		return
 	}
 
-	c = client.apiClient()
+	c, err = client.apiClient()
+	if err != nil {
+		return
+	}
 	c.PrepareRequest = func(req *http.Request) {
 		clientDomain := normalizeHost(client.Host.Host)
 		if strings.HasPrefix(clientDomain, "api.github.") {
@@ -969,9 +1881,31 @@ func (client *Client) simpleApi() (c *simpleClient, err error) {
 	return
 }
 
-func (client *Client) apiClient() *simpleClient {
-	unixSocket := os.ExpandEnv(client.Host.UnixSocket)
-	httpClient := newHttpClient(os.Getenv("HUB_TEST_HOST"), os.Getenv("HUB_VERBOSE") != "", unixSocket)
+// IsOffline reports whether hub was told not to connect to GitHub, with
+// HUB_OFFLINE or the `--offline` flag.
+func IsOffline() bool {
+	return os.Getenv("HUB_OFFLINE") != ""
+}
+
+func (client *Client) apiClient() (*simpleClient, error) {
+	if IsOffline() {
+		return nil, fmt.Errorf("Aborted: %s can't be reached in offline mode (unset HUB_OFFLINE to connect)", client.Host.Host)
+	}
+
+	newTransport := client.Transport
+	if newTransport == nil {
+		newTransport = DefaultTransport
+	}
+	transport, err := newTransport(client.Host)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := requestTimeout()
+	if err != nil {
+		return nil, err
+	}
+	httpClient := newHttpClientWithTransport(os.Getenv("HUB_TEST_HOST"), os.Getenv("HUB_VERBOSE") != "", transport)
+	httpClient.Timeout = timeout
 	apiRoot := client.absolute(normalizeHost(client.Host.Host))
 	if !strings.HasPrefix(apiRoot.Host, "api.github.") {
 		apiRoot.Path = "/api/v3/"
@@ -980,7 +1914,24 @@ func (client *Client) apiClient() *simpleClient {
 	return &simpleClient{
 		httpClient: httpClient,
 		rootUrl:    apiRoot,
+		ctx:        client.context(),
+	}, nil
+}
+
+// UploadsUrl resolves path against the root of the uploads API, which is
+// served from a separate host on github.com.
+func (client *Client) UploadsUrl(path string) string {
+	uploadsRoot := client.absolute(normalizeHost(client.Host.Host))
+	if strings.HasPrefix(uploadsRoot.Host, "api.github.") {
+		uploadsRoot.Host = "uploads." + strings.TrimPrefix(uploadsRoot.Host, "api.")
+	} else {
+		uploadsRoot.Path = "/api/uploads/"
 	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return path
+	}
+	return uploadsRoot.ResolveReference(u).String()
 }
 
 func (client *Client) absolute(host string) *url.URL {
@@ -1008,7 +1959,9 @@ func (client *Client) FetchGist(id string) (gist *Gist, err error) {
 	return
 }
 
-func (client *Client) CreateGist(filenames []string, public bool) (gist *Gist, err error) {
+// CreateGist creates a gist from local files. The filename "-" denotes standard
+// input, which is stored under `stdinFilename` (default: "gistfile1.txt").
+func (client *Client) CreateGist(filenames []string, stdinFilename, description string, public bool) (gist *Gist, err error) {
 	api, err := client.simpleApi()
 	if err != nil {
 		return
@@ -1021,7 +1974,10 @@ func (client *Client) CreateGist(filenames []string, public bool) (gist *Gist, e
 	for _, file := range filenames {
 		if file == "-" {
 			content, err = ioutil.ReadAll(os.Stdin)
-			basename = "gistfile1.txt"
+			basename = stdinFilename
+			if basename == "" {
+				basename = "gistfile1.txt"
+			}
 		} else {
 			content, err = ioutil.ReadFile(file)
 			basename = path.Base(file)
@@ -1034,15 +1990,86 @@ func (client *Client) CreateGist(filenames []string, public bool) (gist *Gist, e
 	}
 
 	g := Gist{
-		Files:  files,
-		Public: public,
+		Files:       files,
+		Description: description,
+		Public:      public,
 	}
 
 	res, err := api.PostJSON("gists", &g)
 	if err = checkStatus(201, "creating gist", res, err); err != nil {
 		if res != nil && res.StatusCode == 404 && !strings.Contains(res.Header.Get("x-oauth-scopes"), "gist") {
-			err = fmt.Errorf("%s\nGo to https://%s/settings/tokens and enable the 'gist' scope for hub", err, client.Host.Host)
+			err = &apiError{
+				message:  fmt.Sprintf("%s\nGo to https://%s/settings/tokens and enable the 'gist' scope for hub", err, client.Host.Host),
+				exitCode: utils.ExitAuth,
+			}
+		}
+		return
+	}
+
+	err = res.Unmarshal(&gist)
+	return
+}
+
+func (client *Client) FetchGists(limit int, filter func(*Gist) bool) (gists []Gist, err error) {
+	return client.fetchGists("gists", limit, filter)
+}
+
+// UserGists lists the public gists of login.
+func (client *Client) UserGists(login string, limit int) (gists []Gist, err error) {
+	return client.fetchGists(fmt.Sprintf("users/%s/gists", login), limit, nil)
+}
+
+func (client *Client) fetchGists(path string, limit int, filter func(*Gist) bool) (gists []Gist, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path = fmt.Sprintf("%s?per_page=%d", path, perPage(limit, 100))
+
+	gists = []Gist{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching gists", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		gistsPage := []Gist{}
+		if err = res.Unmarshal(&gistsPage); err != nil {
+			return
+		}
+		for _, gist := range gistsPage {
+			if filter == nil || filter(&gist) {
+				gists = append(gists, gist)
+				if limit > 0 && len(gists) == limit {
+					path = ""
+					break
+				}
+			}
 		}
+	}
+
+	return
+}
+
+// UpdateGist replaces the contents of the given files in a gist, adding the
+// files that don't exist in it yet.
+func (client *Client) UpdateGist(id string, files map[string]string) (gist *Gist, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	gistFiles := map[string]interface{}{}
+	for name, content := range files {
+		gistFiles[name] = map[string]string{"content": content}
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("gists/%s", id), map[string]interface{}{"files": gistFiles})
+	if err = checkStatus(200, "updating gist", res, err); err != nil {
 		return
 	}
 
@@ -1050,6 +2077,16 @@ func (client *Client) CreateGist(filenames []string, public bool) (gist *Gist, e
 	return
 }
 
+func (client *Client) DeleteGist(id string) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Delete(fmt.Sprintf("gists/%s", id))
+	return checkStatus(204, "deleting gist", res, err)
+}
+
 func normalizeHost(host string) string {
 	if host == "" {
 		return GitHubHost
@@ -1064,13 +2101,19 @@ func normalizeHost(host string) string {
 
 func checkStatus(expectedStatus int, action string, response *simpleResponse, err error) error {
 	if err != nil {
-		return fmt.Errorf("Error %s: %s", action, err.Error())
+		return &apiError{
+			message:  fmt.Sprintf("Error %s: %s", action, err.Error()),
+			exitCode: utils.ExitCode(err),
+		}
 	} else if response.StatusCode != expectedStatus {
 		errInfo, err := response.ErrorInfo()
 		if err == nil {
 			return FormatError(action, errInfo)
 		} else {
-			return fmt.Errorf("Error %s: %s (HTTP %d)", action, err.Error(), response.StatusCode)
+			return &apiError{
+				message:  fmt.Sprintf("Error %s: %s (HTTP %d)", action, err.Error(), response.StatusCode),
+				exitCode: statusExitCode(response.Response),
+			}
 		}
 	} else {
 		return nil
@@ -1120,12 +2163,45 @@ func FormatError(action string, err error) (ee error) {
 			errStr = fmt.Sprintf("%s\n%s", errStr, errorMessage)
 		}
 
-		ee = fmt.Errorf(errStr)
+		ee = &apiError{message: errStr, exitCode: statusExitCode(e.Response)}
 	}
 
 	return
 }
 
+// apiError is an unsuccessful response from the API. Its exit code tells
+// scripts whether something wasn't found, access was denied, or the rate
+// limit was hit.
+type apiError struct {
+	message  string
+	exitCode int
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func (e *apiError) ExitCode() int {
+	return e.exitCode
+}
+
+func statusExitCode(res *http.Response) int {
+	switch res.StatusCode {
+	case 401:
+		return utils.ExitAuth
+	case 403:
+		if res.Header.Get("X-RateLimit-Remaining") == "0" || res.Header.Get("Retry-After") != "" {
+			return utils.ExitRateLimited
+		}
+		return utils.ExitAuth
+	case 429:
+		return utils.ExitRateLimited
+	case 404, 410:
+		return utils.ExitNotFound
+	}
+	return utils.ExitFailure
+}
+
 func authTokenNote(num int) (string, error) {
 	n := os.Getenv("USER")
 
@@ -2,11 +2,15 @@ package github
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/bmizerany/assert"
+	"github.com/github/hub/utils"
 )
 
 func TestClient_FormatError(t *testing.T) {
@@ -31,6 +35,37 @@ func TestClient_FormatError(t *testing.T) {
 	assert.Equal(t, "Error action: Unprocessable Entity (HTTP 422)\nerror message", fmt.Sprintf("%s", err))
 }
 
+func TestClient_FormatError_ExitCode(t *testing.T) {
+	exitCode := func(status int, header http.Header) int {
+		if header == nil {
+			header = http.Header{}
+		}
+		e := &errorInfo{Response: &http.Response{StatusCode: status, Header: header}}
+		return utils.ExitCode(FormatError("action", e))
+	}
+
+	assert.Equal(t, utils.ExitNotFound, exitCode(404, nil))
+	assert.Equal(t, utils.ExitAuth, exitCode(401, nil))
+	assert.Equal(t, utils.ExitAuth, exitCode(403, http.Header{"X-Ratelimit-Remaining": {"4999"}}))
+	assert.Equal(t, utils.ExitRateLimited, exitCode(403, http.Header{"X-Ratelimit-Remaining": {"0"}}))
+	assert.Equal(t, utils.ExitRateLimited, exitCode(429, nil))
+	assert.Equal(t, utils.ExitFailure, exitCode(422, nil))
+}
+
+func TestClient_Offline(t *testing.T) {
+	os.Setenv("HUB_OFFLINE", "1")
+	defer os.Unsetenv("HUB_OFFLINE")
+
+	client := NewClientWithHost(&Host{Host: "github.com", AccessToken: "OTOKEN"})
+	client.Transport = func(host *Host) (http.RoundTripper, error) {
+		t.Fatal("no connection should be made in offline mode")
+		return nil, nil
+	}
+
+	_, err := client.CurrentUser()
+	assert.Equal(t, "Aborted: github.com can't be reached in offline mode (unset HUB_OFFLINE to connect)", err.Error())
+}
+
 func TestAuthTokenNote(t *testing.T) {
 	note, err := authTokenNote(1)
 	assert.Equal(t, nil, err)
@@ -45,3 +80,43 @@ func TestAuthTokenNote(t *testing.T) {
 	assert.T(t, reg.MatchString(note))
 
 }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClient_Transport(t *testing.T) {
+	os.Unsetenv("HUB_TEST_HOST")
+	var requested []string
+	client := NewClient("github.com")
+	client.Transport = func(host *Host) (http.RoundTripper, error) {
+		assert.Equal(t, "github.com", host.Host)
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requested = append(requested, req.URL.String())
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"login":"mislav"}`)),
+				Request:    req,
+			}, nil
+		}), nil
+	}
+
+	api, err := client.apiClient()
+	assert.Equal(t, nil, err)
+	res, err := api.Get("user")
+	assert.Equal(t, nil, err)
+	user := User{}
+	assert.Equal(t, nil, res.Unmarshal(&user))
+	assert.Equal(t, "mislav", user.Login)
+	assert.Equal(t, []string{"https://api.github.com/user"}, requested)
+
+	client = NewClient("github.com")
+	client.Transport = func(host *Host) (http.RoundTripper, error) {
+		return nil, fmt.Errorf("no transport")
+	}
+	_, err = client.apiClient()
+	assert.Equal(t, "no transport", err.Error())
+}
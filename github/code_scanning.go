@@ -0,0 +1,94 @@
+package github
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// maxSarifSize is the largest compressed SARIF file that code scanning
+// accepts.
+const maxSarifSize = 10 * 1024 * 1024
+
+// EncodeSarif validates a SARIF log and returns it gzipped and base64 encoded,
+// which is the form that the code scanning API expects it in.
+func EncodeSarif(data []byte) (string, error) {
+	log := SarifLog{}
+	if err := json.Unmarshal(data, &log); err != nil {
+		return "", fmt.Errorf("invalid SARIF file: %s", err)
+	}
+	if log.Version != "2.1.0" {
+		return "", fmt.Errorf("invalid SARIF file: unsupported version '%s' (expected 2.1.0)", log.Version)
+	}
+
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	if buf.Len() > maxSarifSize {
+		return "", fmt.Errorf("SARIF file is too large: %d bytes compressed (limit: %d)", buf.Len(), maxSarifSize)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// UploadSarif uploads the results of a code scanning tool, encoded with
+// EncodeSarif, for the commit sha of ref. GitHub processes the upload in the
+// background; the returned ID identifies it.
+func (client *Client) UploadSarif(project *Project, sha, ref, sarif string) (id string, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/code-scanning/sarifs", project.Owner, project.Name), map[string]interface{}{
+		"commit_sha": sha,
+		"ref":        ref,
+		"sarif":      sarif,
+	})
+	if err = checkStatus(202, "uploading SARIF file", res, err); err != nil {
+		return
+	}
+
+	upload := struct {
+		Id string `json:"id"`
+	}{}
+	err = res.Unmarshal(&upload)
+	return upload.Id, err
+}
+
+// CodeScanningDismissReasons are the reasons accepted for dismissing a code
+// scanning alert.
+var CodeScanningDismissReasons = []string{"false positive", "won't fix", "used in tests"}
+
+// DismissCodeScanningAlert closes an alert for one of the
+// CodeScanningDismissReasons.
+func (client *Client) DismissCodeScanningAlert(project *Project, number int, reason, comment string) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	params := map[string]interface{}{
+		"state":            "dismissed",
+		"dismissed_reason": reason,
+	}
+	if comment != "" {
+		params["dismissed_comment"] = comment
+	}
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d", project.Owner, project.Name, number), params)
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return fmt.Errorf("Unable to find code scanning alert %d in %s", number, project)
+	}
+	if err = checkStatus(200, "dismissing alert", res, err); err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
@@ -0,0 +1,33 @@
+package github
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io/ioutil"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestEncodeSarif(t *testing.T) {
+	data := []byte(`{"version":"2.1.0","runs":[]}`)
+	encoded, err := EncodeSarif(data)
+	assert.Equal(t, nil, err)
+
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	assert.Equal(t, nil, err)
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	assert.Equal(t, nil, err)
+	decoded, err := ioutil.ReadAll(r)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, string(data), string(decoded))
+}
+
+func TestEncodeSarif_Invalid(t *testing.T) {
+	_, err := EncodeSarif([]byte(`{"version":"2.0.0"}`))
+	assert.Equal(t, "invalid SARIF file: unsupported version '2.0.0' (expected 2.1.0)", err.Error())
+
+	_, err = EncodeSarif([]byte(`not json`))
+	assert.NotEqual(t, nil, err)
+}
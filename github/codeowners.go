@@ -0,0 +1,228 @@
+package github
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CodeownersPaths are the locations where GitHub looks for a CODEOWNERS file,
+// in order of precedence.
+var CodeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// FindCodeowners returns the path of the CODEOWNERS file in effect for the
+// working tree workdir, relative to it.
+func FindCodeowners(workdir string) (string, error) {
+	for _, path := range CodeownersPaths {
+		if info, err := os.Stat(filepath.Join(workdir, path)); err == nil && info.Mode().IsRegular() {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("Unable to find a CODEOWNERS file in %s", strings.Join(CodeownersPaths, ", "))
+}
+
+// CodeownersRule is a line of a CODEOWNERS file.
+type CodeownersRule struct {
+	Line    int
+	Pattern string
+	Owners  []string
+	re      *regexp.Regexp
+}
+
+// CodeownersError is a syntax error on a line of a CODEOWNERS file.
+type CodeownersError struct {
+	Line    int
+	Message string
+}
+
+func (e *CodeownersError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+type Codeowners struct {
+	Rules []CodeownersRule
+	// Errors are the lines that GitHub ignores because they are invalid.
+	Errors []CodeownersError
+}
+
+var codeownersOwnerRe = regexp.MustCompile(`^(@[a-zA-Z0-9](?:[a-zA-Z0-9-]*)(?:/[a-zA-Z0-9._-]+)?|[^@\s]+@[^@\s]+\.[^@\s]+)$`)
+
+// ParseCodeowners reads a CODEOWNERS file. Like on GitHub, invalid lines are
+// recorded as errors and skipped rather than aborting the parse.
+func ParseCodeowners(r io.Reader) (*Codeowners, error) {
+	codeowners := &Codeowners{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := codeownersFields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule := CodeownersRule{Line: line, Pattern: fields[0], Owners: fields[1:]}
+		re, err := codeownersPatternRegexp(rule.Pattern)
+		if err != nil {
+			codeowners.Errors = append(codeowners.Errors, CodeownersError{line, err.Error()})
+			continue
+		}
+		rule.re = re
+
+		valid := true
+		for _, owner := range rule.Owners {
+			if !codeownersOwnerRe.MatchString(owner) {
+				codeowners.Errors = append(codeowners.Errors, CodeownersError{line, fmt.Sprintf("invalid owner '%s'", owner)})
+				valid = false
+			}
+		}
+		if valid {
+			codeowners.Rules = append(codeowners.Rules, rule)
+		}
+	}
+
+	return codeowners, scanner.Err()
+}
+
+// codeownersFields splits a line on whitespace, dropping the comment that
+// starts at an unescaped "#".
+func codeownersFields(line string) []string {
+	fields := []string{}
+	field := strings.Builder{}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' && i+1 < len(line) {
+			field.WriteByte(c)
+			field.WriteByte(line[i+1])
+			i++
+		} else if c == '#' {
+			break
+		} else if c == ' ' || c == '\t' {
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		} else {
+			field.WriteByte(c)
+		}
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// codeownersPatternRegexp translates a CODEOWNERS pattern to a regexp matching
+// the slash-separated paths it applies to. The syntax is that of gitignore,
+// except that negation with "!" and "[ ]" character ranges are unsupported.
+func codeownersPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf("negated pattern '%s' is not supported", pattern)
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("invalid pattern '%s'", pattern)
+	}
+
+	re := strings.Builder{}
+	if anchored {
+		re.WriteString("^")
+	} else {
+		re.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case strings.HasPrefix(p[i:], "**/") && (i == 0 || p[i-1] == '/'):
+			re.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**") && i+2 == len(p) && (i == 0 || p[i-1] == '/'):
+			re.WriteString(".*")
+			i++
+		case c == '*':
+			re.WriteString("[^/]*")
+		case c == '?':
+			re.WriteString("[^/]")
+		case c == '[' || c == ']':
+			return nil, fmt.Errorf("character ranges in pattern '%s' are not supported", pattern)
+		case c == '\\' && i+1 < len(p):
+			re.WriteString(regexp.QuoteMeta(p[i+1 : i+2]))
+			i++
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if dirOnly {
+		// a directory pattern applies to everything inside the directory
+		re.WriteString("/.+")
+	} else if !strings.HasSuffix(p, "/*") {
+		// a pattern matching a directory also applies to its contents, except
+		// that "dir/*" only covers the files directly inside "dir"
+		re.WriteString("(?:/.+)?")
+	}
+	re.WriteString("$")
+
+	return regexp.Compile(re.String())
+}
+
+// Match returns the rule in effect for path, relative to the root of the
+// repository, or nil if no rule matches. The last matching rule takes
+// precedence.
+func (c *Codeowners) Match(path string) *CodeownersRule {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		if c.Rules[i].re.MatchString(path) {
+			return &c.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Owners lists the distinct users and teams referenced in the rules, in order
+// of appearance. E-mail addresses are skipped.
+func (c *Codeowners) Owners() []string {
+	owners := []string{}
+	seen := map[string]bool{}
+	for _, rule := range c.Rules {
+		for _, owner := range rule.Owners {
+			key := strings.ToLower(owner)
+			if strings.HasPrefix(owner, "@") && !seen[key] {
+				seen[key] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners
+}
+
+// OwnerExists checks that owner, in "@user" or "@org/team" format, exists and
+// is visible to the authenticated user.
+func (client *Client) OwnerExists(owner string) (bool, error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return false, err
+	}
+
+	path := "users/" + strings.TrimPrefix(owner, "@")
+	if parts := strings.SplitN(strings.TrimPrefix(owner, "@"), "/", 2); len(parts) == 2 {
+		path = fmt.Sprintf("orgs/%s/teams/%s", parts[0], parts[1])
+	}
+
+	res, err := api.Get(path)
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return false, nil
+	}
+	if err = checkStatus(200, "checking owner", res, err); err != nil {
+		return false, err
+	}
+	res.Body.Close()
+	return true, nil
+}
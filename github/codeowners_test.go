@@ -0,0 +1,76 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestParseCodeowners(t *testing.T) {
+	codeowners, err := ParseCodeowners(strings.NewReader(`# global owners
+*       @global-owner1 @global-owner2
+
+*.js    @js-owner #This is an inline comment.
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+/apps/github
+\#notes @github/notes-team
+!*.md   @nobody
+src/[ab]/ @nobody
+/lib/   @bad@owner
+`))
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 7, len(codeowners.Rules))
+	assert.Equal(t, []string{"@js-owner"}, codeowners.Rules[1].Owners)
+	assert.Equal(t, 0, len(codeowners.Rules[5].Owners))
+
+	assert.Equal(t, 3, len(codeowners.Errors))
+	assert.Equal(t, 10, codeowners.Errors[0].Line)
+	assert.Equal(t, "line 11: character ranges in pattern 'src/[ab]/' are not supported", codeowners.Errors[1].Error())
+	assert.Equal(t, "line 12: invalid owner '@bad@owner'", codeowners.Errors[2].Error())
+
+	assert.Equal(t, []string{"@global-owner1", "@global-owner2", "@js-owner", "@doctocat", "@octocat", "@github/notes-team"}, codeowners.Owners())
+}
+
+func TestCodeowners_Match(t *testing.T) {
+	codeowners, _ := ParseCodeowners(strings.NewReader(`*       @global
+*.js    @js
+/build/logs/ @logs
+docs/*  docs@example.com
+apps/   @apps
+/apps/github
+**/fixtures @fixtures
+/vendor/** @vendor
+\#notes @notes
+`))
+
+	owners := func(path string) string {
+		rule := codeowners.Match(path)
+		if rule == nil {
+			return "<none>"
+		}
+		return strings.Join(rule.Owners, " ")
+	}
+
+	assert.Equal(t, "@global", owners("README.md"))
+	assert.Equal(t, "@js", owners("src/index.js"))
+	assert.Equal(t, "@logs", owners("build/logs/2020/out.log"))
+	assert.Equal(t, "@global", owners("src/build/logs/out.log"))
+	assert.Equal(t, "docs@example.com", owners("docs/getting-started.md"))
+	assert.Equal(t, "@global", owners("docs/build-app/troubleshooting.md"))
+	assert.Equal(t, "@global", owners("src/docs/readme.md"))
+	assert.Equal(t, "@apps", owners("apps/web/main.go"))
+	assert.Equal(t, "@apps", owners("lib/apps/main.go"))
+	assert.Equal(t, "", owners("apps/github/main.go"))
+	assert.Equal(t, "@fixtures", owners("test/fixtures/data.json"))
+	assert.Equal(t, "@fixtures", owners("fixtures"))
+	assert.Equal(t, "@vendor", owners("vendor/github.com/pkg/errors/errors.go"))
+	assert.Equal(t, "@notes", owners("doc/#notes"))
+
+	codeowners, _ = ParseCodeowners(strings.NewReader("/src/ @src\n"))
+	assert.Equal(t, "<none>", owners("README.md"))
+	assert.Equal(t, "@src", owners("/src/main.go"))
+}
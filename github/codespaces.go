@@ -0,0 +1,102 @@
+package github
+
+import (
+	"fmt"
+	"time"
+)
+
+type Codespace struct {
+	Name      string            `json:"name"`
+	State     string            `json:"state"`
+	WebUrl    string            `json:"web_url"`
+	GitStatus CodespaceGit      `json:"git_status"`
+	Machine   *CodespaceMachine `json:"machine"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type CodespaceGit struct {
+	Ref string `json:"ref"`
+}
+
+type CodespaceMachine struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+func codespacesPath(project *Project) string {
+	return fmt.Sprintf("repos/%s/%s/codespaces", project.Owner, project.Name)
+}
+
+// Codespaces lists the codespaces of the authenticated user for project.
+func (client *Client) Codespaces(project *Project) (codespaces []Codespace, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := codespacesPath(project) + "?per_page=100"
+	codespaces = []Codespace{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching codespaces", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := struct {
+			Codespaces []Codespace `json:"codespaces"`
+		}{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		codespaces = append(codespaces, page.Codespaces...)
+	}
+
+	return
+}
+
+// CreateCodespace creates a codespace for project. The "ref" param picks the
+// branch, which is the default branch otherwise.
+func (client *Client) CreateCodespace(project *Project, params map[string]interface{}) (*Codespace, error) {
+	return client.createCodespace(codespacesPath(project), params)
+}
+
+// CreatePullRequestCodespace creates a codespace for the head branch of a pull
+// request, which can be from a fork.
+func (client *Client) CreatePullRequestCodespace(project *Project, prNumber int, params map[string]interface{}) (*Codespace, error) {
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/codespaces", project.Owner, project.Name, prNumber)
+	return client.createCodespace(path, params)
+}
+
+func (client *Client) createCodespace(path string, params map[string]interface{}) (codespace *Codespace, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(path, params)
+	expectedStatus := 201
+	if err == nil && res.StatusCode == 202 {
+		// GitHub queued the codespace to be created
+		expectedStatus = 202
+	}
+	if err = checkStatus(expectedStatus, "creating codespace", res, err); err != nil {
+		return
+	}
+
+	codespace = &Codespace{}
+	err = res.Unmarshal(codespace)
+	return
+}
+
+func (client *Client) DeleteCodespace(name string) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Delete("user/codespaces/" + name)
+	return checkStatus(202, "deleting codespace", res, err)
+}
@@ -0,0 +1,115 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// CollaboratorPermissions are the permission levels that can be granted to
+// repository collaborators, from least to most access.
+var CollaboratorPermissions = []string{"pull", "triage", "push", "maintain", "admin"}
+
+type Collaborator struct {
+	Login    string `json:"login"`
+	RoleName string `json:"role_name"`
+}
+
+// Collaborators lists the users with access to project, optionally only those
+// with permission.
+func (client *Client) Collaborators(project *Project, permission string, limit int) (collaborators []Collaborator, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/collaborators?per_page=%d", project.Owner, project.Name, perPage(limit, 100))
+	if permission != "" {
+		path += "&permission=" + url.QueryEscape(permission)
+	}
+	collaborators = []Collaborator{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching collaborators", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []Collaborator{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, collaborator := range page {
+			collaborators = append(collaborators, collaborator)
+			if limit > 0 && len(collaborators) == limit {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// AddCollaborator grants user permission to project. Users who don't have
+// access yet are sent an invitation, in which case invited is true.
+func (client *Client) AddCollaborator(project *Project, user, permission string) (invited bool, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.jsonRequest("PUT", fmt.Sprintf("repos/%s/%s/collaborators/%s", project.Owner, project.Name, user), map[string]interface{}{
+		"permission": permission,
+	}, nil)
+	if err == nil && res.StatusCode == 204 {
+		res.Body.Close()
+		return false, nil
+	}
+	if err = checkStatus(201, "adding collaborator", res, err); err != nil {
+		return
+	}
+	res.Body.Close()
+	return true, nil
+}
+
+func (client *Client) RemoveCollaborator(project *Project, user string) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/collaborators/%s", project.Owner, project.Name, user))
+	return checkStatus(204, "removing collaborator", res, err)
+}
+
+// CollaboratorPermission looks up the effective permission of user on project,
+// which takes organization and team membership into account. The result is
+// "admin", "maintain", "write", "triage", "read", or "none".
+func (client *Client) CollaboratorPermission(project *Project, user string) (permission string, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/collaborators/%s/permission", project.Owner, project.Name, user))
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return "", fmt.Errorf("Unable to find user '%s'", user)
+	}
+	if err = checkStatus(200, "fetching permission", res, err); err != nil {
+		return
+	}
+
+	result := struct {
+		Permission string `json:"permission"`
+		RoleName   string `json:"role_name"`
+	}{}
+	if err = res.Unmarshal(&result); err != nil {
+		return
+	}
+	if result.RoleName != "" {
+		return result.RoleName, nil
+	}
+	return result.Permission, nil
+}
@@ -16,25 +16,33 @@ import (
 	"github.com/github/hub/utils"
 	"github.com/mitchellh/go-homedir"
 	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/yaml.v2"
 )
 
 type yamlHost struct {
-	User       string `yaml:"user"`
-	OAuthToken string `yaml:"oauth_token"`
-	Protocol   string `yaml:"protocol"`
-	UnixSocket string `yaml:"unix_socket,omitempty"`
+	User       string        `yaml:"user"`
+	OAuthToken string        `yaml:"oauth_token"`
+	Protocol   string        `yaml:"protocol"`
+	UnixSocket string        `yaml:"unix_socket,omitempty"`
+	Profile    string        `yaml:"profile,omitempty"`
+	Owners     []string      `yaml:"owners,omitempty"`
+	Settings   yaml.MapSlice `yaml:"settings,omitempty"`
 }
 
 type Host struct {
-	Host        string `toml:"host"`
-	User        string `toml:"user"`
-	AccessToken string `toml:"access_token"`
-	Protocol    string `toml:"protocol"`
-	UnixSocket  string `toml:"unix_socket,omitempty"`
+	Host        string            `toml:"host"`
+	User        string            `toml:"user"`
+	AccessToken string            `toml:"access_token"`
+	Protocol    string            `toml:"protocol"`
+	UnixSocket  string            `toml:"unix_socket,omitempty"`
+	Profile     string            `toml:"profile,omitempty"`
+	Owners      []string          `toml:"owners,omitempty"`
+	Settings    map[string]string `toml:"settings,omitempty"`
 }
 
 type Config struct {
-	Hosts []*Host `toml:"hosts"`
+	Hosts    []*Host           `toml:"hosts"`
+	Settings map[string]string `toml:"settings,omitempty"`
 }
 
 func (c *Config) PromptForHost(host string) (h *Host, err error) {
@@ -49,6 +57,20 @@ func (c *Config) PromptForHost(host string) (h *Host, err error) {
 	}
 
 	h = c.Find(host)
+	if IsOffline() {
+		// Use whatever is known about the host without asking GitHub, so that
+		// commands that only need the user name, such as `browse -u`, work.
+		if h == nil {
+			h = &Host{
+				Host:        host,
+				User:        os.Getenv("GITHUB_USER"),
+				AccessToken: token,
+				Protocol:    "https",
+				Profile:     ActiveProfile(),
+			}
+		}
+		return
+	}
 	if h != nil {
 		if h.User == "" {
 			utils.Check(CheckWriteable(configsFile()))
@@ -73,6 +95,7 @@ func (c *Config) PromptForHost(host string) (h *Host, err error) {
 			Host:        host,
 			AccessToken: token,
 			Protocol:    "https",
+			Profile:     ActiveProfile(),
 		}
 		c.Hosts = append(c.Hosts, h)
 	}
@@ -198,7 +221,7 @@ func getPassword() (string, error) {
 		return "", err
 	}
 
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		s := <-c
@@ -222,26 +245,91 @@ func getPassword() (string, error) {
 	return string(passBytes), nil
 }
 
+// Find returns the credentials for host. If there are several accounts for
+// host, the one for the active profile is picked, or else the one listing the
+// owner of the current repository among its owners, or else the one without a
+// profile name.
 func (c *Config) Find(host string) *Host {
+	accounts := []*Host{}
 	for _, h := range c.Hosts {
 		if h.Host == host {
+			accounts = append(accounts, h)
+		}
+	}
+
+	if profile := ActiveProfile(); profile != "" {
+		for _, h := range accounts {
+			if h.Profile == profile {
+				return h
+			}
+		}
+		return nil
+	}
+
+	if len(accounts) == 0 {
+		return nil
+	} else if len(accounts) == 1 {
+		return accounts[0]
+	}
+
+	for _, owner := range remoteOwners(host) {
+		for _, h := range accounts {
+			if stringInSlice(owner, h.Owners) {
+				return h
+			}
+		}
+	}
+	for _, h := range accounts {
+		if h.Profile == "" {
 			return h
 		}
 	}
+	return accounts[0]
+}
 
-	return nil
+// ActiveProfile returns the account profile selected via `--account` or the
+// HUB_PROFILE environment variable.
+func ActiveProfile() string {
+	return os.Getenv("HUB_PROFILE")
+}
+
+// remoteOwners lists the owners of the git remotes of the current repository
+// that point to host, in the order remotes are looked up in.
+func remoteOwners(host string) []string {
+	owners := []string{}
+	remotes, err := Remotes()
+	if err != nil {
+		return owners
+	}
+	for _, remote := range remotes {
+		for _, u := range []*url.URL{remote.URL, remote.PushURL} {
+			if u == nil || !strings.EqualFold(u.Host, host) {
+				continue
+			}
+			if parts := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2); parts[0] != "" {
+				owners = append(owners, parts[0])
+			}
+		}
+	}
+	return owners
 }
 
-func (c *Config) selectHost() *Host {
-	options := len(c.Hosts)
+func (c *Config) selectHost() string {
+	hostnames := []string{}
+	for _, h := range c.Hosts {
+		if !stringInSlice(h.Host, hostnames) {
+			hostnames = append(hostnames, h.Host)
+		}
+	}
+	options := len(hostnames)
 
 	if options == 1 {
-		return c.Hosts[0]
+		return hostnames[0]
 	}
 
 	prompt := "Select host:\n"
-	for idx, host := range c.Hosts {
-		prompt += fmt.Sprintf(" %d. %s\n", idx+1, host.Host)
+	for idx, hostname := range hostnames {
+		prompt += fmt.Sprintf(" %d. %s\n", idx+1, hostname)
 	}
 	prompt += fmt.Sprint("> ")
 
@@ -252,7 +340,7 @@ func (c *Config) selectHost() *Host {
 		utils.Check(fmt.Errorf("Error: must enter a number [1-%d]", options))
 	}
 
-	return c.Hosts[i-1]
+	return hostnames[i-1]
 }
 
 var defaultConfigsFile string
@@ -333,10 +421,11 @@ func CurrentConfig() *Config {
 func (c *Config) DefaultHost() (host *Host, err error) {
 	if GitHubHostEnv != "" {
 		host, err = c.PromptForHost(GitHubHostEnv)
+	} else if defaultHost := c.Settings["default_host"]; defaultHost != "" {
+		host, err = c.PromptForHost(defaultHost)
 	} else if len(c.Hosts) > 0 {
-		host = c.selectHost()
 		// HACK: forces host to inherit GITHUB_TOKEN if applicable
-		host, err = c.PromptForHost(host.Host)
+		host, err = c.PromptForHost(c.selectHost())
 	} else {
 		host, err = c.PromptForHost(DefaultGitHubHost())
 	}
@@ -347,6 +436,8 @@ func (c *Config) DefaultHost() (host *Host, err error) {
 func (c *Config) DefaultHostNoPrompt() (*Host, error) {
 	if GitHubHostEnv != "" {
 		return c.PromptForHost(GitHubHostEnv)
+	} else if defaultHost := c.Settings["default_host"]; defaultHost != "" {
+		return c.PromptForHost(defaultHost)
 	} else if len(c.Hosts) > 0 {
 		host := c.Hosts[0]
 		// HACK: forces host to inherit GITHUB_TOKEN if applicable
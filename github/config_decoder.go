@@ -1,6 +1,7 @@
 package github
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 
@@ -37,25 +38,55 @@ func (y *yamlConfigDecoder) Decode(r io.Reader, c *Config) error {
 	}
 
 	for _, hostEntry := range yc {
-		v := hostEntry.Value.([]interface{})
-		if len(v) < 1 {
+		if settings, ok := hostEntry.Value.(yaml.MapSlice); ok && hostEntry.Key == "settings" {
+			c.Settings = yamlSettings(settings)
 			continue
 		}
-		host := &Host{Host: hostEntry.Key.(string)}
-		for _, prop := range v[0].(yaml.MapSlice) {
-			switch prop.Key.(string) {
-			case "user":
-				host.User = prop.Value.(string)
-			case "oauth_token":
-				host.AccessToken = prop.Value.(string)
-			case "protocol":
-				host.Protocol = prop.Value.(string)
-			case "unix_socket":
-				host.UnixSocket = prop.Value.(string)
+		v, ok := hostEntry.Value.([]interface{})
+		if !ok || len(v) < 1 {
+			continue
+		}
+		for _, account := range v {
+			props, ok := account.(yaml.MapSlice)
+			if !ok {
+				continue
+			}
+			host := &Host{Host: hostEntry.Key.(string)}
+			for _, prop := range props {
+				switch prop.Key.(string) {
+				case "user":
+					host.User = prop.Value.(string)
+				case "oauth_token":
+					host.AccessToken = prop.Value.(string)
+				case "protocol":
+					host.Protocol = prop.Value.(string)
+				case "unix_socket":
+					host.UnixSocket = prop.Value.(string)
+				case "profile":
+					host.Profile = fmt.Sprint(prop.Value)
+				case "owners":
+					if owners, ok := prop.Value.([]interface{}); ok {
+						for _, owner := range owners {
+							host.Owners = append(host.Owners, fmt.Sprint(owner))
+						}
+					}
+				case "settings":
+					if settings, ok := prop.Value.(yaml.MapSlice); ok {
+						host.Settings = yamlSettings(settings)
+					}
+				}
 			}
+			c.Hosts = append(c.Hosts, host)
 		}
-		c.Hosts = append(c.Hosts, host)
 	}
 
 	return nil
 }
+
+func yamlSettings(items yaml.MapSlice) map[string]string {
+	settings := map[string]string{}
+	for _, item := range items {
+		settings[fmt.Sprint(item.Key)] = fmt.Sprint(item.Value)
+	}
+	return settings
+}
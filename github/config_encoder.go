@@ -2,6 +2,7 @@ package github
 
 import (
 	"io"
+	"sort"
 
 	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v2"
@@ -24,17 +25,31 @@ type yamlConfigEncoder struct {
 
 func (y *yamlConfigEncoder) Encode(w io.Writer, c *Config) error {
 	yc := yaml.MapSlice{}
+	accounts := map[string]int{}
 	for _, h := range c.Hosts {
+		account := yamlHost{
+			User:       h.User,
+			OAuthToken: h.AccessToken,
+			Protocol:   h.Protocol,
+			UnixSocket: h.UnixSocket,
+			Profile:    h.Profile,
+			Owners:     h.Owners,
+			Settings:   settingsMapSlice(h.Settings),
+		}
+		if i, ok := accounts[h.Host]; ok {
+			yc[i].Value = append(yc[i].Value.([]yamlHost), account)
+			continue
+		}
+		accounts[h.Host] = len(yc)
 		yc = append(yc, yaml.MapItem{
-			Key: h.Host,
-			Value: []yamlHost{
-				{
-					User:       h.User,
-					OAuthToken: h.AccessToken,
-					Protocol:   h.Protocol,
-					UnixSocket: h.UnixSocket,
-				},
-			},
+			Key:   h.Host,
+			Value: []yamlHost{account},
+		})
+	}
+	if len(c.Settings) > 0 {
+		yc = append(yc, yaml.MapItem{
+			Key:   "settings",
+			Value: settingsMapSlice(c.Settings),
 		})
 	}
 
@@ -50,3 +65,21 @@ func (y *yamlConfigEncoder) Encode(w io.Writer, c *Config) error {
 
 	return err
 }
+
+// settingsMapSlice orders settings by key so that the config file is stable.
+func settingsMapSlice(settings map[string]string) yaml.MapSlice {
+	if len(settings) == 0 {
+		return nil
+	}
+	keys := []string{}
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	items := yaml.MapSlice{}
+	for _, key := range keys {
+		items = append(items, yaml.MapItem{Key: key, Value: settings[key]})
+	}
+	return items
+}
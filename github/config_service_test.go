@@ -207,3 +207,91 @@ func TestConfigService_YamlSave_UnixSocket(t *testing.T) {
   unix_socket: /tmp/go.sock`
 	assert.Equal(t, content, strings.TrimSpace(string(b)))
 }
+
+func TestConfigService_YamlSettings(t *testing.T) {
+	file, _ := ioutil.TempFile("", "test-gh-config-")
+	defer os.RemoveAll(file.Name())
+
+	host := &Host{
+		Host:        "github.com",
+		User:        "jingweno",
+		AccessToken: "123",
+		Protocol:    "https",
+		Settings:    map[string]string{"editor": "nano"},
+	}
+	c := &Config{
+		Hosts:    []*Host{host},
+		Settings: map[string]string{"git_protocol": "ssh", "color": "never"},
+	}
+
+	cs := &configService{
+		Encoder: &yamlConfigEncoder{},
+		Decoder: &yamlConfigDecoder{},
+	}
+	err := cs.Save(file.Name(), c)
+	assert.Equal(t, nil, err)
+
+	b, _ := ioutil.ReadFile(file.Name())
+	content := `github.com:
+- user: jingweno
+  oauth_token: "123"
+  protocol: https
+  settings:
+    editor: nano
+settings:
+  color: never
+  git_protocol: ssh`
+	assert.Equal(t, content, strings.TrimSpace(string(b)))
+
+	cc := &Config{}
+	err = cs.Load(file.Name(), cc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(cc.Hosts))
+	assert.Equal(t, "nano", cc.Setting("github.com", "editor"))
+	assert.Equal(t, "ssh", cc.Setting("github.com", "git_protocol"))
+	assert.Equal(t, "", cc.Setting("", "editor"))
+}
+
+func TestConfigService_YamlProfiles(t *testing.T) {
+	file, _ := ioutil.TempFile("", "test-gh-config-")
+	defer os.RemoveAll(file.Name())
+
+	c := &Config{
+		Hosts: []*Host{
+			{Host: "github.com", User: "mislav", AccessToken: "123", Protocol: "https"},
+			{Host: "git.my.org", User: "mislav", AccessToken: "789", Protocol: "https"},
+			{Host: "github.com", User: "mislav-work", AccessToken: "456", Protocol: "https", Profile: "work", Owners: []string{"work-org"}},
+		},
+	}
+
+	cs := &configService{
+		Encoder: &yamlConfigEncoder{},
+		Decoder: &yamlConfigDecoder{},
+	}
+	err := cs.Save(file.Name(), c)
+	assert.Equal(t, nil, err)
+
+	b, _ := ioutil.ReadFile(file.Name())
+	content := `github.com:
+- user: mislav
+  oauth_token: "123"
+  protocol: https
+- user: mislav-work
+  oauth_token: "456"
+  protocol: https
+  profile: work
+  owners:
+  - work-org
+git.my.org:
+- user: mislav
+  oauth_token: "789"
+  protocol: https`
+	assert.Equal(t, content, strings.TrimSpace(string(b)))
+
+	cc := &Config{}
+	err = cs.Load(file.Name(), cc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(cc.Hosts))
+	assert.Equal(t, "work", cc.Hosts[1].Profile)
+	assert.Equal(t, []string{"work-org"}, cc.Hosts[1].Owners)
+}
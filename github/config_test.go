@@ -0,0 +1,31 @@
+package github
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/github/hub/fixtures"
+)
+
+func TestConfig_FindProfile(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	personal := &Host{Host: "github.com", User: "mislav", AccessToken: "123"}
+	work := &Host{Host: "github.com", User: "mislav-work", AccessToken: "456", Profile: "work", Owners: []string{"work-org"}}
+	c := &Config{Hosts: []*Host{work, personal}}
+
+	assert.Equal(t, personal, c.Find("github.com"))
+	assert.Equal(t, (*Host)(nil), c.Find("git.my.org"))
+
+	repo.AddRemote("upstream", "git@github.com:work-org/project.git", "")
+	assert.Equal(t, work, c.Find("github.com"))
+
+	os.Setenv("HUB_PROFILE", "home")
+	defer os.Unsetenv("HUB_PROFILE")
+	assert.Equal(t, (*Host)(nil), c.Find("github.com"))
+
+	os.Setenv("HUB_PROFILE", "work")
+	assert.Equal(t, work, c.Find("github.com"))
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
+)
+
+var (
+	// requestContext is the context of API requests made by clients that
+	// weren't given one with WithContext.
+	requestContext   = context.Background()
+	requestsInFlight int32
+)
+
+// errInterrupted is returned by API requests aborted with Ctrl-C.
+var errInterrupted = &apiError{message: "interrupted", exitCode: utils.ExitInterrupted}
+
+// CancelOnInterrupt makes Ctrl-C abort the API requests in flight right away
+// instead of waiting for their response. If no request is in flight, the
+// signal has the effect it would have had otherwise. The returned function
+// undoes it.
+func CancelOnInterrupt() (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	requestContext = ctx
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig, ok := <-sigs
+		if !ok {
+			return
+		}
+		cancel()
+		signal.Stop(sigs)
+		if atomic.LoadInt32(&requestsInFlight) > 0 {
+			return
+		}
+		// Raise the signal again now that it isn't caught here, so that
+		// hub is terminated, or other handlers such as the pager's see it.
+		if p, err := os.FindProcess(os.Getpid()); err != nil || p.Signal(sig) != nil {
+			ui.Exit(utils.ExitInterrupted)
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigs)
+		close(sigs)
+		cancel()
+		requestContext = context.Background()
+	}
+}
+
+// WithContext returns a copy of the client that makes its API requests with
+// ctx, e.g. to give up on them after a deadline.
+func (client *Client) WithContext(ctx context.Context) *Client {
+	return &Client{
+		Host:      client.Host,
+		Transport: client.Transport,
+		ctx:       ctx,
+	}
+}
+
+func (client *Client) context() context.Context {
+	if client.ctx != nil {
+		return client.ctx
+	}
+	return requestContext
+}
+
+// requestTimeout is the time that a single API request may take, including
+// reading the response, as set with HUB_HTTP_TIMEOUT. Zero means no limit.
+func requestTimeout() (time.Duration, error) {
+	value := os.Getenv("HUB_HTTP_TIMEOUT")
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if timeout, err := time.ParseDuration(value); err == nil && timeout >= 0 {
+		return timeout, nil
+	}
+	return 0, fmt.Errorf("invalid HUB_HTTP_TIMEOUT: %q (expected a number of seconds or a duration such as 1m30s)", value)
+}
+
+// requestError makes the error of a request that didn't get a response
+// explain whether it was interrupted or timed out.
+func requestError(ctx context.Context, timeout time.Duration, err error) error {
+	if ctx.Err() == context.Canceled {
+		return errInterrupted
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() && timeout > 0 {
+		return fmt.Errorf("no response within %s (see HUB_HTTP_TIMEOUT)", timeout)
+	}
+	return err
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+	"github.com/github/hub/utils"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	defer os.Unsetenv("HUB_HTTP_TIMEOUT")
+
+	os.Setenv("HUB_HTTP_TIMEOUT", "")
+	timeout, err := requestTimeout()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, time.Duration(0), timeout)
+
+	os.Setenv("HUB_HTTP_TIMEOUT", "30")
+	timeout, _ = requestTimeout()
+	assert.Equal(t, 30*time.Second, timeout)
+
+	os.Setenv("HUB_HTTP_TIMEOUT", "1m30s")
+	timeout, _ = requestTimeout()
+	assert.Equal(t, 90*time.Second, timeout)
+
+	os.Setenv("HUB_HTTP_TIMEOUT", "soon")
+	_, err = requestTimeout()
+	assert.Equal(t, `invalid HUB_HTTP_TIMEOUT: "soon" (expected a number of seconds or a duration such as 1m30s)`, err.Error())
+}
+
+func TestClient_WithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	os.Setenv("HUB_TEST_HOST", server.URL)
+	defer os.Unsetenv("HUB_TEST_HOST")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := NewClientWithHost(&Host{Host: "github.com", User: "mislav", AccessToken: "OTOKEN"}).WithContext(ctx)
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, err := client.CurrentUser()
+	assert.Equal(t, "Error getting current user: interrupted", err.Error())
+	assert.Equal(t, utils.ExitInterrupted, utils.ExitCode(err))
+}
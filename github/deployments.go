@@ -0,0 +1,117 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type Deployment struct {
+	Id          int       `json:"id"`
+	Sha         string    `json:"sha"`
+	Ref         string    `json:"ref"`
+	Task        string    `json:"task"`
+	Environment string    `json:"environment"`
+	Description string    `json:"description"`
+	Creator     *User     `json:"creator"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type DeploymentStatus struct {
+	Id             int       `json:"id"`
+	State          string    `json:"state"`
+	Description    string    `json:"description"`
+	Environment    string    `json:"environment"`
+	LogUrl         string    `json:"log_url"`
+	EnvironmentUrl string    `json:"environment_url"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func deploymentsPath(project *Project) string {
+	return fmt.Sprintf("repos/%s/%s/deployments", project.Owner, project.Name)
+}
+
+// Deployments lists the deployments of project, most recent first, optionally
+// only those to environment.
+func (client *Client) Deployments(project *Project, environment string, limit int) (deployments []Deployment, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("%s?per_page=%d", deploymentsPath(project), perPage(limit, 100))
+	if environment != "" {
+		path += "&environment=" + url.QueryEscape(environment)
+	}
+
+	deployments = []Deployment{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching deployments", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		deploymentsPage := []Deployment{}
+		if err = res.Unmarshal(&deploymentsPage); err != nil {
+			return
+		}
+		for _, deployment := range deploymentsPage {
+			deployments = append(deployments, deployment)
+			if limit > 0 && len(deployments) == limit {
+				path = ""
+				break
+			}
+		}
+	}
+
+	return
+}
+
+func (client *Client) CreateDeployment(project *Project, params map[string]interface{}) (deployment *Deployment, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(deploymentsPath(project), params)
+	if err == nil && res.StatusCode == 202 {
+		// GitHub merged the default branch into the ref instead of deploying.
+		message := struct {
+			Message string `json:"message"`
+		}{}
+		if err = res.Unmarshal(&message); err != nil {
+			return
+		}
+		return nil, fmt.Errorf("Error creating deployment: %s", message.Message)
+	}
+	if err = checkStatus(201, "creating deployment", res, err); err != nil {
+		return
+	}
+
+	deployment = &Deployment{}
+	err = res.Unmarshal(deployment)
+	return
+}
+
+func (client *Client) CreateDeploymentStatus(project *Project, id int, params map[string]interface{}) (status *DeploymentStatus, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("%s/%d/statuses", deploymentsPath(project), id), params)
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return nil, fmt.Errorf("Unable to find deployment %d in %s", id, project)
+	}
+	if err = checkStatus(201, "creating deployment status", res, err); err != nil {
+		return
+	}
+
+	status = &DeploymentStatus{}
+	err = res.Unmarshal(status)
+	return
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type DiscussionCategory struct {
+	Id           string
+	Name         string
+	Slug         string
+	IsAnswerable bool
+}
+
+type Discussion struct {
+	Id        string
+	Number    int
+	Title     string
+	Body      string
+	URL       string
+	CreatedAt time.Time
+	Author    *User
+	Category  DiscussionCategory
+	Answer    *DiscussionComment
+	Comments  struct {
+		TotalCount int
+		Nodes      []DiscussionComment
+	}
+}
+
+type DiscussionComment struct {
+	Id         string
+	DatabaseId int
+	Body       string
+	URL        string
+	CreatedAt  time.Time
+	Author     *User
+	IsAnswer   bool
+}
+
+// DiscussionCategories lists the discussion categories of project along with
+// the node ID of the repository, which creating a discussion requires.
+func (client *Client) DiscussionCategories(project *Project) (categories []DiscussionCategory, repositoryId string, err error) {
+	query := `query($owner: String!, $name: String!) {
+		repository(owner: $owner, name: $name) {
+			id
+			hasDiscussionsEnabled
+			discussionCategories(first: 100) { nodes { id name slug isAnswerable } }
+		}
+	}`
+	variables := map[string]interface{}{
+		"owner": project.Owner,
+		"name":  project.Name,
+	}
+
+	data := struct {
+		Repository struct {
+			Id                    string
+			HasDiscussionsEnabled bool
+			DiscussionCategories  struct {
+				Nodes []DiscussionCategory
+			}
+		}
+	}{}
+	if err = client.GraphQL("fetching discussion categories", query, variables, &data); err != nil {
+		return
+	}
+	if !data.Repository.HasDiscussionsEnabled {
+		return nil, "", fmt.Errorf("Discussions are disabled for %s", project)
+	}
+
+	return data.Repository.DiscussionCategories.Nodes, data.Repository.Id, nil
+}
+
+// FindDiscussionCategory looks up a category by its name or slug, ignoring case.
+func FindDiscussionCategory(categories []DiscussionCategory, name string) (*DiscussionCategory, error) {
+	names := []string{}
+	for i, category := range categories {
+		if strings.EqualFold(category.Name, name) || strings.EqualFold(category.Slug, name) {
+			return &categories[i], nil
+		}
+		names = append(names, category.Name)
+	}
+	return nil, fmt.Errorf("Unable to find discussion category '%s' (available: %s)", name, strings.Join(names, ", "))
+}
+
+const discussionFields = "id number title url createdAt author { login } category { id name slug isAnswerable } answer { id databaseId }"
+
+// Discussions lists the discussions of project, most recently updated first,
+// optionally only those in the category with the node ID categoryId.
+func (client *Client) Discussions(project *Project, categoryId string, limit int) (discussions []Discussion, err error) {
+	query := fmt.Sprintf(`query($owner: String!, $name: String!, $first: Int!, $after: String, $categoryId: ID) {
+		repository(owner: $owner, name: $name) {
+			discussions(first: $first, after: $after, categoryId: $categoryId, orderBy: {field: UPDATED_AT, direction: DESC}) {
+				nodes { %s }
+				pageInfo { hasNextPage endCursor }
+			}
+		}
+	}`, discussionFields)
+	variables := map[string]interface{}{
+		"owner": project.Owner,
+		"name":  project.Name,
+		"first": perPage(limit, 100),
+	}
+	if categoryId != "" {
+		variables["categoryId"] = categoryId
+	}
+
+	discussions = []Discussion{}
+	for {
+		data := struct {
+			Repository struct {
+				Discussions struct {
+					Nodes    []Discussion
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+				}
+			}
+		}{}
+		if err = client.GraphQL("fetching discussions", query, variables, &data); err != nil {
+			return
+		}
+
+		page := data.Repository.Discussions
+		for _, discussion := range page.Nodes {
+			discussions = append(discussions, discussion)
+			if limit > 0 && len(discussions) == limit {
+				return
+			}
+		}
+		if !page.PageInfo.HasNextPage {
+			return
+		}
+		variables["after"] = page.PageInfo.EndCursor
+	}
+}
+
+// FetchDiscussion fetches a discussion along with its first 100 comments.
+func (client *Client) FetchDiscussion(project *Project, number int) (discussion *Discussion, err error) {
+	query := fmt.Sprintf(`query($owner: String!, $name: String!, $number: Int!) {
+		repository(owner: $owner, name: $name) {
+			discussion(number: $number) {
+				%s
+				body
+				comments(first: 100) {
+					totalCount
+					nodes { id databaseId body url createdAt author { login } isAnswer }
+				}
+			}
+		}
+	}`, discussionFields)
+	variables := map[string]interface{}{
+		"owner":  project.Owner,
+		"name":   project.Name,
+		"number": number,
+	}
+
+	data := struct {
+		Repository struct {
+			Discussion *Discussion
+		}
+	}{}
+	if err = client.GraphQL("fetching discussion", query, variables, &data); err != nil {
+		return
+	}
+	if data.Repository.Discussion == nil {
+		return nil, fmt.Errorf("Unable to find discussion #%d in %s", number, project)
+	}
+
+	return data.Repository.Discussion, nil
+}
+
+func (client *Client) CreateDiscussion(repositoryId string, category *DiscussionCategory, title, body string) (discussion *Discussion, err error) {
+	query := `mutation($repositoryId: ID!, $categoryId: ID!, $title: String!, $body: String!) {
+		createDiscussion(input: {repositoryId: $repositoryId, categoryId: $categoryId, title: $title, body: $body}) {
+			discussion { number url }
+		}
+	}`
+	variables := map[string]interface{}{
+		"repositoryId": repositoryId,
+		"categoryId":   category.Id,
+		"title":        title,
+		"body":         body,
+	}
+
+	data := struct {
+		CreateDiscussion struct {
+			Discussion Discussion
+		}
+	}{}
+	if err = client.GraphQL("creating discussion", query, variables, &data); err != nil {
+		return
+	}
+
+	return &data.CreateDiscussion.Discussion, nil
+}
+
+// MarkDiscussionCommentAsAnswer marks the comment with the node ID commentId
+// as the answer to its discussion, which has to be in an answerable category.
+func (client *Client) MarkDiscussionCommentAsAnswer(commentId string) error {
+	query := `mutation($id: ID!) {
+		markDiscussionCommentAsAnswer(input: {id: $id}) { discussion { number } }
+	}`
+	variables := map[string]interface{}{
+		"id": commentId,
+	}
+	return client.GraphQL("marking discussion answer", query, variables, nil)
+}
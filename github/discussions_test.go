@@ -0,0 +1,25 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestFindDiscussionCategory(t *testing.T) {
+	categories := []DiscussionCategory{
+		{Id: "C_1", Name: "Q&A", Slug: "q-a"},
+		{Id: "C_2", Name: "Ideas", Slug: "ideas"},
+	}
+
+	category, err := FindDiscussionCategory(categories, "q&a")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "C_1", category.Id)
+
+	category, err = FindDiscussionCategory(categories, "ideas")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "C_2", category.Id)
+
+	_, err = FindDiscussionCategory(categories, "Polls")
+	assert.Equal(t, "Unable to find discussion category 'Polls' (available: Q&A, Ideas)", err.Error())
+}
@@ -24,9 +24,12 @@ func NewEditor(filename, topic, message string) (editor *Editor, err error) {
 	}
 	messageFile := filepath.Join(gitDir, filename)
 
-	program, err := git.Editor()
-	if err != nil {
-		return
+	program := ConfigSetting("editor")
+	if program == "" {
+		program, err = git.Editor()
+		if err != nil {
+			return
+		}
 	}
 
 	cs, err := git.CommentChar(message)
@@ -137,14 +140,44 @@ func (e *Editor) readContent() (content []byte, err error) {
 	return ioutil.ReadFile(e.File)
 }
 
+// EditFile opens content in the text editor in a temporary file with the given
+// base name and returns the result. Unlike EditContent, the content is left
+// as-is instead of being treated as a message.
+func EditFile(filename string, content []byte) ([]byte, error) {
+	program, err := git.Editor()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "hub-edit")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, filepath.Base(filename))
+	if err = ioutil.WriteFile(file, content, 0600); err != nil {
+		return nil, err
+	}
+	if err = spawnEditor(program, file, false); err != nil {
+		return nil, fmt.Errorf("error using text editor for %s", filename)
+	}
+
+	return ioutil.ReadFile(file)
+}
+
 func openTextEditor(program, file string) error {
+	return spawnEditor(program, file, true)
+}
+
+func spawnEditor(program, file string, isMessage bool) error {
 	programArgs, err := shellquote.Split(program)
 	if err != nil {
 		return err
 	}
 	editCmd := cmd.NewWithArray(programArgs)
 	r := regexp.MustCompile(`\b(?:[gm]?vim)(?:\.exe)?$`)
-	if r.MatchString(editCmd.Name) {
+	if isMessage && r.MatchString(editCmd.Name) {
 		editCmd.WithArg("--cmd")
 		editCmd.WithArg("set ft=gitcommit tw=0 wrap lbr")
 	}
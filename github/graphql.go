@@ -0,0 +1,553 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/hub/utils"
+)
+
+type GraphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// GraphQLErrors are the errors reported in the body of an otherwise successful
+// GraphQL response.
+type GraphQLErrors []GraphQLError
+
+func (errs GraphQLErrors) Error() string {
+	messages := []string{}
+	for _, e := range errs {
+		messages = append(messages, e.Message)
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (errs GraphQLErrors) exitCode() int {
+	for _, e := range errs {
+		switch e.Type {
+		case "NOT_FOUND":
+			return utils.ExitNotFound
+		case "FORBIDDEN":
+			return utils.ExitAuth
+		case "RATE_LIMITED":
+			return utils.ExitRateLimited
+		}
+	}
+	return utils.ExitFailure
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors GraphQLErrors   `json:"errors"`
+}
+
+// GraphQL performs a GraphQL query or mutation and decodes the "data" portion
+// of the response into `data`. The action describes the operation for error
+// messages, e.g. "transferring issue".
+func (client *Client) GraphQL(action, query string, variables map[string]interface{}, data interface{}) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{"query": query}
+	if len(variables) > 0 {
+		params["variables"] = variables
+	}
+
+	res, err := api.PostJSON("graphql", params)
+	if err = checkStatus(200, action, res, err); err != nil {
+		return
+	}
+
+	result := graphQLResponse{}
+	if err = res.Unmarshal(&result); err != nil {
+		return
+	}
+	if len(result.Errors) > 0 {
+		return &apiError{
+			message:  fmt.Sprintf("Error %s: %s", action, result.Errors),
+			exitCode: result.Errors.exitCode(),
+		}
+	}
+	if data != nil {
+		err = json.Unmarshal(result.Data, data)
+	}
+	return
+}
+
+// TransferIssue moves an issue to another repository of the same owner and
+// returns the issue as it exists in its new location.
+func (client *Client) TransferIssue(issue *Issue, target *Repository) (transferred *Issue, err error) {
+	query := `mutation($issueId: ID!, $repositoryId: ID!) {
+		transferIssue(input: {issueId: $issueId, repositoryId: $repositoryId}) {
+			issue { number url }
+		}
+	}`
+	variables := map[string]interface{}{
+		"issueId":      issue.NodeId,
+		"repositoryId": target.NodeId,
+	}
+
+	data := struct {
+		TransferIssue struct {
+			Issue struct {
+				Number int
+				URL    string
+			}
+		}
+	}{}
+	if err = client.GraphQL("transferring issue", query, variables, &data); err != nil {
+		return
+	}
+
+	transferred = &Issue{
+		Number:  data.TransferIssue.Issue.Number,
+		HtmlUrl: data.TransferIssue.Issue.URL,
+	}
+	return
+}
+
+// SetIssuePinned pins an issue to the repository's issue list, or unpins it.
+func (client *Client) SetIssuePinned(issue *Issue, pinned bool) error {
+	mutation := "unpinIssue"
+	if pinned {
+		mutation = "pinIssue"
+	}
+	query := fmt.Sprintf(`mutation($issueId: ID!) {
+		%s(input: {issueId: $issueId}) { issue { number } }
+	}`, mutation)
+	variables := map[string]interface{}{
+		"issueId": issue.NodeId,
+	}
+
+	action := "unpinning issue"
+	if pinned {
+		action = "pinning issue"
+	}
+	return client.GraphQL(action, query, variables, nil)
+}
+
+// PullRequestStatus summarizes the review and checks status of a pull request.
+type PullRequestStatus struct {
+	// ReviewDecision is "approved", "changes_requested", "review_required", or
+	// blank if the repository doesn't require reviews.
+	ReviewDecision string
+	// Checks is "passing", "failing", "pending", or blank if there are no
+	// checks for the head commit.
+	Checks string
+}
+
+// PullRequestStatuses looks up the status of several pull requests of a
+// repository at once, keyed by pull request number.
+func (client *Client) PullRequestStatuses(project *Project, numbers []int) (statuses map[int]PullRequestStatus, err error) {
+	statuses = map[int]PullRequestStatus{}
+	var statusesMutex sync.Mutex
+	batches := batchNumbers(numbers, 100)
+
+	err = ParallelFetch(len(batches), ParallelFetchLimit, func(i int) error {
+		batch := batches[i]
+		fields := []string{}
+		for _, number := range batch {
+			fields = append(fields, fmt.Sprintf("pr%d: pullRequest(number: %d) { ...status }", number, number))
+		}
+		query := fmt.Sprintf(`query($owner: String!, $name: String!) {
+			repository(owner: $owner, name: $name) { %s }
+		}
+		fragment status on PullRequest {
+			number
+			reviewDecision
+			commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+		}`, strings.Join(fields, "\n"))
+		variables := map[string]interface{}{
+			"owner": project.Owner,
+			"name":  project.Name,
+		}
+
+		data := struct {
+			Repository map[string]*struct {
+				Number         int
+				ReviewDecision string
+				Commits        struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup *struct {
+								State string
+							}
+						}
+					}
+				}
+			}
+		}{}
+		if err := client.GraphQL("fetching pull request status", query, variables, &data); err != nil {
+			return err
+		}
+
+		statusesMutex.Lock()
+		defer statusesMutex.Unlock()
+		for _, pr := range data.Repository {
+			if pr == nil {
+				continue
+			}
+			status := PullRequestStatus{
+				ReviewDecision: strings.ToLower(pr.ReviewDecision),
+			}
+			if len(pr.Commits.Nodes) > 0 && pr.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+				status.Checks = checksStatus(pr.Commits.Nodes[0].Commit.StatusCheckRollup.State)
+			}
+			statuses[pr.Number] = status
+		}
+		return nil
+	})
+
+	return
+}
+
+func checksStatus(rollupState string) string {
+	switch rollupState {
+	case "SUCCESS":
+		return "passing"
+	case "FAILURE", "ERROR":
+		return "failing"
+	case "PENDING", "EXPECTED":
+		return "pending"
+	}
+	return ""
+}
+
+// PullRequestQuery selects the pull requests listed by
+// FetchPullRequestsGraphQL. Blank fields don't filter.
+type PullRequestQuery struct {
+	// States are any of "OPEN", "CLOSED", and "MERGED".
+	States  []string
+	BaseRef string
+	HeadRef string
+	// Labels matches pull requests having any of the labels.
+	Labels []string
+	// OrderBy is "CREATED_AT", "UPDATED_AT", or "COMMENTS".
+	OrderBy   string
+	Ascending bool
+	// Fields lists the optional data to fetch for each pull request: "body",
+	// "labels", "assignees", "milestone", "reviewRequests", "refs" (commit
+	// SHAs), and "status".
+	Fields []string
+}
+
+var pullRequestQueryFields = map[string]string{
+	"body":           "body",
+	"labels":         "labels(first: 100) { nodes { name color } }",
+	"assignees":      "assignees(first: 100) { nodes { login } }",
+	"milestone":      "milestone { number title }",
+	"reviewRequests": "reviewRequests(first: 100) { nodes { requestedReviewer { __typename ... on User { login } ... on Team { name slug } } } }",
+	"refs":           "baseRefOid headRefOid mergeCommit { oid }",
+	"status":         "reviewDecision commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }",
+}
+
+type graphQLPullRequest struct {
+	Number              int
+	Title               string
+	State               string
+	IsDraft             bool
+	URL                 string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	MergedAt            *time.Time
+	BaseRefName         string
+	HeadRefName         string
+	Author              *User
+	HeadRepositoryOwner *User
+	HeadRepository      *struct {
+		Name string
+	}
+	Body   string
+	Labels struct {
+		Nodes []IssueLabel
+	}
+	Assignees struct {
+		Nodes []User
+	}
+	Milestone      *Milestone
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer struct {
+				Typename string `json:"__typename"`
+				Login    string
+				Name     string
+				Slug     string
+			}
+		}
+	}
+	BaseRefOid  string
+	HeadRefOid  string
+	MergeCommit *struct {
+		Oid string
+	}
+	ReviewDecision string
+	Commits        struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string
+				}
+			}
+		}
+	}
+}
+
+func (node *graphQLPullRequest) pullRequest(project *Project) PullRequest {
+	pr := PullRequest{
+		Number:    node.Number,
+		Title:     node.Title,
+		State:     "open",
+		Draft:     node.IsDraft,
+		HtmlUrl:   node.URL,
+		Body:      node.Body,
+		CreatedAt: node.CreatedAt,
+		UpdatedAt: node.UpdatedAt,
+		User:      node.Author,
+		Labels:    node.Labels.Nodes,
+		Assignees: node.Assignees.Nodes,
+		Milestone: node.Milestone,
+		Base: &PullRequestSpec{
+			Label: fmt.Sprintf("%s:%s", project.Owner, node.BaseRefName),
+			Ref:   node.BaseRefName,
+			Sha:   node.BaseRefOid,
+			Repo: &Repository{
+				Name:  project.Name,
+				Owner: &User{Login: project.Owner},
+			},
+		},
+		Head: &PullRequestSpec{
+			Ref: node.HeadRefName,
+			Sha: node.HeadRefOid,
+		},
+	}
+
+	if node.State != "OPEN" {
+		pr.State = "closed"
+	}
+	if node.MergedAt != nil {
+		pr.MergedAt = *node.MergedAt
+	}
+	if pr.User == nil {
+		pr.User = &User{Login: "ghost"}
+	}
+	if node.MergeCommit != nil {
+		pr.MergeCommitSha = node.MergeCommit.Oid
+	}
+	if node.HeadRepositoryOwner != nil {
+		pr.Head.Label = fmt.Sprintf("%s:%s", node.HeadRepositoryOwner.Login, node.HeadRefName)
+		if node.HeadRepository != nil {
+			pr.Head.Repo = &Repository{
+				Name:  node.HeadRepository.Name,
+				Owner: &User{Login: node.HeadRepositoryOwner.Login},
+			}
+		}
+	}
+	for _, request := range node.ReviewRequests.Nodes {
+		reviewer := request.RequestedReviewer
+		if reviewer.Typename == "Team" {
+			pr.RequestedTeams = append(pr.RequestedTeams, Team{Name: reviewer.Name, Slug: reviewer.Slug})
+		} else if reviewer.Login != "" {
+			pr.RequestedReviewers = append(pr.RequestedReviewers, User{Login: reviewer.Login})
+		}
+	}
+
+	return pr
+}
+
+// FetchPullRequestsGraphQL lists pull requests with a single GraphQL query per
+// page of results, selecting only the optional fields named in the query. The
+// statuses are only returned if the "status" field was requested.
+func (client *Client) FetchPullRequestsGraphQL(project *Project, q PullRequestQuery, limit int, filter func(*PullRequest) bool) (pulls []PullRequest, statuses map[int]PullRequestStatus, err error) {
+	selection := []string{
+		"number title state isDraft url createdAt updatedAt mergedAt",
+		"baseRefName headRefName author { login } headRepositoryOwner { login } headRepository { name }",
+	}
+	withStatus := false
+	for _, field := range q.Fields {
+		if s, ok := pullRequestQueryFields[field]; ok {
+			selection = append(selection, s)
+			withStatus = withStatus || field == "status"
+		}
+	}
+
+	query := fmt.Sprintf(`query($owner: String!, $name: String!, $first: Int!, $after: String, $states: [PullRequestState!], $baseRefName: String, $headRefName: String, $labels: [String!], $orderBy: IssueOrder) {
+		repository(owner: $owner, name: $name) {
+			pullRequests(first: $first, after: $after, states: $states, baseRefName: $baseRefName, headRefName: $headRefName, labels: $labels, orderBy: $orderBy) {
+				nodes { %s }
+				pageInfo { hasNextPage endCursor }
+			}
+		}
+	}`, strings.Join(selection, " "))
+
+	variables := map[string]interface{}{
+		"owner": project.Owner,
+		"name":  project.Name,
+		"first": perPage(limit, 100),
+	}
+	if filter != nil {
+		variables["first"] = 100
+	}
+	if len(q.States) > 0 {
+		variables["states"] = q.States
+	}
+	if q.BaseRef != "" {
+		variables["baseRefName"] = q.BaseRef
+	}
+	if q.HeadRef != "" {
+		variables["headRefName"] = q.HeadRef
+	}
+	if len(q.Labels) > 0 {
+		variables["labels"] = q.Labels
+	}
+	if q.OrderBy != "" {
+		direction := "DESC"
+		if q.Ascending {
+			direction = "ASC"
+		}
+		variables["orderBy"] = map[string]string{"field": q.OrderBy, "direction": direction}
+	}
+
+	pulls = []PullRequest{}
+	if withStatus {
+		statuses = map[int]PullRequestStatus{}
+	}
+
+	for {
+		data := struct {
+			Repository *struct {
+				PullRequests struct {
+					Nodes    []graphQLPullRequest
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+				}
+			}
+		}{}
+		if err = client.GraphQL("fetching pull requests", query, variables, &data); err != nil {
+			return
+		}
+		if data.Repository == nil {
+			err = fmt.Errorf("Error fetching pull requests: repository %s not found", project)
+			return
+		}
+
+		for _, node := range data.Repository.PullRequests.Nodes {
+			pr := node.pullRequest(project)
+			if filter != nil && !filter(&pr) {
+				continue
+			}
+			pulls = append(pulls, pr)
+			if withStatus {
+				status := PullRequestStatus{ReviewDecision: strings.ToLower(node.ReviewDecision)}
+				if len(node.Commits.Nodes) > 0 && node.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+					status.Checks = checksStatus(node.Commits.Nodes[0].Commit.StatusCheckRollup.State)
+				}
+				statuses[pr.Number] = status
+			}
+			if limit > 0 && len(pulls) == limit {
+				return
+			}
+		}
+
+		pageInfo := data.Repository.PullRequests.PageInfo
+		if !pageInfo.HasNextPage {
+			return
+		}
+		variables["after"] = pageInfo.EndCursor
+	}
+}
+
+// EnablePullRequestAutoMerge arranges for a pull request to be merged with
+// `method` ("merge", "squash", or "rebase") once all requirements are met.
+// Blank commitTitle and commitBody leave the default commit message in place.
+func (client *Client) EnablePullRequestAutoMerge(pr *PullRequest, method, commitTitle, commitBody string) error {
+	query := `mutation($input: EnablePullRequestAutoMergeInput!) {
+		enablePullRequestAutoMerge(input: $input) { clientMutationId }
+	}`
+	input := map[string]interface{}{
+		"pullRequestId": pr.NodeId,
+		"mergeMethod":   strings.ToUpper(method),
+	}
+	if commitTitle != "" {
+		input["commitHeadline"] = commitTitle
+	}
+	if commitBody != "" {
+		input["commitBody"] = commitBody
+	}
+
+	return client.GraphQL("enabling auto-merge", query, map[string]interface{}{"input": input}, nil)
+}
+
+// UpdatePullRequestBranch brings the head branch of a pull request up to date
+// with its base using `method` ("merge" or "rebase"). The update fails if the
+// head branch has moved since pr was fetched.
+func (client *Client) UpdatePullRequestBranch(pr *PullRequest, method string) error {
+	query := `mutation($input: UpdatePullRequestBranchInput!) {
+		updatePullRequestBranch(input: $input) { clientMutationId }
+	}`
+	input := map[string]interface{}{
+		"pullRequestId":   pr.NodeId,
+		"updateMethod":    strings.ToUpper(method),
+		"expectedHeadOid": pr.Head.Sha,
+	}
+
+	return client.GraphQL("updating pull request branch", query, map[string]interface{}{"input": input}, nil)
+}
+
+// LinkedIssue is an issue that a pull request will close when merged.
+type LinkedIssue struct {
+	Number     int
+	Title      string
+	State      string
+	URL        string
+	Repository struct {
+		NameWithOwner string
+	}
+}
+
+// PullRequestClosingIssues lists the issues linked to a pull request, either
+// with closing keywords in its description or manually on GitHub.
+func (client *Client) PullRequestClosingIssues(project *Project, prNumber int) (issues []LinkedIssue, err error) {
+	query := `query($owner: String!, $name: String!, $number: Int!) {
+		repository(owner: $owner, name: $name) {
+			pullRequest(number: $number) {
+				closingIssuesReferences(first: 100) {
+					nodes { number title state url repository { nameWithOwner } }
+				}
+			}
+		}
+	}`
+	variables := map[string]interface{}{
+		"owner":  project.Owner,
+		"name":   project.Name,
+		"number": prNumber,
+	}
+
+	data := struct {
+		Repository *struct {
+			PullRequest *struct {
+				ClosingIssuesReferences struct {
+					Nodes []LinkedIssue
+				}
+			}
+		}
+	}{}
+	if err = client.GraphQL("fetching linked issues", query, variables, &data); err != nil {
+		return
+	}
+	if data.Repository == nil || data.Repository.PullRequest == nil {
+		err = fmt.Errorf("Error fetching linked issues: pull request #%d not found in %s", prNumber, project)
+		return
+	}
+
+	issues = data.Repository.PullRequest.ClosingIssuesReferences.Nodes
+	return
+}
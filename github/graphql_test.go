@@ -0,0 +1,49 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestGraphQLErrors_Error(t *testing.T) {
+	errs := GraphQLErrors{
+		{Type: "NOT_FOUND", Message: "Could not resolve to a node with the global id of 'X'"},
+		{Type: "FORBIDDEN", Message: "Resource not accessible by integration"},
+	}
+	assert.Equal(t, "Could not resolve to a node with the global id of 'X'\nResource not accessible by integration", errs.Error())
+}
+
+func TestGraphQLPullRequest_PullRequest(t *testing.T) {
+	project := &Project{Owner: "github", Name: "hub", Host: "github.com"}
+
+	node := graphQLPullRequest{
+		Number:              12,
+		State:               "MERGED",
+		HeadRefName:         "patch-1",
+		BaseRefName:         "master",
+		HeadRepositoryOwner: &User{Login: "mislav"},
+		HeadRepository:      &struct{ Name string }{Name: "hub"},
+	}
+	err := json.Unmarshal([]byte(`{"nodes": [
+		{"requestedReviewer": {"__typename": "User", "login": "josh"}},
+		{"requestedReviewer": {"__typename": "Team", "name": "Hubbers", "slug": "hubbers"}}
+	]}`), &node.ReviewRequests)
+	assert.Equal(t, nil, err)
+	pr := node.pullRequest(project)
+
+	assert.Equal(t, "closed", pr.State)
+	assert.Equal(t, "ghost", pr.User.Login)
+	assert.Equal(t, "mislav:patch-1", pr.Head.Label)
+	assert.Equal(t, "github", pr.Base.Repo.Owner.Login)
+	assert.Equal(t, false, pr.IsSameRepo())
+	assert.Equal(t, true, pr.HasRequestedReviewer("josh"))
+	assert.Equal(t, true, pr.HasRequestedTeam("hubbers"))
+
+	node.HeadRepositoryOwner = &User{Login: "github"}
+	node.State = "OPEN"
+	pr = node.pullRequest(project)
+	assert.Equal(t, "open", pr.State)
+	assert.Equal(t, true, pr.IsSameRepo())
+}
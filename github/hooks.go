@@ -0,0 +1,173 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type Hook struct {
+	Id     int64      `json:"id"`
+	Name   string     `json:"name"`
+	Active bool       `json:"active"`
+	Events []string   `json:"events"`
+	Config HookConfig `json:"config"`
+}
+
+type HookConfig struct {
+	Url         string `json:"url"`
+	ContentType string `json:"content_type"`
+}
+
+type HookDelivery struct {
+	Id          int64     `json:"id"`
+	Guid        string    `json:"guid"`
+	DeliveredAt time.Time `json:"delivered_at"`
+	Redelivery  bool      `json:"redelivery"`
+	Duration    float64   `json:"duration"`
+	Status      string    `json:"status"`
+	StatusCode  int       `json:"status_code"`
+	Event       string    `json:"event"`
+	Action      string    `json:"action"`
+}
+
+// HookDeliveryRequest is the HTTP request that GitHub made for a delivery.
+type HookDeliveryRequest struct {
+	Headers map[string]string `json:"headers"`
+	Payload json.RawMessage   `json:"payload"`
+}
+
+func hooksPath(project *Project) string {
+	return fmt.Sprintf("repos/%s/%s/hooks", project.Owner, project.Name)
+}
+
+func (client *Client) Hooks(project *Project) (hooks []Hook, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := hooksPath(project) + "?per_page=100"
+	hooks = []Hook{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching webhooks", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []Hook{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		hooks = append(hooks, page...)
+	}
+
+	return
+}
+
+func (client *Client) CreateHook(project *Project, params map[string]interface{}) (hook *Hook, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(hooksPath(project), params)
+	if err = checkStatus(201, "creating webhook", res, err); err != nil {
+		return
+	}
+
+	hook = &Hook{}
+	err = res.Unmarshal(hook)
+	return
+}
+
+func (client *Client) DeleteHook(project *Project, id int64) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Delete(fmt.Sprintf("%s/%d", hooksPath(project), id))
+	return checkStatus(204, "deleting webhook", res, err)
+}
+
+// PingHook has GitHub send a "ping" event to the webhook.
+func (client *Client) PingHook(project *Project, id int64) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("%s/%d/pings", hooksPath(project), id), map[string]interface{}{})
+	return checkStatus(204, "pinging webhook", res, err)
+}
+
+// HookDeliveries lists the recent deliveries of a webhook, most recent first.
+func (client *Client) HookDeliveries(project *Project, id int64, limit int) (deliveries []HookDelivery, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("%s/%d/deliveries?per_page=%d", hooksPath(project), id, perPage(limit, 100))
+	deliveries = []HookDelivery{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching webhook deliveries", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []HookDelivery{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, delivery := range page {
+			deliveries = append(deliveries, delivery)
+			if limit > 0 && len(deliveries) == limit {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// HookDeliveryRequest fetches the headers and payload of a delivery.
+func (client *Client) HookDeliveryRequest(project *Project, id, deliveryId int64) (request *HookDeliveryRequest, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("%s/%d/deliveries/%d", hooksPath(project), id, deliveryId))
+	if err = checkStatus(200, "fetching webhook delivery", res, err); err != nil {
+		return
+	}
+
+	delivery := struct {
+		Request *HookDeliveryRequest `json:"request"`
+	}{}
+	err = res.Unmarshal(&delivery)
+	return delivery.Request, err
+}
+
+// RedeliverHook has GitHub send the payload of a previous delivery again.
+func (client *Client) RedeliverHook(project *Project, id, deliveryId int64) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("%s/%d/deliveries/%d/attempts", hooksPath(project), id, deliveryId), map[string]interface{}{})
+	if err = checkStatus(202, "redelivering webhook", res, err); err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
@@ -50,12 +50,22 @@ func knownGitHubHosts() []string {
 		}
 	}
 
+	// hosts that the user has authenticated with are GitHub hosts, too
+	for _, h := range CurrentConfig().Hosts {
+		if h.Host != "" {
+			hosts = append(hosts, h.Host)
+		}
+	}
+
 	cachedHosts = hosts
 	return hosts
 }
 
 func DefaultGitHubHost() string {
 	defaultHost := GitHubHostEnv
+	if defaultHost == "" {
+		defaultHost = CurrentConfig().Settings["default_host"]
+	}
 	if defaultHost == "" {
 		defaultHost = GitHubHost
 	}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -18,18 +20,26 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/github/hub/ui"
 	"github.com/github/hub/utils"
+	"github.com/mitchellh/go-homedir"
 	"golang.org/x/net/http/httpproxy"
 )
 
 const apiPayloadVersion = "application/vnd.github.v3+json;charset=utf-8"
 const patchMediaType = "application/vnd.github.v3.patch;charset=utf-8"
+const diffMediaType = "application/vnd.github.v3.diff;charset=utf-8"
 const textMediaType = "text/plain;charset=utf-8"
 const checksType = "application/vnd.github.antiope-preview+json;charset=utf-8"
 const draftsType = "application/vnd.github.shadow-cat-preview+json;charset=utf-8"
+const protectionType = "application/vnd.github.luke-cage-preview+json, application/vnd.github.zzzax-preview+json;charset=utf-8"
+const topicsType = "application/vnd.github.mercy-preview+json;charset=utf-8"
+const communityProfileType = "application/vnd.github.black-panther-preview+json;charset=utf-8"
+const reactionsType = "application/vnd.github.squirrel-girl-preview+json;charset=utf-8"
+const templatesType = "application/vnd.github.baptiste-preview+json;charset=utf-8"
 const cacheVersion = 2
 
 var inspectHeaders = []string{
@@ -38,20 +48,43 @@ var inspectHeaders = []string{
 	"Location",
 	"Link",
 	"Accept",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
 }
 
+var (
+	redactedHeaderRegexp = regexp.MustCompile("(?i)^(basic|token|bearer) (.+)")
+	redactedBodyRegexp   = regexp.MustCompile(`("(?:token|hashed_token|password|client_secret|encrypted_value|secret)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	redactedQueryParams  = []string{"access_token", "client_secret", "token"}
+)
+
 type verboseTransport struct {
-	Transport   *http.Transport
+	Transport   http.RoundTripper
 	Verbose     bool
 	OverrideURL *url.URL
 	Out         io.Writer
 	Colorized   bool
+	TraceFile   string
+}
+
+// traceEntry is a line of the JSONL log written to HUB_TRACE_FILE.
+type traceEntry struct {
+	Time      time.Time         `json:"time"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Status    int               `json:"status,omitempty"`
+	Duration  float64           `json:"duration_ms"`
+	RateLimit map[string]string `json:"rate_limit,omitempty"`
+	Error     string            `json:"error,omitempty"`
 }
 
 func (t *verboseTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
 	if t.Verbose {
 		t.dumpRequest(req)
 	}
+	started := time.Now()
+	method, reqURL := req.Method, redactURL(req.URL)
 
 	if t.OverrideURL != nil {
 		port := "80"
@@ -68,16 +101,78 @@ func (t *verboseTransport) RoundTrip(req *http.Request) (resp *http.Response, er
 	}
 
 	resp, err = t.Transport.RoundTrip(req)
+	elapsed := time.Since(started)
 
 	if err == nil && t.Verbose {
-		t.dumpResponse(resp)
+		t.dumpResponse(resp, elapsed)
+	}
+	if t.TraceFile != "" {
+		t.trace(method, reqURL, started, elapsed, resp, err)
 	}
 
 	return
 }
 
+func (t *verboseTransport) trace(method, reqURL string, started time.Time, elapsed time.Duration, resp *http.Response, err error) {
+	entry := traceEntry{
+		Time:     started.UTC(),
+		Method:   method,
+		URL:      reqURL,
+		Duration: float64(elapsed.Microseconds()) / 1000,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		for _, name := range []string{"Limit", "Remaining", "Reset"} {
+			if value := resp.Header.Get("X-RateLimit-" + name); value != "" {
+				if entry.RateLimit == nil {
+					entry.RateLimit = map[string]string{}
+				}
+				entry.RateLimit[strings.ToLower(name)] = value
+			}
+		}
+	}
+
+	line, e := json.Marshal(entry)
+	if e != nil {
+		return
+	}
+	f, e := os.OpenFile(t.TraceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if e != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(append(line, '\n'))
+}
+
+// redactURL returns the URL with the values of credential query parameters
+// replaced.
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for _, name := range redactedQueryParams {
+		if query.Get(name) != "" {
+			query.Set(name, "[REDACTED]")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+
+	dup := *u
+	dup.RawQuery = query.Encode()
+	return dup.String()
+}
+
+// redactBody replaces the values of credential fields in a JSON body.
+func redactBody(body string) string {
+	return redactedBodyRegexp.ReplaceAllString(body, `$1"[REDACTED]"`)
+}
+
 func (t *verboseTransport) dumpRequest(req *http.Request) {
-	info := fmt.Sprintf("> %s %s://%s%s", req.Method, req.URL.Scheme, req.URL.Host, req.URL.RequestURI())
+	info := fmt.Sprintf("> %s %s", req.Method, redactURL(req.URL))
 	t.verbosePrintln(info)
 	t.dumpHeaders(req.Header, ">")
 	body := t.dumpBody(req.Body)
@@ -87,7 +182,7 @@ func (t *verboseTransport) dumpRequest(req *http.Request) {
 	}
 }
 
-func (t *verboseTransport) dumpResponse(resp *http.Response) {
+func (t *verboseTransport) dumpResponse(resp *http.Response, elapsed time.Duration) {
 	info := fmt.Sprintf("< HTTP %d", resp.StatusCode)
 	t.verbosePrintln(info)
 	t.dumpHeaders(resp.Header, "<")
@@ -96,6 +191,7 @@ func (t *verboseTransport) dumpResponse(resp *http.Response) {
 		// reset body since it's been read
 		resp.Body = body
 	}
+	t.verbosePrintln(fmt.Sprintf("< (%s)", elapsed.Round(time.Millisecond)))
 }
 
 func (t *verboseTransport) dumpHeaders(header http.Header, indent string) {
@@ -106,9 +202,8 @@ func (t *verboseTransport) dumpHeaders(header http.Header, indent string) {
 			}
 			for _, v := range vv {
 				if v != "" {
-					r := regexp.MustCompile("(?i)^(basic|token) (.+)")
-					if r.MatchString(v) {
-						v = r.ReplaceAllString(v, "$1 [REDACTED]")
+					if redactedHeaderRegexp.MatchString(v) {
+						v = redactedHeaderRegexp.ReplaceAllString(v, "$1 [REDACTED]")
 					}
 
 					info := fmt.Sprintf("%s %s: %s", indent, name, v)
@@ -130,7 +225,7 @@ func (t *verboseTransport) dumpBody(body io.ReadCloser) io.ReadCloser {
 	utils.Check(err)
 
 	if buf.Len() > 0 {
-		t.verbosePrintln(buf.String())
+		t.verbosePrintln(redactBody(buf.String()))
 	}
 
 	return ioutil.NopCloser(buf)
@@ -144,11 +239,50 @@ func (t *verboseTransport) verbosePrintln(msg string) {
 	fmt.Fprintln(t.Out, msg)
 }
 
+// TransportFactory creates the transport that carries API requests to host.
+// It allows routing API traffic through something other than a direct
+// connection, e.g. to record or audit it.
+type TransportFactory func(host *Host) (http.RoundTripper, error)
+
+// DefaultTransport connects to host directly or through a unix socket, and
+// applies the proxy and TLS settings for host. The socket is read from
+// HUB_UNIX_SOCKET or, failing that, the "unix_socket" key of host.
+func DefaultTransport(host *Host) (http.RoundTripper, error) {
+	unixSocket := os.Getenv("HUB_UNIX_SOCKET")
+	if unixSocket == "" {
+		unixSocket = os.ExpandEnv(host.UnixSocket)
+	}
+	t := newTransport(unixSocket)
+	if err := configureTransport(t, host.Host); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
 func newHttpClient(testHost string, verbose bool, unixSocket string) *http.Client {
+	return newHttpClientWithTransport(testHost, verbose, newTransport(unixSocket))
+}
+
+func newHttpClientWithTransport(testHost string, verbose bool, transport http.RoundTripper) *http.Client {
 	var testURL *url.URL
 	if testHost != "" {
 		testURL, _ = url.Parse(testHost)
 	}
+	tr := &verboseTransport{
+		Transport:   transport,
+		Verbose:     verbose,
+		OverrideURL: testURL,
+		Out:         ui.Stderr,
+		Colorized:   ui.IsTerminal(os.Stderr),
+		TraceFile:   os.Getenv("HUB_TRACE_FILE"),
+	}
+
+	return &http.Client{
+		Transport: tr,
+	}
+}
+
+func newTransport(unixSocket string) *http.Transport {
 	var httpTransport *http.Transport
 	if unixSocket != "" {
 		dialFunc := func(network, addr string) (net.Conn, error) {
@@ -174,17 +308,51 @@ func newHttpClient(testHost string, verbose bool, unixSocket string) *http.Clien
 			TLSHandshakeTimeout: 10 * time.Second,
 		}
 	}
-	tr := &verboseTransport{
-		Transport:   httpTransport,
-		Verbose:     verbose,
-		OverrideURL: testURL,
-		Out:         ui.Stderr,
-		Colorized:   ui.IsTerminal(os.Stderr),
+	return httpTransport
+}
+
+// configureTransport applies the "proxy", "ca_cert", and "skip_tls_verify"
+// settings for host. A configured proxy takes precedence over the proxy
+// environment variables.
+func configureTransport(t *http.Transport, host string) error {
+	config := CurrentConfig()
+
+	if proxy := config.Setting(host, "proxy"); proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil || proxyURL.Host == "" || !stringInSlice(proxyURL.Scheme, []string{"http", "https", "socks5"}) {
+			return fmt.Errorf("invalid proxy for %s: %q (expected an http, https, or socks5 URL)", host, proxy)
+		}
+		t.Proxy = http.ProxyURL(proxyURL)
 	}
 
-	return &http.Client{
-		Transport: tr,
+	caCert := config.Setting(host, "ca_cert")
+	skipVerify := config.Setting(host, "skip_tls_verify") == "true"
+	if caCert == "" && !skipVerify {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: skipVerify}
+	if caCert != "" {
+		caCert, err := homedir.Expand(os.ExpandEnv(caCert))
+		if err != nil {
+			return err
+		}
+		pem, err := ioutil.ReadFile(caCert)
+		if err != nil {
+			return fmt.Errorf("error reading CA certificate for %s: %s", host, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no PEM certificates found in %s", caCert)
+		}
+		tlsConfig.RootCAs = pool
 	}
+	t.TLSClientConfig = tlsConfig
+
+	return nil
 }
 
 func cloneRequest(req *http.Request) *http.Request {
@@ -212,6 +380,7 @@ type simpleClient struct {
 	rootUrl        *url.URL
 	PrepareRequest func(*http.Request)
 	CacheTTL       int
+	ctx            context.Context
 }
 
 func (c *simpleClient) performRequest(method, path string, body io.Reader, configure func(*http.Request)) (*simpleResponse, error) {
@@ -234,6 +403,9 @@ func (c *simpleClient) performRequestUrl(method string, url *url.URL, body io.Re
 	if err != nil {
 		return
 	}
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
 	if c.PrepareRequest != nil {
 		c.PrepareRequest(req)
 	}
@@ -250,12 +422,45 @@ func (c *simpleClient) performRequestUrl(method string, url *url.URL, body io.Re
 		return
 	}
 
+	var storedResponse *http.Response
+	conditional := c.CacheTTL <= 0 && canRevalidate(req)
+	if conditional {
+		if f := etagCacheFile(key); f != "" {
+			storedResponse = readCachedResponse(f)
+		}
+		if storedResponse != nil {
+			if etag := storedResponse.Header.Get("ETag"); etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if lastModified := storedResponse.Header.Get("Last-Modified"); lastModified != "" {
+				req.Header.Set("If-Modified-Since", lastModified)
+			}
+		}
+	}
+
+	atomic.AddInt32(&requestsInFlight, 1)
 	httpResponse, err := c.httpClient.Do(req)
+	atomic.AddInt32(&requestsInFlight, -1)
 	if err != nil {
+		err = requestError(req.Context(), c.httpClient.Timeout, err)
+		return
+	}
+
+	recordRateLimit(httpResponse)
+	if storedResponse != nil && httpResponse.StatusCode == http.StatusNotModified {
+		httpResponse.Body.Close()
+		storedResponse.Request = req
+		res = &simpleResponse{storedResponse}
 		return
 	}
 
 	c.cacheWrite(key, httpResponse)
+	if conditional && httpResponse.StatusCode == http.StatusOK &&
+		(httpResponse.Header.Get("ETag") != "" || httpResponse.Header.Get("Last-Modified") != "") {
+		if f := etagCacheFile(key); f != "" {
+			writeCachedResponse(f, httpResponse, 0700)
+		}
+	}
 	res = &simpleResponse{httpResponse}
 
 	return
@@ -269,6 +474,18 @@ func canCache(req *http.Request) bool {
 	return strings.EqualFold(req.Method, "GET") || isGraphQL(req)
 }
 
+// canRevalidate reports whether a request may be answered from the ETag
+// cache after the server confirmed that the stored response is still current.
+// Revalidated requests don't count against the rate limit. Binary downloads
+// are never stored since they can be arbitrarily large.
+func canRevalidate(req *http.Request) bool {
+	return strings.EqualFold(req.Method, "GET") &&
+		req.Header.Get("Accept") != "application/octet-stream" &&
+		req.Header.Get("If-None-Match") == "" &&
+		req.Header.Get("If-Modified-Since") == "" &&
+		os.Getenv("HUB_NO_CACHE") == ""
+}
+
 func (c *simpleClient) cacheRead(key string, req *http.Request) (res *http.Response) {
 	if c.CacheTTL > 0 && canCache(req) {
 		f := cacheFile(key)
@@ -279,41 +496,46 @@ func (c *simpleClient) cacheRead(key string, req *http.Request) (res *http.Respo
 		if time.Since(cacheInfo.ModTime()).Seconds() > float64(c.CacheTTL) {
 			return
 		}
-		cf, err := os.Open(f)
-		if err != nil {
-			return
-		}
-		defer cf.Close()
+		res = readCachedResponse(f)
+	}
+	return
+}
 
-		cb, err := ioutil.ReadAll(cf)
-		if err != nil {
-			return
-		}
-		parts := strings.SplitN(string(cb), "\r\n\r\n", 2)
-		if len(parts) < 2 {
-			return
-		}
+func readCachedResponse(f string) (res *http.Response) {
+	cf, err := os.Open(f)
+	if err != nil {
+		return
+	}
+	defer cf.Close()
 
-		res = &http.Response{
-			Body:   ioutil.NopCloser(bytes.NewBufferString(parts[1])),
-			Header: http.Header{},
-		}
-		headerLines := strings.Split(parts[0], "\r\n")
-		if len(headerLines) < 1 {
-			return
-		}
-		if proto := strings.SplitN(headerLines[0], " ", 3); len(proto) >= 3 {
-			res.Proto = proto[0]
-			res.Status = fmt.Sprintf("%s %s", proto[1], proto[2])
-			if code, _ := strconv.Atoi(proto[1]); code > 0 {
-				res.StatusCode = code
-			}
+	cb, err := ioutil.ReadAll(cf)
+	if err != nil {
+		return
+	}
+	parts := strings.SplitN(string(cb), "\r\n\r\n", 2)
+	if len(parts) < 2 {
+		return
+	}
+
+	res = &http.Response{
+		Body:   ioutil.NopCloser(bytes.NewBufferString(parts[1])),
+		Header: http.Header{},
+	}
+	headerLines := strings.Split(parts[0], "\r\n")
+	if len(headerLines) < 1 {
+		return
+	}
+	if proto := strings.SplitN(headerLines[0], " ", 3); len(proto) >= 3 {
+		res.Proto = proto[0]
+		res.Status = fmt.Sprintf("%s %s", proto[1], proto[2])
+		if code, _ := strconv.Atoi(proto[1]); code > 0 {
+			res.StatusCode = code
 		}
-		for _, line := range headerLines[1:] {
-			kv := strings.SplitN(line, ":", 2)
-			if len(kv) >= 2 {
-				res.Header.Add(kv[0], strings.TrimLeft(kv[1], " "))
-			}
+	}
+	for _, line := range headerLines[1:] {
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) >= 2 {
+			res.Header.Add(kv[0], strings.TrimLeft(kv[1], " "))
 		}
 	}
 	return
@@ -321,40 +543,56 @@ func (c *simpleClient) cacheRead(key string, req *http.Request) (res *http.Respo
 
 func (c *simpleClient) cacheWrite(key string, res *http.Response) {
 	if c.CacheTTL > 0 && canCache(res.Request) && res.StatusCode < 500 && res.StatusCode != 403 {
-		bodyCopy := &bytes.Buffer{}
-		bodyReplacement := readCloserCallback{
-			Reader: io.TeeReader(res.Body, bodyCopy),
-			Closer: res.Body,
-			Callback: func() {
-				f := cacheFile(key)
-				err := os.MkdirAll(filepath.Dir(f), 0771)
-				if err != nil {
-					return
-				}
-				cf, err := os.OpenFile(f, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
-				if err != nil {
-					return
-				}
-				defer cf.Close()
-				fmt.Fprintf(cf, "%s %s\r\n", res.Proto, res.Status)
-				res.Header.Write(cf)
-				fmt.Fprintf(cf, "\r\n")
-				io.Copy(cf, bodyCopy)
-			},
-		}
-		res.Body = &bodyReplacement
+		writeCachedResponse(cacheFile(key), res, 0771)
 	}
 }
 
+// writeCachedResponse arranges for the response to be stored to f once its
+// body was read completely. Missing directories are created with dirMode.
+func writeCachedResponse(f string, res *http.Response, dirMode os.FileMode) {
+	bodyCopy := &bytes.Buffer{}
+	bodyReplacement := readCloserCallback{
+		Reader: io.TeeReader(res.Body, bodyCopy),
+		Closer: res.Body,
+		Callback: func() {
+			err := os.MkdirAll(filepath.Dir(f), dirMode)
+			if err != nil {
+				return
+			}
+			cf, err := os.OpenFile(f, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return
+			}
+			defer cf.Close()
+			fmt.Fprintf(cf, "%s %s\r\n", res.Proto, res.Status)
+			res.Header.Write(cf)
+			fmt.Fprintf(cf, "\r\n")
+			io.Copy(cf, bodyCopy)
+		},
+	}
+	res.Body = &bodyReplacement
+}
+
 type readCloserCallback struct {
 	Callback func()
 	Closer   io.Closer
 	io.Reader
+	eof bool
+}
+
+func (rc *readCloserCallback) Read(p []byte) (n int, err error) {
+	n, err = rc.Reader.Read(p)
+	if err == io.EOF {
+		rc.eof = true
+	}
+	return
 }
 
+// Close invokes the callback only if the whole body was read, so that a
+// partially read response never gets cached.
 func (rc *readCloserCallback) Close() error {
 	err := rc.Closer.Close()
-	if err == nil {
+	if err == nil && rc.eof {
 		rc.Callback()
 	}
 	return err
@@ -391,6 +629,18 @@ func cacheFile(key string) string {
 	return path.Join(os.TempDir(), "hub", "api", key)
 }
 
+// etagCacheFile is where the response for `key` is kept for revalidation.
+// Unlike the '--cache' files, these outlive a single session and may hold
+// private data, so they go to the user cache directory instead of a shared
+// temporary one.
+func etagCacheFile(key string) string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cacheDir, "hub", "etag", key)
+}
+
 func (c *simpleClient) jsonRequest(method, path string, body interface{}, configure func(*http.Request)) (*simpleResponse, error) {
 	json, err := json.Marshal(body)
 	if err != nil {
@@ -434,7 +684,9 @@ func (c *simpleClient) PatchJSON(path string, payload interface{}) (*simpleRespo
 	return c.jsonRequest("PATCH", path, payload, nil)
 }
 
-func (c *simpleClient) PostFile(path, filename string) (*simpleResponse, error) {
+// PostFile uploads the contents of filename. If progress isn't nil, the bytes
+// are copied to it as they are sent.
+func (c *simpleClient) PostFile(path, filename string, progress io.Writer) (*simpleResponse, error) {
 	stat, err := os.Stat(filename)
 	if err != nil {
 		return nil, err
@@ -446,7 +698,12 @@ func (c *simpleClient) PostFile(path, filename string) (*simpleResponse, error)
 	}
 	defer file.Close()
 
-	return c.performRequest("POST", path, file, func(req *http.Request) {
+	var body io.Reader = file
+	if progress != nil {
+		body = io.TeeReader(file, progress)
+	}
+
+	return c.performRequest("POST", path, body, func(req *http.Request) {
 		req.ContentLength = stat.Size()
 		req.Header.Set("Content-Type", "application/octet-stream")
 	})
@@ -516,6 +773,19 @@ func (res *simpleResponse) ErrorInfo() (msg *errorInfo, err error) {
 	return
 }
 
+// FormatError describes an unsuccessful response using the error message
+// from its body, if any.
+func (res *simpleResponse) FormatError(action string) error {
+	if errInfo, err := res.ErrorInfo(); err == nil {
+		return FormatError(action, errInfo)
+	}
+	reason := strings.TrimPrefix(res.Status, strconv.Itoa(res.StatusCode)+" ")
+	return &apiError{
+		message:  fmt.Sprintf("Error %s: %s (HTTP %d)", action, reason, res.StatusCode),
+		exitCode: statusExitCode(res.Response),
+	}
+}
+
 func (res *simpleResponse) Link(name string) string {
 	linkVal := res.Header.Get("Link")
 	re := regexp.MustCompile(`<([^>]+)>; rel="([^"]+)"`)
@@ -2,6 +2,8 @@ package github
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -10,6 +12,8 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/bmizerany/assert"
@@ -82,6 +86,29 @@ func TestNewHttpClient_UnixSocket(t *testing.T) {
 	assert.Equal(t, "unix-socket-works", string(result))
 }
 
+func TestDefaultTransport_UnixSocket(t *testing.T) {
+	sock := "/tmp/hub-go-env.sock"
+	s := setupTestServer(sock)
+	defer s.Close()
+
+	s.HandleFunc("/unix-socket", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("unix-socket-works"))
+	})
+
+	os.Setenv("HUB_CONFIG", filepath.Join(os.TempDir(), "hub-nonexistent-config"))
+	defer os.Unsetenv("HUB_CONFIG")
+	os.Setenv("HUB_UNIX_SOCKET", sock)
+	defer os.Unsetenv("HUB_UNIX_SOCKET")
+	configLoadedFrom = ""
+
+	tr, err := DefaultTransport(&Host{Host: "github.com", UnixSocket: "/tmp/hub-go-missing.sock"})
+	assert.Equal(t, nil, err)
+	resp, err := (&http.Client{Transport: tr}).Get(fmt.Sprintf("%s/unix-socket", s.URL.String()))
+	assert.Equal(t, nil, err)
+	result, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, "unix-socket-works", string(result))
+}
+
 func TestVerboseTransport_VerbosePrintln(t *testing.T) {
 	var b bytes.Buffer
 	tr := &verboseTransport{
@@ -92,3 +119,135 @@ func TestVerboseTransport_VerbosePrintln(t *testing.T) {
 	tr.verbosePrintln("foo")
 	assert.Equal(t, "\033[36mfoo\033[0m\n", b.String())
 }
+
+func TestVerboseTransport_Redaction(t *testing.T) {
+	u, _ := url.Parse("https://api.github.com/user?access_token=SECRET&page=2")
+	assert.Equal(t, "https://api.github.com/user?access_token=%5BREDACTED%5D&page=2", redactURL(u))
+
+	u, _ = url.Parse("https://api.github.com/user?page=2")
+	assert.Equal(t, "https://api.github.com/user?page=2", redactURL(u))
+
+	body := `{"id":1,"token":"abc\"def","hashed_token": "123","name":"token"}`
+	assert.Equal(t, `{"id":1,"token":"[REDACTED]","hashed_token": "[REDACTED]","name":"token"}`, redactBody(body))
+}
+
+func TestVerboseTransport_TraceFile(t *testing.T) {
+	s := setupTestServer("")
+	defer s.Close()
+	s.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	dir, _ := ioutil.TempDir("", "hub-trace")
+	defer os.RemoveAll(dir)
+	traceFile := filepath.Join(dir, "trace.jsonl")
+
+	tr := &verboseTransport{
+		Transport: &http.Transport{},
+		TraceFile: traceFile,
+	}
+	c := &http.Client{Transport: tr}
+	_, err := c.Get(s.URL.String() + "/user?token=SECRET")
+	assert.Equal(t, nil, err)
+
+	content, _ := ioutil.ReadFile(traceFile)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Equal(t, 1, len(lines))
+
+	var entry traceEntry
+	assert.Equal(t, nil, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "GET", entry.Method)
+	assert.Equal(t, s.URL.String()+"/user?token=%5BREDACTED%5D", entry.URL)
+	assert.Equal(t, http.StatusTeapot, entry.Status)
+	assert.Equal(t, "4999", entry.RateLimit["remaining"])
+}
+
+func TestSimpleClient_ETagCache(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "hub-etag")
+	defer os.RemoveAll(dir)
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	defer os.Setenv("XDG_CACHE_HOME", os.Getenv("XDG_CACHE_HOME"))
+	os.Setenv("HOME", dir)
+	os.Setenv("XDG_CACHE_HOME", dir)
+
+	s := setupTestServer("")
+	defer s.Close()
+	requests := 0
+	s.HandleFunc("/issues", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"number":1}]`))
+	})
+
+	c := &simpleClient{
+		httpClient: &http.Client{},
+		rootUrl:    s.URL,
+	}
+	for i := 0; i < 2; i++ {
+		res, err := c.Get("issues")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 200, res.StatusCode)
+		issues := []Issue{}
+		assert.Equal(t, nil, res.Unmarshal(&issues))
+		assert.Equal(t, 1, len(issues))
+	}
+	assert.Equal(t, 2, requests)
+
+	cacheDir, _ := os.UserCacheDir()
+	info, err := os.Stat(filepath.Join(cacheDir, "hub", "etag"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	os.Setenv("HUB_NO_CACHE", "1")
+	defer os.Unsetenv("HUB_NO_CACHE")
+	res, err := c.Get("issues")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 200, res.StatusCode)
+	res.Body.Close()
+	assert.Equal(t, 3, requests)
+}
+
+func TestConfigureTransport(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("tls-works"))
+	}))
+	defer s.Close()
+
+	dir, _ := ioutil.TempDir("", "hub-ca")
+	defer os.RemoveAll(dir)
+	caCert := filepath.Join(dir, "ca.pem")
+	ioutil.WriteFile(caCert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}), 0600)
+
+	c := &Config{Hosts: []*Host{{Host: "ghe.example.com", User: "mislav"}}}
+	c.SetSetting("ghe.example.com", "ca_cert", caCert)
+	c.SetSetting("ghe.example.com", "proxy", "ftp://proxy.example.com")
+	configFile := filepath.Join(dir, "hub")
+	assert.Equal(t, nil, newConfigService().Save(configFile, c))
+	os.Setenv("HUB_CONFIG", configFile)
+	defer os.Unsetenv("HUB_CONFIG")
+
+	tr := &http.Transport{}
+	err := configureTransport(tr, "ghe.example.com")
+	assert.Equal(t, `invalid proxy for ghe.example.com: "ftp://proxy.example.com" (expected an http, https, or socks5 URL)`, err.Error())
+
+	c.UnsetSetting("ghe.example.com", "proxy")
+	assert.Equal(t, nil, newConfigService().Save(configFile, c))
+	configLoadedFrom = ""
+
+	tr = &http.Transport{}
+	assert.Equal(t, nil, configureTransport(tr, "ghe.example.com"))
+	resp, err := (&http.Client{Transport: tr}).Get(s.URL)
+	assert.Equal(t, nil, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, "tls-works", string(body))
+
+	tr = &http.Transport{}
+	assert.Equal(t, nil, configureTransport(tr, "github.com"))
+	_, err = (&http.Client{Transport: tr}).Get(s.URL)
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,56 @@
+package github
+
+import (
+	"fmt"
+)
+
+type Invitation struct {
+	Id          int64       `json:"id"`
+	Repository  *Repository `json:"repository"`
+	Inviter     *User       `json:"inviter"`
+	Permissions string      `json:"permissions"`
+	HtmlUrl     string      `json:"html_url"`
+}
+
+// RepositoryInvitations lists the pending repository invitations of the
+// authenticated user.
+func (client *Client) RepositoryInvitations() (invitations []Invitation, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := "user/repository_invitations?per_page=100"
+	invitations = []Invitation{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching invitations", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []Invitation{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		invitations = append(invitations, page...)
+	}
+
+	return
+}
+
+func (client *Client) AcceptInvitation(id int64) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("user/repository_invitations/%d", id), map[string]interface{}{})
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return fmt.Errorf("Unable to find invitation %d", id)
+	}
+	return checkStatus(204, "accepting invitation", res, err)
+}
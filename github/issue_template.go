@@ -0,0 +1,253 @@
+package github
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const issueTemplateDir = "ISSUE_TEMPLATE"
+
+// IssueTemplateFile is one of the templates that GitHub offers to choose from
+// when opening an issue: either a Markdown template with YAML front matter or
+// a YAML issue form.
+type IssueTemplateFile struct {
+	Path      string
+	Name      string
+	About     string
+	Title     string
+	Labels    []string
+	Assignees []string
+	// Body is the Markdown content of a template. It's blank for issue forms.
+	Body string
+	// Fields are the inputs of an issue form.
+	Fields []IssueFormField
+}
+
+func (t *IssueTemplateFile) IsForm() bool {
+	return len(t.Fields) > 0
+}
+
+type IssueFormField struct {
+	Type       string `yaml:"type"`
+	ID         string `yaml:"id"`
+	Attributes struct {
+		Label       string        `yaml:"label"`
+		Description string        `yaml:"description"`
+		Placeholder string        `yaml:"placeholder"`
+		Value       string        `yaml:"value"`
+		Render      string        `yaml:"render"`
+		Multiple    bool          `yaml:"multiple"`
+		Options     []interface{} `yaml:"options"`
+	} `yaml:"attributes"`
+	Validations struct {
+		Required bool `yaml:"required"`
+	} `yaml:"validations"`
+}
+
+// OptionLabels returns the choices of a dropdown or checkboxes field.
+func (f *IssueFormField) OptionLabels() []string {
+	labels := []string{}
+	for _, option := range f.Attributes.Options {
+		switch o := option.(type) {
+		case string:
+			labels = append(labels, o)
+		case map[interface{}]interface{}:
+			labels = append(labels, fmt.Sprint(o["label"]))
+		}
+	}
+	return labels
+}
+
+type issueTemplateMeta struct {
+	Name        string      `yaml:"name"`
+	About       string      `yaml:"about"`
+	Description string      `yaml:"description"`
+	Title       string      `yaml:"title"`
+	Labels      interface{} `yaml:"labels"`
+	Assignees   interface{} `yaml:"assignees"`
+
+	Body []IssueFormField `yaml:"body"`
+}
+
+type issueTemplateConfig struct {
+	BlankIssuesEnabled *bool `yaml:"blank_issues_enabled"`
+}
+
+var frontMatterRe = regexp.MustCompile(`(?s)\A---\s*\n(.*?\n)?---\s*(?:\n|\z)`)
+
+// ReadIssueTemplates reads the templates from the `.github/ISSUE_TEMPLATE`
+// directory of workdir, sorted by file name. It also reports whether the
+// repository allows opening issues without a template.
+func ReadIssueTemplates(workdir string) (templates []*IssueTemplateFile, blankAllowed bool, err error) {
+	blankAllowed = true
+	dir := filepath.Join(workdir, githubTemplateDir, issueTemplateDir)
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		filename := filepath.Join(dir, file.Name())
+		ext := strings.ToLower(filepath.Ext(file.Name()))
+		base := strings.TrimSuffix(strings.ToLower(file.Name()), ext)
+
+		var content []byte
+		if content, err = ioutil.ReadFile(filename); err != nil {
+			return
+		}
+
+		if base == "config" && (ext == ".yml" || ext == ".yaml") {
+			config := issueTemplateConfig{}
+			if err = yaml.Unmarshal(content, &config); err != nil {
+				err = fmt.Errorf("%s: %s", filename, err)
+				return
+			}
+			if config.BlankIssuesEnabled != nil {
+				blankAllowed = *config.BlankIssuesEnabled
+			}
+			continue
+		}
+
+		var template *IssueTemplateFile
+		switch ext {
+		case ".md":
+			template, err = parseMarkdownIssueTemplate(content)
+		case ".yml", ".yaml":
+			template, err = parseIssueForm(content)
+		default:
+			continue
+		}
+		if err != nil {
+			err = fmt.Errorf("%s: %s", filename, err)
+			return
+		}
+
+		template.Path = filename
+		if template.Name == "" {
+			template.Name = strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+		}
+		templates = append(templates, template)
+	}
+
+	return
+}
+
+func parseMarkdownIssueTemplate(content []byte) (*IssueTemplateFile, error) {
+	text := strings.Replace(string(content), "\r\n", "\n", -1)
+	meta := issueTemplateMeta{}
+	if match := frontMatterRe.FindStringSubmatchIndex(text); match != nil {
+		if match[2] >= 0 {
+			if err := yaml.Unmarshal([]byte(text[match[2]:match[3]]), &meta); err != nil {
+				return nil, err
+			}
+		}
+		text = text[match[1]:]
+	}
+
+	template := newIssueTemplateFile(meta)
+	template.About = meta.About
+	template.Body = strings.TrimSpace(text)
+	return template, nil
+}
+
+func parseIssueForm(content []byte) (*IssueTemplateFile, error) {
+	meta := issueTemplateMeta{}
+	if err := yaml.Unmarshal(content, &meta); err != nil {
+		return nil, err
+	}
+	if len(meta.Body) == 0 {
+		return nil, fmt.Errorf("issue form has no body")
+	}
+
+	template := newIssueTemplateFile(meta)
+	template.About = meta.Description
+	template.Fields = meta.Body
+	return template, nil
+}
+
+func newIssueTemplateFile(meta issueTemplateMeta) *IssueTemplateFile {
+	return &IssueTemplateFile{
+		Name:      meta.Name,
+		Title:     meta.Title,
+		Labels:    stringList(meta.Labels),
+		Assignees: stringList(meta.Assignees),
+	}
+}
+
+// stringList normalizes a YAML value that is either a list or a
+// comma-separated string.
+func stringList(value interface{}) []string {
+	items := []string{}
+	switch v := value.(type) {
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+	}
+	return items
+}
+
+// RenderIssueForm renders the answers to the fields of an issue form as
+// Markdown, in the same way GitHub does when an issue is submitted through
+// the form. Answers are keyed by the index of the field: a string for inputs,
+// textareas, and dropdowns, and a list of the checked labels for checkboxes.
+func RenderIssueForm(template *IssueTemplateFile, answers map[int]interface{}) string {
+	sections := []string{}
+	for i, field := range template.Fields {
+		if field.Type == "markdown" {
+			continue
+		}
+
+		value := ""
+		switch answer := answers[i].(type) {
+		case string:
+			value = strings.TrimSpace(answer)
+			if value != "" && field.Type == "textarea" && field.Attributes.Render != "" {
+				value = fmt.Sprintf("```%s\n%s\n```", field.Attributes.Render, value)
+			}
+		case []string:
+			if field.Type == "checkboxes" {
+				checked := map[string]bool{}
+				for _, label := range answer {
+					checked[label] = true
+				}
+				lines := []string{}
+				for _, label := range field.OptionLabels() {
+					mark := " "
+					if checked[label] {
+						mark = "X"
+					}
+					lines = append(lines, fmt.Sprintf("- [%s] %s", mark, label))
+				}
+				value = strings.Join(lines, "\n")
+			} else {
+				value = strings.Join(answer, ", ")
+			}
+		}
+		if value == "" {
+			value = "_No response_"
+		}
+
+		sections = append(sections, fmt.Sprintf("### %s\n\n%s", field.Attributes.Label, value))
+	}
+	return strings.Join(sections, "\n\n")
+}
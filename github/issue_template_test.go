@@ -0,0 +1,117 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/github/hub/fixtures"
+)
+
+var bugReportTemplate = `---
+name: Bug report
+about: Create a report to help us improve
+title: "[BUG] "
+labels: bug, needs triage
+assignees:
+  - octocat
+---
+
+**Describe the bug**
+`
+
+var featureRequestForm = `name: Feature request
+description: Suggest an idea
+labels: [enhancement]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time!
+  - type: input
+    id: summary
+    attributes:
+      label: Summary
+    validations:
+      required: true
+  - type: textarea
+    attributes:
+      label: Logs
+      render: shell
+  - type: dropdown
+    attributes:
+      label: Platform
+      options:
+        - macOS
+        - Linux
+  - type: checkboxes
+    attributes:
+      label: Checklist
+      options:
+        - label: I searched existing issues
+        - label: I read the docs
+`
+
+func addIssueTemplate(r *fixtures.TestRepo, name, content string) {
+	r.AddFile(filepath.Join("test.git", githubTemplateDir, issueTemplateDir, name), content)
+}
+
+func TestReadIssueTemplates(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	addIssueTemplate(repo, "bug_report.md", bugReportTemplate)
+	addIssueTemplate(repo, "feature.yml", featureRequestForm)
+	addIssueTemplate(repo, "config.yml", "blank_issues_enabled: false\n")
+
+	pwd, _ := os.Getwd()
+	templates, blankAllowed, err := ReadIssueTemplates(pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, blankAllowed)
+	assert.Equal(t, 2, len(templates))
+
+	bug := templates[0]
+	assert.Equal(t, "Bug report", bug.Name)
+	assert.Equal(t, "Create a report to help us improve", bug.About)
+	assert.Equal(t, "[BUG] ", bug.Title)
+	assert.Equal(t, []string{"bug", "needs triage"}, bug.Labels)
+	assert.Equal(t, []string{"octocat"}, bug.Assignees)
+	assert.Equal(t, "**Describe the bug**", bug.Body)
+	assert.Equal(t, false, bug.IsForm())
+
+	feature := templates[1]
+	assert.Equal(t, "Feature request", feature.Name)
+	assert.Equal(t, "Suggest an idea", feature.About)
+	assert.Equal(t, []string{"enhancement"}, feature.Labels)
+	assert.Equal(t, true, feature.IsForm())
+	assert.Equal(t, 5, len(feature.Fields))
+	assert.Equal(t, true, feature.Fields[1].Validations.Required)
+	assert.Equal(t, []string{"I searched existing issues", "I read the docs"}, feature.Fields[4].OptionLabels())
+}
+
+func TestReadIssueTemplates_withoutTemplates(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	pwd, _ := os.Getwd()
+	templates, blankAllowed, err := ReadIssueTemplates(pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, blankAllowed)
+	assert.Equal(t, 0, len(templates))
+}
+
+func TestRenderIssueForm(t *testing.T) {
+	template, err := parseIssueForm([]byte(featureRequestForm))
+	assert.Equal(t, nil, err)
+
+	body := RenderIssueForm(template, map[int]interface{}{
+		1: "Add dark mode",
+		2: "panic: oops",
+		4: []string{"I read the docs"},
+	})
+	expected := "### Summary\n\nAdd dark mode\n\n" +
+		"### Logs\n\n```shell\npanic: oops\n```\n\n" +
+		"### Platform\n\n_No response_\n\n" +
+		"### Checklist\n\n- [ ] I searched existing issues\n- [X] I read the docs"
+	assert.Equal(t, expected, body)
+}
@@ -0,0 +1,143 @@
+package github
+
+import (
+	"fmt"
+	"time"
+)
+
+type SSHKey struct {
+	Id        int64     `json:"id"`
+	Key       string    `json:"key"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GPGKey struct {
+	Id        int64      `json:"id"`
+	Name      string     `json:"name"`
+	KeyId     string     `json:"key_id"`
+	Emails    []GPGEmail `json:"emails"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type GPGEmail struct {
+	Email    string `json:"email"`
+	Verified bool   `json:"verified"`
+}
+
+func (client *Client) SSHKeys() (keys []SSHKey, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := "user/keys?per_page=100"
+	keys = []SSHKey{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching SSH keys", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []SSHKey{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		keys = append(keys, page...)
+	}
+
+	return
+}
+
+func (client *Client) AddSSHKey(title, key string) (sshKey *SSHKey, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON("user/keys", map[string]interface{}{
+		"title": title,
+		"key":   key,
+	})
+	if err = checkStatus(201, "adding SSH key", res, err); err != nil {
+		return
+	}
+
+	sshKey = &SSHKey{}
+	err = res.Unmarshal(sshKey)
+	return
+}
+
+func (client *Client) DeleteSSHKey(id int64) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Delete(fmt.Sprintf("user/keys/%d", id))
+	return checkStatus(204, "removing SSH key", res, err)
+}
+
+func (client *Client) GPGKeys() (keys []GPGKey, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := "user/gpg_keys?per_page=100"
+	keys = []GPGKey{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching GPG keys", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []GPGKey{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		keys = append(keys, page...)
+	}
+
+	return
+}
+
+// AddGPGKey registers an ASCII-armored public key. The name is optional.
+func (client *Client) AddGPGKey(name, armoredKey string) (gpgKey *GPGKey, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"armored_public_key": armoredKey,
+	}
+	if name != "" {
+		params["name"] = name
+	}
+	res, err := api.PostJSON("user/gpg_keys", params)
+	if err = checkStatus(201, "adding GPG key", res, err); err != nil {
+		return
+	}
+
+	gpgKey = &GPGKey{}
+	err = res.Unmarshal(gpgKey)
+	return
+}
+
+func (client *Client) DeleteGPGKey(id int64) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Delete(fmt.Sprintf("user/gpg_keys/%d", id))
+	return checkStatus(204, "removing GPG key", res, err)
+}
@@ -65,7 +65,7 @@ func (r *GitHubRepo) remotesForPublish(owner string) (remotes []Remote) {
 		}
 	}
 
-	names := OriginNamesInLookupOrder
+	names := RemotePriority()
 	for _, name := range names {
 		if _, ok := remotesMap[name]; ok {
 			continue
@@ -163,7 +163,7 @@ func (r *GitHubRepo) RemoteBranchAndProject(owner string, preferUpstream bool) (
 	shortName := branch.ShortName()
 	remotes := r.remotesForPublish(owner)
 	if preferUpstream {
-		// reverse the remote lookup order; see OriginNamesInLookupOrder
+		// reverse the remote lookup order; see RemotePriority
 		remotesInOrder := []Remote{}
 		for i := len(remotes) - 1; i >= 0; i-- {
 			remotesInOrder = append(remotesInOrder, remotes[i])
@@ -246,7 +246,9 @@ func (r *GitHubRepo) MainRemote() (*Remote, error) {
 }
 
 func (r *GitHubRepo) MainProject() (*Project, error) {
-	r.loadRemotes()
+	if err := r.loadRemotes(); err != nil {
+		return nil, err
+	}
 
 	for _, remote := range r.remotes {
 		if project, err := remote.Project(); err == nil {
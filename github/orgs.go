@@ -0,0 +1,119 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// fetchUsers fetches a paginated list of users from path, which has to have a
+// query string already.
+func (client *Client) fetchUsers(action, path string, limit int) (users []User, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path = fmt.Sprintf("%s&per_page=%d", path, perPage(limit, 100))
+	users = []User{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, action, res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []User{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, user := range page {
+			users = append(users, user)
+			if limit > 0 && len(users) == limit {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// OrgMembers lists the members of org. The role is "all", "admin", or
+// "member".
+func (client *Client) OrgMembers(org, role string, limit int) ([]User, error) {
+	path := fmt.Sprintf("orgs/%s/members?role=%s", org, url.QueryEscape(role))
+	return client.fetchUsers("fetching organization members", path, limit)
+}
+
+// TeamMembers lists the members of a team. The role is "all", "maintainer",
+// or "member".
+func (client *Client) TeamMembers(org, team, role string, limit int) ([]User, error) {
+	path := fmt.Sprintf("orgs/%s/teams/%s/members?role=%s", org, team, url.QueryEscape(role))
+	return client.fetchUsers("fetching team members", path, limit)
+}
+
+func (client *Client) Teams(org string, limit int) (teams []Team, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("orgs/%s/teams?per_page=%d", org, perPage(limit, 100))
+	teams = []Team{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching teams", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []Team{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, team := range page {
+			teams = append(teams, team)
+			if limit > 0 && len(teams) == limit {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// AddTeamMember adds user to a team with the role "member" or "maintainer".
+// Users who aren't members of the organization yet are invited to it, in
+// which case the returned state is "pending" rather than "active".
+func (client *Client) AddTeamMember(org, team, user, role string) (state string, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.jsonRequest("PUT", fmt.Sprintf("orgs/%s/teams/%s/memberships/%s", org, team, user), map[string]interface{}{
+		"role": role,
+	}, nil)
+	if err = checkStatus(200, "adding team member", res, err); err != nil {
+		return
+	}
+
+	membership := struct {
+		State string `json:"state"`
+	}{}
+	err = res.Unmarshal(&membership)
+	return membership.State, err
+}
+
+func (client *Client) RemoveTeamMember(org, team, user string) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Delete(fmt.Sprintf("orgs/%s/teams/%s/memberships/%s", org, team, user))
+	return checkStatus(204, "removing team member", res, err)
+}
@@ -0,0 +1,62 @@
+package github
+
+import "sync"
+
+// ParallelFetchLimit is the number of API requests that commands make at once
+// when they have many independent ones to make. GitHub discourages more than
+// a few concurrent requests per user, which can trigger secondary rate limits.
+const ParallelFetchLimit = 4
+
+// ParallelFetch calls fetch for each index from 0 to n-1, running at most
+// limit calls at a time, and waits for all of them to return. Results are
+// meant to be stored by index, so that their order doesn't depend on which
+// call finished first. The error is the one of the lowest index that failed.
+func ParallelFetch(n, limit int, fetch func(i int) error) error {
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > n {
+		limit = n
+	}
+
+	errs := make([]error, n)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(limit)
+	for w := 0; w < limit; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = fetch(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// batchNumbers splits numbers into batches of at most size, e.g. to look up
+// as many issues as a single GraphQL query allows.
+func batchNumbers(numbers []int, size int) [][]int {
+	batches := [][]int{}
+	for len(numbers) > 0 {
+		batch := numbers
+		if len(batch) > size {
+			batch = batch[:size]
+		}
+		numbers = numbers[len(batch):]
+		batches = append(batches, batch)
+	}
+	return batches
+}
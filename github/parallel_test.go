@@ -0,0 +1,52 @@
+package github
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestParallelFetch(t *testing.T) {
+	var running, maxRunning int32
+	results := make([]int, 10)
+	err := ParallelFetch(len(results), 3, func(i int) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		results[i] = i * i
+		return nil
+	})
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, results)
+	assert.T(t, maxRunning <= 3)
+}
+
+func TestParallelFetch_Error(t *testing.T) {
+	calls := int32(0)
+	err := ParallelFetch(5, 2, func(i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 1 || i == 3 {
+			return fmt.Errorf("failed %d", i)
+		}
+		return nil
+	})
+
+	assert.Equal(t, "failed 1", err.Error())
+	assert.Equal(t, int32(5), calls)
+}
+
+func TestBatchNumbers(t *testing.T) {
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, batchNumbers([]int{1, 2, 3, 4, 5}, 2))
+	assert.Equal(t, [][]int{}, batchNumbers(nil, 100))
+}
@@ -72,9 +72,10 @@ func (p *Project) GitURL(name, owner string, isSSH bool) (url string) {
 
 	host := rawHost(p.Host)
 
-	if preferredProtocol() == "https" {
+	protocol := preferredProtocol(p.Host)
+	if protocol == "https" {
 		url = fmt.Sprintf("https://%s/%s/%s.git", host, owner, name)
-	} else if isSSH || preferredProtocol() == "ssh" {
+	} else if isSSH || protocol == "ssh" {
 		url = fmt.Sprintf("git@%s:%s/%s.git", host, owner, name)
 	} else {
 		url = fmt.Sprintf("git://%s/%s/%s.git", host, owner, name)
@@ -95,11 +96,14 @@ func rawHost(host string) string {
 	}
 }
 
-func preferredProtocol() string {
+func preferredProtocol(host string) string {
 	userProtocol := os.Getenv("HUB_PROTOCOL")
 	if userProtocol == "" {
 		userProtocol, _ = git.Config("hub.protocol")
 	}
+	if userProtocol == "" {
+		userProtocol = CurrentConfig().Setting(host, "git_protocol")
+	}
 	return userProtocol
 }
 
@@ -0,0 +1,140 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type BranchProtection struct {
+	RequiredStatusChecks *struct {
+		Strict   bool     `json:"strict"`
+		Contexts []string `json:"contexts"`
+	} `json:"required_status_checks"`
+	RequiredPullRequestReviews *struct {
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	} `json:"required_pull_request_reviews"`
+	EnforceAdmins         *protectionSetting `json:"enforce_admins"`
+	RequiredSignatures    *protectionSetting `json:"required_signatures"`
+	RequiredLinearHistory *protectionSetting `json:"required_linear_history"`
+}
+
+type protectionSetting struct {
+	Enabled bool `json:"enabled"`
+}
+
+func (p *BranchProtection) EnforcesAdmins() bool {
+	return p.EnforceAdmins != nil && p.EnforceAdmins.Enabled
+}
+
+func (p *BranchProtection) RequiresSignatures() bool {
+	return p.RequiredSignatures != nil && p.RequiredSignatures.Enabled
+}
+
+func (p *BranchProtection) RequiresLinearHistory() bool {
+	return p.RequiredLinearHistory != nil && p.RequiredLinearHistory.Enabled
+}
+
+func protectionPath(project *Project, branch string) string {
+	return fmt.Sprintf("repos/%s/%s/branches/%s/protection", project.Owner, project.Name, url.PathEscape(branch))
+}
+
+func (client *Client) BranchProtection(project *Project, branch string) (protection *BranchProtection, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.GetFile(protectionPath(project, branch), protectionType)
+	if err = checkStatus(200, "fetching branch protection", res, err); err != nil {
+		return
+	}
+
+	protection = &BranchProtection{}
+	err = res.Unmarshal(protection)
+	return
+}
+
+// RequiredStatusChecks lists the checks that have to pass before a pull
+// request can be merged into branch. Unlike BranchProtection, it only needs
+// read access to the repository.
+func (client *Client) RequiredStatusChecks(project *Project, branch string) (contexts []string, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/branches/%s", project.Owner, project.Name, url.PathEscape(branch)))
+	if err = checkStatus(200, "fetching branch", res, err); err != nil {
+		return
+	}
+
+	data := struct {
+		Protection struct {
+			RequiredStatusChecks struct {
+				Contexts []string `json:"contexts"`
+			} `json:"required_status_checks"`
+		} `json:"protection"`
+	}{}
+	if err = res.Unmarshal(&data); err != nil {
+		return
+	}
+	contexts = data.Protection.RequiredStatusChecks.Contexts
+	return
+}
+
+func (client *Client) UpdateBranchProtection(project *Project, branch string, params map[string]interface{}) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.jsonRequest("PUT", protectionPath(project, branch), params, func(req *http.Request) {
+		req.Header.Set("Accept", protectionType)
+	})
+	if err = checkStatus(200, "updating branch protection", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) SetRequiredSignatures(project *Project, branch string, enabled bool) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	method, expectedStatus := "POST", 200
+	if !enabled {
+		method, expectedStatus = "DELETE", 204
+	}
+
+	res, err := api.performRequest(method, protectionPath(project, branch)+"/required_signatures", nil, func(req *http.Request) {
+		req.Header.Set("Accept", protectionType)
+	})
+	if err = checkStatus(expectedStatus, "updating required signatures", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) DeleteBranchProtection(project *Project, branch string) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(protectionPath(project, branch))
+	if err = checkStatus(204, "removing branch protection", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
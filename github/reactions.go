@@ -0,0 +1,210 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ReactionContents are the reactions that GitHub supports, by their API names.
+var ReactionContents = []string{"+1", "-1", "laugh", "hooray", "confused", "heart", "rocket", "eyes"}
+
+var reactionAliases = map[string]string{
+	"thumbsup":   "+1",
+	"thumbsdown": "-1",
+	"tada":       "hooray",
+	"smile":      "laugh",
+}
+
+// NormalizeReaction maps a reaction name, which may be an emoji shortcode such
+// as ":tada:", to its API name.
+func NormalizeReaction(name string) (string, error) {
+	content := strings.ToLower(strings.Trim(name, ":"))
+	if alias, ok := reactionAliases[content]; ok {
+		content = alias
+	}
+	for _, c := range ReactionContents {
+		if c == content {
+			return content, nil
+		}
+	}
+	return "", fmt.Errorf("invalid reaction: '%s' (expected one of: %s)", name, strings.Join(ReactionContents, ", "))
+}
+
+type Reaction struct {
+	Id      int    `json:"id"`
+	Content string `json:"content"`
+	User    *User  `json:"user"`
+}
+
+// ReactionSubject is what a reaction is added to: an issue or pull request,
+// or one of their comments.
+type ReactionSubject struct {
+	Project *Project
+	Number  int
+	// CommentId selects a comment in the conversation of the issue or pull
+	// request instead.
+	CommentId int
+}
+
+func (s *ReactionSubject) String() string {
+	if s.CommentId > 0 {
+		return fmt.Sprintf("comment %d", s.CommentId)
+	}
+	return fmt.Sprintf("#%d", s.Number)
+}
+
+func (s *ReactionSubject) path() string {
+	if s.CommentId > 0 {
+		return fmt.Sprintf("repos/%s/%s/issues/comments/%d/reactions", s.Project.Owner, s.Project.Name, s.CommentId)
+	}
+	return fmt.Sprintf("repos/%s/%s/issues/%d/reactions", s.Project.Owner, s.Project.Name, s.Number)
+}
+
+// AddReaction reacts to subject with content. It reports false if the
+// authenticated user had already reacted the same way.
+func (client *Client) AddReaction(subject *ReactionSubject, content string) (created bool, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(subject.path(), map[string]string{"content": content})
+	if err == nil && res.StatusCode == 200 {
+		res.Body.Close()
+		return false, nil
+	}
+	if err = checkStatus(201, "adding reaction", res, err); err != nil {
+		return
+	}
+	res.Body.Close()
+	return true, nil
+}
+
+// RemoveReaction removes the reaction content of user from subject. It reports
+// false if there was no such reaction.
+func (client *Client) RemoveReaction(subject *ReactionSubject, content, user string) (removed bool, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("%s?content=%s&per_page=100", subject.path(), url.QueryEscape(content))
+	var res *simpleResponse
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching reactions", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		reactions := []Reaction{}
+		if err = res.Unmarshal(&reactions); err != nil {
+			return
+		}
+		for _, reaction := range reactions {
+			if reaction.User != nil && strings.EqualFold(reaction.User.Login, user) {
+				res, err = api.Delete(fmt.Sprintf("%s/%d", subject.path(), reaction.Id))
+				return true, checkStatus(204, "removing reaction", res, err)
+			}
+		}
+	}
+
+	return false, nil
+}
+
+var reactionGroupContents = map[string]string{
+	"THUMBS_UP":   "+1",
+	"THUMBS_DOWN": "-1",
+	"LAUGH":       "laugh",
+	"HOORAY":      "hooray",
+	"CONFUSED":    "confused",
+	"HEART":       "heart",
+	"ROCKET":      "rocket",
+	"EYES":        "eyes",
+}
+
+// ReactionCounts looks up the reactions to several issues or pull requests of
+// a repository at once, keyed by number. The pull requests API doesn't
+// include reactions otherwise.
+func (client *Client) ReactionCounts(project *Project, numbers []int) (counts map[int]*Reactions, err error) {
+	counts = map[int]*Reactions{}
+	var countsMutex sync.Mutex
+	batches := batchNumbers(numbers, 100)
+
+	err = ParallelFetch(len(batches), ParallelFetchLimit, func(i int) error {
+		batch := batches[i]
+		fields := []string{}
+		for _, number := range batch {
+			fields = append(fields, fmt.Sprintf("n%d: issueOrPullRequest(number: %d) { ...reactions }", number, number))
+		}
+		query := fmt.Sprintf(`query($owner: String!, $name: String!) {
+			repository(owner: $owner, name: $name) { %s }
+		}
+		fragment reactions on Reactable {
+			reactionGroups { content reactors { totalCount } }
+		}`, strings.Join(fields, "\n"))
+		variables := map[string]interface{}{
+			"owner": project.Owner,
+			"name":  project.Name,
+		}
+
+		data := struct {
+			Repository map[string]*struct {
+				ReactionGroups []struct {
+					Content  string
+					Reactors struct {
+						TotalCount int
+					}
+				}
+			}
+		}{}
+		if err := client.GraphQL("fetching reactions", query, variables, &data); err != nil {
+			return err
+		}
+
+		countsMutex.Lock()
+		defer countsMutex.Unlock()
+		for key, node := range data.Repository {
+			var number int
+			if node == nil {
+				continue
+			} else if _, err := fmt.Sscanf(key, "n%d", &number); err != nil {
+				continue
+			}
+			reactions := &Reactions{}
+			for _, group := range node.ReactionGroups {
+				reactions.add(reactionGroupContents[group.Content], group.Reactors.TotalCount)
+			}
+			counts[number] = reactions
+		}
+		return nil
+	})
+
+	return
+}
+
+func (r *Reactions) add(content string, count int) {
+	switch content {
+	case "+1":
+		r.PlusOne += count
+	case "-1":
+		r.MinusOne += count
+	case "laugh":
+		r.Laugh += count
+	case "hooray":
+		r.Hooray += count
+	case "confused":
+		r.Confused += count
+	case "heart":
+		r.Heart += count
+	case "rocket":
+		r.Rocket += count
+	case "eyes":
+		r.Eyes += count
+	default:
+		return
+	}
+	r.TotalCount += count
+}
@@ -0,0 +1,31 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestNormalizeReaction(t *testing.T) {
+	for name, expected := range map[string]string{
+		"+1":       "+1",
+		":tada:":   "hooray",
+		"ThumbsUp": "+1",
+		"eyes":     "eyes",
+	} {
+		content, err := NormalizeReaction(name)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, expected, content)
+	}
+
+	_, err := NormalizeReaction("party")
+	assert.Equal(t, "invalid reaction: 'party' (expected one of: +1, -1, laugh, hooray, confused, heart, rocket, eyes)", err.Error())
+}
+
+func TestReactions_add(t *testing.T) {
+	reactions := &Reactions{}
+	reactions.add("+1", 3)
+	reactions.add("heart", 1)
+	reactions.add("unknown", 5)
+	assert.Equal(t, Reactions{TotalCount: 4, PlusOne: 3, Heart: 1}, *reactions)
+}
@@ -3,7 +3,9 @@ package github
 import (
 	"fmt"
 	"net/url"
+	"os"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/github/hub/git"
@@ -31,6 +33,35 @@ func (remote *Remote) Project() (*Project, error) {
 	return p, err
 }
 
+// RemotePriority lists the names of the git remotes that hub looks at first,
+// most important first, when deciding which GitHub repository a command is
+// about. The remote given with the `--remote` global flag, which sets
+// HUB_REMOTE, comes first. It's followed by the comma-separated names in the
+// "hub.remotePriority" git config, or else OriginNamesInLookupOrder.
+func RemotePriority() []string {
+	names := []string{}
+	if name := os.Getenv("HUB_REMOTE"); name != "" {
+		names = append(names, name)
+	}
+
+	configured := OriginNamesInLookupOrder
+	if value, _ := git.Config("hub.remotePriority"); value != "" {
+		configured = strings.Split(value, ",")
+	}
+	for _, name := range configured {
+		name = strings.TrimSpace(name)
+		seen := name == ""
+		for _, n := range names {
+			seen = seen || n == name
+		}
+		if !seen {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
 func Remotes() (remotes []Remote, err error) {
 	re := regexp.MustCompile(`(.+)\s+(.+)\s+\((push|fetch)\)`)
 
@@ -57,8 +88,15 @@ func Remotes() (remotes []Remote, err error) {
 		}
 	}
 
+	if name := os.Getenv("HUB_REMOTE"); name != "" {
+		if _, ok := remotesMap[name]; !ok {
+			err = fmt.Errorf("Aborted: no git remote named '%s'", name)
+			return
+		}
+	}
+
 	// construct remotes in priority order
-	names := OriginNamesInLookupOrder
+	names := RemotePriority()
 	for _, name := range names {
 		if u, ok := remotesMap[name]; ok {
 			r, err := newRemote(name, u)
@@ -69,9 +107,14 @@ func Remotes() (remotes []Remote, err error) {
 		}
 	}
 
-	// the rest of the remotes
-	for n, u := range remotesMap {
-		r, err := newRemote(n, u)
+	// the rest of the remotes, in alphabetical order
+	rest := []string{}
+	for n := range remotesMap {
+		rest = append(rest, n)
+	}
+	sort.Strings(rest)
+	for _, n := range rest {
+		r, err := newRemote(n, remotesMap[n])
 		if err == nil {
 			remotes = append(remotes, r)
 		}
@@ -1,10 +1,12 @@
 package github
 
 import (
+	"os"
 	"testing"
 
 	"github.com/bmizerany/assert"
 	"github.com/github/hub/fixtures"
+	"github.com/github/hub/git"
 )
 
 func TestGithubRemote_NoPush(t *testing.T) {
@@ -78,3 +80,35 @@ func TestGithubRemote_ColonSlash(t *testing.T) {
 	assert.Equal(t, remotes[1].Name, "origin")
 	assert.Equal(t, remotes[1].URL.Path, repo.Remote)
 }
+
+func TestRemotes_Priority(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	repo.AddRemote("zeta", "git@github.com:zeta/project.git", "")
+	repo.AddRemote("alpha", "git@github.com:alpha/project.git", "")
+	repo.AddRemote("upstream", "git@github.com:upstream/project.git", "")
+
+	remoteNames := func() []string {
+		remotes, err := Remotes()
+		assert.Equal(t, nil, err)
+		names := []string{}
+		for _, r := range remotes {
+			names = append(names, r.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"upstream", "origin", "alpha", "zeta"}, remoteNames())
+
+	git.SetConfig("hub.remotePriority", "origin, upstream")
+	assert.Equal(t, []string{"origin", "upstream", "alpha", "zeta"}, remoteNames())
+
+	os.Setenv("HUB_REMOTE", "zeta")
+	defer os.Unsetenv("HUB_REMOTE")
+	assert.Equal(t, []string{"zeta", "origin", "upstream", "alpha"}, remoteNames())
+
+	os.Setenv("HUB_REMOTE", "missing")
+	_, err := Remotes()
+	assert.Equal(t, "Aborted: no git remote named 'missing'", err.Error())
+}
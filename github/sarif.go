@@ -0,0 +1,60 @@
+package github
+
+// SarifLog is a log in the Static Analysis Results Interchange Format, version
+// 2.1.0, which code scanning tools and editors exchange results in.
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver struct {
+		Name string `json:"name"`
+	} `json:"driver"`
+}
+
+type SarifResult struct {
+	RuleId  string `json:"ruleId,omitempty"`
+	Level   string `json:"level"`
+	Message struct {
+		Text string `json:"text"`
+	} `json:"message"`
+	Locations []SarifLocation `json:"locations"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			Uri string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region SarifRegion `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+type SarifRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+func NewSarifLog() *SarifLog {
+	return &SarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []SarifRun{},
+	}
+}
+
+// AddRun appends the results of the tool named toolName.
+func (log *SarifLog) AddRun(toolName string, results []SarifResult) {
+	run := SarifRun{Results: results}
+	run.Tool.Driver.Name = toolName
+	log.Runs = append(log.Runs, run)
+}
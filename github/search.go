@@ -0,0 +1,134 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type searchPage struct {
+	TotalCount int               `json:"total_count"`
+	Items      []json.RawMessage `json:"items"`
+}
+
+// search pages through the results of the search API for `kind` ("issues",
+// "repositories", or "code") until `limit` items were collected, and
+// returns the items in their raw form.
+func (client *Client) search(kind, query string, params map[string]interface{}, limit int) (total int, items []json.RawMessage, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("search/%s?per_page=%d", kind, perPage(limit, 100))
+	queryParams := map[string]interface{}{"q": query}
+	for key, value := range params {
+		queryParams[key] = value
+	}
+	path = addQuery(path, queryParams)
+
+	items = []json.RawMessage{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "searching "+kind, res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := searchPage{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		total = page.TotalCount
+		for _, item := range page.Items {
+			items = append(items, item)
+			if limit > 0 && len(items) == limit {
+				path = ""
+				break
+			}
+		}
+	}
+
+	return
+}
+
+type IssueSearchResult struct {
+	TotalCount int     `json:"total_count"`
+	Items      []Issue `json:"items"`
+}
+
+// SearchIssues finds issues and pull requests across repositories. The params
+// may specify "sort" and "order" of the results.
+func (client *Client) SearchIssues(query string, params map[string]interface{}, limit int) (result *IssueSearchResult, err error) {
+	total, items, err := client.search("issues", query, params, limit)
+	if err != nil {
+		return
+	}
+
+	result = &IssueSearchResult{TotalCount: total, Items: make([]Issue, len(items))}
+	for i, item := range items {
+		if err = json.Unmarshal(item, &result.Items[i]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+type RepositorySearchResult struct {
+	TotalCount int          `json:"total_count"`
+	Items      []Repository `json:"items"`
+}
+
+func (client *Client) SearchRepositories(query string, params map[string]interface{}, limit int) (result *RepositorySearchResult, err error) {
+	total, items, err := client.search("repositories", query, params, limit)
+	if err != nil {
+		return
+	}
+
+	result = &RepositorySearchResult{TotalCount: total, Items: make([]Repository, len(items))}
+	for i, item := range items {
+		if err = json.Unmarshal(item, &result.Items[i]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+type CodeSearchItem struct {
+	Name       string     `json:"name"`
+	Path       string     `json:"path"`
+	Sha        string     `json:"sha"`
+	HtmlUrl    string     `json:"html_url"`
+	Repository Repository `json:"repository"`
+}
+
+type CodeSearchResult struct {
+	TotalCount int              `json:"total_count"`
+	Items      []CodeSearchItem `json:"items"`
+}
+
+func (client *Client) SearchCode(query string, params map[string]interface{}, limit int) (result *CodeSearchResult, err error) {
+	total, items, err := client.search("code", query, params, limit)
+	if err != nil {
+		return
+	}
+
+	result = &CodeSearchResult{TotalCount: total, Items: make([]CodeSearchItem, len(items))}
+	for i, item := range items {
+		if err = json.Unmarshal(item, &result.Items[i]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// RepositoryName returns the "OWNER/REPO" name of the repository an issue
+// belongs to, as reported by the search API.
+func (i *Issue) RepositoryName() string {
+	if idx := strings.Index(i.RepositoryUrl, "/repos/"); idx >= 0 {
+		return i.RepositoryUrl[idx+len("/repos/"):]
+	}
+	return ""
+}
@@ -0,0 +1,195 @@
+package github
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// SecretScope identifies where secrets are stored: the secrets of either a
+// repository or an organization, for either GitHub Actions or Dependabot.
+type SecretScope struct {
+	Project *Project
+	Org     string
+	App     string
+}
+
+func (s *SecretScope) String() string {
+	if s.Org != "" {
+		return s.Org
+	}
+	return s.Project.String()
+}
+
+func (s *SecretScope) path() string {
+	app := s.App
+	if app == "" {
+		app = "actions"
+	}
+	if s.Org != "" {
+		return fmt.Sprintf("orgs/%s/%s/secrets", s.Org, app)
+	}
+	return fmt.Sprintf("repos/%s/%s/%s/secrets", s.Project.Owner, s.Project.Name, app)
+}
+
+type Secret struct {
+	Name       string    `json:"name"`
+	Visibility string    `json:"visibility"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type SecretPublicKey struct {
+	KeyID string `json:"key_id"`
+	Key   string `json:"key"`
+}
+
+type secretsPage struct {
+	TotalCount int      `json:"total_count"`
+	Secrets    []Secret `json:"secrets"`
+}
+
+func (client *Client) Secrets(scope *SecretScope) (secrets []Secret, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("%s?per_page=100", scope.path())
+	for path != "" {
+		res, err := api.Get(path)
+		if err = checkStatus(200, "fetching secrets", res, err); err != nil {
+			return nil, err
+		}
+		path = res.Link("next")
+
+		page := secretsPage{}
+		if err = res.Unmarshal(&page); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, page.Secrets...)
+	}
+
+	return
+}
+
+func (client *Client) SecretPublicKey(scope *SecretScope) (key *SecretPublicKey, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(scope.path() + "/public-key")
+	if err = checkStatus(200, "fetching secrets public key", res, err); err != nil {
+		return
+	}
+
+	key = &SecretPublicKey{}
+	err = res.Unmarshal(key)
+	return
+}
+
+// SetSecret encrypts value against the public key of the scope and stores it
+// as the secret `name`. The visibility and repository IDs are only used for
+// organization secrets.
+func (client *Client) SetSecret(scope *SecretScope, name string, value []byte, visibility string, repositoryIDs []int) (err error) {
+	key, err := client.SecretPublicKey(scope)
+	if err != nil {
+		return
+	}
+
+	encrypted, err := EncryptSecret(key.Key, value)
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"encrypted_value": encrypted,
+		"key_id":          key.KeyID,
+	}
+	if scope.Org != "" {
+		if visibility == "" {
+			visibility = "private"
+		}
+		params["visibility"] = visibility
+		if repositoryIDs != nil {
+			params["selected_repository_ids"] = repositoryIDs
+		}
+	}
+
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.jsonRequest("PUT", fmt.Sprintf("%s/%s", scope.path(), url.PathEscape(name)), params, nil)
+	if err == nil && res.StatusCode == http.StatusNoContent {
+		// an existing secret was updated
+		return nil
+	}
+	return checkStatus(201, "setting secret", res, err)
+}
+
+func (client *Client) DeleteSecret(scope *SecretScope, name string) (err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("%s/%s", scope.path(), url.PathEscape(name)))
+	return checkStatus(204, "deleting secret", res, err)
+}
+
+// EncryptSecret encrypts value into a libsodium sealed box for the base64
+// encoded public key and returns the base64 encoded result, which is the form
+// that the GitHub API expects secret values in.
+func EncryptSecret(publicKey string, value []byte) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(decoded) != 32 {
+		return "", fmt.Errorf("invalid secrets public key: %q", publicKey)
+	}
+	recipientKey := &[32]byte{}
+	copy(recipientKey[:], decoded)
+
+	sealed, err := sealAnonymous(value, recipientKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// sealAnonymous implements libsodium's crypto_box_seal: the message is boxed
+// with a throwaway key pair whose public key is prepended to the ciphertext,
+// and the nonce is derived from both public keys.
+func sealAnonymous(message []byte, recipientKey *[32]byte) ([]byte, error) {
+	ephemeralPublic, ephemeralPrivate, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := sealNonce(ephemeralPublic, recipientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return box.Seal(ephemeralPublic[:], message, nonce, recipientKey, ephemeralPrivate), nil
+}
+
+func sealNonce(ephemeralPublic, recipientKey *[32]byte) (*[24]byte, error) {
+	hash, err := blake2b.New(24, nil)
+	if err != nil {
+		return nil, err
+	}
+	hash.Write(ephemeralPublic[:])
+	hash.Write(recipientKey[:])
+
+	nonce := &[24]byte{}
+	copy(nonce[:], hash.Sum(nil))
+	return nonce, nil
+}
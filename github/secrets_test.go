@@ -0,0 +1,49 @@
+package github
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestEncryptSecret(t *testing.T) {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	assert.Equal(t, nil, err)
+
+	encrypted, err := EncryptSecret(base64.StdEncoding.EncodeToString(publicKey[:]), []byte("hunter2"))
+	assert.Equal(t, nil, err)
+
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 32+box.Overhead+len("hunter2"), len(sealed))
+
+	ephemeralPublic := &[32]byte{}
+	copy(ephemeralPublic[:], sealed[:32])
+	nonce, err := sealNonce(ephemeralPublic, publicKey)
+	assert.Equal(t, nil, err)
+
+	decrypted, ok := box.Open(nil, sealed[32:], nonce, ephemeralPublic, privateKey)
+	assert.T(t, ok)
+	assert.Equal(t, "hunter2", string(decrypted))
+}
+
+func TestEncryptSecret_InvalidKey(t *testing.T) {
+	_, err := EncryptSecret("bm90IGEga2V5", []byte("hunter2"))
+	assert.Equal(t, `invalid secrets public key: "bm90IGEga2V5"`, err.Error())
+}
+
+func TestSecretScope_path(t *testing.T) {
+	project := NewProject("octocat", "hello-world", "github.com")
+
+	scope := &SecretScope{Project: project}
+	assert.Equal(t, "repos/octocat/hello-world/actions/secrets", scope.path())
+
+	scope = &SecretScope{Project: project, App: "dependabot"}
+	assert.Equal(t, "repos/octocat/hello-world/dependabot/secrets", scope.path())
+
+	scope = &SecretScope{Org: "acme", App: "actions"}
+	assert.Equal(t, "orgs/acme/actions/secrets", scope.path())
+}
@@ -0,0 +1,371 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// AuditLogEntry is an event of the audit log of an organization. Only the
+// fields common to most actions are decoded.
+type AuditLogEntry struct {
+	Timestamp int64  `json:"@timestamp"`
+	Action    string `json:"action"`
+	Actor     string `json:"actor"`
+	Org       string `json:"org,omitempty"`
+	Repo      string `json:"repo,omitempty"`
+	User      string `json:"user,omitempty"`
+}
+
+// Time is the moment the event happened. The API reports it in milliseconds
+// since the epoch.
+func (e *AuditLogEntry) Time() time.Time {
+	return time.Unix(e.Timestamp/1000, e.Timestamp%1000*int64(time.Millisecond)).UTC()
+}
+
+// AuditLog fetches up to limit events of the audit log of org, most recent
+// first, that match the search phrase.
+func (client *Client) AuditLog(org, phrase string, limit int) (entries []AuditLogEntry, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("orgs/%s/audit-log?include=all&per_page=%d", org, perPage(limit, 100))
+	if phrase != "" {
+		path += "&phrase=" + url.QueryEscape(phrase)
+	}
+	entries = []AuditLogEntry{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching audit log", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []AuditLogEntry{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, entry := range page {
+			entries = append(entries, entry)
+			if limit > 0 && len(entries) == limit {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+type SecurityPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+func (p SecurityPackage) String() string {
+	return fmt.Sprintf("%s/%s", p.Ecosystem, p.Name)
+}
+
+type AdvisoryVulnerability struct {
+	Package                SecurityPackage `json:"package"`
+	VulnerableVersionRange string          `json:"vulnerable_version_range"`
+	PatchedVersions        string          `json:"patched_versions"`
+}
+
+// RepositoryAdvisory is a security advisory drafted or published by the
+// maintainers of a repository.
+type RepositoryAdvisory struct {
+	GhsaId          string                  `json:"ghsa_id"`
+	CveId           string                  `json:"cve_id"`
+	Summary         string                  `json:"summary"`
+	Description     string                  `json:"description"`
+	Severity        string                  `json:"severity"`
+	State           string                  `json:"state"`
+	HtmlUrl         string                  `json:"html_url"`
+	CreatedAt       time.Time               `json:"created_at"`
+	PublishedAt     *time.Time              `json:"published_at"`
+	Vulnerabilities []AdvisoryVulnerability `json:"vulnerabilities"`
+}
+
+// RepositoryAdvisories lists up to limit security advisories of a repository,
+// optionally only those in state "triage", "draft", "published", or "closed".
+func (client *Client) RepositoryAdvisories(project *Project, state string, limit int) (advisories []RepositoryAdvisory, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/security-advisories?per_page=%d", project.Owner, project.Name, perPage(limit, 100))
+	if state != "" {
+		path += "&state=" + url.QueryEscape(state)
+	}
+	advisories = []RepositoryAdvisory{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching security advisories", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []RepositoryAdvisory{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, advisory := range page {
+			advisories = append(advisories, advisory)
+			if limit > 0 && len(advisories) == limit {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (client *Client) FetchRepositoryAdvisory(project *Project, ghsaId string) (advisory *RepositoryAdvisory, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/security-advisories/%s", project.Owner, project.Name, ghsaId))
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return nil, fmt.Errorf("Unable to find security advisory %s in %s", ghsaId, project)
+	}
+	if err = checkStatus(200, "fetching security advisory", res, err); err != nil {
+		return
+	}
+
+	advisory = &RepositoryAdvisory{}
+	err = res.Unmarshal(advisory)
+	return
+}
+
+// DependabotAlert is an alert about a dependency of a repository that is
+// affected by a known vulnerability.
+type DependabotAlert struct {
+	Number     int    `json:"number"`
+	State      string `json:"state"`
+	Dependency struct {
+		Package      SecurityPackage `json:"package"`
+		ManifestPath string          `json:"manifest_path"`
+		Scope        string          `json:"scope"`
+	} `json:"dependency"`
+	SecurityAdvisory struct {
+		GhsaId      string `json:"ghsa_id"`
+		CveId       string `json:"cve_id"`
+		Summary     string `json:"summary"`
+		Description string `json:"description"`
+		Severity    string `json:"severity"`
+	} `json:"security_advisory"`
+	SecurityVulnerability struct {
+		VulnerableVersionRange string `json:"vulnerable_version_range"`
+		FirstPatchedVersion    *struct {
+			Identifier string `json:"identifier"`
+		} `json:"first_patched_version"`
+	} `json:"security_vulnerability"`
+	HtmlUrl         string    `json:"html_url"`
+	CreatedAt       time.Time `json:"created_at"`
+	DismissedReason string    `json:"dismissed_reason,omitempty"`
+}
+
+func (client *Client) FetchDependabotAlert(project *Project, number int) (alert *DependabotAlert, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/dependabot/alerts/%d", project.Owner, project.Name, number))
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return nil, fmt.Errorf("Unable to find Dependabot alert %d in %s", number, project)
+	}
+	if err = checkStatus(200, "fetching Dependabot alert", res, err); err != nil {
+		return
+	}
+
+	alert = &DependabotAlert{}
+	err = res.Unmarshal(alert)
+	return
+}
+
+// AlertFilters narrows down the alerts of a repository. Empty fields don't
+// filter. Only code scanning alerts can be filtered by Tool and Ref.
+type AlertFilters struct {
+	State    string
+	Severity string
+	Tool     string
+	Ref      string
+}
+
+func (f AlertFilters) query(limit int) string {
+	params := url.Values{}
+	if f.State != "" {
+		params.Set("state", f.State)
+	}
+	if f.Severity != "" {
+		params.Set("severity", f.Severity)
+	}
+	if f.Tool != "" {
+		params.Set("tool_name", f.Tool)
+	}
+	if f.Ref != "" {
+		params.Set("ref", f.Ref)
+	}
+	params.Set("per_page", fmt.Sprint(perPage(limit, 100)))
+	return params.Encode()
+}
+
+// DependabotAlerts lists up to limit Dependabot alerts of a repository.
+func (client *Client) DependabotAlerts(project *Project, filters AlertFilters, limit int) (alerts []DependabotAlert, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/dependabot/alerts?%s", project.Owner, project.Name, filters.query(limit))
+	alerts = []DependabotAlert{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching Dependabot alerts", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []DependabotAlert{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, alert := range page {
+			alerts = append(alerts, alert)
+			if limit > 0 && len(alerts) == limit {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// CodeScanningAlert is a problem found in the code of a repository by a code
+// scanning tool such as CodeQL.
+type CodeScanningAlert struct {
+	Number int    `json:"number"`
+	State  string `json:"state"`
+	Rule   struct {
+		Id                    string `json:"id"`
+		Severity              string `json:"severity"`
+		SecuritySeverityLevel string `json:"security_severity_level"`
+		Description           string `json:"description"`
+	} `json:"rule"`
+	Tool struct {
+		Name string `json:"name"`
+	} `json:"tool"`
+	MostRecentInstance struct {
+		Ref      string `json:"ref"`
+		Location struct {
+			Path      string `json:"path"`
+			StartLine int    `json:"start_line"`
+		} `json:"location"`
+	} `json:"most_recent_instance"`
+	HtmlUrl         string    `json:"html_url"`
+	CreatedAt       time.Time `json:"created_at"`
+	DismissedReason string    `json:"dismissed_reason,omitempty"`
+}
+
+// Severity is the security severity of the rule that raised the alert, such
+// as "critical", or the severity of the problem otherwise, such as "error".
+func (a *CodeScanningAlert) Severity() string {
+	if a.Rule.SecuritySeverityLevel != "" {
+		return a.Rule.SecuritySeverityLevel
+	}
+	return a.Rule.Severity
+}
+
+// CodeScanningAlerts lists up to limit code scanning alerts of a repository.
+func (client *Client) CodeScanningAlerts(project *Project, filters AlertFilters, limit int) (alerts []CodeScanningAlert, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts?%s", project.Owner, project.Name, filters.query(limit))
+	alerts = []CodeScanningAlert{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching code scanning alerts", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []CodeScanningAlert{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, alert := range page {
+			alerts = append(alerts, alert)
+			if limit > 0 && len(alerts) == limit {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// SecretScanningAlert is a secret, such as an access token, that was found
+// committed to a repository.
+type SecretScanningAlert struct {
+	Number                int       `json:"number"`
+	State                 string    `json:"state"`
+	SecretType            string    `json:"secret_type"`
+	SecretTypeDisplayName string    `json:"secret_type_display_name"`
+	Resolution            string    `json:"resolution,omitempty"`
+	HtmlUrl               string    `json:"html_url"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+// SecretScanningAlerts lists up to limit secret scanning alerts of a
+// repository. Secret scanning alerts have no severity to filter by.
+func (client *Client) SecretScanningAlerts(project *Project, filters AlertFilters, limit int) (alerts []SecretScanningAlert, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	filters.Severity = ""
+	path := fmt.Sprintf("repos/%s/%s/secret-scanning/alerts?%s", project.Owner, project.Name, filters.query(limit))
+	alerts = []SecretScanningAlert{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching secret scanning alerts", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []SecretScanningAlert{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, alert := range page {
+			alerts = append(alerts, alert)
+			if limit > 0 && len(alerts) == limit {
+				return
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,26 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestAuditLogEntry_Time(t *testing.T) {
+	entry := AuditLogEntry{Timestamp: 1600000000123}
+	assert.Equal(t, time.Date(2020, 9, 13, 12, 26, 40, 123000000, time.UTC), entry.Time())
+}
+
+func TestCodeScanningAlert_Severity(t *testing.T) {
+	alert := CodeScanningAlert{}
+	alert.Rule.Severity = "error"
+	assert.Equal(t, "error", alert.Severity())
+	alert.Rule.SecuritySeverityLevel = "critical"
+	assert.Equal(t, "critical", alert.Severity())
+}
+
+func TestAlertFilters_Query(t *testing.T) {
+	assert.Equal(t, "per_page=100", AlertFilters{}.query(0))
+	assert.Equal(t, "per_page=3&severity=high&state=open", AlertFilters{State: "open", Severity: "high"}.query(2))
+}
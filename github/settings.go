@@ -0,0 +1,150 @@
+package github
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/hub/ui"
+)
+
+// settingValues lists the settings managed by `hub config` along with the
+// values they accept. A nil list accepts any value.
+var settingValues = map[string][]string{
+	"git_protocol": {"https", "ssh", "git"},
+	"default_host": nil,
+	"editor":       nil,
+	"pager":        nil,
+	"color":        {"always", "never", "auto"},
+	"color.labels": {"background", "foreground", "none"},
+	"color.open":   ui.ColorNames(),
+	"color.closed": ui.ColorNames(),
+	"color.merged": ui.ColorNames(),
+	"color.draft":  ui.ColorNames(),
+
+	"proxy":           nil,
+	"ca_cert":         nil,
+	"skip_tls_verify": {"true", "false"},
+}
+
+// SettingKeys returns the names of all known settings in alphabetical order.
+func SettingKeys() []string {
+	keys := []string{}
+	for key := range settingValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func validateSetting(host, key, value string) error {
+	if !strings.HasPrefix(key, "aliases.") && !strings.HasPrefix(key, "filters.") {
+		values, ok := settingValues[key]
+		if !ok {
+			return fmt.Errorf("unknown config key: %s", key)
+		}
+		if value != "" && values != nil && !stringInSlice(value, values) {
+			return fmt.Errorf("invalid value for %s: %q (expected one of: %s)", key, value, strings.Join(values, ", "))
+		}
+	} else if key == "aliases." {
+		return fmt.Errorf("alias name can't be empty")
+	} else if key == "filters." {
+		return fmt.Errorf("query name can't be empty")
+	}
+
+	if host != "" && key == "default_host" {
+		return fmt.Errorf("%s can't be set per host", key)
+	}
+	return nil
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Setting returns the value of key for host, falling back to the global value
+// if the host doesn't override it.
+func (c *Config) Setting(host, key string) string {
+	if h := c.Find(host); h != nil {
+		if value, ok := h.Settings[key]; ok {
+			return value
+		}
+	}
+	return c.Settings[key]
+}
+
+// EffectiveSettings returns all settings that apply to host.
+func (c *Config) EffectiveSettings(host string) map[string]string {
+	settings := map[string]string{}
+	for key, value := range c.Settings {
+		settings[key] = value
+	}
+	if h := c.Find(host); h != nil {
+		for key, value := range h.Settings {
+			settings[key] = value
+		}
+	}
+	return settings
+}
+
+// SetSetting stores a setting globally, or only for host if given. The host
+// has to be one that hub has credentials for.
+func (c *Config) SetSetting(host, key, value string) error {
+	if err := validateSetting(host, key, value); err != nil {
+		return err
+	}
+
+	if host == "" {
+		if c.Settings == nil {
+			c.Settings = map[string]string{}
+		}
+		c.Settings[key] = value
+		return nil
+	}
+
+	h := c.Find(host)
+	if h == nil {
+		return fmt.Errorf("no credentials found for host %s", host)
+	}
+	if h.Settings == nil {
+		h.Settings = map[string]string{}
+	}
+	h.Settings[key] = value
+	return nil
+}
+
+// UnsetSetting removes a setting globally, or only for host if given.
+func (c *Config) UnsetSetting(host, key string) error {
+	if host == "" {
+		if _, ok := c.Settings[key]; !ok {
+			return fmt.Errorf("%s is not set", key)
+		}
+		delete(c.Settings, key)
+		return nil
+	}
+
+	h := c.Find(host)
+	if h == nil {
+		return fmt.Errorf("no credentials found for host %s", host)
+	}
+	if _, ok := h.Settings[key]; !ok {
+		return fmt.Errorf("%s is not set for %s", key, host)
+	}
+	delete(h.Settings, key)
+	return nil
+}
+
+// Save writes the configuration back to the hub config file.
+func (c *Config) Save() error {
+	return newConfigService().Save(configsFile(), c)
+}
+
+// ConfigSetting returns the value of a setting for the default host.
+func ConfigSetting(key string) string {
+	return CurrentConfig().Setting(DefaultGitHubHost(), key)
+}
@@ -0,0 +1,27 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestConfig_SetSetting(t *testing.T) {
+	c := &Config{Hosts: []*Host{{Host: "github.com", User: "mislav"}}}
+
+	assert.Equal(t, nil, c.SetSetting("", "color", "always"))
+	assert.Equal(t, nil, c.SetSetting("github.com", "color", "never"))
+	assert.Equal(t, nil, c.SetSetting("", "aliases.co", "pr checkout"))
+	assert.Equal(t, "never", c.Setting("github.com", "color"))
+	assert.Equal(t, "always", c.Setting("git.example.com", "color"))
+	assert.Equal(t, "pr checkout", c.Setting("github.com", "aliases.co"))
+
+	assert.Equal(t, "unknown config key: colour", c.SetSetting("", "colour", "never").Error())
+	assert.Equal(t, `invalid value for color: "blue" (expected one of: always, never, auto)`, c.SetSetting("", "color", "blue").Error())
+	assert.Equal(t, "default_host can't be set per host", c.SetSetting("github.com", "default_host", "git.example.com").Error())
+	assert.Equal(t, "no credentials found for host git.example.com", c.SetSetting("git.example.com", "editor", "vi").Error())
+
+	assert.Equal(t, nil, c.UnsetSetting("github.com", "color"))
+	assert.Equal(t, "always", c.Setting("github.com", "color"))
+	assert.Equal(t, "color is not set for github.com", c.UnsetSetting("github.com", "color").Error())
+}
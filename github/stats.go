@@ -0,0 +1,95 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrStatsComputing is returned while GitHub computes the statistics of a
+// repository in the background. Asking again after a little while returns
+// them once they are ready.
+var ErrStatsComputing = errors.New("GitHub is computing the statistics")
+
+type ContributorStats struct {
+	Author *User               `json:"author"`
+	Total  int                 `json:"total"`
+	Weeks  []ContributorWeekly `json:"weeks"`
+}
+
+// ContributorWeekly is the activity of a contributor during the week that
+// starts on Week, a Unix timestamp.
+type ContributorWeekly struct {
+	Week      int64 `json:"w"`
+	Additions int   `json:"a"`
+	Deletions int   `json:"d"`
+	Commits   int   `json:"c"`
+}
+
+// CommitActivity is the number of commits during the week that starts on
+// Week, a Unix timestamp, in total and for each day starting with Sunday.
+type CommitActivity struct {
+	Days  []int `json:"days"`
+	Total int   `json:"total"`
+	Week  int64 `json:"week"`
+}
+
+func (a CommitActivity) WeekStart() time.Time {
+	return time.Unix(a.Week, 0).UTC()
+}
+
+// PunchCardHour is the number of commits during an hour of a day of the week,
+// as [DAY, HOUR, COMMITS] where DAY 0 is Sunday.
+type PunchCardHour [3]int
+
+func statsPath(project *Project, name string) string {
+	return fmt.Sprintf("repos/%s/%s/stats/%s", project.Owner, project.Name, name)
+}
+
+// ContributorStats lists the contributors of project with their weekly
+// activity. It returns ErrStatsComputing if the statistics aren't ready yet.
+func (client *Client) ContributorStats(project *Project) (stats []ContributorStats, err error) {
+	stats = []ContributorStats{}
+	err = client.fetchStats(statsPath(project, "contributors"), "fetching contributor statistics", &stats)
+	return
+}
+
+// CommitActivity lists the commits to project per week over the last year. It
+// returns ErrStatsComputing if the statistics aren't ready yet.
+func (client *Client) CommitActivity(project *Project) (weeks []CommitActivity, err error) {
+	weeks = []CommitActivity{}
+	err = client.fetchStats(statsPath(project, "commit_activity"), "fetching commit activity", &weeks)
+	return
+}
+
+// PunchCard lists the commits to project per hour of each day of the week. It
+// returns ErrStatsComputing if the statistics aren't ready yet.
+func (client *Client) PunchCard(project *Project) (hours []PunchCardHour, err error) {
+	hours = []PunchCardHour{}
+	err = client.fetchStats(statsPath(project, "punch_card"), "fetching punch card", &hours)
+	return
+}
+
+func (client *Client) fetchStats(path, action string, result interface{}) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Get(path)
+	if err == nil {
+		switch res.StatusCode {
+		case 202:
+			res.Body.Close()
+			return ErrStatsComputing
+		case 204:
+			// a repository without commits has no statistics
+			res.Body.Close()
+			return nil
+		}
+	}
+	if err = checkStatus(200, action, res, err); err != nil {
+		return err
+	}
+	return res.Unmarshal(result)
+}
@@ -0,0 +1,79 @@
+package github
+
+import (
+	"fmt"
+	"time"
+)
+
+// TrafficCount is the number of views or clones of a repository on a day, and
+// how many unique visitors or cloners there were.
+type TrafficCount struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+	Uniques   int       `json:"uniques"`
+}
+
+type TrafficPath struct {
+	Path    string `json:"path"`
+	Title   string `json:"title"`
+	Count   int    `json:"count"`
+	Uniques int    `json:"uniques"`
+}
+
+type TrafficReferrer struct {
+	Referrer string `json:"referrer"`
+	Count    int    `json:"count"`
+	Uniques  int    `json:"uniques"`
+}
+
+func trafficPath(project *Project, name string) string {
+	return fmt.Sprintf("repos/%s/%s/traffic/%s", project.Owner, project.Name, name)
+}
+
+// TrafficViews lists the daily views of project over the last 14 days.
+func (client *Client) TrafficViews(project *Project) (views []TrafficCount, err error) {
+	result := struct {
+		Views []TrafficCount `json:"views"`
+	}{}
+	err = client.fetchTraffic(trafficPath(project, "views?per=day"), "fetching traffic views", &result)
+	views = result.Views
+	return
+}
+
+// TrafficClones lists the daily clones of project over the last 14 days.
+func (client *Client) TrafficClones(project *Project) (clones []TrafficCount, err error) {
+	result := struct {
+		Clones []TrafficCount `json:"clones"`
+	}{}
+	err = client.fetchTraffic(trafficPath(project, "clones?per=day"), "fetching traffic clones", &result)
+	clones = result.Clones
+	return
+}
+
+// TrafficPaths lists the most viewed paths of project over the last 14 days.
+func (client *Client) TrafficPaths(project *Project) (paths []TrafficPath, err error) {
+	paths = []TrafficPath{}
+	err = client.fetchTraffic(trafficPath(project, "popular/paths"), "fetching popular paths", &paths)
+	return
+}
+
+// TrafficReferrers lists the sites that referred the most visitors to project
+// over the last 14 days.
+func (client *Client) TrafficReferrers(project *Project) (referrers []TrafficReferrer, err error) {
+	referrers = []TrafficReferrer{}
+	err = client.fetchTraffic(trafficPath(project, "popular/referrers"), "fetching referrers", &referrers)
+	return
+}
+
+func (client *Client) fetchTraffic(path, action string, result interface{}) error {
+	api, err := client.simpleApi()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Get(path)
+	if err = checkStatus(200, action, res, err); err != nil {
+		return err
+	}
+	return res.Unmarshal(result)
+}
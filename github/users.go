@@ -0,0 +1,125 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Profile is the public profile of a user or an organization.
+type Profile struct {
+	Login       string    `json:"login"`
+	Type        string    `json:"type"`
+	Name        string    `json:"name"`
+	Company     string    `json:"company"`
+	Blog        string    `json:"blog"`
+	Location    string    `json:"location"`
+	Email       string    `json:"email"`
+	Bio         string    `json:"bio"`
+	PublicRepos int       `json:"public_repos"`
+	PublicGists int       `json:"public_gists"`
+	Followers   int       `json:"followers"`
+	Following   int       `json:"following"`
+	HtmlUrl     string    `json:"html_url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (p *Profile) IsOrganization() bool {
+	return p.Type == "Organization"
+}
+
+// UserProfile fetches the profile of login, or of the authenticated user if
+// login is empty.
+func (client *Client) UserProfile(login string) (profile *Profile, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := "user"
+	if login != "" {
+		path = "users/" + login
+	}
+	res, err := api.Get(path)
+	if err = checkStatus(200, "fetching user", res, err); err != nil {
+		return
+	}
+
+	profile = &Profile{}
+	err = res.Unmarshal(profile)
+	return
+}
+
+// UserSSHKeys lists the public SSH keys of login. Only their ids and keys are
+// available.
+func (client *Client) UserSSHKeys(login string) (keys []SSHKey, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("users/%s/keys?per_page=100", login)
+	keys = []SSHKey{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching SSH keys", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []SSHKey{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		keys = append(keys, page...)
+	}
+
+	return
+}
+
+// UserRepositories lists the repositories owned by login, or by the
+// authenticated user if login is empty, including their private ones. The sort
+// key is "created", "updated", "pushed", or "full_name".
+func (client *Client) UserRepositories(login, sort string, limit int, filter func(*Repository) bool) (repos []Repository, err error) {
+	api, err := client.simpleApi()
+	if err != nil {
+		return
+	}
+
+	path := "user/repos?type=owner"
+	if login != "" {
+		path = fmt.Sprintf("users/%s/repos?type=owner", login)
+	}
+	if sort != "" {
+		path += "&sort=" + url.QueryEscape(sort)
+	}
+	path = fmt.Sprintf("%s&per_page=%d", path, perPage(limit, 100))
+
+	repos = []Repository{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching repositories", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []Repository{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		for _, repo := range page {
+			if filter == nil || filter(&repo) {
+				repos = append(repos, repo)
+				if limit > 0 && len(repos) == limit {
+					return
+				}
+			}
+		}
+	}
+
+	return
+}
@@ -6,5 +6,8 @@ import (
 
 func IsHttpsProtocol() bool {
 	httpProtocol, _ := git.Config("hub.protocol")
+	if httpProtocol == "" {
+		httpProtocol = ConfigSetting("git_protocol")
+	}
 	return httpProtocol == "https"
 }
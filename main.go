@@ -10,11 +10,13 @@ import (
 	"github.com/github/hub/commands"
 	"github.com/github/hub/github"
 	"github.com/github/hub/ui"
+	"github.com/github/hub/utils"
 )
 
 func main() {
 	defer github.CaptureCrash()
 	err := commands.CmdRunner.Execute(os.Args)
+	ui.StopPager()
 	exitCode := handleError(err)
 	os.Exit(exitCode)
 }
@@ -38,6 +40,6 @@ func handleError(err error) int {
 		if errString := err.Error(); errString != "" {
 			ui.Errorln(err)
 		}
-		return 1
+		return utils.ExitCode(err)
 	}
 }
@@ -61,6 +61,7 @@ var colorMap = map[string]string{
 	"magenta": "35",
 	"cyan":    "36",
 	"white":   "37",
+	"gray":    "90",
 	"reset":   "",
 }
 
@@ -114,7 +115,7 @@ func (f *expander) expandSpecialChar(firstChar byte, format string) (expand stri
 	case 'x':
 		if len(format) >= 2 {
 			if v, err := strconv.ParseInt(format[:2], 16, 32); err == nil {
-				return string(v), format[2:], true
+				return string(rune(v)), format[2:], true
 			}
 		}
 	case '+':
@@ -155,7 +156,7 @@ func (f *expander) pad(s string, p *padder) string {
 		size -= len(previous) - strings.LastIndex(previous, "\n") - 1
 	}
 
-	numPadding := size - len(s)
+	numPadding := size - DisplayWidth(s)
 	if numPadding == 0 {
 		return s
 	}
@@ -247,18 +248,39 @@ func (p *padder) truncate(s string, numReduce int) string {
 	if numReduce == 0 {
 		return s
 	}
-	numLeft := len(s) - numReduce - 2
+	numLeft := DisplayWidth(s) - numReduce - 2
 	if numLeft < 0 {
 		numLeft = 0
 	}
 
 	switch p.truncing {
 	case truncRight:
-		return ".." + s[len(s)-numLeft:]
+		return ".." + suffixOfWidth(s, numLeft)
 	case truncMiddle:
-		return s[:numLeft/2] + ".." + s[len(s)-(numLeft+1)/2:]
+		return prefixOfWidth(s, numLeft/2) + ".." + suffixOfWidth(s, (numLeft+1)/2)
 	}
 
 	// Trunc left by default.
-	return s[:numLeft] + ".."
+	return prefixOfWidth(s, numLeft) + ".."
+}
+
+func prefixOfWidth(s string, width int) string {
+	w := 0
+	for i, r := range s {
+		if w += runeWidth(r); w > width {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func suffixOfWidth(s string, width int) string {
+	runes := []rune(s)
+	w := 0
+	for i := len(runes) - 1; i >= 0; i-- {
+		if w += runeWidth(runes[i]); w > width {
+			return string(runes[i+1:])
+		}
+	}
+	return s
 }
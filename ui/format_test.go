@@ -128,6 +128,12 @@ func TestExpand_Padding(t *testing.T) {
 			values: map[string]string{"a": "012"},
 			expect: "abcdef 012",
 		},
+		{
+			name:   "padding wide characters",
+			format: "%<(10)%a|",
+			values: map[string]string{"a": "修正バグ"},
+			expect: "修正バグ  |",
+		},
 	})
 }
 
@@ -169,5 +175,11 @@ func TestExpand_Truncing(t *testing.T) {
 			values: map[string]string{"a": "0123456"},
 			expect: "|0123..",
 		},
+		{
+			name:   "truncing wide characters",
+			format: "%<(7,trunc)%a",
+			values: map[string]string{"a": "修正バグです"},
+			expect: "修正..",
+		},
 	})
 }
@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/russross/blackfriday"
+)
+
+// The extensions match what GitHub renders in issues and comments: tables,
+// fenced code, strikethrough, bare URLs as links, and line breaks kept as is.
+const markdownExtensions = blackfriday.NoIntraEmphasis |
+	blackfriday.Tables |
+	blackfriday.FencedCode |
+	blackfriday.Autolink |
+	blackfriday.Strikethrough |
+	blackfriday.SpaceHeadings |
+	blackfriday.HardLineBreak |
+	blackfriday.NoEmptyLineBeforeBlock
+
+// RenderMarkdown formats markdown, such as the body of an issue, for reading
+// in a terminal. HTML comments, which issue templates are full of, are left
+// out. With colorize, headings, emphasis, code, and links are styled with
+// escape sequences.
+func RenderMarkdown(src string, colorize bool) string {
+	src = strings.Replace(src, "\r\n", "\n", -1)
+	parser := blackfriday.New(blackfriday.WithExtensions(markdownExtensions))
+	ast := parser.Parse([]byte(src))
+
+	r := &markdownRenderer{colorize: colorize}
+	return strings.Join(r.blocks(ast), "\n\n")
+}
+
+type markdownRenderer struct {
+	colorize bool
+}
+
+func (r *markdownRenderer) style(on, off int, text string) string {
+	if !r.colorize || text == "" {
+		return text
+	}
+	return fmt.Sprintf("\033[%dm%s\033[%dm", on, text, off)
+}
+
+func (r *markdownRenderer) blocks(parent *blackfriday.Node) []string {
+	blocks := []string{}
+	for node := parent.FirstChild; node != nil; node = node.Next {
+		if block := r.block(node); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+func (r *markdownRenderer) block(node *blackfriday.Node) string {
+	switch node.Type {
+	case blackfriday.Heading:
+		return r.style(1, 22, strings.Repeat("#", node.HeadingData.Level)+" "+strings.TrimRight(r.inline(node), "\n"))
+	case blackfriday.HorizontalRule:
+		return r.style(90, 39, strings.Repeat("─", 20))
+	case blackfriday.CodeBlock:
+		lines := strings.Split(strings.TrimSuffix(string(node.Literal), "\n"), "\n")
+		for i, line := range lines {
+			lines[i] = "    " + r.style(36, 39, line)
+		}
+		return strings.Join(lines, "\n")
+	case blackfriday.BlockQuote:
+		lines := strings.Split(strings.Join(r.blocks(node), "\n\n"), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(r.style(90, 39, "│")+" "+line, " ")
+		}
+		return strings.Join(lines, "\n")
+	case blackfriday.List:
+		return r.list(node)
+	case blackfriday.Table:
+		return r.table(node)
+	case blackfriday.HTMLBlock:
+		if isHTMLComment(node.Literal) {
+			return ""
+		}
+		return strings.TrimSpace(string(node.Literal))
+	}
+	return strings.TrimRight(r.inline(node), "\n")
+}
+
+func (r *markdownRenderer) list(node *blackfriday.Node) string {
+	separator := "\n\n"
+	if node.ListData.Tight {
+		separator = "\n"
+	}
+
+	items := []string{}
+	number := 0
+	for item := node.FirstChild; item != nil; item = item.Next {
+		number++
+		marker := "• "
+		if node.ListFlags&blackfriday.ListTypeOrdered != 0 {
+			marker = fmt.Sprintf("%d. ", number)
+		}
+		indent := strings.Repeat(" ", DisplayWidth(marker))
+
+		lines := strings.Split(strings.Join(r.blocks(item), separator), "\n")
+		for i, line := range lines {
+			if i == 0 {
+				lines[i] = marker + line
+			} else if line != "" {
+				lines[i] = indent + line
+			}
+		}
+		items = append(items, strings.Join(lines, "\n"))
+	}
+	return strings.Join(items, separator)
+}
+
+func (r *markdownRenderer) table(node *blackfriday.Node) string {
+	table := NewTable()
+	table.Colorize = r.colorize
+	node.Walk(func(row *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if !entering || row.Type != blackfriday.TableRow {
+			return blackfriday.GoToNext
+		}
+		cells := []TableCell{}
+		for cell := row.FirstChild; cell != nil; cell = cell.Next {
+			if len(table.Columns) < len(cells)+1 {
+				table.Columns = append(table.Columns, TableColumn{
+					AlignRight: cell.Align == blackfriday.TableAlignmentRight,
+				})
+			}
+			tableCell := TableCell{Text: strings.TrimSpace(r.inline(cell))}
+			if cell.IsHeader {
+				tableCell.Color = "1"
+			}
+			cells = append(cells, tableCell)
+		}
+		table.AddRow(cells...)
+		return blackfriday.SkipChildren
+	})
+
+	buf := &bytes.Buffer{}
+	table.Render(buf)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func (r *markdownRenderer) inline(parent *blackfriday.Node) string {
+	text := &strings.Builder{}
+	for node := parent.FirstChild; node != nil; node = node.Next {
+		switch node.Type {
+		case blackfriday.Text:
+			text.Write(node.Literal)
+		case blackfriday.Softbreak, blackfriday.Hardbreak:
+			text.WriteString("\n")
+		case blackfriday.Emph:
+			text.WriteString(r.style(3, 23, r.inline(node)))
+		case blackfriday.Strong:
+			text.WriteString(r.style(1, 22, r.inline(node)))
+		case blackfriday.Del:
+			text.WriteString(r.style(9, 29, r.inline(node)))
+		case blackfriday.Code:
+			if r.colorize {
+				text.WriteString(r.style(36, 39, string(node.Literal)))
+			} else {
+				text.WriteString("`" + string(node.Literal) + "`")
+			}
+		case blackfriday.Link:
+			label := r.inline(node)
+			destination := strings.TrimPrefix(string(node.LinkData.Destination), "mailto:")
+			text.WriteString(r.style(4, 24, label))
+			if label != destination {
+				text.WriteString(" " + r.style(90, 39, "("+destination+")"))
+			}
+		case blackfriday.Image:
+			text.WriteString(fmt.Sprintf("[image: %s] ", r.inline(node)))
+			text.WriteString(r.style(90, 39, "("+string(node.LinkData.Destination)+")"))
+		case blackfriday.HTMLSpan:
+			if tag := strings.ToLower(string(node.Literal)); tag == "<br>" || tag == "<br/>" || tag == "<br />" {
+				text.WriteString("\n")
+			} else if !isHTMLComment(node.Literal) {
+				text.Write(node.Literal)
+			}
+		default:
+			text.WriteString(r.inline(node))
+		}
+	}
+	return text.String()
+}
+
+func isHTMLComment(html []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(html), []byte("<!--"))
+}
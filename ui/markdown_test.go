@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	src := "<!-- Describe the bug\n-->\n" +
+		"## Steps\n\n" +
+		"Run **this** with `--verbose`\r\nthen see [the docs](https://hub.github.com)\n\n" +
+		"- one\n- two\n  - nested\n\n" +
+		"1. first\n2. second\n\n" +
+		"> quoted\n\n" +
+		"```\ngo build\n```\n"
+
+	assert.Equal(t, ""+
+		"## Steps\n\n"+
+		"Run this with `--verbose`\n"+
+		"then see the docs (https://hub.github.com)\n\n"+
+		"• one\n"+
+		"• two\n"+
+		"  • nested\n\n"+
+		"1. first\n"+
+		"2. second\n\n"+
+		"│ quoted\n\n"+
+		"    go build", RenderMarkdown(src, false))
+}
+
+func TestRenderMarkdown_Colorize(t *testing.T) {
+	assert.Equal(t, "\033[1m# Title\033[22m\n\nSome \033[3memphasis\033[23m and \033[36mcode\033[39m, see \033[4mhttps://github.com\033[24m",
+		RenderMarkdown("# Title\n\nSome _emphasis_ and `code`, see https://github.com", true))
+}
+
+func TestRenderMarkdown_Table(t *testing.T) {
+	src := "| Name | Count |\n|------|------:|\n| bugs | 12 |\n| features | 3 |\n"
+	assert.Equal(t, ""+
+		"Name      Count\n"+
+		"bugs         12\n"+
+		"features      3", RenderMarkdown(src, false))
+}
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// OutputFormats lists the structured output formats supported by
+// WriteRecords.
+var OutputFormats = []string{"json", "yaml", "csv", "tsv"}
+
+// A Field is a single named value of a Record.
+type Field struct {
+	Name  string
+	Value interface{}
+}
+
+// A Record is one item of structured output. Fields are serialized in the
+// order they appear in, which is also the column order for CSV and TSV.
+type Record []Field
+
+// With returns the record extended by a field.
+func (r Record) With(name string, value interface{}) Record {
+	return append(r, Field{Name: name, Value: value})
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, f := range r {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeJSON(buf, f.Name); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := writeJSON(buf, normalizeValue(f.Value)); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func writeJSON(buf *bytes.Buffer, v interface{}) error {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
+
+func (r Record) mapSlice() yaml.MapSlice {
+	m := make(yaml.MapSlice, len(r))
+	for i, f := range r {
+		m[i] = yaml.MapItem{Key: f.Name, Value: normalizeValue(f.Value)}
+	}
+	return m
+}
+
+// WriteRecords serializes records to `out` in one of the OutputFormats.
+func WriteRecords(out io.Writer, format string, records []Record) error {
+	return WriteRecordsWithColumns(out, format, records, nil)
+}
+
+// WriteRecordsWithColumns is like WriteRecords, but the header row of CSV and
+// TSV is made of the field names of columns, so that it is written even if
+// there are no records.
+func WriteRecordsWithColumns(out io.Writer, format string, records []Record, columns Record) error {
+	switch format {
+	case "json":
+		if records == nil {
+			records = []Record{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetEscapeHTML(false)
+		return enc.Encode(records)
+	case "yaml":
+		items := make([]yaml.MapSlice, len(records))
+		for i, r := range records {
+			items[i] = r.mapSlice()
+		}
+		data, err := yaml.Marshal(items)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	case "csv", "tsv":
+		if columns == nil && len(records) > 0 {
+			columns = records[0]
+		}
+		if columns == nil {
+			return nil
+		}
+		rows := [][]string{}
+		header := make([]string, len(columns))
+		for j, f := range columns {
+			header[j] = f.Name
+		}
+		rows = append(rows, header)
+		for _, r := range records {
+			row := make([]string, len(r))
+			for j, f := range r {
+				row[j] = cellValue(f.Value)
+			}
+			rows = append(rows, row)
+		}
+		if format == "tsv" {
+			return writeTSV(out, rows)
+		}
+		w := csv.NewWriter(out)
+		w.WriteAll(rows)
+		return w.Error()
+	default:
+		return fmt.Errorf("invalid output format: '%s' (expected one of: %s)", format, strings.Join(OutputFormats, ", "))
+	}
+}
+
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// writeTSV writes rows as tab-separated values. Instead of quoting values like
+// CSV does, backslashes, tabs, and line breaks in them are escaped as "\\",
+// "\t", "\n", and "\r", so that every line is exactly one row.
+func writeTSV(out io.Writer, rows [][]string) error {
+	buf := &bytes.Buffer{}
+	for _, row := range rows {
+		for j, value := range row {
+			if j > 0 {
+				buf.WriteByte('\t')
+			}
+			buf.WriteString(tsvEscaper.Replace(value))
+		}
+		buf.WriteByte('\n')
+	}
+	_, err := out.Write(buf.Bytes())
+	return err
+}
+
+// WriteRecord serializes a single record to `out` as a JSON object.
+func WriteRecord(out io.Writer, record Record) error {
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(record)
+}
+
+func normalizeValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case time.Time:
+		if t.IsZero() {
+			return nil
+		}
+		return t.Format(time.RFC3339)
+	case []string:
+		if t == nil {
+			return []string{}
+		}
+	}
+	return v
+}
+
+func cellValue(v interface{}) string {
+	switch t := normalizeValue(v).(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case []string:
+		return strings.Join(t, ",")
+	default:
+		return fmt.Sprint(t)
+	}
+}
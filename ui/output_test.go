@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func outputRecords() []Record {
+	created := time.Date(2020, 4, 1, 12, 30, 0, 0, time.UTC)
+	return []Record{
+		Record{}.
+			With("number", 12).
+			With("title", "Fix \"quoted\" <title>").
+			With("labels", []string{"bug", "help wanted"}).
+			With("created_at", created).
+			With("closed_at", time.Time{}),
+		Record{}.
+			With("number", 13).
+			With("title", "Tabs\tand, commas").
+			With("labels", []string(nil)).
+			With("created_at", created).
+			With("closed_at", created),
+	}
+}
+
+func TestWriteRecords(t *testing.T) {
+	tests := []struct {
+		format string
+		expect string
+	}{
+		{
+			format: "json",
+			expect: `[{"number":12,"title":"Fix \"quoted\" <title>","labels":["bug","help wanted"],"created_at":"2020-04-01T12:30:00Z","closed_at":null},` +
+				`{"number":13,"title":"Tabs\tand, commas","labels":[],"created_at":"2020-04-01T12:30:00Z","closed_at":"2020-04-01T12:30:00Z"}]` + "\n",
+		},
+		{
+			format: "yaml",
+			expect: `- number: 12
+  title: Fix "quoted" <title>
+  labels:
+  - bug
+  - help wanted
+  created_at: "2020-04-01T12:30:00Z"
+  closed_at: null
+- number: 13
+  title: "Tabs\tand, commas"
+  labels: []
+  created_at: "2020-04-01T12:30:00Z"
+  closed_at: "2020-04-01T12:30:00Z"
+`,
+		},
+		{
+			format: "csv",
+			expect: `number,title,labels,created_at,closed_at
+12,"Fix ""quoted"" <title>","bug,help wanted",2020-04-01T12:30:00Z,
+13,"Tabs	and, commas",,2020-04-01T12:30:00Z,2020-04-01T12:30:00Z
+`,
+		},
+		{
+			format: "tsv",
+			expect: "number\ttitle\tlabels\tcreated_at\tclosed_at\n" +
+				"12\tFix \"quoted\" <title>\tbug,help wanted\t2020-04-01T12:30:00Z\t\n" +
+				"13\tTabs\\tand, commas\t\t2020-04-01T12:30:00Z\t2020-04-01T12:30:00Z\n",
+		},
+	}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		if err := WriteRecords(buf, test.format, outputRecords()); err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.format, err)
+		}
+		if got := buf.String(); got != test.expect {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.format, got, test.expect)
+		}
+	}
+}
+
+func TestWriteRecords_Empty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteRecords(buf, "json", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "[]\n" {
+		t.Errorf("got %q", got)
+	}
+
+	buf.Reset()
+	if err := WriteRecords(buf, "csv", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestWriteRecords_Escaping(t *testing.T) {
+	records := []Record{
+		Record{}.With("title", "Line\r\nbreak").With("path", `C:\dir`),
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteRecords(buf, "csv", records); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "title,path\n\"Line\r\nbreak\",C:\\dir\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := WriteRecords(buf, "tsv", records); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "title\tpath\nLine\\r\\nbreak\tC:\\\\dir\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWriteRecordsWithColumns(t *testing.T) {
+	columns := Record{}.With("number", 0).With("title", "")
+	for format, want := range map[string]string{
+		"csv":  "number,title\n",
+		"tsv":  "number\ttitle\n",
+		"json": "[]\n",
+	} {
+		buf := &bytes.Buffer{}
+		if err := WriteRecordsWithColumns(buf, format, nil, columns); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("%s: got %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestWriteRecords_InvalidFormat(t *testing.T) {
+	err := WriteRecords(&bytes.Buffer{}, "xml", outputRecords())
+	if err == nil || err.Error() != "invalid output format: 'xml' (expected one of: json, yaml, csv, tsv)" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
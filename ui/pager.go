@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/kballard/go-shellquote"
+)
+
+var (
+	pager      *exec.Cmd
+	pagerInput io.WriteCloser
+	pagerMutex sync.Mutex
+	pagerSigs  chan os.Signal
+	pagedOut   io.Writer
+)
+
+// StartPager sends everything printed to Stdout from now on through the pager
+// program, such as "less", until StopPager is called. Like git, it sets LESS
+// to "FRX" unless it is already set, so that less quits right away when the
+// output fits on one screen and passes colors through.
+func StartPager(program string) error {
+	pagerMutex.Lock()
+	defer pagerMutex.Unlock()
+	if pager != nil {
+		return nil
+	}
+
+	words, err := shellquote.Split(program)
+	if err != nil {
+		return err
+	} else if len(words) == 0 {
+		return nil
+	}
+
+	c := exec.Command(words[0], words[1:]...)
+	c.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		c.Env = append(c.Env, "LESS=FRX")
+	}
+	if os.Getenv("LV") == "" {
+		c.Env = append(c.Env, "LV=-c")
+	}
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	input, err := c.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err = c.Start(); err != nil {
+		return err
+	}
+
+	pager, pagerInput = c, input
+	pagedOut, Stdout = Stdout, input
+	Default = Console{Stdout: Stdout, Stderr: Stderr}
+
+	// Interrupting hub mustn't leave the pager behind in control of the
+	// terminal.
+	pagerSigs = make(chan os.Signal, 1)
+	signal.Notify(pagerSigs, os.Interrupt, syscall.SIGTERM)
+	go func(sigs chan os.Signal) {
+		if _, ok := <-sigs; ok {
+			StopPager()
+			os.Exit(130)
+		}
+	}(pagerSigs)
+
+	return nil
+}
+
+// StopPager finishes the output to the pager started with StartPager, if any,
+// and waits for the user to quit it.
+func StopPager() {
+	pagerMutex.Lock()
+	defer pagerMutex.Unlock()
+	if pager == nil {
+		return
+	}
+
+	pagerInput.Close()
+	pager.Wait()
+	signal.Stop(pagerSigs)
+	close(pagerSigs)
+
+	pager, pagerInput = nil, nil
+	Stdout = pagedOut
+	Default = Console{Stdout: Stdout, Stderr: Stderr}
+}
+
+// Exit stops the pager, if any, and then exits with the code.
+func Exit(code int) {
+	StopPager()
+	os.Exit(code)
+}
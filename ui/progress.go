@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const progressInterval = 100 * time.Millisecond
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// progressEnabled reports whether progress can be drawn on stderr: it has to
+// be a terminal that isn't taken over by a pager or by verbose output, and
+// hub mustn't have been asked to be quiet.
+func progressEnabled() bool {
+	pagerMutex.Lock()
+	paging := pager != nil
+	pagerMutex.Unlock()
+	return !paging && !IsQuiet() && IsTerminal(os.Stderr) && os.Getenv("HUB_VERBOSE") == ""
+}
+
+// Spinner shows on stderr that something is going on, such as waiting for a
+// response from the API, so that hub doesn't appear hung. It does nothing if
+// stderr isn't a terminal.
+type Spinner struct {
+	message string
+	stop    chan struct{}
+	done    sync.WaitGroup
+}
+
+// StartSpinner shows a spinner followed by message until Stop is called. The
+// spinner only appears if the operation takes a moment.
+func StartSpinner(message string) *Spinner {
+	s := &Spinner{message: message}
+	if !progressEnabled() {
+		return s
+	}
+
+	s.stop = make(chan struct{})
+	s.done.Add(1)
+	go func() {
+		defer s.done.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		drawn := false
+		for frame := 0; ; frame++ {
+			select {
+			case <-s.stop:
+				if drawn {
+					fmt.Fprint(Stderr, "\r\033[K")
+				}
+				return
+			case <-ticker.C:
+				fmt.Fprintf(Stderr, "\r%s %s\033[K", spinnerFrames[frame%len(spinnerFrames)], s.message)
+				drawn = true
+			}
+		}
+	}()
+	return s
+}
+
+// Stop removes the spinner. It is safe to call more than once.
+func (s *Spinner) Stop() {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	s.done.Wait()
+	s.stop = nil
+}
+
+// ProgressBar shows on stderr how many bytes of a transfer are done. It is an
+// io.Writer so that it can count the bytes passing through an io.TeeReader.
+// It does nothing if stderr isn't a terminal.
+type ProgressBar struct {
+	label    string
+	total    int64
+	current  int64
+	items    bool
+	enabled  bool
+	lastDraw time.Time
+	mutex    sync.Mutex
+}
+
+// NewProgressBar prepares a progress bar for a transfer of total bytes.
+func NewProgressBar(label string, total int64) *ProgressBar {
+	return &ProgressBar{
+		label:   label,
+		total:   total,
+		enabled: progressEnabled(),
+	}
+}
+
+// NewProgressCounter prepares a progress bar for total items of work, such as
+// API requests, which it shows as a count instead of in bytes.
+func NewProgressCounter(label string, total int) *ProgressBar {
+	p := NewProgressBar(label, int64(total))
+	p.items = true
+	return p
+}
+
+func (p *ProgressBar) Write(b []byte) (int, error) {
+	p.Add(int64(len(b)))
+	return len(b), nil
+}
+
+// Add counts n more bytes as transferred. It is safe to call from several
+// goroutines.
+func (p *ProgressBar) Add(n int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.current += n
+	if p.enabled && (time.Since(p.lastDraw) >= progressInterval || p.current >= p.total) {
+		p.draw()
+	}
+}
+
+// Clear removes the progress bar from the screen, e.g. to print a message in
+// its place. It is drawn again on the next Add.
+func (p *ProgressBar) Clear() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.clear()
+}
+
+// Println prints a message to stdout in place of the progress bar. Unlike
+// calling Clear and then printing, it is safe to call from several goroutines.
+func (p *ProgressBar) Println(a ...interface{}) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.clear()
+	Println(a...)
+}
+
+func (p *ProgressBar) clear() {
+	if p.enabled && !p.lastDraw.IsZero() {
+		fmt.Fprint(Stderr, "\r\033[K")
+		p.lastDraw = time.Time{}
+	}
+}
+
+// Done removes the progress bar once the transfer is finished or failed.
+func (p *ProgressBar) Done() {
+	p.Clear()
+	p.mutex.Lock()
+	p.enabled = false
+	p.mutex.Unlock()
+}
+
+func (p *ProgressBar) draw() {
+	const width = 20
+	ratio := 1.0
+	if p.total > 0 && p.current < p.total {
+		ratio = float64(p.current) / float64(p.total)
+	}
+	filled := int(ratio * width)
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", width-filled)
+	if filled > 0 && filled < width {
+		bar = bar[:filled-1] + ">" + bar[filled:]
+	}
+
+	current, total := formatBytes(p.current), formatBytes(p.total)
+	if p.items {
+		current, total = fmt.Sprint(p.current), fmt.Sprint(p.total)
+	}
+	fmt.Fprintf(Stderr, "\r%s [%s] %3d%% %s/%s\033[K", p.label, bar, int(ratio*100), current, total)
+	p.lastDraw = time.Now()
+}
+
+// formatBytes formats a number of bytes for humans, e.g. "1.5 MB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value := float64(n) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f TB", value)
+}
@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KB", formatBytes(1536))
+	assert.Equal(t, "2.0 MB", formatBytes(2*1024*1024))
+	assert.Equal(t, "1.0 TB", formatBytes(1024*1024*1024*1024))
+}
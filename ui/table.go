@@ -0,0 +1,310 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var escapeSequence = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// DisplayWidth is the number of terminal columns that s takes up. Wide
+// characters, such as CJK ideographs and most emoji, take up two columns, and
+// combining marks and color escape sequences take up none.
+func DisplayWidth(s string) int {
+	width := 0
+	for _, r := range escapeSequence.ReplaceAllString(s, "") {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// TruncateWidth shortens s to at most width columns, replacing the end of it
+// with "..". s shouldn't contain escape sequences.
+func TruncateWidth(s string, width int) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	if width < 2 {
+		return strings.Repeat(".", width)
+	}
+
+	return prefixOfWidth(s, width-2) + ".."
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r == 0 || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// wideRanges are the code points that the Unicode East Asian Width property
+// classifies as Wide or Fullwidth.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f},
+	{0x231a, 0x231b},
+	{0x2329, 0x232a},
+	{0x23e9, 0x23ec},
+	{0x23f0, 0x23f0},
+	{0x23f3, 0x23f3},
+	{0x25fd, 0x25fe},
+	{0x2614, 0x2615},
+	{0x2648, 0x2653},
+	{0x267f, 0x267f},
+	{0x2693, 0x2693},
+	{0x26a1, 0x26a1},
+	{0x26aa, 0x26ab},
+	{0x26bd, 0x26be},
+	{0x26c4, 0x26c5},
+	{0x26ce, 0x26ce},
+	{0x26d4, 0x26d4},
+	{0x26ea, 0x26ea},
+	{0x26f2, 0x26f3},
+	{0x26f5, 0x26f5},
+	{0x26fa, 0x26fa},
+	{0x26fd, 0x26fd},
+	{0x2705, 0x2705},
+	{0x270a, 0x270b},
+	{0x2728, 0x2728},
+	{0x274c, 0x274c},
+	{0x274e, 0x274e},
+	{0x2753, 0x2755},
+	{0x2757, 0x2757},
+	{0x2795, 0x2797},
+	{0x27b0, 0x27b0},
+	{0x27bf, 0x27bf},
+	{0x2b1b, 0x2b1c},
+	{0x2b50, 0x2b50},
+	{0x2b55, 0x2b55},
+	{0x2e80, 0x303e},
+	{0x3041, 0x33ff},
+	{0x3400, 0x4dbf},
+	{0x4e00, 0x9fff},
+	{0xa000, 0xa4cf},
+	{0xa960, 0xa97f},
+	{0xac00, 0xd7a3},
+	{0xf900, 0xfaff},
+	{0xfe10, 0xfe19},
+	{0xfe30, 0xfe6f},
+	{0xff00, 0xff60},
+	{0xffe0, 0xffe6},
+	{0x16fe0, 0x16fe4},
+	{0x17000, 0x18cff},
+	{0x1b000, 0x1b2ff},
+	{0x1f004, 0x1f004},
+	{0x1f0cf, 0x1f0cf},
+	{0x1f18e, 0x1f18e},
+	{0x1f191, 0x1f19a},
+	{0x1f200, 0x1f251},
+	{0x1f300, 0x1f320},
+	{0x1f32d, 0x1f335},
+	{0x1f337, 0x1f37c},
+	{0x1f37e, 0x1f393},
+	{0x1f3a0, 0x1f3ca},
+	{0x1f3cf, 0x1f3d3},
+	{0x1f3e0, 0x1f3f0},
+	{0x1f3f4, 0x1f3f4},
+	{0x1f3f8, 0x1f43e},
+	{0x1f440, 0x1f440},
+	{0x1f442, 0x1f4fc},
+	{0x1f4ff, 0x1f53d},
+	{0x1f54b, 0x1f54e},
+	{0x1f550, 0x1f567},
+	{0x1f57a, 0x1f57a},
+	{0x1f595, 0x1f596},
+	{0x1f5a4, 0x1f5a4},
+	{0x1f5fb, 0x1f64f},
+	{0x1f680, 0x1f6c5},
+	{0x1f6cc, 0x1f6cc},
+	{0x1f6d0, 0x1f6d2},
+	{0x1f6d5, 0x1f6d7},
+	{0x1f6eb, 0x1f6ec},
+	{0x1f6f4, 0x1f6fc},
+	{0x1f7e0, 0x1f7eb},
+	{0x1f90c, 0x1f93a},
+	{0x1f93c, 0x1f945},
+	{0x1f947, 0x1f9ff},
+	{0x1fa70, 0x1faff},
+	{0x20000, 0x2fffd},
+	{0x30000, 0x3fffd},
+}
+
+func isWide(r rune) bool {
+	if r < wideRanges[0][0] {
+		return false
+	}
+	for _, wr := range wideRanges {
+		if r < wr[0] {
+			return false
+		} else if r <= wr[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// ColorNames lists the color names that ColorCode accepts.
+func ColorNames() []string {
+	names := []string{}
+	for name := range colorMap {
+		if name != "reset" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ColorCode returns the SGR parameter for the color name, e.g. "31" for "red".
+func ColorCode(name string) (string, bool) {
+	code, ok := colorMap[name]
+	return code, ok && name != "reset"
+}
+
+type TableColumn struct {
+	AlignRight bool
+	// MinWidth pads the column to this width even if its cells are narrower.
+	MinWidth int
+	// Truncate lets the cells of the column be shortened so that the table
+	// fits within the MaxWidth of the table.
+	Truncate bool
+}
+
+type TableCell struct {
+	Text string
+	// Color is the SGR parameter of the color for the cell, e.g. "32" for
+	// green. See ColorCode.
+	Color string
+}
+
+type tableRow struct {
+	cells []TableCell
+	line  string
+}
+
+// Table renders rows of cells as aligned columns. The widths of the columns
+// follow from the display width of their cells.
+type Table struct {
+	Columns []TableColumn
+	// Separator goes between columns (default: two spaces).
+	Separator string
+	// MaxWidth is the display width that the table is truncated to, if any,
+	// by shortening the cells of columns that allow it.
+	MaxWidth int
+	Colorize bool
+	rows     []tableRow
+}
+
+func NewTable(columns ...TableColumn) *Table {
+	return &Table{Columns: columns, Separator: "  "}
+}
+
+// AddRow adds a row with a cell for each column. Trailing empty cells are left
+// out of the output along with the separators before them.
+func (t *Table) AddRow(cells ...TableCell) {
+	t.rows = append(t.rows, tableRow{cells: cells})
+}
+
+// AddLine adds a line that is printed as is between the rows and doesn't
+// affect the widths of the columns.
+func (t *Table) AddLine(line string) {
+	t.rows = append(t.rows, tableRow{line: line})
+}
+
+func (t *Table) widths() []int {
+	widths := make([]int, len(t.Columns))
+	for i, column := range t.Columns {
+		widths[i] = column.MinWidth
+	}
+	for _, row := range t.rows {
+		for i, cell := range row.cells {
+			if w := DisplayWidth(cell.Text); i < len(widths) && w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	if t.MaxWidth <= 0 {
+		return widths
+	}
+	total := DisplayWidth(t.Separator) * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for i, column := range t.Columns {
+		if total <= t.MaxWidth {
+			break
+		}
+		if !column.Truncate {
+			continue
+		}
+		// keep at least some of the text legible
+		minWidth := 10
+		if minWidth > widths[i] {
+			minWidth = widths[i]
+		}
+		reduce := total - t.MaxWidth
+		if widths[i]-reduce < minWidth {
+			reduce = widths[i] - minWidth
+		}
+		widths[i] -= reduce
+		total -= reduce
+	}
+	return widths
+}
+
+func (t *Table) Render(out io.Writer) error {
+	widths := t.widths()
+
+	for _, row := range t.rows {
+		if row.cells == nil {
+			if _, err := fmt.Fprintln(out, row.line); err != nil {
+				return err
+			}
+			continue
+		}
+
+		last := -1
+		for i, cell := range row.cells {
+			if cell.Text != "" && i < len(widths) {
+				last = i
+			}
+		}
+
+		line := &strings.Builder{}
+		for i := 0; i <= last; i++ {
+			cell := row.cells[i]
+			column := t.Columns[i]
+			text := cell.Text
+			if column.Truncate {
+				text = TruncateWidth(text, widths[i])
+			}
+
+			if padding := widths[i] - DisplayWidth(text); column.AlignRight {
+				text = strings.Repeat(" ", padding) + text
+			} else if i < last {
+				text += strings.Repeat(" ", padding)
+			}
+			if t.Colorize && cell.Color != "" {
+				text = fmt.Sprintf("\033[%sm%s\033[m", cell.Color, text)
+			}
+
+			if i > 0 {
+				line.WriteString(t.Separator)
+			}
+			line.WriteString(text)
+		}
+		if _, err := fmt.Fprintln(out, line.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	assert.Equal(t, 5, DisplayWidth("hello"))
+	assert.Equal(t, 8, DisplayWidth("修正バグ"))
+	assert.Equal(t, 2, DisplayWidth("🚀"))
+	assert.Equal(t, 1, DisplayWidth("✔︎"))
+	assert.Equal(t, 4, DisplayWidth("café"))
+	assert.Equal(t, 5, DisplayWidth("\033[32mhello\033[m"))
+}
+
+func TestTruncateWidth(t *testing.T) {
+	assert.Equal(t, "hello", TruncateWidth("hello", 5))
+	assert.Equal(t, "hel..", TruncateWidth("hello world", 5))
+	assert.Equal(t, "修..", TruncateWidth("修正バグ", 5))
+	assert.Equal(t, ".", TruncateWidth("hello", 1))
+}
+
+func TestTable(t *testing.T) {
+	table := NewTable(TableColumn{AlignRight: true}, TableColumn{}, TableColumn{})
+	table.AddRow(TableCell{Text: "#1"}, TableCell{Text: "修正バグ"}, TableCell{Text: "bug"})
+	table.AddRow(TableCell{Text: "#102"}, TableCell{Text: "Fix it"}, TableCell{})
+	table.AddLine("  note")
+	table.AddRow(TableCell{Text: "#13"}, TableCell{Text: "Add a feature"}, TableCell{Text: "feature"})
+
+	out := &bytes.Buffer{}
+	assert.Equal(t, nil, table.Render(out))
+	assert.Equal(t, ""+
+		"  #1  修正バグ       bug\n"+
+		"#102  Fix it\n"+
+		"  note\n"+
+		" #13  Add a feature  feature\n", out.String())
+}
+
+func TestTable_MinWidthAndSeparator(t *testing.T) {
+	table := NewTable(TableColumn{AlignRight: true, MinWidth: 6}, TableColumn{})
+	table.Separator = "\t"
+	table.AddRow(TableCell{Text: "#1"}, TableCell{Text: "one"})
+
+	out := &bytes.Buffer{}
+	assert.Equal(t, nil, table.Render(out))
+	assert.Equal(t, "    #1\tone\n", out.String())
+}
+
+func TestTable_Truncate(t *testing.T) {
+	table := NewTable(TableColumn{}, TableColumn{Truncate: true}, TableColumn{})
+	table.MaxWidth = 30
+	table.AddRow(TableCell{Text: "#1"}, TableCell{Text: "A title that is much too long to fit"}, TableCell{Text: "bug"})
+	table.AddRow(TableCell{Text: "#2"}, TableCell{Text: "Short"}, TableCell{Text: "docs"})
+
+	out := &bytes.Buffer{}
+	assert.Equal(t, nil, table.Render(out))
+	assert.Equal(t, ""+
+		"#1  A title that is mu..  bug\n"+
+		"#2  Short                 docs\n", out.String())
+}
+
+func TestTable_Colorize(t *testing.T) {
+	table := NewTable(TableColumn{AlignRight: true}, TableColumn{})
+	table.AddRow(TableCell{Text: "#1", Color: "32"}, TableCell{Text: "one"})
+	table.AddRow(TableCell{Text: "#10", Color: "31"}, TableCell{Text: "ten"})
+
+	out := &bytes.Buffer{}
+	assert.Equal(t, nil, table.Render(out))
+	assert.Equal(t, " #1  one\n#10  ten\n", out.String())
+
+	table.Colorize = true
+	out.Reset()
+	assert.Equal(t, nil, table.Render(out))
+	assert.Equal(t, "\033[32m #1\033[m  one\n\033[31m#10\033[m  ten\n", out.String())
+}
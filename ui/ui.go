@@ -7,6 +7,7 @@ import (
 
 	"github.com/mattn/go-colorable"
 	"github.com/mattn/go-isatty"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 type UI interface {
@@ -68,10 +69,46 @@ func Errorln(a ...interface{}) (n int) {
 	return
 }
 
+// IsQuiet reports whether informational output is suppressed with the
+// `--quiet` flag.
+func IsQuiet() bool {
+	return os.Getenv("HUB_QUIET") != ""
+}
+
+// Infof prints an informational message, such as the confirmation that
+// something was done, unless hub was asked to be quiet.
+func Infof(format string, a ...interface{}) (n int) {
+	if IsQuiet() {
+		return 0
+	}
+	return Printf(format, a...)
+}
+
+// Infoln is like Infof, but formats its arguments like Println.
+func Infoln(a ...interface{}) (n int) {
+	if IsQuiet() {
+		return 0
+	}
+	return Println(a...)
+}
+
 func IsTerminal(f *os.File) bool {
 	return isatty.IsTerminal(f.Fd())
 }
 
+// TerminalWidth is the number of columns of the terminal that f is attached
+// to, or 0 if it isn't a terminal.
+func TerminalWidth(f *os.File) int {
+	if !IsTerminal(f) {
+		return 0
+	}
+	width, _, err := terminal.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
 type Console struct {
 	Stdout io.Writer
 	Stderr io.Writer
@@ -0,0 +1,1273 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// JQ is a compiled expression in a subset of the jq language: paths, array
+// iteration, pipes, comparisons, boolean logic, the alternative operator,
+// array and object construction, string interpolation, and the most commonly
+// used builtin functions.
+type JQ struct {
+	root jqNode
+}
+
+// CompileJQ parses a jq expression.
+func CompileJQ(expr string) (*JQ, error) {
+	p := &jqParser{src: expr}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	root, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("jq: unexpected %q", p.tokens[p.pos].text)
+	}
+	return &JQ{root: root}, nil
+}
+
+// Evaluate runs the expression against a decoded JSON value and returns all of
+// its results.
+func (q *JQ) Evaluate(input interface{}) ([]interface{}, error) {
+	return q.root.eval(input)
+}
+
+// Execute runs the expression against a decoded JSON value and writes each
+// result on its own line. Strings are written as-is; other values are
+// written as JSON.
+func (q *JQ) Execute(out io.Writer, input interface{}) error {
+	results, err := q.Evaluate(input)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if s, ok := result.(string); ok {
+			fmt.Fprintln(out, s)
+			continue
+		}
+		encoded, err := jqEncode(result)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, encoded)
+	}
+	return nil
+}
+
+func jqEncode(v interface{}) (string, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// Lexer
+
+type jqTokenKind int
+
+const (
+	jqPunctToken jqTokenKind = iota
+	jqIdentToken
+	jqFieldToken
+	jqNumberToken
+	jqStringToken
+)
+
+type jqToken struct {
+	kind  jqTokenKind
+	text  string
+	parts []interface{} // for strings: literal text, or the source of an interpolation
+}
+
+type jqParser struct {
+	src    string
+	tokens []jqToken
+	pos    int
+}
+
+var jqPunctuation = []string{"==", "!=", "<=", ">=", "//", "|", ",", ".", "[", "]", "(", ")", "{", "}", ":", "?", "<", ">", "+", "-"}
+
+func isJQIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isJQIdentChar(c byte) bool {
+	return isJQIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+func (p *jqParser) tokenize() error {
+	src := p.src
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '.' && i+1 < len(src) && isJQIdentStart(src[i+1]):
+			j := i + 1
+			for j < len(src) && isJQIdentChar(src[j]) {
+				j++
+			}
+			p.tokens = append(p.tokens, jqToken{kind: jqFieldToken, text: src[i+1 : j]})
+			i = j
+		case isJQIdentStart(c):
+			j := i
+			for j < len(src) && isJQIdentChar(src[j]) {
+				j++
+			}
+			p.tokens = append(p.tokens, jqToken{kind: jqIdentToken, text: src[i:j]})
+			i = j
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(src) && (src[j] >= '0' && src[j] <= '9' ||
+				src[j] == '.' && j+1 < len(src) && src[j+1] >= '0' && src[j+1] <= '9') {
+				j++
+			}
+			p.tokens = append(p.tokens, jqToken{kind: jqNumberToken, text: src[i:j]})
+			i = j
+		case c == '"':
+			token, end, err := lexJQString(src, i)
+			if err != nil {
+				return err
+			}
+			p.tokens = append(p.tokens, token)
+			i = end
+		default:
+			matched := false
+			for _, punct := range jqPunctuation {
+				if strings.HasPrefix(src[i:], punct) {
+					p.tokens = append(p.tokens, jqToken{kind: jqPunctToken, text: punct})
+					i += len(punct)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return fmt.Errorf("jq: unexpected character %q", c)
+			}
+		}
+	}
+	return nil
+}
+
+// lexJQString reads the string literal starting at the quote at src[start].
+// Interpolations like "\(.name)" are kept as source to be parsed separately.
+func lexJQString(src string, start int) (jqToken, int, error) {
+	token := jqToken{kind: jqStringToken, text: "string"}
+	literal := &strings.Builder{}
+	for i := start + 1; i < len(src); i++ {
+		c := src[i]
+		if c == '"' {
+			token.parts = append(token.parts, literal.String())
+			return token, i + 1, nil
+		}
+		if c != '\\' {
+			literal.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(src) {
+			break
+		}
+		switch src[i] {
+		case 'n':
+			literal.WriteByte('\n')
+		case 't':
+			literal.WriteByte('\t')
+		case 'r':
+			literal.WriteByte('\r')
+		case '(':
+			end, err := matchJQParen(src, i)
+			if err != nil {
+				return token, 0, err
+			}
+			token.parts = append(token.parts, literal.String(), jqInterpolation(src[i+1:end]))
+			literal.Reset()
+			i = end
+		default:
+			literal.WriteByte(src[i])
+		}
+	}
+	return token, 0, fmt.Errorf("jq: unterminated string")
+}
+
+type jqInterpolation string
+
+func matchJQParen(src string, open int) (int, error) {
+	depth := 0
+	inString := false
+	for i := open; i < len(src); i++ {
+		switch c := src[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("jq: unterminated string interpolation")
+}
+
+// Parser
+
+func (p *jqParser) peek() *jqToken {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *jqParser) isPunct(text string) bool {
+	t := p.peek()
+	return t != nil && t.kind == jqPunctToken && t.text == text
+}
+
+func (p *jqParser) isKeyword(text string) bool {
+	t := p.peek()
+	return t != nil && t.kind == jqIdentToken && t.text == text
+}
+
+func (p *jqParser) expect(text string) error {
+	if !p.isPunct(text) {
+		if t := p.peek(); t != nil {
+			return fmt.Errorf("jq: expected %q, got %q", text, t.text)
+		}
+		return fmt.Errorf("jq: expected %q at end of expression", text)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *jqParser) parsePipe() (jqNode, error) {
+	left, err := p.parseComma()
+	if err != nil {
+		return nil, err
+	}
+	if p.isPunct("|") {
+		p.pos++
+		right, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return &jqPipe{left, right}, nil
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseComma() (jqNode, error) {
+	left, err := p.parseAlternative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct(",") {
+		p.pos++
+		right, err := p.parseAlternative()
+		if err != nil {
+			return nil, err
+		}
+		left = &jqComma{left, right}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseAlternative() (jqNode, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("//") {
+		p.pos++
+		right, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		left = &jqAlternative{left, right}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseOr() (jqNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &jqBoolean{"or", left, right}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseAnd() (jqNode, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.pos++
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = &jqBoolean{"and", left, right}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseComparison() (jqNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.isPunct(op) {
+			p.pos++
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			return &jqBinary{op, left, right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseAdditive() (jqNode, error) {
+	left, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("+") || p.isPunct("-") {
+		op := p.peek().text
+		p.pos++
+		right, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+		left = &jqBinary{op, left, right}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parsePostfix() (jqNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case t == nil:
+			return node, nil
+		case t.kind == jqFieldToken:
+			p.pos++
+			node = &jqIndex{node, &jqLiteral{t.text}}
+		case t.kind == jqPunctToken && t.text == "." && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].kind == jqStringToken:
+			p.pos++
+			key, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			node = &jqIndex{node, key}
+		case t.kind == jqPunctToken && (t.text == "[" || t.text == "." && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].text == "["):
+			if t.text == "." {
+				p.pos++
+			}
+			p.pos++
+			if p.isPunct("]") {
+				p.pos++
+				node = &jqIterate{node}
+				continue
+			}
+			index, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			node = &jqIndex{node, index}
+		case t.kind == jqPunctToken && t.text == "?":
+			p.pos++
+			node = &jqTry{node}
+		default:
+			return node, nil
+		}
+	}
+}
+
+func (p *jqParser) parsePrimary() (jqNode, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, fmt.Errorf("jq: unexpected end of expression")
+	}
+	p.pos++
+
+	switch t.kind {
+	case jqFieldToken:
+		return &jqIndex{&jqIdentity{}, &jqLiteral{t.text}}, nil
+	case jqNumberToken:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("jq: invalid number %q", t.text)
+		}
+		return &jqLiteral{n}, nil
+	case jqStringToken:
+		return parseJQString(t)
+	case jqIdentToken:
+		switch t.text {
+		case "true":
+			return &jqLiteral{true}, nil
+		case "false":
+			return &jqLiteral{false}, nil
+		case "null":
+			return &jqLiteral{nil}, nil
+		}
+		call := &jqCall{name: t.text}
+		if p.isPunct("(") {
+			p.pos++
+			arg, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+		}
+		if _, ok := jqFunctions[call.name]; !ok || jqArity[call.name] != len(call.args) {
+			return nil, fmt.Errorf("jq: %s/%d is not defined", call.name, len(call.args))
+		}
+		return call, nil
+	}
+
+	switch t.text {
+	case ".":
+		return &jqIdentity{}, nil
+	case "(":
+		node, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return node, p.expect(")")
+	case "[":
+		if p.isPunct("]") {
+			p.pos++
+			return &jqArray{}, nil
+		}
+		node, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return &jqArray{node}, p.expect("]")
+	case "{":
+		return p.parseObject()
+	case "-":
+		operand, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+		return &jqBinary{"-", &jqLiteral{0.0}, operand}, nil
+	}
+	return nil, fmt.Errorf("jq: unexpected %q", t.text)
+}
+
+func (p *jqParser) parseObject() (jqNode, error) {
+	object := &jqObject{}
+	for !p.isPunct("}") {
+		t := p.peek()
+		if t == nil {
+			return nil, fmt.Errorf("jq: unterminated object")
+		}
+		var key jqNode
+		switch {
+		case t.kind == jqIdentToken:
+			p.pos++
+			key = &jqLiteral{t.text}
+		case t.kind == jqStringToken:
+			p.pos++
+			var err error
+			if key, err = parseJQString(t); err != nil {
+				return nil, err
+			}
+		case t.kind == jqPunctToken && t.text == "(":
+			var err error
+			if key, err = p.parsePrimary(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("jq: unexpected %q in object", t.text)
+		}
+
+		var value jqNode
+		if p.isPunct(":") {
+			p.pos++
+			var err error
+			if value, err = p.parseAlternative(); err != nil {
+				return nil, err
+			}
+		} else if literal, ok := key.(*jqLiteral); ok {
+			value = &jqIndex{&jqIdentity{}, literal}
+		} else {
+			return nil, fmt.Errorf("jq: object key needs a value")
+		}
+		object.keys = append(object.keys, key)
+		object.values = append(object.values, value)
+
+		if !p.isPunct(",") {
+			break
+		}
+		p.pos++
+	}
+	return object, p.expect("}")
+}
+
+func parseJQString(t *jqToken) (jqNode, error) {
+	if len(t.parts) == 1 {
+		return &jqLiteral{t.parts[0]}, nil
+	}
+	str := &jqString{}
+	for _, part := range t.parts {
+		if source, ok := part.(jqInterpolation); ok {
+			node, err := CompileJQ(string(source))
+			if err != nil {
+				return nil, err
+			}
+			str.parts = append(str.parts, node.root)
+		} else {
+			str.parts = append(str.parts, part)
+		}
+	}
+	return str, nil
+}
+
+// Evaluation
+
+type jqNode interface {
+	eval(input interface{}) ([]interface{}, error)
+}
+
+type jqIdentity struct{}
+
+func (n *jqIdentity) eval(input interface{}) ([]interface{}, error) {
+	return []interface{}{input}, nil
+}
+
+type jqLiteral struct {
+	value interface{}
+}
+
+func (n *jqLiteral) eval(input interface{}) ([]interface{}, error) {
+	return []interface{}{n.value}, nil
+}
+
+type jqString struct {
+	parts []interface{}
+}
+
+func (n *jqString) eval(input interface{}) ([]interface{}, error) {
+	results := []interface{}{""}
+	for _, part := range n.parts {
+		node, ok := part.(jqNode)
+		if !ok {
+			for i := range results {
+				results[i] = results[i].(string) + part.(string)
+			}
+			continue
+		}
+		values, err := node.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		next := []interface{}{}
+		for _, prefix := range results {
+			for _, value := range values {
+				s, err := jqToString(value)
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, prefix.(string)+s)
+			}
+		}
+		results = next
+	}
+	return results, nil
+}
+
+type jqPipe struct {
+	left, right jqNode
+}
+
+func (n *jqPipe) eval(input interface{}) ([]interface{}, error) {
+	values, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	results := []interface{}{}
+	for _, value := range values {
+		out, err := n.right.eval(value)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, out...)
+	}
+	return results, nil
+}
+
+type jqComma struct {
+	left, right jqNode
+}
+
+func (n *jqComma) eval(input interface{}) ([]interface{}, error) {
+	left, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
+
+type jqAlternative struct {
+	left, right jqNode
+}
+
+func (n *jqAlternative) eval(input interface{}) ([]interface{}, error) {
+	results := []interface{}{}
+	if values, err := n.left.eval(input); err == nil {
+		for _, value := range values {
+			if jqTruthy(value) {
+				results = append(results, value)
+			}
+		}
+	}
+	if len(results) > 0 {
+		return results, nil
+	}
+	return n.right.eval(input)
+}
+
+type jqTry struct {
+	body jqNode
+}
+
+func (n *jqTry) eval(input interface{}) ([]interface{}, error) {
+	results, err := n.body.eval(input)
+	if err != nil {
+		return []interface{}{}, nil
+	}
+	return results, nil
+}
+
+type jqIndex struct {
+	target, index jqNode
+}
+
+func (n *jqIndex) eval(input interface{}) ([]interface{}, error) {
+	targets, err := n.target.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	indices, err := n.index.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	results := []interface{}{}
+	for _, target := range targets {
+		for _, index := range indices {
+			value, err := jqLookup(target, index)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, value)
+		}
+	}
+	return results, nil
+}
+
+func jqLookup(target, index interface{}) (interface{}, error) {
+	switch t := target.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		if key, ok := index.(string); ok {
+			return t[key], nil
+		}
+	case []interface{}:
+		if f, ok := jqNumber(index); ok {
+			i := int(f)
+			if i < 0 {
+				i += len(t)
+			}
+			if i < 0 || i >= len(t) {
+				return nil, nil
+			}
+			return t[i], nil
+		}
+	}
+	return nil, fmt.Errorf("jq: cannot index %s with %s", jqTypeName(target), jqTypeName(index))
+}
+
+type jqIterate struct {
+	target jqNode
+}
+
+func (n *jqIterate) eval(input interface{}) ([]interface{}, error) {
+	targets, err := n.target.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	results := []interface{}{}
+	for _, target := range targets {
+		switch t := target.(type) {
+		case []interface{}:
+			results = append(results, t...)
+		case map[string]interface{}:
+			for _, key := range jqSortedKeys(t) {
+				results = append(results, t[key])
+			}
+		default:
+			return nil, fmt.Errorf("jq: cannot iterate over %s", jqTypeName(target))
+		}
+	}
+	return results, nil
+}
+
+type jqArray struct {
+	body jqNode
+}
+
+func (n *jqArray) eval(input interface{}) ([]interface{}, error) {
+	if n.body == nil {
+		return []interface{}{[]interface{}{}}, nil
+	}
+	values, err := n.body.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{values}, nil
+}
+
+type jqObject struct {
+	keys, values []jqNode
+}
+
+func (n *jqObject) eval(input interface{}) ([]interface{}, error) {
+	results := []interface{}{map[string]interface{}{}}
+	for i := range n.keys {
+		keys, err := n.keys[i].eval(input)
+		if err != nil {
+			return nil, err
+		}
+		values, err := n.values[i].eval(input)
+		if err != nil {
+			return nil, err
+		}
+		next := []interface{}{}
+		for _, result := range results {
+			for _, key := range keys {
+				k, ok := key.(string)
+				if !ok {
+					return nil, fmt.Errorf("jq: object keys must be strings, not %s", jqTypeName(key))
+				}
+				for _, value := range values {
+					object := map[string]interface{}{}
+					for existingKey, existingValue := range result.(map[string]interface{}) {
+						object[existingKey] = existingValue
+					}
+					object[k] = value
+					next = append(next, object)
+				}
+			}
+		}
+		results = next
+	}
+	return results, nil
+}
+
+type jqBoolean struct {
+	op          string
+	left, right jqNode
+}
+
+func (n *jqBoolean) eval(input interface{}) ([]interface{}, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	results := []interface{}{}
+	for _, left := range lefts {
+		if n.op == "and" && !jqTruthy(left) || n.op == "or" && jqTruthy(left) {
+			results = append(results, n.op == "or")
+			continue
+		}
+		rights, err := n.right.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, right := range rights {
+			results = append(results, jqTruthy(right))
+		}
+	}
+	return results, nil
+}
+
+type jqBinary struct {
+	op          string
+	left, right jqNode
+}
+
+func (n *jqBinary) eval(input interface{}) ([]interface{}, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	rights, err := n.right.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	results := []interface{}{}
+	for _, right := range rights {
+		for _, left := range lefts {
+			result, err := jqApply(n.op, left, right)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func jqApply(op string, left, right interface{}) (interface{}, error) {
+	switch op {
+	case "==":
+		return jqCompare(left, right) == 0, nil
+	case "!=":
+		return jqCompare(left, right) != 0, nil
+	case "<":
+		return jqCompare(left, right) < 0, nil
+	case "<=":
+		return jqCompare(left, right) <= 0, nil
+	case ">":
+		return jqCompare(left, right) > 0, nil
+	case ">=":
+		return jqCompare(left, right) >= 0, nil
+	}
+
+	if left == nil && op == "+" {
+		return right, nil
+	} else if right == nil && op == "+" {
+		return left, nil
+	}
+	if l, ok := jqNumber(left); ok {
+		if r, ok := jqNumber(right); ok {
+			if op == "+" {
+				return l + r, nil
+			}
+			return l - r, nil
+		}
+	}
+	switch l := left.(type) {
+	case string:
+		if r, ok := right.(string); ok && op == "+" {
+			return l + r, nil
+		}
+	case []interface{}:
+		if r, ok := right.([]interface{}); ok {
+			if op == "+" {
+				return append(append([]interface{}{}, l...), r...), nil
+			}
+			result := []interface{}{}
+			for _, item := range l {
+				if !jqContainsEqual(r, item) {
+					result = append(result, item)
+				}
+			}
+			return result, nil
+		}
+	case map[string]interface{}:
+		if r, ok := right.(map[string]interface{}); ok && op == "+" {
+			result := map[string]interface{}{}
+			for key, value := range l {
+				result[key] = value
+			}
+			for key, value := range r {
+				result[key] = value
+			}
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("jq: %s and %s cannot be combined with %s", jqTypeName(left), jqTypeName(right), op)
+}
+
+type jqCall struct {
+	name string
+	args []jqNode
+}
+
+type jqFunction func(input interface{}, args []jqNode) ([]interface{}, error)
+
+var jqFunctions map[string]jqFunction
+
+func init() {
+	jqFunctions = map[string]jqFunction{
+		"empty": func(input interface{}, args []jqNode) ([]interface{}, error) {
+			return []interface{}{}, nil
+		},
+		"not": jqSimple(func(input interface{}) (interface{}, error) {
+			return !jqTruthy(input), nil
+		}),
+		"length": jqSimple(func(input interface{}) (interface{}, error) {
+			switch v := input.(type) {
+			case nil:
+				return 0.0, nil
+			case string:
+				return float64(utf8.RuneCountInString(v)), nil
+			case []interface{}:
+				return float64(len(v)), nil
+			case map[string]interface{}:
+				return float64(len(v)), nil
+			}
+			if f, ok := jqNumber(input); ok {
+				return math.Abs(f), nil
+			}
+			return nil, fmt.Errorf("jq: %s has no length", jqTypeName(input))
+		}),
+		"keys": jqSimple(func(input interface{}) (interface{}, error) {
+			switch v := input.(type) {
+			case map[string]interface{}:
+				keys := []interface{}{}
+				for _, key := range jqSortedKeys(v) {
+					keys = append(keys, key)
+				}
+				return keys, nil
+			case []interface{}:
+				keys := []interface{}{}
+				for i := range v {
+					keys = append(keys, float64(i))
+				}
+				return keys, nil
+			}
+			return nil, fmt.Errorf("jq: %s has no keys", jqTypeName(input))
+		}),
+		"type": jqSimple(func(input interface{}) (interface{}, error) {
+			return jqTypeName(input), nil
+		}),
+		"tostring": jqSimple(func(input interface{}) (interface{}, error) {
+			return jqToString(input)
+		}),
+		"tonumber": jqSimple(func(input interface{}) (interface{}, error) {
+			if f, ok := jqNumber(input); ok {
+				return f, nil
+			}
+			if s, ok := input.(string); ok {
+				if f, err := strconv.ParseFloat(s, 64); err == nil {
+					return f, nil
+				}
+			}
+			return nil, fmt.Errorf("jq: cannot parse %s as a number", jqTypeName(input))
+		}),
+		"ascii_downcase": jqStringFunction(strings.ToLower),
+		"ascii_upcase":   jqStringFunction(strings.ToUpper),
+		"first": jqSimple(func(input interface{}) (interface{}, error) {
+			return jqLookup(input, 0.0)
+		}),
+		"last": jqSimple(func(input interface{}) (interface{}, error) {
+			return jqLookup(input, -1.0)
+		}),
+		"add": jqSimple(func(input interface{}) (interface{}, error) {
+			list, ok := input.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("jq: cannot add the elements of %s", jqTypeName(input))
+			}
+			var sum interface{}
+			for _, item := range list {
+				var err error
+				if sum, err = jqApply("+", sum, item); err != nil {
+					return nil, err
+				}
+			}
+			return sum, nil
+		}),
+		"sort": jqSimple(func(input interface{}) (interface{}, error) {
+			list, ok := input.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("jq: cannot sort %s", jqTypeName(input))
+			}
+			sorted := append([]interface{}{}, list...)
+			sort.SliceStable(sorted, func(i, j int) bool {
+				return jqCompare(sorted[i], sorted[j]) < 0
+			})
+			return sorted, nil
+		}),
+		"select": func(input interface{}, args []jqNode) ([]interface{}, error) {
+			conditions, err := args[0].eval(input)
+			if err != nil {
+				return nil, err
+			}
+			results := []interface{}{}
+			for _, condition := range conditions {
+				if jqTruthy(condition) {
+					results = append(results, input)
+				}
+			}
+			return results, nil
+		},
+		"map": func(input interface{}, args []jqNode) ([]interface{}, error) {
+			return (&jqArray{&jqPipe{&jqIterate{&jqIdentity{}}, args[0]}}).eval(input)
+		},
+		"has": jqWithArgument(func(input, key interface{}) (interface{}, error) {
+			switch v := input.(type) {
+			case map[string]interface{}:
+				if k, ok := key.(string); ok {
+					_, exists := v[k]
+					return exists, nil
+				}
+			case []interface{}:
+				if f, ok := jqNumber(key); ok {
+					return f >= 0 && int(f) < len(v), nil
+				}
+			}
+			return nil, fmt.Errorf("jq: cannot check whether %s has a %s key", jqTypeName(input), jqTypeName(key))
+		}),
+		"join": jqWithArgument(func(input, separator interface{}) (interface{}, error) {
+			list, ok := input.([]interface{})
+			sep, sepOk := separator.(string)
+			if !ok || !sepOk {
+				return nil, fmt.Errorf("jq: cannot join %s with %s", jqTypeName(input), jqTypeName(separator))
+			}
+			strs := []string{}
+			for _, item := range list {
+				if item == nil {
+					strs = append(strs, "")
+					continue
+				}
+				s, err := jqToString(item)
+				if err != nil {
+					return nil, err
+				}
+				strs = append(strs, s)
+			}
+			return strings.Join(strs, sep), nil
+		}),
+		"contains": jqWithArgument(func(input, element interface{}) (interface{}, error) {
+			if s, ok := input.(string); ok {
+				if sub, ok := element.(string); ok {
+					return strings.Contains(s, sub), nil
+				}
+			}
+			if list, ok := input.([]interface{}); ok {
+				if elements, ok := element.([]interface{}); ok {
+					for _, e := range elements {
+						if !jqContainsEqual(list, e) {
+							return false, nil
+						}
+					}
+					return true, nil
+				}
+			}
+			return nil, fmt.Errorf("jq: cannot check whether %s contains %s", jqTypeName(input), jqTypeName(element))
+		}),
+		"startswith": jqStringPredicate(strings.HasPrefix),
+		"endswith":   jqStringPredicate(strings.HasSuffix),
+		"test": jqStringPredicate(func(s, pattern string) bool {
+			re, err := regexp.Compile(pattern)
+			return err == nil && re.MatchString(s)
+		}),
+	}
+}
+
+// jqArity lists the functions that take an argument; all others take none.
+var jqArity = map[string]int{
+	"select": 1, "map": 1, "has": 1, "join": 1, "contains": 1, "startswith": 1, "endswith": 1, "test": 1,
+}
+
+func (n *jqCall) eval(input interface{}) ([]interface{}, error) {
+	return jqFunctions[n.name](input, n.args)
+}
+
+func jqSimple(f func(input interface{}) (interface{}, error)) jqFunction {
+	return func(input interface{}, args []jqNode) ([]interface{}, error) {
+		result, err := f(input)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{result}, nil
+	}
+}
+
+func jqWithArgument(f func(input, arg interface{}) (interface{}, error)) jqFunction {
+	return func(input interface{}, args []jqNode) ([]interface{}, error) {
+		values, err := args[0].eval(input)
+		if err != nil {
+			return nil, err
+		}
+		results := []interface{}{}
+		for _, value := range values {
+			result, err := f(input, value)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+		return results, nil
+	}
+}
+
+func jqStringFunction(f func(string) string) jqFunction {
+	return jqSimple(func(input interface{}) (interface{}, error) {
+		if s, ok := input.(string); ok {
+			return f(s), nil
+		}
+		return nil, fmt.Errorf("jq: %s is not a string", jqTypeName(input))
+	})
+}
+
+func jqStringPredicate(f func(s, arg string) bool) jqFunction {
+	return jqWithArgument(func(input, arg interface{}) (interface{}, error) {
+		s, ok := input.(string)
+		a, argOk := arg.(string)
+		if !ok || !argOk {
+			return nil, fmt.Errorf("jq: %s and %s are not both strings", jqTypeName(input), jqTypeName(arg))
+		}
+		return f(s, a), nil
+	})
+}
+
+// Values
+
+func jqNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func jqTruthy(v interface{}) bool {
+	return v != nil && v != false
+}
+
+func jqTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	if _, ok := jqNumber(v); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func jqToString(v interface{}) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return jqEncode(v)
+}
+
+func jqSortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jqContainsEqual(list []interface{}, value interface{}) bool {
+	for _, item := range list {
+		if jqCompare(item, value) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+var jqTypeOrder = map[string]int{"null": 0, "boolean": 1, "number": 2, "string": 3, "array": 4, "object": 5}
+
+// jqCompare orders values the way jq does: null < false < true < numbers <
+// strings < arrays < objects.
+func jqCompare(a, b interface{}) int {
+	ta, tb := jqTypeName(a), jqTypeName(b)
+	if ta != tb {
+		return jqTypeOrder[ta] - jqTypeOrder[tb]
+	}
+	switch ta {
+	case "boolean":
+		if a == b {
+			return 0
+		} else if a == false {
+			return -1
+		}
+		return 1
+	case "number":
+		fa, _ := jqNumber(a)
+		fb, _ := jqNumber(b)
+		if fa < fb {
+			return -1
+		} else if fa > fb {
+			return 1
+		}
+		return 0
+	case "string":
+		return strings.Compare(a.(string), b.(string))
+	case "array":
+		la, lb := a.([]interface{}), b.([]interface{})
+		for i := 0; i < len(la) && i < len(lb); i++ {
+			if c := jqCompare(la[i], lb[i]); c != 0 {
+				return c
+			}
+		}
+		return len(la) - len(lb)
+	case "object":
+		if reflect.DeepEqual(a, b) {
+			return 0
+		}
+		ea, _ := jqEncode(a)
+		eb, _ := jqEncode(b)
+		return strings.Compare(ea, eb)
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+const jqTestInput = `[
+	{"number": 1, "title": "Crash", "draft": false, "user": {"login": "mislav"}, "labels": [{"name": "bug"}, {"name": "ui"}]},
+	{"number": 12, "title": "Docs", "draft": true, "user": {"login": "octocat"}, "labels": []},
+	{"number": 3, "title": "Speed", "draft": false, "user": null, "labels": [{"name": "perf"}]}
+]`
+
+func runJQ(t *testing.T, expr string) string {
+	dec := json.NewDecoder(strings.NewReader(jqTestInput))
+	dec.UseNumber()
+	var input interface{}
+	assert.Equal(t, nil, dec.Decode(&input))
+
+	q, err := CompileJQ(expr)
+	if err != nil {
+		return err.Error()
+	}
+	out := &bytes.Buffer{}
+	if err := q.Execute(out, input); err != nil {
+		return err.Error()
+	}
+	return out.String()
+}
+
+func TestJQ_Paths(t *testing.T) {
+	assert.Equal(t, "Crash\n", runJQ(t, ".[0].title"))
+	assert.Equal(t, "Speed\n", runJQ(t, `.[-1]["title"]`))
+	assert.Equal(t, "mislav\n", runJQ(t, `.[0].user."login"`))
+	assert.Equal(t, "null\n", runJQ(t, ".[2].user.login"))
+	assert.Equal(t, "1\n12\n3\n", runJQ(t, ".[].number"))
+	assert.Equal(t, "bug\nui\nperf\n", runJQ(t, ".[].labels[].name"))
+	assert.Equal(t, "jq: cannot index array with string\n", runJQ(t, ".title")+"\n")
+	assert.Equal(t, "", runJQ(t, ".title?"))
+}
+
+func TestJQ_Select(t *testing.T) {
+	assert.Equal(t, "12\n", runJQ(t, ".[] | select(.draft) | .number"))
+	assert.Equal(t, "1\n3\n", runJQ(t, ".[] | select(.draft | not) | .number"))
+	assert.Equal(t, "12\n", runJQ(t, `.[] | select(.number > 3 and .user.login == "octocat") | .number`))
+	assert.Equal(t, "Crash\nSpeed\n", runJQ(t, `.[] | select(.labels | length > 0) | .title`))
+	assert.Equal(t, "Crash\n", runJQ(t, `.[] | select(.labels[].name == "bug") | .title`))
+	assert.Equal(t, "Docs\n", runJQ(t, `.[] | select(.title | test("^D")) | .title`))
+}
+
+func TestJQ_Construction(t *testing.T) {
+	assert.Equal(t, "#1 Crash by mislav\n#3 Speed by nobody\n",
+		runJQ(t, `.[] | select(.draft == false) | "#\(.number) \(.title) by \(.user.login // "nobody")"`))
+	assert.Equal(t, "[1,12,3]\n", runJQ(t, "map(.number)"))
+	assert.Equal(t, "[1,3,12]\n", runJQ(t, "[.[].number] | sort"))
+	assert.Equal(t, "16\n", runJQ(t, "map(.number) | add"))
+	assert.Equal(t, `{"labels":"bug,ui","n":1}`+"\n", runJQ(t, `.[0] | {n: .number, labels: (.labels | map(.name) | join(","))}`))
+	assert.Equal(t, `{"number":12,"title":"Docs"}`+"\n", runJQ(t, ".[1] | {number, title}"))
+	assert.Equal(t, "3\n1\n", runJQ(t, "length, (.[0] | keys | length - 4)"))
+}
+
+func TestJQ_Errors(t *testing.T) {
+	assert.Equal(t, "jq: frobnicate/0 is not defined", runJQ(t, ".[] | frobnicate"))
+	assert.Equal(t, `jq: expected ")", got "]"`, runJQ(t, "select(.a]"))
+	assert.Equal(t, "jq: unterminated string", runJQ(t, `"abc`))
+	assert.Equal(t, "jq: cannot iterate over null", runJQ(t, ".[0].missing[]"))
+}
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+var templateColors = map[string]string{
+	"black":   "30",
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"white":   "37",
+	"bold":    "1",
+}
+
+// tableCellSep separates the cells of a `tablerow` in the rendered template
+// until they get aligned.
+const tableCellSep = "\x1f"
+
+var (
+	ansiCodeRe        = regexp.MustCompile("\033\\[[0-9;]*m")
+	tableCellReplacer = strings.NewReplacer(tableCellSep, "", "\t", " ", "\n", " ")
+)
+
+// ExecuteTemplate renders data through a Go text/template. Besides the
+// builtin functions, templates have access to `timeago`, `color`, `truncate`,
+// and `tablerow`. Rows emitted with `tablerow` are aligned into columns; the
+// rest of the output is left as is.
+func ExecuteTemplate(out io.Writer, tmpl string, data interface{}, colorize bool) error {
+	t, err := template.New("format").Funcs(templateFuncs(colorize)).Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, alignTableRows(buf.String()))
+	return err
+}
+
+// alignTableRows pads the cells of consecutive `tablerow` lines to the width
+// of the widest cell in their column. Color codes don't count towards the
+// width of a cell.
+func alignTableRows(text string) string {
+	result := &bytes.Buffer{}
+	rows := [][]string{}
+
+	flush := func() {
+		widths := []int{}
+		for _, row := range rows {
+			for i, cell := range row[:len(row)-1] {
+				if w := cellWidth(cell); i == len(widths) {
+					widths = append(widths, w)
+				} else if w > widths[i] {
+					widths[i] = w
+				}
+			}
+		}
+		for _, row := range rows {
+			for i, cell := range row {
+				result.WriteString(cell)
+				if i < len(row)-1 {
+					result.WriteString(strings.Repeat(" ", widths[i]-cellWidth(cell)+2))
+				}
+			}
+		}
+		rows = rows[:0]
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if strings.Contains(line, tableCellSep) {
+			rows = append(rows, strings.Split(line, tableCellSep))
+		} else {
+			flush()
+			result.WriteString(line)
+		}
+	}
+	flush()
+
+	return result.String()
+}
+
+func cellWidth(cell string) int {
+	return utf8.RuneCountInString(ansiCodeRe.ReplaceAllString(cell, ""))
+}
+
+func templateFuncs(colorize bool) template.FuncMap {
+	return template.FuncMap{
+		"timeago": func(v interface{}) (string, error) {
+			t, err := templateTime(v)
+			if err != nil || t.IsZero() {
+				return "", err
+			}
+			return TimeAgo(t), nil
+		},
+		"color": func(name string, v interface{}) (string, error) {
+			text := fmt.Sprint(v)
+			if !colorize {
+				return text, nil
+			}
+			codes := []string{}
+			for _, c := range strings.Split(name, "+") {
+				code, ok := templateColors[c]
+				if !ok {
+					return "", fmt.Errorf("unknown color: %s", c)
+				}
+				codes = append(codes, code)
+			}
+			return fmt.Sprintf("\033[%sm%s\033[m", strings.Join(codes, ";"), text), nil
+		},
+		"truncate": func(length int, v interface{}) string {
+			text := []rune(fmt.Sprint(v))
+			if length <= 0 || len(text) <= length {
+				return string(text)
+			}
+			if length <= 3 {
+				return string(text[:length])
+			}
+			return string(text[:length-3]) + "..."
+		},
+		"tablerow": func(fields ...interface{}) string {
+			cells := make([]string, len(fields))
+			for i, f := range fields {
+				cells[i] = tableCellReplacer.Replace(fmt.Sprint(f))
+			}
+			return strings.Join(cells, tableCellSep) + "\n"
+		},
+	}
+}
+
+func templateTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case nil:
+		return time.Time{}, nil
+	case string:
+		if t == "" {
+			return time.Time{}, nil
+		}
+		return time.Parse(time.RFC3339, t)
+	default:
+		return time.Time{}, fmt.Errorf("cannot parse time from %v", v)
+	}
+}
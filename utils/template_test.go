@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestExecuteTemplate(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	}
+	defer func() { timeNow = time.Now }()
+
+	data := []interface{}{
+		map[string]interface{}{"number": 1, "title": "Fix the thing", "created_at": "2019-10-01T09:00:00Z"},
+		map[string]interface{}{"number": 1234, "title": "A much longer title", "created_at": "2019-09-01T12:00:00Z"},
+	}
+	tmpl := `{{range .}}{{tablerow (printf "#%v" .number) (truncate 10 .title) (timeago .created_at)}}{{end}}`
+
+	out := &bytes.Buffer{}
+	err := ExecuteTemplate(out, tmpl, data, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "#1     Fix the...  3 hours ago\n#1234  A much ...  1 month ago\n", out.String())
+}
+
+func TestExecuteTemplate_Color(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := ExecuteTemplate(out, `{{color "green" .}}`, "ok", true)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "\033[32mok\033[m", out.String())
+
+	out.Reset()
+	err = ExecuteTemplate(out, `{{color "green" .}}`, "ok", false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ok", out.String())
+}
+
+func TestExecuteTemplate_TableRowsOnly(t *testing.T) {
+	data := []interface{}{
+		map[string]interface{}{"number": 1, "state": "open"},
+		map[string]interface{}{"number": 1234, "state": "closed"},
+	}
+	tmpl := "Issues:\tall\n{{range .}}{{tablerow (color \"green\" .number) .state}}{{end}}a\tb\n"
+
+	out := &bytes.Buffer{}
+	err := ExecuteTemplate(out, tmpl, data, true)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Issues:\tall\n"+
+		"\033[32m1\033[m     open\n"+
+		"\033[32m1234\033[m  closed\n"+
+		"a\tb\n", out.String())
+}
@@ -10,16 +10,46 @@ import (
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/github/hub/ui"
 	"github.com/kballard/go-shellquote"
 )
 
 var timeNow = time.Now
 
+// Exit codes that scripts can branch on. Failures of git commands that hub
+// runs are passed on with git's own exit code instead.
+const (
+	ExitFailure     = 1
+	ExitUsage       = 2
+	ExitNotFound    = 3
+	ExitAuth        = 4
+	ExitRateLimited = 5
+	ExitInterrupted = 130
+
+	// ExitPending and ExitNoChecks are how 'ci-status' and 'pr checks' report
+	// a commit whose checks haven't finished, or that has none at all.
+	ExitPending  = 8
+	ExitNoChecks = 9
+)
+
+// ExitCoder is implemented by errors that call for a specific exit code.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode is the code that hub exits with because of err.
+func ExitCode(err error) int {
+	if e, ok := err.(ExitCoder); ok && e.ExitCode() > 0 {
+		return e.ExitCode()
+	}
+	return ExitFailure
+}
+
 func Check(err error) {
 	if err != nil {
 		ui.Errorln(err)
-		os.Exit(1)
+		ui.Exit(ExitCode(err))
 	}
 }
 
@@ -63,6 +93,39 @@ func searchBrowserLauncher(goos string) (browser string) {
 	return browser
 }
 
+// CopyToClipboard puts text on the system clipboard. Wayland sessions and
+// Windows Subsystem for Linux are supported in addition to the xclip and xsel
+// utilities.
+func CopyToClipboard(text string) error {
+	command := searchClipboardCommand(runtime.GOOS, os.Getenv("WAYLAND_DISPLAY") != "")
+	if command == "" {
+		return clipboard.WriteAll(text)
+	}
+
+	cmd := exec.Command(command)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+func searchClipboardCommand(goos string, wayland bool) (command string) {
+	if goos == "darwin" || goos == "windows" {
+		return
+	}
+
+	candidates := []string{"clip.exe"}
+	if wayland {
+		candidates = append([]string{"wl-copy"}, candidates...)
+	}
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c); err == nil {
+			command = c
+			break
+		}
+	}
+
+	return command
+}
+
 func CommandPath(cmd string) (string, error) {
 	if runtime.GOOS == "windows" {
 		cmd = cmd + ".exe"
@@ -14,6 +14,11 @@ func TestSearchBrowserLauncher(t *testing.T) {
 	assert.Equal(t, "cmd /c start", browser)
 }
 
+func TestSearchClipboardCommand(t *testing.T) {
+	assert.Equal(t, "", searchClipboardCommand("darwin", true))
+	assert.Equal(t, "", searchClipboardCommand("windows", false))
+}
+
 func TestConcatPaths(t *testing.T) {
 	assert.Equal(t, "foo/bar/baz", ConcatPaths("foo", "bar", "baz"))
 }